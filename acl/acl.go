@@ -58,6 +58,15 @@ type ACL struct {
 	// We use an iradix for the purposes of ordered iteration.
 	wildcardHostVolumes *iradix.Tree
 
+	// variables maps a namespace and variable path to a capabilitySet. The
+	// key is built by variablesKey.
+	variables *iradix.Tree
+
+	// wildcardVariables maps a namespace and variable path, where either
+	// contains a glob pattern, to a capabilitySet. The key is built by
+	// variablesKey. We use an iradix for the purposes of ordered iteration.
+	wildcardVariables *iradix.Tree
+
 	agent    string
 	node     string
 	operator string
@@ -95,10 +104,54 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	wnsTxn := iradix.New().Txn()
 	hvTxn := iradix.New().Txn()
 	whvTxn := iradix.New().Txn()
+	svTxn := iradix.New().Txn()
+	wsvTxn := iradix.New().Txn()
 
 	for _, policy := range policies {
 	NAMESPACES:
 		for _, ns := range policy.Namespaces {
+			// Add the variables capabilities for the namespace before any
+			// namespace deny skips the rest of the loop body, as the paths
+			// are tracked separately.
+			if ns.Variables != nil {
+			PATHS:
+				for _, pathPolicy := range ns.Variables.Paths {
+					key := variablesKey(ns.Name, pathPolicy.PathSpec)
+
+					// Should the path be matched using a glob?
+					txn := svTxn
+					if strings.Contains(key, "*") {
+						txn = wsvTxn
+					}
+
+					// Check for existing capabilities
+					var capabilities capabilitySet
+					raw, ok := txn.Get([]byte(key))
+					if ok {
+						capabilities = raw.(capabilitySet)
+					} else {
+						capabilities = make(capabilitySet)
+						txn.Insert([]byte(key), capabilities)
+					}
+
+					// Deny always takes precedence
+					if capabilities.Check(VariablesCapabilityDeny) {
+						continue
+					}
+
+					// Add in all the capabilities
+					for _, cap := range pathPolicy.Capabilities {
+						if cap == VariablesCapabilityDeny {
+							// Overwrite any existing capabilities
+							capabilities.Clear()
+							capabilities.Set(VariablesCapabilityDeny)
+							continue PATHS
+						}
+						capabilities.Set(cap)
+					}
+				}
+			}
+
 			// Should the namespace be matched using a glob?
 			globDefinition := strings.Contains(ns.Name, "*")
 
@@ -206,6 +259,8 @@ func NewACL(management bool, policies []*Policy) (*ACL, error) {
 	acl.wildcardNamespaces = wnsTxn.Commit()
 	acl.hostVolumes = hvTxn.Commit()
 	acl.wildcardHostVolumes = whvTxn.Commit()
+	acl.variables = svTxn.Commit()
+	acl.wildcardVariables = wsvTxn.Commit()
 
 	return acl, nil
 }
@@ -323,6 +378,55 @@ func (a *ACL) matchingHostVolumeCapabilitySet(name string) (capabilitySet, bool)
 	return a.findClosestMatchingGlob(a.wildcardHostVolumes, name)
 }
 
+// AllowVariableOperation checks if a given operation is allowed for the
+// variable at the path within the namespace.
+func (a *ACL) AllowVariableOperation(ns, path, op string) bool {
+	// Hot path management tokens
+	if a.management {
+		return true
+	}
+
+	// Check for a matching capability set
+	capabilities, ok := a.matchingVariablesCapabilitySet(ns, path)
+	if !ok {
+		return false
+	}
+
+	// Deny always takes precedence
+	if capabilities.Check(VariablesCapabilityDeny) {
+		return false
+	}
+
+	// Check if the capability has been granted
+	return capabilities.Check(op)
+}
+
+// matchingVariablesCapabilitySet looks for a capabilitySet that matches the
+// namespace and path of a variable. If no concrete definitions are found,
+// then we return the closest matching glob.
+// The closest matching glob is the one that has the smallest character
+// difference between the namespace and path, and the glob.
+func (a *ACL) matchingVariablesCapabilitySet(ns, path string) (capabilitySet, bool) {
+	key := variablesKey(ns, path)
+
+	// Check for a concrete matching capability set
+	raw, ok := a.variables.Get([]byte(key))
+	if ok {
+		return raw.(capabilitySet), true
+	}
+
+	// We didn't find a concrete match, so lets try and evaluate globs.
+	return a.findClosestMatchingGlob(a.wildcardVariables, key)
+}
+
+// variablesKey builds the key used to store the capabilities of a variables
+// path within a namespace. Neither namespaces nor paths can contain the null
+// character, so it is used as the separator, which allows globs in either
+// part to be matched against the whole key.
+func variablesKey(ns, path string) string {
+	return ns + "\x00" + path
+}
+
 type matchingGlob struct {
 	name          string
 	difference    int
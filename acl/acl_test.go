@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCapabilitySet(t *testing.T) {
@@ -327,6 +328,145 @@ func TestWildcardNamespaceMatching(t *testing.T) {
 	}
 }
 
+func TestAllowVariableOperation(t *testing.T) {
+	ci.Parallel(t)
+
+	tests := []struct {
+		Name   string
+		Policy string
+		NS     string
+		Path   string
+		Op     string
+		Allow  bool
+	}{
+		{
+			Name:   "no variables block",
+			Policy: `namespace "default" { capabilities = ["read-job"] }`,
+			NS:     "default",
+			Path:   "foo",
+			Op:     VariablesCapabilityRead,
+			Allow:  false,
+		},
+		{
+			Name:   "namespace read policy",
+			Policy: `namespace "default" { policy = "read" }`,
+			NS:     "default",
+			Path:   "foo/bar",
+			Op:     VariablesCapabilityRead,
+			Allow:  true,
+		},
+		{
+			Name:   "namespace read policy cannot write",
+			Policy: `namespace "default" { policy = "read" }`,
+			NS:     "default",
+			Path:   "foo/bar",
+			Op:     VariablesCapabilityWrite,
+			Allow:  false,
+		},
+		{
+			Name: "exact path",
+			Policy: `namespace "default" {
+				variables {
+					path "foo/bar" { capabilities = ["write"] }
+				}
+			}`,
+			NS:    "default",
+			Path:  "foo/bar",
+			Op:    VariablesCapabilityWrite,
+			Allow: true,
+		},
+		{
+			Name: "exact path other namespace",
+			Policy: `namespace "default" {
+				variables {
+					path "foo/bar" { capabilities = ["write"] }
+				}
+			}`,
+			NS:    "other",
+			Path:  "foo/bar",
+			Op:    VariablesCapabilityWrite,
+			Allow: false,
+		},
+		{
+			Name: "read implies list",
+			Policy: `namespace "default" {
+				variables {
+					path "foo/*" { capabilities = ["read"] }
+				}
+			}`,
+			NS:    "default",
+			Path:  "foo/bar",
+			Op:    VariablesCapabilityList,
+			Allow: true,
+		},
+		{
+			Name: "concrete path takes precedence",
+			Policy: `namespace "default" {
+				variables {
+					path "foo/*" { capabilities = ["read"] }
+					path "foo/secret" { capabilities = ["deny"] }
+				}
+			}`,
+			NS:    "default",
+			Path:  "foo/secret",
+			Op:    VariablesCapabilityRead,
+			Allow: false,
+		},
+		{
+			Name: "closest glob wins",
+			Policy: `namespace "default" {
+				variables {
+					path "*" { capabilities = ["deny"] }
+					path "foo/*" { capabilities = ["read"] }
+				}
+			}`,
+			NS:    "default",
+			Path:  "foo/bar",
+			Op:    VariablesCapabilityRead,
+			Allow: true,
+		},
+		{
+			Name: "wildcard namespace",
+			Policy: `namespace "prod-*" {
+				variables {
+					path "foo/*" { capabilities = ["destroy"] }
+				}
+			}`,
+			NS:    "prod-api",
+			Path:  "foo/bar",
+			Op:    VariablesCapabilityDestroy,
+			Allow: true,
+		},
+		{
+			Name: "namespace deny policy",
+			Policy: `namespace "default" {
+				policy = "deny"
+				variables {
+					path "foo/*" { capabilities = ["read"] }
+				}
+			}`,
+			NS:    "default",
+			Path:  "bar",
+			Op:    VariablesCapabilityRead,
+			Allow: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			policy, err := Parse(tc.Policy)
+			require.NoError(t, err)
+
+			acl, err := NewACL(false, []*Policy{policy})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.Allow, acl.AllowVariableOperation(tc.NS, tc.Path, tc.Op))
+		})
+	}
+
+	require.True(t, ManagementACL.AllowVariableOperation("default", "foo", VariablesCapabilityDestroy))
+}
+
 func TestWildcardHostVolumeMatching(t *testing.T) {
 	ci.Parallel(t)
 
@@ -52,6 +52,23 @@ var (
 	validNamespace = regexp.MustCompile("^[a-zA-Z0-9-*]{1,128}$")
 )
 
+const (
+	// The following are the fine-grained capabilities that can be granted for
+	// a path of variables within a namespace. When capabilities are combined
+	// we take the union of all capabilities. If the deny capability is
+	// present, it takes precedence and overwrites all other capabilities.
+
+	VariablesCapabilityList    = "list"
+	VariablesCapabilityRead    = "read"
+	VariablesCapabilityWrite   = "write"
+	VariablesCapabilityDestroy = "destroy"
+	VariablesCapabilityDeny    = "deny"
+)
+
+var (
+	validVariablesPath = regexp.MustCompile("^[a-zA-Z0-9-_~/*]{1,128}$")
+)
+
 const (
 	// The following are the fine-grained capabilities that can be granted for a volume set.
 	// The Policy stanza is a short hand for granting several of these. When capabilities are
@@ -96,6 +113,19 @@ type NamespacePolicy struct {
 	Name         string `hcl:",key"`
 	Policy       string
 	Capabilities []string
+	Variables    *VariablesPolicy `hcl:"variables"`
+}
+
+// VariablesPolicy is the policy for the variables within a namespace
+type VariablesPolicy struct {
+	Paths []*VariablesPathPolicy `hcl:"path"`
+}
+
+// VariablesPathPolicy is the policy for a specific path, or glob pattern of
+// paths, of variables
+type VariablesPathPolicy struct {
+	PathSpec     string `hcl:",key"`
+	Capabilities []string
 }
 
 // HostVolumePolicy is the policy for a specific named host volume
@@ -211,6 +241,60 @@ func expandNamespacePolicy(policy string) []string {
 	}
 }
 
+// isVariablesCapabilityValid ensures the given capability is valid for a
+// variables path policy
+func isVariablesCapabilityValid(cap string) bool {
+	switch cap {
+	case VariablesCapabilityList, VariablesCapabilityRead, VariablesCapabilityWrite,
+		VariablesCapabilityDestroy, VariablesCapabilityDeny:
+		return true
+	default:
+		return false
+	}
+}
+
+// expandVariablesCapabilities adds the capabilities implied by those given.
+// Reading a variable implies being able to list it.
+func expandVariablesCapabilities(caps []string) []string {
+	var foundRead, foundList bool
+	for _, cap := range caps {
+		switch cap {
+		case VariablesCapabilityDeny:
+			return []string{VariablesCapabilityDeny}
+		case VariablesCapabilityRead:
+			foundRead = true
+		case VariablesCapabilityList:
+			foundList = true
+		}
+	}
+	if foundRead && !foundList {
+		caps = append(caps, VariablesCapabilityList)
+	}
+	return caps
+}
+
+// expandNamespaceVariablesPolicy provides the equivalent variables path
+// policy for a namespace policy. The namespace policy grants the
+// capabilities to all paths within the namespace.
+func expandNamespaceVariablesPolicy(policy string) *VariablesPathPolicy {
+	var caps []string
+	switch policy {
+	case PolicyDeny:
+		caps = []string{VariablesCapabilityDeny}
+	case PolicyRead:
+		caps = []string{VariablesCapabilityRead, VariablesCapabilityList}
+	case PolicyWrite:
+		caps = []string{VariablesCapabilityRead, VariablesCapabilityList,
+			VariablesCapabilityWrite, VariablesCapabilityDestroy}
+	default:
+		return nil
+	}
+	return &VariablesPathPolicy{
+		PathSpec:     "*",
+		Capabilities: caps,
+	}
+}
+
 func isHostVolumeCapabilityValid(cap string) bool {
 	switch cap {
 	case HostVolumeCapabilityDeny, HostVolumeCapabilityMountReadOnly, HostVolumeCapabilityMountReadWrite:
@@ -275,6 +359,31 @@ func Parse(rules string) (*Policy, error) {
 			extraCap := expandNamespacePolicy(ns.Policy)
 			ns.Capabilities = append(ns.Capabilities, extraCap...)
 		}
+
+		if ns.Variables != nil {
+			if len(ns.Variables.Paths) == 0 {
+				return nil, fmt.Errorf("Invalid variables policy: no variable paths in namespace %s", ns.Name)
+			}
+			for _, pathPolicy := range ns.Variables.Paths {
+				if !validVariablesPath.MatchString(pathPolicy.PathSpec) {
+					return nil, fmt.Errorf("Invalid variable path %q in namespace %s", pathPolicy.PathSpec, ns.Name)
+				}
+				for _, cap := range pathPolicy.Capabilities {
+					if !isVariablesCapabilityValid(cap) {
+						return nil, fmt.Errorf("Invalid variable capability '%s' in namespace %s", cap, ns.Name)
+					}
+				}
+				pathPolicy.Capabilities = expandVariablesCapabilities(pathPolicy.Capabilities)
+			}
+		}
+
+		// Expand the short hand policy to the variables of the namespace
+		if pathPolicy := expandNamespaceVariablesPolicy(ns.Policy); pathPolicy != nil {
+			if ns.Variables == nil {
+				ns.Variables = &VariablesPolicy{}
+			}
+			ns.Variables.Paths = append(ns.Variables.Paths, pathPolicy)
+		}
 	}
 
 	for _, hv := range p.HostVolumes {
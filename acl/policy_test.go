@@ -40,6 +40,14 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityListScalingPolicies,
 							NamespaceCapabilityReadScalingPolicy,
 						},
+						Variables: &VariablesPolicy{
+							Paths: []*VariablesPathPolicy{
+								{
+									PathSpec:     "*",
+									Capabilities: []string{VariablesCapabilityRead, VariablesCapabilityList},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -90,6 +98,14 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityListScalingPolicies,
 							NamespaceCapabilityReadScalingPolicy,
 						},
+						Variables: &VariablesPolicy{
+							Paths: []*VariablesPathPolicy{
+								{
+									PathSpec:     "*",
+									Capabilities: []string{VariablesCapabilityRead, VariablesCapabilityList},
+								},
+							},
+						},
 					},
 					{
 						Name:   "other",
@@ -114,6 +130,15 @@ func TestParse(t *testing.T) {
 							NamespaceCapabilityCSIWriteVolume,
 							NamespaceCapabilitySubmitRecommendation,
 						},
+						Variables: &VariablesPolicy{
+							Paths: []*VariablesPathPolicy{
+								{
+									PathSpec: "*",
+									Capabilities: []string{VariablesCapabilityRead, VariablesCapabilityList,
+										VariablesCapabilityWrite, VariablesCapabilityDestroy},
+								},
+							},
+						},
 					},
 					{
 						Name: "secret",
@@ -224,6 +249,70 @@ func TestParse(t *testing.T) {
 			"Invalid namespace name",
 			nil,
 		},
+		{
+			`
+			namespace "default" {
+				variables {
+					path "project/*" {
+						capabilities = ["read", "write"]
+					}
+					path "other/secret" {
+						capabilities = ["deny", "read"]
+					}
+				}
+			}
+			`,
+			"",
+			&Policy{
+				Namespaces: []*NamespacePolicy{
+					{
+						Name: "default",
+						Variables: &VariablesPolicy{
+							Paths: []*VariablesPathPolicy{
+								{
+									PathSpec: "project/*",
+									Capabilities: []string{
+										VariablesCapabilityRead,
+										VariablesCapabilityWrite,
+										VariablesCapabilityList,
+									},
+								},
+								{
+									PathSpec:     "other/secret",
+									Capabilities: []string{VariablesCapabilityDeny},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			`
+			namespace "default" {
+				variables {
+					path "project/*" {
+						capabilities = ["submit-job"]
+					}
+				}
+			}
+			`,
+			"Invalid variable capability",
+			nil,
+		},
+		{
+			`
+			namespace "default" {
+				variables {
+					path "has a space" {
+						capabilities = ["read"]
+					}
+				}
+			}
+			`,
+			"Invalid variable path",
+			nil,
+		},
 		{
 			`
 			namespace "default" {
@@ -2,9 +2,11 @@ package api
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/api/internal/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLPolicies_ListUpsert(t *testing.T) {
@@ -158,6 +160,40 @@ func TestACLTokens_CreateUpdate(t *testing.T) {
 	assert.Equal(t, out.Name, out2.Name)
 }
 
+func TestACLTokens_CreateWithTTL(t *testing.T) {
+	testutil.Parallel(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+	at := c.ACLTokens()
+
+	token := &ACLToken{
+		Name:          "foo",
+		Type:          "client",
+		Policies:      []string{"foo1"},
+		ExpirationTTL: time.Hour,
+	}
+
+	// Create the token and check the expiration is relative to its creation
+	out, wm, err := at.Create(token, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+	require.NotNil(t, out.ExpirationTime)
+	require.Equal(t, time.Hour, out.ExpirationTTL)
+	require.Equal(t, out.CreateTime.Add(time.Hour), *out.ExpirationTime)
+
+	// The expiration should be included within the token listing
+	list, _, err := at.List(nil)
+	require.NoError(t, err)
+	var found bool
+	for _, stub := range list {
+		if stub.AccessorID == out.AccessorID {
+			found = true
+			require.Equal(t, out.ExpirationTime, stub.ExpirationTime)
+		}
+	}
+	require.True(t, found)
+}
+
 func TestACLTokens_Info(t *testing.T) {
 	testutil.Parallel(t)
 	c, s, _ := makeACLClient(t, nil, nil)
@@ -269,3 +305,182 @@ func TestACL_OneTimeToken(t *testing.T) {
 	assert.NotNil(t, out3)
 	assert.Equal(t, out3.AccessorID, out.AccessorID)
 }
+
+func TestACLRoles(t *testing.T) {
+	testutil.Parallel(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+
+	// An initial listing should return an empty list.
+	aclRoleListResp, queryMeta, err := c.ACLRoles().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, aclRoleListResp)
+	assertQueryMeta(t, queryMeta)
+
+	// Create an ACL policy that can be referenced within the ACL role.
+	aclPolicy := ACLPolicy{
+		Name: "acl-role-api-test",
+		Rules: `namespace "default" {
+			policy = "read"
+		}
+		`,
+	}
+	writeMeta, err := c.ACLPolicies().Upsert(&aclPolicy, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+
+	// Create an ACL role referencing the previously created policy.
+	role := ACLRole{
+		Name:     "acl-role-api-test",
+		Policies: []*ACLRolePolicyLink{{Name: aclPolicy.Name}},
+	}
+	aclRoleCreateResp, writeMeta, err := c.ACLRoles().Create(&role, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.NotEmpty(t, aclRoleCreateResp.ID)
+	require.Equal(t, role.Name, aclRoleCreateResp.Name)
+
+	// Another listing should return one result.
+	aclRoleListResp, queryMeta, err = c.ACLRoles().List(nil)
+	require.NoError(t, err)
+	require.Len(t, aclRoleListResp, 1)
+	assertQueryMeta(t, queryMeta)
+
+	// Read the role using its ID.
+	aclRoleReadResp, queryMeta, err := c.ACLRoles().Get(aclRoleCreateResp.ID, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, queryMeta)
+	require.Equal(t, aclRoleCreateResp, aclRoleReadResp)
+
+	// Read the role using its name.
+	aclRoleReadResp, queryMeta, err = c.ACLRoles().GetByName(role.Name, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, queryMeta)
+	require.Equal(t, aclRoleCreateResp, aclRoleReadResp)
+
+	// Update the role name.
+	role.ID = aclRoleCreateResp.ID
+	role.Name = "acl-role-api-test-badger-badger-badger"
+	aclRoleUpdateResp, writeMeta, err := c.ACLRoles().Update(&role, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.Equal(t, role.Name, aclRoleUpdateResp.Name)
+	require.Equal(t, role.ID, aclRoleUpdateResp.ID)
+
+	// Create a token linked to the role using its name.
+	token := ACLToken{
+		Name:  "acl-role-api-test",
+		Type:  "client",
+		Roles: []*ACLTokenRoleLink{{Name: role.Name}},
+	}
+	tokenCreateResp, _, err := c.ACLTokens().Create(&token, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, tokenCreateResp.Roles)
+
+	// Delete the role.
+	writeMeta, err = c.ACLRoles().Delete(aclRoleCreateResp.ID, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+
+	// Make sure there are no ACL roles now present.
+	aclRoleListResp, queryMeta, err = c.ACLRoles().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, aclRoleListResp)
+	assertQueryMeta(t, queryMeta)
+}
+
+func TestACLAuthMethodsAndBindingRules(t *testing.T) {
+	testutil.Parallel(t)
+	c, s, _ := makeACLClient(t, nil, nil)
+	defer s.Stop()
+
+	// An initial listing should return an empty list.
+	authMethodListResp, queryMeta, err := c.ACLAuthMethods().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, authMethodListResp)
+	assertQueryMeta(t, queryMeta)
+
+	// Create an auth method.
+	authMethod := ACLAuthMethod{
+		Name:          "acl-auth-method-api-test",
+		Type:          ACLAuthMethodTypeOIDC,
+		TokenLocality: ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Default:       true,
+		Config: &ACLAuthMethodConfig{
+			OIDCDiscoveryURL:    "https://idp.example.com",
+			OIDCClientID:        "nomad",
+			OIDCClientSecret:    "very secret secret",
+			AllowedRedirectURIs: []string{"http://localhost:4649/oidc/callback"},
+		},
+	}
+	authMethodCreateResp, writeMeta, err := c.ACLAuthMethods().Create(&authMethod, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.Equal(t, authMethod.Name, authMethodCreateResp.Name)
+	require.Equal(t, authMethod.Config, authMethodCreateResp.Config)
+
+	// Another listing should return one result, without the config.
+	authMethodListResp, queryMeta, err = c.ACLAuthMethods().List(nil)
+	require.NoError(t, err)
+	require.Len(t, authMethodListResp, 1)
+	require.True(t, authMethodListResp[0].Default)
+	assertQueryMeta(t, queryMeta)
+
+	// Update the auth method.
+	authMethod.MaxTokenTTL = 2 * time.Hour
+	authMethodUpdateResp, writeMeta, err := c.ACLAuthMethods().Update(&authMethod, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.Equal(t, 2*time.Hour, authMethodUpdateResp.MaxTokenTTL)
+
+	// Read the auth method.
+	authMethodReadResp, queryMeta, err := c.ACLAuthMethods().Get(authMethod.Name, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, queryMeta)
+	require.Equal(t, authMethodUpdateResp, authMethodReadResp)
+
+	// Create a binding rule for the auth method.
+	rule := ACLBindingRule{
+		AuthMethod: authMethod.Name,
+		Selector:   `"engineering" in list.groups`,
+		BindType:   ACLBindingRuleBindTypeRole,
+		BindName:   "engineering",
+	}
+	ruleCreateResp, writeMeta, err := c.ACLBindingRules().Create(&rule, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.NotEmpty(t, ruleCreateResp.ID)
+
+	// Update the binding rule.
+	rule.ID = ruleCreateResp.ID
+	rule.Description = "engineers"
+	ruleUpdateResp, writeMeta, err := c.ACLBindingRules().Update(&rule, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+	require.Equal(t, "engineers", ruleUpdateResp.Description)
+
+	// Read and list the binding rules.
+	ruleReadResp, queryMeta, err := c.ACLBindingRules().Get(rule.ID, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, queryMeta)
+	require.Equal(t, ruleUpdateResp, ruleReadResp)
+
+	ruleListResp, queryMeta, err := c.ACLBindingRules().List(nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, queryMeta)
+	require.Len(t, ruleListResp, 1)
+
+	// Delete the binding rule, and then the auth method.
+	writeMeta, err = c.ACLBindingRules().Delete(rule.ID, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+
+	writeMeta, err = c.ACLAuthMethods().Delete(authMethod.Name, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, writeMeta)
+
+	authMethodListResp, _, err = c.ACLAuthMethods().List(nil)
+	require.NoError(t, err)
+	require.Empty(t, authMethodListResp)
+}
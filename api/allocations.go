@@ -106,6 +106,33 @@ func (a *Allocations) Stats(alloc *Allocation, q *QueryOptions) (*AllocResourceU
 	return &resp, err
 }
 
+// Checks gets the latest results of the checks of the services of the
+// allocation which use the nomad provider, by check ID.
+func (a *Allocations) Checks(allocID string, q *QueryOptions) (AllocCheckStatuses, error) {
+	var resp AllocCheckStatuses
+	path := fmt.Sprintf("/v1/client/allocation/%s/checks", allocID)
+	_, err := a.client.query(path, &resp, q)
+	return resp, err
+}
+
+// AllocCheckStatus is the latest result of a check of a service using the
+// nomad provider.
+type AllocCheckStatus struct {
+	ID         string
+	Check      string
+	Group      string
+	Output     string
+	Service    string
+	Status     string
+	StatusCode int
+	Task       string
+	Timestamp  int64
+}
+
+// AllocCheckStatuses holds the latest results of the checks of an allocation,
+// by check ID.
+type AllocCheckStatuses map[string]AllocCheckStatus
+
 func (a *Allocations) GC(alloc *Allocation, q *QueryOptions) error {
 	var resp struct{}
 	_, err := a.client.query("/v1/client/allocation/"+alloc.ID+"/gc", &resp, nil)
@@ -209,6 +236,9 @@ type AllocationMetric struct {
 	AllocationTime    time.Duration
 	CoalescedFailures int
 	ScoreMetaData     []*NodeScoreMeta
+	GangSize          int
+	GangPlaced        int
+	GangBlockedBy     string
 }
 
 // NodeScoreMeta is used to serialize node scoring metadata
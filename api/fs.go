@@ -227,6 +227,31 @@ func queryClientNode(c *Client, alloc *Allocation, reqPath string, q *QueryOptio
 func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin string,
 	offset int64, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
 
+	return a.LogsWithFilter(alloc, follow, task, logType, origin, offset, nil, cancel, q)
+}
+
+// LogsFilter filters the lines of the logs streamed by LogsWithFilter. Lines
+// are filtered by the client running the allocation before being sent.
+type LogsFilter struct {
+	// Since and Until stream the lines written in the time range when set.
+	// Times are approximated to the second.
+	Since time.Time
+	Until time.Time
+
+	// Grep is a regular expression the lines must match.
+	Grep string
+
+	// TailLines streams the last lines matching the other filters only,
+	// before following the logs.
+	TailLines int
+}
+
+// LogsWithFilter streams the lines of a tasks logs matching the filter,
+// blocking on EOF. The parameters are the parameters of Logs and:
+// * filter: the filter the lines must match, or nil to stream the logs as is.
+func (a *AllocFS) LogsWithFilter(alloc *Allocation, follow bool, task, logType, origin string,
+	offset int64, filter *LogsFilter, cancel <-chan struct{}, q *QueryOptions) (<-chan *StreamFrame, <-chan error) {
+
 	errCh := make(chan error, 1)
 
 	reqPath := fmt.Sprintf("/v1/client/fs/logs/%s", alloc.ID)
@@ -237,6 +262,21 @@ func (a *AllocFS) Logs(alloc *Allocation, follow bool, task, logType, origin str
 			q.Params["type"] = logType
 			q.Params["origin"] = origin
 			q.Params["offset"] = strconv.FormatInt(offset, 10)
+			if filter == nil {
+				return
+			}
+			if !filter.Since.IsZero() {
+				q.Params["since"] = filter.Since.Format(time.RFC3339Nano)
+			}
+			if !filter.Until.IsZero() {
+				q.Params["until"] = filter.Until.Format(time.RFC3339Nano)
+			}
+			if filter.Grep != "" {
+				q.Params["grep"] = filter.Grep
+			}
+			if filter.TailLines > 0 {
+				q.Params["tail_lines"] = strconv.Itoa(filter.TailLines)
+			}
 		})
 	if err != nil {
 		errCh <- err
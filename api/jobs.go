@@ -205,6 +205,17 @@ func (j *Jobs) ScaleStatus(jobID string, q *QueryOptions) (*JobScaleStatusRespon
 	return &resp, qm, nil
 }
 
+// QueueStatus is used to retrieve the position of a job in the job queue,
+// which holds the jobs waiting for capacity to place their allocations.
+func (j *Jobs) QueueStatus(jobID string, q *QueryOptions) (*JobQueueStatus, *QueryMeta, error) {
+	var resp JobQueueStatus
+	qm, err := j.client.query(fmt.Sprintf("/v1/job/%s/queue", url.PathEscape(jobID)), &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Versions is used to retrieve all versions of a particular job given its
 // unique ID.
 func (j *Jobs) Versions(jobID string, diffs bool, q *QueryOptions) ([]*Job, []*JobDiff, *QueryMeta, error) {
@@ -836,8 +847,10 @@ type Job struct {
 	Name             *string                 `hcl:"name,optional"`
 	Type             *string                 `hcl:"type,optional"`
 	Priority         *int                    `hcl:"priority,optional"`
+	Preemptible      *bool                   `hcl:"preemptible,optional"`
 	AllAtOnce        *bool                   `mapstructure:"all_at_once" hcl:"all_at_once,optional"`
 	Datacenters      []string                `hcl:"datacenters,optional"`
+	NodePool         *string                 `mapstructure:"node_pool" hcl:"node_pool,optional"`
 	Constraints      []*Constraint           `hcl:"constraint,block"`
 	Affinities       []*Affinity             `hcl:"affinity,block"`
 	TaskGroups       []*TaskGroup            `hcl:"group,block"`
@@ -924,6 +937,9 @@ func (j *Job) Canonicalize() {
 	if j.ConsulNamespace == nil {
 		j.ConsulNamespace = stringToPtr("")
 	}
+	if j.NodePool == nil {
+		j.NodePool = stringToPtr("")
+	}
 	if j.VaultToken == nil {
 		j.VaultToken = stringToPtr("")
 	}
@@ -1027,6 +1043,36 @@ type TaskGroupSummary struct {
 	Unknown  int
 }
 
+// JobQueueStatus is the position of a job in the job queue. The queue is
+// ordered by job priority and then by submit time.
+type JobQueueStatus struct {
+	JobID     string
+	Namespace string
+
+	// Queued is whether the job has allocations waiting for capacity. The
+	// other fields are only set if the job is queued.
+	Queued bool
+
+	// Position is the 1-based position of the job in the queue, out of
+	// QueueLength jobs.
+	Position    int
+	QueueLength int
+
+	// EvalID is the ID of the blocked evaluation holding the job's place.
+	EvalID string
+
+	// Reason explains why the job is waiting.
+	Reason string
+
+	// QueuedAllocations is the number of allocations waiting to be placed,
+	// keyed by task group name.
+	QueuedAllocations map[string]int
+
+	// QueuedTime is the time, in nanoseconds since the epoch, at which the
+	// job entered the queue.
+	QueuedTime int64
+}
+
 // JobListStub is used to return a subset of information about
 // jobs during list operations.
 type JobListStub struct {
@@ -1035,6 +1081,7 @@ type JobListStub struct {
 	Name              string
 	Namespace         string `json:",omitempty"`
 	Datacenters       []string
+	NodePool          string
 	Type              string
 	Priority          int
 	Periodic          bool
@@ -1304,8 +1351,17 @@ type ObjectDiff struct {
 }
 
 type PlanAnnotations struct {
-	DesiredTGUpdates map[string]*DesiredUpdates
-	PreemptedAllocs  []*AllocationListStub
+	DesiredTGUpdates    map[string]*DesiredUpdates
+	PreemptedAllocs     []*AllocationListStub
+	SpreadDistributions map[string][]*SpreadDistribution
+}
+
+// SpreadDistribution is the number of allocations of a task group placed in
+// each domain of a max_skew spread.
+type SpreadDistribution struct {
+	Attributes []string
+	MaxSkew    int
+	Counts     map[string]int
 }
 
 type DesiredUpdates struct {
@@ -298,6 +298,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -393,6 +394,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -471,6 +473,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -642,6 +645,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -808,6 +812,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -899,6 +904,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -1075,6 +1081,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -1246,6 +1253,7 @@ func TestJobs_Canonicalize(t *testing.T) {
 				AllAtOnce:         boolToPtr(false),
 				ConsulToken:       stringToPtr(""),
 				ConsulNamespace:   stringToPtr(""),
+				NodePool:          stringToPtr(""),
 				VaultToken:        stringToPtr(""),
 				VaultNamespace:    stringToPtr(""),
 				NomadTokenID:      stringToPtr(""),
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Keyring is used to access the root keys held by the servers, which are used
+// to encrypt variables and sign workload identities. This is separate from the
+// gossip keyring, which is accessed via the Agent API.
+type Keyring struct {
+	client *Client
+}
+
+// Keyring returns a handle to the root keyring.
+func (c *Client) Keyring() *Keyring {
+	return &Keyring{client: c}
+}
+
+// EncryptionAlgorithm chooses which algorithm is used for encrypting or
+// decrypting data with a root key.
+type EncryptionAlgorithm string
+
+const (
+	// EncryptionAlgorithmAES256GCM uses a 256-bit key with AES-GCM.
+	EncryptionAlgorithmAES256GCM EncryptionAlgorithm = "aes256-gcm"
+)
+
+// RootKeyState enum describes the lifecycle of a root key.
+type RootKeyState string
+
+const (
+	RootKeyStateActive   RootKeyState = "active"
+	RootKeyStateInactive RootKeyState = "inactive"
+	RootKeyStateRekeying RootKeyState = "rekeying"
+)
+
+// RootKeyMeta is the metadata used to refer to a root key. The key material
+// itself never leaves the servers.
+type RootKeyMeta struct {
+	KeyID       string
+	Algorithm   EncryptionAlgorithm
+	CreateTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+	State       RootKeyState
+}
+
+// List returns the metadata of all the root keys.
+func (k *Keyring) List(q *QueryOptions) ([]*RootKeyMeta, *QueryMeta, error) {
+	var resp []*RootKeyMeta
+	qm, err := k.client.query("/v1/operator/keyring/keys", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// KeyringDeleteOptions are parameters for the Delete API.
+type KeyringDeleteOptions struct {
+	KeyID string // UUID
+}
+
+// Delete removes an inactive root key which is no longer used to encrypt any
+// data.
+func (k *Keyring) Delete(opts *KeyringDeleteOptions, w *WriteOptions) (*WriteMeta, error) {
+	wm, err := k.client.delete(fmt.Sprintf("/v1/operator/keyring/key/%v",
+		url.PathEscape(opts.KeyID)), nil, w)
+	return wm, err
+}
+
+// KeyringRotateOptions are parameters for the Rotate API.
+type KeyringRotateOptions struct {
+	// Algorithm is the encryption algorithm of the new key. The servers
+	// choose the default algorithm if it is empty.
+	Algorithm EncryptionAlgorithm
+
+	// Full re-encrypts all the existing data with the new key, so the old
+	// keys can be removed.
+	Full bool
+}
+
+// Rotate generates a new root key and makes it the active key, which is used
+// to encrypt all new data.
+func (k *Keyring) Rotate(opts *KeyringRotateOptions, w *WriteOptions) (*RootKeyMeta, *WriteMeta, error) {
+	qp := url.Values{}
+	if opts != nil {
+		if opts.Algorithm != "" {
+			qp.Set("algo", string(opts.Algorithm))
+		}
+		if opts.Full {
+			qp.Set("full", "true")
+		}
+	}
+	resp := &struct {
+		Key *RootKeyMeta
+	}{}
+	wm, err := k.client.write("/v1/operator/keyring/rotate?"+qp.Encode(), nil, resp, w)
+	return resp.Key, wm, err
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring_CRUD(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+
+	kr := c.Keyring()
+
+	// Find the bootstrap key, which is created asynchronously once the
+	// leader is elected.
+	var keys []*RootKeyMeta
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		keys, _, err = kr.List(nil)
+		if err != nil {
+			return false, err
+		}
+		return len(keys) == 1, nil
+	}, func(err error) {
+		t.Fatalf("keyring was not initialized: %v", err)
+	})
+	oldKeyID := keys[0].KeyID
+	require.Equal(t, RootKeyStateActive, keys[0].State)
+
+	// Rotate the key
+	key, wm, err := kr.Rotate(&KeyringRotateOptions{Algorithm: EncryptionAlgorithmAES256GCM}, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+	require.NotNil(t, key)
+	require.Equal(t, RootKeyStateActive, key.State)
+	require.NotEqual(t, oldKeyID, key.KeyID)
+
+	keys, qm, err := kr.List(nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Len(t, keys, 2)
+
+	// The active key cannot be removed
+	_, err = kr.Delete(&KeyringDeleteOptions{KeyID: key.KeyID}, nil)
+	require.Error(t, err)
+
+	// Remove the old key
+	wm, err = kr.Delete(&KeyringDeleteOptions{KeyID: oldKeyID}, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	keys, _, err = kr.List(nil)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, key.KeyID, keys[0].KeyID)
+}
@@ -72,8 +72,14 @@ type Namespace struct {
 	Quota        string
 	Capabilities *NamespaceCapabilities `hcl:"capabilities,block"`
 	Meta         map[string]string
-	CreateIndex  uint64
-	ModifyIndex  uint64
+	// FairShareWeight is the weight of the namespace when the eval broker
+	// dequeues evaluations fairly across namespaces.
+	FairShareWeight int `mapstructure:"fair_share_weight" hcl:"fair_share_weight,optional"`
+	// EvalRateLimit overrides the evaluation rate limits of the server
+	// configuration for the namespace.
+	EvalRateLimit *EvalRateLimit `hcl:"eval_rate_limit,block"`
+	CreateIndex   uint64
+	ModifyIndex   uint64
 }
 
 type NamespaceCapabilities struct {
@@ -81,6 +87,17 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string `hcl:"disabled_task_drivers"`
 }
 
+// EvalRateLimit limits the rate at which the job register, evaluate, dispatch
+// and scale endpoints are called, per namespace and per job. Zero fields
+// inherit the rate limits of the server configuration, and rates of -1
+// disable the limit.
+type EvalRateLimit struct {
+	NamespaceRate  float64 `hcl:"namespace_rate"`
+	NamespaceBurst int     `hcl:"namespace_burst"`
+	JobRate        float64 `hcl:"job_rate"`
+	JobBurst       int     `hcl:"job_burst"`
+}
+
 // NamespaceIndexSort is a wrapper to sort Namespaces by CreateIndex. We
 // reverse the test so that we get the highest index first.
 type NamespaceIndexSort []*Namespace
@@ -549,6 +549,7 @@ type Node struct {
 	Links                 map[string]string
 	Meta                  map[string]string
 	NodeClass             string
+	NodePool              string
 	CgroupParent          string
 	Drain                 bool
 	DrainStrategy         *DrainStrategy
@@ -573,6 +574,7 @@ type NodeResources struct {
 	Disk     NodeDiskResources
 	Networks []*NetworkResource
 	Devices  []*NodeDeviceResource
+	Numa     NodeNumaResources
 
 	MinDynamicPort int
 	MaxDynamicPort int
@@ -588,6 +590,15 @@ type NodeMemoryResources struct {
 	MemoryMB int64
 }
 
+type NodeNumaResources struct {
+	Nodes []NodeNumaNode
+}
+
+type NodeNumaNode struct {
+	ID    uint16
+	Cores []uint16
+}
+
 type NodeDiskResources struct {
 	DiskMB int64
 }
@@ -910,6 +921,7 @@ type NodeListStub struct {
 	Datacenter            string
 	Name                  string
 	NodeClass             string
+	NodePool              string
 	Version               string
 	Drain                 bool
 	SchedulingEligibility string
@@ -134,6 +134,15 @@ type SchedulerConfiguration struct {
 	// management ACL token
 	RejectJobRegistration bool
 
+	// EvalBrokerFairShareEnabled specifies whether the eval broker dequeues
+	// ready evaluations fairly across namespaces, according to their
+	// FairShareWeight, rather than strictly by priority.
+	EvalBrokerFairShareEnabled bool
+
+	// RebalanceConfig specifies whether and how allocations are periodically
+	// migrated off under-utilized nodes to improve bin-packing.
+	RebalanceConfig RebalanceConfig
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -147,6 +156,31 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// SchedulerBrokerStatsResponse is the response object that wraps the
+// statistics of the eval broker queues by namespace
+type SchedulerBrokerStatsResponse struct {
+	// FairShareEnabled is whether the eval broker dequeues ready evaluations
+	// fairly across namespaces
+	FairShareEnabled bool
+
+	// Namespaces contains the statistics of the namespaces with ready
+	// evaluations or a fair share weight
+	Namespaces map[string]*SchedulerBrokerNamespaceStats
+
+	QueryMeta
+}
+
+// SchedulerBrokerNamespaceStats are the statistics of the eval broker queue of
+// a namespace
+type SchedulerBrokerNamespaceStats struct {
+	// Weight is the fair share weight of the namespace
+	Weight int
+
+	// Ready is the number of ready evaluations of the namespace waiting to be
+	// dequeued by the schedulers
+	Ready int
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -173,6 +207,67 @@ type PreemptionConfig struct {
 	SysBatchSchedulerEnabled bool
 	BatchSchedulerEnabled    bool
 	ServiceSchedulerEnabled  bool
+	VictimRules              []string
+}
+
+// RebalanceConfig specifies whether and how allocations are periodically
+// migrated off under-utilized nodes, so that the nodes can be emptied.
+type RebalanceConfig struct {
+	// Enabled specifies if the periodic rebalancing rounds migrate
+	// allocations.
+	Enabled bool
+
+	// MaxMigrations is the maximum number of allocations migrated in a
+	// rebalancing round. Defaults to 5.
+	MaxMigrations int
+
+	// UtilizationThreshold is the fraction of CPU or memory utilization,
+	// between 0 and 1, below which nodes are emptied. Defaults to 0.5.
+	UtilizationThreshold float64
+}
+
+// SchedulerRebalanceRequest is used to request a round of rebalancing.
+type SchedulerRebalanceRequest struct {
+	// DryRun only reports the proposed migrations, without migrating the
+	// allocations.
+	DryRun bool
+
+	// MaxMigrations overrides the maximum number of allocations migrated by
+	// the scheduler configuration when set.
+	MaxMigrations int
+}
+
+// SchedulerRebalanceResponse is the response of a round of rebalancing.
+type SchedulerRebalanceResponse struct {
+	// Nodes are the under-utilized nodes allocations are migrated off.
+	Nodes []*RebalanceNode
+
+	// Migrations are the proposed allocation migrations.
+	Migrations []*RebalanceMigration
+
+	// EvalIDs are the IDs of the evaluations created for the migrations,
+	// unless the request was a dry run.
+	EvalIDs []string
+
+	WriteMeta
+}
+
+// RebalanceNode is an under-utilized node emptied by rebalancing.
+type RebalanceNode struct {
+	ID          string
+	Name        string
+	Utilization float64
+}
+
+// RebalanceMigration is an allocation proposed to be migrated by rebalancing.
+type RebalanceMigration struct {
+	AllocID      string
+	AllocName    string
+	Namespace    string
+	JobID        string
+	TaskGroup    string
+	NodeID       string
+	TargetNodeID string
 }
 
 // SchedulerGetConfiguration is used to query the current Scheduler configuration.
@@ -208,6 +303,28 @@ func (op *Operator) SchedulerCASConfiguration(conf *SchedulerConfiguration, q *W
 	return &out, wm, nil
 }
 
+// SchedulerRebalance is used to migrate allocations off under-utilized nodes,
+// or to only report the proposed migrations for a dry run.
+func (op *Operator) SchedulerRebalance(req *SchedulerRebalanceRequest, q *WriteOptions) (*SchedulerRebalanceResponse, *WriteMeta, error) {
+	var out SchedulerRebalanceResponse
+	wm, err := op.c.write("/v1/operator/scheduler/rebalance", req, &out, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &out, wm, nil
+}
+
+// SchedulerGetBrokerStats is used to query the statistics of the eval broker
+// queues by namespace.
+func (op *Operator) SchedulerGetBrokerStats(q *QueryOptions) (*SchedulerBrokerStatsResponse, *QueryMeta, error) {
+	var resp SchedulerBrokerStatsResponse
+	qm, err := op.c.query("/v1/operator/scheduler/broker", &resp, q)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &resp, qm, nil
+}
+
 // Snapshot is used to capture a snapshot state of a running cluster.
 // The returned reader that must be consumed fully
 func (op *Operator) Snapshot(q *QueryOptions) (io.ReadCloser, error) {
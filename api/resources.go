@@ -14,6 +14,7 @@ type Resources struct {
 	DiskMB      *int               `mapstructure:"disk" hcl:"disk,optional"`
 	Networks    []*NetworkResource `hcl:"network,block"`
 	Devices     []*RequestedDevice `hcl:"device,block"`
+	NUMA        *NUMA              `hcl:"numa,block"`
 
 	// COMPAT(0.10)
 	// XXX Deprecated. Please do not use. The field will be removed in Nomad
@@ -241,6 +242,13 @@ type NodeDeviceLocality struct {
 	PciBusID string
 }
 
+// NUMA is used to keep the reserved cores of a task, and the memory they
+// access, within a single NUMA node of the client. Affinity is one of "none",
+// "prefer" or "require".
+type NUMA struct {
+	Affinity string `hcl:"affinity,optional"`
+}
+
 // RequestedDevice is used to request a device for a task.
 type RequestedDevice struct {
 	// Name is the request name. The possible values are as follows:
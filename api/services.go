@@ -56,6 +56,10 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Status is the health of the service registration as determined by the
+	// checks of the service, either "healthy" or "unhealthy".
+	Status string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -108,7 +112,10 @@ func (s *Services) List(q *QueryOptions) ([]*ServiceRegistrationListStub, *Query
 }
 
 // Get is used to return a list of service registrations whose name matches the
-// specified parameter.
+// specified parameter. Registrations whose checks are failing are omitted,
+// unless the "include_unhealthy" query parameter is set to true. The "choose"
+// query parameter, in the form "<count>|<key>", selects a stable subset of the
+// registrations for the key using rendezvous hashing.
 func (s *Services) Get(serviceName string, q *QueryOptions) ([]*ServiceRegistration, *QueryMeta, error) {
 	var resp []*ServiceRegistration
 	qm, err := s.client.query("/v1/service/"+url.PathEscape(serviceName), &resp, q)
@@ -255,9 +255,12 @@ func (p *ReschedulePolicy) String() string {
 
 // Spread is used to serialize task group allocation spread preferences
 type Spread struct {
-	Attribute    string          `hcl:"attribute,optional"`
-	Weight       *int8           `hcl:"weight,optional"`
-	SpreadTarget []*SpreadTarget `hcl:"target,block"`
+	Attribute         string          `hcl:"attribute,optional"`
+	Weight            *int8           `hcl:"weight,optional"`
+	SpreadTarget      []*SpreadTarget `hcl:"target,block"`
+	MaxSkew           int             `mapstructure:"max_skew" hcl:"max_skew,optional"`
+	WhenUnsatisfiable string          `mapstructure:"when_unsatisfiable" hcl:"when_unsatisfiable,optional"`
+	NestedAttributes  []string        `mapstructure:"nested_attributes" hcl:"nested_attributes,optional"`
 }
 
 // SpreadTarget is used to serialize target allocation spread percentages
@@ -289,9 +292,10 @@ func (s *Spread) Canonicalize() {
 
 // EphemeralDisk is an ephemeral disk object
 type EphemeralDisk struct {
-	Sticky  *bool `hcl:"sticky,optional"`
-	Migrate *bool `hcl:"migrate,optional"`
-	SizeMB  *int  `mapstructure:"size" hcl:"size,optional"`
+	Sticky     *bool `hcl:"sticky,optional"`
+	Migrate    *bool `hcl:"migrate,optional"`
+	SizeMB     *int  `mapstructure:"size" hcl:"size,optional"`
+	Checkpoint bool  `hcl:"checkpoint,optional"`
 }
 
 func DefaultEphemeralDisk() *EphemeralDisk {
@@ -432,6 +436,7 @@ type TaskGroup struct {
 	ShutdownDelay             *time.Duration            `mapstructure:"shutdown_delay" hcl:"shutdown_delay,optional"`
 	StopAfterClientDisconnect *time.Duration            `mapstructure:"stop_after_client_disconnect" hcl:"stop_after_client_disconnect,optional"`
 	MaxClientDisconnect       *time.Duration            `mapstructure:"max_client_disconnect" hcl:"max_client_disconnect,optional"`
+	Gang                      *bool                     `hcl:"gang,optional"`
 	Scaling                   *ScalingPolicy            `hcl:"scaling,block"`
 	Consul                    *Consul                   `hcl:"consul,block"`
 }
@@ -617,8 +622,13 @@ func (g *TaskGroup) AddSpread(s *Spread) *TaskGroup {
 
 // LogConfig provides configuration for log rotation
 type LogConfig struct {
-	MaxFiles      *int `mapstructure:"max_files" hcl:"max_files,optional"`
-	MaxFileSizeMB *int `mapstructure:"max_file_size" hcl:"max_file_size,optional"`
+	MaxFiles       *int           `mapstructure:"max_files" hcl:"max_files,optional"`
+	MaxFileSizeMB  *int           `mapstructure:"max_file_size" hcl:"max_file_size,optional"`
+	RotateEvery    *time.Duration `mapstructure:"rotate_every" hcl:"rotate_every,optional"`
+	Compression    *string        `mapstructure:"compression" hcl:"compression,optional"`
+	MaxTotalSizeMB *int           `mapstructure:"max_total_size" hcl:"max_total_size,optional"`
+	MaxAge         *time.Duration `mapstructure:"max_age" hcl:"max_age,optional"`
+	Sinks          []*LogSink     `mapstructure:"sink" hcl:"sink,block"`
 }
 
 func DefaultLogConfig() *LogConfig {
@@ -635,6 +645,35 @@ func (l *LogConfig) Canonicalize() {
 	if l.MaxFileSizeMB == nil {
 		l.MaxFileSizeMB = intToPtr(10)
 	}
+	for _, s := range l.Sinks {
+		s.Canonicalize()
+	}
+}
+
+// LogSink is an external destination task logs are shipped to.
+type LogSink struct {
+	Name      string            `hcl:"name,label"`
+	Type      string            `mapstructure:"type" hcl:"type,optional"`
+	Address   string            `mapstructure:"address" hcl:"address,optional"`
+	Facility  string            `mapstructure:"facility" hcl:"facility,optional"`
+	Tag       string            `mapstructure:"tag" hcl:"tag,optional"`
+	Path      string            `mapstructure:"path" hcl:"path,optional"`
+	Endpoint  string            `mapstructure:"endpoint" hcl:"endpoint,optional"`
+	Headers   map[string]string `mapstructure:"headers" hcl:"headers,optional"`
+	BatchSize *int              `mapstructure:"batch_size" hcl:"batch_size,optional"`
+	BatchWait *time.Duration    `mapstructure:"batch_wait" hcl:"batch_wait,optional"`
+}
+
+func (s *LogSink) Canonicalize() {
+	if s.Type == "syslog" && s.Facility == "" {
+		s.Facility = "local0"
+	}
+	if s.BatchSize == nil {
+		s.BatchSize = intToPtr(100)
+	}
+	if s.BatchWait == nil {
+		s.BatchWait = timeToPtr(1 * time.Second)
+	}
 }
 
 // DispatchPayloadConfig configures how a task gets its input from a job dispatch
@@ -969,6 +1008,8 @@ const (
 	TaskLeaderDead             = "Leader Task Dead"
 	TaskBuildingTaskDir        = "Building Task Directory"
 	TaskClientReconnected      = "Reconnected"
+	TaskCheckpointed           = "Checkpointed"
+	TaskRestoredCheckpoint     = "Restored Checkpoint"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
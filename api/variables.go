@@ -0,0 +1,369 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// ErrVariableNotFound was used as the content of an error string.
+	//
+	// Deprecated: use ErrVariablePathNotFound instead.
+	ErrVariableNotFound = "variable not found"
+)
+
+var (
+	// ErrVariablePathNotFound is returned when trying to read a variable
+	// that does not exist.
+	ErrVariablePathNotFound = errors.New("variable not found")
+)
+
+// Variables is used to access variables.
+type Variables struct {
+	client *Client
+}
+
+// Variables returns a new handle on the variables.
+func (c *Client) Variables() *Variables {
+	return &Variables{client: c}
+}
+
+// Create is used to create a variable. If a variable already exists at the
+// path it is overwritten.
+func (vars *Variables) Create(v *Variable, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	return vars.put(v, nil, qo)
+}
+
+// CheckedCreate is used to create a variable if it doesn't exist already. If
+// it does, it will return an ErrCASConflict that can be unwrapped for more
+// details.
+func (vars *Variables) CheckedCreate(v *Variable, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	var checkIndex uint64
+	return vars.put(v, &checkIndex, qo)
+}
+
+// Update is used to update a variable. If a variable does not exist at the
+// path it is created.
+func (vars *Variables) Update(v *Variable, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	return vars.put(v, nil, qo)
+}
+
+// CheckedUpdate is used to updated a variable if the modify index matches
+// the one on the server. If it does not, it will return an ErrCASConflict
+// that can be unwrapped for more details.
+func (vars *Variables) CheckedUpdate(v *Variable, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	checkIndex := v.ModifyIndex
+	return vars.put(v, &checkIndex, qo)
+}
+
+// Delete is used to delete a variable.
+func (vars *Variables) Delete(path string, qo *WriteOptions) (*WriteMeta, error) {
+	return vars.deleteInternal(path, nil, qo)
+}
+
+// CheckedDelete is used to delete a variable if the modify index matches
+// the one on the server. If it does not, it will return an ErrCASConflict
+// that can be unwrapped for more details.
+func (vars *Variables) CheckedDelete(path string, checkIndex uint64, qo *WriteOptions) (*WriteMeta, error) {
+	return vars.deleteInternal(path, &checkIndex, qo)
+}
+
+// Read is used to query a single variable by path. This will error if the
+// variable is not found.
+func (vars *Variables) Read(path string, qo *QueryOptions) (*Variable, *QueryMeta, error) {
+	v, qm, err := vars.readInternal(path, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if v == nil {
+		return nil, qm, ErrVariablePathNotFound
+	}
+	return v, qm, nil
+}
+
+// Peek is used to query a single variable by path, but does not error if the
+// variable is not found.
+func (vars *Variables) Peek(path string, qo *QueryOptions) (*Variable, *QueryMeta, error) {
+	return vars.readInternal(path, qo)
+}
+
+// GetItems is used to query a single variable by path and return only its
+// items. This will error if the variable is not found.
+func (vars *Variables) GetItems(path string, qo *QueryOptions) (*VariableItems, *QueryMeta, error) {
+	v, qm, err := vars.Read(path, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &v.Items, qm, nil
+}
+
+// List is used to dump all of the variables the caller is permitted to list.
+// The prefix query option can be used to filter the results.
+func (vars *Variables) List(qo *QueryOptions) ([]*VariableMetadata, *QueryMeta, error) {
+	var resp []*VariableMetadata
+	qm, err := vars.client.query("/v1/vars", &resp, qo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp, qm, nil
+}
+
+// PrefixList is used to list the variables with paths beginning with the
+// given prefix.
+func (vars *Variables) PrefixList(prefix string, qo *QueryOptions) ([]*VariableMetadata, *QueryMeta, error) {
+	if qo == nil {
+		qo = &QueryOptions{Prefix: prefix}
+	} else {
+		qo.Prefix = prefix
+	}
+	return vars.List(qo)
+}
+
+// readInternal performs the query for a variable, returning a nil variable
+// when it does not exist.
+func (vars *Variables) readInternal(path string, qo *QueryOptions) (*Variable, *QueryMeta, error) {
+	path, err := escapeVariablePath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := vars.client.newRequest("GET", "/v1/var/"+path)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setQueryOptions(qo)
+
+	rtt, resp, err := vars.client.doRequest(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	qm := &QueryMeta{}
+	_ = parseQueryMeta(resp, qm)
+	qm.RequestTime = rtt
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var out Variable
+		if err := decodeBody(resp, &out); err != nil {
+			return nil, nil, err
+		}
+		return &out, qm, nil
+	case http.StatusNotFound:
+		return nil, qm, nil
+	default:
+		return nil, nil, unexpectedResponseError(resp)
+	}
+}
+
+// put writes the variable, optionally using check-and-set semantics.
+func (vars *Variables) put(v *Variable, checkIndex *uint64, qo *WriteOptions) (*Variable, *WriteMeta, error) {
+	if v == nil {
+		return nil, nil, errors.New("variable is required")
+	}
+	path, err := escapeVariablePath(v.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+	qo = writeOptionsWithNamespace(qo, v.Namespace)
+
+	r, err := vars.client.newRequest("PUT", "/v1/var/"+path)
+	if err != nil {
+		return nil, nil, err
+	}
+	r.setWriteOptions(qo)
+	if checkIndex != nil {
+		r.params.Set("cas", fmt.Sprint(*checkIndex))
+	}
+	r.obj = v
+
+	var out Variable
+	wm, err := vars.doChecked(r, &out)
+	if err != nil {
+		return nil, wm, err
+	}
+	return &out, wm, nil
+}
+
+// deleteInternal deletes the variable, optionally using check-and-set
+// semantics.
+func (vars *Variables) deleteInternal(path string, checkIndex *uint64, qo *WriteOptions) (*WriteMeta, error) {
+	path, err := escapeVariablePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := vars.client.newRequest("DELETE", "/v1/var/"+path)
+	if err != nil {
+		return nil, err
+	}
+	r.setWriteOptions(qo)
+	if checkIndex != nil {
+		r.params.Set("cas", fmt.Sprint(*checkIndex))
+	}
+
+	return vars.doChecked(r, nil)
+}
+
+// doChecked performs the write request and decodes the response into out
+// when it is not nil. A check-and-set conflict is returned as an
+// ErrCASConflict error.
+func (vars *Variables) doChecked(r *request, out interface{}) (*WriteMeta, error) {
+	rtt, resp, err := vars.client.doRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	wm := &WriteMeta{RequestTime: rtt}
+	_ = parseWriteMeta(resp, wm)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if out == nil {
+			return wm, nil
+		}
+		if err := decodeBody(resp, out); err != nil {
+			return nil, err
+		}
+		return wm, nil
+	case http.StatusConflict:
+		var conflict Variable
+		if err := decodeBody(resp, &conflict); err != nil {
+			return nil, err
+		}
+		return wm, ErrCASConflict{
+			CheckIndex: r.params.Get("cas"),
+			Conflict:   &conflict,
+		}
+	default:
+		return nil, unexpectedResponseError(resp)
+	}
+}
+
+// unexpectedResponseError returns the same error as requireOK for responses
+// with a status code the caller does not handle.
+func unexpectedResponseError(resp *http.Response) error {
+	var buf bytes.Buffer
+	io.Copy(&buf, resp.Body)
+	return fmt.Errorf("Unexpected response code: %d (%s)", resp.StatusCode, buf.Bytes())
+}
+
+// escapeVariablePath validates and escapes the path of a variable for use
+// in a URL.
+func escapeVariablePath(path string) (string, error) {
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		return "", errors.New("variable path is required")
+	}
+	return (&url.URL{Path: path}).EscapedPath(), nil
+}
+
+// writeOptionsWithNamespace returns write options targeting the namespace,
+// unless the options already specify one.
+func writeOptionsWithNamespace(qo *WriteOptions, namespace string) *WriteOptions {
+	if namespace == "" || (qo != nil && qo.Namespace != "") {
+		return qo
+	}
+	if qo == nil {
+		return &WriteOptions{Namespace: namespace}
+	}
+	out := *qo
+	out.Namespace = namespace
+	return &out
+}
+
+// Variable specifies the metadata and contents to be stored in the
+// encrypted Nomad backend.
+type Variable struct {
+	// Namespace is the Nomad namespace associated with the variable
+	Namespace string
+
+	// Path is the path to the variable
+	Path string
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+
+	// Times provided as a convenience for operators expressed as Unix
+	// timestamps in nanoseconds
+	CreateTime int64
+	ModifyTime int64
+
+	// Items contains the k/v variable component
+	Items VariableItems
+}
+
+// VariableMetadata specifies the metadata for a variable and is used as the
+// list object.
+type VariableMetadata struct {
+	// Namespace is the Nomad namespace associated with the variable
+	Namespace string
+
+	// Path is the path to the variable
+	Path string
+
+	// Raft indexes to track creation and modification
+	CreateIndex uint64
+	ModifyIndex uint64
+
+	// Times provided as a convenience for operators expressed as Unix
+	// timestamps in nanoseconds
+	CreateTime int64
+	ModifyTime int64
+}
+
+// VariableItems are the key/value pairs of a Variable.
+type VariableItems map[string]string
+
+// NewVariable is a convenience method to more easily create a ready-to-use
+// variable.
+func NewVariable(path string) *Variable {
+	return &Variable{
+		Path:  path,
+		Items: make(VariableItems),
+	}
+}
+
+// Copy returns a new deep copy of this Variable.
+func (v *Variable) Copy() *Variable {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	out.Items = make(VariableItems, len(v.Items))
+	for k, val := range v.Items {
+		out.Items[k] = val
+	}
+	return &out
+}
+
+// Metadata returns the VariableMetadata component of a Variable.
+func (v *Variable) Metadata() *VariableMetadata {
+	return &VariableMetadata{
+		Namespace:   v.Namespace,
+		Path:        v.Path,
+		CreateIndex: v.CreateIndex,
+		ModifyIndex: v.ModifyIndex,
+		CreateTime:  v.CreateTime,
+		ModifyTime:  v.ModifyTime,
+	}
+}
+
+// ErrCASConflict is returned when a check-and-set write or delete fails
+// because the variable has been modified since the given index.
+type ErrCASConflict struct {
+	CheckIndex string
+	Conflict   *Variable
+}
+
+func (e ErrCASConflict) Error() string {
+	return fmt.Sprintf("cas conflict: expected ModifyIndex %s; found %v", e.CheckIndex, e.Conflict.ModifyIndex)
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/nomad/api/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariables_SimpleCRUD(t *testing.T) {
+	testutil.Parallel(t)
+	c, s := makeClient(t, nil, nil)
+	defer s.Stop()
+	vars := c.Variables()
+
+	// Writing a variable requires the keyring to be initialized, which
+	// happens asynchronously once the leader is elected.
+	sv := NewVariable("my/first/variable")
+	sv.Items["k1"] = "v1"
+	var out *Variable
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		out, _, err = vars.Create(sv, nil)
+		return err == nil, err
+	}, func(err error) {
+		t.Fatalf("failed to create variable: %v", err)
+	})
+	require.Equal(t, "default", out.Namespace)
+	require.Equal(t, sv.Items, out.Items)
+	require.NotZero(t, out.ModifyIndex)
+
+	// Read the variable back.
+	got, qm, err := vars.Read(sv.Path, nil)
+	require.NoError(t, err)
+	assertQueryMeta(t, qm)
+	require.Equal(t, out.ModifyIndex, got.ModifyIndex)
+	require.Equal(t, "v1", got.Items["k1"])
+
+	// Peeking at a missing variable is not an error, reading one is.
+	missing, _, err := vars.Peek("does/not/exist", nil)
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	_, _, err = vars.Read("does/not/exist", nil)
+	require.ErrorIs(t, err, ErrVariablePathNotFound)
+
+	// Listing by prefix.
+	list, _, err := vars.PrefixList("my/", nil)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, sv.Path, list[0].Path)
+
+	list, _, err = vars.PrefixList("other/", nil)
+	require.NoError(t, err)
+	require.Len(t, list, 0)
+
+	// Checked writes conflict when the variable has been modified.
+	update := got.Copy()
+	update.Items["k1"] = "v2"
+	update.ModifyIndex--
+	_, _, err = vars.CheckedUpdate(update, nil)
+	var conflictErr ErrCASConflict
+	require.True(t, errors.As(err, &conflictErr))
+	require.Equal(t, got.ModifyIndex, conflictErr.Conflict.ModifyIndex)
+
+	_, _, err = vars.CheckedCreate(sv, nil)
+	require.True(t, errors.As(err, &conflictErr))
+
+	update.ModifyIndex = got.ModifyIndex
+	out, _, err = vars.CheckedUpdate(update, nil)
+	require.NoError(t, err)
+	require.Equal(t, "v2", out.Items["k1"])
+
+	// Checked deletes conflict when the variable has been modified.
+	_, err = vars.CheckedDelete(sv.Path, got.ModifyIndex, nil)
+	require.True(t, errors.As(err, &conflictErr))
+
+	wm, err := vars.CheckedDelete(sv.Path, out.ModifyIndex, nil)
+	require.NoError(t, err)
+	assertWriteMeta(t, wm)
+
+	_, _, err = vars.Read(sv.Path, nil)
+	require.ErrorIs(t, err, ErrVariablePathNotFound)
+}
@@ -21,6 +21,10 @@ const (
 	// tokenCacheSize is the number of ACL tokens to keep cached. Tokens have a fetching cost,
 	// so we keep the hot tokens cached to reduce the lookups.
 	tokenCacheSize = 64
+
+	// roleCacheSize is the number of ACL roles to keep cached. Roles have a fetching cost,
+	// so we keep the hot roles cached to reduce the ACL token resolution time.
+	roleCacheSize = 64
 )
 
 // clientACLResolver holds the state required for client resolution
@@ -34,6 +38,9 @@ type clientACLResolver struct {
 
 	// tokenCache is used to maintain the fetched token objects
 	tokenCache *lru.TwoQueueCache
+
+	// roleCache is used to maintain the fetched role objects
+	roleCache *lru.TwoQueueCache
 }
 
 // init is used to setup the client resolver state
@@ -52,13 +59,18 @@ func (c *clientACLResolver) init() error {
 	if err != nil {
 		return err
 	}
+	c.roleCache, err = lru.New2Q(roleCacheSize)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-// cachedACLValue is used to manage ACL Token or Policy TTLs
+// cachedACLValue is used to manage ACL Token, Policy or Role TTLs
 type cachedACLValue struct {
 	Token     *structs.ACLToken
 	Policy    *structs.ACLPolicy
+	Role      *structs.ACLRole
 	CacheTime time.Time
 }
 
@@ -95,13 +107,27 @@ func (c *Client) resolveTokenAndACL(secretID string) (*acl.ACL, *structs.ACLToke
 		return nil, nil, structs.ErrTokenNotFound
 	}
 
+	// Tokens are cached, so the expiration time must be checked against the
+	// cached value rather than relying on the server
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, nil, structs.ErrTokenExpired
+	}
+
 	// Check if this is a management token
 	if token.Type == structs.ACLManagementToken {
 		return acl.ManagementACL, token, nil
 	}
 
-	// Resolve the policies
-	policies, err := c.resolvePolicies(token.SecretID, token.Policies)
+	// Resolve the policies, including those granted through the token's roles
+	policyNames := token.Policies
+	if len(token.Roles) > 0 {
+		roles, err := c.resolveRoles(token.SecretID, token.Roles)
+		if err != nil {
+			return nil, nil, err
+		}
+		policyNames = mergeRolePolicyNames(token.Policies, roles)
+	}
+	policies, err := c.resolvePolicies(token.SecretID, policyNames)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -227,3 +253,93 @@ func (c *Client) resolvePolicies(secretID string, policies []string) ([]*structs
 	// Return the valid policies
 	return out, nil
 }
+
+// resolveRoles is used to translate a set of ACL role links into the role
+// objects. Roles are cached and refreshed in the same way as policies, using
+// the policy TTL, since a role is only a named set of policies.
+func (c *Client) resolveRoles(secretID string, roleLinks []*structs.ACLTokenRoleLink) ([]*structs.ACLRole, error) {
+	var out []*structs.ACLRole
+	var expired []*structs.ACLRole
+	var missing []string
+
+	// Scan the cache for each role
+	for _, roleLink := range roleLinks {
+		// Lookup the role in the cache
+		raw, ok := c.roleCache.Get(roleLink.ID)
+		if !ok {
+			missing = append(missing, roleLink.ID)
+			continue
+		}
+
+		// Check if the cached value is valid or expired
+		cached := raw.(*cachedACLValue)
+		if cached.Age() <= c.config.ACLPolicyTTL {
+			out = append(out, cached.Role)
+		} else {
+			expired = append(expired, cached.Role)
+		}
+	}
+
+	// Hot-path if we have no missing or expired roles
+	if len(missing)+len(expired) == 0 {
+		return out, nil
+	}
+
+	// Lookup the missing and expired roles
+	fetch := missing
+	for _, r := range expired {
+		fetch = append(fetch, r.ID)
+	}
+	req := structs.ACLRolesByIDRequest{
+		ACLRoleIDs: fetch,
+		QueryOptions: structs.QueryOptions{
+			Region:     c.Region(),
+			AuthToken:  secretID,
+			AllowStale: true,
+		},
+	}
+	var resp structs.ACLRolesByIDResponse
+	if err := c.RPC(structs.ACLGetRolesRPCMethod, &req, &resp); err != nil {
+		// If we encounter an error but have cached roles, mask the error and extend the cache
+		if len(missing) == 0 {
+			c.logger.Warn("failed to resolve roles, using expired cached value", "error", err)
+			out = append(out, expired...)
+			return out, nil
+		}
+		return nil, err
+	}
+
+	// Handle each output
+	for _, role := range resp.ACLRoles {
+		c.roleCache.Add(role.ID, &cachedACLValue{
+			Role:      role,
+			CacheTime: time.Now(),
+		})
+		out = append(out, role)
+	}
+
+	// Return the valid roles
+	return out, nil
+}
+
+// mergeRolePolicyNames returns the deduplicated names of the policies linked
+// directly to a token and through its roles.
+func mergeRolePolicyNames(policies []string, roles []*structs.ACLRole) []string {
+	seen := make(map[string]struct{}, len(policies))
+	out := make([]string, 0, len(policies))
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			out = append(out, name)
+		}
+	}
+	for _, policyName := range policies {
+		add(policyName)
+	}
+	for _, role := range roles {
+		for _, policyLink := range role.Policies {
+			add(policyLink.Name)
+		}
+	}
+	return out
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestClient_ACL_resolveTokenValue(t *testing.T) {
@@ -175,6 +176,50 @@ func TestClient_ACL_ResolveToken(t *testing.T) {
 	assert.Nil(t, out4)
 }
 
+func TestClient_ACL_ResolveToken_Roles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, _, cleanupS1 := testACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	c1, cleanup := TestClient(t, func(c *config.Config) {
+		c.RPCHandler = s1
+		c.ACLEnabled = true
+	})
+	defer cleanup()
+
+	// Create a token which is only granted permissions via a role
+	policy := mock.ACLPolicy()
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy.Name}}
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: role.ID}}
+	require.NoError(t, s1.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLPolicy{policy}))
+	require.NoError(t, s1.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLRole{role}, false))
+	require.NoError(t, s1.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 120, []*structs.ACLToken{token}))
+
+	// Test the client resolution
+	out, err := c1.ResolveToken(token.SecretID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.True(t, out.AllowNamespaceOperation("default", acl.NamespaceCapabilityListJobs))
+
+	// The role should now be cached
+	raw, ok := c1.roleCache.Get(role.ID)
+	require.True(t, ok)
+	require.Equal(t, role.ID, raw.(*cachedACLValue).Role.ID)
+
+	// Test caching
+	out2, err := c1.ResolveToken(token.SecretID)
+	require.NoError(t, err)
+	require.Same(t, out, out2)
+}
+
 func TestClient_ACL_ResolveSecretToken(t *testing.T) {
 	ci.Parallel(t)
 
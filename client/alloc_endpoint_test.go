@@ -457,6 +457,37 @@ func TestAllocations_Stats(t *testing.T) {
 	})
 }
 
+func TestAllocations_Checks(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	client, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	a := mock.Alloc()
+	require.Nil(client.addAlloc(a, ""))
+
+	// Try with bad alloc
+	req := &cstructs.AllocChecksRequest{}
+	var resp cstructs.AllocChecksResponse
+	err := client.ClientRPC("Allocations.Checks", &req, &resp)
+	require.NotNil(err)
+
+	// Try with good alloc
+	result := &nstructs.CheckQueryResult{
+		ID:      "_nomad-check-abc123",
+		Status:  nstructs.CheckSuccess,
+		Service: "web",
+		Check:   "alive",
+	}
+	client.checkStore.Set(a.ID, result)
+
+	req.AllocID = a.ID
+	var resp2 cstructs.AllocChecksResponse
+	require.Nil(client.ClientRPC("Allocations.Checks", &req, &resp2))
+	require.Equal(map[nstructs.CheckID]*nstructs.CheckQueryResult{result.ID: result}, resp2.Results)
+}
+
 func TestAllocations_Stats_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -47,6 +47,16 @@ var (
 	// task.
 	TmpDirName = "tmp"
 
+	// CheckpointDirName is the name of the directory in the client's alloc
+	// directory holding the checkpoint images of the tasks of each
+	// allocation. It is kept outside of the allocation directories, which
+	// tasks can write to, and is only accessible by the client.
+	CheckpointDirName = "checkpoint"
+
+	// SnapshotCheckpointRecord is the PAX record marking the checkpoint
+	// images in snapshots. Its value is the name of the checkpointed task.
+	SnapshotCheckpointRecord = "NOMAD.checkpoint"
+
 	// The set of directories that exist inside each shared alloc directory.
 	SharedAllocDirs = []string{LogDirName, TmpDirName, SharedDataDir}
 
@@ -84,6 +94,12 @@ type AllocDir struct {
 	// TaskDirs is a mapping of task names to their non-shared directory.
 	TaskDirs map[string]*TaskDir
 
+	// CheckpointDir is the directory holding the checkpoint images of the
+	// tasks of this allocation. It is only created when checkpointing or
+	// migrating tasks, and is purged on alloc destroy.
+	// <client_alloc_dir>/checkpoint/<alloc_id>/
+	CheckpointDir string
+
 	// clientAllocDir is the client agent's root alloc directory. It must
 	// be excluded from chroots and is configured via client.alloc_dir.
 	clientAllocDir string
@@ -116,6 +132,7 @@ func NewAllocDir(logger hclog.Logger, clientAllocDir, allocID string) *AllocDir
 		AllocDir:       allocDir,
 		SharedDir:      filepath.Join(allocDir, SharedAllocName),
 		TaskDirs:       make(map[string]*TaskDir),
+		CheckpointDir:  filepath.Join(clientAllocDir, CheckpointDirName, allocID),
 		logger:         logger,
 	}
 }
@@ -131,7 +148,8 @@ func (d *AllocDir) NewTaskDir(name string) *TaskDir {
 }
 
 // Snapshot creates an archive of the files and directories in the data dir of
-// the allocation and the task local directories
+// the allocation, the task local directories and the checkpoint images of the
+// tasks.
 //
 // Since a valid tar may have been written even when an error occurs, a special
 // file "NOMAD-${ALLOC_ID}-ERROR.log" will be appended to the tar with the
@@ -145,7 +163,6 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 	for _, taskdir := range d.TaskDirs {
 		rootPaths = append(rootPaths, taskdir.LocalDir)
 	}
-
 	tw := tar.NewWriter(w)
 	defer tw.Close()
 
@@ -197,24 +214,76 @@ func (d *AllocDir) Snapshot(w io.Writer) error {
 
 	// Walk through all the top level directories and add the files and
 	// directories in the archive
+	walkErr := func(path string, err error) error {
+		allocID := filepath.Base(d.AllocDir)
+		if writeErr := writeError(tw, allocID, err); writeErr != nil {
+			// This could be bad; other side won't know
+			// snapshotting failed. It could also just mean
+			// the snapshotting side closed the connect
+			// prematurely and won't try to use the tar
+			// anyway.
+			d.logger.Warn("snapshotting failed and unable to write error marker", "error", writeErr)
+		}
+		return fmt.Errorf("failed to snapshot %s: %v", path, err)
+	}
 	for _, path := range rootPaths {
 		if err := filepath.Walk(path, walkFn); err != nil {
-			allocID := filepath.Base(d.AllocDir)
-			if writeErr := writeError(tw, allocID, err); writeErr != nil {
-				// This could be bad; other side won't know
-				// snapshotting failed. It could also just mean
-				// the snapshotting side closed the connect
-				// prematurely and won't try to use the tar
-				// anyway.
-				d.logger.Warn("snapshotting failed and unable to write error marker", "error", writeErr)
-			}
-			return fmt.Errorf("failed to snapshot %s: %v", path, err)
+			return walkErr(path, err)
+		}
+	}
+
+	// Add the checkpoint images of the tasks, marked so that they are kept
+	// apart from the alloc dir on the other side
+	if pathExists(d.CheckpointDir) {
+		if err := filepath.Walk(d.CheckpointDir, checkpointWalkFn(tw, d.CheckpointDir)); err != nil {
+			return walkErr(d.CheckpointDir, err)
 		}
 	}
 
 	return nil
 }
 
+// checkpointWalkFn returns a filepath.WalkFunc adding the checkpoint images
+// in checkpointDir to the archive. The images are named after the path
+// relative to checkpointDir, and carry the SnapshotCheckpointRecord PAX
+// record. Only regular files are included.
+func checkpointWalkFn(tw *tar.Writer, checkpointDir string) filepath.WalkFunc {
+	return func(path string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !fileInfo.Mode().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(checkpointDir, path)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(fileInfo, "")
+		if err != nil {
+			return fmt.Errorf("error creating file header: %v", err)
+		}
+		hdr.Name = filepath.ToSlash(relPath)
+		hdr.Format = tar.FormatPAX
+		hdr.PAXRecords = map[string]string{
+			SnapshotCheckpointRecord: strings.SplitN(hdr.Name, "/", 2)[0],
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	}
+}
+
 // Move other alloc directory's shared path and local dir to this alloc dir.
 func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	d.mu.RLock()
@@ -260,6 +329,34 @@ func (d *AllocDir) Move(other *AllocDir, tasks []*structs.Task) error {
 	return nil
 }
 
+// MoveCheckpoints moves the checkpoint images of the given tasks from the
+// other alloc directory to this one. Tasks without images are skipped.
+func (d *AllocDir) MoveCheckpoints(other *AllocDir, tasks []string) error {
+	for _, task := range tasks {
+		otherImageDir := filepath.Join(other.CheckpointDir, task)
+		fileInfo, err := os.Lstat(otherImageDir)
+		if err != nil || !fileInfo.IsDir() {
+			continue
+		}
+
+		if err := BuildCheckpointDir(d.CheckpointDir); err != nil {
+			return fmt.Errorf("error creating checkpoint dir: %v", err)
+		}
+		imageDir := filepath.Join(d.CheckpointDir, task)
+		os.RemoveAll(imageDir) // remove stale images if any
+		if err := os.Rename(otherImageDir, imageDir); err != nil {
+			return fmt.Errorf("error moving task %q checkpoint: %v", task, err)
+		}
+	}
+	return nil
+}
+
+// BuildCheckpointDir creates dir, which must be in the client's checkpoint
+// directory, and its parents so they are only accessible by the client.
+func BuildCheckpointDir(dir string) error {
+	return os.MkdirAll(dir, 0700)
+}
+
 // Destroy tears down previously build directory structure.
 func (d *AllocDir) Destroy() error {
 	// Unmount all mounted shared alloc dirs.
@@ -272,6 +369,10 @@ func (d *AllocDir) Destroy() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to remove alloc dir %q: %v", d.AllocDir, err))
 	}
 
+	if err := os.RemoveAll(d.CheckpointDir); err != nil {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("failed to remove checkpoint dir %q: %v", d.CheckpointDir, err))
+	}
+
 	// Unset built since the alloc dir has been destroyed.
 	d.mu.Lock()
 	d.built = false
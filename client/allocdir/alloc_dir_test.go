@@ -277,6 +277,95 @@ func TestAllocDir_Move(t *testing.T) {
 	}
 }
 
+// Test that the checkpoint images of the tasks are kept outside of the alloc
+// dir, snapshotted apart from it, moved and destroyed
+func TestAllocDir_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	tmp1 := t.TempDir()
+	tmp2 := t.TempDir()
+
+	d1 := NewAllocDir(testlog.HCLogger(t), tmp1, "test")
+	if err := d1.Build(); err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	defer d1.Destroy()
+
+	d2 := NewAllocDir(testlog.HCLogger(t), tmp2, "test")
+	if err := d2.Build(); err != nil {
+		t.Fatalf("Build() failed: %v", err)
+	}
+	defer d2.Destroy()
+
+	td1 := d1.NewTaskDir(t1.Name)
+	if err := td1.Build(false, nil); err != nil {
+		t.Fatalf("TaskDir.Build() failed: %v", err)
+	}
+	d2.NewTaskDir(t1.Name)
+
+	if strings.HasPrefix(td1.CheckpointDir, d1.AllocDir) {
+		t.Fatalf("checkpoint dir %q is in the alloc dir", td1.CheckpointDir)
+	}
+
+	// Write an image to the task checkpoint dir
+	image := "pages-1.img"
+	if err := BuildCheckpointDir(td1.CheckpointDir); err != nil {
+		t.Fatalf("couldn't create checkpoint directory: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(td1.CheckpointDir, image), []byte("foo"), 0600); err != nil {
+		t.Fatalf("couldn't write to checkpoint directory: %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := d1.Snapshot(&b); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expected := filepath.Join(t1.Name, image)
+	found := false
+	tr := tar.NewReader(&b)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if hdr.Name == expected && hdr.PAXRecords[SnapshotCheckpointRecord] == t1.Name {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected snapshot to include checkpoint image %q", expected)
+	}
+
+	// Moving the d1 allocdir to d2 leaves the checkpoint images
+	if err := d2.Move(d1, []*structs.Task{t1}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(d2.TaskDirs[t1.Name].CheckpointDir, image)); err == nil {
+		t.Fatalf("checkpoint dir was moved with the alloc dir")
+	}
+
+	// Move the checkpoint images
+	if err := d2.MoveCheckpoints(d1, []string{t1.Name}); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	fi, err := os.Stat(filepath.Join(d2.TaskDirs[t1.Name].CheckpointDir, image))
+	if err != nil || fi == nil {
+		t.Fatalf("checkpoint dir was not moved")
+	}
+
+	// Destroying the alloc dir removes its checkpoint images
+	if err := d2.Destroy(); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if _, err := os.Stat(d2.CheckpointDir); !os.IsNotExist(err) {
+		t.Fatalf("checkpoint dir was not destroyed: %v", err)
+	}
+}
+
 func TestAllocDir_EscapeChecking(t *testing.T) {
 	ci.Parallel(t)
 
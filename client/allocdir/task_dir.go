@@ -39,6 +39,12 @@ type TaskDir struct {
 	// <task_dir>/secrets/
 	SecretsDir string
 
+	// CheckpointDir is the path to the directory holding the checkpoint
+	// images of the task on the host. It is outside of the alloc dir and
+	// isn't created by Build.
+	// <client_alloc_dir>/checkpoint/<alloc_id>/<task_name>/
+	CheckpointDir string
+
 	// skip embedding these paths in chroots. Used for avoiding embedding
 	// client.alloc_dir recursively.
 	skip map[string]struct{}
@@ -66,6 +72,7 @@ func newTaskDir(logger hclog.Logger, clientAllocDir, allocDir, taskName string)
 		SharedTaskDir:  filepath.Join(taskDir, SharedAllocName),
 		LocalDir:       filepath.Join(taskDir, TaskLocal),
 		SecretsDir:     filepath.Join(taskDir, TaskSecrets),
+		CheckpointDir:  filepath.Join(clientAllocDir, CheckpointDirName, filepath.Base(allocDir), taskName),
 		skip:           skip,
 		logger:         logger,
 	}
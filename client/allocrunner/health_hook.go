@@ -27,12 +27,13 @@ type healthSetter interface {
 }
 
 // allocHealthWatcherHook is responsible for watching an allocation's task
-// status and (optionally) Consul health check status to determine if the
+// status and (optionally) Consul or Nomad health check status to determine if the
 // allocation is health or unhealthy. Used by deployments and migrations.
 type allocHealthWatcherHook struct {
 	healthSetter healthSetter
 
-	// consul client used to monitor health checks
+	// consul is the service registration handler used to monitor health
+	// checks, of either the Consul or Nomad provider
 	consul serviceregistration.Handler
 
 	// listener is given to trackers to listen for alloc updates and closed
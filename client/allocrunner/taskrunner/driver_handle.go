@@ -48,6 +48,15 @@ func (h *DriverHandle) Kill() error {
 	return h.driver.StopTask(h.taskID, h.killTimeout, h.killSignal)
 }
 
+// Checkpoint checkpoints the task into imageDir, stopping it.
+func (h *DriverHandle) Checkpoint(imageDir string) error {
+	d, ok := h.driver.(drivers.DriverCheckpointer)
+	if !ok {
+		return fmt.Errorf("driver does not support checkpointing")
+	}
+	return d.CheckpointTask(h.taskID, imageDir)
+}
+
 func (h *DriverHandle) Stats(ctx context.Context, interval time.Duration) (<-chan *cstructs.TaskResourceUsage, error) {
 	return h.driver.TaskStats(ctx, h.taskID, interval)
 }
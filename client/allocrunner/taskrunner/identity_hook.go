@@ -0,0 +1,98 @@
+package taskrunner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// identityTokenFilePerms is the level of file permissions granted on the
+	// file holding the workload identity in the secrets directory of the task.
+	// The file is owned by the client, so it must be readable by the user the
+	// task runs as, which depends on the driver.
+	identityTokenFilePerms = 0666
+)
+
+// identityHook writes the workload identity signed by the servers for the
+// task into its secrets directory, so the task can prove which allocation it
+// belongs to and use it as a Nomad API credential.
+type identityHook struct {
+	taskName string
+
+	// token is the current workload identity of the task
+	token string
+
+	// tokenPath is the path of the file the token is written to. It is set
+	// in Prestart once the task directory has been built.
+	tokenPath string
+
+	lock   sync.Mutex
+	logger hclog.Logger
+}
+
+func newIdentityHook(alloc *structs.Allocation, taskName string, logger hclog.Logger) *identityHook {
+	h := &identityHook{
+		taskName: taskName,
+		token:    alloc.SignedIdentities[taskName],
+	}
+	h.logger = logger.Named(h.Name())
+	return h
+}
+
+func (*identityHook) Name() string {
+	return "identity"
+}
+
+func (h *identityHook) Prestart(ctx context.Context, req *interfaces.TaskPrestartRequest, resp *interfaces.TaskPrestartResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.tokenPath = filepath.Join(req.TaskDir.SecretsDir, structs.WorkloadIdentityFile)
+	return h.writeToken()
+}
+
+func (h *identityHook) Update(ctx context.Context, req *interfaces.TaskUpdateRequest, resp *interfaces.TaskUpdateResponse) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	token := req.Alloc.SignedIdentities[h.taskName]
+	if token == "" || token == h.token {
+		return nil
+	}
+	h.token = token
+
+	// The token is written once the task directory has been built.
+	if h.tokenPath == "" {
+		return nil
+	}
+	return h.writeToken()
+}
+
+// writeToken writes the current token of the task to its secrets directory.
+// Allocations placed before the servers were able to sign workload
+// identities do not have a token, so nothing is written.
+func (h *identityHook) writeToken() error {
+	if h.token == "" {
+		return nil
+	}
+
+	if err := ioutil.WriteFile(h.tokenPath, []byte(h.token), identityTokenFilePerms); err != nil {
+		return err
+	}
+
+	// Tokens written by older clients may only be readable by the client,
+	// and WriteFile only sets the permissions of the files it creates.
+	if err := os.Chmod(h.tokenPath, identityTokenFilePerms); err != nil {
+		return err
+	}
+
+	h.logger.Trace("workload identity written", "path", h.tokenPath)
+	return nil
+}
@@ -0,0 +1,96 @@
+package taskrunner
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// Statically assert the identity hook implements the expected interfaces
+var _ interfaces.TaskPrestartHook = (*identityHook)(nil)
+var _ interfaces.TaskUpdateHook = (*identityHook)(nil)
+
+// TestTaskRunner_IdentityHook asserts that the workload identity of the task
+// is written to its secrets dir, and rewritten when it changes.
+func TestTaskRunner_IdentityHook(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	alloc.SignedIdentities = map[string]string{task.Name: "original"}
+
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identity", alloc.ID)
+	defer allocDir.Destroy()
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(t, taskDir.Build(false, nil))
+
+	h := newIdentityHook(alloc, task.Name, logger)
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(t, h.Prestart(ctx, &req, &resp))
+
+	tokenPath := filepath.Join(taskDir.SecretsDir, structs.WorkloadIdentityFile)
+	data, err := ioutil.ReadFile(tokenPath)
+	require.NoError(t, err)
+	require.Equal(t, "original", string(data))
+
+	// The token is readable by the user the task runs as.
+	fi, err := os.Stat(tokenPath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(identityTokenFilePerms), fi.Mode().Perm())
+
+	// Updates with a new identity rewrite the token.
+	update := alloc.Copy()
+	update.SignedIdentities[task.Name] = "updated"
+	require.NoError(t, h.Update(ctx, &interfaces.TaskUpdateRequest{Alloc: update}, &interfaces.TaskUpdateResponse{}))
+
+	data, err = ioutil.ReadFile(tokenPath)
+	require.NoError(t, err)
+	require.Equal(t, "updated", string(data))
+}
+
+// TestTaskRunner_IdentityHook_NoIdentity asserts that no token is written
+// for allocations without a workload identity.
+func TestTaskRunner_IdentityHook_NoIdentity(t *testing.T) {
+	ci.Parallel(t)
+
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_noidentity", alloc.ID)
+	defer allocDir.Destroy()
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(t, taskDir.Build(false, nil))
+
+	h := newIdentityHook(alloc, task.Name, logger)
+
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(t, h.Prestart(ctx, &req, &resp))
+
+	_, err := os.Stat(filepath.Join(taskDir.SecretsDir, structs.WorkloadIdentityFile))
+	require.True(t, os.IsNotExist(err))
+}
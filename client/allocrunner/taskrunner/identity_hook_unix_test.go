@@ -0,0 +1,73 @@
+//go:build !windows
+// +build !windows
+
+package taskrunner
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/interfaces"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaskRunner_IdentityHook_NonRootUser asserts that the workload identity
+// can be read by a task which runs as another user than the client, as exec
+// tasks run as nobody by default.
+func TestTaskRunner_IdentityHook_NonRootUser(t *testing.T) {
+	ci.Parallel(t)
+	if syscall.Geteuid() != 0 {
+		t.Skip("Must be root to run test")
+	}
+
+	ctx := context.Background()
+	logger := testlog.HCLogger(t)
+
+	alloc := mock.BatchAlloc()
+	task := alloc.Job.TaskGroups[0].Tasks[0]
+	task.User = "nobody"
+	alloc.SignedIdentities = map[string]string{task.Name: "original"}
+
+	allocDir := allocdir.NewAllocDir(logger, "nomadtest_identity_user", alloc.ID)
+	defer allocDir.Destroy()
+	taskDir := allocDir.NewTaskDir(task.Name)
+	require.NoError(t, taskDir.Build(false, nil))
+
+	// Tokens written by older clients are only readable by the client
+	tokenPath := filepath.Join(taskDir.SecretsDir, structs.WorkloadIdentityFile)
+	require.NoError(t, os.WriteFile(tokenPath, []byte("stale"), 0600))
+
+	h := newIdentityHook(alloc, task.Name, logger)
+	req := interfaces.TaskPrestartRequest{
+		Task:    task,
+		TaskDir: taskDir,
+	}
+	resp := interfaces.TaskPrestartResponse{}
+	require.NoError(t, h.Prestart(ctx, &req, &resp))
+
+	u, err := user.Lookup(task.User)
+	require.NoError(t, err)
+	uid, err := strconv.Atoi(u.Uid)
+	require.NoError(t, err)
+	gid, err := strconv.Atoi(u.Gid)
+	require.NoError(t, err)
+
+	cmd := exec.Command("cat", tokenPath)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Credential: &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)},
+	}
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	require.Equal(t, "original", string(out))
+}
@@ -44,6 +44,24 @@ type logmonHookConfig struct {
 	logDir     string
 	stdoutFifo string
 	stderrFifo string
+
+	// allocID, jobID, namespace and taskGroup identify the task in the lines
+	// shipped to its log sinks
+	allocID   string
+	jobID     string
+	namespace string
+	taskGroup string
+
+	// defaultSinks are the log sinks of the client, used by tasks which don't
+	// configure their own
+	defaultSinks []*structs.LogSink
+
+	// unixSockets are the unix sockets the sinks of tasks may ship to
+	unixSockets []string
+
+	// networkDestinations are the host:port destinations the sinks of tasks
+	// may ship to
+	networkDestinations []string
 }
 
 func newLogMonHook(tr *TaskRunner, logger hclog.Logger) *logmonHook {
@@ -108,6 +126,13 @@ func (h *logmonHook) Prestart(ctx context.Context,
 		return nil
 	}
 
+	// Tasks may only ship their logs to the destinations the client allows
+	for _, sink := range req.Task.LogConfig.Sinks {
+		if err := sink.ValidateDestination(h.config.unixSockets, h.config.networkDestinations); err != nil {
+			return structs.NewRecoverableError(err, false)
+		}
+	}
+
 	attempts := 0
 	for {
 		err := h.prestartOneLoop(ctx, req)
@@ -176,14 +201,30 @@ func (h *logmonHook) prestartOneLoop(ctx context.Context, req *interfaces.TaskPr
 		}
 	}
 
+	// Tasks without sinks ship their logs to the default sinks of the client
+	sinks := req.Task.LogConfig.Sinks
+	if len(sinks) == 0 {
+		sinks = h.config.defaultSinks
+	}
+
 	err := h.logmon.Start(&logmon.LogConfig{
-		LogDir:        h.config.logDir,
-		StdoutLogFile: fmt.Sprintf("%s.stdout", req.Task.Name),
-		StderrLogFile: fmt.Sprintf("%s.stderr", req.Task.Name),
-		StdoutFifo:    h.config.stdoutFifo,
-		StderrFifo:    h.config.stderrFifo,
-		MaxFiles:      req.Task.LogConfig.MaxFiles,
-		MaxFileSizeMB: req.Task.LogConfig.MaxFileSizeMB,
+		LogDir:         h.config.logDir,
+		StdoutLogFile:  fmt.Sprintf("%s.stdout", req.Task.Name),
+		StderrLogFile:  fmt.Sprintf("%s.stderr", req.Task.Name),
+		StdoutFifo:     h.config.stdoutFifo,
+		StderrFifo:     h.config.stderrFifo,
+		MaxFiles:       req.Task.LogConfig.MaxFiles,
+		MaxFileSizeMB:  req.Task.LogConfig.MaxFileSizeMB,
+		RotateEvery:    req.Task.LogConfig.RotateEvery,
+		Compression:    req.Task.LogConfig.Compression,
+		MaxTotalSizeMB: req.Task.LogConfig.MaxTotalSizeMB,
+		MaxAge:         req.Task.LogConfig.MaxAge,
+		AllocID:        h.config.allocID,
+		JobID:          h.config.jobID,
+		Namespace:      h.config.namespace,
+		TaskGroup:      h.config.taskGroup,
+		TaskName:       req.Task.Name,
+		Sinks:          sinks,
 	})
 	if err != nil {
 		h.logger.Error("failed to start logmon", "error", err)
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
 	pstructs "github.com/hashicorp/nomad/plugins/shared/structs"
 	"github.com/stretchr/testify/require"
 )
@@ -100,3 +101,36 @@ func TestTaskRunner_LogmonHook_StartStop(t *testing.T) {
 	}
 	require.NoError(t, hook.Stop(context.Background(), &stopReq, nil))
 }
+
+// TestTaskRunner_LogmonHook_SinkDestinations asserts that tasks may only ship
+// their logs to the unix sockets and network destinations allowed by the
+// client.
+func TestTaskRunner_LogmonHook_SinkDestinations(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []*structs.LogSink{
+		{Name: "syslog", Type: structs.LogSinkTypeSyslog, Address: "unix:///var/run/docker.sock"},
+		{Name: "syslog", Type: structs.LogSinkTypeSyslog, Address: "tcp://127.0.0.1:514"},
+		{Name: "otlp", Type: structs.LogSinkTypeOTLP, Endpoint: "http://169.254.169.254/latest"},
+	}
+	for _, sink := range cases {
+		alloc := mock.BatchAlloc()
+		task := alloc.Job.TaskGroups[0].Tasks[0]
+		task.LogConfig.Sinks = []*structs.LogSink{sink}
+
+		hookConf := newLogMonHookConfig(task.Name, t.TempDir())
+		hookConf.unixSockets = []string{"/dev/log"}
+		hookConf.networkDestinations = []string{"logs.example.com:514"}
+		runner := &TaskRunner{logmonHookConfig: hookConf}
+		hook := newLogMonHook(runner, testlog.HCLogger(t))
+
+		req := interfaces.TaskPrestartRequest{
+			Task: task,
+		}
+		resp := interfaces.TaskPrestartResponse{}
+
+		err := hook.Prestart(context.Background(), &req, &resp)
+		require.ErrorContains(t, err, "is not allowed by the client")
+		require.False(t, structs.IsRecoverable(err))
+	}
+}
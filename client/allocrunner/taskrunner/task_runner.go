@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -838,8 +840,12 @@ func (tr *TaskRunner) runDriver() error {
 		return nil
 	}
 
-	// Start the job if there's no existing handle (or if RecoverTask failed)
-	handle, net, err := tr.driver.StartTask(taskConfig)
+	// Restore the task from the checkpoint of the previous allocation, or
+	// start the job if there's none or restoring it failed
+	handle, net, restored := tr.restoreTask(taskConfig)
+	if !restored {
+		handle, net, err = tr.driver.StartTask(taskConfig)
+	}
 	if err != nil {
 		// The plugin has died, try relaunching it
 		if err == bstructs.ErrPluginShutdown {
@@ -878,6 +884,10 @@ func (tr *TaskRunner) runDriver() error {
 
 	tr.setDriverHandle(NewDriverHandle(tr.driver, taskConfig.ID, tr.Task(), net))
 
+	if restored {
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskRestoredCheckpoint))
+	}
+
 	// Emit an event that we started
 	tr.UpdateState(structs.TaskStateRunning, structs.NewTaskEvent(structs.TaskStarted))
 	return nil
@@ -948,6 +958,11 @@ func (tr *TaskRunner) handleKill(resultCh <-chan *drivers.ExitResult) *drivers.E
 		return nil
 	}
 
+	// Checkpoint the task before killing it if it's being migrated
+	if tr.shouldCheckpoint() {
+		tr.checkpointTask(handle)
+	}
+
 	// Kill the task using an exponential backoff in-case of failures.
 	result, killErr := tr.killTask(handle, resultCh)
 	if killErr != nil {
@@ -1090,7 +1105,84 @@ func (tr *TaskRunner) buildTaskConfig() *drivers.TaskConfig {
 		AllocID:          tr.allocID,
 		NetworkIsolation: tr.networkIsolationSpec,
 		DNS:              dns,
+		Checkpointable:   tr.checkpointEnabled(),
+	}
+}
+
+// checkpointEnabled returns whether the task group enables checkpointing its
+// tasks for migration and the driver supports it.
+func (tr *TaskRunner) checkpointEnabled() bool {
+	alloc := tr.Alloc()
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.EphemeralDisk == nil || !tg.EphemeralDisk.Checkpoint {
+		return false
+	}
+	return tr.driverCapabilities != nil && tr.driverCapabilities.Checkpoint
+}
+
+// shouldCheckpoint returns whether the task should be checkpointed before
+// being killed, which is the case when its allocation is stopped to be
+// migrated.
+func (tr *TaskRunner) shouldCheckpoint() bool {
+	alloc := tr.Alloc()
+	return alloc.ServerTerminalStatus() && alloc.DesiredTransition.ShouldMigrate() && tr.checkpointEnabled()
+}
+
+// checkpointTask checkpoints the task into its checkpoint dir, which is
+// migrated by the alloc watcher of the replacement allocation to restore the
+// task from. The task is killed as usual if it can't be checkpointed.
+func (tr *TaskRunner) checkpointTask(handle *DriverHandle) {
+	imageDir := tr.taskDir.CheckpointDir
+	err := allocdir.BuildCheckpointDir(filepath.Dir(imageDir))
+	if err == nil {
+		err = os.RemoveAll(imageDir)
+	}
+	if err == nil {
+		err = handle.Checkpoint(imageDir)
+	}
+	if err != nil {
+		tr.logger.Warn("failed to checkpoint task, killing it", "error", err)
+		if err := os.RemoveAll(imageDir); err != nil {
+			tr.logger.Warn("failed to remove checkpoint", "error", err)
+		}
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to checkpoint task: %v", err)))
+		return
+	}
+
+	tr.EmitEvent(structs.NewTaskEvent(structs.TaskCheckpointed))
+}
+
+// restoreTask restores the task from the checkpoint migrated from the
+// previous allocation, if any. Checkpoints are only migrated for tasks of the
+// previous allocation that were checkpointed by their task runner. It returns
+// false if there's no checkpoint or restoring the task failed, in which case
+// the task should be started. The checkpoint is removed either way.
+func (tr *TaskRunner) restoreTask(taskConfig *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, bool) {
+	imageDir := tr.taskDir.CheckpointDir
+	if fi, err := os.Lstat(imageDir); err != nil || !fi.IsDir() || tr.Alloc().PreviousAllocation == "" {
+		return nil, nil, false
+	}
+	defer func() {
+		if err := os.RemoveAll(imageDir); err != nil {
+			tr.logger.Warn("failed to remove checkpoint", "error", err)
+		}
+	}()
+
+	checkpointer, ok := tr.driver.(drivers.DriverCheckpointer)
+	if !ok || !taskConfig.Checkpointable {
+		tr.logger.Warn("ignoring checkpoint of task as checkpointing is disabled")
+		return nil, nil, false
+	}
+
+	handle, net, err := checkpointer.RestoreTask(taskConfig, imageDir)
+	if err != nil {
+		tr.logger.Warn("failed to restore task from checkpoint, starting it", "error", err)
+		tr.EmitEvent(structs.NewTaskEvent(structs.TaskDriverMessage).
+			SetDriverMessage(fmt.Sprintf("Failed to restore task from checkpoint: %v", err)))
+		return nil, nil, false
 	}
+	return handle, net, true
 }
 
 // Restore task runner state. Called by AllocRunner.Restore after NewTaskRunner
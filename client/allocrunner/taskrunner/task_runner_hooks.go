@@ -50,19 +50,27 @@ func (tr *TaskRunner) initHooks() {
 	hookLogger := tr.logger.Named("task_hook")
 	task := tr.Task()
 
+	alloc := tr.Alloc()
 	tr.logmonHookConfig = newLogMonHookConfig(task.Name, tr.taskDir.LogDir)
+	tr.logmonHookConfig.allocID = alloc.ID
+	tr.logmonHookConfig.jobID = alloc.JobID
+	tr.logmonHookConfig.namespace = alloc.Namespace
+	tr.logmonHookConfig.taskGroup = alloc.TaskGroup
+	tr.logmonHookConfig.defaultSinks = tr.clientConfig.LogSinks
+	tr.logmonHookConfig.unixSockets = tr.clientConfig.LogSinkUnixSockets
+	tr.logmonHookConfig.networkDestinations = tr.clientConfig.LogSinkNetworkDestinations
 
 	// Add the hook resources
 	tr.hookResources = &hookResources{}
 
 	// Create the task directory hook. This is run first to ensure the
 	// directory path exists for other hooks.
-	alloc := tr.Alloc()
 	tr.runnerHooks = []interfaces.TaskHook{
 		newValidateHook(tr.clientConfig, hookLogger),
 		newTaskDirHook(tr, hookLogger),
 		newLogMonHook(tr, hookLogger),
 		newDispatchHook(alloc, hookLogger),
+		newIdentityHook(alloc, task.Name, hookLogger),
 		newVolumeHook(tr, hookLogger),
 		newArtifactHook(tr, hookLogger),
 		newStatsHook(tr, tr.clientConfig.StatsCollectionInterval, hookLogger),
@@ -114,6 +122,7 @@ func (tr *TaskRunner) initHooks() {
 			envBuilder:      tr.envBuilder,
 			consulNamespace: consulNamespace,
 			nomadNamespace:  tr.alloc.Job.Namespace,
+			nomadToken:      alloc.SignedIdentities[task.Name],
 		}))
 	}
 
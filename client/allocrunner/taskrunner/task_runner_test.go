@@ -1127,6 +1127,102 @@ func TestTaskRunner_NoShutdownDelay(t *testing.T) {
 	require.NoError(t, err, "killing task returned unexpected error")
 }
 
+// TestTaskRunner_Checkpoint asserts that tasks are checkpointed when their
+// alloc is stopped to be migrated, and restored from the checkpoint by the
+// task runner of the replacement alloc.
+func TestTaskRunner_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.EphemeralDisk.Migrate = true
+	tg.EphemeralDisk.Checkpoint = true
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "1000s",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	// Stop the alloc to migrate it
+	update := alloc.Copy()
+	update.DesiredStatus = structs.AllocDesiredStatusStop
+	update.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	tr.Update(update)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(testutil.TestMultiplier()*10)*time.Second)
+	defer cancel()
+	require.NoError(t, tr.Kill(ctx, structs.NewTaskEvent(structs.TaskKilling)))
+
+	require.True(t, hasTaskEvent(tr.TaskState(), structs.TaskCheckpointed))
+	imageDir := tr.taskDir.CheckpointDir
+	require.DirExists(t, imageDir)
+
+	// Migrate the checkpoint to the replacement alloc
+	newAlloc := alloc.Copy()
+	newAlloc.ID = uuid.Generate()
+	newAlloc.PreviousAllocation = alloc.ID
+	conf, cleanup2 := testTaskRunnerConfig(t, newAlloc, task.Name)
+	defer cleanup2()
+	require.NoError(t, allocdir.BuildCheckpointDir(filepath.Dir(conf.TaskDir.CheckpointDir)))
+	require.NoError(t, os.Rename(imageDir, conf.TaskDir.CheckpointDir))
+
+	newTR, err := NewTaskRunner(conf)
+	require.NoError(t, err)
+	go newTR.Run()
+	defer newTR.Kill(context.Background(), structs.NewTaskEvent("cleanup"))
+	testWaitForTaskToStart(t, newTR)
+
+	require.True(t, hasTaskEvent(newTR.TaskState(), structs.TaskRestoredCheckpoint))
+	require.NoDirExists(t, conf.TaskDir.CheckpointDir)
+
+	driverPlugin, err := conf.DriverManager.Dispense(mockdriver.PluginID.Name)
+	require.NoError(t, err)
+	handle := driverPlugin.(*mockdriver.Driver).GetHandle(newTR.getDriverHandle().ID())
+	require.NotNil(t, handle)
+	require.True(t, handle.Restored)
+}
+
+// TestTaskRunner_Checkpoint_Missing asserts that tasks whose alloc enables
+// checkpointing are started when there's no checkpoint to restore.
+func TestTaskRunner_Checkpoint_Missing(t *testing.T) {
+	ci.Parallel(t)
+
+	alloc := mock.BatchAlloc()
+	tg := alloc.Job.TaskGroups[0]
+	tg.EphemeralDisk.Migrate = true
+	tg.EphemeralDisk.Checkpoint = true
+	task := tg.Tasks[0]
+	task.Driver = "mock_driver"
+	task.Config = map[string]interface{}{
+		"run_for": "1000s",
+	}
+
+	tr, _, cleanup := runTestTaskRunner(t, alloc, task.Name)
+	defer cleanup()
+	testWaitForTaskToStart(t, tr)
+
+	// Killing the task without migrating the alloc doesn't checkpoint it
+	require.NoError(t, tr.Kill(context.Background(), structs.NewTaskEvent(structs.TaskKilling)))
+	state := tr.TaskState()
+	require.False(t, hasTaskEvent(state, structs.TaskRestoredCheckpoint))
+	require.False(t, hasTaskEvent(state, structs.TaskCheckpointed))
+	require.NoDirExists(t, tr.taskDir.CheckpointDir)
+}
+
+// hasTaskEvent returns whether the task state has an event of the given type.
+func hasTaskEvent(state *structs.TaskState, eventType string) bool {
+	for _, e := range state.Events {
+		if e.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
 // TestTaskRunner_Dispatch_Payload asserts that a dispatch job runs and the
 // payload was written to disk.
 func TestTaskRunner_Dispatch_Payload(t *testing.T) {
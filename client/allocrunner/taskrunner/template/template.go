@@ -105,6 +105,10 @@ type TaskTemplateManagerConfig struct {
 
 	// NomadNamespace is the Nomad namespace for the task
 	NomadNamespace string
+
+	// NomadToken is the signed workload identity of the task. If it is not
+	// set, the SecretID of the node is used instead.
+	NomadToken string
 }
 
 // Validate validates the configuration.
@@ -814,8 +818,16 @@ func newRunnerConfig(config *TaskTemplateManagerConfig,
 	conf.Nomad.Namespace = &config.NomadNamespace
 	conf.Nomad.Transport.CustomDialer = cc.TemplateDialer
 
-	// Use the Node's SecretID to authenticate Nomad template function calls.
-	conf.Nomad.Token = &cc.Node.SecretID
+	// Use the workload identity of the task to authenticate Nomad template
+	// function calls, which can read the variables of the task and the
+	// services of its namespace. Allocations placed before the servers
+	// signed workload identities fall back to the Node's SecretID, which can
+	// only read services, not variables.
+	if config.NomadToken != "" {
+		conf.Nomad.Token = &config.NomadToken
+	} else {
+		conf.Nomad.Token = &cc.Node.SecretID
+	}
 
 	conf.Finalize()
 	return conf, nil
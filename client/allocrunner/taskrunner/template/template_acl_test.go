@@ -0,0 +1,101 @@
+package template_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaskTemplateManager_NomadService_ACL asserts that templates can look up
+// the services of their namespace with the workload identity of the task when
+// ACLs are enabled.
+func TestTaskTemplateManager_NomadService_ACL(t *testing.T) {
+	ci.Parallel(t)
+
+	a := agent.NewTestAgent(t, t.Name(), func(c *agent.Config) {
+		c.ACL.Enabled = true
+		c.Client.Enabled = true
+	})
+	defer a.Shutdown()
+
+	client := a.Client()
+	client.SetSecretID(a.RootToken.SecretID)
+
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		if len(nodes) == 0 {
+			return false, fmt.Errorf("missing node")
+		}
+		if _, ok := nodes[0].Drivers["mock_driver"]; !ok {
+			return false, fmt.Errorf("mock_driver not ready")
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The task registers a service and renders it with a template, which is
+	// rendered again once the service has been registered.
+	task := api.NewTask("web", "mock_driver").
+		SetConfig("run_for", "1m").
+		Require(&api.Resources{
+			MemoryMB: helper.IntToPtr(64),
+			CPU:      helper.IntToPtr(100),
+		})
+	task.Services = []*api.Service{{Name: "template-acl", Provider: "nomad"}}
+	task.Templates = []*api.Template{{
+		EmbeddedTmpl: helper.StringToPtr(`{{ range nomadService "template-acl" }}{{ .Name }}{{ end }}`),
+		DestPath:     helper.StringToPtr("local/services.txt"),
+		ChangeMode:   helper.StringToPtr("noop"),
+	}}
+	job := api.NewServiceJob("template-acl", "template-acl", "global", 50).
+		AddDatacenter("dc1").
+		AddTaskGroup(api.NewTaskGroup("web", 1).AddTask(task))
+
+	_, _, err := client.Jobs().Register(job, nil)
+	require.NoError(t, err)
+
+	var allocID string
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations("template-acl", false, nil)
+		if err != nil {
+			return false, err
+		}
+		if len(allocs) != 1 {
+			return false, fmt.Errorf("expected 1 alloc, got %d", len(allocs))
+		}
+		allocID = allocs[0].ID
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The template is rendered again once the service is registered and the
+	// default wait of the template has passed.
+	dest := filepath.Join(a.DataDir, "alloc", allocID, "web", "local", "services.txt")
+	testutil.WaitForResultUntil(30*time.Second, func() (bool, error) {
+		data, err := ioutil.ReadFile(dest)
+		if err != nil {
+			return false, err
+		}
+		if out := strings.TrimSpace(string(data)); out != "template-acl" {
+			return false, fmt.Errorf("expected service to be rendered, got %q", out)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
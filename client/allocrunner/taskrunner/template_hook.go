@@ -42,6 +42,10 @@ type templateHookConfig struct {
 
 	// nomadNamespace is the job's Nomad namespace
 	nomadNamespace string
+
+	// nomadToken is the signed workload identity of the task, which is used
+	// to authenticate Nomad template function calls
+	nomadToken string
 }
 
 type templateHook struct {
@@ -126,6 +130,7 @@ func (h *templateHook) newManager() (unblock chan struct{}, err error) {
 		EnvBuilder:           h.config.envBuilder,
 		MaxTemplateEventRate: template.DefaultMaxTemplateEventRate,
 		NomadNamespace:       h.config.nomadNamespace,
+		NomadToken:           h.config.nomadToken,
 	})
 	if err != nil {
 		h.logger.Error("failed to create template manager", "error", err)
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -14,6 +16,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	nomadapi "github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/state"
 	"github.com/hashicorp/nomad/client/config"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
@@ -44,6 +47,7 @@ type AllocRunnerMeta interface {
 	GetAllocDir() *allocdir.AllocDir
 	Listener() *cstructs.AllocListener
 	Alloc() *structs.Allocation
+	AllocState() *state.State
 }
 
 // PrevAllocWatcher allows AllocRunners to wait for a previous allocation to
@@ -111,6 +115,7 @@ func newMigratorForAlloc(c Config, tg *structs.TaskGroup, watchedAllocID string,
 			prevAllocDir: m.GetAllocDir(),
 			prevListener: m.Listener(),
 			prevStatus:   m.Alloc(),
+			prevState:    m.AllocState,
 			logger:       logger,
 		}
 	}
@@ -221,6 +226,10 @@ type localPrevAlloc struct {
 	// terminated (and therefore won't send updates to the listener)
 	prevStatus terminated
 
+	// prevState returns the state of the previous alloc, used to find the
+	// tasks that were checkpointed. May be nil if not migrating.
+	prevState func() *state.State
+
 	// waiting and migrating are true when alloc runner is waiting on the
 	// prevAllocWatcher. Writers must acquire the waitingLock and readers
 	// should use the helper methods IsWaiting and IsMigrating.
@@ -300,6 +309,10 @@ func (p *localPrevAlloc) Migrate(ctx context.Context, dest *allocdir.AllocDir) e
 	p.logger.Debug("copying previous alloc")
 
 	moveErr := dest.Move(p.prevAllocDir, p.tasks)
+	if moveErr == nil && p.prevState != nil {
+		tasks := checkpointedTasks(p.prevState().TaskStates)
+		moveErr = dest.MoveCheckpoints(p.prevAllocDir, tasks)
+	}
 
 	// Always cleanup previous alloc
 	if err := p.prevAllocDir.Destroy(); err != nil {
@@ -336,6 +349,10 @@ type remotePrevAlloc struct {
 	// Migrate() iff the previous alloc has not already been GC'd.
 	nodeID string
 
+	// checkpointed are the tasks of the previous alloc that were
+	// checkpointed. Set by Wait() along with nodeID.
+	checkpointed []string
+
 	// waiting and migrating are true when alloc runner is waiting on the
 	// prevAllocWatcher. Writers must acquire the waitingLock and readers
 	// should use the helper methods IsWaiting and IsMigrating.
@@ -415,6 +432,7 @@ func (p *remotePrevAlloc) Wait(ctx context.Context) error {
 		}
 		if resp.Alloc.Terminated() || resp.Alloc.ClientStatus == structs.AllocClientStatusUnknown {
 			p.nodeID = resp.Alloc.NodeID
+			p.checkpointed = checkpointedTasks(resp.Alloc.TaskStates)
 			return nil
 		}
 
@@ -462,7 +480,11 @@ func (p *remotePrevAlloc) Migrate(ctx context.Context, dest *allocdir.AllocDir)
 		return err
 	}
 
-	if err := dest.Move(prevAllocDir, p.tasks); err != nil {
+	err = dest.Move(prevAllocDir, p.tasks)
+	if err == nil {
+		err = dest.MoveCheckpoints(prevAllocDir, p.checkpointed)
+	}
+	if err != nil {
 		// cleanup on error
 		prevAllocDir.Destroy()
 		return err
@@ -544,7 +566,7 @@ func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string)
 		return nil, fmt.Errorf("error getting snapshot from previous alloc %q: %v", p.prevAllocID, err)
 	}
 
-	if err := p.streamAllocDir(ctx, resp, prevAllocDir.AllocDir); err != nil {
+	if err := p.streamAllocDir(ctx, resp, prevAllocDir.AllocDir, prevAllocDir.CheckpointDir); err != nil {
 		prevAllocDir.Destroy()
 		return nil, err
 	}
@@ -552,9 +574,10 @@ func (p *remotePrevAlloc) migrateAllocDir(ctx context.Context, nodeAddr string)
 	return prevAllocDir, nil
 }
 
-// stream remote alloc to dir to a local path. Caller should cleanup dest on
-// error.
-func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser, dest string) error {
+// stream remote alloc to dir to a local path. Checkpoint images are written to
+// checkpointDest instead, which is created if needed. Caller should cleanup
+// dest and checkpointDest on error.
+func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser, dest, checkpointDest string) error {
 	p.logger.Debug("streaming snapshot of previous alloc", "destination", dest)
 	tr := tar.NewReader(resp)
 	defer resp.Close()
@@ -602,6 +625,15 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 				p.prevAllocID, p.allocID, string(errBuf))
 		}
 
+		// Checkpoint images are kept apart from the alloc dir
+		if _, ok := hdr.PAXRecords[allocdir.SnapshotCheckpointRecord]; ok {
+			if err := p.streamCheckpoint(tr, hdr, checkpointDest); err != nil {
+				return fmt.Errorf("error streaming checkpoint of previous alloc %q for new alloc %q: %v",
+					p.prevAllocID, p.allocID, err)
+			}
+			continue
+		}
+
 		// If the header is for a directory we create the directory
 		if hdr.Typeflag == tar.TypeDir {
 			name := filepath.Join(dest, hdr.Name)
@@ -673,6 +705,47 @@ func (p *remotePrevAlloc) streamAllocDir(ctx context.Context, resp io.ReadCloser
 	return nil
 }
 
+// streamCheckpoint writes the checkpoint image of the header to the image dir
+// of its task in checkpointDest. Images must be regular files directly in the
+// image dir of the task named by the SnapshotCheckpointRecord PAX record.
+func (p *remotePrevAlloc) streamCheckpoint(tr *tar.Reader, hdr *tar.Header, checkpointDest string) error {
+	task := hdr.PAXRecords[allocdir.SnapshotCheckpointRecord]
+	dir, file := path.Split(hdr.Name)
+	if hdr.Typeflag != tar.TypeReg || dir != task+"/" || strings.Contains(task, "/") ||
+		task == "" || task == "." || task == ".." || file == "" || file == "." || file == ".." {
+		return fmt.Errorf("invalid checkpoint image %q", hdr.Name)
+	}
+
+	imageDir := filepath.Join(checkpointDest, task)
+	if err := allocdir.BuildCheckpointDir(imageDir); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(imageDir, file), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, tr); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// checkpointedTasks returns the tasks whose task runner recorded checkpointing
+// them.
+func checkpointedTasks(states map[string]*structs.TaskState) []string {
+	var tasks []string
+	for name, ts := range states {
+		for _, e := range ts.Events {
+			if e.Type == structs.TaskCheckpointed {
+				tasks = append(tasks, name)
+				break
+			}
+		}
+	}
+	return tasks
+}
+
 // NoopPrevAlloc does not block or migrate on a previous allocation and never
 // returns an error.
 type NoopPrevAlloc struct{}
@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/client/allocdir"
+	"github.com/hashicorp/nomad/client/allocrunner/state"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/mock"
@@ -28,6 +30,7 @@ type fakeAllocRunner struct {
 	alloc       *structs.Allocation
 	AllocDir    *allocdir.AllocDir
 	Broadcaster *cstructs.AllocBroadcaster
+	TaskStates  map[string]*structs.TaskState
 }
 
 // newFakeAllocRunner creates a new AllocRunnerMeta. Callers must call
@@ -58,6 +61,10 @@ func (f *fakeAllocRunner) Alloc() *structs.Allocation {
 	return f.alloc
 }
 
+func (f *fakeAllocRunner) AllocState() *state.State {
+	return &state.State{TaskStates: f.TaskStates}
+}
+
 // newConfig returns a new Config and cleanup func
 func newConfig(t *testing.T) (Config, func()) {
 	logger := testlog.HCLogger(t)
@@ -255,7 +262,7 @@ func TestPrevAlloc_StreamAllocDir_Error(t *testing.T) {
 	}
 
 	// Assert streamAllocDir fails
-	err = prevAlloc.streamAllocDir(context.Background(), ioutil.NopCloser(tarBuf), dest)
+	err = prevAlloc.streamAllocDir(context.Background(), ioutil.NopCloser(tarBuf), dest, t.TempDir())
 	if err == nil {
 		t.Fatalf("expected an error from streamAllocDir")
 	}
@@ -273,3 +280,90 @@ func TestPrevAlloc_StreamAllocDir_Error(t *testing.T) {
 		t.Fatalf("expected foo.txt to be size 1 but found %d", fi.Size())
 	}
 }
+
+// TestPrevAlloc_LocalPrevAlloc_Checkpoint asserts that only the checkpoint
+// images of the tasks the previous alloc recorded checkpointing are migrated.
+func TestPrevAlloc_LocalPrevAlloc_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	conf, cleanup := newConfig(t)
+	defer cleanup()
+
+	prevAR := conf.PreviousRunner.(*fakeAllocRunner)
+	prevAR.alloc.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, prevAR.AllocDir.Build())
+
+	// Both tasks have images but only web was checkpointed
+	for _, task := range []string{"web", "other"} {
+		imageDir := filepath.Join(prevAR.AllocDir.CheckpointDir, task)
+		require.NoError(t, os.MkdirAll(imageDir, 0700))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(imageDir, "pages-1.img"), []byte("foo"), 0600))
+	}
+	prevAR.TaskStates = map[string]*structs.TaskState{
+		"web":   {Events: []*structs.TaskEvent{structs.NewTaskEvent(structs.TaskCheckpointed)}},
+		"other": {Events: []*structs.TaskEvent{structs.NewTaskEvent(structs.TaskKilled)}},
+	}
+
+	dest := allocdir.NewAllocDir(conf.Logger, t.TempDir(), conf.Alloc.ID)
+	require.NoError(t, dest.Build())
+	defer dest.Destroy()
+
+	_, migrator := NewAllocWatcher(conf)
+	require.NoError(t, migrator.Migrate(context.Background(), dest))
+
+	require.FileExists(t, filepath.Join(dest.CheckpointDir, "web", "pages-1.img"))
+	require.NoDirExists(t, filepath.Join(dest.CheckpointDir, "other"))
+	require.NoDirExists(t, prevAR.AllocDir.CheckpointDir)
+}
+
+// TestPrevAlloc_StreamAllocDir_Checkpoint asserts that checkpoint images are
+// streamed apart from the alloc dir, and that anything but regular files in
+// the image dir of their task is rejected.
+func TestPrevAlloc_StreamAllocDir_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	prevAlloc := &remotePrevAlloc{
+		logger:      testlog.HCLogger(t),
+		allocID:     "123",
+		prevAllocID: "abc",
+		migrate:     true,
+	}
+
+	writeTar := func(hdr *tar.Header, contents string) io.ReadCloser {
+		buf := bytes.NewBuffer(nil)
+		tw := tar.NewWriter(buf)
+		hdr.Mode = 0600
+		hdr.Size = int64(len(contents))
+		hdr.Format = tar.FormatPAX
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(contents))
+		require.NoError(t, err)
+		require.NoError(t, tw.Close())
+		return ioutil.NopCloser(buf)
+	}
+	record := func(task string) map[string]string {
+		return map[string]string{allocdir.SnapshotCheckpointRecord: task}
+	}
+
+	dest, checkpointDest := t.TempDir(), t.TempDir()
+	rc := writeTar(&tar.Header{
+		Name:       "web/pages-1.img",
+		Typeflag:   tar.TypeReg,
+		PAXRecords: record("web"),
+	}, "foo")
+	require.NoError(t, prevAlloc.streamAllocDir(context.Background(), rc, dest, checkpointDest))
+	require.FileExists(t, filepath.Join(checkpointDest, "web", "pages-1.img"))
+	require.NoFileExists(t, filepath.Join(dest, "web", "pages-1.img"))
+
+	invalid := []*tar.Header{
+		{Name: "../web/pages-1.img", Typeflag: tar.TypeReg, PAXRecords: record("web")},
+		{Name: "web/pages-1.img", Typeflag: tar.TypeReg, PAXRecords: record("..")},
+		{Name: "web/sub/pages-1.img", Typeflag: tar.TypeReg, PAXRecords: record("web")},
+		{Name: "web/pages-2.img", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd", PAXRecords: record("web")},
+		{Name: "web/pages-1.img", Typeflag: tar.TypeReg, PAXRecords: record("web")},
+	}
+	for _, hdr := range invalid {
+		err := prevAlloc.streamAllocDir(context.Background(), writeTar(hdr, ""), dest, checkpointDest)
+		require.Error(t, err, hdr.Name)
+	}
+}
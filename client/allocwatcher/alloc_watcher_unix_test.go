@@ -124,7 +124,7 @@ func TestPrevAlloc_StreamAllocDir_Ok(t *testing.T) {
 
 	rc := ioutil.NopCloser(buf)
 	prevAlloc := &remotePrevAlloc{logger: testlog.HCLogger(t)}
-	if err := prevAlloc.streamAllocDir(context.Background(), rc, dir1); err != nil {
+	if err := prevAlloc.streamAllocDir(context.Background(), rc, dir1, t.TempDir()); err != nil {
 		t.Fatalf("err: %v", err)
 	}
 
@@ -35,6 +35,7 @@ import (
 	"github.com/hashicorp/nomad/client/pluginmanager/drivermanager"
 	"github.com/hashicorp/nomad/client/servers"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/client/serviceregistration/nsd"
 	"github.com/hashicorp/nomad/client/serviceregistration/wrapper"
 	"github.com/hashicorp/nomad/client/state"
@@ -235,6 +236,10 @@ type Client struct {
 	// registrations.
 	nomadService serviceregistration.Handler
 
+	// checkStore holds the results of the checks the nomadService handler
+	// runs for the services of the allocations.
+	checkStore *checkstore.Store
+
 	// serviceRegWrapper wraps the consulService and nomadService
 	// implementations so that the alloc and task runner service hooks can call
 	// this without needing to identify which backend provider should be used.
@@ -2556,6 +2561,7 @@ func (c *Client) setupVaultClient() error {
 // setupNomadServiceRegistrationHandler sets up the registration handler to use
 // for native service discovery.
 func (c *Client) setupNomadServiceRegistrationHandler() {
+	c.checkStore = checkstore.NewStore()
 	cfg := nsd.ServiceRegistrationHandlerCfg{
 		Datacenter: c.Datacenter(),
 		Enabled:    c.config.NomadServiceDiscovery,
@@ -2563,6 +2569,7 @@ func (c *Client) setupNomadServiceRegistrationHandler() {
 		NodeSecret: c.secretNodeID(),
 		Region:     c.Region(),
 		RPCFn:      c.RPC,
+		CheckStore: c.checkStore,
 	}
 	c.nomadService = nsd.NewServiceRegistrationHandler(c.logger, &cfg)
 }
@@ -266,6 +266,18 @@ type Config struct {
 	// HostNetworks is a map of the conigured host networks by name.
 	HostNetworks map[string]*structs.ClientHostNetworkConfig
 
+	// LogSinks are the sinks the logs of the tasks which don't set their own
+	// sinks are shipped to.
+	LogSinks []*structs.LogSink
+
+	// LogSinkUnixSockets are the paths of the unix sockets the syslog sinks
+	// of tasks may ship their logs to.
+	LogSinkUnixSockets []string
+
+	// LogSinkNetworkDestinations are the host:port destinations the syslog
+	// and OTLP sinks of tasks may ship their logs to.
+	LogSinkNetworkDestinations []string
+
 	// BindWildcardDefaultHostNetwork toggles if the default host network should accept all
 	// destinations (true) or only filter on the IP of the default host network (false) when
 	// port mapping. This allows Nomad clients with no defined host networks to accept and
@@ -695,6 +707,14 @@ func (c *Config) Copy() *Config {
 		nc.ReservableCores = make([]uint16, len(c.ReservableCores))
 		copy(nc.ReservableCores, c.ReservableCores)
 	}
+	nc.LogSinkUnixSockets = helper.CopySliceString(c.LogSinkUnixSockets)
+	nc.LogSinkNetworkDestinations = helper.CopySliceString(c.LogSinkNetworkDestinations)
+	if c.LogSinks != nil {
+		nc.LogSinks = make([]*structs.LogSink, len(c.LogSinks))
+		for i, sink := range c.LogSinks {
+			nc.LogSinks[i] = sink.Copy()
+		}
+	}
 	return nc
 }
 
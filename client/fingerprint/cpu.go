@@ -32,7 +32,7 @@ func NewCPUFingerprint(logger log.Logger) Fingerprint {
 
 func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintResponse) error {
 	cfg := req.Config
-	setResourcesCPU := func(totalCompute int, totalCores uint16, reservableCores []uint16) {
+	setResourcesCPU := func(totalCompute int, totalCores uint16, reservableCores []uint16, numaNodes []structs.NodeNumaNode) {
 		// COMPAT(0.10): Remove in 0.10
 		resp.Resources = &structs.Resources{
 			CPU: totalCompute,
@@ -44,6 +44,9 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 				TotalCpuCores:      totalCores,
 				ReservableCpuCores: reservableCores,
 			},
+			Numa: structs.NodeNumaResources{
+				Nodes: numaNodes,
+			},
 		}
 	}
 
@@ -81,6 +84,14 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 		}
 	}
 
+	numaNodes, err := f.deriveNumaNodes()
+	if err != nil {
+		f.logger.Warn("failed to detect NUMA topology", "error", err)
+	} else if len(numaNodes) > 0 {
+		resp.AddAttribute("cpu.numa_nodes", fmt.Sprintf("%d", len(numaNodes)))
+		f.logger.Debug("detected NUMA nodes", "count", len(numaNodes))
+	}
+
 	tt := int(stats.TotalTicksAvailable())
 	if cfg.CpuCompute > 0 {
 		f.logger.Debug("using user specified cpu compute", "cpu_compute", cfg.CpuCompute)
@@ -97,7 +108,7 @@ func (f *CPUFingerprint) Fingerprint(req *FingerprintRequest, resp *FingerprintR
 	}
 
 	resp.AddAttribute("cpu.totalcompute", fmt.Sprintf("%d", tt))
-	setResourcesCPU(tt, uint16(numCores), reservableCores)
+	setResourcesCPU(tt, uint16(numCores), reservableCores, numaNodes)
 	resp.Detected = true
 
 	return nil
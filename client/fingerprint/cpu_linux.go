@@ -1,7 +1,11 @@
 package fingerprint
 
 import (
+	"sort"
+
 	"github.com/hashicorp/nomad/client/lib/cgutil"
+	"github.com/hashicorp/nomad/client/lib/numa"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 func (f *CPUFingerprint) deriveReservableCores(req *FingerprintRequest) ([]uint16, error) {
@@ -10,3 +14,17 @@ func (f *CPUFingerprint) deriveReservableCores(req *FingerprintRequest) ([]uint1
 	// We may assume the hierarchy is already setup.
 	return cgutil.GetCPUsFromCgroup(req.Config.CgroupParent)
 }
+
+func (f *CPUFingerprint) deriveNumaNodes() ([]structs.NodeNumaNode, error) {
+	topology, err := numa.Scan()
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]structs.NodeNumaNode, 0, len(topology))
+	for id, cores := range topology {
+		nodes = append(nodes, structs.NodeNumaNode{ID: id, Cores: cores.ToSlice()})
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes, nil
+}
@@ -9,8 +9,8 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/client/allocdir"
 	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -33,6 +34,8 @@ var (
 	taskNotPresentErr    = fmt.Errorf("must provide task name")
 	logTypeNotPresentErr = fmt.Errorf("must provide log type (stdout/stderr)")
 	invalidOrigin        = fmt.Errorf("origin must be start or end")
+	invalidTailLines     = fmt.Errorf("tail lines must not be negative")
+	invalidTimeRange     = fmt.Errorf("until must not be before since")
 )
 
 const (
@@ -69,6 +72,13 @@ const (
 	OriginEnd   = "end"
 )
 
+// frameSender is the part of the StreamFramer the content of files is
+// streamed to, so that the lines of logs can be filtered before being framed.
+type frameSender interface {
+	Send(file, fileEvent string, data []byte, offset int64) error
+	ExitCh() <-chan struct{}
+}
+
 // FileSystem endpoint is used for accessing the logs and filesystem of
 // allocations.
 type FileSystem struct {
@@ -434,10 +444,35 @@ func (f *FileSystem) logs(conn io.ReadWriteCloser) {
 	frames := make(chan *sframer.StreamFrame, streamFramesBuffer)
 	errCh := make(chan error)
 
+	var grep *regexp.Regexp
+	if req.Grep != "" {
+		if grep, err = regexp.Compile(req.Grep); err != nil {
+			handleStreamResultError(
+				fmt.Errorf("invalid grep expression: %v", err),
+				helper.Int64ToPtr(400),
+				encoder)
+			return
+		}
+	}
+	if req.TailLines < 0 {
+		handleStreamResultError(invalidTailLines, helper.Int64ToPtr(400), encoder)
+		return
+	}
+	if !req.Since.IsZero() && !req.Until.IsZero() && req.Until.Before(req.Since) {
+		handleStreamResultError(invalidTimeRange, helper.Int64ToPtr(400), encoder)
+		return
+	}
+
 	// Start streaming
 	go func() {
-		if err := f.logsImpl(ctx, req.Follow, req.PlainText,
-			req.Offset, req.Origin, req.Task, req.LogType, fs, frames); err != nil {
+		var err error
+		if req.Filtered() {
+			err = f.filteredLogsImpl(ctx, &req, grep, fs, frames)
+		} else {
+			err = f.logsImpl(ctx, req.Follow, req.PlainText,
+				req.Offset, req.Origin, req.Task, req.LogType, fs, frames)
+		}
+		if err != nil {
 			select {
 			case errCh <- err:
 			case <-ctx.Done():
@@ -530,20 +565,35 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 	framer.Run()
 	defer framer.Destroy()
 
-	// Path to the logs
-	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	nextIdx, offset, err := logsStart(origin, offset)
+	if err != nil {
+		return err
+	}
+	return f.streamLogs(ctx, follow, nextIdx, offset, task, logType, fs, framer)
+}
 
-	// nextIdx is the next index to read logs from
-	var nextIdx int64
+// logsStart returns the index of the log file to start streaming logs from
+// and the offset relative to it, for an offset relative to the origin of the
+// logs.
+func logsStart(origin string, offset int64) (int64, int64, error) {
 	switch origin {
 	case "start":
-		nextIdx = 0
+		return 0, offset, nil
 	case "end":
-		nextIdx = math.MaxInt64
-		offset *= -1
+		return math.MaxInt64, -offset, nil
 	default:
-		return invalidOrigin
+		return 0, 0, invalidOrigin
 	}
+}
+
+// streamLogs streams the logs of the given task from the log file closest to
+// the nextIdx index, at the offset relative to it. It returns on EOF if follow
+// is not true otherwise when the context is cancelled or on an error.
+func (f *FileSystem) streamLogs(ctx context.Context, follow bool, nextIdx, offset int64,
+	task, logType string, fs allocdir.AllocDirFS, framer frameSender) error {
+
+	// Path to the logs
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
 
 	for {
 		// Logic for picking next file is:
@@ -557,6 +607,15 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 			return fmt.Errorf("failed to list entries: %v", err)
 		}
 
+		// Offsets in compressed log files are offsets in their uncompressed
+		// content
+		if offset != 0 {
+			entries, err = uncompressedLogSizes(fs, logPath, entries, task, logType)
+			if err != nil {
+				return err
+			}
+		}
+
 		// If we are not following logs, determine the max index for the logs we are
 		// interested in so we can stop there.
 		maxIndex := int64(math.MaxInt64)
@@ -588,7 +647,12 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 		}
 
 		p := filepath.Join(logPath, logEntry.Name)
-		err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		_, compression, _ := logging.ParseLogFileName(logBaseFile(task, logType), logEntry.Name)
+		if compression != logging.CompressionNone {
+			err = f.streamCompressedFile(ctx, openOffset, p, compression, fs, framer)
+		} else {
+			err = f.streamFile(ctx, openOffset, p, 0, fs, framer, eofCancelCh, cancelAfterFirstEof)
+		}
 
 		// Check if the context is cancelled
 		select {
@@ -637,7 +701,7 @@ func (f *FileSystem) logsImpl(ctx context.Context, follow, plain bool, offset in
 // cancel the stream on the next EOF. If the connection is broken an EPIPE
 // error is returned.
 func (f *FileSystem) streamFile(ctx context.Context, offset int64, path string, limit int64,
-	fs allocdir.AllocDirFS, framer *sframer.StreamFramer, eofCancelCh chan error, cancelAfterFirstEof bool) error {
+	fs allocdir.AllocDirFS, framer frameSender, eofCancelCh chan error, cancelAfterFirstEof bool) error {
 
 	// Get the reader
 	file, err := fs.ReadAt(path, offset)
@@ -725,6 +789,20 @@ OUTER:
 			case <-changes.Modified:
 				continue OUTER
 			case <-changes.Deleted:
+				// The file may be deleted once compressed after being
+				// rotated, so stream what is left of it first
+				for {
+					n, readErr := fileReader.Read(data)
+					offset += int64(n)
+					if n != 0 {
+						if err := framer.Send(path, "", data[:n], offset); err != nil {
+							return parseFramerErr(err)
+						}
+					}
+					if readErr != nil {
+						break
+					}
+				}
 				return parseFramerErr(framer.Send(path, deleteEvent, nil, offset))
 			case <-changes.Truncated:
 				// Close the current reader
@@ -778,6 +856,54 @@ OUTER:
 	}
 }
 
+// streamCompressedFile streams the uncompressed content of a rotated log file
+// which was compressed, starting at the offset in the uncompressed content.
+// Compressed files are never written to, so the stream ends at EOF.
+func (f *FileSystem) streamCompressedFile(ctx context.Context, offset int64, path, compression string,
+	fs allocdir.AllocDirFS, framer frameSender) error {
+
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r, err := logging.NewDecompressor(file, compression)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	if _, err := io.CopyN(io.Discard, r, offset); err != nil && err != io.EOF {
+		return err
+	}
+
+	data := make([]byte, streamFrameSize)
+	for {
+		n, readErr := r.Read(data)
+		offset += int64(n)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+		if n != 0 {
+			if err := framer.Send(path, "", data[:n], offset); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+
+		select {
+		case <-framer.ExitCh():
+			return nil
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+	}
+}
+
 // blockUntilNextLog returns a channel that will have data sent when the next
 // log index or anything greater is created.
 func blockUntilNextLog(ctx context.Context, fs allocdir.AllocDirFS, logPath, task, logType string, nextIndex int64) chan error {
@@ -854,7 +980,9 @@ func (a indexTupleArray) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 // error is returned.
 func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexTupleArray, error) {
 	var indexes []indexTuple
-	prefix := fmt.Sprintf("%s.%s.", task, logType)
+	seen := make(map[int]int)
+	baseFile := logBaseFile(task, logType)
+	prefix := baseFile + "."
 	for _, entry := range entries {
 		if entry.IsDir {
 			continue
@@ -866,18 +994,98 @@ func logIndexes(entries []*cstructs.AllocFileInfo, task, logType string) (indexT
 			continue
 		}
 
-		// Convert to an int
-		idx, err := strconv.Atoi(idxStr)
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert %q to a log index: %v", idxStr, err)
+		// Convert to an int, ignoring the extension of compressed files
+		idx, compression, ok := logging.ParseLogFileName(baseFile, entry.Name)
+		if !ok {
+			return nil, fmt.Errorf("failed to convert %q to a log index", idxStr)
 		}
 
+		// A rotated file exists both uncompressed and compressed while it is
+		// being compressed, in which case the uncompressed file is used
+		if i, ok := seen[idx]; ok {
+			if compression == logging.CompressionNone {
+				indexes[i].entry = entry
+			}
+			continue
+		}
+		seen[idx] = len(indexes)
+
 		indexes = append(indexes, indexTuple{idx: int64(idx), entry: entry})
 	}
 
 	return indexTupleArray(indexes), nil
 }
 
+// logBaseFile returns the base file name of the rotated log files of a task.
+func logBaseFile(task, logType string) string {
+	return fmt.Sprintf("%s.%s", task, logType)
+}
+
+// uncompressedLogSizes returns the entries with the size of the compressed log
+// files of the task replaced by the size of their uncompressed content.
+func uncompressedLogSizes(fs allocdir.AllocDirFS, logPath string, entries []*cstructs.AllocFileInfo,
+	task, logType string) ([]*cstructs.AllocFileInfo, error) {
+
+	baseFile := logBaseFile(task, logType)
+	out := make([]*cstructs.AllocFileInfo, len(entries))
+	for i, entry := range entries {
+		out[i] = entry
+		if entry.IsDir {
+			continue
+		}
+		idx, compression, ok := logging.ParseLogFileName(baseFile, entry.Name)
+		if !ok || compression == logging.CompressionNone {
+			continue
+		}
+
+		size, err := recordedSize(fs, filepath.Join(logPath, logging.SizeFileName(baseFile, idx)))
+		if err != nil {
+			// Files compressed before their size was recorded are
+			// decompressed to find it
+			size, err = uncompressedSize(fs, filepath.Join(logPath, entry.Name), compression)
+		}
+		if os.IsNotExist(err) {
+			// The file was purged since it was listed
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %v", entry.Name, err)
+		}
+
+		e := *entry
+		e.Size = size
+		out[i] = &e
+	}
+	return out, nil
+}
+
+// recordedSize returns the uncompressed size of a compressed log file recorded
+// by the rotator in the size file at path.
+func recordedSize(fs allocdir.AllocDirFS, path string) (int64, error) {
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	return logging.ReadSize(file)
+}
+
+// uncompressedSize returns the size of the uncompressed content of a file.
+func uncompressedSize(fs allocdir.AllocDirFS, path, compression string) (int64, error) {
+	file, err := fs.ReadAt(path, 0)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	r, err := logging.NewDecompressor(file, compression)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	return io.Copy(io.Discard, r)
+}
+
 // notFoundErr is returned when a log is requested but cannot be found.
 // Implements agent.HTTPCodedError but does not reference it to avoid circular
 // imports.
@@ -1,6 +1,8 @@
 package client
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -29,6 +31,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/require"
 )
 
@@ -1900,6 +1903,131 @@ func TestFS_logsImpl_NoFollow(t *testing.T) {
 	}
 }
 
+func TestFS_logsImpl_Compressed(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Create rotated log files compressed with each algorithm, and a file
+	// still being compressed
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write([]byte("0123"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	var zst bytes.Buffer
+	zw, err := zstd.NewWriter(&zst)
+	require.NoError(t, err)
+	_, err = zw.Write([]byte("4567"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	// The size of the first file isn't recorded, as for files compressed
+	// before sizes were recorded
+	files := map[string][]byte{
+		"foo.stdout.0.gz":    gz.Bytes(),
+		"foo.stdout.1.zst":   zst.Bytes(),
+		".foo.stdout.1.size": []byte("4"),
+		"foo.stdout.1":       []byte("4567"),
+		"foo.stdout.2":       []byte("89"),
+	}
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, name), data, 0777))
+	}
+
+	cases := []struct {
+		name     string
+		origin   string
+		offset   int64
+		expected string
+	}{
+		{
+			name:     "start",
+			origin:   OriginStart,
+			expected: "0123456789",
+		},
+		{
+			name:     "offset from start",
+			origin:   OriginStart,
+			offset:   2,
+			expected: "23456789",
+		},
+		{
+			name:     "offset from end",
+			origin:   OriginEnd,
+			offset:   7,
+			expected: "3456789",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			doneCh := make(chan []byte)
+			go func() {
+				var received []byte
+				for frame := range frames {
+					if !frame.IsHeartbeat() {
+						received = append(received, frame.Data...)
+					}
+				}
+				doneCh <- received
+			}()
+
+			require.NoError(t, c.endpoints.FileSystem.logsImpl(
+				ctx, false, false, tc.offset,
+				tc.origin, "foo", "stdout", ad, frames))
+			require.Equal(t, tc.expected, string(<-doneCh))
+		})
+	}
+}
+
+func TestFS_uncompressedLogSizes(t *testing.T) {
+	ci.Parallel(t)
+
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// The recorded size is used without decompressing the file
+	files := map[string][]byte{
+		"foo.stdout.0.gz":    []byte("not gzip"),
+		".foo.stdout.0.size": []byte("1024"),
+		"foo.stdout.1":       []byte("89"),
+	}
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, name), data, 0777))
+	}
+
+	logPath := filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName)
+	entries, err := ad.List(logPath)
+	require.NoError(t, err)
+	entries, err = uncompressedLogSizes(ad, logPath, entries, "foo", "stdout")
+	require.NoError(t, err)
+
+	sizes := make(map[string]int64)
+	for _, entry := range entries {
+		sizes[entry.Name] = entry.Size
+	}
+	require.EqualValues(t, 1024, sizes["foo.stdout.0.gz"])
+	require.EqualValues(t, 2, sizes["foo.stdout.1"])
+}
+
 func TestFS_logsImpl_Follow(t *testing.T) {
 	ci.Parallel(t)
 
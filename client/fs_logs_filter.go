@@ -0,0 +1,333 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/nomad/client/allocdir"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+)
+
+const (
+	// logIndexReloadRate is the rate at which the timestamp index of a log
+	// file is reloaded when looking up the time of lines past its last entry,
+	// as the index grows while the file is written to.
+	logIndexReloadRate = 250 * time.Millisecond
+)
+
+// filteredLogsImpl is used to stream the lines of the logs of the given task
+// matching the filters of the request. Output is sent on the passed frames
+// channel and the method will return on EOF if follow is not true otherwise
+// when the context is cancelled, once lines are past the until time of the
+// request or on an error.
+func (f *FileSystem) filteredLogsImpl(ctx context.Context, req *cstructs.FsLogsRequest,
+	grep *regexp.Regexp, fs allocdir.AllocDirFS, frames chan<- *sframer.StreamFrame) error {
+
+	// Create the framer
+	framer := sframer.NewStreamFramer(frames, streamHeartbeatRate, streamBatchWindow, streamFrameSize)
+	framer.Run()
+	defer framer.Destroy()
+
+	// The filter cancels streaming once lines are past the until time
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	filter := &logsFilter{
+		cancel:   cancel,
+		out:      framer,
+		fs:       fs,
+		logPath:  filepath.Join(allocdir.SharedAllocName, allocdir.LogDirName),
+		task:     req.Task,
+		logType:  req.LogType,
+		baseFile: logBaseFile(req.Task, req.LogType),
+		since:    req.Since,
+		until:    req.Until,
+		grep:     grep,
+		indexes:  make(map[int]*cachedLogIndex),
+	}
+
+	nextIdx, offset, err := logsStart(req.Origin, req.Offset)
+	if err != nil {
+		return err
+	}
+
+	// Skip the lines written before the since time
+	if !req.Since.IsZero() {
+		nextIdx, offset, err = filter.sinceStart()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Find the last matching lines before following the logs, starting from
+	// where they end
+	if req.TailLines > 0 {
+		filter.tailLines = req.TailLines
+		if err := f.streamLogs(ctx, false, nextIdx, offset, req.Task, req.LogType, fs, filter); err != nil {
+			return err
+		}
+		if !req.Follow || filter.done {
+			if err := filter.flush(); err != nil {
+				return parseFramerErr(err)
+			}
+		}
+		if err := filter.sendTail(); err != nil {
+			return parseFramerErr(err)
+		}
+		if !req.Follow || filter.done {
+			return nil
+		}
+
+		if filter.file != "" {
+			idx, _, _ := logging.ParseLogFileName(filter.baseFile, filepath.Base(filter.file))
+			nextIdx, offset = int64(idx), filter.lineOffset
+			filter.file = ""
+			filter.line = nil
+		}
+	}
+
+	if err := f.streamLogs(ctx, req.Follow, nextIdx, offset, req.Task, req.LogType, fs, filter); err != nil {
+		return err
+	}
+
+	// The last line of the logs may not end with a new line
+	if !req.Follow {
+		return parseFramerErr(filter.flush())
+	}
+	return nil
+}
+
+// logLine is a line of a log file kept to be sent later.
+type logLine struct {
+	file string
+	data []byte
+
+	// offset is the offset of the end of the line in the file
+	offset int64
+}
+
+// cachedLogIndex is a loaded timestamp index of a log file.
+type cachedLogIndex struct {
+	index    logging.LogIndex
+	loadedAt time.Time
+}
+
+// logsFilter is a frameSender splitting the streamed content of log files into
+// lines, and sending the lines matching its filters to another frameSender.
+type logsFilter struct {
+	// cancel stops streaming once the lines are past the until time
+	cancel context.CancelFunc
+
+	out      frameSender
+	fs       allocdir.AllocDirFS
+	logPath  string
+	task     string
+	logType  string
+	baseFile string
+
+	since time.Time
+	until time.Time
+	grep  *regexp.Regexp
+
+	// tailLines is the number of last matching lines kept in tail instead
+	// of being sent, when positive
+	tailLines int
+	tail      []logLine
+
+	// file is the file being streamed, line is the partial line read from it
+	// and lineOffset is the offset of the line in the file
+	file       string
+	line       []byte
+	lineOffset int64
+
+	// done is set once the lines are past the until time
+	done bool
+
+	// indexes are the timestamp indexes of the log files by file index
+	indexes map[int]*cachedLogIndex
+}
+
+// Send splits the data read from a log file into lines and filters them.
+func (l *logsFilter) Send(file, fileEvent string, data []byte, offset int64) error {
+	if l.done {
+		return nil
+	}
+
+	// A truncated file is streamed again from its start
+	if fileEvent == truncateEvent {
+		l.line = nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	// The last line of a rotated file ends with it
+	if file != l.file {
+		if err := l.flush(); err != nil {
+			return err
+		}
+		l.file = file
+	}
+
+	if len(l.line) == 0 {
+		l.lineOffset = offset - int64(len(data))
+	}
+	for len(data) > 0 && !l.done {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			l.line = append(l.line, data...)
+			break
+		}
+
+		l.line = append(l.line, data[:i+1]...)
+		data = data[i+1:]
+		if err := l.flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExitCh returns the exit channel of the frameSender lines are sent to.
+func (l *logsFilter) ExitCh() <-chan struct{} {
+	return l.out.ExitCh()
+}
+
+// flush filters the partial line as a complete line.
+func (l *logsFilter) flush() error {
+	if len(l.line) == 0 || l.done {
+		return nil
+	}
+
+	line, offset := l.line, l.lineOffset
+	l.line = nil
+	l.lineOffset += int64(len(line))
+	return l.filter(line, offset)
+}
+
+// filter sends or keeps the line starting at the offset of the current file
+// if it matches the filters.
+func (l *logsFilter) filter(line []byte, offset int64) error {
+	// Lines whose time is unknown, such as lines of files written before the
+	// timestamp indexes were, aren't filtered by time
+	if !l.since.IsZero() || !l.until.IsZero() {
+		t := l.lineTime(offset)
+		if !t.IsZero() && !l.until.IsZero() && t.After(l.until) {
+			// The following lines are written later
+			l.done = true
+			l.cancel()
+			return nil
+		}
+		if !t.IsZero() && t.Before(l.since) {
+			return nil
+		}
+	}
+
+	if l.grep != nil && !l.grep.Match(bytes.TrimSuffix(line, []byte{'\n'})) {
+		return nil
+	}
+
+	end := offset + int64(len(line))
+	if l.tailLines > 0 {
+		l.tail = append(l.tail, logLine{file: l.file, data: line, offset: end})
+
+		// Drop the older lines once in a while
+		if len(l.tail) >= 2*l.tailLines {
+			l.tail = append(l.tail[:0], l.tail[len(l.tail)-l.tailLines:]...)
+		}
+		return nil
+	}
+	return l.out.Send(l.file, "", line, end)
+}
+
+// sendTail sends the last matching lines kept and stops keeping lines.
+func (l *logsFilter) sendTail() error {
+	tail := l.tail
+	if len(tail) > l.tailLines {
+		tail = tail[len(tail)-l.tailLines:]
+	}
+	l.tail = nil
+	l.tailLines = 0
+
+	for _, line := range tail {
+		if err := l.out.Send(line.file, "", line.data, line.offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lineTime returns the approximate time the line at the offset of the
+// current file was written at, or the zero time if unknown.
+func (l *logsFilter) lineTime(offset int64) time.Time {
+	idx, _, ok := logging.ParseLogFileName(l.baseFile, filepath.Base(l.file))
+	if !ok {
+		return time.Time{}
+	}
+
+	cached, ok := l.indexes[idx]
+	if !ok || (past(cached.index, offset) && time.Since(cached.loadedAt) >= logIndexReloadRate) {
+		cached = &cachedLogIndex{index: l.loadIndex(idx), loadedAt: time.Now()}
+		l.indexes[idx] = cached
+	}
+	return cached.index.Time(offset)
+}
+
+// past returns whether the offset is past the last entry of the index, whose
+// next entries may not have been loaded yet.
+func past(index logging.LogIndex, offset int64) bool {
+	return len(index) == 0 || offset > index[len(index)-1].Offset
+}
+
+// loadIndex returns the timestamp index of the log file with the given index.
+// Missing or unreadable indexes are empty.
+func (l *logsFilter) loadIndex(idx int) logging.LogIndex {
+	r, err := l.fs.ReadAt(filepath.Join(l.logPath, logging.IndexFileName(l.baseFile, idx)), 0)
+	if err != nil {
+		return nil
+	}
+	defer r.Close()
+
+	index, err := logging.ReadLogIndex(r)
+	if err != nil {
+		return nil
+	}
+	return index
+}
+
+// sinceStart returns the index of the log file to start streaming from and
+// the offset in it, skipping the files and lines written before the since
+// time.
+func (l *logsFilter) sinceStart() (int64, int64, error) {
+	entries, err := l.fs.List(l.logPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, nil
+		}
+		return 0, 0, err
+	}
+	indexes, err := logIndexes(entries, l.task, l.logType)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Start from the last file whose first line was written before the
+	// since time
+	var nextIdx, offset int64
+	for _, t := range indexes {
+		index := l.loadIndex(int(t.idx))
+		if len(index) == 0 || index[0].Time.After(l.since) {
+			continue
+		}
+		if t.idx >= nextIdx {
+			nextIdx, offset = t.idx, index.Offset(l.since)
+		}
+	}
+	return nextIdx, offset, nil
+}
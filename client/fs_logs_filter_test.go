@@ -0,0 +1,229 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/allocdir"
+	sframer "github.com/hashicorp/nomad/client/lib/streamframer"
+	"github.com/hashicorp/nomad/client/logmon/logging"
+	cstructs "github.com/hashicorp/nomad/client/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLogIndex writes the timestamp index of a log file, with an entry at
+// each offset written 10 seconds apart from the start time.
+func writeLogIndex(t *testing.T, logDir string, idx int, start time.Time, offsets ...int64) {
+	var b strings.Builder
+	for i, offset := range offsets {
+		at := start.Add(time.Duration(i) * 10 * time.Second)
+		fmt.Fprintf(&b, "%d %d\n", at.UnixNano(), offset)
+	}
+	name := filepath.Join(logDir, logging.IndexFileName("foo.stdout", idx))
+	require.NoError(t, ioutil.WriteFile(name, []byte(b.String()), 0777))
+}
+
+// receiveFrames returns a channel receiving the data of the frames once they
+// are closed.
+func receiveFrames(frames <-chan *sframer.StreamFrame) <-chan []byte {
+	doneCh := make(chan []byte, 1)
+	go func() {
+		var received []byte
+		for frame := range frames {
+			if !frame.IsHeartbeat() {
+				received = append(received, frame.Data...)
+			}
+		}
+		doneCh <- received
+	}()
+	return doneCh
+}
+
+func TestFS_filteredLogsImpl(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	// Create a compressed rotated file and the file being written, whose
+	// last line isn't terminated yet
+	var gz bytes.Buffer
+	gw := gzip.NewWriter(&gz)
+	_, err := gw.Write([]byte("info one\nerror two\n"))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	files := map[string][]byte{
+		"foo.stdout.0.gz": gz.Bytes(),
+		"foo.stdout.1":    []byte("info three\nerror four\ninfo five"),
+	}
+	for name, data := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(logDir, name), data, 0777))
+	}
+
+	// Lines are written 10 seconds apart
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Second)
+	writeLogIndex(t, logDir, 0, t0, 0, 9)
+	writeLogIndex(t, logDir, 1, t0.Add(20*time.Second), 0, 11, 22)
+
+	cases := []struct {
+		name     string
+		since    time.Duration
+		until    time.Duration
+		grep     string
+		tail     int
+		expected string
+	}{
+		{
+			name:     "grep",
+			grep:     "^error",
+			expected: "error two\nerror four\n",
+		},
+		{
+			name:     "since before file",
+			since:    15 * time.Second,
+			expected: "info three\nerror four\ninfo five",
+		},
+		{
+			name:     "since within file",
+			since:    30 * time.Second,
+			expected: "error four\ninfo five",
+		},
+		{
+			name:     "until",
+			until:    25 * time.Second,
+			expected: "info one\nerror two\ninfo three\n",
+		},
+		{
+			name:     "since and until",
+			since:    5 * time.Second,
+			until:    30 * time.Second,
+			expected: "error two\ninfo three\nerror four\n",
+		},
+		{
+			name:     "tail lines",
+			tail:     2,
+			expected: "error four\ninfo five",
+		},
+		{
+			name:     "tail lines matching",
+			grep:     "info",
+			tail:     2,
+			expected: "info three\ninfo five",
+		},
+		{
+			name:     "since and grep",
+			since:    5 * time.Second,
+			grep:     "error",
+			expected: "error two\nerror four\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			frames := make(chan *sframer.StreamFrame, 32)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			req := &cstructs.FsLogsRequest{
+				Task:      "foo",
+				LogType:   "stdout",
+				Origin:    OriginStart,
+				TailLines: tc.tail,
+			}
+			if tc.since != 0 {
+				req.Since = t0.Add(tc.since)
+			}
+			if tc.until != 0 {
+				req.Until = t0.Add(tc.until)
+			}
+			var grep *regexp.Regexp
+			if tc.grep != "" {
+				grep = regexp.MustCompile(tc.grep)
+			}
+
+			doneCh := receiveFrames(frames)
+			require.NoError(t, c.endpoints.FileSystem.filteredLogsImpl(ctx, req, grep, ad, frames))
+			require.Equal(t, tc.expected, string(<-doneCh))
+		})
+	}
+}
+
+func TestFS_filteredLogsImpl_FollowTail(t *testing.T) {
+	ci.Parallel(t)
+
+	c, cleanup := TestClient(t, nil)
+	defer cleanup()
+
+	// Get a temp alloc dir and create the log dir
+	ad := tempAllocDir(t)
+	require.NoError(t, ad.Build())
+	defer ad.Destroy()
+
+	logDir := filepath.Join(ad.SharedDir, allocdir.LogDirName)
+	require.NoError(t, os.MkdirAll(logDir, 0777))
+
+	logFile := filepath.Join(logDir, "foo.stdout.0")
+	require.NoError(t, ioutil.WriteFile(logFile, []byte("one\ntwo\nthr"), 0777))
+
+	frames := make(chan *sframer.StreamFrame, 32)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := &cstructs.FsLogsRequest{
+		Task:      "foo",
+		LogType:   "stdout",
+		Origin:    OriginStart,
+		Follow:    true,
+		TailLines: 1,
+	}
+
+	var received []byte
+	var receivedLock sync.Mutex
+	go func() {
+		for frame := range frames {
+			receivedLock.Lock()
+			received = append(received, frame.Data...)
+			receivedLock.Unlock()
+		}
+	}()
+	go c.endpoints.FileSystem.filteredLogsImpl(ctx, req, nil, ad, frames)
+
+	// The last complete line is sent, and the partial line once complete
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0777)
+	require.NoError(t, err)
+	defer f.Close()
+	time.Sleep(500 * time.Millisecond)
+	_, err = f.Write([]byte("ee\nfour\n"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		receivedLock.Lock()
+		defer receivedLock.Unlock()
+		if string(received) != "two\nthree\nfour\n" {
+			return false, fmt.Errorf("expected %q, got %q", "two\nthree\nfour\n", received)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/hashicorp/nomad/client/lib/numa"
 	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
@@ -60,7 +61,36 @@ type TaskCgroupInfo struct {
 	CgroupPath         string
 	RelativeCgroupPath string
 	Cpuset             cpuset.CPUSet
-	Error              error
+	// Mems is the set of NUMA nodes the task's memory is bound to. If empty
+	// the task uses the memory nodes of its parent cgroup.
+	Mems  cpuset.CPUSet
+	Error error
+}
+
+// numaAffinity returns the NUMA affinity of the task of the allocation.
+func numaAffinity(alloc *structs.Allocation, task string) string {
+	if alloc.Job == nil {
+		return structs.NUMAAffinityNone
+	}
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil {
+		return structs.NUMAAffinityNone
+	}
+	t := tg.LookupTask(task)
+	if t == nil || t.Resources == nil {
+		return structs.NUMAAffinityNone
+	}
+	return t.Resources.NUMA.GetAffinity()
+}
+
+// numaMems returns the NUMA nodes the memory of a task with the reserved
+// cores should be bound to, which is empty unless the task has a NUMA
+// affinity.
+func numaMems(topology numa.Topology, alloc *structs.Allocation, task string, cores cpuset.CPUSet) cpuset.CPUSet {
+	if cores.Size() == 0 || numaAffinity(alloc, task) == structs.NUMAAffinityNone {
+		return cpuset.New()
+	}
+	return topology.NodesOf(cores)
 }
 
 // identity is the "<allocID>.<taskName>" string that uniquely identifies an
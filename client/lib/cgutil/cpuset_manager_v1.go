@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/numa"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -52,6 +53,10 @@ type cpusetManagerV1 struct {
 
 	parentCpuset cpuset.CPUSet
 
+	// topology is the NUMA topology of the host, used to bind the memory of
+	// tasks with a NUMA affinity to the nodes of their reserved cores.
+	topology numa.Topology
+
 	// all exported functions are synchronized
 	mu sync.Mutex
 
@@ -78,6 +83,7 @@ func (c *cpusetManagerV1) AddAlloc(alloc *structs.Allocation) {
 			CgroupPath:         cgroupPath,
 			RelativeCgroupPath: relativeCgroupPath,
 			Cpuset:             taskCpuset,
+			Mems:               numaMems(c.topology, alloc, task, taskCpuset),
 		}
 	}
 	c.mu.Lock()
@@ -175,6 +181,12 @@ func (c *cpusetManagerV1) Init(_ []uint16) error {
 		return err
 	}
 
+	c.topology, err = numa.Scan()
+	if err != nil {
+		c.logger.Warn("failed to detect NUMA topology", "error", err)
+		c.topology = numa.Topology{}
+	}
+
 	c.doneCh = make(chan struct{})
 	c.signalCh = make(chan struct{})
 
@@ -255,15 +267,19 @@ func (c *cpusetManagerV1) reconcileCpusets() {
 			continue
 		}
 
-		// copy cpuset.mems from parent
-		_, parentMems, err := getCpusetSubsystemSettingsV1(filepath.Dir(info.CgroupPath))
-		if err != nil {
-			c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
-			info.Error = err
-			continue
+		// bind memory to the NUMA nodes of the task, or copy cpuset.mems from parent
+		mems := info.Mems.String()
+		if info.Mems.Size() == 0 {
+			_, parentMems, err := getCpusetSubsystemSettingsV1(filepath.Dir(info.CgroupPath))
+			if err != nil {
+				c.logger.Error("failed to read parent cgroup settings for task", "path", info.CgroupPath, "error", err)
+				info.Error = err
+				continue
+			}
+			mems = parentMems
 		}
-		if err := cgroups.WriteFile(info.CgroupPath, "cpuset.mems", parentMems); err != nil {
-			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", parentMems, "error", err)
+		if err := cgroups.WriteFile(info.CgroupPath, "cpuset.mems", mems); err != nil {
+			c.logger.Error("failed to write cgroup cpuset.mems setting for task", "path", info.CgroupPath, "mems", mems, "error", err)
 			info.Error = err
 			continue
 		}
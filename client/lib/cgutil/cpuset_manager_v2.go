@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/lib/numa"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/lib/cpuset"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -49,11 +50,13 @@ type cpusetManagerV2 struct {
 	parent    string        // relative to cgroup root (e.g. "nomad.slice")
 	parentAbs string        // absolute path (e.g. "/sys/fs/cgroup/nomad.slice")
 	initial   cpuset.CPUSet // set of initial cores (never changes)
+	topology  numa.Topology // NUMA topology of the host (never changes)
 
 	lock      sync.Mutex                 // hold this when managing pool / sharing / isolating
 	pool      cpuset.CPUSet              // pool of cores being shared among all tasks
 	sharing   map[identity]nothing       // sharing tasks using cores only from the pool
 	isolating map[identity]cpuset.CPUSet // isolating tasks using cores from the pool + reserved cores
+	mems      map[identity]cpuset.CPUSet // NUMA nodes the memory of isolating tasks is bound to
 }
 
 func NewCpusetManagerV2(parent string, logger hclog.Logger) CpusetManager {
@@ -63,6 +66,7 @@ func NewCpusetManagerV2(parent string, logger hclog.Logger) CpusetManager {
 		logger:    logger,
 		sharing:   make(map[identity]nothing),
 		isolating: make(map[identity]cpuset.CPUSet),
+		mems:      make(map[identity]cpuset.CPUSet),
 	}
 }
 
@@ -73,6 +77,13 @@ func (c *cpusetManagerV2) Init(cores []uint16) error {
 		return err
 	}
 	c.initial = cpuset.New(cores...)
+
+	topology, err := numa.Scan()
+	if err != nil {
+		c.logger.Warn("failed to detect NUMA topology", "err", err)
+		topology = numa.Topology{}
+	}
+	c.topology = topology
 	return nil
 }
 
@@ -90,7 +101,11 @@ func (c *cpusetManagerV2) AddAlloc(alloc *structs.Allocation) {
 	for task, resources := range alloc.AllocatedResources.Tasks {
 		id := makeID(alloc.ID, task)
 		if len(resources.Cpu.ReservedCores) > 0 {
-			c.isolating[id] = cpuset.New(resources.Cpu.ReservedCores...)
+			cores := cpuset.New(resources.Cpu.ReservedCores...)
+			c.isolating[id] = cores
+			if mems := numaMems(c.topology, alloc, task, cores); mems.Size() > 0 {
+				c.mems[id] = mems
+			}
 		} else {
 			c.sharing[id] = present
 		}
@@ -123,6 +138,7 @@ func (c *cpusetManagerV2) RemoveAlloc(allocID string) {
 	for id := range c.isolating {
 		if strings.HasPrefix(string(id), allocID) {
 			delete(c.isolating, id)
+			delete(c.mems, id)
 		}
 	}
 
@@ -180,11 +196,11 @@ func (c *cpusetManagerV2) recalculate() {
 // must be called while holding c.lock
 func (c *cpusetManagerV2) reconcile() {
 	for id := range c.sharing {
-		c.write(id, c.pool)
+		c.write(id, c.pool, cpuset.New())
 	}
 
 	for id, set := range c.isolating {
-		c.write(id, c.pool.Union(set))
+		c.write(id, c.pool.Union(set), c.mems[id])
 	}
 }
 
@@ -262,8 +278,9 @@ func (c *cpusetManagerV2) remove(path string) {
 	}
 }
 
-// write does the actual write of cpuset set for cgroup id
-func (c *cpusetManagerV2) write(id identity, set cpuset.CPUSet) {
+// write does the actual write of cpuset set for cgroup id, binding its memory
+// to the mems NUMA nodes if not empty
+func (c *cpusetManagerV2) write(id identity, set, mems cpuset.CPUSet) {
 	path := c.pathOf(id)
 
 	// make a manager for the cgroup
@@ -278,9 +295,13 @@ func (c *cpusetManagerV2) write(id identity, set cpuset.CPUSet) {
 	}
 
 	// set the cpuset value for the cgroup
-	if err = m.Set(&configs.Resources{
+	resources := &configs.Resources{
 		CpusetCpus: set.String(),
-	}); err != nil {
+	}
+	if mems.Size() > 0 {
+		resources.CpusetMems = mems.String()
+	}
+	if err = m.Set(resources); err != nil {
 		c.logger.Error("failed to set cgroup", "path", path, "err", err)
 	}
 }
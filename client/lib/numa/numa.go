@@ -0,0 +1,65 @@
+// Package numa discovers the NUMA topology of the host, which is used to keep
+// the reserved cores and memory of tasks within a single NUMA node.
+package numa
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/lib/cpuset"
+)
+
+// Topology maps the IDs of the NUMA nodes of the host to the set of cores they
+// contain.
+type Topology map[uint16]cpuset.CPUSet
+
+// NodesOf returns the IDs of the NUMA nodes containing any of the cores, as a
+// set usable for cpuset.mems.
+func (t Topology) NodesOf(cores cpuset.CPUSet) cpuset.CPUSet {
+	nodes := cpuset.New()
+	for id, nodeCores := range t {
+		if nodeCores.ContainsAny(cores) {
+			nodes = nodes.Union(cpuset.New(id))
+		}
+	}
+	return nodes
+}
+
+// scan reads the topology from a sysfs node directory, which contains a
+// "node<id>" directory with a "cpulist" file for each NUMA node. A missing
+// directory results in an empty topology, as the host doesn't expose NUMA
+// nodes.
+func scan(root string) (Topology, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return Topology{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list NUMA nodes: %v", err)
+	}
+
+	topology := make(Topology)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || !strings.HasPrefix(name, "node") {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimPrefix(name, "node"), 10, 16)
+		if err != nil {
+			continue
+		}
+
+		raw, err := os.ReadFile(filepath.Join(root, name, "cpulist"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cores of NUMA node %d: %v", id, err)
+		}
+		cores, err := cpuset.Parse(string(raw))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cores of NUMA node %d: %v", id, err)
+		}
+		topology[uint16(id)] = cores
+	}
+	return topology, nil
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package numa
+
+// Scan returns the NUMA topology of the host, which is only discovered on
+// Linux.
+func Scan() (Topology, error) {
+	return Topology{}, nil
+}
@@ -0,0 +1,11 @@
+//go:build linux
+
+package numa
+
+// sysfsNodePath is the sysfs directory listing the NUMA nodes of the host.
+const sysfsNodePath = "/sys/devices/system/node"
+
+// Scan returns the NUMA topology of the host.
+func Scan() (Topology, error) {
+	return scan(sysfsNodePath)
+}
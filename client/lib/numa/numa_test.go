@@ -0,0 +1,42 @@
+package numa
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/lib/cpuset"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScan(t *testing.T) {
+	ci.Parallel(t)
+
+	root := t.TempDir()
+	for name, cpulist := range map[string]string{
+		"node0": "0-3,8-11\n",
+		"node1": "4-7,12-15\n",
+	} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, name, "cpulist"), []byte(cpulist), 0644))
+	}
+
+	// Other entries of the sysfs directory are ignored
+	require.NoError(t, os.Mkdir(filepath.Join(root, "power"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "online"), []byte("0-1\n"), 0644))
+
+	topology, err := scan(root)
+	require.NoError(t, err)
+	require.Len(t, topology, 2)
+	require.Equal(t, []uint16{0, 1, 2, 3, 8, 9, 10, 11}, topology[0].ToSlice())
+	require.Equal(t, []uint16{4, 5, 6, 7, 12, 13, 14, 15}, topology[1].ToSlice())
+
+	require.Equal(t, []uint16{1}, topology.NodesOf(cpuset.New(5, 6)).ToSlice())
+	require.Equal(t, []uint16{0, 1}, topology.NodesOf(cpuset.New(3, 4)).ToSlice())
+
+	// A host without NUMA nodes has an empty topology
+	topology, err = scan(filepath.Join(root, "missing"))
+	require.NoError(t, err)
+	require.Empty(t, topology)
+}
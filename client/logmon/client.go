@@ -26,6 +26,29 @@ func (c *logmonClient) Start(cfg *LogConfig) error {
 		MaxFileSizeMb:  uint32(cfg.MaxFileSizeMB),
 		StdoutFifo:     cfg.StdoutFifo,
 		StderrFifo:     cfg.StderrFifo,
+		AllocId:        cfg.AllocID,
+		JobId:          cfg.JobID,
+		Namespace:      cfg.Namespace,
+		TaskGroup:      cfg.TaskGroup,
+		TaskName:       cfg.TaskName,
+		RotateEvery:    int64(cfg.RotateEvery),
+		Compression:    cfg.Compression,
+		MaxTotalSizeMb: uint32(cfg.MaxTotalSizeMB),
+		MaxAge:         int64(cfg.MaxAge),
+	}
+	for _, sink := range cfg.Sinks {
+		req.Sinks = append(req.Sinks, &proto.LogSink{
+			Name:      sink.Name,
+			Type:      sink.Type,
+			Address:   sink.Address,
+			Facility:  sink.Facility,
+			Tag:       sink.Tag,
+			Path:      sink.Path,
+			Endpoint:  sink.Endpoint,
+			Headers:   sink.Headers,
+			BatchSize: uint32(sink.BatchSize),
+			BatchWait: int64(sink.BatchWait),
+		})
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), logmonRPCTimeout)
 	defer cancel()
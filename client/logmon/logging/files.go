@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// CompressionNone keeps the rotated log files uncompressed.
+	CompressionNone = "none"
+
+	// CompressionGzip compresses the rotated log files with gzip.
+	CompressionGzip = "gzip"
+
+	// CompressionZstd compresses the rotated log files with zstd.
+	CompressionZstd = "zstd"
+)
+
+// compressionExts maps the compression algorithms to the extension of the
+// compressed log files.
+var compressionExts = map[string]string{
+	CompressionGzip: ".gz",
+	CompressionZstd: ".zst",
+}
+
+// sizeSuffix is the suffix of the files recording the uncompressed size of
+// the compressed log files.
+const sizeSuffix = ".size"
+
+// LogFileName returns the name of the rotated log file with the given index,
+// compressed with the given algorithm.
+func LogFileName(baseFile string, idx int, compression string) string {
+	return fmt.Sprintf("%s.%d%s", baseFile, idx, compressionExts[compression])
+}
+
+// SizeFileName returns the name of the hidden file recording the size of the
+// uncompressed content of the compressed log file with the given index, so
+// that readers don't have to decompress the file to find it.
+func SizeFileName(baseFile string, idx int) string {
+	return fmt.Sprintf(".%s.%d%s", baseFile, idx, sizeSuffix)
+}
+
+// ReadSize reads the uncompressed size recorded in a size file.
+func ReadSize(r io.Reader) (int64, error) {
+	buf, err := ioutil.ReadAll(io.LimitReader(r, 32))
+	if err != nil {
+		return 0, err
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(string(buf)), 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size %q", buf)
+	}
+	return size, nil
+}
+
+// ParseLogFileName returns the index and compression algorithm of a rotated
+// log file with the given base file name. Returns false if the name isn't the
+// name of one of its log files.
+func ParseLogFileName(baseFile, name string) (int, string, bool) {
+	suffix := strings.TrimPrefix(name, baseFile+".")
+	if suffix == name {
+		return 0, "", false
+	}
+
+	compression := CompressionNone
+	for c, ext := range compressionExts {
+		if strings.HasSuffix(suffix, ext) {
+			compression = c
+			suffix = strings.TrimSuffix(suffix, ext)
+			break
+		}
+	}
+
+	idx, err := strconv.Atoi(suffix)
+	if err != nil || idx < 0 {
+		return 0, "", false
+	}
+	return idx, compression, true
+}
+
+// NewDecompressor returns a reader of the uncompressed content of a log file
+// compressed with the given algorithm. Closing the reader doesn't close r.
+func NewDecompressor(r io.Reader, compression string) (io.ReadCloser, error) {
+	switch compression {
+	case CompressionNone, "":
+		return ioutil.NopCloser(r), nil
+	case CompressionGzip:
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		return zr, nil
+	case CompressionZstd:
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return dec.IOReadCloser(), nil
+	default:
+		return nil, fmt.Errorf("unknown log compression %q", compression)
+	}
+}
+
+// newCompressor returns a writer compressing the content written to w with
+// the given algorithm. The writer must be closed to flush the content.
+func newCompressor(w io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case CompressionGzip:
+		return gzip.NewWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unknown log compression %q", compression)
+	}
+}
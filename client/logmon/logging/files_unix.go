@@ -0,0 +1,11 @@
+//go:build !windows
+// +build !windows
+
+package logging
+
+import "syscall"
+
+// openNoFollow makes opening a file fail if it is a symlink. The log
+// directory is writable by the task, which could otherwise redirect the
+// writes of the client to any file.
+const openNoFollow = syscall.O_NOFOLLOW
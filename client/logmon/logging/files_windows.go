@@ -0,0 +1,8 @@
+//go:build windows
+// +build windows
+
+package logging
+
+// openNoFollow makes opening a file fail if it is a symlink. Windows has no
+// such flag, and tasks need a privilege to create symlinks.
+const openNoFollow = 0
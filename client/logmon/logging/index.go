@@ -0,0 +1,98 @@
+package logging
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// indexInterval is the minimum interval between the entries of the
+	// timestamp index of a log file.
+	indexInterval = 1 * time.Second
+
+	// indexSuffix is the suffix of the timestamp index files.
+	indexSuffix = ".idx"
+)
+
+// IndexEntry is an entry of the timestamp index of a log file, recording that
+// the bytes of the file from Offset onwards were written at or after Time.
+type IndexEntry struct {
+	Time   time.Time
+	Offset int64
+}
+
+// LogIndex is the timestamp index written alongside a log file, sorted by
+// offset. Entries are written at most every second, so the times derived from
+// it are approximate.
+type LogIndex []IndexEntry
+
+// IndexFileName returns the name of the hidden file holding the timestamp
+// index of the rotated log file with the given index. Compressing the log file
+// doesn't change its index file, as offsets are offsets in the uncompressed
+// content.
+func IndexFileName(baseFile string, idx int) string {
+	return fmt.Sprintf(".%s.%d%s", baseFile, idx, indexSuffix)
+}
+
+// formatIndexEntry returns the line of an index file recording an entry.
+func formatIndexEntry(e IndexEntry) string {
+	return fmt.Sprintf("%d %d\n", e.Time.UnixNano(), e.Offset)
+}
+
+// ReadLogIndex reads the entries of an index file. Malformed lines, such as a
+// line partially written when the logger was killed, are skipped.
+func ReadLogIndex(r io.Reader) (LogIndex, error) {
+	var index LogIndex
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		nanos, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		offset, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || offset < 0 {
+			continue
+		}
+		index = append(index, IndexEntry{Time: time.Unix(0, nanos), Offset: offset})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Entries are appended in order, but a restarted logger appends to the
+	// index of the file it resumes writing to
+	sort.SliceStable(index, func(i, j int) bool { return index[i].Offset < index[j].Offset })
+	return index, nil
+}
+
+// Time returns the approximate time the byte at the given offset was written
+// at, or the zero time if the index doesn't cover the offset.
+func (i LogIndex) Time(offset int64) time.Time {
+	n := sort.Search(len(i), func(n int) bool { return i[n].Offset > offset })
+	if n == 0 {
+		return time.Time{}
+	}
+	return i[n-1].Time
+}
+
+// Offset returns the offset before which every byte was written before the
+// given time, so that reading from it skips no byte written at or after it.
+func (i LogIndex) Offset(t time.Time) int64 {
+	var offset int64
+	for _, e := range i {
+		if e.Time.After(t) {
+			break
+		}
+		offset = e.Offset
+	}
+	return offset
+}
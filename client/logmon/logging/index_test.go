@@ -0,0 +1,42 @@
+package logging
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogIndex(t *testing.T) {
+	t0 := time.Unix(100, 0)
+	input := strings.Join([]string{
+		"100000000000 0",
+		"102000000000 40",
+		"malformed",
+		"101000000000 10",
+		"103000000000 -1",
+		"104000000000 90",
+		"1050000",
+	}, "\n")
+
+	index, err := ReadLogIndex(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, LogIndex{
+		{Time: t0, Offset: 0},
+		{Time: t0.Add(time.Second), Offset: 10},
+		{Time: t0.Add(2 * time.Second), Offset: 40},
+		{Time: t0.Add(4 * time.Second), Offset: 90},
+	}, index)
+
+	require.Equal(t, t0, index.Time(0))
+	require.Equal(t, t0, index.Time(9))
+	require.Equal(t, t0.Add(time.Second), index.Time(10))
+	require.Equal(t, t0.Add(4*time.Second), index.Time(1000))
+	require.True(t, LogIndex(nil).Time(10).IsZero())
+
+	require.Equal(t, int64(0), index.Offset(t0.Add(-time.Second)))
+	require.Equal(t, int64(10), index.Offset(t0.Add(1500*time.Millisecond)))
+	require.Equal(t, int64(40), index.Offset(t0.Add(3*time.Second)))
+	require.Equal(t, int64(90), index.Offset(t0.Add(time.Hour)))
+}
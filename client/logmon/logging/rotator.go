@@ -3,7 +3,9 @@ package logging
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -31,22 +33,75 @@ const (
 
 	// newLineDelimiter is the delimiter used for new lines.
 	newLineDelimiter = '\n'
+
+	// purgeInterval is the interval at which files older than the maximum
+	// age are purged, in addition to purging them on rotation.
+	purgeInterval = 1 * time.Minute
+
+	// compressBufferSize is the size of the reads from the files being
+	// compressed.
+	compressBufferSize = 32 * 1024
+
+	// compressTmpSuffix is the suffix of the temporary files rotated files are
+	// compressed into.
+	compressTmpSuffix = ".tmp"
 )
 
+// errRotatorClosed is returned when the rotator is closed while compressing a
+// file.
+var errRotatorClosed = errors.New("rotator closed")
+
+// RotatorOptions are the rotation and retention policies of a FileRotator in
+// addition to its maximum file size and number of files.
+type RotatorOptions struct {
+	// RotateEvery is the interval after which the current file is rotated
+	// on the next write, whatever its size. Zero disables time based
+	// rotation.
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated files are compressed with.
+	Compression string
+
+	// MaxTotalSize is the maximum total size in bytes of the files on disk.
+	// Zero disables the limit.
+	MaxTotalSize int64
+
+	// MaxAge is the maximum time since a rotated file was last written to.
+	// Zero disables the limit.
+	MaxAge time.Duration
+}
+
 // FileRotator writes bytes to a rotated set of files
 type FileRotator struct {
 	MaxFiles int   // MaxFiles is the maximum number of rotated files allowed in a path
 	FileSize int64 // FileSize is the size a rotated file is allowed to grow
 
+	opts RotatorOptions // opts are the rotation and retention policies
+
 	path             string // path is the path on the file system where the rotated set of files are opened
 	baseFileName     string // baseFileName is the base file name of the rotated files
 	logFileIdx       int    // logFileIdx is the current index of the rotated files
 	oldestLogFileIdx int    // oldestLogFileIdx is the index of the oldest log file in a path
 
-	currentFile *os.File // currentFile is the file that is currently getting written
-	currentWr   int64    // currentWr is the number of bytes written to the current file
-	bufw        *bufio.Writer
-	bufLock     sync.Mutex
+	currentFile   *os.File  // currentFile is the file that is currently getting written
+	currentWr     int64     // currentWr is the number of bytes written to the current file
+	currentOpened time.Time // currentOpened is the time the current file was opened
+	bufw          *bufio.Writer
+	bufLock       sync.Mutex
+
+	indexFile   *os.File  // indexFile is the timestamp index of the current file
+	lastIndexed time.Time // lastIndexed is the time of the last index entry
+
+	// filesLock serializes the removal of purged files with the replacement
+	// of rotated files by their compressed version
+	filesLock sync.Mutex
+
+	// compressQueue is the indexes of the rotated files to compress, and
+	// compressCh notifies the compressing goroutine of new indexes
+	compressQueue  []int
+	compressLock   sync.Mutex
+	compressCh     chan struct{}
+	compressDoneCh chan struct{}
 
 	flushTicker *time.Ticker
 	logger      hclog.Logger
@@ -60,6 +115,13 @@ type FileRotator struct {
 // NewFileRotator returns a new file rotator
 func NewFileRotator(path string, baseFile string, maxFiles int,
 	fileSize int64, logger hclog.Logger) (*FileRotator, error) {
+	return NewFileRotatorWithOptions(path, baseFile, maxFiles, fileSize, nil, logger)
+}
+
+// NewFileRotatorWithOptions returns a new file rotator with the given rotation
+// and retention policies.
+func NewFileRotatorWithOptions(path string, baseFile string, maxFiles int,
+	fileSize int64, opts *RotatorOptions, logger hclog.Logger) (*FileRotator, error) {
 	logger = logger.Named("rotator")
 	rotator := &FileRotator{
 		MaxFiles: maxFiles,
@@ -68,17 +130,37 @@ func NewFileRotator(path string, baseFile string, maxFiles int,
 		path:         path,
 		baseFileName: baseFile,
 
-		flushTicker: time.NewTicker(bufferFlushDuration),
-		logger:      logger,
-		purgeCh:     make(chan struct{}, 1),
-		doneCh:      make(chan struct{}),
+		compressCh:     make(chan struct{}, 1),
+		compressDoneCh: make(chan struct{}),
+		flushTicker:    time.NewTicker(bufferFlushDuration),
+		logger:         logger,
+		purgeCh:        make(chan struct{}, 1),
+		doneCh:         make(chan struct{}),
+	}
+	if opts != nil {
+		rotator.opts = *opts
+	}
+
+	switch rotator.opts.Compression {
+	case "":
+		rotator.opts.Compression = CompressionNone
+	case CompressionNone, CompressionGzip, CompressionZstd:
+	default:
+		rotator.flushTicker.Stop()
+		return nil, fmt.Errorf("unknown log compression %q", rotator.opts.Compression)
 	}
 
 	if err := rotator.lastFile(); err != nil {
+		rotator.flushTicker.Stop()
 		return nil, err
 	}
 	go rotator.purgeOldFiles()
 	go rotator.flushPeriodically()
+	if rotator.opts.Compression != CompressionNone {
+		go rotator.compressFiles()
+	} else {
+		close(rotator.compressDoneCh)
+	}
 	return rotator, nil
 }
 
@@ -91,7 +173,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	for n < len(p) {
 		// Check if we still have space in the current file, otherwise close and
 		// open the next file
-		if forceRotate || f.currentWr >= f.FileSize {
+		if forceRotate || f.currentWr >= f.FileSize || f.rotateDue() {
 			forceRotate = false
 			f.flushBuffer()
 			f.currentFile.Close()
@@ -100,6 +182,8 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 				return 0, err
 			}
 		}
+		f.writeIndex()
+
 		// Calculate the remaining size on this file and how much we have left
 		// to write
 		remainingSpace := f.FileSize - f.currentWr
@@ -142,7 +226,7 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 		n += nw
 
 		// Increment the total number of bytes in the file
-		f.currentWr += int64(n)
+		f.currentWr += int64(nw)
 		if err != nil {
 			f.logger.Error("error writing to file", "err", err)
 
@@ -157,13 +241,40 @@ func (f *FileRotator) Write(p []byte) (n int, err error) {
 	return
 }
 
-// nextFile opens the next file and purges older files if the number of rotated
-// files is larger than the maximum files configured by the user
+// writeIndex records the time the bytes written from the current offset are
+// written at in the timestamp index of the current file, at most every
+// indexInterval.
+func (f *FileRotator) writeIndex() {
+	now := time.Now()
+	if f.indexFile == nil || now.Sub(f.lastIndexed) < indexInterval {
+		return
+	}
+	f.lastIndexed = now
+	entry := formatIndexEntry(IndexEntry{Time: now, Offset: f.currentWr})
+	if _, err := f.indexFile.WriteString(entry); err != nil {
+		f.logger.Warn("error writing log index", "err", err)
+	}
+}
+
+// rotateDue returns whether the current file has been written to for longer
+// than the rotation interval.
+func (f *FileRotator) rotateDue() bool {
+	return f.opts.RotateEvery > 0 && f.currentWr > 0 &&
+		time.Since(f.currentOpened) >= f.opts.RotateEvery
+}
+
+// nextFile opens the next file, compresses the previous file and purges older
+// files if the number of rotated files is larger than the maximum files
+// configured by the user or retention limits are set
 func (f *FileRotator) nextFile() error {
+	prevFileIdx := f.logFileIdx
 	nextFileIdx := f.logFileIdx
 	for {
 		nextFileIdx += 1
-		logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, nextFileIdx))
+		if f.compressedExists(nextFileIdx) {
+			continue
+		}
+		logFileName := filepath.Join(f.path, LogFileName(f.baseFileName, nextFileIdx, CompressionNone))
 		if fi, err := os.Stat(logFileName); err == nil {
 			if fi.IsDir() || fi.Size() >= f.FileSize {
 				continue
@@ -175,18 +286,30 @@ func (f *FileRotator) nextFile() error {
 		}
 		break
 	}
+	f.compress(prevFileIdx)
+
 	// Purge old files if we have more files than MaxFiles
-	f.closedLock.Lock()
-	defer f.closedLock.Unlock()
-	if f.logFileIdx-f.oldestLogFileIdx >= f.MaxFiles && !f.closed {
-		select {
-		case f.purgeCh <- struct{}{}:
-		default:
-		}
+	f.filesLock.Lock()
+	oldestLogFileIdx := f.oldestLogFileIdx
+	f.filesLock.Unlock()
+	if f.logFileIdx-oldestLogFileIdx >= f.MaxFiles ||
+		f.opts.MaxTotalSize > 0 || f.opts.MaxAge > 0 {
+		f.triggerPurge()
 	}
 	return nil
 }
 
+// compressedExists returns whether a compressed file exists for the index.
+func (f *FileRotator) compressedExists(idx int) bool {
+	for compression := range compressionExts {
+		logFileName := filepath.Join(f.path, LogFileName(f.baseFileName, idx, compression))
+		if _, err := os.Stat(logFileName); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // lastFile finds out the rotated file with the largest index in a path.
 func (f *FileRotator) lastFile() error {
 	finfos, err := ioutil.ReadDir(f.path)
@@ -194,43 +317,86 @@ func (f *FileRotator) lastFile() error {
 		return err
 	}
 
-	prefix := fmt.Sprintf("%s.", f.baseFileName)
+	tmpPrefix := fmt.Sprintf(".%s.", f.baseFileName)
+	compressed := make(map[int]bool)
+	var uncompressed []int
 	for _, fi := range finfos {
 		if fi.IsDir() {
 			continue
 		}
-		if strings.HasPrefix(fi.Name(), prefix) {
-			fileIdx := strings.TrimPrefix(fi.Name(), prefix)
-			n, err := strconv.Atoi(fileIdx)
-			if err != nil {
-				continue
-			}
-			if n > f.logFileIdx {
-				f.logFileIdx = n
+
+		// Remove the files left over by a compression interrupted by a
+		// restart, as the rotated file is compressed again
+		if strings.HasPrefix(fi.Name(), tmpPrefix) && strings.HasSuffix(fi.Name(), compressTmpSuffix) {
+			if err := os.Remove(filepath.Join(f.path, fi.Name())); err != nil {
+				f.logger.Warn("error removing file", "filename", fi.Name(), "err", err)
 			}
+			continue
+		}
+
+		n, compression, ok := ParseLogFileName(f.baseFileName, fi.Name())
+		if !ok {
+			continue
+		}
+		if compression == CompressionNone {
+			uncompressed = append(uncompressed, n)
+		} else {
+			compressed[n] = true
 		}
+		if n > f.logFileIdx {
+			f.logFileIdx = n
+		}
+	}
+
+	// A compressed file can't be appended to
+	if compressed[f.logFileIdx] {
+		f.logFileIdx++
 	}
 	if err := f.createFile(); err != nil {
 		return err
 	}
+
+	// Compress the files rotated before a restart
+	sort.Ints(uncompressed)
+	for _, n := range uncompressed {
+		if n < f.logFileIdx {
+			f.compress(n)
+		}
+	}
 	return nil
 }
 
 // createFile opens a new or existing file for writing
 func (f *FileRotator) createFile() error {
-	logFileName := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, f.logFileIdx))
+	logFileName := filepath.Join(f.path, LogFileName(f.baseFileName, f.logFileIdx, CompressionNone))
 	cFile, err := os.OpenFile(logFileName, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0644)
 	if err != nil {
 		return err
 	}
 
 	f.currentFile = cFile
+	f.currentOpened = time.Now()
 	fi, err := f.currentFile.Stat()
 	if err != nil {
 		return err
 	}
 	f.currentWr = fi.Size()
 	f.createOrResetBuffer()
+
+	// Open the timestamp index of the file. Logs are still written if it
+	// can't be opened.
+	if f.indexFile != nil {
+		f.indexFile.Close()
+		f.indexFile = nil
+	}
+	indexFileName := filepath.Join(f.path, IndexFileName(f.baseFileName, f.logFileIdx))
+	indexFile, err := os.OpenFile(indexFileName, os.O_WRONLY|os.O_CREATE|os.O_APPEND|openNoFollow, 0644)
+	if err != nil {
+		f.logger.Warn("error opening log index", "filename", indexFileName, "err", err)
+	} else {
+		f.indexFile = indexFile
+	}
+	f.lastIndexed = time.Time{}
 	return nil
 }
 
@@ -250,6 +416,15 @@ func (f *FileRotator) flushPeriodically() {
 
 // Close flushes and closes the rotator. It never returns an error.
 func (f *FileRotator) Close() error {
+	f.stop()
+
+	// Wait for the file being compressed to be abandoned
+	<-f.compressDoneCh
+	return nil
+}
+
+// stop flushes the buffer and stops the go routines.
+func (f *FileRotator) stop() {
 	f.closedLock.Lock()
 	defer f.closedLock.Unlock()
 
@@ -263,58 +438,307 @@ func (f *FileRotator) Close() error {
 		close(f.purgeCh)
 		f.closed = true
 		f.currentFile.Close()
+		if f.indexFile != nil {
+			f.indexFile.Close()
+		}
 	}
+}
 
-	return nil
+// triggerPurge notifies the purging goroutine to purge older files.
+func (f *FileRotator) triggerPurge() {
+	f.closedLock.Lock()
+	defer f.closedLock.Unlock()
+	if f.closed {
+		return
+	}
+	select {
+	case f.purgeCh <- struct{}{}:
+	default:
+	}
 }
 
 // purgeOldFiles removes older files and keeps only the last N files rotated for
-// a file
+// a file, as well as files exceeding the total size or age retained
 func (f *FileRotator) purgeOldFiles() {
+	var purgeTick <-chan time.Time
+	if f.opts.MaxAge > 0 {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+		purgeTick = ticker.C
+	}
+
 	for {
 		select {
-		case <-f.purgeCh:
-			var fIndexes []int
-			files, err := ioutil.ReadDir(f.path)
-			if err != nil {
-				f.logger.Error("error getting directory listing", "err", err)
+		case _, ok := <-f.purgeCh:
+			if !ok {
 				return
 			}
-			// Inserting all the rotated files in a slice
-			for _, fi := range files {
-				if strings.HasPrefix(fi.Name(), f.baseFileName) {
-					fileIdx := strings.TrimPrefix(fi.Name(), fmt.Sprintf("%s.", f.baseFileName))
-					n, err := strconv.Atoi(fileIdx)
-					if err != nil {
-						f.logger.Error("error extracting file index", "err", err)
-						continue
-					}
-					fIndexes = append(fIndexes, n)
-				}
+		case <-purgeTick:
+		case <-f.doneCh:
+			return
+		}
+
+		if err := f.purge(); err != nil {
+			f.logger.Error("error getting directory listing", "err", err)
+			return
+		}
+	}
+}
+
+// rotatedFile is the set of files of a rotated file index, which may exist
+// both uncompressed and compressed while being compressed.
+type rotatedFile struct {
+	idx     int
+	names   []string
+	size    int64
+	modTime time.Time
+}
+
+// purge removes the oldest rotated files exceeding the retention policies.
+// The file with the largest index is being written and is always kept.
+func (f *FileRotator) purge() error {
+	f.filesLock.Lock()
+	defer f.filesLock.Unlock()
+
+	files, err := ioutil.ReadDir(f.path)
+	if err != nil {
+		return err
+	}
+
+	// Group the files by index
+	byIdx := make(map[int]*rotatedFile)
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+		n, _, ok := ParseLogFileName(f.baseFileName, fi.Name())
+		if !ok {
+			continue
+		}
+		rf, ok := byIdx[n]
+		if !ok {
+			rf = &rotatedFile{idx: n}
+			byIdx[n] = rf
+		}
+		rf.names = append(rf.names, fi.Name())
+		rf.size += fi.Size()
+		if fi.ModTime().After(rf.modTime) {
+			rf.modTime = fi.ModTime()
+		}
+	}
+	if len(byIdx) == 0 {
+		return nil
+	}
+
+	rotated := make([]*rotatedFile, 0, len(byIdx))
+	for _, rf := range byIdx {
+		rotated = append(rotated, rf)
+	}
+	sort.Slice(rotated, func(i, j int) bool { return rotated[i].idx < rotated[j].idx })
+
+	// Find the number of oldest files to delete, keeping only the number of
+	// files configured by the user
+	last := len(rotated) - 1
+	purged := 0
+	if len(rotated) > f.MaxFiles {
+		purged = len(rotated) - f.MaxFiles
+	}
+	if purged > last {
+		purged = last
+	}
+	if f.opts.MaxAge > 0 {
+		cutoff := time.Now().Add(-f.opts.MaxAge)
+		for purged < last && rotated[purged].modTime.Before(cutoff) {
+			purged++
+		}
+	}
+	if f.opts.MaxTotalSize > 0 {
+		var total int64
+		for _, rf := range rotated[purged:] {
+			total += rf.size
+		}
+		for purged < last && total > f.opts.MaxTotalSize {
+			total -= rotated[purged].size
+			purged++
+		}
+	}
+
+	for _, rf := range rotated[:purged] {
+		names := append(rf.names, IndexFileName(f.baseFileName, rf.idx), SizeFileName(f.baseFileName, rf.idx))
+		for _, name := range names {
+			fname := filepath.Join(f.path, name)
+			if err := os.RemoveAll(fname); err != nil {
+				f.logger.Error("error removing file", "filename", fname, "err", err)
 			}
+		}
+	}
+	f.oldestLogFileIdx = rotated[purged].idx
+	return nil
+}
 
-			// Not continuing to delete files if the number of files is not more
-			// than MaxFiles
-			if len(fIndexes) <= f.MaxFiles {
-				continue
+// compress queues the rotated file with the given index to be compressed.
+func (f *FileRotator) compress(idx int) {
+	if f.opts.Compression == CompressionNone {
+		return
+	}
+
+	f.compressLock.Lock()
+	f.compressQueue = append(f.compressQueue, idx)
+	f.compressLock.Unlock()
+
+	select {
+	case f.compressCh <- struct{}{}:
+	default:
+	}
+}
+
+// compressFiles compresses the queued rotated files until the rotator is
+// closed.
+func (f *FileRotator) compressFiles() {
+	defer close(f.compressDoneCh)
+
+	for {
+		select {
+		case <-f.compressCh:
+		case <-f.doneCh:
+			return
+		}
+
+		for {
+			f.compressLock.Lock()
+			if len(f.compressQueue) == 0 {
+				f.compressLock.Unlock()
+				break
 			}
+			idx := f.compressQueue[0]
+			f.compressQueue = f.compressQueue[1:]
+			f.compressLock.Unlock()
 
-			// Sorting the file indexes so that we can purge the older files and keep
-			// only the number of files as configured by the user
-			sort.Ints(fIndexes)
-			toDelete := fIndexes[0 : len(fIndexes)-f.MaxFiles]
-			for _, fIndex := range toDelete {
-				fname := filepath.Join(f.path, fmt.Sprintf("%s.%d", f.baseFileName, fIndex))
-				err := os.RemoveAll(fname)
-				if err != nil {
-					f.logger.Error("error removing file", "filename", fname, "err", err)
-				}
+			if err := f.compressFile(idx); err == errRotatorClosed {
+				return
+			} else if err != nil {
+				f.logger.Error("error compressing file", "index", idx, "err", err)
 			}
-			f.oldestLogFileIdx = fIndexes[0]
+		}
+
+		// The compressed files may now fit in the total size retained
+		if f.opts.MaxTotalSize > 0 {
+			f.triggerPurge()
+		}
+	}
+}
+
+// compressFile replaces the rotated file with the given index by its
+// compressed version. The file is compressed into a hidden temporary file
+// first, so that readers never see a partially compressed file, and the size
+// of the uncompressed file is recorded before the compressed file appears.
+func (f *FileRotator) compressFile(idx int) error {
+	src := filepath.Join(f.path, LogFileName(f.baseFileName, idx, CompressionNone))
+	dst := filepath.Join(f.path, LogFileName(f.baseFileName, idx, f.opts.Compression))
+	tmp := filepath.Join(f.path, "."+filepath.Base(dst)+compressTmpSuffix)
+
+	in, err := os.Open(src)
+	if os.IsNotExist(err) {
+		// The file was purged
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	fi, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := createNewFile(tmp)
+	if err != nil {
+		return err
+	}
+	err = f.copyCompressed(out, in)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		// Keep the time the file was last written to for the age retention
+		err = os.Chtimes(tmp, fi.ModTime(), fi.ModTime())
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	f.filesLock.Lock()
+	defer f.filesLock.Unlock()
+
+	// Don't resurrect a file purged while being compressed
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		os.Remove(tmp)
+		return nil
+	}
+	sizeFile := filepath.Join(f.path, SizeFileName(f.baseFileName, idx))
+	sf, err := createNewFile(sizeFile)
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	_, err = sf.WriteString(strconv.FormatInt(fi.Size(), 10))
+	if cerr := sf.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Remove(src)
+}
+
+// createNewFile creates the file at path for writing, replacing any existing
+// file. The log directory is writable by the task, so the file is created
+// exclusively, which fails rather than follows a symlink planted in its place.
+func createNewFile(path string) (*os.File, error) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL|openNoFollow, 0644)
+}
+
+// copyCompressed compresses the content of r into w, aborting once the
+// rotator is closed.
+func (f *FileRotator) copyCompressed(w io.Writer, r io.Reader) error {
+	cw, err := newCompressor(w, f.opts.Compression)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]byte, compressBufferSize)
+	for {
+		select {
 		case <-f.doneCh:
-			return
+			cw.Close()
+			return errRotatorClosed
+		default:
+		}
+
+		n, err := r.Read(buf)
+		if n > 0 {
+			if _, werr := cw.Write(buf[:n]); werr != nil {
+				cw.Close()
+				return werr
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			cw.Close()
+			return err
 		}
 	}
+	return cw.Close()
 }
 
 // flushBuffer flushes the buffer
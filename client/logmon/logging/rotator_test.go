@@ -6,7 +6,9 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/testutil"
@@ -258,7 +260,7 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	require.Equal(t, len(str), nw)
 
 	testutil.WaitForResult(func() (bool, error) {
-		f, err := ioutil.ReadDir(path)
+		f, err := readLogFiles(path)
 		if err != nil {
 			return false, fmt.Errorf("failed to read dir %v: %w", path, err)
 		}
@@ -273,6 +275,280 @@ func TestFileRotator_PurgeOldFiles(t *testing.T) {
 	})
 }
 
+func TestFileRotator_RotateEvery(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	opts := &RotatorOptions{RotateEvery: 50 * time.Millisecond}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 1024, opts, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abc\n"))
+	require.NoError(t, err)
+
+	// The file is rotated on the first write after the interval
+	time.Sleep(2 * opts.RotateEvery)
+	_, err = fr.Write([]byte("def\n"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		for name, expected := range map[string]string{"redis.stdout.0": "abc\n", "redis.stdout.1": "def\n"} {
+			buf, err := ioutil.ReadFile(filepath.Join(path, name))
+			if err != nil {
+				return false, err
+			}
+			if string(buf) != expected {
+				return false, fmt.Errorf("expected %q in %v, got %q", expected, name, buf)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
+// readCompressedFile returns the uncompressed content of a log file.
+func readCompressedFile(path, name, compression string) ([]byte, error) {
+	f, err := os.Open(filepath.Join(path, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := NewDecompressor(f, compression)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+func TestFileRotator_Compression(t *testing.T) {
+	for _, compression := range []string{CompressionGzip, CompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			defer goleak.VerifyNone(t)
+
+			path := t.TempDir()
+
+			opts := &RotatorOptions{Compression: compression}
+			fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5, opts, testlog.HCLogger(t))
+			require.NoError(t, err)
+			defer fr.Close()
+
+			_, err = fr.Write([]byte("abcdefgh"))
+			require.NoError(t, err)
+
+			// The rotated file is replaced by its compressed version
+			compressed := LogFileName(baseFileName, 0, compression)
+			testutil.WaitForResult(func() (bool, error) {
+				buf, err := readCompressedFile(path, compressed, compression)
+				if err != nil {
+					return false, err
+				}
+				if string(buf) != "abcde" {
+					return false, fmt.Errorf("expected %q, got %q", "abcde", buf)
+				}
+				if _, err := os.Stat(filepath.Join(path, "redis.stdout.0")); !os.IsNotExist(err) {
+					return false, fmt.Errorf("expected uncompressed file to be removed: %v", err)
+				}
+				return true, nil
+			}, func(err error) {
+				require.NoError(t, err)
+			})
+
+			files, err := readLogFiles(path)
+			require.NoError(t, err)
+			var names []string
+			for _, fi := range files {
+				names = append(names, fi.Name())
+			}
+			require.ElementsMatch(t, []string{compressed, "redis.stdout.1"}, names)
+
+			// The size of the uncompressed file is recorded
+			f, err := os.Open(filepath.Join(path, SizeFileName(baseFileName, 0)))
+			require.NoError(t, err)
+			defer f.Close()
+			size, err := ReadSize(f)
+			require.NoError(t, err)
+			require.EqualValues(t, 5, size)
+		})
+	}
+}
+
+func TestFileRotator_Compression_Restart(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	// A rotated file left uncompressed, a compressed file, and the temporary
+	// file of an interrupted compression
+	require.NoError(t, ioutil.WriteFile(filepath.Join(path, "redis.stdout.0"), []byte("abc"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(path, ".redis.stdout.0.gz.tmp"), []byte("ab"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(path, "redis.stdout.1.gz"), nil, 0644))
+
+	opts := &RotatorOptions{Compression: CompressionGzip}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5, opts, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	// The compressed file isn't appended to
+	require.Equal(t, filepath.Join(path, "redis.stdout.2"), fr.currentFile.Name())
+
+	testutil.WaitForResult(func() (bool, error) {
+		buf, err := readCompressedFile(path, "redis.stdout.0.gz", CompressionGzip)
+		if err != nil {
+			return false, err
+		}
+		if string(buf) != "abc" {
+			return false, fmt.Errorf("expected %q, got %q", "abc", buf)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	_, err = os.Stat(filepath.Join(path, ".redis.stdout.0.gz.tmp"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestFileRotator_PurgeTotalSize(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	opts := &RotatorOptions{MaxTotalSize: 4}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 2, opts, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	str := "abcdefghij"
+	nw, err := fr.Write([]byte(str))
+	require.NoError(t, err)
+	require.Equal(t, len(str), nw)
+
+	// The total size is checked when rotating to the last file, which is
+	// still empty
+	testutil.WaitForResult(func() (bool, error) {
+		f, err := readLogFiles(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read dir %v: %w", path, err)
+		}
+
+		if len(f) != 3 {
+			return false, fmt.Errorf("expected number of files: %v, got: %v %v", 3, len(f), f)
+		}
+		if f[0].Name() != "redis.stdout.2" {
+			return false, fmt.Errorf("expected oldest file redis.stdout.2, got %v", f[0].Name())
+		}
+
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
+func TestFileRotator_PurgeMaxAge(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, name := range []string{"redis.stdout.0", "redis.stdout.1"} {
+		fname := filepath.Join(path, name)
+		require.NoError(t, ioutil.WriteFile(fname, nil, 0644))
+		require.NoError(t, os.Chtimes(fname, old, old))
+	}
+
+	opts := &RotatorOptions{MaxAge: time.Hour}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 2, opts, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	// Writing to the last file and rotating it purges the older file
+	_, err = fr.Write([]byte("abcd"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		if _, err := os.Stat(filepath.Join(path, "redis.stdout.0")); !os.IsNotExist(err) {
+			return false, fmt.Errorf("expected old file to be purged: %v", err)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	for _, name := range []string{"redis.stdout.1", "redis.stdout.2"} {
+		_, err := os.Stat(filepath.Join(path, name))
+		require.NoError(t, err)
+	}
+}
+
+func TestFileRotator_Index(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 6, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = fr.Write([]byte("abc\n"))
+	require.NoError(t, err)
+
+	// Writes within the index interval aren't indexed
+	_, err = fr.Write([]byte("d\n"))
+	require.NoError(t, err)
+
+	// The first write to the next file is indexed
+	_, err = fr.Write([]byte("efg\n"))
+	require.NoError(t, err)
+	require.NoError(t, fr.Close())
+
+	readIndex := func(idx int) LogIndex {
+		f, err := os.Open(filepath.Join(path, IndexFileName(baseFileName, idx)))
+		require.NoError(t, err)
+		defer f.Close()
+		index, err := ReadLogIndex(f)
+		require.NoError(t, err)
+		return index
+	}
+
+	index := readIndex(0)
+	require.Len(t, index, 1)
+	require.Equal(t, int64(0), index[0].Offset)
+	require.False(t, index[0].Time.Before(start.Truncate(time.Second)))
+
+	index = readIndex(1)
+	require.Len(t, index, 1)
+	require.Equal(t, int64(0), index[0].Offset)
+}
+
+func TestFileRotator_PurgeIndex(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	path := t.TempDir()
+
+	fr, err := NewFileRotator(path, baseFileName, 1, 3, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("ab\n"))
+	require.NoError(t, err)
+	_, err = fr.Write([]byte("cd\n"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		if _, err := os.Stat(filepath.Join(path, IndexFileName(baseFileName, 0))); !os.IsNotExist(err) {
+			return false, fmt.Errorf("expected index of purged file to be removed: %v", err)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
 func BenchmarkRotator(b *testing.B) {
 	kb := 1024
 	for _, inputSize := range []int{kb, 2 * kb, 4 * kb, 8 * kb, 16 * kb, 32 * kb, 64 * kb, 128 * kb, 256 * kb} {
@@ -309,3 +585,19 @@ func benchmarkRotatorWithInputSize(size int, b *testing.B) {
 		require.NoError(b, err)
 	}
 }
+
+// readLogFiles lists the log files in the path, ignoring the hidden index
+// files.
+func readLogFiles(path string) ([]os.FileInfo, error) {
+	files, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var logFiles []os.FileInfo
+	for _, fi := range files {
+		if !strings.HasPrefix(fi.Name(), ".") {
+			logFiles = append(logFiles, fi)
+		}
+	}
+	return logFiles, nil
+}
@@ -0,0 +1,91 @@
+//go:build !windows
+// +build !windows
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func TestFileRotator_IndexSymlink(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, nil, 0644))
+
+	// The task plants a symlink in place of the index of the next file
+	path := t.TempDir()
+	require.NoError(t, os.Symlink(target, filepath.Join(path, IndexFileName(baseFileName, 0))))
+
+	fr, err := NewFileRotator(path, baseFileName, 10, 10, testlog.HCLogger(t))
+	require.NoError(t, err)
+
+	// Logs are still written without the index
+	_, err = fr.Write([]byte("abc\n"))
+	require.NoError(t, err)
+	require.NoError(t, fr.Close())
+
+	buf, err := os.ReadFile(filepath.Join(path, "redis.stdout.0"))
+	require.NoError(t, err)
+	require.Equal(t, "abc\n", string(buf))
+
+	// The file outside of the log directory is untouched
+	buf, err = os.ReadFile(target)
+	require.NoError(t, err)
+	require.Empty(t, buf)
+}
+
+func TestFileRotator_CompressionSymlinks(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, nil, 0644))
+
+	// The task plants symlinks in place of the temporary compressed file and
+	// the size file of the next rotated file
+	path := t.TempDir()
+	compressed := LogFileName(baseFileName, 0, CompressionGzip)
+	require.NoError(t, os.Symlink(target, filepath.Join(path, "."+compressed+compressTmpSuffix)))
+	require.NoError(t, os.Symlink(target, filepath.Join(path, SizeFileName(baseFileName, 0))))
+
+	opts := &RotatorOptions{Compression: CompressionGzip}
+	fr, err := NewFileRotatorWithOptions(path, baseFileName, 10, 5, opts, testlog.HCLogger(t))
+	require.NoError(t, err)
+	defer fr.Close()
+
+	_, err = fr.Write([]byte("abcdefgh"))
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		buf, err := readCompressedFile(path, compressed, CompressionGzip)
+		if err != nil {
+			return false, err
+		}
+		if string(buf) != "abcde" {
+			return false, fmt.Errorf("expected %q, got %q", "abcde", buf)
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The size file replaced the symlink
+	fi, err := os.Lstat(filepath.Join(path, SizeFileName(baseFileName, 0)))
+	require.NoError(t, err)
+	require.True(t, fi.Mode().IsRegular())
+
+	// The file outside of the log directory is untouched
+	buf, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Empty(t, buf)
+}
@@ -11,6 +11,8 @@ import (
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/nomad/client/lib/fifo"
 	"github.com/hashicorp/nomad/client/logmon/logging"
+	"github.com/hashicorp/nomad/client/logmon/sinks"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 const (
@@ -41,6 +43,30 @@ type LogConfig struct {
 
 	// MaxFileSizeMB is the max log file size in MB allowed before rotation occures
 	MaxFileSizeMB int
+
+	// RotateEvery is the interval after which log files are rotated whatever
+	// their size
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with
+	Compression string
+
+	// MaxTotalSizeMB is the max total size in MB of the log files of a stream
+	MaxTotalSizeMB int
+
+	// MaxAge is the max time since a rotated log file was last written to
+	MaxAge time.Duration
+
+	// AllocID, JobID, Namespace, TaskGroup and TaskName identify the task
+	// whose logs are shipped to the sinks
+	AllocID   string
+	JobID     string
+	Namespace string
+	TaskGroup string
+	TaskName  string
+
+	// Sinks are the external destinations the logs are shipped to
+	Sinks []*structs.LogSink
 }
 
 type LogMon interface {
@@ -106,6 +132,12 @@ type TaskLogger struct {
 
 	// rotator for stderr
 	lre *logRotatorWrapper
+
+	// sinks the logs are shipped to
+	sinks []sinks.Sink
+
+	// shippers of the stdout and stderr log files to the sinks
+	shippers []*sinks.Shipper
 }
 
 // IsRunning will return true as long as one rotator wrapper is still running
@@ -133,14 +165,33 @@ func (tl *TaskLogger) Close() {
 		}()
 	}
 	wg.Wait()
+
+	// Ship the lines flushed by the rotators before closing the sinks
+	for _, shipper := range tl.shippers {
+		wg.Add(1)
+		go func(shipper *sinks.Shipper) {
+			shipper.Stop()
+			wg.Done()
+		}(shipper)
+	}
+	wg.Wait()
+	for _, sink := range tl.sinks {
+		sink.Close()
+	}
 }
 
 func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 	tl := &TaskLogger{config: cfg}
 
 	logFileSize := int64(cfg.MaxFileSizeMB * 1024 * 1024)
-	lro, err := logging.NewFileRotator(cfg.LogDir, cfg.StdoutLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	opts := &logging.RotatorOptions{
+		RotateEvery:  cfg.RotateEvery,
+		Compression:  cfg.Compression,
+		MaxTotalSize: int64(cfg.MaxTotalSizeMB) * 1024 * 1024,
+		MaxAge:       cfg.MaxAge,
+	}
+	lro, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StdoutLogFile,
+		cfg.MaxFiles, logFileSize, opts, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stdout logfile for %q: %v", cfg.StdoutLogFile, err)
 	}
@@ -152,8 +203,8 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 	tl.lro = wrapperOut
 
-	lre, err := logging.NewFileRotator(cfg.LogDir, cfg.StderrLogFile,
-		cfg.MaxFiles, logFileSize, logger)
+	lre, err := logging.NewFileRotatorWithOptions(cfg.LogDir, cfg.StderrLogFile,
+		cfg.MaxFiles, logFileSize, opts, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stderr logfile for %q: %v", cfg.StderrLogFile, err)
 	}
@@ -165,10 +216,40 @@ func NewTaskLogger(cfg *LogConfig, logger hclog.Logger) (*TaskLogger, error) {
 
 	tl.lre = wrapperErr
 
+	if err := tl.startShippers(logger); err != nil {
+		tl.Close()
+		return nil, err
+	}
+
 	return tl, nil
 
 }
 
+// startShippers starts shipping the stdout and stderr log files to each sink.
+func (tl *TaskLogger) startShippers(logger hclog.Logger) error {
+	cfg := tl.config
+	meta := &sinks.Metadata{
+		AllocID:   cfg.AllocID,
+		JobID:     cfg.JobID,
+		Namespace: cfg.Namespace,
+		TaskGroup: cfg.TaskGroup,
+		Task:      cfg.TaskName,
+	}
+
+	for _, sinkCfg := range cfg.Sinks {
+		sink, err := sinks.New(sinkCfg, meta, cfg.LogDir)
+		if err != nil {
+			return fmt.Errorf("failed to create log sink %q: %v", sinkCfg.Name, err)
+		}
+		tl.sinks = append(tl.sinks, sink)
+
+		tl.shippers = append(tl.shippers,
+			sinks.NewShipper(logger, sink, sinkCfg, cfg.LogDir, cfg.StdoutLogFile, sinks.StreamStdout),
+			sinks.NewShipper(logger, sink, sinkCfg, cfg.LogDir, cfg.StderrLogFile, sinks.StreamStderr))
+	}
+	return nil
+}
+
 // logRotatorWrapper wraps our log rotator and exposes a pipe that can feed the
 // log rotator data. The processOutWriter should be attached to the process and
 // data will be copied from the reader to the rotator.
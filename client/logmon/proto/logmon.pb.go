@@ -25,16 +25,26 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type StartRequest struct {
-	LogDir               string   `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
-	StdoutFileName       string   `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
-	StderrFileName       string   `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
-	MaxFiles             uint32   `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
-	MaxFileSizeMb        uint32   `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
-	StdoutFifo           string   `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
-	StderrFifo           string   `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	LogDir               string     `protobuf:"bytes,1,opt,name=log_dir,json=logDir,proto3" json:"log_dir,omitempty"`
+	StdoutFileName       string     `protobuf:"bytes,2,opt,name=stdout_file_name,json=stdoutFileName,proto3" json:"stdout_file_name,omitempty"`
+	StderrFileName       string     `protobuf:"bytes,3,opt,name=stderr_file_name,json=stderrFileName,proto3" json:"stderr_file_name,omitempty"`
+	MaxFiles             uint32     `protobuf:"varint,4,opt,name=max_files,json=maxFiles,proto3" json:"max_files,omitempty"`
+	MaxFileSizeMb        uint32     `protobuf:"varint,5,opt,name=max_file_size_mb,json=maxFileSizeMb,proto3" json:"max_file_size_mb,omitempty"`
+	StdoutFifo           string     `protobuf:"bytes,6,opt,name=stdout_fifo,json=stdoutFifo,proto3" json:"stdout_fifo,omitempty"`
+	StderrFifo           string     `protobuf:"bytes,7,opt,name=stderr_fifo,json=stderrFifo,proto3" json:"stderr_fifo,omitempty"`
+	AllocId              string     `protobuf:"bytes,8,opt,name=alloc_id,json=allocId,proto3" json:"alloc_id,omitempty"`
+	JobId                string     `protobuf:"bytes,9,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	Namespace            string     `protobuf:"bytes,10,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	TaskGroup            string     `protobuf:"bytes,11,opt,name=task_group,json=taskGroup,proto3" json:"task_group,omitempty"`
+	TaskName             string     `protobuf:"bytes,12,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`
+	Sinks                []*LogSink `protobuf:"bytes,13,rep,name=sinks,proto3" json:"sinks,omitempty"`
+	RotateEvery          int64      `protobuf:"varint,14,opt,name=rotate_every,json=rotateEvery,proto3" json:"rotate_every,omitempty"`
+	Compression          string     `protobuf:"bytes,15,opt,name=compression,proto3" json:"compression,omitempty"`
+	MaxTotalSizeMb       uint32     `protobuf:"varint,16,opt,name=max_total_size_mb,json=maxTotalSizeMb,proto3" json:"max_total_size_mb,omitempty"`
+	MaxAge               int64      `protobuf:"varint,17,opt,name=max_age,json=maxAge,proto3" json:"max_age,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
 
 func (m *StartRequest) Reset()         { *m = StartRequest{} }
@@ -111,6 +121,187 @@ func (m *StartRequest) GetStderrFifo() string {
 	return ""
 }
 
+func (m *StartRequest) GetAllocId() string {
+	if m != nil {
+		return m.AllocId
+	}
+	return ""
+}
+
+func (m *StartRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+func (m *StartRequest) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *StartRequest) GetTaskGroup() string {
+	if m != nil {
+		return m.TaskGroup
+	}
+	return ""
+}
+
+func (m *StartRequest) GetTaskName() string {
+	if m != nil {
+		return m.TaskName
+	}
+	return ""
+}
+
+func (m *StartRequest) GetSinks() []*LogSink {
+	if m != nil {
+		return m.Sinks
+	}
+	return nil
+}
+
+func (m *StartRequest) GetRotateEvery() int64 {
+	if m != nil {
+		return m.RotateEvery
+	}
+	return 0
+}
+
+func (m *StartRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *StartRequest) GetMaxTotalSizeMb() uint32 {
+	if m != nil {
+		return m.MaxTotalSizeMb
+	}
+	return 0
+}
+
+func (m *StartRequest) GetMaxAge() int64 {
+	if m != nil {
+		return m.MaxAge
+	}
+	return 0
+}
+
+type LogSink struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Address              string            `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Facility             string            `protobuf:"bytes,4,opt,name=facility,proto3" json:"facility,omitempty"`
+	Tag                  string            `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	Path                 string            `protobuf:"bytes,6,opt,name=path,proto3" json:"path,omitempty"`
+	Endpoint             string            `protobuf:"bytes,7,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Headers              map[string]string `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	BatchSize            uint32            `protobuf:"varint,9,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	BatchWait            int64             `protobuf:"varint,10,opt,name=batch_wait,json=batchWait,proto3" json:"batch_wait,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *LogSink) Reset()         { *m = LogSink{} }
+func (m *LogSink) String() string { return proto.CompactTextString(m) }
+func (*LogSink) ProtoMessage()    {}
+func (*LogSink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_be72d5e24d2ecba6, []int{1}
+}
+
+func (m *LogSink) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogSink.Unmarshal(m, b)
+}
+func (m *LogSink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogSink.Marshal(b, m, deterministic)
+}
+func (m *LogSink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogSink.Merge(m, src)
+}
+func (m *LogSink) XXX_Size() int {
+	return xxx_messageInfo_LogSink.Size(m)
+}
+func (m *LogSink) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogSink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogSink proto.InternalMessageInfo
+
+func (m *LogSink) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *LogSink) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *LogSink) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *LogSink) GetFacility() string {
+	if m != nil {
+		return m.Facility
+	}
+	return ""
+}
+
+func (m *LogSink) GetTag() string {
+	if m != nil {
+		return m.Tag
+	}
+	return ""
+}
+
+func (m *LogSink) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *LogSink) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *LogSink) GetHeaders() map[string]string {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+func (m *LogSink) GetBatchSize() uint32 {
+	if m != nil {
+		return m.BatchSize
+	}
+	return 0
+}
+
+func (m *LogSink) GetBatchWait() int64 {
+	if m != nil {
+		return m.BatchWait
+	}
+	return 0
+}
+
 type StartResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -121,7 +312,7 @@ func (m *StartResponse) Reset()         { *m = StartResponse{} }
 func (m *StartResponse) String() string { return proto.CompactTextString(m) }
 func (*StartResponse) ProtoMessage()    {}
 func (*StartResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be72d5e24d2ecba6, []int{1}
+	return fileDescriptor_be72d5e24d2ecba6, []int{2}
 }
 
 func (m *StartResponse) XXX_Unmarshal(b []byte) error {
@@ -152,7 +343,7 @@ func (m *StopRequest) Reset()         { *m = StopRequest{} }
 func (m *StopRequest) String() string { return proto.CompactTextString(m) }
 func (*StopRequest) ProtoMessage()    {}
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be72d5e24d2ecba6, []int{2}
+	return fileDescriptor_be72d5e24d2ecba6, []int{3}
 }
 
 func (m *StopRequest) XXX_Unmarshal(b []byte) error {
@@ -183,7 +374,7 @@ func (m *StopResponse) Reset()         { *m = StopResponse{} }
 func (m *StopResponse) String() string { return proto.CompactTextString(m) }
 func (*StopResponse) ProtoMessage()    {}
 func (*StopResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_be72d5e24d2ecba6, []int{3}
+	return fileDescriptor_be72d5e24d2ecba6, []int{4}
 }
 
 func (m *StopResponse) XXX_Unmarshal(b []byte) error {
@@ -206,6 +397,8 @@ var xxx_messageInfo_StopResponse proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*StartRequest)(nil), "hashicorp.nomad.client.logmon.proto.StartRequest")
+	proto.RegisterType((*LogSink)(nil), "hashicorp.nomad.client.logmon.proto.LogSink")
+	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.client.logmon.proto.LogSink.HeadersEntry")
 	proto.RegisterType((*StartResponse)(nil), "hashicorp.nomad.client.logmon.proto.StartResponse")
 	proto.RegisterType((*StopRequest)(nil), "hashicorp.nomad.client.logmon.proto.StopRequest")
 	proto.RegisterType((*StopResponse)(nil), "hashicorp.nomad.client.logmon.proto.StopResponse")
@@ -216,27 +409,49 @@ func init() {
 }
 
 var fileDescriptor_be72d5e24d2ecba6 = []byte{
-	// 320 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x90, 0x31, 0x6f, 0xc2, 0x30,
-	0x10, 0x85, 0x1b, 0x0a, 0xa1, 0x1c, 0x0d, 0x45, 0x5e, 0x1a, 0xd1, 0xa1, 0x28, 0x1d, 0xca, 0x14,
-	0x0a, 0xfd, 0x07, 0x55, 0xd5, 0xa9, 0x74, 0x80, 0xad, 0x4b, 0x64, 0xc0, 0x09, 0x96, 0x62, 0x5f,
-	0x6a, 0x1b, 0x09, 0xb1, 0xf6, 0xd7, 0xf6, 0x5f, 0x54, 0x71, 0x4c, 0x94, 0x11, 0x26, 0xeb, 0xee,
-	0x7d, 0x4f, 0xf7, 0xfc, 0x60, 0xbc, 0xc9, 0x39, 0x93, 0x66, 0x9a, 0x63, 0x26, 0x50, 0x4e, 0x0b,
-	0x85, 0x06, 0xdd, 0x10, 0xdb, 0x81, 0x3c, 0xed, 0xa8, 0xde, 0xf1, 0x0d, 0xaa, 0x22, 0x96, 0x28,
-	0xe8, 0x36, 0xae, 0x1c, 0x71, 0x13, 0x8a, 0x7e, 0x5b, 0x70, 0xbb, 0x32, 0x54, 0x99, 0x25, 0xfb,
-	0xd9, 0x33, 0x6d, 0xc8, 0x3d, 0x74, 0x73, 0xcc, 0x92, 0x2d, 0x57, 0xa1, 0x37, 0xf6, 0x26, 0xbd,
-	0xa5, 0x9f, 0x63, 0xf6, 0xce, 0x15, 0x99, 0xc0, 0x50, 0x9b, 0x2d, 0xee, 0x4d, 0x92, 0xf2, 0x9c,
-	0x25, 0x92, 0x0a, 0x16, 0xb6, 0x2c, 0x31, 0xa8, 0xf6, 0x1f, 0x3c, 0x67, 0x5f, 0x54, 0x30, 0x47,
-	0x32, 0xa5, 0x1a, 0xe4, 0x75, 0x4d, 0x32, 0xa5, 0x6a, 0xf2, 0x01, 0x7a, 0x82, 0x1e, 0x2c, 0xa6,
-	0xc3, 0xf6, 0xd8, 0x9b, 0x04, 0xcb, 0x1b, 0x41, 0x0f, 0xa5, 0xae, 0xc9, 0x33, 0x0c, 0x4f, 0x62,
-	0xa2, 0xf9, 0x91, 0x25, 0x62, 0x1d, 0x76, 0x2c, 0x13, 0x38, 0x66, 0xc5, 0x8f, 0x6c, 0xb1, 0x26,
-	0x8f, 0xd0, 0xaf, 0x93, 0xa5, 0x18, 0xfa, 0xf6, 0x14, 0x9c, 0x42, 0xa5, 0xe8, 0x80, 0x2a, 0x50,
-	0x8a, 0x61, 0xb7, 0x06, 0x6c, 0x96, 0x14, 0xa3, 0x3b, 0x08, 0x5c, 0x09, 0xba, 0x40, 0xa9, 0x59,
-	0x14, 0x40, 0x7f, 0x65, 0xb0, 0x70, 0xa5, 0x44, 0x83, 0xb2, 0xa4, 0x72, 0xac, 0xe4, 0xf9, 0x9f,
-	0x07, 0xfe, 0x27, 0x66, 0x0b, 0x94, 0xa4, 0x80, 0x8e, 0xb5, 0x92, 0x59, 0x7c, 0x46, 0xdf, 0x71,
-	0xb3, 0xeb, 0xd1, 0xfc, 0x12, 0x8b, 0x4b, 0x76, 0x45, 0x04, 0xb4, 0xcb, 0x30, 0xe4, 0xe5, 0x4c,
-	0x77, 0xfd, 0x8d, 0xd1, 0xec, 0x02, 0xc7, 0xe9, 0xdc, 0x5b, 0xf7, 0xbb, 0x63, 0xf7, 0x6b, 0xdf,
-	0x3e, 0xaf, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0xd8, 0xb4, 0x87, 0xfc, 0x7a, 0x02, 0x00, 0x00,
+	// 662 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x54, 0x4d, 0x6f, 0x13, 0x3b,
+	0x14, 0x7d, 0x69, 0x3e, 0x26, 0xb9, 0xf9, 0x68, 0x6a, 0xbd, 0xa7, 0xfa, 0x05, 0x10, 0x21, 0x2c,
+	0x08, 0x12, 0x4a, 0x69, 0xd9, 0x40, 0x77, 0x54, 0x14, 0xa8, 0xd4, 0xb2, 0x98, 0x20, 0x21, 0xb1,
+	0x19, 0x39, 0x19, 0x67, 0xe2, 0x66, 0x66, 0x3c, 0xd8, 0x4e, 0x49, 0xfa, 0x5b, 0xf9, 0x03, 0xfc,
+	0x03, 0x96, 0xc8, 0x77, 0x3c, 0xd3, 0x2c, 0xdb, 0x55, 0x7c, 0xcf, 0x39, 0xd7, 0xbe, 0x3e, 0x3e,
+	0x13, 0x18, 0xce, 0x63, 0xc1, 0x53, 0x73, 0x14, 0xcb, 0x28, 0x91, 0xe9, 0x51, 0xa6, 0xa4, 0x91,
+	0xae, 0x98, 0x60, 0x41, 0x9e, 0x2f, 0x99, 0x5e, 0x8a, 0xb9, 0x54, 0xd9, 0x24, 0x95, 0x09, 0x0b,
+	0x27, 0x79, 0xc7, 0x64, 0x57, 0x34, 0xfa, 0x55, 0x83, 0xce, 0xd4, 0x30, 0x65, 0x7c, 0xfe, 0x63,
+	0xcd, 0xb5, 0x21, 0x87, 0xe0, 0xc5, 0x32, 0x0a, 0x42, 0xa1, 0x68, 0x65, 0x58, 0x19, 0xb7, 0xfc,
+	0x46, 0x2c, 0xa3, 0x0f, 0x42, 0x91, 0x31, 0xf4, 0xb5, 0x09, 0xe5, 0xda, 0x04, 0x0b, 0x11, 0xf3,
+	0x20, 0x65, 0x09, 0xa7, 0x7b, 0xa8, 0xe8, 0xe5, 0xf8, 0x47, 0x11, 0xf3, 0x2f, 0x2c, 0xe1, 0x4e,
+	0xc9, 0x95, 0xda, 0x51, 0x56, 0x4b, 0x25, 0x57, 0xaa, 0x54, 0x3e, 0x82, 0x56, 0xc2, 0x36, 0x28,
+	0xd3, 0xb4, 0x36, 0xac, 0x8c, 0xbb, 0x7e, 0x33, 0x61, 0x1b, 0xcb, 0x6b, 0xf2, 0x02, 0xfa, 0x05,
+	0x19, 0x68, 0x71, 0xcb, 0x83, 0x64, 0x46, 0xeb, 0xa8, 0xe9, 0x3a, 0xcd, 0x54, 0xdc, 0xf2, 0xab,
+	0x19, 0x79, 0x0a, 0xed, 0x72, 0xb2, 0x85, 0xa4, 0x0d, 0x3c, 0x0a, 0x8a, 0xa1, 0x16, 0xd2, 0x09,
+	0xf2, 0x81, 0x16, 0x92, 0x7a, 0xa5, 0x00, 0x67, 0x59, 0x48, 0xf2, 0x3f, 0x34, 0x59, 0x1c, 0xcb,
+	0x79, 0x20, 0x42, 0xda, 0x44, 0xd6, 0xc3, 0xfa, 0x22, 0x24, 0xff, 0x41, 0xe3, 0x5a, 0xce, 0x2c,
+	0xd1, 0x42, 0xa2, 0x7e, 0x2d, 0x67, 0x17, 0x21, 0x79, 0x0c, 0x2d, 0x7b, 0x2f, 0x9d, 0xb1, 0x39,
+	0xa7, 0x80, 0xcc, 0x1d, 0x40, 0x9e, 0x00, 0x18, 0xa6, 0x57, 0x41, 0xa4, 0xe4, 0x3a, 0xa3, 0xed,
+	0x9c, 0xb6, 0xc8, 0x27, 0x0b, 0xd8, 0x6b, 0x23, 0x8d, 0xce, 0x74, 0x90, 0x6d, 0x5a, 0x00, 0x3d,
+	0x39, 0x83, 0xba, 0x16, 0xe9, 0x4a, 0xd3, 0xee, 0xb0, 0x3a, 0x6e, 0x9f, 0xbc, 0x9a, 0xdc, 0xe3,
+	0x19, 0x27, 0x97, 0x32, 0x9a, 0x8a, 0x74, 0xe5, 0xe7, 0xad, 0xe4, 0x19, 0x74, 0x94, 0x34, 0xcc,
+	0xf0, 0x80, 0xdf, 0x70, 0xb5, 0xa5, 0xbd, 0x61, 0x65, 0x5c, 0xf5, 0xdb, 0x39, 0x76, 0x6e, 0x21,
+	0x32, 0x84, 0xf6, 0x5c, 0x26, 0x99, 0xe2, 0x5a, 0x0b, 0x99, 0xd2, 0x7d, 0x9c, 0x62, 0x17, 0x22,
+	0x2f, 0xe1, 0xc0, 0xfa, 0x6f, 0xa4, 0x61, 0x71, 0xf9, 0x00, 0x7d, 0x7c, 0x80, 0x5e, 0xc2, 0x36,
+	0x5f, 0x2d, 0xee, 0x5e, 0xe0, 0x10, 0x3c, 0x2b, 0x65, 0x11, 0xa7, 0x07, 0x78, 0x54, 0x23, 0x61,
+	0x9b, 0xf7, 0x11, 0x1f, 0xfd, 0xd9, 0x03, 0xcf, 0xcd, 0x46, 0x08, 0xd4, 0xf0, 0xc2, 0x79, 0xac,
+	0x70, 0x6d, 0x31, 0xb3, 0xcd, 0x8a, 0x20, 0xe1, 0x9a, 0x50, 0xf0, 0x58, 0x18, 0xda, 0x29, 0x5c,
+	0x6a, 0x8a, 0x92, 0x0c, 0xa0, 0xb9, 0x60, 0x73, 0x11, 0x0b, 0xb3, 0xc5, 0xb4, 0xb4, 0xfc, 0xb2,
+	0x26, 0x7d, 0xa8, 0x1a, 0x16, 0x61, 0x40, 0x5a, 0xbe, 0x5d, 0xda, 0xbd, 0x33, 0x66, 0x96, 0x2e,
+	0x0f, 0xb8, 0xb6, 0x3b, 0xf0, 0x34, 0xcc, 0xa4, 0x48, 0x8d, 0x8b, 0x41, 0x59, 0x93, 0x29, 0x78,
+	0x4b, 0xce, 0x42, 0xae, 0x34, 0x6d, 0xa2, 0xf5, 0xef, 0x1e, 0x62, 0xfd, 0xe4, 0x73, 0xde, 0x7b,
+	0x9e, 0x1a, 0xb5, 0xf5, 0x8b, 0x9d, 0x6c, 0x12, 0x66, 0xcc, 0xcc, 0x97, 0x68, 0x20, 0x46, 0xa8,
+	0xeb, 0xb7, 0x10, 0xb1, 0xd6, 0xdd, 0xd1, 0x3f, 0x99, 0x30, 0x98, 0xa3, 0xaa, 0xa3, 0xbf, 0x31,
+	0x61, 0x06, 0xa7, 0xd0, 0xd9, 0xdd, 0xd6, 0x5e, 0x72, 0xc5, 0xb7, 0xce, 0x41, 0xbb, 0x24, 0xff,
+	0x42, 0xfd, 0x86, 0xc5, 0xeb, 0xc2, 0xc1, 0xbc, 0x38, 0xdd, 0x7b, 0x5b, 0x19, 0xed, 0x43, 0xd7,
+	0x7d, 0xd8, 0x3a, 0x93, 0xa9, 0xe6, 0xa3, 0x2e, 0xb4, 0xa7, 0x46, 0x66, 0xee, 0x43, 0x1f, 0xf5,
+	0xa0, 0x93, 0x97, 0x39, 0x7d, 0xf2, 0xbb, 0x02, 0x8d, 0x4b, 0x19, 0x5d, 0xc9, 0x94, 0x64, 0x50,
+	0xc7, 0x56, 0x72, 0x7c, 0x2f, 0x07, 0x76, 0xff, 0x3f, 0x06, 0x27, 0x0f, 0x69, 0x71, 0x93, 0xfd,
+	0x43, 0x12, 0xa8, 0xd9, 0x61, 0xc8, 0xeb, 0x7b, 0x76, 0x97, 0xd7, 0x18, 0x1c, 0x3f, 0xa0, 0xa3,
+	0x38, 0xee, 0xcc, 0xfb, 0x5e, 0x47, 0x7c, 0xd6, 0xc0, 0x9f, 0x37, 0x7f, 0x07, 0x00, 0x0c, 0x45,
+	0xab, 0x41, 0x4e, 0x05, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1,10 +1,13 @@
 package logmon
 
 import (
+	"time"
+
 	"golang.org/x/net/context"
 
 	plugin "github.com/hashicorp/go-plugin"
 	"github.com/hashicorp/nomad/client/logmon/proto"
+	"github.com/hashicorp/nomad/nomad/structs"
 )
 
 type logmonServer struct {
@@ -14,13 +17,36 @@ type logmonServer struct {
 
 func (s *logmonServer) Start(ctx context.Context, req *proto.StartRequest) (*proto.StartResponse, error) {
 	cfg := &LogConfig{
-		LogDir:        req.LogDir,
-		StdoutLogFile: req.StdoutFileName,
-		StderrLogFile: req.StderrFileName,
-		MaxFiles:      int(req.MaxFiles),
-		MaxFileSizeMB: int(req.MaxFileSizeMb),
-		StdoutFifo:    req.StdoutFifo,
-		StderrFifo:    req.StderrFifo,
+		LogDir:         req.LogDir,
+		StdoutLogFile:  req.StdoutFileName,
+		StderrLogFile:  req.StderrFileName,
+		MaxFiles:       int(req.MaxFiles),
+		MaxFileSizeMB:  int(req.MaxFileSizeMb),
+		StdoutFifo:     req.StdoutFifo,
+		StderrFifo:     req.StderrFifo,
+		AllocID:        req.AllocId,
+		JobID:          req.JobId,
+		Namespace:      req.Namespace,
+		TaskGroup:      req.TaskGroup,
+		TaskName:       req.TaskName,
+		RotateEvery:    time.Duration(req.RotateEvery),
+		Compression:    req.Compression,
+		MaxTotalSizeMB: int(req.MaxTotalSizeMb),
+		MaxAge:         time.Duration(req.MaxAge),
+	}
+	for _, sink := range req.Sinks {
+		cfg.Sinks = append(cfg.Sinks, &structs.LogSink{
+			Name:      sink.Name,
+			Type:      sink.Type,
+			Address:   sink.Address,
+			Facility:  sink.Facility,
+			Tag:       sink.Tag,
+			Path:      sink.Path,
+			Endpoint:  sink.Endpoint,
+			Headers:   sink.Headers,
+			BatchSize: int(sink.BatchSize),
+			BatchWait: time.Duration(sink.BatchWait),
+		})
 	}
 
 	err := s.impl.Start(cfg)
@@ -0,0 +1,82 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// jsonFileRecord is a line of a JSON file sink.
+type jsonFileRecord struct {
+	Time      string `json:"time"`
+	Stream    string `json:"stream"`
+	Message   string `json:"message"`
+	AllocID   string `json:"alloc_id"`
+	JobID     string `json:"job_id"`
+	Namespace string `json:"namespace"`
+	TaskGroup string `json:"task_group"`
+	Task      string `json:"task"`
+}
+
+// jsonFileSink appends the lines of the task to a file as line-delimited JSON
+// records with the metadata of the allocation.
+type jsonFileSink struct {
+	meta *Metadata
+	path string
+
+	l sync.Mutex
+	f *os.File
+}
+
+func newJSONFileSink(config *structs.LogSink, meta *Metadata, logDir string) (*jsonFileSink, error) {
+	f, err := openJSONFile(logDir, config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open json_file sink: %v", err)
+	}
+
+	return &jsonFileSink{
+		meta: meta,
+		path: f.Name(),
+		f:    f,
+	}, nil
+}
+
+// Send appends the records to the file with a single write, so that the
+// records of the stdout and stderr streams aren't interleaved.
+func (s *jsonFileSink) Send(records []*Record) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, r := range records {
+		err := enc.Encode(&jsonFileRecord{
+			Time:      r.Time.UTC().Format(time.RFC3339Nano),
+			Stream:    r.Stream,
+			Message:   string(r.Line),
+			AllocID:   s.meta.AllocID,
+			JobID:     s.meta.JobID,
+			Namespace: s.meta.Namespace,
+			TaskGroup: s.meta.TaskGroup,
+			Task:      s.meta.Task,
+		})
+		if err != nil {
+			return &permanentError{err}
+		}
+	}
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	if _, err := s.f.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write to %q: %v", s.path, err)
+	}
+	return nil
+}
+
+func (s *jsonFileSink) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,71 @@
+//go:build !windows
+// +build !windows
+
+package sinks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// openJSONFile opens the file at the relative path below dir for appending,
+// creating it and its directories if needed. The log directory is writable by
+// the task, so the path is resolved one directory at a time without following
+// symlinks, and only a regular file with a single link is opened.
+func openJSONFile(dir, rel string) (*os.File, error) {
+	const dirFlags = unix.O_RDONLY | unix.O_DIRECTORY | unix.O_NOFOLLOW | unix.O_CLOEXEC
+
+	fd, err := unix.Open(dir, dirFlags, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+
+	path := dir
+	parts := strings.Split(filepath.Clean(rel), string(filepath.Separator))
+	for _, part := range parts[:len(parts)-1] {
+		path = filepath.Join(path, part)
+		if err := unix.Mkdirat(fd, part, 0755); err != nil && err != unix.EEXIST {
+			unix.Close(fd)
+			return nil, &os.PathError{Op: "mkdir", Path: path, Err: err}
+		}
+		next, err := unix.Openat(fd, part, dirFlags, 0)
+		unix.Close(fd)
+		if err != nil {
+			return nil, &os.PathError{Op: "open", Path: path, Err: err}
+		}
+		fd = next
+	}
+
+	// Opening a FIFO without a reader fails rather than blocks when
+	// non-blocking
+	path = filepath.Join(dir, rel)
+	fileFlags := unix.O_WRONLY | unix.O_CREAT | unix.O_APPEND | unix.O_NOFOLLOW | unix.O_NONBLOCK | unix.O_CLOEXEC
+	file, err := unix.Openat(fd, parts[len(parts)-1], fileFlags, 0644)
+	unix.Close(fd)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+
+	var st unix.Stat_t
+	if err := unix.Fstat(file, &st); err != nil {
+		unix.Close(file)
+		return nil, &os.PathError{Op: "stat", Path: path, Err: err}
+	}
+	if st.Mode&unix.S_IFMT != unix.S_IFREG {
+		unix.Close(file)
+		return nil, fmt.Errorf("%q is not a regular file", path)
+	}
+	if st.Nlink > 1 {
+		unix.Close(file)
+		return nil, fmt.Errorf("%q has more than one link", path)
+	}
+	if err := unix.SetNonblock(file, false); err != nil {
+		unix.Close(file)
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return os.NewFile(uintptr(file), path), nil
+}
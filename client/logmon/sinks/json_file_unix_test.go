@@ -0,0 +1,49 @@
+//go:build !windows
+// +build !windows
+
+package sinks
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONFileSink_Symlinks(t *testing.T) {
+	ci.Parallel(t)
+
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, nil, 0644))
+
+	dir := t.TempDir()
+	require.NoError(t, os.Symlink(target, filepath.Join(dir, "file.json")))
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "subdir")))
+	require.NoError(t, os.Link(target, filepath.Join(dir, "link.json")))
+	require.NoError(t, syscall.Mkfifo(filepath.Join(dir, "fifo.json"), 0644))
+
+	meta := &Metadata{AllocID: "8a3e5c2d", Task: "web"}
+	for _, path := range []string{"file.json", "subdir/new.json", "link.json", "fifo.json"} {
+		config := &structs.LogSink{Name: "json", Type: structs.LogSinkTypeJSONFile, Path: path}
+		_, err := New(config, meta, dir)
+		require.Error(t, err, path)
+	}
+
+	// Nothing was created outside of the log directory
+	_, err := os.Stat(filepath.Join(outside, "new.json"))
+	require.True(t, os.IsNotExist(err))
+
+	// New files and directories are created as usual
+	config := &structs.LogSink{Name: "json", Type: structs.LogSinkTypeJSONFile, Path: "shipped/web.json"}
+	sink, err := New(config, meta, dir)
+	require.NoError(t, err)
+	require.NoError(t, sink.Close())
+	fi, err := os.Lstat(filepath.Join(dir, "shipped", "web.json"))
+	require.NoError(t, err)
+	require.True(t, fi.Mode().IsRegular())
+}
@@ -0,0 +1,48 @@
+//go:build windows
+// +build windows
+
+package sinks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// openJSONFile opens the file at the relative path below dir for appending,
+// creating it and its directories if needed. The log directory is writable by
+// the task, so the directories of the path and the file must not be symlinks,
+// and only a regular file is opened.
+func openJSONFile(dir, rel string) (*os.File, error) {
+	path := dir
+	parts := strings.Split(filepath.Clean(rel), string(filepath.Separator))
+	for _, part := range parts[:len(parts)-1] {
+		path = filepath.Join(path, part)
+		if err := os.Mkdir(path, 0755); err != nil && !os.IsExist(err) {
+			return nil, err
+		}
+		if fi, err := os.Lstat(path); err != nil {
+			return nil, err
+		} else if !fi.IsDir() {
+			return nil, fmt.Errorf("%q is not a directory", path)
+		}
+	}
+
+	path = filepath.Join(dir, rel)
+	if fi, err := os.Lstat(path); err == nil && !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("%q is not a regular file", path)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := f.Stat(); err != nil {
+		f.Close()
+		return nil, err
+	} else if !fi.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("%q is not a regular file", path)
+	}
+	return f, nil
+}
@@ -0,0 +1,172 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	cleanhttp "github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// otlpRequestTimeout is the timeout of the requests exporting a batch of
+	// records.
+	otlpRequestTimeout = 30 * time.Second
+
+	// otlpScopeName is the name of the instrumentation scope of the records.
+	otlpScopeName = "nomad.logmon"
+
+	// otlpSeverityInfo and otlpSeverityError are the severities of the lines
+	// written to stdout and stderr.
+	otlpSeverityInfo  = 9
+	otlpSeverityError = 17
+)
+
+// otlpSink exports the lines of the task to an OpenTelemetry collector with
+// the JSON encoding of the OTLP/HTTP protocol.
+type otlpSink struct {
+	endpoint string
+	headers  map[string]string
+	resource *otlpResource
+	client   *http.Client
+}
+
+type otlpExportRequest struct {
+	ResourceLogs []*otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  *otlpResource    `json:"resource"`
+	ScopeLogs []*otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      *otlpScope       `json:"scope"`
+	LogRecords []*otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string          `json:"timeUnixNano"`
+	ObservedTimeUnixNano string          `json:"observedTimeUnixNano"`
+	SeverityNumber       int             `json:"severityNumber"`
+	SeverityText         string          `json:"severityText"`
+	Body                 *otlpAnyValue   `json:"body"`
+	Attributes           []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `json:"key"`
+	Value *otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+func otlpAttribute(key, value string) *otlpKeyValue {
+	return &otlpKeyValue{Key: key, Value: &otlpAnyValue{StringValue: value}}
+}
+
+func newOTLPSink(config *structs.LogSink, meta *Metadata) (*otlpSink, error) {
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = otlpRequestTimeout
+
+	return &otlpSink{
+		endpoint: config.Endpoint,
+		headers:  helper.CopyMapStringString(config.Headers),
+		resource: &otlpResource{
+			Attributes: []*otlpKeyValue{
+				otlpAttribute("service.name", meta.JobID),
+				otlpAttribute("nomad.alloc.id", meta.AllocID),
+				otlpAttribute("nomad.job.id", meta.JobID),
+				otlpAttribute("nomad.namespace", meta.Namespace),
+				otlpAttribute("nomad.task_group", meta.TaskGroup),
+				otlpAttribute("nomad.task", meta.Task),
+			},
+		},
+		client: client,
+	}, nil
+}
+
+// Send exports the records in a single request. Requests rejected because
+// the collector is unavailable or throttling are retried, while other
+// rejections are permanent as retrying the same records would fail again.
+func (s *otlpSink) Send(records []*Record) error {
+	logRecords := make([]*otlpLogRecord, len(records))
+	for i, r := range records {
+		ts := strconv.FormatInt(r.Time.UnixNano(), 10)
+		severity, severityText := otlpSeverityInfo, "INFO"
+		if r.Stream == StreamStderr {
+			severity, severityText = otlpSeverityError, "ERROR"
+		}
+		logRecords[i] = &otlpLogRecord{
+			TimeUnixNano:         ts,
+			ObservedTimeUnixNano: ts,
+			SeverityNumber:       severity,
+			SeverityText:         severityText,
+			Body:                 &otlpAnyValue{StringValue: string(r.Line)},
+			Attributes:           []*otlpKeyValue{otlpAttribute("log.iostream", r.Stream)},
+		}
+	}
+
+	body, err := json.Marshal(&otlpExportRequest{
+		ResourceLogs: []*otlpResourceLogs{{
+			Resource: s.resource,
+			ScopeLogs: []*otlpScopeLogs{{
+				Scope:      &otlpScope{Name: otlpScopeName},
+				LogRecords: logRecords,
+			}},
+		}},
+	})
+	if err != nil {
+		return &permanentError{err}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to export logs to %q: %v", s.endpoint, err)
+	}
+	defer resp.Body.Close()
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+		return fmt.Errorf("failed to export logs to %q: %s: %s", s.endpoint, resp.Status, msg)
+	default:
+		return &permanentError{fmt.Errorf("logs rejected by %q: %s: %s", s.endpoint, resp.Status, msg)}
+	}
+}
+
+func (s *otlpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,82 @@
+package sinks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOTLPSink_Send(t *testing.T) {
+	ci.Parallel(t)
+
+	var status int32 = http.StatusOK
+	requests := make(chan *otlpExportRequest, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/logs", r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.Equal(t, "ops", r.Header.Get("X-Scope"))
+
+		var req otlpExportRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		requests <- &req
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}))
+	defer srv.Close()
+
+	sink, err := New(&structs.LogSink{
+		Name:     "collector",
+		Type:     structs.LogSinkTypeOTLP,
+		Endpoint: srv.URL + "/v1/logs",
+		Headers:  map[string]string{"X-Scope": "ops"},
+	}, testSyslogMeta, "")
+	require.NoError(t, err)
+	defer sink.Close()
+
+	ts := time.Date(2022, 5, 4, 10, 30, 0, 0, time.UTC)
+	records := []*Record{
+		{Time: ts, Stream: StreamStdout, Line: []byte("started")},
+		{Time: ts, Stream: StreamStderr, Line: []byte("failed")},
+	}
+	require.NoError(t, sink.Send(records))
+
+	req := <-requests
+	require.Len(t, req.ResourceLogs, 1)
+	attrs := map[string]string{}
+	for _, kv := range req.ResourceLogs[0].Resource.Attributes {
+		attrs[kv.Key] = kv.Value.StringValue
+	}
+	require.Equal(t, "8a3e5c2d", attrs["nomad.alloc.id"])
+	require.Equal(t, "example", attrs["nomad.job.id"])
+	require.Equal(t, "web", attrs["nomad.task"])
+
+	logRecords := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, logRecords, 2)
+	require.Equal(t, "started", logRecords[0].Body.StringValue)
+	require.Equal(t, otlpSeverityInfo, logRecords[0].SeverityNumber)
+	require.Equal(t, "1651660200000000000", logRecords[0].TimeUnixNano)
+	require.Equal(t, "failed", logRecords[1].Body.StringValue)
+	require.Equal(t, otlpSeverityError, logRecords[1].SeverityNumber)
+
+	// Throttled requests are retried
+	atomic.StoreInt32(&status, http.StatusTooManyRequests)
+	err = sink.Send(records)
+	<-requests
+	require.Error(t, err)
+	_, ok := err.(*permanentError)
+	require.False(t, ok)
+
+	// Rejected requests are dropped
+	atomic.StoreInt32(&status, http.StatusBadRequest)
+	err = sink.Send(records)
+	<-requests
+	require.Error(t, err)
+	_, ok = err.(*permanentError)
+	require.True(t, ok)
+}
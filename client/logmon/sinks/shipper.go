@@ -0,0 +1,517 @@
+package sinks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/logmon/logging"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// shipperPollInterval is the interval at which the shipper checks for new
+	// lines once it has read all the lines of the log files.
+	shipperPollInterval = 250 * time.Millisecond
+
+	// shipperMinBackoff and shipperMaxBackoff bound the time the shipper
+	// waits before sending a batch again when the sink failed to send it.
+	shipperMinBackoff = 1 * time.Second
+	shipperMaxBackoff = 30 * time.Second
+
+	// shipperDrainTimeout is the maximum time the shipper keeps shipping the
+	// remaining lines of the log files once stopped.
+	shipperDrainTimeout = 5 * time.Second
+
+	// maxRecordSize is the maximum size of a record. Longer lines are split
+	// into several records.
+	maxRecordSize = 64 * 1024
+
+	// readBufferSize is the size of the reads from the log files.
+	readBufferSize = 32 * 1024
+)
+
+// Cursor is the position in the rotated log files of a stream up to which
+// the lines have been shipped.
+type Cursor struct {
+	// Index is the index of the log file.
+	Index int `json:"index"`
+
+	// Offset is the offset in the log file.
+	Offset int64 `json:"offset"`
+}
+
+// Shipper tails the rotated log files of a stream and ships their lines to a
+// sink in batches. The shipper reads the files at the pace of the sink, so a
+// slow or unavailable sink holds back the shipping of the lines rather than
+// the output of the task. Its cursor is stored next to the log files after
+// each batch, so that shipping resumes where it left off when logmon is
+// restarted, the same way the rotator resumes writing to the last file.
+// Lines are shipped at least once.
+type Shipper struct {
+	logger hclog.Logger
+	sink   Sink
+	stream string
+
+	// dir and baseFile are the directory and base file name of the rotated
+	// log files
+	dir      string
+	baseFile string
+
+	// statePath is the path of the file the cursor is stored in
+	statePath string
+
+	batchSize    int
+	batchWait    time.Duration
+	drainTimeout time.Duration
+
+	// pos is the position after the last line read from the log files, and
+	// f and buf are the current log file and the bytes read from it after
+	// pos. r reads the uncompressed content of f.
+	pos     Cursor
+	f       *os.File
+	r       io.ReadCloser
+	buf     []byte
+	readBuf []byte
+
+	// index is the timestamp index of the current log file. It is read again
+	// when stale, once bytes have been read from the file since.
+	index      logging.LogIndex
+	indexStale bool
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	killCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+// NewShipper starts shipping the lines of the stream written to the rotated
+// log files with the base file name to the sink.
+func NewShipper(logger hclog.Logger, sink Sink, config *structs.LogSink, dir, baseFile, stream string) *Shipper {
+	s := &Shipper{
+		logger:       logger.Named("log_shipper").With("sink", config.Name, "stream", stream),
+		sink:         sink,
+		stream:       stream,
+		dir:          dir,
+		baseFile:     baseFile,
+		statePath:    filepath.Join(dir, fmt.Sprintf(".%s.%s.cursor", baseFile, url.PathEscape(config.Name))),
+		batchSize:    config.BatchSize,
+		batchWait:    config.BatchWait,
+		drainTimeout: shipperDrainTimeout,
+		readBuf:      make([]byte, readBufferSize),
+		stopCh:       make(chan struct{}),
+		killCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+	if s.batchSize == 0 {
+		s.batchSize = defaultBatchSize
+	}
+	if s.batchWait == 0 {
+		s.batchWait = defaultBatchWait
+	}
+
+	s.pos = s.loadCursor()
+	go s.run()
+	return s
+}
+
+// Stop stops the shipper once it has shipped the remaining lines of the log
+// files, waiting at most for the drain timeout.
+func (s *Shipper) Stop() {
+	s.stopOnce.Do(func() { close(s.stopCh) })
+
+	select {
+	case <-s.doneCh:
+	case <-time.After(s.drainTimeout):
+		s.logger.Warn("timed out shipping the remaining log lines")
+		close(s.killCh)
+		<-s.doneCh
+	}
+}
+
+func (s *Shipper) run() {
+	defer close(s.doneCh)
+	defer s.closeFile()
+
+	for {
+		records, drained := s.readBatch()
+		if len(records) > 0 {
+			if !s.deliver(records) {
+				return
+			}
+			s.saveCursor()
+		}
+		if drained {
+			return
+		}
+	}
+}
+
+// readBatch reads the next batch of records, waiting at most for the batch
+// wait once a record has been read. Once stopped it returns whether all the
+// lines of the log files have been read.
+func (s *Shipper) readBatch() ([]*Record, bool) {
+	var records []*Record
+	var deadline <-chan time.Time
+
+	for len(records) < s.batchSize {
+		select {
+		case <-s.killCh:
+			return nil, true
+		default:
+		}
+
+		record, ok, err := s.next()
+		if err != nil {
+			s.logger.Warn("failed to read log file", "error", err)
+		}
+		if ok {
+			records = append(records, record)
+			if deadline == nil {
+				timer := time.NewTimer(s.batchWait)
+				defer timer.Stop()
+				deadline = timer.C
+			}
+			continue
+		}
+
+		// All the lines have been read. Once stopped, the task won't write
+		// the end of its last line so it is shipped as is.
+		if s.stopped() {
+			if len(s.buf) > 0 {
+				records = append(records, s.consume(len(s.buf), 0))
+			}
+			return records, true
+		}
+
+		select {
+		case <-deadline:
+			return records, false
+		case <-s.stopCh:
+		case <-time.After(shipperPollInterval):
+		}
+	}
+	return records, false
+}
+
+// next returns the record of the next line of the log files, if a complete
+// line has been written.
+func (s *Shipper) next() (*Record, bool, error) {
+	for {
+		if i := bytes.IndexByte(s.buf, '\n'); i >= 0 {
+			record := s.consume(i, 1)
+			record.Line = bytes.TrimSuffix(record.Line, []byte{'\r'})
+			return record, true, nil
+		}
+		if len(s.buf) >= maxRecordSize {
+			return s.consume(maxRecordSize, 0), true, nil
+		}
+
+		if s.f == nil {
+			if err := s.open(); err != nil || s.f == nil {
+				return nil, false, err
+			}
+		}
+
+		n, err := s.r.Read(s.readBuf)
+		if n > 0 {
+			s.buf = append(s.buf, s.readBuf[:n]...)
+			s.indexStale = true
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+
+		// At the end of the file, move on to the next file once it has been
+		// created. The rotator flushes a file before creating the next one,
+		// so the lines written since the last read are read first.
+		next, ok := s.nextIndex()
+		if !ok {
+			return nil, false, nil
+		}
+		if n, _ := s.r.Read(s.readBuf); n > 0 {
+			s.buf = append(s.buf, s.readBuf[:n]...)
+			s.indexStale = true
+			continue
+		}
+
+		// The rotator may split lines which don't fit in a file
+		var record *Record
+		if len(s.buf) > 0 {
+			record = s.consume(len(s.buf), 0)
+		}
+		s.closeFile()
+		s.pos = Cursor{Index: next}
+		if record != nil {
+			return record, true, nil
+		}
+	}
+}
+
+// consume returns the record of the first n bytes read and skips the
+// following skip bytes, advancing the position past them.
+func (s *Shipper) consume(n, skip int) *Record {
+	record := &Record{
+		Time:   s.lineTime(s.pos.Offset),
+		Stream: s.stream,
+		Line:   make([]byte, n),
+	}
+	copy(record.Line, s.buf[:n])
+	s.buf = s.buf[n+skip:]
+	s.pos.Offset += int64(n + skip)
+	return record
+}
+
+// lineTime returns the time the line at the offset of the current log file
+// was written at, from the timestamp index of the file. The rotator records
+// an index entry before writing the bytes it covers, so reading the index
+// again after reading the bytes finds the latest entry. If the index doesn't
+// cover the offset, such as for files written before indexes were, the
+// current time is returned.
+func (s *Shipper) lineTime(offset int64) time.Time {
+	if n := len(s.index); s.indexStale && (n == 0 || s.index[n-1].Offset <= offset) {
+		s.index = s.readIndex(s.pos.Index)
+		s.indexStale = false
+	}
+	if t := s.index.Time(offset); !t.IsZero() {
+		return t
+	}
+	return time.Now()
+}
+
+// readIndex returns the timestamp index of the log file with the given index,
+// or nil if it can't be read.
+func (s *Shipper) readIndex(idx int) logging.LogIndex {
+	f, err := os.Open(filepath.Join(s.dir, logging.IndexFileName(s.baseFile, idx)))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	index, err := logging.ReadLogIndex(f)
+	if err != nil {
+		s.logger.Warn("failed to read log index", "file", f.Name(), "error", err)
+	}
+	return index
+}
+
+// open opens the log file of the position. If the file was removed by the
+// rotator before it was shipped, the position is moved to the next file.
+func (s *Shipper) open() error {
+	f, compression, err := s.openLogFile(s.pos.Index)
+	if os.IsNotExist(err) {
+		next, ok := s.nextIndex()
+		if !ok {
+			return nil
+		}
+		s.logger.Warn("log file removed before it was shipped", "file", s.logFile(s.pos.Index))
+		s.pos = Cursor{Index: next}
+		return s.open()
+	} else if err != nil {
+		return err
+	}
+
+	// Compressed files can't be seeked, so the lines already shipped are
+	// skipped once uncompressed. The offset is past the end of the file if
+	// it was truncated.
+	var r io.ReadCloser
+	if compression == logging.CompressionNone {
+		_, err = f.Seek(s.pos.Offset, io.SeekStart)
+		r = ioutil.NopCloser(f)
+	} else if r, err = logging.NewDecompressor(f, compression); err == nil {
+		_, err = io.CopyN(ioutil.Discard, r, s.pos.Offset)
+		if err == io.EOF {
+			err = nil
+		}
+	}
+	if err != nil {
+		if r != nil {
+			r.Close()
+		}
+		f.Close()
+		return err
+	}
+
+	s.f = f
+	s.r = r
+	s.buf = s.buf[:0]
+	s.index = nil
+	s.indexStale = true
+	return nil
+}
+
+// openLogFile opens the log file with the given index, which is replaced by a
+// compressed version once rotated if compression is enabled.
+func (s *Shipper) openLogFile(idx int) (*os.File, string, error) {
+	f, err := os.Open(s.logFile(idx))
+	if !os.IsNotExist(err) {
+		return f, logging.CompressionNone, err
+	}
+	for _, compression := range []string{logging.CompressionGzip, logging.CompressionZstd} {
+		path := filepath.Join(s.dir, logging.LogFileName(s.baseFile, idx, compression))
+		if f, err := os.Open(path); !os.IsNotExist(err) {
+			return f, compression, err
+		}
+	}
+	return nil, "", err
+}
+
+func (s *Shipper) closeFile() {
+	if s.f != nil {
+		s.r.Close()
+		s.f.Close()
+		s.f = nil
+		s.r = nil
+	}
+}
+
+// nextIndex returns the smallest index of the log files after the index of
+// the position, if any.
+func (s *Shipper) nextIndex() (int, bool) {
+	indexes, err := s.logIndexes()
+	if err != nil {
+		s.logger.Warn("failed to list log files", "error", err)
+		return 0, false
+	}
+
+	next, ok := 0, false
+	for _, idx := range indexes {
+		if idx > s.pos.Index && (!ok || idx < next) {
+			next, ok = idx, true
+		}
+	}
+	return next, ok
+}
+
+// logIndexes returns the indexes of the rotated log files, compressed or
+// not.
+func (s *Shipper) logIndexes() ([]int, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexes []int
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+		idx, _, ok := logging.ParseLogFileName(s.baseFile, fi.Name())
+		if !ok {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes, nil
+}
+
+func (s *Shipper) logFile(idx int) string {
+	return filepath.Join(s.dir, logging.LogFileName(s.baseFile, idx, logging.CompressionNone))
+}
+
+// deliver sends the records to the sink, retrying with a backoff until they
+// are sent or dropped. Returns false if the shipper was killed first.
+func (s *Shipper) deliver(records []*Record) bool {
+	backoff := shipperMinBackoff
+	for {
+		err := s.sink.Send(records)
+		if err == nil {
+			return true
+		}
+		if _, ok := err.(*permanentError); ok {
+			s.logger.Error("dropping log lines rejected by sink", "lines", len(records), "error", err)
+			return true
+		}
+
+		s.logger.Warn("failed to ship log lines", "error", err, "retry_in", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-s.killCh:
+			return false
+		}
+
+		backoff *= 2
+		if backoff > shipperMaxBackoff {
+			backoff = shipperMaxBackoff
+		}
+	}
+}
+
+func (s *Shipper) stopped() bool {
+	select {
+	case <-s.stopCh:
+		return true
+	default:
+		return false
+	}
+}
+
+// loadCursor returns the stored cursor, or the start of the oldest log file
+// if none was stored.
+func (s *Shipper) loadCursor() Cursor {
+	var cursor Cursor
+	buf, err := ioutil.ReadFile(s.statePath)
+	if err == nil {
+		if err := json.Unmarshal(buf, &cursor); err == nil {
+			return cursor
+		}
+		s.logger.Warn("failed to decode cursor; shipping all the log files", "file", s.statePath, "error", err)
+	} else if !os.IsNotExist(err) {
+		s.logger.Warn("failed to read cursor; shipping all the log files", "file", s.statePath, "error", err)
+	}
+
+	indexes, err := s.logIndexes()
+	if err != nil {
+		return cursor
+	}
+	for i, idx := range indexes {
+		if i == 0 || idx < cursor.Index {
+			cursor.Index = idx
+		}
+	}
+	return cursor
+}
+
+// saveCursor stores the position as the cursor, replacing the file
+// atomically. The log directory is writable by the task, so the temporary
+// file is always created anew, which fails rather than follows a symlink
+// planted in its place.
+func (s *Shipper) saveCursor() {
+	buf, err := json.Marshal(&s.pos)
+	if err != nil {
+		s.logger.Warn("failed to encode cursor", "error", err)
+		return
+	}
+
+	tmp := s.statePath + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("failed to write cursor", "file", tmp, "error", err)
+		return
+	}
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		s.logger.Warn("failed to write cursor", "file", tmp, "error", err)
+		return
+	}
+	_, err = f.Write(buf)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		s.logger.Warn("failed to write cursor", "file", tmp, "error", err)
+		return
+	}
+	if err := os.Rename(tmp, s.statePath); err != nil {
+		s.logger.Warn("failed to write cursor", "file", s.statePath, "error", err)
+	}
+}
@@ -0,0 +1,336 @@
+package sinks
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/client/logmon/logging"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// testSink records the lines it is sent, failing while fail is set.
+type testSink struct {
+	l     sync.Mutex
+	lines []string
+	times []time.Time
+	fail  error
+	sends int
+}
+
+func (s *testSink) Send(records []*Record) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.sends++
+	if s.fail != nil {
+		return s.fail
+	}
+	for _, r := range records {
+		s.lines = append(s.lines, string(r.Line))
+		s.times = append(s.times, r.Time)
+	}
+	return nil
+}
+
+func (s *testSink) Close() error { return nil }
+
+func (s *testSink) Lines() []string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return append([]string(nil), s.lines...)
+}
+
+func (s *testSink) Times() []time.Time {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return append([]time.Time(nil), s.times...)
+}
+
+func (s *testSink) SetFail(err error) {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.fail = err
+}
+
+func waitForLines(t *testing.T, sink *testSink, expected []string) {
+	testutil.WaitForResult(func() (bool, error) {
+		lines := sink.Lines()
+		if len(lines) != len(expected) {
+			return false, fmt.Errorf("expected %d lines, got %d: %v", len(expected), len(lines), lines)
+		}
+		for i := range lines {
+			if lines[i] != expected[i] {
+				return false, fmt.Errorf("expected lines %v, got %v", expected, lines)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+}
+
+func TestShipper_RotatedFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	logger := testlog.HCLogger(t)
+	rotator, err := logging.NewFileRotator(dir, "web.stdout", 10, 40, logger)
+	require.NoError(t, err)
+	defer rotator.Close()
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(logger, sink, config, dir, "web.stdout", StreamStdout)
+
+	// Write enough lines for the rotator to create several files
+	var expected []string
+	for i := 0; i < 10; i++ {
+		line := fmt.Sprintf("line %d of the log", i)
+		expected = append(expected, line)
+		_, err := rotator.Write([]byte(line + "\n"))
+		require.NoError(t, err)
+	}
+	waitForLines(t, sink, expected)
+
+	_, err = os.Stat(filepath.Join(dir, "web.stdout.3"))
+	require.NoError(t, err)
+
+	shipper.Stop()
+
+	// A new shipper resumes after the lines already shipped
+	_, err = rotator.Write([]byte("after restart\n"))
+	require.NoError(t, err)
+
+	sink2 := &testSink{}
+	shipper2 := NewShipper(logger, sink2, config, dir, "web.stdout", StreamStdout)
+	defer shipper2.Stop()
+	waitForLines(t, sink2, []string{"after restart"})
+}
+
+func TestShipper_PartialLines(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "web.stdout.0"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+
+	// Lines are only shipped once complete
+	_, err = f.WriteString("first\npar")
+	require.NoError(t, err)
+	waitForLines(t, sink, []string{"first"})
+	time.Sleep(2 * shipperPollInterval)
+	require.Equal(t, []string{"first"}, sink.Lines())
+
+	_, err = f.WriteString("tial\r\nlast")
+	require.NoError(t, err)
+	waitForLines(t, sink, []string{"first", "partial"})
+
+	// The last line is shipped as is once stopped
+	shipper.Stop()
+	require.Equal(t, []string{"first", "partial", "last"}, sink.Lines())
+}
+
+func TestShipper_IndexTime(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "web.stdout.0"))
+	require.NoError(t, err)
+	defer f.Close()
+	index, err := os.Create(filepath.Join(dir, logging.IndexFileName("web.stdout", 0)))
+	require.NoError(t, err)
+	defer index.Close()
+
+	t1 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+	t3 := t2.Add(time.Minute)
+
+	// The index records the time of the offsets the lines are written at
+	_, err = fmt.Fprintf(index, "%d 0\n%d 6\n", t1.UnixNano(), t2.UnixNano())
+	require.NoError(t, err)
+	_, err = f.WriteString("first\nsecond\nthird\n")
+	require.NoError(t, err)
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+	defer shipper.Stop()
+	waitForLines(t, sink, []string{"first", "second", "third"})
+
+	// Lines written after the index was read get the time of the entries
+	// recorded before them
+	_, err = fmt.Fprintf(index, "%d 19\n", t3.UnixNano())
+	require.NoError(t, err)
+	_, err = f.WriteString("fourth\n")
+	require.NoError(t, err)
+	waitForLines(t, sink, []string{"first", "second", "third", "fourth"})
+
+	times := sink.Times()
+	for i, expected := range []time.Time{t1, t2, t2, t3} {
+		require.True(t, expected.Equal(times[i]), "expected %v, got %v", expected, times[i])
+	}
+}
+
+func TestShipper_Retry(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stderr.0"), []byte("one\ntwo\n"), 0644))
+
+	sink := &testSink{fail: errors.New("unavailable")}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stderr", StreamStderr)
+	defer shipper.Stop()
+
+	// The lines are shipped once the sink is available
+	testutil.WaitForResult(func() (bool, error) {
+		sink.l.Lock()
+		defer sink.l.Unlock()
+		return sink.sends > 0, fmt.Errorf("batch wasn't sent")
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+	sink.SetFail(nil)
+	waitForLines(t, sink, []string{"one", "two"})
+
+	// Lines rejected permanently are dropped
+	sink.SetFail(&permanentError{errors.New("rejected")})
+	f, err := os.OpenFile(filepath.Join(dir, "web.stderr.0"), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.WriteString("three\n")
+	require.NoError(t, err)
+
+	testutil.WaitForResult(func() (bool, error) {
+		buf, err := ioutil.ReadFile(filepath.Join(dir, ".web.stderr.test.cursor"))
+		if err != nil {
+			return false, err
+		}
+		var cursor Cursor
+		if err := json.Unmarshal(buf, &cursor); err != nil {
+			return false, err
+		}
+		return cursor.Offset == 14, fmt.Errorf("unexpected cursor %#v", cursor)
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	sink.SetFail(nil)
+	_, err = f.WriteString("four\n")
+	require.NoError(t, err)
+	waitForLines(t, sink, []string{"one", "two", "four"})
+}
+
+func TestShipper_RemovedFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stdout.5"), []byte("five\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stdout.7"), []byte("seven\n"), 0644))
+
+	// The cursor points to a file removed by the rotator
+	cursor, err := json.Marshal(&Cursor{Index: 3, Offset: 10})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".web.stdout.test.cursor"), cursor, 0644))
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+	defer shipper.Stop()
+	waitForLines(t, sink, []string{"five", "seven"})
+}
+
+func TestShipper_CompressedFiles(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte("zero\none\n"))
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stdout.0.gz"), buf.Bytes(), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stdout.1"), []byte("two\n"), 0644))
+
+	// The cursor points into the file compressed once rotated
+	cursor, err := json.Marshal(&Cursor{Index: 0, Offset: 5})
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".web.stdout.test.cursor"), cursor, 0644))
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+	defer shipper.Stop()
+	waitForLines(t, sink, []string{"one", "two"})
+}
+
+func TestShipper_JSONFile(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "web.stdout.0"), []byte("hello\nworld\n"), 0644))
+
+	meta := &Metadata{
+		AllocID:   "8a3e5c2d",
+		JobID:     "example",
+		Namespace: "default",
+		TaskGroup: "cache",
+		Task:      "web",
+	}
+	config := &structs.LogSink{
+		Name:      "json",
+		Type:      structs.LogSinkTypeJSONFile,
+		Path:      "shipped/web.json",
+		BatchWait: 10 * time.Millisecond,
+	}
+	sink, err := New(config, meta, dir)
+	require.NoError(t, err)
+
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+	shipper.Stop()
+	require.NoError(t, sink.Close())
+
+	f, err := os.Open(filepath.Join(dir, "shipped", "web.json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var records []*jsonFileRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r jsonFileRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, &r)
+	}
+	require.Len(t, records, 2)
+	require.Equal(t, "hello", records[0].Message)
+	require.Equal(t, "world", records[1].Message)
+	for _, r := range records {
+		require.Equal(t, StreamStdout, r.Stream)
+		require.Equal(t, "8a3e5c2d", r.AllocID)
+		require.Equal(t, "example", r.JobID)
+		require.Equal(t, "default", r.Namespace)
+		require.Equal(t, "cache", r.TaskGroup)
+		require.Equal(t, "web", r.Task)
+		_, err := time.Parse(time.RFC3339Nano, r.Time)
+		require.NoError(t, err)
+	}
+}
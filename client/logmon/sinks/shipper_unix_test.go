@@ -0,0 +1,57 @@
+//go:build !windows
+// +build !windows
+
+package sinks
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShipper_CursorSymlink(t *testing.T) {
+	ci.Parallel(t)
+
+	outside := t.TempDir()
+	target := filepath.Join(outside, "target")
+	require.NoError(t, os.WriteFile(target, []byte("secret"), 0644))
+
+	// The task plants a symlink in place of the temporary cursor file
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "web.stdout.0"), []byte("hello\n"), 0644))
+	require.NoError(t, os.Symlink(target, filepath.Join(dir, ".web.stdout.test.cursor.tmp")))
+
+	sink := &testSink{}
+	config := &structs.LogSink{Name: "test", BatchWait: 10 * time.Millisecond}
+	shipper := NewShipper(testlog.HCLogger(t), sink, config, dir, "web.stdout", StreamStdout)
+	defer shipper.Stop()
+	waitForLines(t, sink, []string{"hello"})
+
+	testutil.WaitForResult(func() (bool, error) {
+		buf, err := os.ReadFile(filepath.Join(dir, ".web.stdout.test.cursor"))
+		if err != nil {
+			return false, err
+		}
+		var cursor Cursor
+		if err := json.Unmarshal(buf, &cursor); err != nil {
+			return false, err
+		}
+		return cursor.Offset == 6, fmt.Errorf("unexpected cursor %#v", cursor)
+	}, func(err error) {
+		require.NoError(t, err)
+	})
+
+	// The file outside of the log directory is untouched
+	buf, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(buf))
+}
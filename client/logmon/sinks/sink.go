@@ -0,0 +1,87 @@
+package sinks
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// StreamStdout is the stream of the lines written to stdout.
+	StreamStdout = "stdout"
+
+	// StreamStderr is the stream of the lines written to stderr.
+	StreamStderr = "stderr"
+
+	// defaultBatchSize is the maximum number of lines shipped at once when
+	// the sink doesn't set a batch size.
+	defaultBatchSize = 100
+
+	// defaultBatchWait is the maximum time lines wait to fill a batch when
+	// the sink doesn't set a batch wait.
+	defaultBatchWait = 1 * time.Second
+
+	// defaultSyslogFacility is the syslog facility of the messages when the
+	// sink doesn't set one.
+	defaultSyslogFacility = "local0"
+)
+
+// Metadata identifies the task whose logs are shipped.
+type Metadata struct {
+	AllocID   string
+	JobID     string
+	Namespace string
+	TaskGroup string
+	Task      string
+}
+
+// Record is a line of output of a task.
+type Record struct {
+	// Time is the approximate time the line was written at, derived from the
+	// timestamp index of the log file, which has a resolution of a second.
+	Time time.Time
+
+	// Stream is the stream the line was written to, stdout or stderr.
+	Stream string
+
+	// Line is the content of the line, without the new line.
+	Line []byte
+}
+
+// Sink ships the lines of the tasks to an external destination. Sinks are
+// shared by the shippers of the stdout and stderr streams of a task, so they
+// must be safe for concurrent use.
+type Sink interface {
+	// Send ships the records. If an error is returned the records are sent
+	// again later, unless the error is permanent.
+	Send(records []*Record) error
+
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// permanentError is returned by sinks when sending the records again would
+// fail the same way, so that the records are dropped instead.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// New returns the sink of the configuration for the task. The paths of JSON
+// files are relative to the log directory.
+func New(config *structs.LogSink, meta *Metadata, logDir string) (Sink, error) {
+	switch config.Type {
+	case structs.LogSinkTypeSyslog:
+		return newSyslogSink(config, meta)
+	case structs.LogSinkTypeJSONFile:
+		return newJSONFileSink(config, meta, logDir)
+	case structs.LogSinkTypeOTLP:
+		return newOTLPSink(config, meta)
+	default:
+		return nil, fmt.Errorf("unknown log sink type %q", config.Type)
+	}
+}
@@ -0,0 +1,217 @@
+package sinks
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// syslogDialTimeout is the timeout of connecting to the syslog server.
+	syslogDialTimeout = 5 * time.Second
+
+	// syslogWriteTimeout is the timeout of writing a batch of messages to the
+	// syslog server.
+	syslogWriteTimeout = 10 * time.Second
+
+	// syslogSDID is the ID of the structured data element holding the
+	// metadata of the allocation. 32473 is the private enterprise number
+	// reserved for documentation.
+	syslogSDID = "nomad@32473"
+
+	// syslogSeverityInfo and syslogSeverityErr are the severities of the
+	// lines written to stdout and stderr.
+	syslogSeverityInfo = 6
+	syslogSeverityErr  = 3
+
+	// syslogMaxAppName is the maximum length of the APP-NAME field.
+	syslogMaxAppName = 48
+)
+
+// syslogFacilityCodes maps the names of the syslog facilities to their codes.
+var syslogFacilityCodes = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSink ships the lines of the task to a syslog server as RFC5424
+// messages. Messages sent over TCP are framed with octet counting as
+// described in RFC6587, and messages sent over UDP or unix datagram sockets
+// are sent one per datagram.
+type syslogSink struct {
+	network  string
+	address  string
+	facility int
+	hostname string
+	appName  string
+	sd       string
+
+	l    sync.Mutex
+	conn net.Conn
+}
+
+func newSyslogSink(config *structs.LogSink, meta *Metadata) (*syslogSink, error) {
+	u, err := url.Parse(config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address %q: %v", config.Address, err)
+	}
+
+	s := &syslogSink{network: u.Scheme}
+	switch u.Scheme {
+	case "tcp", "udp":
+		s.address = u.Host
+	case "unix", "unixgram":
+		s.address = u.Path
+	default:
+		return nil, fmt.Errorf("unsupported syslog address scheme %q", u.Scheme)
+	}
+
+	facility := config.Facility
+	if facility == "" {
+		facility = defaultSyslogFacility
+	}
+	code, ok := syslogFacilityCodes[facility]
+	if !ok {
+		return nil, fmt.Errorf("unknown syslog facility %q", facility)
+	}
+	s.facility = code
+
+	s.hostname, _ = os.Hostname()
+	if s.hostname == "" {
+		s.hostname = "-"
+	}
+
+	s.appName = config.Tag
+	if s.appName == "" {
+		s.appName = meta.Task
+	}
+	s.appName = syslogHeaderField(s.appName, syslogMaxAppName)
+
+	s.sd = fmt.Sprintf(`[%s alloc_id="%s" job_id="%s" namespace="%s" task_group="%s" task="%s"]`,
+		syslogSDID,
+		syslogParamValue(meta.AllocID),
+		syslogParamValue(meta.JobID),
+		syslogParamValue(meta.Namespace),
+		syslogParamValue(meta.TaskGroup),
+		syslogParamValue(meta.Task))
+
+	return s, nil
+}
+
+// Send writes the messages of the records to the syslog server, connecting
+// to it first if needed. The connection is closed on errors so that the
+// records are sent over a new connection when retried.
+func (s *syslogSink) Send(records []*Record) error {
+	s.l.Lock()
+	defer s.l.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial()
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog server %q: %v", s.address, err)
+		}
+		s.conn = conn
+	}
+
+	s.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout))
+
+	if s.network == "tcp" {
+		var buf bytes.Buffer
+		for _, r := range records {
+			msg := s.format(r)
+			fmt.Fprintf(&buf, "%d %s", len(msg), msg)
+		}
+		if _, err := s.conn.Write(buf.Bytes()); err != nil {
+			s.closeConn()
+			return fmt.Errorf("failed to write to syslog server %q: %v", s.address, err)
+		}
+		return nil
+	}
+
+	for _, r := range records {
+		if _, err := s.conn.Write(s.format(r)); err != nil {
+			s.closeConn()
+			return fmt.Errorf("failed to write to syslog server %q: %v", s.address, err)
+		}
+	}
+	return nil
+}
+
+// dial connects to the syslog server. Unix sockets are most often datagram
+// sockets, so stream sockets are only used if that fails.
+func (s *syslogSink) dial() (net.Conn, error) {
+	if s.network != "unix" {
+		return net.DialTimeout(s.network, s.address, syslogDialTimeout)
+	}
+
+	conn, err := net.DialTimeout("unixgram", s.address, syslogDialTimeout)
+	if err == nil {
+		return conn, nil
+	}
+	return net.DialTimeout("unix", s.address, syslogDialTimeout)
+}
+
+// format returns the RFC5424 message of the record.
+func (s *syslogSink) format(r *Record) []byte {
+	severity := syslogSeverityInfo
+	if r.Stream == StreamStderr {
+		severity = syslogSeverityErr
+	}
+
+	// <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA MSG
+	return []byte(fmt.Sprintf("<%d>1 %s %s %s - %s %s %s",
+		s.facility*8+severity,
+		r.Time.UTC().Format(time.RFC3339Nano),
+		s.hostname,
+		s.appName,
+		r.Stream,
+		s.sd,
+		r.Line))
+}
+
+func (s *syslogSink) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *syslogSink) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	s.closeConn()
+	return nil
+}
+
+// syslogHeaderField returns the value as a header field, which is limited to
+// printable US-ASCII characters without spaces.
+func syslogHeaderField(value string, max int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '_'
+		}
+		return r
+	}, value)
+	if len(field) > max {
+		field = field[:max]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
+
+// syslogParamValue escapes the characters which must be escaped in the
+// values of structured data parameters.
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
@@ -0,0 +1,137 @@
+package sinks
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+var testSyslogMeta = &Metadata{
+	AllocID:   "8a3e5c2d",
+	JobID:     "example",
+	Namespace: "default",
+	TaskGroup: "cache",
+	Task:      "web",
+}
+
+func testSyslogRecords() []*Record {
+	ts := time.Date(2022, 5, 4, 10, 30, 0, 0, time.UTC)
+	return []*Record{
+		{Time: ts, Stream: StreamStdout, Line: []byte("started")},
+		{Time: ts, Stream: StreamStderr, Line: []byte("failed")},
+	}
+}
+
+func TestSyslogSink_Format(t *testing.T) {
+	ci.Parallel(t)
+
+	sink, err := newSyslogSink(&structs.LogSink{
+		Name:     "siem",
+		Type:     structs.LogSinkTypeSyslog,
+		Address:  "udp://127.0.0.1:514",
+		Facility: "local3",
+		Tag:      "my app",
+	}, testSyslogMeta)
+	require.NoError(t, err)
+	sink.hostname = "host"
+
+	records := testSyslogRecords()
+	require.Equal(t,
+		`<158>1 2022-05-04T10:30:00Z host my_app - stdout [nomad@32473 alloc_id="8a3e5c2d" job_id="example" namespace="default" task_group="cache" task="web"] started`,
+		string(sink.format(records[0])))
+	require.Equal(t,
+		`<155>1 2022-05-04T10:30:00Z host my_app - stderr [nomad@32473 alloc_id="8a3e5c2d" job_id="example" namespace="default" task_group="cache" task="web"] failed`,
+		string(sink.format(records[1])))
+
+	require.Equal(t, `a\"b\\c\]`, syslogParamValue(`a"b\c]`))
+}
+
+func TestSyslogSink_TCP(t *testing.T) {
+	ci.Parallel(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	msgs := make(chan string, 2)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Read the octet counted messages
+		r := bufio.NewReader(conn)
+		for {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			msgs <- string(msg)
+		}
+	}()
+
+	sink, err := New(&structs.LogSink{
+		Name:    "siem",
+		Type:    structs.LogSinkTypeSyslog,
+		Address: fmt.Sprintf("tcp://%s", l.Addr()),
+	}, testSyslogMeta, "")
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(testSyslogRecords()))
+	for _, suffix := range []string{"] started", "] failed"} {
+		select {
+		case msg := <-msgs:
+			require.True(t, strings.HasPrefix(msg, "<13"), msg)
+			require.True(t, strings.HasSuffix(msg, suffix), msg)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for syslog message")
+		}
+	}
+}
+
+func TestSyslogSink_UDP(t *testing.T) {
+	ci.Parallel(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sink, err := New(&structs.LogSink{
+		Name:    "siem",
+		Type:    structs.LogSinkTypeSyslog,
+		Address: fmt.Sprintf("udp://%s", conn.LocalAddr()),
+	}, testSyslogMeta, "")
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(testSyslogRecords()))
+
+	// Each message is sent in its own datagram
+	buf := make([]byte, 1024)
+	for _, suffix := range []string{"] started", "] failed"} {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		require.True(t, strings.HasSuffix(string(buf[:n]), suffix), string(buf[:n]))
+	}
+}
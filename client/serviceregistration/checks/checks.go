@@ -0,0 +1,192 @@
+// Package checks implements the HTTP and TCP checks the Nomad client runs for
+// services using the nomad provider.
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// outputSizeLimit is the maximum number of bytes of an HTTP check
+	// response body kept as the output of the check.
+	outputSizeLimit = 4 * 1024
+)
+
+// Query is the information required to execute a check against a service.
+type Query struct {
+	// Type is the type of the check, either http or tcp.
+	Type string
+
+	// Timeout is the maximum duration of the check.
+	Timeout time.Duration
+
+	// Address and Port are the address of the service to check.
+	Address string
+	Port    int
+
+	// Protocol, Path, Method, Headers and Body configure HTTP checks.
+	Protocol      string
+	Path          string
+	Method        string
+	Headers       map[string][]string
+	Body          string
+	TLSSkipVerify bool
+}
+
+// GetCheckQuery returns the Query for the check against the service at the
+// given address.
+func GetCheckQuery(check *structs.ServiceCheck, address string, port int) *Query {
+	protocol := check.Protocol
+	if protocol == "" {
+		protocol = "http"
+	}
+	method := check.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return &Query{
+		Type:          strings.ToLower(check.Type),
+		Timeout:       check.Timeout,
+		Address:       address,
+		Port:          port,
+		Protocol:      protocol,
+		Path:          check.Path,
+		Method:        method,
+		Headers:       check.Header,
+		Body:          check.Body,
+		TLSSkipVerify: check.TLSSkipVerify,
+	}
+}
+
+// Checker executes check queries.
+type Checker interface {
+	// Do executes the check query and returns its result. Only the status,
+	// status code, output and timestamp of the result are set.
+	Do(ctx context.Context, q *Query) *structs.CheckQueryResult
+}
+
+// NewChecker returns a Checker which executes HTTP and TCP checks.
+func NewChecker() Checker {
+	return &checker{
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				DisableKeepAlives: true,
+			},
+		},
+		httpClientSkipVerify: &http.Client{
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				DisableKeepAlives: true,
+				TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+			},
+		},
+	}
+}
+
+type checker struct {
+	httpClient           *http.Client
+	httpClientSkipVerify *http.Client
+}
+
+func (c *checker) Do(ctx context.Context, q *Query) *structs.CheckQueryResult {
+	if q.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, q.Timeout)
+		defer cancel()
+	}
+
+	var result *structs.CheckQueryResult
+	switch q.Type {
+	case structs.ServiceCheckHTTP:
+		result = c.checkHTTP(ctx, q)
+	case structs.ServiceCheckTCP:
+		result = c.checkTCP(ctx, q)
+	default:
+		result = failure(fmt.Sprintf("unsupported check type %q", q.Type))
+	}
+	result.Timestamp = time.Now().UTC().Unix()
+	return result
+}
+
+func (c *checker) checkTCP(ctx context.Context, q *Query) *structs.CheckQueryResult {
+	addr := net.JoinHostPort(q.Address, strconv.Itoa(q.Port))
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return failure(err.Error())
+	}
+	_ = conn.Close()
+
+	return &structs.CheckQueryResult{
+		Status: structs.CheckSuccess,
+		Output: fmt.Sprintf("TCP connect %s: success", addr),
+	}
+}
+
+func (c *checker) checkHTTP(ctx context.Context, q *Query) *structs.CheckQueryResult {
+	addr := net.JoinHostPort(q.Address, strconv.Itoa(q.Port))
+	u := fmt.Sprintf("%s://%s%s", q.Protocol, addr, q.Path)
+
+	var body io.Reader
+	if q.Body != "" {
+		body = strings.NewReader(q.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, q.Method, u, body)
+	if err != nil {
+		return failure(err.Error())
+	}
+	for name, values := range q.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	client := c.httpClient
+	if q.TLSSkipVerify {
+		client = c.httpClientSkipVerify
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return failure(err.Error())
+	}
+	defer resp.Body.Close()
+
+	output, err := io.ReadAll(io.LimitReader(resp.Body, outputSizeLimit))
+	if err != nil {
+		return failure(err.Error())
+	}
+
+	result := &structs.CheckQueryResult{
+		Status:     structs.CheckSuccess,
+		StatusCode: resp.StatusCode,
+		Output:     string(output),
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		result.Status = structs.CheckFailure
+	}
+	return result
+}
+
+func failure(output string) *structs.CheckQueryResult {
+	return &structs.CheckQueryResult{
+		Status: structs.CheckFailure,
+		Output: output,
+	}
+}
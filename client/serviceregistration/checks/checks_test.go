@@ -0,0 +1,106 @@
+package checks
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func splitURL(t *testing.T, u string) (string, int) {
+	parsed, err := url.Parse(u)
+	require.NoError(t, err)
+	host, portStr, err := net.SplitHostPort(parsed.Host)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func TestChecker_Do_HTTP(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fail":
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("down"))
+		case "/host":
+			_, _ = w.Write([]byte(r.Host))
+		default:
+			_, _ = w.Write([]byte("up"))
+		}
+	}))
+	defer ts.Close()
+
+	address, port := splitURL(t, ts.URL)
+	checker := NewChecker()
+
+	testCases := []struct {
+		name           string
+		check          *structs.ServiceCheck
+		expectedStatus structs.CheckStatus
+		expectedCode   int
+		expectedOutput string
+	}{
+		{
+			name:           "success",
+			check:          &structs.ServiceCheck{Type: "http", Path: "/", Timeout: time.Second},
+			expectedStatus: structs.CheckSuccess,
+			expectedCode:   http.StatusOK,
+			expectedOutput: "up",
+		},
+		{
+			name:           "failure",
+			check:          &structs.ServiceCheck{Type: "http", Path: "/fail", Timeout: time.Second},
+			expectedStatus: structs.CheckFailure,
+			expectedCode:   http.StatusInternalServerError,
+			expectedOutput: "down",
+		},
+		{
+			name: "host header",
+			check: &structs.ServiceCheck{
+				Type:    "http",
+				Path:    "/host",
+				Timeout: time.Second,
+				Header:  map[string][]string{"Host": {"example.com"}},
+			},
+			expectedStatus: structs.CheckSuccess,
+			expectedCode:   http.StatusOK,
+			expectedOutput: "example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := checker.Do(context.Background(), GetCheckQuery(tc.check, address, port))
+			require.Equal(t, tc.expectedStatus, result.Status)
+			require.Equal(t, tc.expectedCode, result.StatusCode)
+			require.Equal(t, tc.expectedOutput, result.Output)
+			require.NotZero(t, result.Timestamp)
+		})
+	}
+}
+
+func TestChecker_Do_TCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	checker := NewChecker()
+	check := &structs.ServiceCheck{Type: "tcp", Timeout: time.Second}
+
+	result := checker.Do(context.Background(), GetCheckQuery(check, "127.0.0.1", port))
+	require.Equal(t, structs.CheckSuccess, result.Status)
+
+	// Nothing listens on the port once the listener is closed.
+	require.NoError(t, ln.Close())
+	result = checker.Do(context.Background(), GetCheckQuery(check, "127.0.0.1", port))
+	require.Equal(t, structs.CheckFailure, result.Status)
+	require.NotEmpty(t, result.Output)
+}
@@ -0,0 +1,79 @@
+// Package checkstore stores the results of the checks the Nomad client runs
+// for services using the nomad provider.
+package checkstore
+
+import (
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Store holds the latest result of each check, by allocation. It is safe for
+// concurrent use.
+type Store struct {
+	lock sync.RWMutex
+
+	// results maps allocation IDs to the latest result of each check.
+	results map[string]map[structs.CheckID]*structs.CheckQueryResult
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		results: make(map[string]map[structs.CheckID]*structs.CheckQueryResult),
+	}
+}
+
+// Set stores the result of a check of the allocation, replacing the previous
+// result of the check.
+func (s *Store) Set(allocID string, result *structs.CheckQueryResult) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.results[allocID]; !ok {
+		s.results[allocID] = make(map[structs.CheckID]*structs.CheckQueryResult)
+	}
+	s.results[allocID][result.ID] = result.Copy()
+}
+
+// Get returns the latest result of a check of the allocation, if any.
+func (s *Store) Get(allocID string, id structs.CheckID) (*structs.CheckQueryResult, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result, ok := s.results[allocID][id]
+	return result.Copy(), ok
+}
+
+// List returns the latest results of the checks of the allocation.
+func (s *Store) List(allocID string) map[structs.CheckID]*structs.CheckQueryResult {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	results := make(map[structs.CheckID]*structs.CheckQueryResult, len(s.results[allocID]))
+	for id, result := range s.results[allocID] {
+		results[id] = result.Copy()
+	}
+	return results
+}
+
+// Remove deletes the results of the given checks of the allocation.
+func (s *Store) Remove(allocID string, ids []structs.CheckID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, id := range ids {
+		delete(s.results[allocID], id)
+	}
+	if len(s.results[allocID]) == 0 {
+		delete(s.results, allocID)
+	}
+}
+
+// Purge deletes the results of all the checks of the allocation.
+func (s *Store) Purge(allocID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.results, allocID)
+}
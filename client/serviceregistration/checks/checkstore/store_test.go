@@ -0,0 +1,37 @@
+package checkstore
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore()
+
+	s.Set("alloc1", &structs.CheckQueryResult{ID: "check1", Status: structs.CheckSuccess})
+	s.Set("alloc1", &structs.CheckQueryResult{ID: "check2", Status: structs.CheckFailure})
+	s.Set("alloc2", &structs.CheckQueryResult{ID: "check3", Status: structs.CheckPending})
+
+	result, ok := s.Get("alloc1", "check2")
+	require.True(t, ok)
+	require.Equal(t, structs.CheckFailure, result.Status)
+
+	// Results returned are copies.
+	result.Status = structs.CheckSuccess
+	result, _ = s.Get("alloc1", "check2")
+	require.Equal(t, structs.CheckFailure, result.Status)
+
+	_, ok = s.Get("alloc2", "check1")
+	require.False(t, ok)
+
+	require.Len(t, s.List("alloc1"), 2)
+	require.Empty(t, s.List("alloc3"))
+
+	s.Remove("alloc1", []structs.CheckID{"check1"})
+	require.Len(t, s.List("alloc1"), 1)
+
+	s.Purge("alloc2")
+	require.Empty(t, s.List("alloc2"))
+}
@@ -14,6 +14,9 @@ const (
 	// nomadTaskPrefix is the prefix that scopes Nomad registered services
 	// for tasks.
 	nomadTaskPrefix = nomadServicePrefix + "-task-"
+
+	// nomadCheckPrefix is the prefix that scopes Nomad registered checks.
+	nomadCheckPrefix = nomadServicePrefix + "-check-"
 )
 
 // MakeAllocServiceID creates a unique ID for identifying an alloc service in
@@ -25,3 +28,12 @@ func MakeAllocServiceID(allocID, taskName string, service *structs.Service) stri
 	return fmt.Sprintf("%s%s-%s-%s-%s",
 		nomadTaskPrefix, allocID, taskName, service.Name, service.PortLabel)
 }
+
+// MakeCheckID creates a unique ID for identifying a check of a service in a
+// service registration provider. It uses the same format as the IDs of checks
+// registered in Consul.
+//
+// Example Check ID: _nomad-check-434ae42f9a57c5705344974ac38de2aee0ee089d
+func MakeCheckID(serviceID string, check *structs.ServiceCheck) structs.CheckID {
+	return structs.CheckID(nomadCheckPrefix + check.Hash(serviceID))
+}
@@ -1,7 +1,9 @@
 package serviceregistration
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/stretchr/testify/require"
@@ -34,3 +36,22 @@ func Test_MakeAllocServiceID(t *testing.T) {
 		})
 	}
 }
+
+func Test_MakeCheckID(t *testing.T) {
+	serviceID := "_nomad-task-7ac7c672-1824-6f06-644c-4c249e1578b9-cache-redis-db"
+	check := &structs.ServiceCheck{
+		Name:     "check-db",
+		Type:     "tcp",
+		Interval: 10 * time.Second,
+		Timeout:  2 * time.Second,
+	}
+
+	id := MakeCheckID(serviceID, check)
+	require.True(t, strings.HasPrefix(string(id), "_nomad-check-"))
+	require.Equal(t, id, MakeCheckID(serviceID, check))
+
+	// The ID changes with the service and the check definition.
+	require.NotEqual(t, id, MakeCheckID(serviceID+"-other", check))
+	check.Timeout = 3 * time.Second
+	require.NotEqual(t, id, MakeCheckID(serviceID, check))
+}
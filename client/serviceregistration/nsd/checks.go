@@ -0,0 +1,369 @@
+package nsd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+const (
+	// restartTimeout is the maximum duration of a restart triggered by a
+	// failing check.
+	restartTimeout = 10 * time.Second
+)
+
+// serviceChecks tracks a registered service which has checks. The handler
+// runs the checks of the service until it is removed.
+type serviceChecks struct {
+	workload     *serviceregistration.WorkloadServices
+	service      *structs.Service
+	registration *structs.ServiceRegistration
+
+	// cancel stops the checks of the service.
+	cancel context.CancelFunc
+}
+
+// checkIDs returns the IDs of the checks of the service.
+func (sc *serviceChecks) checkIDs() []structs.CheckID {
+	ids := make([]structs.CheckID, 0, len(sc.service.Checks))
+	for _, check := range sc.service.Checks {
+		ids = append(ids, serviceregistration.MakeCheckID(sc.registration.ID, check))
+	}
+	return ids
+}
+
+// watchChecks starts running the checks of the registered service, replacing
+// the checks of any previous registration of the service.
+func (s *ServiceRegistrationHandler) watchChecks(
+	workload *serviceregistration.WorkloadServices, service *structs.Service, reg *structs.ServiceRegistration) {
+
+	s.checksLock.Lock()
+	defer s.checksLock.Unlock()
+
+	if existing, ok := s.checks[reg.ID]; ok {
+		existing.cancel()
+		delete(s.checks, reg.ID)
+	}
+	if len(service.Checks) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := &serviceChecks{
+		workload:     workload,
+		service:      service,
+		registration: reg,
+		cancel:       cancel,
+	}
+	s.checks[reg.ID] = sc
+
+	for _, check := range service.Checks {
+		go s.runCheck(ctx, sc, check)
+	}
+}
+
+// unwatchChecks stops running the checks of the service and removes their
+// results.
+func (s *ServiceRegistrationHandler) unwatchChecks(allocID, serviceID string) {
+	s.checksLock.Lock()
+	defer s.checksLock.Unlock()
+
+	sc, ok := s.checks[serviceID]
+	if !ok {
+		return
+	}
+	sc.cancel()
+	delete(s.checks, serviceID)
+	s.cfg.CheckStore.Remove(allocID, sc.checkIDs())
+}
+
+// runCheck executes the check at its interval until the context is cancelled
+// or the handler is shut down. It stores the result of each execution, updates
+// the status of the service registration when the check changes status, and
+// restarts the workload according to the check_restart block of the check.
+func (s *ServiceRegistrationHandler) runCheck(ctx context.Context, sc *serviceChecks, check *structs.ServiceCheck) {
+	checkID := serviceregistration.MakeCheckID(sc.registration.ID, check)
+	logger := s.log.With("alloc_id", sc.workload.AllocID, "service", sc.service.Name, "check", check.Name)
+
+	var restarter *checkRestarter
+	if check.TriggersRestarts() && sc.workload.Restarter != nil {
+		restarter = newCheckRestarter(check, time.Now())
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.shutDownCh:
+			return
+		case <-timer.C:
+		}
+
+		result := s.executeCheck(ctx, sc, check)
+		result.ID = checkID
+		result.Group = sc.workload.Group
+		result.Task = sc.workload.Task
+		result.Service = sc.service.Name
+		result.Check = check.Name
+
+		// Discard the results of checks interrupted by the removal of the
+		// service.
+		if ctx.Err() != nil {
+			return
+		}
+
+		previous, ok := s.cfg.CheckStore.Get(sc.workload.AllocID, checkID)
+		s.cfg.CheckStore.Set(sc.workload.AllocID, result)
+		if !ok || previous.Status != result.Status {
+			logger.Debug("check changed status", "status", result.Status)
+			s.updateStatus(sc.registration.ID)
+		}
+
+		if restarter != nil && restarter.apply(time.Now(), result.Status) {
+			logger.Debug("restarting due to unhealthy check")
+			reason := fmt.Sprintf("healthcheck: check %q unhealthy", check.Name)
+			event := structs.NewTaskEvent(structs.TaskRestartSignal).SetRestartReason(reason)
+			go asyncRestart(logger, sc.workload.Restarter, event)
+		}
+
+		timer.Reset(check.Interval)
+	}
+}
+
+// executeCheck resolves the address of the check and executes it.
+func (s *ServiceRegistrationHandler) executeCheck(
+	ctx context.Context, sc *serviceChecks, check *structs.ServiceCheck) *structs.CheckQueryResult {
+
+	portLabel := check.PortLabel
+	if portLabel == "" {
+		portLabel = sc.service.PortLabel
+	}
+
+	addrMode := check.AddressMode
+	if addrMode == "" {
+		if sc.service.Address != "" {
+			// if the service is using a custom address, enable the check
+			// to use that address
+			addrMode = structs.AddressModeAuto
+		} else {
+			// otherwise default to the host address
+			addrMode = structs.AddressModeHost
+		}
+	}
+
+	workload := sc.workload
+	ip, port, err := serviceregistration.GetAddress(
+		sc.service.Address, addrMode, portLabel, workload.Networks,
+		workload.DriverNetwork, workload.Ports, workload.NetworkStatus)
+	if err != nil {
+		return &structs.CheckQueryResult{
+			Status:    structs.CheckFailure,
+			Output:    fmt.Sprintf("error getting address for check: %v", err),
+			Timestamp: time.Now().UTC().Unix(),
+		}
+	}
+
+	return s.cfg.Checker.Do(ctx, checks.GetCheckQuery(check, ip, port))
+}
+
+// serviceStatus returns the status of the service registration according to
+// the latest results of its checks. Checks without results yet do not make
+// the service unhealthy.
+func (s *ServiceRegistrationHandler) serviceStatus(
+	allocID, serviceID string, service *structs.Service) string {
+
+	for _, check := range service.Checks {
+		result, ok := s.cfg.CheckStore.Get(allocID, serviceregistration.MakeCheckID(serviceID, check))
+		if ok && result.Status == structs.CheckFailure {
+			return structs.ServiceRegistrationStatusUnhealthy
+		}
+	}
+	return structs.ServiceRegistrationStatusHealthy
+}
+
+// updateStatus upserts the registration of the service if its status changed
+// according to the latest results of its checks.
+func (s *ServiceRegistrationHandler) updateStatus(serviceID string) {
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
+	s.checksLock.Lock()
+	sc, ok := s.checks[serviceID]
+	if !ok {
+		s.checksLock.Unlock()
+		return
+	}
+	reg := sc.registration.Copy()
+	s.checksLock.Unlock()
+
+	status := s.serviceStatus(reg.AllocID, reg.ID, sc.service)
+	if status == reg.Status {
+		return
+	}
+	reg.Status = status
+
+	args := structs.ServiceRegistrationUpsertRequest{
+		Services: []*structs.ServiceRegistration{reg},
+		WriteRequest: structs.WriteRequest{
+			Region:    s.cfg.Region,
+			AuthToken: s.cfg.NodeSecret,
+		},
+	}
+	var resp structs.ServiceRegistrationUpsertResponse
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		s.log.Error("failed to update service registration status",
+			"error", err, "service_id", reg.ID, "status", status)
+		return
+	}
+
+	// Only track the new status if the service was not re-registered or
+	// removed in the meantime.
+	s.checksLock.Lock()
+	if s.checks[serviceID] == sc {
+		sc.registration = reg
+	}
+	s.checksLock.Unlock()
+}
+
+// allocRegistrations returns the registered services of the allocation which
+// have checks, along with the status of their checks in the format of Consul
+// registrations used by the allocation health tracker.
+func (s *ServiceRegistrationHandler) allocRegistrations(allocID string) *serviceregistration.AllocRegistration {
+	s.checksLock.Lock()
+	defer s.checksLock.Unlock()
+
+	var allocReg *serviceregistration.AllocRegistration
+	for _, sc := range s.checks {
+		if sc.workload.AllocID != allocID {
+			continue
+		}
+		if allocReg == nil {
+			allocReg = &serviceregistration.AllocRegistration{
+				Tasks: make(map[string]*serviceregistration.ServiceRegistrations),
+			}
+		}
+
+		name := sc.workload.Name()
+		if _, ok := allocReg.Tasks[name]; !ok {
+			allocReg.Tasks[name] = &serviceregistration.ServiceRegistrations{
+				Services: make(map[string]*serviceregistration.ServiceRegistration),
+			}
+		}
+
+		reg := sc.registration
+		sreg := &serviceregistration.ServiceRegistration{
+			ServiceID:     reg.ID,
+			CheckIDs:      make(map[string]struct{}, len(sc.service.Checks)),
+			CheckOnUpdate: make(map[string]string, len(sc.service.Checks)),
+			Service: &api.AgentService{
+				ID:      reg.ID,
+				Service: reg.ServiceName,
+				Tags:    reg.Tags,
+				Address: reg.Address,
+				Port:    reg.Port,
+			},
+		}
+		for _, check := range sc.service.Checks {
+			checkID := string(serviceregistration.MakeCheckID(reg.ID, check))
+			sreg.CheckIDs[checkID] = struct{}{}
+			sreg.CheckOnUpdate[checkID] = check.OnUpdate
+
+			// Checks without results yet are critical, like checks registered
+			// in Consul without an initial status.
+			status := api.HealthCritical
+			var output string
+			if result, ok := s.cfg.CheckStore.Get(allocID, structs.CheckID(checkID)); ok {
+				if result.Status == structs.CheckSuccess {
+					status = api.HealthPassing
+				}
+				output = result.Output
+			}
+			sreg.Checks = append(sreg.Checks, &api.AgentCheck{
+				CheckID:     checkID,
+				Name:        check.Name,
+				Status:      status,
+				Output:      output,
+				ServiceID:   reg.ID,
+				ServiceName: reg.ServiceName,
+			})
+		}
+		allocReg.Tasks[name].Services[reg.ID] = sreg
+	}
+	return allocReg
+}
+
+// checkRestarter tracks the failures of a check to restart its workload
+// according to the check_restart block of the check.
+type checkRestarter struct {
+	limit int
+	grace time.Duration
+
+	// graceUntil is when the grace period of the check expires and failures
+	// start being counted.
+	graceUntil time.Time
+
+	// failures is the number of consecutive failures of the check.
+	failures int
+}
+
+func newCheckRestarter(check *structs.ServiceCheck, now time.Time) *checkRestarter {
+	return &checkRestarter{
+		limit:      check.CheckRestart.Limit,
+		grace:      check.CheckRestart.Grace,
+		graceUntil: now.Add(check.CheckRestart.Grace),
+	}
+}
+
+// apply records the status of the check, and returns whether the workload
+// should be restarted. The grace period starts again after a restart.
+func (c *checkRestarter) apply(now time.Time, status structs.CheckStatus) bool {
+	if status != structs.CheckFailure {
+		c.failures = 0
+		return false
+	}
+	if now.Before(c.graceUntil) {
+		return false
+	}
+
+	c.failures++
+	if c.failures < c.limit {
+		return false
+	}
+
+	c.failures = 0
+	c.graceUntil = now.Add(c.grace)
+	return true
+}
+
+// asyncRestart restarts the workload because of a failing check. It is
+// intended to be called in a goroutine.
+//
+// The restart is not bound to the checks of the service, as restarting a task
+// removes its services and therefore stops their checks.
+func asyncRestart(logger hclog.Logger,
+	restarter serviceregistration.WorkloadRestarter, event *structs.TaskEvent) {
+
+	// Check restarts are always failures
+	const failure = true
+
+	// Restarting is asynchronous so there's no reason to allow this
+	// goroutine to block indefinitely.
+	ctx, cancel := context.WithTimeout(context.Background(), restartTimeout)
+	defer cancel()
+
+	if err := restarter.Restart(ctx, event, failure); err != nil {
+		// Restart errors are not actionable and only relevant when
+		// debugging allocation lifecycle management.
+		logger.Debug("failed to restart workload", "error", err,
+			"event_time", event.Time, "event_type", event.Type)
+	}
+}
@@ -0,0 +1,171 @@
+package nsd
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRegistrationHandler_Checks(t *testing.T) {
+	checker := &mockChecker{status: structs.CheckSuccess}
+	rpc := &statusRPC{}
+	store := checkstore.NewStore()
+	restarter := &mockRestarter{}
+
+	h := NewServiceRegistrationHandler(hclog.NewNullLogger(), &ServiceRegistrationHandlerCfg{
+		Enabled:    true,
+		RPCFn:      rpc.RPC,
+		CheckStore: store,
+		Checker:    checker,
+	})
+
+	workload := mockWorkload()
+	workload.Restarter = restarter
+	workload.Services[0].Checks = []*structs.ServiceCheck{{
+		Name:     "check-db",
+		Type:     "tcp",
+		Interval: 10 * time.Millisecond,
+		Timeout:  time.Second,
+		CheckRestart: &structs.CheckRestart{
+			Limit: 2,
+		},
+	}}
+	require.NoError(t, h.RegisterWorkload(workload))
+
+	// The registration starts healthy, and the check results are stored.
+	require.Equal(t, structs.ServiceRegistrationStatusHealthy, rpc.lastStatus())
+	require.Eventually(t, func() bool {
+		return len(store.List(workload.AllocID)) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	allocReg, err := h.AllocRegistrations(workload.AllocID)
+	require.NoError(t, err)
+	require.Equal(t, 1, allocReg.NumChecks())
+
+	// Failing checks mark the registration unhealthy and restart the task.
+	checker.setStatus(structs.CheckFailure)
+	require.Eventually(t, func() bool {
+		return rpc.lastStatus() == structs.ServiceRegistrationStatusUnhealthy
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return restarter.count() > 0
+	}, time.Second, 10*time.Millisecond)
+
+	// Passing checks mark the registration healthy again.
+	checker.setStatus(structs.CheckSuccess)
+	require.Eventually(t, func() bool {
+		return rpc.lastStatus() == structs.ServiceRegistrationStatusHealthy
+	}, time.Second, 10*time.Millisecond)
+
+	// Removing the workload stops the checks and removes their results.
+	h.RemoveWorkload(workload)
+	require.Empty(t, store.List(workload.AllocID))
+	allocReg, err = h.AllocRegistrations(workload.AllocID)
+	require.NoError(t, err)
+	require.Nil(t, allocReg)
+}
+
+func TestCheckRestarter_apply(t *testing.T) {
+	now := time.Now()
+	check := &structs.ServiceCheck{
+		CheckRestart: &structs.CheckRestart{
+			Limit: 2,
+			Grace: time.Minute,
+		},
+	}
+	c := newCheckRestarter(check, now)
+
+	// Failures are ignored during the grace period.
+	require.False(t, c.apply(now.Add(time.Second), structs.CheckFailure))
+	require.Zero(t, c.failures)
+
+	// Consecutive failures reaching the limit trigger a restart.
+	now = now.Add(2 * time.Minute)
+	require.False(t, c.apply(now, structs.CheckFailure))
+	require.False(t, c.apply(now, structs.CheckSuccess))
+	require.False(t, c.apply(now, structs.CheckFailure))
+	require.True(t, c.apply(now, structs.CheckFailure))
+
+	// The grace period starts again after a restart.
+	require.False(t, c.apply(now.Add(time.Second), structs.CheckFailure))
+	require.False(t, c.apply(now.Add(time.Second), structs.CheckFailure))
+}
+
+// mockChecker returns check results with the configured status.
+type mockChecker struct {
+	status structs.CheckStatus
+	l      sync.Mutex
+}
+
+func (m *mockChecker) setStatus(status structs.CheckStatus) {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.status = status
+}
+
+func (m *mockChecker) Do(_ context.Context, _ *checks.Query) *structs.CheckQueryResult {
+	m.l.Lock()
+	defer m.l.Unlock()
+	return &structs.CheckQueryResult{
+		Status:    m.status,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// statusRPC mocks the server RPCs, tracking the status of the latest upserted
+// service registration.
+type statusRPC struct {
+	status string
+	l      sync.Mutex
+}
+
+func (s *statusRPC) lastStatus() string {
+	s.l.Lock()
+	defer s.l.Unlock()
+	return s.status
+}
+
+func (s *statusRPC) RPC(method string, args, _ interface{}) error {
+	if method != structs.ServiceRegistrationUpsertRPCMethod {
+		return nil
+	}
+	req := args.(*structs.ServiceRegistrationUpsertRequest)
+
+	s.l.Lock()
+	defer s.l.Unlock()
+	for _, reg := range req.Services {
+		if reg.ServiceName == "redis-db" {
+			s.status = reg.Status
+		}
+	}
+	return nil
+}
+
+// mockRestarter counts the restarts of the workload.
+type mockRestarter struct {
+	restarts int
+	l        sync.Mutex
+}
+
+func (m *mockRestarter) count() int {
+	m.l.Lock()
+	defer m.l.Unlock()
+	return m.restarts
+}
+
+func (m *mockRestarter) Restart(_ context.Context, _ *structs.TaskEvent, _ bool) error {
+	m.l.Lock()
+	defer m.l.Unlock()
+	m.restarts++
+	return nil
+}
+
+var _ serviceregistration.WorkloadRestarter = (*mockRestarter)(nil)
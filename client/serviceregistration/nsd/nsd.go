@@ -4,10 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/client/serviceregistration"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks"
+	"github.com/hashicorp/nomad/client/serviceregistration/checks/checkstore"
 	"github.com/hashicorp/nomad/nomad/structs"
 )
 
@@ -25,6 +28,15 @@ type ServiceRegistrationHandler struct {
 	// shutDownCh coordinates shutting down the handler and any long-running
 	// processes, such as the RPC retry.
 	shutDownCh chan struct{}
+
+	// checks tracks the registered services which have checks, by service
+	// ID. The handler runs the checks of these services.
+	checks     map[string]*serviceChecks
+	checksLock sync.Mutex
+
+	// statusLock serializes the updates of the service registration statuses
+	// triggered by the checks.
+	statusLock sync.Mutex
 }
 
 // ServiceRegistrationHandlerCfg holds critical information used during the
@@ -49,6 +61,15 @@ type ServiceRegistrationHandlerCfg struct {
 	// server service registration RPC calls. This RPC function has basic retry
 	// functionality.
 	RPCFn func(method string, args, resp interface{}) error
+
+	// CheckStore holds the results of the checks of the services, which are
+	// exposed through the client allocation checks endpoint. A new store is
+	// used if it is nil.
+	CheckStore *checkstore.Store
+
+	// Checker executes the checks of the services. The default HTTP and TCP
+	// checker is used if it is nil.
+	Checker checks.Checker
 }
 
 // NewServiceRegistrationHandler returns a ready to use
@@ -56,11 +77,18 @@ type ServiceRegistrationHandlerCfg struct {
 // interface.
 func NewServiceRegistrationHandler(
 	log hclog.Logger, cfg *ServiceRegistrationHandlerCfg) serviceregistration.Handler {
+	if cfg.CheckStore == nil {
+		cfg.CheckStore = checkstore.NewStore()
+	}
+	if cfg.Checker == nil {
+		cfg.Checker = checks.NewChecker()
+	}
 	return &ServiceRegistrationHandler{
 		cfg:                 cfg,
 		log:                 log.Named("service_registration.nomad"),
 		registrationEnabled: cfg.Enabled,
 		shutDownCh:          make(chan struct{}),
+		checks:              make(map[string]*serviceChecks),
 	}
 }
 
@@ -73,6 +101,11 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 		return errors.New(`service registration provider "nomad" not enabled`)
 	}
 
+	// Serialize the registration with the status updates triggered by the
+	// checks, so the registration status reflects the latest check results.
+	s.statusLock.Lock()
+	defer s.statusLock.Unlock()
+
 	// Collect all errors generating service registrations.
 	var mErr multierror.Error
 
@@ -105,7 +138,16 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 
 	var resp structs.ServiceRegistrationUpsertResponse
 
-	return s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp)
+	if err := s.cfg.RPCFn(structs.ServiceRegistrationUpsertRPCMethod, &args, &resp); err != nil {
+		return err
+	}
+
+	// Run the checks of the registered services, which updates their status
+	// as the checks pass or fail.
+	for i, serviceSpec := range workload.Services {
+		s.watchChecks(workload, serviceSpec, registrations[i])
+	}
+	return nil
 }
 
 // RemoveWorkload iterates the services and removes them from the service
@@ -116,6 +158,8 @@ func (s *ServiceRegistrationHandler) RegisterWorkload(workload *serviceregistrat
 // allocations which, when stopped need their registrations removed.
 func (s *ServiceRegistrationHandler) RemoveWorkload(workload *serviceregistration.WorkloadServices) {
 	for _, serviceSpec := range workload.Services {
+		s.unwatchChecks(workload.AllocID,
+			serviceregistration.MakeAllocServiceID(workload.AllocID, workload.Name(), serviceSpec))
 		go s.removeWorkload(workload, serviceSpec)
 	}
 }
@@ -137,7 +181,11 @@ func (s *ServiceRegistrationHandler) removeWorkload(
 
 	var deleteResp structs.ServiceRegistrationDeleteByIDResponse
 
+	// Wait for any in-flight status update of the service, so the registration
+	// is not upserted again once deleted.
+	s.statusLock.Lock()
 	err := s.cfg.RPCFn(structs.ServiceRegistrationDeleteByIDRPCMethod, &deleteArgs, &deleteResp)
+	s.statusLock.Unlock()
 	if err == nil {
 		return
 	}
@@ -240,15 +288,15 @@ func (s *ServiceRegistrationHandler) dedupUpdatedWorkload(
 	return oldCopy, newCopy
 }
 
-// AllocRegistrations is currently a noop implementation as the Nomad provider
-// does not support health check which is the sole subsystem caller of this
-// function.
-func (s *ServiceRegistrationHandler) AllocRegistrations(_ string) (*serviceregistration.AllocRegistration, error) {
-	return nil, nil
+// AllocRegistrations returns the registered services of the allocation which
+// have checks, along with the status of their checks. It returns nil if the
+// allocation has no such services.
+func (s *ServiceRegistrationHandler) AllocRegistrations(allocID string) (*serviceregistration.AllocRegistration, error) {
+	return s.allocRegistrations(allocID), nil
 }
 
 // UpdateTTL is currently a noop implementation as the Nomad provider does not
-// support health check which is the sole subsystem caller of this function.
+// support TTL based checks, such as script checks.
 func (s *ServiceRegistrationHandler) UpdateTTL(_, _, _, _ string) error {
 	return nil
 }
@@ -289,8 +337,10 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		copy(tags, serviceSpec.Tags)
 	}
 
+	id := serviceregistration.MakeAllocServiceID(workload.AllocID, workload.Name(), serviceSpec)
+
 	return &structs.ServiceRegistration{
-		ID:          serviceregistration.MakeAllocServiceID(workload.AllocID, workload.Name(), serviceSpec),
+		ID:          id,
 		ServiceName: serviceSpec.Name,
 		NodeID:      s.cfg.NodeID,
 		JobID:       workload.JobID,
@@ -300,5 +350,6 @@ func (s *ServiceRegistrationHandler) generateNomadServiceRegistration(
 		Tags:        tags,
 		Address:     ip,
 		Port:        port,
+		Status:      s.serviceStatus(workload.AllocID, id, serviceSpec),
 	}, nil
 }
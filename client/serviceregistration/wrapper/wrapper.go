@@ -137,3 +137,24 @@ func (h *HandlerWrapper) UpdateWorkload(old, new *serviceregistration.WorkloadSe
 
 	return nil
 }
+
+// AllocRegistrations wraps the serviceregistration.Handler AllocRegistrations
+// function. A task group can only use a single provider, so the Nomad
+// provider registrations are returned if the allocation has any, otherwise
+// the Consul provider registrations are returned.
+func (h *HandlerWrapper) AllocRegistrations(allocID string) (*serviceregistration.AllocRegistration, error) {
+	reg, err := h.nomadServiceProvider.AllocRegistrations(allocID)
+	if err != nil {
+		return nil, err
+	}
+	if reg.NumServices() > 0 {
+		return reg, nil
+	}
+	return h.consulServiceProvider.AllocRegistrations(allocID)
+}
+
+// UpdateTTL wraps the serviceregistration.Handler UpdateTTL function. Only the
+// Consul provider supports TTL based checks, such as script checks.
+func (h *HandlerWrapper) UpdateTTL(id, namespace, output, status string) error {
+	return h.consulServiceProvider.UpdateTTL(id, namespace, output, status)
+}
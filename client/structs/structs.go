@@ -153,9 +153,27 @@ type FsLogsRequest struct {
 	// Follow follows logs.
 	Follow bool
 
+	// Since and Until filter the lines written in the time range when set.
+	// The times lines were written at are approximated from the timestamp
+	// index logmon writes alongside the log files.
+	Since time.Time
+	Until time.Time
+
+	// Grep is a regular expression the streamed lines must match.
+	Grep string
+
+	// TailLines limits the lines streamed to the last lines matching the
+	// other filters, before following new lines.
+	TailLines int
+
 	structs.QueryOptions
 }
 
+// Filtered returns whether the lines of the logs are filtered.
+func (r *FsLogsRequest) Filtered() bool {
+	return !r.Since.IsZero() || !r.Until.IsZero() || r.Grep != "" || r.TailLines > 0
+}
+
 // StreamErrWrapper is used to serialize output of a stream of a file or logs.
 type StreamErrWrapper struct {
 	// Error stores any error that may have occurred.
@@ -201,6 +219,22 @@ type AllocStatsResponse struct {
 	structs.QueryMeta
 }
 
+// AllocChecksRequest is used to request the latest results of the checks of
+// the services of an allocation using the nomad provider.
+type AllocChecksRequest struct {
+	// AllocID is the allocation to retrieve the check results for
+	AllocID string
+
+	structs.QueryOptions
+}
+
+// AllocChecksResponse is used to return the latest results of the checks of
+// an allocation, by check ID.
+type AllocChecksResponse struct {
+	Results map[structs.CheckID]*structs.CheckQueryResult
+	structs.QueryMeta
+}
+
 // MemoryStats holds memory usage related stats
 type MemoryStats struct {
 	RSS            uint64
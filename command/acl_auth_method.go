@@ -0,0 +1,118 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type ACLAuthMethodCommand struct {
+	Meta
+}
+
+func (a *ACLAuthMethodCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with ACL auth methods.
+  Auth methods allow users to log into Nomad using an external identity
+  provider, such as an OIDC provider, and be issued short-lived ACL tokens.
+
+  Create an ACL auth method:
+
+      $ nomad acl auth-method create -name="name" -type="OIDC" -max-token-ttl="1h" -config=@config.json
+
+  List all ACL auth methods:
+
+      $ nomad acl auth-method list
+
+  Lookup a specific ACL auth method:
+
+      $ nomad acl auth-method info <acl_auth_method_name>
+
+  Update an ACL auth method:
+
+      $ nomad acl auth-method update -default=true <acl_auth_method_name>
+
+  Delete an ACL auth method:
+
+      $ nomad acl auth-method delete <acl_auth_method_name>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLAuthMethodCommand) Synopsis() string { return "Interact with ACL auth methods" }
+
+func (a *ACLAuthMethodCommand) Name() string { return "acl auth-method" }
+
+func (a *ACLAuthMethodCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatAuthMethod formats and converts the ACL auth method API object into a
+// string KV representation suitable for console output.
+func formatAuthMethod(authMethod *api.ACLAuthMethod) string {
+	out := []string{
+		fmt.Sprintf("Name|%s", authMethod.Name),
+		fmt.Sprintf("Type|%s", authMethod.Type),
+		fmt.Sprintf("Locality|%s", authMethod.TokenLocality),
+		fmt.Sprintf("Max Token TTL|%s", authMethod.MaxTokenTTL),
+		fmt.Sprintf("Default|%t", authMethod.Default),
+		fmt.Sprintf("Create Index|%d", authMethod.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", authMethod.ModifyIndex),
+	}
+	if authMethod.Config == nil {
+		return formatKV(out)
+	}
+	return formatKV(out) + "\n\nAuth Method Config\n" + formatAuthMethodConfig(authMethod.Config)
+}
+
+// formatAuthMethodConfig formats the ACL auth method config for console
+// output. The client secret is not displayed.
+func formatAuthMethodConfig(config *api.ACLAuthMethodConfig) string {
+	return formatKV([]string{
+		fmt.Sprintf("OIDC Discovery URL|%s", config.OIDCDiscoveryURL),
+		fmt.Sprintf("OIDC Client ID|%s", config.OIDCClientID),
+		fmt.Sprintf("OIDC Scopes|%s", strings.Join(config.OIDCScopes, ",")),
+		fmt.Sprintf("Bound Audiences|%s", strings.Join(config.BoundAudiences, ",")),
+		fmt.Sprintf("Allowed Redirect URIs|%s", strings.Join(config.AllowedRedirectURIs, ",")),
+		fmt.Sprintf("Signing Algorithms|%s", strings.Join(config.SigningAlgs, ",")),
+		fmt.Sprintf("Claim Mappings|%s", formatAuthMethodClaimMappings(config.ClaimMappings)),
+		fmt.Sprintf("List Claim Mappings|%s", formatAuthMethodClaimMappings(config.ListClaimMappings)),
+	})
+}
+
+// formatAuthMethodClaimMappings formats claim mappings as a sorted, comma
+// separated list of "claim:name" pairs.
+func formatAuthMethodClaimMappings(mappings map[string]string) string {
+	out := make([]string, 0, len(mappings))
+	for claim, name := range mappings {
+		out = append(out, claim+":"+name)
+	}
+	sort.Strings(out)
+	return strings.Join(out, ",")
+}
+
+// parseAuthMethodConfig parses the auth method config passed to the -config
+// flag. The config is either a JSON object, or the path of a file containing
+// one when prefixed with "@".
+func parseAuthMethodConfig(input string) (*api.ACLAuthMethodConfig, error) {
+	raw := []byte(input)
+	if strings.HasPrefix(input, "@") {
+		var err error
+		if raw, err = ioutil.ReadFile(strings.TrimPrefix(input, "@")); err != nil {
+			return nil, fmt.Errorf("failed to read config file: %v", err)
+		}
+	}
+
+	var config api.ACLAuthMethodConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %v", err)
+	}
+	return &config, nil
+}
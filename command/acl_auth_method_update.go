@@ -0,0 +1,185 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/posener/complete"
+)
+
+type ACLAuthMethodUpdateCommand struct {
+	Meta
+
+	methodType    string
+	tokenLocality string
+	maxTokenTTL   time.Duration
+	isDefault     string
+	config        string
+	json          bool
+	tmpl          string
+}
+
+func (a *ACLAuthMethodUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl auth-method update [options] <acl_auth_method_name>
+
+  Update is used to update an existing ACL auth method. The flags which are
+  not specified keep their current value. Use requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Auth Method Update Options:
+
+  -type
+    Updates the type of the auth method. Currently the only supported type is
+    "OIDC".
+
+  -token-locality
+    Updates the kind of token that this auth method should produce. This can
+    be either "local" or "global".
+
+  -max-token-ttl
+    Updates the duration for which tokens created by this auth method are
+    valid, such as "1h".
+
+  -default
+    Specifies whether this auth method should be treated as the default one,
+    either "true" or "false".
+
+  -config
+    Replaces the configuration of the auth method with the passed JSON object.
+    Prefix the value with "@" to read the configuration from a file, such as
+    "-config=@config.json".
+
+  -json
+    Output the ACL auth method in a JSON format.
+
+  -t
+    Format and display the ACL auth method using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLAuthMethodUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-type":           complete.PredictSet("OIDC"),
+			"-token-locality": complete.PredictSet("local", "global"),
+			"-max-token-ttl":  complete.PredictAnything,
+			"-default":        complete.PredictSet("true", "false"),
+			"-config":         complete.PredictFiles("*.json"),
+			"-json":           complete.PredictNothing,
+			"-t":              complete.PredictAnything,
+		})
+}
+
+func (a *ACLAuthMethodUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (a *ACLAuthMethodUpdateCommand) Synopsis() string { return "Update an existing ACL auth method" }
+
+func (*ACLAuthMethodUpdateCommand) Name() string { return "acl auth-method update" }
+
+func (a *ACLAuthMethodUpdateCommand) Run(args []string) int {
+
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.methodType, "type", "", "")
+	flags.StringVar(&a.tokenLocality, "token-locality", "", "")
+	flags.DurationVar(&a.maxTokenTTL, "max-token-ttl", 0, "")
+	flags.StringVar(&a.isDefault, "default", "", "")
+	flags.StringVar(&a.config, "config", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument which is expected to be the ACL
+	// auth method name.
+	if len(flags.Args()) != 1 {
+		a.Ui.Error("This command takes one argument: <acl_auth_method_name>")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Check that the operator specified at least one flag to update the ACL
+	// auth method with.
+	if a.methodType == "" && a.tokenLocality == "" && a.maxTokenTTL == 0 &&
+		a.isDefault == "" && a.config == "" {
+		a.Ui.Error("Please provide at least one flag to update the ACL auth method")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	methodName := flags.Args()[0]
+
+	// Read the current auth method, so we can fail better if not found.
+	currentMethod, _, err := client.ACLAuthMethods().Get(methodName, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error when retrieving ACL auth method: %v", err))
+		return 1
+	}
+
+	updatedMethod := *currentMethod
+	if a.methodType != "" {
+		updatedMethod.Type = strings.ToUpper(a.methodType)
+	}
+	if a.tokenLocality != "" {
+		updatedMethod.TokenLocality = a.tokenLocality
+	}
+	if a.maxTokenTTL != 0 {
+		updatedMethod.MaxTokenTTL = a.maxTokenTTL
+	}
+	if a.isDefault != "" {
+		switch a.isDefault {
+		case "true":
+			updatedMethod.Default = true
+		case "false":
+			updatedMethod.Default = false
+		default:
+			a.Ui.Error(`The -default flag must be either "true" or "false"`)
+			return 1
+		}
+	}
+	if a.config != "" {
+		config, err := parseAuthMethodConfig(a.config)
+		if err != nil {
+			a.Ui.Error(fmt.Sprintf("Error parsing ACL auth method config: %s", err))
+			return 1
+		}
+		updatedMethod.Config = config
+	}
+
+	// Update the ACL auth method with the new information via the API.
+	method, _, err := client.ACLAuthMethods().Update(&updatedMethod, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error updating ACL auth method: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, method)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatAuthMethod(method))
+	return 0
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+type ACLBindingRuleCommand struct {
+	Meta
+}
+
+func (a *ACLBindingRuleCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with ACL binding rules.
+  Binding rules map the claims of users logging in via an ACL auth method to
+  ACL roles and policies, which are linked to the tokens issued to them.
+
+  Create an ACL binding rule:
+
+      $ nomad acl binding-rule create -auth-method="name" \
+          -selector='"engineering" in list.groups' \
+          -bind-type="role" -bind-name="engineering"
+
+  List all ACL binding rules:
+
+      $ nomad acl binding-rule list
+
+  Lookup a specific ACL binding rule:
+
+      $ nomad acl binding-rule info <acl_binding_rule_id>
+
+  Update an ACL binding rule:
+
+      $ nomad acl binding-rule update -description="updated" <acl_binding_rule_id>
+
+  Delete an ACL binding rule:
+
+      $ nomad acl binding-rule delete <acl_binding_rule_id>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLBindingRuleCommand) Synopsis() string { return "Interact with ACL binding rules" }
+
+func (a *ACLBindingRuleCommand) Name() string { return "acl binding-rule" }
+
+func (a *ACLBindingRuleCommand) Run(_ []string) int { return cli.RunResultHelp }
+
+// formatACLBindingRule formats and converts the ACL binding rule API object
+// into a string KV representation suitable for console output.
+func formatACLBindingRule(rule *api.ACLBindingRule) string {
+	return formatKV([]string{
+		fmt.Sprintf("ID|%s", rule.ID),
+		fmt.Sprintf("Description|%s", rule.Description),
+		fmt.Sprintf("Auth Method|%s", rule.AuthMethod),
+		fmt.Sprintf("Selector|%q", rule.Selector),
+		fmt.Sprintf("Bind Type|%s", rule.BindType),
+		fmt.Sprintf("Bind Name|%s", rule.BindName),
+		fmt.Sprintf("Create Index|%d", rule.CreateIndex),
+		fmt.Sprintf("Modify Index|%d", rule.ModifyIndex),
+	})
+}
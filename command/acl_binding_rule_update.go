@@ -0,0 +1,160 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/posener/complete"
+)
+
+type ACLBindingRuleUpdateCommand struct {
+	Meta
+
+	description string
+	selector    string
+	bindType    string
+	bindName    string
+	json        bool
+	tmpl        string
+}
+
+func (a *ACLBindingRuleUpdateCommand) Help() string {
+	helpText := `
+Usage: nomad acl binding-rule update [options] <acl_binding_rule_id>
+
+  Update is used to update an existing ACL binding rule. The flags which are
+  not specified keep their current value. Use requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+ACL Binding Rule Update Options:
+
+  -description
+    A free form text description of the binding rule that must not exceed 256
+    characters.
+
+  -selector
+    An expression that matches against the claims of users logging in, such as
+    '"engineering" in list.groups'.
+
+  -bind-type
+    Adjusts how this binding rule is applied at login time. Valid options are
+    "role" and "policy".
+
+  -bind-name
+    The name of the role or policy to bind on selector match.
+
+  -json
+    Output the ACL binding rule in a JSON format.
+
+  -t
+    Format and display the ACL binding rule using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (a *ACLBindingRuleUpdateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(a.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-description": complete.PredictAnything,
+			"-selector":    complete.PredictAnything,
+			"-bind-type":   complete.PredictSet("role", "policy"),
+			"-bind-name":   complete.PredictAnything,
+			"-json":        complete.PredictNothing,
+			"-t":           complete.PredictAnything,
+		})
+}
+
+func (a *ACLBindingRuleUpdateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (a *ACLBindingRuleUpdateCommand) Synopsis() string {
+	return "Update an existing ACL binding rule"
+}
+
+func (*ACLBindingRuleUpdateCommand) Name() string { return "acl binding-rule update" }
+
+func (a *ACLBindingRuleUpdateCommand) Run(args []string) int {
+
+	flags := a.Meta.FlagSet(a.Name(), FlagSetClient)
+	flags.Usage = func() { a.Ui.Output(a.Help()) }
+	flags.StringVar(&a.description, "description", "", "")
+	flags.StringVar(&a.selector, "selector", "", "")
+	flags.StringVar(&a.bindType, "bind-type", "", "")
+	flags.StringVar(&a.bindName, "bind-name", "", "")
+	flags.BoolVar(&a.json, "json", false, "")
+	flags.StringVar(&a.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one argument which is expected to be the ACL
+	// binding rule ID.
+	if len(flags.Args()) != 1 {
+		a.Ui.Error("This command takes one argument: <acl_binding_rule_id>")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Check that the operator specified at least one flag to update the ACL
+	// binding rule with.
+	if a.description == "" && a.selector == "" && a.bindType == "" && a.bindName == "" {
+		a.Ui.Error("Please provide at least one flag to update the ACL binding rule")
+		a.Ui.Error(commandErrorText(a))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := a.Meta.Client()
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	ruleID := flags.Args()[0]
+
+	// Read the current binding rule, so we can fail better if not found.
+	currentRule, _, err := client.ACLBindingRules().Get(ruleID, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error when retrieving ACL binding rule: %v", err))
+		return 1
+	}
+
+	updatedRule := *currentRule
+	if a.description != "" {
+		updatedRule.Description = a.description
+	}
+	if a.selector != "" {
+		updatedRule.Selector = a.selector
+	}
+	if a.bindType != "" {
+		updatedRule.BindType = a.bindType
+	}
+	if a.bindName != "" {
+		updatedRule.BindName = a.bindName
+	}
+
+	// Update the ACL binding rule with the new information via the API.
+	rule, _, err := client.ACLBindingRules().Update(&updatedRule, nil)
+	if err != nil {
+		a.Ui.Error(fmt.Sprintf("Error updating ACL binding rule: %s", err))
+		return 1
+	}
+
+	if a.json || len(a.tmpl) > 0 {
+		out, err := Format(a.json, a.tmpl, rule)
+		if err != nil {
+			a.Ui.Error(err.Error())
+			return 1
+		}
+
+		a.Ui.Output(out)
+		return 0
+	}
+
+	a.Ui.Output(formatACLBindingRule(rule))
+	return 0
+}
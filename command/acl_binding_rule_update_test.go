@@ -0,0 +1,73 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLBindingRuleUpdateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	// Ensure we have a bootstrap token.
+	rootACLToken := srv.RootToken
+	require.NotNil(t, rootACLToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLBindingRuleUpdateCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Try calling the command without setting a binding rule ID.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes one argument")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL auth method and binding rule directly within state.
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Name = "auth0"
+	err := srv.Agent.Server().State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod})
+	require.NoError(t, err)
+
+	aclBindingRule := mock.ACLBindingRule()
+	err = srv.Agent.Server().State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLBindingRule{aclBindingRule}, true)
+	require.NoError(t, err)
+
+	// Try a request without setting any flags to update.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "-token=" + rootACLToken.SecretID, aclBindingRule.ID}))
+	require.Contains(t, ui.ErrorWriter.String(), "Please provide at least one flag to update the ACL binding rule")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Update the description and bind name, and ensure the other fields are
+	// retained.
+	require.Equal(t, 0, cmd.Run([]string{
+		"-address=" + url, "-token=" + rootACLToken.SecretID,
+		"-description=acl-binding-rule-cli-test", "-bind-name=eng-rw", aclBindingRule.ID,
+	}), ui.ErrorWriter.String())
+	s := ui.OutputWriter.String()
+	require.Contains(t, s, "ID           = "+aclBindingRule.ID)
+	require.Contains(t, s, "Description  = acl-binding-rule-cli-test")
+	require.Contains(t, s, "Auth Method  = auth0")
+	require.Contains(t, s, "Bind Type    = role")
+	require.Contains(t, s, "Bind Name    = eng-rw")
+}
@@ -3,6 +3,7 @@ package command
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/nomad/api"
 	"github.com/posener/complete"
@@ -128,18 +129,48 @@ func formatKVACLToken(token *api.ACLToken) string {
 		fmt.Sprintf("Global|%v", token.Global),
 	}
 
-	// Special case the policy output
+	// Special case the policy and role output
 	if token.Type == "management" {
-		output = append(output, "Policies|n/a")
+		output = append(output, "Policies|n/a", "Roles|n/a")
 	} else {
-		output = append(output, fmt.Sprintf("Policies|%v", token.Policies))
+		output = append(output,
+			fmt.Sprintf("Policies|%v", token.Policies),
+			fmt.Sprintf("Roles|%s", formatACLTokenRoleLinks(token.Roles)),
+		)
 	}
 
 	// Add the generic output
 	output = append(output,
 		fmt.Sprintf("Create Time|%v", token.CreateTime),
+		fmt.Sprintf("Expiry Time|%s", formatACLTokenExpiry(token.ExpirationTime)),
 		fmt.Sprintf("Create Index|%d", token.CreateIndex),
 		fmt.Sprintf("Modify Index|%d", token.ModifyIndex),
 	)
 	return formatKV(output)
 }
+
+// formatACLTokenExpiry formats the expiration time of a token for console
+// output. Tokens without an expiration time never expire.
+func formatACLTokenExpiry(expirationTime *time.Time) string {
+	if expirationTime == nil {
+		return "<none>"
+	}
+	return expirationTime.String()
+}
+
+// formatACLTokenRoleLinks formats the role links of a token for console
+// output, preferring the role name and falling back to the ID.
+func formatACLTokenRoleLinks(links []*api.ACLTokenRoleLink) string {
+	if len(links) == 0 {
+		return "<none>"
+	}
+	out := make([]string, len(links))
+	for i, link := range links {
+		if link.Name != "" {
+			out[i] = link.Name
+		} else {
+			out[i] = link.ID
+		}
+	}
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,75 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRoleInfoCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	// Ensure we have a bootstrap token.
+	rootACLToken := srv.RootToken
+	require.NotNil(t, rootACLToken)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLRoleInfoCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+	}
+
+	// Perform a lookup without specifying an ID.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes one argument: <acl_role_id>")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Create an ACL role directly within state.
+	aclRole := structs.ACLRole{
+		ID:          "bb8c9e92-a0fc-59e4-a0a8-1f8e4fb34cd8",
+		Name:        "acl-role-cli-test",
+		Description: "my-lovely-role",
+		Policies:    []*structs.ACLRolePolicyLink{{Name: "acl-role-cli-test-policy"}},
+	}
+	err := srv.Agent.Server().State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{&aclRole}, true)
+	require.NoError(t, err)
+
+	// Look up the ACL role using its ID.
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-token=" + rootACLToken.SecretID, aclRole.ID}))
+	s := ui.OutputWriter.String()
+	require.Contains(t, s, "ID           = bb8c9e92-a0fc-59e4-a0a8-1f8e4fb34cd8")
+	require.Contains(t, s, "Name         = acl-role-cli-test")
+	require.Contains(t, s, "Description  = my-lovely-role")
+	require.Contains(t, s, "Policies     = acl-role-cli-test-policy")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Look up the ACL role using its name.
+	require.Equal(t, 0, cmd.Run([]string{
+		"-address=" + url, "-token=" + rootACLToken.SecretID, "-by-name", aclRole.Name}))
+	require.Contains(t, ui.OutputWriter.String(), "ID           = bb8c9e92-a0fc-59e4-a0a8-1f8e4fb34cd8")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Look up a role which does not exist.
+	require.Equal(t, 1, cmd.Run([]string{
+		"-address=" + url, "-token=" + rootACLToken.SecretID, "-by-name", "not-a-role"}))
+	require.Contains(t, ui.ErrorWriter.String(), "ACL role not found")
+}
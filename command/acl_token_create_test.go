@@ -3,11 +3,14 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestACLTokenCreateCommand(t *testing.T) {
@@ -41,3 +44,73 @@ func TestACLTokenCreateCommand(t *testing.T) {
 		t.Fatalf("bad: %v", out)
 	}
 }
+
+func TestACLTokenCreateCommand_Roles(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	// Create a role to link the token to
+	aclRole := structs.ACLRole{
+		ID:       "bb8c9e92-a0fc-59e4-a0a8-1f8e4fb34cd8",
+		Name:     "acl-role-cli-test",
+		Policies: []*structs.ACLRolePolicyLink{{Name: "foo"}},
+	}
+	err := srv.Agent.Server().State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{&aclRole}, true)
+	require.NoError(t, err)
+
+	ui := cli.NewMockUi()
+	cmd := &ACLTokenCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Create a token which links to the role using its name
+	code := cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-role-name=acl-role-cli-test"})
+	require.Equal(t, 0, code)
+	require.Contains(t, ui.OutputWriter.String(), "Roles        = acl-role-cli-test")
+
+	// Linking to a role which does not exist should fail
+	code = cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-role-id=not-a-role"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "cannot find role not-a-role")
+}
+
+func TestACLTokenCreateCommand_TTL(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, url := testServer(t, true, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &ACLTokenCreateCommand{Meta: Meta{Ui: ui, flagAddress: url}}
+
+	// Tokens without a TTL never expire
+	code := cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-policy=foo"})
+	require.Equal(t, 0, code)
+	require.Contains(t, ui.OutputWriter.String(), "Expiry Time  = <none>")
+
+	ui.OutputWriter.Reset()
+
+	// Create a token which expires, and check the expiry is stored
+	code = cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-policy=foo", "-ttl=10m"})
+	require.Equal(t, 0, code)
+	require.NotContains(t, ui.OutputWriter.String(), "Expiry Time  = <none>")
+
+	iter, err := srv.Agent.Server().State().ACLTokensByExpired(false)
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	token := raw.(*structs.ACLToken)
+	require.Equal(t, 10*time.Minute, token.ExpirationTTL)
+	require.Equal(t, token.CreateTime.Add(10*time.Minute), *token.ExpirationTime)
+	require.Nil(t, iter.Next())
+
+	// A negative TTL should be rejected
+	code = cmd.Run([]string{"-address=" + url, "-token=" + srv.RootToken.SecretID, "-policy=foo", "-ttl=-10m"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "cannot be negative")
+}
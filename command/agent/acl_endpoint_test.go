@@ -515,3 +515,234 @@ func TestHTTP_OneTimeToken(t *testing.T) {
 		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
 	})
 }
+
+func TestHTTP_ACLRoleCreate(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		// Create the policy the role links to
+		p1 := mock.ACLPolicy()
+		require.NoError(t, s.Agent.server.State().UpsertACLPolicies(
+			structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{p1}))
+
+		// Make the HTTP request
+		role := mock.ACLRole()
+		role.ID = ""
+		role.Policies = []*structs.ACLRolePolicyLink{{Name: p1.Name}}
+		req, err := http.NewRequest("PUT", "/v1/acl/role", encodeReq(role))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+
+		obj, err := s.Server.ACLRoleRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		out, ok := obj.(*structs.ACLRole)
+		require.True(t, ok)
+		require.NotEmpty(t, out.ID)
+		require.Equal(t, role.Name, out.Name)
+
+		// Creating a role with an ID should be rejected
+		req, err = http.NewRequest("PUT", "/v1/acl/role", encodeReq(out))
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+	})
+}
+
+func TestHTTP_ACLRoleSpecificRequest(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		role := mock.ACLRole()
+		require.NoError(t, s.Agent.server.State().UpsertACLRoles(
+			structs.MsgTypeTestSetup, 10, []*structs.ACLRole{role}, true))
+
+		// Read the role using its ID
+		req, err := http.NewRequest("GET", "/v1/acl/role/"+role.ID, nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		obj, err := s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, role.ID, obj.(*structs.ACLRole).ID)
+		require.Equal(t, "true", respW.Result().Header.Get("X-Nomad-KnownLeader"))
+
+		// Read the role using its name
+		req, err = http.NewRequest("GET", "/v1/acl/role/name/"+role.Name, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, role.ID, obj.(*structs.ACLRole).ID)
+
+		// Deleting by name is not supported
+		req, err = http.NewRequest("DELETE", "/v1/acl/role/name/"+role.Name, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, ErrInvalidMethod)
+
+		// Read a role which does not exist
+		req, err = http.NewRequest("GET", "/v1/acl/role/name/not-a-role", nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "ACL role not found")
+
+		// List the roles
+		req, err = http.NewRequest("GET", "/v1/acl/roles", nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLRoleListRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLRoleListStub), 1)
+
+		// Delete the role
+		req, err = http.NewRequest("DELETE", "/v1/acl/role/"+role.ID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLRoleSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+
+		out, err := s.Agent.server.State().GetACLRoleByID(nil, role.ID)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	})
+}
+
+func TestHTTP_ACLAuthMethodRequests(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		// Create the auth method
+		authMethod := mock.ACLAuthMethod()
+		req, err := http.NewRequest("PUT", "/v1/acl/auth-method", encodeReq(authMethod))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+
+		obj, err := s.Server.ACLAuthMethodRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+		require.Equal(t, authMethod.Name, obj.(*structs.ACLAuthMethod).Name)
+
+		// Updating an auth method using another name should be rejected
+		req, err = http.NewRequest("PUT", "/v1/acl/auth-method/other", encodeReq(authMethod))
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "ACL auth method name does not match request path")
+
+		// Read the auth method
+		req, err = http.NewRequest("GET", "/v1/acl/auth-method/"+authMethod.Name, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLAuthMethodSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, authMethod.Config, obj.(*structs.ACLAuthMethod).Config)
+
+		// List the auth methods, which does not require a token
+		req, err = http.NewRequest("GET", "/v1/acl/auth-methods", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.ACLAuthMethodListRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLAuthMethodListStub), 1)
+
+		// Delete the auth method
+		req, err = http.NewRequest("DELETE", "/v1/acl/auth-method/"+authMethod.Name, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+
+		// Read an auth method which does not exist
+		req, err = http.NewRequest("GET", "/v1/acl/auth-method/"+authMethod.Name, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLAuthMethodSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "ACL auth method not found")
+	})
+}
+
+func TestHTTP_ACLBindingRuleRequests(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		authMethod := mock.ACLAuthMethod()
+		require.NoError(t, s.Agent.server.State().UpsertACLAuthMethods(
+			structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+		// Create the binding rule
+		rule := mock.ACLBindingRule()
+		rule.ID = ""
+		rule.AuthMethod = authMethod.Name
+		req, err := http.NewRequest("PUT", "/v1/acl/binding-rule", encodeReq(rule))
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		setToken(req, s.RootToken)
+
+		obj, err := s.Server.ACLBindingRuleRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Result().Header.Get("X-Nomad-Index"))
+		out := obj.(*structs.ACLBindingRule)
+		require.NotEmpty(t, out.ID)
+
+		// Creating a binding rule with an ID should be rejected
+		req, err = http.NewRequest("PUT", "/v1/acl/binding-rule", encodeReq(out))
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLBindingRuleRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+
+		// Read the binding rule
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rule/"+out.ID, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Equal(t, out.ID, obj.(*structs.ACLBindingRule).ID)
+
+		// List the binding rules
+		req, err = http.NewRequest("GET", "/v1/acl/binding-rules", nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		obj, err = s.Server.ACLBindingRuleListRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.ACLBindingRuleListStub), 1)
+
+		// Delete the binding rule
+		req, err = http.NewRequest("DELETE", "/v1/acl/binding-rule/"+out.ID, nil)
+		require.NoError(t, err)
+		setToken(req, s.RootToken)
+		_, err = s.Server.ACLBindingRuleSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+
+		rule, err = s.Agent.server.State().GetACLBindingRule(nil, out.ID)
+		require.NoError(t, err)
+		require.Nil(t, rule)
+	})
+}
+
+func TestHTTP_ACLOIDCAuthURLRequest(t *testing.T) {
+	ci.Parallel(t)
+	httpACLTest(t, nil, func(s *TestAgent) {
+		authMethod := mock.ACLAuthMethod()
+		require.NoError(t, s.Agent.server.State().UpsertACLAuthMethods(
+			structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+		// Only PUT and POST are supported
+		req, err := http.NewRequest("GET", "/v1/acl/oidc/auth-url", nil)
+		require.NoError(t, err)
+		_, err = s.Server.ACLOIDCAuthURLRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, ErrInvalidMethod)
+
+		// The request is validated before it reaches the auth method
+		req, err = http.NewRequest("PUT", "/v1/acl/oidc/auth-url", encodeReq(&structs.ACLOIDCAuthURLRequest{
+			AuthMethodName: authMethod.Name,
+		}))
+		require.NoError(t, err)
+		_, err = s.Server.ACLOIDCAuthURLRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, "invalid OIDC auth-url request")
+	})
+}
@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -232,6 +233,12 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		conf.EnabledSchedulers = schedulers
 
 	}
+	if len(agentConfig.Server.ScorePlugins) != 0 {
+		conf.ScorePlugins = agentConfig.Server.ScorePlugins
+	}
+	if agentConfig.Server.EvalRateLimit != nil {
+		conf.EvalRateLimit = agentConfig.Server.EvalRateLimit.Copy()
+	}
 	if agentConfig.ACL.Enabled {
 		conf.ACLEnabled = true
 	}
@@ -374,6 +381,47 @@ func convertServerConfig(agentConfig *Config) (*nomad.Config, error) {
 		}
 		conf.CSIPluginGCThreshold = dur
 	}
+	if gcInterval := agentConfig.Server.RootKeyGCInterval; gcInterval != "" {
+		dur, err := time.ParseDuration(gcInterval)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyGCInterval = dur
+	}
+	if gcThreshold := agentConfig.Server.RootKeyGCThreshold; gcThreshold != "" {
+		dur, err := time.ParseDuration(gcThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyGCThreshold = dur
+	}
+	if rotationThreshold := agentConfig.Server.RootKeyRotationThreshold; rotationThreshold != "" {
+		dur, err := time.ParseDuration(rotationThreshold)
+		if err != nil {
+			return nil, err
+		}
+		conf.RootKeyRotationThreshold = dur
+	}
+	conf.KeyringReplicationToken = agentConfig.Server.KeyringReplicationToken
+	if keystoreKey := agentConfig.Server.KeystoreKey; keystoreKey != "" {
+		key, err := base64.StdEncoding.DecodeString(keystoreKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode keystore_key: %v", err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("keystore_key must be 32 bytes, got %d", len(key))
+		}
+		conf.KeystoreKey = key
+	}
+	if rebalanceInterval := agentConfig.Server.RebalanceInterval; rebalanceInterval != "" {
+		dur, err := time.ParseDuration(rebalanceInterval)
+		if err != nil {
+			return nil, err
+		} else if dur <= 0 {
+			return nil, fmt.Errorf("rebalance_interval must be greater than 0, got %s", rebalanceInterval)
+		}
+		conf.RebalanceInterval = dur
+	}
 
 	if heartbeatGrace := agentConfig.Server.HeartbeatGrace; heartbeatGrace != 0 {
 		conf.HeartbeatGrace = heartbeatGrace
@@ -614,12 +662,26 @@ func convertClientConfig(agentConfig *Config) (*clientconfig.Config, error) {
 	}
 	conf.HostVolumes = hvMap
 
+	for _, sinkConfig := range agentConfig.Client.LogSinks {
+		sink := sinkConfig.LogSink()
+		if err := sink.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid log_sink %q: %v", sink.Name, err)
+		}
+		conf.LogSinks = append(conf.LogSinks, sink)
+	}
+	conf.LogSinkUnixSockets = agentConfig.Client.LogSinkUnixSockets
+	conf.LogSinkNetworkDestinations = agentConfig.Client.LogSinkNetworkDestinations
+
 	// Setup the node
 	conf.Node = new(structs.Node)
 	conf.Node.Datacenter = agentConfig.Datacenter
 	conf.Node.Name = agentConfig.NodeName
 	conf.Node.Meta = agentConfig.Client.Meta
 	conf.Node.NodeClass = agentConfig.Client.NodeClass
+	conf.Node.NodePool = agentConfig.Client.NodePool
+	if conf.Node.NodePool == "" {
+		conf.Node.NodePool = structs.NodePoolDefault
+	}
 
 	// Set up the HTTP advertise address
 	conf.Node.HTTPAddr = agentConfig.AdvertiseAddrs.HTTP
@@ -472,6 +472,24 @@ func TestAgent_ServerConfig_RaftMultiplier_Bad(t *testing.T) {
 	}
 }
 
+func TestAgent_ServerConfig_RebalanceInterval(t *testing.T) {
+	ci.Parallel(t)
+
+	conf := DevConfig(nil)
+	require.NoError(t, conf.normalizeAddrs())
+
+	conf.Server.RebalanceInterval = "10m"
+	serverConf, err := convertServerConfig(conf)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Minute, serverConf.RebalanceInterval)
+
+	for _, interval := range []string{"0s", "-5m"} {
+		conf.Server.RebalanceInterval = interval
+		_, err := convertServerConfig(conf)
+		require.ErrorContains(t, err, "rebalance_interval must be greater than 0")
+	}
+}
+
 func TestAgent_ClientConfig(t *testing.T) {
 	ci.Parallel(t)
 	conf := DefaultConfig()
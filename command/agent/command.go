@@ -103,6 +103,7 @@ func (c *Command) readConfig() *Config {
 	flags.StringVar(&cmdConfig.Client.StateDir, "state-dir", "", "")
 	flags.StringVar(&cmdConfig.Client.AllocDir, "alloc-dir", "", "")
 	flags.StringVar(&cmdConfig.Client.NodeClass, "node-class", "", "")
+	flags.StringVar(&cmdConfig.Client.NodePool, "node-pool", "", "")
 	flags.StringVar(&servers, "servers", "", "")
 	flags.Var((*flaghelper.StringFlag)(&meta), "meta", "")
 	flags.StringVar(&cmdConfig.Client.NetworkInterface, "network-interface", "", "")
@@ -431,6 +432,14 @@ func (c *Command) IsValidConfig(config, cmdConfig *Config) bool {
 		if config.Server.Enabled && config.Server.BootstrapExpect == 1 {
 			c.Ui.Error("WARNING: Bootstrap mode enabled! Potentially unsafe operation.")
 		}
+
+		// The key material of the root keys is never written to raft, so
+		// servers which aren't bootstrapped alone must be able to replicate it
+		if config.Server.Enabled && config.Server.BootstrapExpect != 1 &&
+			config.Server.KeyringReplicationToken == "" {
+			c.Ui.Warn("WARNING: keyring_replication_token is not set. Servers which " +
+				"don't hold a root key can't decrypt variables or verify workload identities.")
+		}
 	}
 
 	// ProtocolVersion has never been used. Warn if it is set as someone
@@ -602,6 +611,7 @@ func (c *Command) AutocompleteFlags() complete.Flags {
 		"-state-dir":                     complete.PredictDirs("*"),
 		"-alloc-dir":                     complete.PredictDirs("*"),
 		"-node-class":                    complete.PredictAnything,
+		"-node-pool":                     complete.PredictAnything,
 		"-servers":                       complete.PredictAnything,
 		"-meta":                          complete.PredictAnything,
 		"-config":                        configFilePredictor,
@@ -1372,6 +1382,10 @@ Client Options:
     Mark this node as a member of a node-class. This can be used to label
     similar node types.
 
+  -node-pool
+    Register this node in the given node pool. Jobs are only placed on nodes
+    in the node pool they target. Defaults to the "default" node pool.
+
   -meta
     User specified metadata to associated with the node. Each instance of -meta
     parses a single KEY=VALUE pair. Repeat the meta flag for each key/value pair
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/version"
 )
 
@@ -382,6 +383,39 @@ func TestIsValidConfig(t *testing.T) {
 			},
 			err: `host_network["test"].reserved_ports "3-2147483647" invalid: port must be < 65536 but found 2147483647`,
 		},
+		{
+			name: "ServerWithoutKeyringReplication",
+			conf: Config{
+				DataDir: "/tmp",
+				Server: &ServerConfig{
+					Enabled:         true,
+					BootstrapExpect: 3,
+				},
+				TLSConfig: &config.TLSConfig{},
+			},
+		},
+		{
+			name: "ServerWithKeyringReplicationWithoutTLS",
+			conf: Config{
+				DataDir: "/tmp",
+				Server: &ServerConfig{
+					Enabled:                 true,
+					BootstrapExpect:         3,
+					KeyringReplicationToken: "replication-token",
+				},
+				TLSConfig: &config.TLSConfig{},
+			},
+		},
+		{
+			name: "ServerBootstrappedAlone",
+			conf: Config{
+				DataDir: "/tmp",
+				Server: &ServerConfig{
+					Enabled:         true,
+					BootstrapExpect: 1,
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
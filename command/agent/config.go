@@ -197,6 +197,10 @@ type ClientConfig struct {
 	// NodeClass is used to group the node by class
 	NodeClass string `hcl:"node_class"`
 
+	// NodePool is the node pool the node registers into. Nodes that don't
+	// specify a pool are placed in the default node pool.
+	NodePool string `hcl:"node_pool"`
+
 	// Options is used for configuration of nomad internals,
 	// like fingerprinters and drivers. The format is:
 	//
@@ -288,6 +292,18 @@ type ClientConfig struct {
 	// available to jobs running on this node.
 	HostVolumes []*structs.ClientHostVolumeConfig `hcl:"host_volume"`
 
+	// LogSinks are the sinks the logs of the tasks which don't set their own
+	// sinks are shipped to.
+	LogSinks []*LogSinkConfig `hcl:"log_sink"`
+
+	// LogSinkUnixSockets are the paths of the unix sockets the syslog sinks
+	// of tasks may ship their logs to.
+	LogSinkUnixSockets []string `hcl:"log_sink_unix_sockets"`
+
+	// LogSinkNetworkDestinations are the host:port destinations the syslog
+	// and OTLP sinks of tasks may ship their logs to.
+	LogSinkNetworkDestinations []string `hcl:"log_sink_network_destinations"`
+
 	// CNIPath is the path to search for CNI plugins, multiple paths can be
 	// specified colon delimited
 	CNIPath string `hcl:"cni_path"`
@@ -328,6 +344,60 @@ type ClientConfig struct {
 	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
 }
 
+// LogSinkConfig is the configuration of a default log sink of the client.
+type LogSinkConfig struct {
+	// Name uniquely identifies the sink
+	Name string `hcl:",key"`
+
+	// Type is the type of the sink: syslog, json_file or otlp
+	Type string `hcl:"type"`
+
+	// Address, Facility and Tag configure syslog sinks
+	Address  string `hcl:"address"`
+	Facility string `hcl:"facility"`
+	Tag      string `hcl:"tag"`
+
+	// Path configures json_file sinks
+	Path string `hcl:"path"`
+
+	// Endpoint and Headers configure otlp sinks
+	Endpoint string            `hcl:"endpoint"`
+	Headers  map[string]string `hcl:"headers"`
+
+	// BatchSize is the maximum number of lines shipped at once
+	BatchSize int `hcl:"batch_size"`
+
+	// BatchWait is the maximum time lines wait to fill a batch
+	BatchWait    time.Duration
+	BatchWaitHCL string `hcl:"batch_wait" json:"-"`
+}
+
+// LogSink returns the log sink of the configuration.
+func (c *LogSinkConfig) LogSink() *structs.LogSink {
+	return &structs.LogSink{
+		Name:      c.Name,
+		Type:      c.Type,
+		Address:   c.Address,
+		Facility:  c.Facility,
+		Tag:       c.Tag,
+		Path:      c.Path,
+		Endpoint:  c.Endpoint,
+		Headers:   helper.CopyMapStringString(c.Headers),
+		BatchSize: c.BatchSize,
+		BatchWait: c.BatchWait,
+	}
+}
+
+func (c *LogSinkConfig) Copy() *LogSinkConfig {
+	if c == nil {
+		return nil
+	}
+	nc := new(LogSinkConfig)
+	*nc = *c
+	nc.Headers = helper.CopyMapStringString(c.Headers)
+	return nc
+}
+
 // ACLConfig is configuration specific to the ACL system
 type ACLConfig struct {
 	// Enabled controls if we are enforce and manage ACLs
@@ -394,6 +464,10 @@ type ServerConfig struct {
 	// that the workers dequeue for processing.
 	EnabledSchedulers []string `hcl:"enabled_schedulers"`
 
+	// ScorePlugins configures the scoring plugins the schedulers use to rank
+	// nodes alongside the built in scoring, and the weight of their scores.
+	ScorePlugins []*config.ScorePluginConfig `hcl:"score_plugin"`
+
 	// NodeGCThreshold controls how "old" a node must be to be collected by GC.
 	// Age is not the only requirement for a node to be GCed but the threshold
 	// can be used to filter by age.
@@ -428,6 +502,23 @@ type ServerConfig struct {
 	// GCed but the threshold can be used to filter by age.
 	CSIPluginGCThreshold string `hcl:"csi_plugin_gc_threshold"`
 
+	// RootKeyGCInterval is how often we dispatch a job to GC inactive root
+	// keys, and to rotate the active root key.
+	RootKeyGCInterval string `hcl:"root_key_gc_interval"`
+
+	// RootKeyGCThreshold controls how "old" an inactive root key must be to
+	// be collected by GC. Keys still in use are never GCed.
+	RootKeyGCThreshold string `hcl:"root_key_gc_threshold"`
+
+	// RootKeyRotationThreshold controls how "old" the active root key must
+	// be before it is rotated, and the data encrypted with it rekeyed.
+	RootKeyRotationThreshold string `hcl:"root_key_rotation_threshold"`
+
+	// RebalanceInterval is how often we dispatch a job to migrate allocations
+	// off under-utilized nodes, when rebalancing is enabled in the scheduler
+	// configuration.
+	RebalanceInterval string `hcl:"rebalance_interval"`
+
 	// HeartbeatGrace is the grace period beyond the TTL to account for network,
 	// processing delays and clock skew before marking a node as "down".
 	HeartbeatGrace    time.Duration
@@ -492,6 +583,15 @@ type ServerConfig struct {
 	// Encryption key to use for the Serf communication
 	EncryptKey string `hcl:"encrypt" json:"-"`
 
+	// KeyringReplicationToken is the secret shared by the servers which they
+	// present to each other to replicate the key material of the root keys.
+	KeyringReplicationToken string `hcl:"keyring_replication_token" json:"-"`
+
+	// KeystoreKey is the base64 encoded key used to encrypt the root keys in
+	// the local keystore. If unset, a key is generated and written into the
+	// keystore alongside the keys it encrypts.
+	KeystoreKey string `hcl:"keystore_key" json:"-"`
+
 	// ServerJoin contains information that is used to attempt to join servers
 	ServerJoin *ServerJoin `hcl:"server_join"`
 
@@ -500,6 +600,10 @@ type ServerConfig struct {
 	// This value is ignored.
 	DefaultSchedulerConfig *structs.SchedulerConfiguration `hcl:"default_scheduler_config"`
 
+	// EvalRateLimit limits the rate at which the job register, evaluate,
+	// dispatch and scale endpoints are called, per namespace and per job.
+	EvalRateLimit *structs.EvalRateLimit `hcl:"eval_rate_limit"`
+
 	// EnableEventBroker configures whether this server's state store
 	// will generate events for its event stream.
 	EnableEventBroker *bool `hcl:"enable_event_broker"`
@@ -1522,6 +1626,18 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.CSIPluginGCThreshold != "" {
 		result.CSIPluginGCThreshold = b.CSIPluginGCThreshold
 	}
+	if b.RootKeyGCInterval != "" {
+		result.RootKeyGCInterval = b.RootKeyGCInterval
+	}
+	if b.RootKeyGCThreshold != "" {
+		result.RootKeyGCThreshold = b.RootKeyGCThreshold
+	}
+	if b.RootKeyRotationThreshold != "" {
+		result.RootKeyRotationThreshold = b.RootKeyRotationThreshold
+	}
+	if b.RebalanceInterval != "" {
+		result.RebalanceInterval = b.RebalanceInterval
+	}
 	if b.HeartbeatGrace != 0 {
 		result.HeartbeatGrace = b.HeartbeatGrace
 	}
@@ -1567,6 +1683,12 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	if b.EncryptKey != "" {
 		result.EncryptKey = b.EncryptKey
 	}
+	if b.KeyringReplicationToken != "" {
+		result.KeyringReplicationToken = b.KeyringReplicationToken
+	}
+	if b.KeystoreKey != "" {
+		result.KeystoreKey = b.KeystoreKey
+	}
 	if b.ServerJoin != nil {
 		result.ServerJoin = result.ServerJoin.Merge(b.ServerJoin)
 	}
@@ -1604,6 +1726,10 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 		}
 	}
 
+	if b.EvalRateLimit != nil {
+		result.EvalRateLimit = result.EvalRateLimit.Merge(b.EvalRateLimit)
+	}
+
 	if b.RaftBoltConfig != nil {
 		result.RaftBoltConfig = &RaftBoltConfig{
 			NoFreelistSync: b.RaftBoltConfig.NoFreelistSync,
@@ -1613,6 +1739,11 @@ func (s *ServerConfig) Merge(b *ServerConfig) *ServerConfig {
 	// Add the schedulers
 	result.EnabledSchedulers = append(result.EnabledSchedulers, b.EnabledSchedulers...)
 
+	// Merge the scoring plugins
+	if len(b.ScorePlugins) != 0 {
+		result.ScorePlugins = config.ScorePluginConfigSetMerge(result.ScorePlugins, b.ScorePlugins)
+	}
+
 	// Copy the start join addresses
 	result.StartJoin = make([]string, 0, len(s.StartJoin)+len(b.StartJoin))
 	result.StartJoin = append(result.StartJoin, s.StartJoin...)
@@ -1642,6 +1773,9 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 	if b.NodeClass != "" {
 		result.NodeClass = b.NodeClass
 	}
+	if b.NodePool != "" {
+		result.NodePool = b.NodePool
+	}
 	if b.NetworkInterface != "" {
 		result.NetworkInterface = b.NetworkInterface
 	}
@@ -1746,6 +1880,19 @@ func (a *ClientConfig) Merge(b *ClientConfig) *ClientConfig {
 		result.HostVolumes = structs.HostVolumeSliceMerge(a.HostVolumes, b.HostVolumes)
 	}
 
+	if len(b.LogSinks) != 0 {
+		result.LogSinks = make([]*LogSinkConfig, len(b.LogSinks))
+		for i, sink := range b.LogSinks {
+			result.LogSinks[i] = sink.Copy()
+		}
+	}
+	if len(b.LogSinkUnixSockets) != 0 {
+		result.LogSinkUnixSockets = helper.CopySliceString(b.LogSinkUnixSockets)
+	}
+	if len(b.LogSinkNetworkDestinations) != 0 {
+		result.LogSinkNetworkDestinations = helper.CopySliceString(b.LogSinkNetworkDestinations)
+	}
+
 	if b.CNIPath != "" {
 		result.CNIPath = b.CNIPath
 	}
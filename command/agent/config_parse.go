@@ -123,6 +123,12 @@ func ParseConfigFile(path string) (*Config, error) {
 		},
 	}
 
+	// Add log sinks for time.Duration parsing
+	for i, sink := range c.Client.LogSinks {
+		tds = append(tds, durationConversionMap{
+			fmt.Sprintf("client.log_sink.%d", i), &sink.BatchWait, &sink.BatchWaitHCL, nil})
+	}
+
 	// Add enterprise audit sinks for time.Duration parsing
 	for i, sink := range c.Audit.Sinks {
 		tds = append(tds, durationConversionMap{
@@ -209,6 +215,12 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "host_volume")
 	}
 
+	// Remove LogSink extra keys
+	for _, s := range c.Client.LogSinks {
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, s.Name)
+		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, "log_sink")
+	}
+
 	// Remove HostNetwork extra keys
 	for _, hn := range c.Client.HostNetworks {
 		helper.RemoveEqualFold(&c.Client.ExtraKeysHCL, hn.Name)
@@ -226,6 +238,12 @@ func extraKeys(c *Config) error {
 		helper.RemoveEqualFold(&c.Audit.ExtraKeysHCL, "sink")
 	}
 
+	// Remove ScorePlugins extra keys
+	for _, p := range c.Server.ScorePlugins {
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, p.Name)
+		helper.RemoveEqualFold(&c.Server.ExtraKeysHCL, "score_plugin")
+	}
+
 	for _, k := range []string{"enabled_schedulers", "start_join", "retry_join", "server_join"} {
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, k)
 		helper.RemoveEqualFold(&c.ExtraKeysHCL, "server")
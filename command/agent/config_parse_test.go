@@ -87,9 +87,20 @@ var basicConfig = &Config{
 		HostVolumes: []*structs.ClientHostVolumeConfig{
 			{Name: "tmp", Path: "/tmp"},
 		},
-		CNIPath:             "/tmp/cni_path",
-		BridgeNetworkName:   "custom_bridge_name",
-		BridgeNetworkSubnet: "custom_bridge_subnet",
+		LogSinks: []*LogSinkConfig{
+			{
+				Name:         "siem",
+				Type:         "syslog",
+				Address:      "udp://127.0.0.1:514",
+				BatchWait:    2 * time.Second,
+				BatchWaitHCL: "2s",
+			},
+		},
+		LogSinkUnixSockets:         []string{"/dev/log"},
+		LogSinkNetworkDestinations: []string{"logs.example.com:514"},
+		CNIPath:                    "/tmp/cni_path",
+		BridgeNetworkName:          "custom_bridge_name",
+		BridgeNetworkSubnet:        "custom_bridge_subnet",
 	},
 	Server: &ServerConfig{
 		Enabled:                   true,
@@ -107,6 +118,10 @@ var basicConfig = &Config{
 		DeploymentGCThreshold:     "12h",
 		CSIVolumeClaimGCThreshold: "12h",
 		CSIPluginGCThreshold:      "12h",
+		RootKeyGCInterval:         "3m",
+		RootKeyGCThreshold:        "12h",
+		RootKeyRotationThreshold:  "720h",
+		RebalanceInterval:         "10m",
 		HeartbeatGrace:            30 * time.Second,
 		HeartbeatGraceHCL:         "30s",
 		MinHeartbeatTTL:           33 * time.Second,
@@ -124,6 +139,8 @@ var basicConfig = &Config{
 		RedundancyZone:            "foo",
 		UpgradeVersion:            "0.8.0",
 		EncryptKey:                "abc",
+		KeyringReplicationToken:   "def",
+		KeystoreKey:               "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8=",
 		EnableEventBroker:         helper.BoolToPtr(false),
 		EventBufferSize:           helper.IntToPtr(200),
 		ServerJoin: &ServerJoin{
@@ -140,6 +157,22 @@ var basicConfig = &Config{
 				ServiceSchedulerEnabled: true,
 			},
 		},
+		EvalRateLimit: &structs.EvalRateLimit{
+			NamespaceRate:  50,
+			NamespaceBurst: 100,
+			JobRate:        0.5,
+			JobBurst:       5,
+		},
+		ScorePlugins: []*config.ScorePluginConfig{
+			{
+				Name:   "node-meta",
+				Weight: 0.5,
+				Config: map[string]interface{}{
+					"key":    "power_cost",
+					"invert": true,
+				},
+			},
+		},
 		LicensePath: "/tmp/nomad.hclic",
 	},
 	ACL: &ACLConfig{
@@ -7,8 +7,10 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docker/docker/pkg/ioutils"
 	"github.com/hashicorp/go-msgpack/codec"
@@ -312,6 +314,32 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		return nil, invalidOrigin
 	}
 
+	var since, until time.Time
+	if sinceStr := q.Get("since"); sinceStr != "" {
+		if since, err = time.Parse(time.RFC3339Nano, sinceStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse since field to RFC 3339 time: %v", err))
+		}
+	}
+	if untilStr := q.Get("until"); untilStr != "" {
+		if until, err = time.Parse(time.RFC3339Nano, untilStr); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse until field to RFC 3339 time: %v", err))
+		}
+	}
+
+	grep := q.Get("grep")
+	if grep != "" {
+		if _, err = regexp.Compile(grep); err != nil {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse grep field to regular expression: %v", err))
+		}
+	}
+
+	var tailLines int
+	if tailLinesStr := q.Get("tail_lines"); tailLinesStr != "" {
+		if tailLines, err = strconv.Atoi(tailLinesStr); err != nil || tailLines < 0 {
+			return nil, CodedError(400, fmt.Sprintf("failed to parse tail_lines field to positive integer: %q", tailLinesStr))
+		}
+	}
+
 	// Create the request arguments
 	fsReq := &cstructs.FsLogsRequest{
 		AllocID:   allocID,
@@ -321,6 +349,10 @@ func (s *HTTPServer) Logs(resp http.ResponseWriter, req *http.Request) (interfac
 		Origin:    origin,
 		PlainText: plain,
 		Follow:    follow,
+		Since:     since,
+		Until:     until,
+		Grep:      grep,
+		TailLines: tailLines,
 	}
 	s.parse(resp, req, &fsReq.QueryOptions.Region, &fsReq.QueryOptions)
 
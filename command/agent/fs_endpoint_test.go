@@ -565,6 +565,74 @@ func TestHTTP_FS_Logs_XSS(t *testing.T) {
 	})
 }
 
+func TestHTTP_FS_Logs_Filters(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		a := mockFSAlloc(s.client.NodeID(), nil)
+		addAllocToClient(s, a, terminalClientAlloc)
+
+		cases := []struct {
+			name     string
+			params   string
+			expected string
+		}{
+			{
+				name:     "grep match",
+				params:   "grep=other+si",
+				expected: defaultLoggerMockDriverStdout,
+			},
+			{
+				name:     "grep no match",
+				params:   "grep=%5Ebye",
+				expected: "",
+			},
+			{
+				name:     "tail lines",
+				params:   "tail_lines=1",
+				expected: defaultLoggerMockDriverStdout,
+			},
+			{
+				name:     "until",
+				params:   "until=2000-01-01T00:00:00Z",
+				expected: "",
+			},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				path := fmt.Sprintf("%s/v1/client/fs/logs/%s?type=stdout&task=web&plain=true&%s",
+					s.HTTPAddr(), a.ID, tc.params)
+				resp, err := http.DefaultClient.Get(path)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+
+				buf, err := ioutil.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.Equal(t, 200, resp.StatusCode)
+				require.Equal(t, tc.expected, string(buf))
+			})
+		}
+	})
+}
+
+func TestHTTP_FS_Logs_InvalidFilters(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		for _, params := range []string{
+			"since=yesterday",
+			"until=10m",
+			"grep=%5B",
+			"tail_lines=-1",
+		} {
+			req, err := http.NewRequest("GET", "/v1/client/fs/logs/foo?task=foo&type=stdout&"+params, nil)
+			require.NoError(t, err)
+			respW := httptest.NewRecorder()
+
+			s.Server.mux.ServeHTTP(respW, req)
+			require.Equal(t, 400, respW.Code, params)
+		}
+	})
+}
+
 func TestHTTP_FS_Logs_Follow(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -310,6 +310,23 @@ func TestWrapNonJSON_Error(t *testing.T) {
 
 }
 
+func TestWrap_EvalRateLimited(t *testing.T) {
+	ci.Parallel(t)
+	s := makeHTTPServer(t, nil)
+	defer s.Shutdown()
+
+	handler := func(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+		return nil, structs.NewErrEvalRateLimited(`job "example"`, 1500*time.Millisecond)
+	}
+
+	resp := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/v1/job/example", nil)
+	s.Server.wrap(handler)(resp, req)
+	require.Equal(t, http.StatusTooManyRequests, resp.Code)
+	require.Equal(t, "2", resp.Header().Get("Retry-After"))
+	require.Contains(t, resp.Body.String(), "Evaluation rate limit exceeded")
+}
+
 func TestPrettyPrint(t *testing.T) {
 	ci.Parallel(t)
 	testPrettyPrint("pretty=1", true, t)
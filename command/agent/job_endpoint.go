@@ -90,6 +90,9 @@ func (s *HTTPServer) JobSpecificRequest(resp http.ResponseWriter, req *http.Requ
 	case strings.HasSuffix(path, "/scale"):
 		jobName := strings.TrimSuffix(path, "/scale")
 		return s.jobScale(resp, req, jobName)
+	case strings.HasSuffix(path, "/queue"):
+		jobName := strings.TrimSuffix(path, "/queue")
+		return s.jobQueueStatus(resp, req, jobName)
 	case strings.HasSuffix(path, "/services"):
 		jobName := strings.TrimSuffix(path, "/services")
 		return s.jobServiceRegistrations(resp, req, jobName)
@@ -529,6 +532,33 @@ func (s *HTTPServer) jobScaleStatus(resp http.ResponseWriter, req *http.Request,
 	return out.JobScaleStatus, nil
 }
 
+func (s *HTTPServer) jobQueueStatus(resp http.ResponseWriter, req *http.Request,
+	jobName string) (interface{}, error) {
+
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.JobQueueStatusRequest{
+		JobID: jobName,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.JobQueueStatusResponse
+	if err := s.agent.RPC("Job.QueueStatus", &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.JobQueueStatus == nil {
+		return nil, CodedError(404, "job not found")
+	}
+
+	return out.JobQueueStatus, nil
+}
+
 func (s *HTTPServer) jobScaleAction(resp http.ResponseWriter, req *http.Request,
 	jobName string) (interface{}, error) {
 
@@ -905,8 +935,10 @@ func ApiJobToStructJob(job *api.Job) *structs.Job {
 		Name:           *job.Name,
 		Type:           *job.Type,
 		Priority:       *job.Priority,
+		Preemptible:    job.Preemptible,
 		AllAtOnce:      *job.AllAtOnce,
 		Datacenters:    job.Datacenters,
+		NodePool:       *job.NodePool,
 		Payload:        job.Payload,
 		Meta:           job.Meta,
 		ConsulToken:    *job.ConsulToken,
@@ -1016,6 +1048,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 		tg.MaxClientDisconnect = taskGroup.MaxClientDisconnect
 	}
 
+	if taskGroup.Gang != nil {
+		tg.Gang = *taskGroup.Gang
+	}
+
 	if taskGroup.ReschedulePolicy != nil {
 		tg.ReschedulePolicy = &structs.ReschedulePolicy{
 			Attempts:      *taskGroup.ReschedulePolicy.Attempts,
@@ -1041,9 +1077,10 @@ func ApiTgToStructsTG(job *structs.Job, taskGroup *api.TaskGroup, tg *structs.Ta
 	}
 
 	tg.EphemeralDisk = &structs.EphemeralDisk{
-		Sticky:  *taskGroup.EphemeralDisk.Sticky,
-		SizeMB:  *taskGroup.EphemeralDisk.SizeMB,
-		Migrate: *taskGroup.EphemeralDisk.Migrate,
+		Sticky:     *taskGroup.EphemeralDisk.Sticky,
+		SizeMB:     *taskGroup.EphemeralDisk.SizeMB,
+		Migrate:    *taskGroup.EphemeralDisk.Migrate,
+		Checkpoint: taskGroup.EphemeralDisk.Checkpoint,
 	}
 
 	if len(taskGroup.Spreads) > 0 {
@@ -1177,10 +1214,7 @@ func ApiTaskToStructsTask(job *structs.Job, group *structs.TaskGroup,
 
 	structsTask.Resources = ApiResourcesToStructs(apiTask.Resources)
 
-	structsTask.LogConfig = &structs.LogConfig{
-		MaxFiles:      *apiTask.LogConfig.MaxFiles,
-		MaxFileSizeMB: *apiTask.LogConfig.MaxFileSizeMB,
-	}
+	structsTask.LogConfig = apiLogConfigToStructs(apiTask.LogConfig)
 
 	if len(apiTask.Artifacts) > 0 {
 		structsTask.Artifacts = []*structs.TaskArtifact{}
@@ -1305,6 +1339,12 @@ func ApiResourcesToStructs(in *api.Resources) *structs.Resources {
 		}
 	}
 
+	if in.NUMA != nil {
+		out.NUMA = &structs.NUMA{
+			Affinity: in.NUMA.Affinity,
+		}
+	}
+
 	return out
 }
 
@@ -1704,10 +1744,46 @@ func apiLogConfigToStructs(in *api.LogConfig) *structs.LogConfig {
 	if in == nil {
 		return nil
 	}
-	return &structs.LogConfig{
-		MaxFiles:      dereferenceInt(in.MaxFiles),
-		MaxFileSizeMB: dereferenceInt(in.MaxFileSizeMB),
+	out := &structs.LogConfig{
+		MaxFiles:       dereferenceInt(in.MaxFiles),
+		MaxFileSizeMB:  dereferenceInt(in.MaxFileSizeMB),
+		MaxTotalSizeMB: dereferenceInt(in.MaxTotalSizeMB),
+		Sinks:          apiLogSinksToStructs(in.Sinks),
+	}
+	if in.RotateEvery != nil {
+		out.RotateEvery = *in.RotateEvery
+	}
+	if in.Compression != nil {
+		out.Compression = *in.Compression
+	}
+	if in.MaxAge != nil {
+		out.MaxAge = *in.MaxAge
+	}
+	return out
+}
+
+func apiLogSinksToStructs(in []*api.LogSink) []*structs.LogSink {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*structs.LogSink, len(in))
+	for i, s := range in {
+		out[i] = &structs.LogSink{
+			Name:      s.Name,
+			Type:      s.Type,
+			Address:   s.Address,
+			Facility:  s.Facility,
+			Tag:       s.Tag,
+			Path:      s.Path,
+			Endpoint:  s.Endpoint,
+			Headers:   helper.CopyMapStringString(s.Headers),
+			BatchSize: dereferenceInt(s.BatchSize),
+		}
+		if s.BatchWait != nil {
+			out[i].BatchWait = *s.BatchWait
+		}
 	}
+	return out
 }
 
 func dereferenceInt(in *int) int {
@@ -1768,6 +1844,9 @@ func ApiSpreadToStructs(a1 *api.Spread) *structs.Spread {
 	ret := &structs.Spread{}
 	ret.Attribute = a1.Attribute
 	ret.Weight = *a1.Weight
+	ret.MaxSkew = a1.MaxSkew
+	ret.WhenUnsatisfiable = a1.WhenUnsatisfiable
+	ret.NestedAttributes = helper.CopySliceString(a1.NestedAttributes)
 	if a1.SpreadTarget != nil {
 		ret.SpreadTarget = make([]*structs.SpreadTarget, len(a1.SpreadTarget))
 		for i, st := range a1.SpreadTarget {
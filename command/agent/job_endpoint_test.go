@@ -1230,6 +1230,57 @@ func TestHTTP_Job_ScaleStatus(t *testing.T) {
 	})
 }
 
+func TestHTTP_Job_QueueStatus(t *testing.T) {
+	ci.Parallel(t)
+
+	require := require.New(t)
+
+	httpTest(t, nil, func(s *TestAgent) {
+		// Create the job
+		job := mock.Job()
+		args := structs.JobRegisterRequest{
+			Job: job,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				Namespace: structs.DefaultNamespace,
+			},
+		}
+		var resp structs.JobRegisterResponse
+		require.NoError(s.Agent.RPC("Job.Register", &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/job/"+job.ID+"/queue", nil)
+		require.NoError(err)
+		respW := httptest.NewRecorder()
+
+		// Make the request
+		obj, err := s.Server.JobSpecificRequest(respW, req)
+		require.NoError(err)
+
+		// Check the response
+		status := obj.(*structs.JobQueueStatus)
+		require.Equal(job.ID, status.JobID)
+		require.Equal(job.Namespace, status.Namespace)
+
+		// Check for the index
+		require.NotEmpty(respW.Header().Get("X-Nomad-Index"))
+
+		// Unknown jobs are not found
+		req, err = http.NewRequest("GET", "/v1/job/unknown/queue", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(404, err.(HTTPCodedError).Code())
+
+		// Only reads are allowed
+		req, err = http.NewRequest("PUT", "/v1/job/"+job.ID+"/queue", nil)
+		require.NoError(err)
+		_, err = s.Server.JobSpecificRequest(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Equal(405, err.(HTTPCodedError).Code())
+	})
+}
+
 func TestHTTP_JobForceEvaluate(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
@@ -2369,6 +2420,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Name:        helper.StringToPtr("name"),
 		Type:        helper.StringToPtr("service"),
 		Priority:    helper.IntToPtr(50),
+		Preemptible: helper.BoolToPtr(false),
 		AllAtOnce:   helper.BoolToPtr(true),
 		Datacenters: []string{"dc1", "dc2"},
 		Constraints: []*api.Constraint{
@@ -2488,6 +2540,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 					},
+					{
+						Attribute:         "${meta.zone}",
+						Weight:            helper.Int8ToPtr(50),
+						MaxSkew:           1,
+						WhenUnsatisfiable: "do_not_schedule",
+						NestedAttributes:  []string{"${meta.rack}"},
+					},
 				},
 				EphemeralDisk: &api.EphemeralDisk{
 					SizeMB:  helper.IntToPtr(100),
@@ -2766,6 +2825,7 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 		Name:           "name",
 		Type:           "service",
 		Priority:       50,
+		Preemptible:    helper.BoolToPtr(false),
 		AllAtOnce:      true,
 		Datacenters:    []string{"dc1", "dc2"},
 		Constraints: []*structs.Constraint{
@@ -2865,6 +2925,13 @@ func TestJobs_ApiJobToStructsJob(t *testing.T) {
 							},
 						},
 					},
+					{
+						Attribute:         "${meta.zone}",
+						Weight:            50,
+						MaxSkew:           1,
+						WhenUnsatisfiable: "do_not_schedule",
+						NestedAttributes:  []string{"${meta.rack}"},
+					},
 				},
 				ReschedulePolicy: &structs.ReschedulePolicy{
 					Interval:      12 * time.Hour,
@@ -3551,6 +3618,44 @@ func TestConversion_apiLogConfigToStructs(t *testing.T) {
 		MaxFiles:      helper.IntToPtr(2),
 		MaxFileSizeMB: helper.IntToPtr(8),
 	}))
+	require.Equal(t, &structs.LogConfig{
+		MaxFiles:      2,
+		MaxFileSizeMB: 8,
+		Sinks: []*structs.LogSink{{
+			Name:      "collector",
+			Type:      structs.LogSinkTypeOTLP,
+			Endpoint:  "http://127.0.0.1:4318/v1/logs",
+			Headers:   map[string]string{"X-Scope": "ops"},
+			BatchSize: 50,
+			BatchWait: 2 * time.Second,
+		}},
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:      helper.IntToPtr(2),
+		MaxFileSizeMB: helper.IntToPtr(8),
+		Sinks: []*api.LogSink{{
+			Name:      "collector",
+			Type:      "otlp",
+			Endpoint:  "http://127.0.0.1:4318/v1/logs",
+			Headers:   map[string]string{"X-Scope": "ops"},
+			BatchSize: helper.IntToPtr(50),
+			BatchWait: helper.TimeToPtr(2 * time.Second),
+		}},
+	}))
+	require.Equal(t, &structs.LogConfig{
+		MaxFiles:       2,
+		MaxFileSizeMB:  8,
+		RotateEvery:    time.Hour,
+		Compression:    structs.LogCompressionGzip,
+		MaxTotalSizeMB: 20,
+		MaxAge:         72 * time.Hour,
+	}, apiLogConfigToStructs(&api.LogConfig{
+		MaxFiles:       helper.IntToPtr(2),
+		MaxFileSizeMB:  helper.IntToPtr(8),
+		RotateEvery:    helper.TimeToPtr(time.Hour),
+		Compression:    helper.StringToPtr("gzip"),
+		MaxTotalSizeMB: helper.IntToPtr(20),
+		MaxAge:         helper.TimeToPtr(72 * time.Hour),
+	}))
 }
 
 func TestConversion_apiResourcesToStructs(t *testing.T) {
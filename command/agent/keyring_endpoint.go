@@ -0,0 +1,132 @@
+package agent
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// KeyringRequest is used to route the requests for managing the root keys.
+func (s *HTTPServer) KeyringRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/operator/keyring/")
+	switch {
+	case path == "keys":
+		if req.Method != "GET" {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringListRequest(resp, req)
+	case path == "rotate":
+		if req.Method != "PUT" && req.Method != "POST" {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringRotateRequest(resp, req)
+	case strings.HasPrefix(path, "key/"):
+		keyID := strings.TrimPrefix(path, "key/")
+		if keyID == "" {
+			return nil, CodedError(400, "missing key ID")
+		}
+		if req.Method != "DELETE" {
+			return nil, CodedError(405, ErrInvalidMethod)
+		}
+		return s.keyringDeleteRequest(resp, req, keyID)
+	default:
+		return nil, CodedError(404, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) keyringListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.KeyringListRootKeyMetaRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringListRootKeyMetaResponse
+	if err := s.agent.RPC(structs.KeyringListRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Keys == nil {
+		out.Keys = make([]*structs.RootKeyMeta, 0)
+	}
+	return out.Keys, nil
+}
+
+func (s *HTTPServer) keyringRotateRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	args := structs.KeyringRotateRootKeyRequest{}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	query := req.URL.Query()
+	switch query.Get("algo") {
+	case string(structs.EncryptionAlgorithmAES256GCM):
+		args.Algorithm = structs.EncryptionAlgorithmAES256GCM
+	case "":
+	default:
+		return nil, CodedError(400, "invalid algorithm")
+	}
+
+	if _, ok := query["full"]; ok {
+		args.Full = true
+	}
+
+	var out structs.KeyringRotateRootKeyResponse
+	if err := s.agent.RPC(structs.KeyringRotateRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+func (s *HTTPServer) keyringDeleteRequest(resp http.ResponseWriter, req *http.Request, keyID string) (interface{}, error) {
+	args := structs.KeyringDeleteRootKeyRequest{KeyID: keyID}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var out structs.KeyringDeleteRootKeyResponse
+	if err := s.agent.RPC(structs.KeyringDeleteRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+	return out, nil
+}
+
+// JWKSRequest is used to serve the public keys of the root keys as a JSON
+// Web Key Set, which third parties use to verify workload identities.
+func (s *HTTPServer) JWKSRequest(resp http.ResponseWriter, req *http.Request) ([]byte, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.GenericRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.KeyringListPublicResponse
+	if err := s.agent.RPC(structs.KeyringListPublicRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &out.QueryMeta)
+
+	jwks := jose.JSONWebKeySet{
+		Keys: make([]jose.JSONWebKey, 0, len(out.PublicKeys)),
+	}
+	for _, pubKey := range out.PublicKeys {
+		jwks.Keys = append(jwks.Keys, jose.JSONWebKey{
+			Key:       ed25519.PublicKey(pubKey.PublicKey),
+			KeyID:     pubKey.KeyID,
+			Algorithm: pubKey.Algorithm,
+			Use:       pubKey.Use,
+		})
+	}
+
+	buf, err := json.Marshal(jwks)
+	if err != nil {
+		return nil, err
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	return buf, nil
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/square/go-jose.v2"
+)
+
+func TestHTTP_JWKS(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// The public keys are available once the leader has initialized the
+		// keyring.
+		var jwks jose.JSONWebKeySet
+		testutil.WaitForResult(func() (bool, error) {
+			req, err := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+			if err != nil {
+				return false, err
+			}
+			respW := httptest.NewRecorder()
+			buf, err := s.Server.JWKSRequest(respW, req)
+			if err != nil {
+				return false, err
+			}
+			if err := json.Unmarshal(buf, &jwks); err != nil {
+				return false, err
+			}
+			return len(jwks.Keys) == 1, nil
+		}, func(err error) {
+			t.Fatalf("failed to list public keys: %v", err)
+		})
+
+		key := jwks.Keys[0]
+		require.NotEmpty(t, key.KeyID)
+		require.Equal(t, "EdDSA", key.Algorithm)
+		require.Equal(t, "sig", key.Use)
+		require.True(t, key.Valid())
+		require.True(t, key.IsPublic())
+
+		// Only GET is allowed
+		req, err := http.NewRequest("PUT", "/.well-known/jwks.json", nil)
+		require.NoError(t, err)
+		_, err = s.Server.JWKSRequest(httptest.NewRecorder(), req)
+		require.Error(t, err)
+	})
+}
+
+func TestHTTP_Keyring_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		// List the keys once the leader has initialized the keyring
+		var keys []*structs.RootKeyMeta
+		testutil.WaitForResult(func() (bool, error) {
+			req, err := http.NewRequest("GET", "/v1/operator/keyring/keys", nil)
+			if err != nil {
+				return false, err
+			}
+			obj, err := s.Server.KeyringRequest(httptest.NewRecorder(), req)
+			if err != nil {
+				return false, err
+			}
+			keys = obj.([]*structs.RootKeyMeta)
+			return len(keys) == 1, nil
+		}, func(err error) {
+			t.Fatalf("failed to list keys: %v", err)
+		})
+		oldKeyID := keys[0].KeyID
+
+		// Rotate the key
+		req, err := http.NewRequest("PUT", "/v1/operator/keyring/rotate?algo=aes256-gcm", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.KeyringRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+		rotateResp := obj.(structs.KeyringRotateRootKeyResponse)
+		require.True(t, rotateResp.Key.Active())
+		require.NotEqual(t, oldKeyID, rotateResp.Key.KeyID)
+
+		// An unknown algorithm is rejected
+		req, err = http.NewRequest("PUT", "/v1/operator/keyring/rotate?algo=foo", nil)
+		require.NoError(t, err)
+		_, err = s.Server.KeyringRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "invalid algorithm")
+
+		// Remove the old key
+		req, err = http.NewRequest("DELETE", "/v1/operator/keyring/key/"+oldKeyID, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.KeyringRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.HeaderMap.Get("X-Nomad-Index"))
+
+		req, err = http.NewRequest("GET", "/v1/operator/keyring/keys", nil)
+		require.NoError(t, err)
+		obj, err = s.Server.KeyringRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+		keys = obj.([]*structs.RootKeyMeta)
+		require.Len(t, keys, 1)
+		require.Equal(t, rotateResp.Key.KeyID, keys[0].KeyID)
+	})
+}
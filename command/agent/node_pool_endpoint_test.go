@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_NodePoolList(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		args := structs.NodePoolUpsertRequest{
+			NodePools:    []*structs.NodePool{mock.NodePool(), mock.NodePool()},
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.GenericResponse
+		require.NoError(t, s.Agent.RPC(structs.NodePoolUpsertRPCMethod, &args, &resp))
+
+		// Make the HTTP request
+		req, err := http.NewRequest("GET", "/v1/node/pools", nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+
+		obj, err := s.Server.NodePoolsRequest(respW, req)
+		require.NoError(t, err)
+
+		// Check for the index
+		require.NotEmpty(t, respW.Header().Get("X-Nomad-Index"))
+
+		// Check the output (the 2 we register + the built-in pools)
+		require.Len(t, obj.([]*structs.NodePool), 4)
+	})
+}
+
+func TestHTTP_NodePoolCRUD(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		pool := mock.NodePool()
+
+		// Create the node pool
+		buf := encodeReq(pool)
+		req, err := http.NewRequest("PUT", "/v1/node/pools", buf)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		_, err = s.Server.NodePoolsRequest(respW, req)
+		require.NoError(t, err)
+		require.NotEmpty(t, respW.Header().Get("X-Nomad-Index"))
+
+		// Query the node pool
+		req, err = http.NewRequest("GET", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err := s.Server.NodePoolSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, pool.Description, obj.(*structs.NodePool).Description)
+
+		// Mismatched names are rejected
+		buf = encodeReq(pool)
+		req, err = http.NewRequest("PUT", "/v1/node/pool/other", buf)
+		require.NoError(t, err)
+		_, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, "does not match")
+
+		// Delete the node pool
+		req, err = http.NewRequest("DELETE", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		_, err = s.Server.NodePoolSpecificRequest(respW, req)
+		require.NoError(t, err)
+
+		// The node pool should be gone
+		req, err = http.NewRequest("GET", "/v1/node/pool/"+pool.Name, nil)
+		require.NoError(t, err)
+		_, err = s.Server.NodePoolSpecificRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, "not found")
+	})
+}
@@ -262,11 +262,18 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 		SchedulerAlgorithm:            structs.SchedulerAlgorithm(conf.SchedulerAlgorithm),
 		MemoryOversubscriptionEnabled: conf.MemoryOversubscriptionEnabled,
 		RejectJobRegistration:         conf.RejectJobRegistration,
+		EvalBrokerFairShareEnabled:    conf.EvalBrokerFairShareEnabled,
 		PreemptionConfig: structs.PreemptionConfig{
 			SystemSchedulerEnabled:   conf.PreemptionConfig.SystemSchedulerEnabled,
 			SysBatchSchedulerEnabled: conf.PreemptionConfig.SysBatchSchedulerEnabled,
 			BatchSchedulerEnabled:    conf.PreemptionConfig.BatchSchedulerEnabled,
-			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled},
+			ServiceSchedulerEnabled:  conf.PreemptionConfig.ServiceSchedulerEnabled,
+			VictimRules:              conf.PreemptionConfig.VictimRules},
+		RebalanceConfig: structs.RebalanceConfig{
+			Enabled:              conf.RebalanceConfig.Enabled,
+			MaxMigrations:        conf.RebalanceConfig.MaxMigrations,
+			UtilizationThreshold: conf.RebalanceConfig.UtilizationThreshold,
+		},
 	}
 
 	if err := args.Config.Validate(); err != nil {
@@ -292,6 +299,56 @@ func (s *HTTPServer) schedulerUpdateConfig(resp http.ResponseWriter, req *http.R
 	return reply, nil
 }
 
+// OperatorSchedulerBrokerStats is used to query the statistics of the eval
+// broker queues by namespace.
+func (s *HTTPServer) OperatorSchedulerBrokerStats(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var args structs.GenericRequest
+	if done := s.parse(resp, req, &args.Region, &args.QueryOptions); done {
+		return nil, nil
+	}
+
+	var reply structs.SchedulerBrokerStatsResponse
+	if err := s.agent.RPC("Operator.SchedulerGetBrokerStats", &args, &reply); err != nil {
+		return nil, err
+	}
+	setMeta(resp, &reply.QueryMeta)
+
+	return reply, nil
+}
+
+// OperatorSchedulerRebalance is used to compute and apply a round of
+// rebalancing migrations, or to only report them for a dry run.
+func (s *HTTPServer) OperatorSchedulerRebalance(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "PUT" && req.Method != "POST" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	var body api.SchedulerRebalanceRequest
+	if err := decodeBody(req, &body); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if body.MaxMigrations < 0 {
+		return nil, CodedError(http.StatusBadRequest, "max migrations must be positive")
+	}
+
+	args := structs.SchedulerRebalanceRequest{
+		DryRun:        body.DryRun,
+		MaxMigrations: body.MaxMigrations,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+
+	var reply structs.SchedulerRebalanceResponse
+	if err := s.agent.RPC("Operator.SchedulerRebalance", &args, &reply); err != nil {
+		return nil, err
+	}
+	setIndex(resp, reply.Index)
+	return reply, nil
+}
+
 func (s *HTTPServer) SnapshotRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
 	switch req.Method {
 	case "GET":
@@ -291,6 +291,61 @@ func TestOperator_SchedulerGetConfiguration(t *testing.T) {
 	})
 }
 
+func TestOperator_SchedulerBrokerStats(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+		req, _ := http.NewRequest("GET", "/v1/operator/scheduler/broker", nil)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerBrokerStats(resp, req)
+		require.Nil(err)
+		require.Equal(200, resp.Code)
+		out, ok := obj.(structs.SchedulerBrokerStatsResponse)
+		require.True(ok)
+
+		require.False(out.FairShareEnabled)
+		require.Equal(&structs.SchedulerBrokerNamespaceStats{Weight: 1},
+			out.Namespaces[structs.DefaultNamespace])
+
+		// Only GET is allowed.
+		req, _ = http.NewRequest("PUT", "/v1/operator/scheduler/broker", nil)
+		_, err = s.Server.OperatorSchedulerBrokerStats(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), ErrInvalidMethod)
+	})
+}
+
+func TestOperator_SchedulerRebalance(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		require := require.New(t)
+		body := bytes.NewBuffer([]byte(`{"DryRun": true, "MaxMigrations": 2}`))
+		req, _ := http.NewRequest("PUT", "/v1/operator/scheduler/rebalance", body)
+		resp := httptest.NewRecorder()
+		obj, err := s.Server.OperatorSchedulerRebalance(resp, req)
+		require.Nil(err)
+		require.Equal(200, resp.Code)
+		require.NotEmpty(resp.Header().Get("X-Nomad-Index"))
+		out, ok := obj.(structs.SchedulerRebalanceResponse)
+		require.True(ok)
+		require.Empty(out.Migrations)
+		require.Empty(out.EvalIDs)
+
+		// A negative budget is rejected.
+		body = bytes.NewBuffer([]byte(`{"MaxMigrations": -1}`))
+		req, _ = http.NewRequest("PUT", "/v1/operator/scheduler/rebalance", body)
+		_, err = s.Server.OperatorSchedulerRebalance(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), "max migrations must be positive")
+
+		// Only PUT and POST are allowed.
+		req, _ = http.NewRequest("GET", "/v1/operator/scheduler/rebalance", nil)
+		_, err = s.Server.OperatorSchedulerRebalance(httptest.NewRecorder(), req)
+		require.Error(err)
+		require.Contains(err.Error(), ErrInvalidMethod)
+	})
+}
+
 func TestOperator_SchedulerSetConfiguration(t *testing.T) {
 	ci.Parallel(t)
 	httpTest(t, nil, func(s *TestAgent) {
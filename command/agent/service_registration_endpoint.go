@@ -95,6 +95,15 @@ func (s *HTTPServer) serviceGetRequest(
 		return nil, nil
 	}
 
+	includeUnhealthy, err := parseBool(req, "include_unhealthy")
+	if err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+	if includeUnhealthy != nil {
+		args.IncludeUnhealthy = *includeUnhealthy
+	}
+	args.Choose = req.URL.Query().Get("choose")
+
 	var reply structs.ServiceRegistrationByNameResponse
 	if err := s.agent.RPC(structs.ServiceRegistrationGetServiceRPCMethod, &args, &reply); err != nil {
 		return nil, err
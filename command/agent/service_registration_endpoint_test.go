@@ -216,6 +216,47 @@ func TestHTTPServer_ServiceRegistrationRequest(t *testing.T) {
 			},
 			name: "get service by name",
 		},
+		{
+			testFn: func(s *TestAgent) {
+
+				// Grab the state, so we can manipulate it and test against it.
+				testState := s.Agent.server.State()
+
+				// Generate several registrations of the same service and
+				// upsert them.
+				var serviceRegs []*structs.ServiceRegistration
+				for i := 0; i < 5; i++ {
+					serviceReg := mock.ServiceRegistrations()[0]
+					serviceReg.ID = fmt.Sprintf("%s-%d", serviceReg.ID, i)
+					serviceRegs = append(serviceRegs, serviceReg)
+				}
+				require.NoError(t, testState.UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 10, serviceRegs))
+
+				// Build the HTTP request, choosing two registrations.
+				path := fmt.Sprintf("/v1/service/%s?choose=2|abc123", serviceRegs[0].ServiceName)
+				req, err := http.NewRequest(http.MethodGet, path, nil)
+				require.NoError(t, err)
+				respW := httptest.NewRecorder()
+
+				// Send the HTTP request.
+				obj, err := s.Server.ServiceRegistrationRequest(respW, req)
+				require.NoError(t, err)
+				require.Len(t, obj.([]*structs.ServiceRegistration), 2)
+
+				// A malformed choose parameter is rejected.
+				path = fmt.Sprintf("/v1/service/%s?choose=abc123", serviceRegs[0].ServiceName)
+				req, err = http.NewRequest(http.MethodGet, path, nil)
+				require.NoError(t, err)
+				respW = httptest.NewRecorder()
+
+				obj, err = s.Server.ServiceRegistrationRequest(respW, req)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "invalid choose parameter")
+				require.Nil(t, obj)
+			},
+			name: "get service choose",
+		},
 		{
 			testFn: func(s *TestAgent) {
 
@@ -96,6 +96,15 @@ client {
     path = "/tmp"
   }
 
+  log_sink "siem" {
+    type       = "syslog"
+    address    = "udp://127.0.0.1:514"
+    batch_wait = "2s"
+  }
+
+  log_sink_unix_sockets         = ["/dev/log"]
+  log_sink_network_destinations = ["logs.example.com:514"]
+
   cni_path              = "/tmp/cni_path"
   bridge_network_name   = "custom_bridge_name"
   bridge_network_subnet = "custom_bridge_subnet"
@@ -116,6 +125,10 @@ server {
   deployment_gc_threshold       = "12h"
   csi_volume_claim_gc_threshold = "12h"
   csi_plugin_gc_threshold       = "12h"
+  root_key_gc_interval          = "3m"
+  root_key_gc_threshold         = "12h"
+  root_key_rotation_threshold   = "720h"
+  rebalance_interval            = "10m"
   heartbeat_grace               = "30s"
   min_heartbeat_ttl             = "33s"
   max_heartbeats_per_second     = 11.0
@@ -129,6 +142,8 @@ server {
   redundancy_zone               = "foo"
   upgrade_version               = "0.8.0"
   encrypt                       = "abc"
+  keyring_replication_token     = "def"
+  keystore_key                  = "AAECAwQFBgcICQoLDA0ODxAREhMUFRYXGBkaGxwdHh8="
   raft_multiplier               = 4
   enable_event_broker           = false
   event_buffer_size             = 200
@@ -149,6 +164,22 @@ server {
     }
   }
 
+  eval_rate_limit {
+    namespace_rate  = 50
+    namespace_burst = 100
+    job_rate        = 0.5
+    job_burst       = 5
+  }
+
+  score_plugin "node-meta" {
+    weight = 0.5
+
+    config {
+      key    = "power_cost"
+      invert = true
+    }
+  }
+
   license_path = "/tmp/nomad.hclic"
 }
 
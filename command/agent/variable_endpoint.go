@@ -0,0 +1,151 @@
+package agent
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+func (s *HTTPServer) VariablesListRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	if req.Method != "GET" {
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+
+	args := structs.VariablesListRequest{}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.VariablesListResponse
+	if err := s.agent.RPC(structs.VariablesListRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Data == nil {
+		out.Data = make([]*structs.VariableMetadata, 0)
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) VariableSpecificRequest(resp http.ResponseWriter, req *http.Request) (interface{}, error) {
+	path := strings.TrimPrefix(req.URL.Path, "/v1/var/")
+	if len(path) == 0 {
+		return nil, CodedError(http.StatusBadRequest, "missing variable path")
+	}
+	switch req.Method {
+	case "GET":
+		return s.variableQuery(resp, req, path)
+	case "PUT", "POST":
+		return s.variableUpsert(resp, req, path)
+	case "DELETE":
+		return s.variableDelete(resp, req, path)
+	default:
+		return nil, CodedError(405, ErrInvalidMethod)
+	}
+}
+
+func (s *HTTPServer) variableQuery(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	args := structs.VariablesReadRequest{
+		Path: path,
+	}
+	if s.parse(resp, req, &args.Region, &args.QueryOptions) {
+		return nil, nil
+	}
+
+	var out structs.VariablesReadResponse
+	if err := s.agent.RPC(structs.VariablesReadRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+
+	setMeta(resp, &out.QueryMeta)
+	if out.Data == nil {
+		return nil, CodedError(http.StatusNotFound, "variable not found")
+	}
+	return out.Data, nil
+}
+
+func (s *HTTPServer) variableUpsert(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+	// Parse the variable
+	var variable structs.VariableDecrypted
+	if err := decodeBody(req, &variable); err != nil {
+		return nil, CodedError(http.StatusBadRequest, err.Error())
+	}
+
+	// Ensure the variable path matches
+	if variable.Path == "" {
+		variable.Path = path
+	} else if variable.Path != path {
+		return nil, CodedError(http.StatusBadRequest, "variable path does not match request path")
+	}
+
+	// Format the request
+	args := structs.VariablesUpsertRequest{
+		Var: &variable,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+	if err := parseCAS(req, &args.CheckIndex); err != nil {
+		return nil, err
+	}
+
+	var out structs.VariablesUpsertResponse
+	if err := s.agent.RPC(structs.VariablesUpsertRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	if out.Conflict != nil {
+		return variableConflict(resp, out.Conflict), nil
+	}
+	return out.Output, nil
+}
+
+func (s *HTTPServer) variableDelete(resp http.ResponseWriter, req *http.Request,
+	path string) (interface{}, error) {
+
+	args := structs.VariablesDeleteRequest{
+		Path: path,
+	}
+	s.parseWriteRequest(req, &args.WriteRequest)
+	if err := parseCAS(req, &args.CheckIndex); err != nil {
+		return nil, err
+	}
+
+	var out structs.VariablesDeleteResponse
+	if err := s.agent.RPC(structs.VariablesDeleteRPCMethod, &args, &out); err != nil {
+		return nil, err
+	}
+	setIndex(resp, out.Index)
+
+	if out.Conflict != nil {
+		return variableConflict(resp, out.Conflict), nil
+	}
+	return nil, nil
+}
+
+// parseCAS is used to parse the ?cas parameter, which holds the modify index
+// a variable must have for a write to succeed.
+func parseCAS(req *http.Request, checkIndex **uint64) error {
+	raw := req.URL.Query().Get("cas")
+	if raw == "" {
+		return nil
+	}
+	index, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return CodedError(http.StatusBadRequest, "failed to parse cas: "+err.Error())
+	}
+	*checkIndex = &index
+	return nil
+}
+
+// variableConflict writes the 409 status code for a check-and-set conflict
+// and returns the conflicting variable, which is used as the response body.
+func variableConflict(resp http.ResponseWriter, conflict *structs.VariableDecrypted) *structs.VariableDecrypted {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusConflict)
+	return conflict
+}
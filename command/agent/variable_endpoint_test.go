@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_Variables(t *testing.T) {
+	ci.Parallel(t)
+	httpTest(t, nil, func(s *TestAgent) {
+		sv := mock.Variable()
+
+		// Writing requires the keyring to be initialized by the leader.
+		var out *structs.VariableDecrypted
+		testutil.WaitForResult(func() (bool, error) {
+			buf, err := json.Marshal(sv)
+			if err != nil {
+				return false, err
+			}
+			req, err := http.NewRequest("PUT", "/v1/var/"+sv.Path, bytes.NewReader(buf))
+			if err != nil {
+				return false, err
+			}
+			obj, err := s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+			if err != nil {
+				return false, err
+			}
+			out = obj.(*structs.VariableDecrypted)
+			return true, nil
+		}, func(err error) {
+			t.Fatalf("failed to write variable: %v", err)
+		})
+		require.Equal(t, sv.Items, out.Items)
+
+		// Read the variable.
+		req, err := http.NewRequest("GET", "/v1/var/"+sv.Path, nil)
+		require.NoError(t, err)
+		respW := httptest.NewRecorder()
+		obj, err := s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.NotZero(t, respW.Header().Get("X-Nomad-Index"))
+		require.Equal(t, sv.Items, obj.(*structs.VariableDecrypted).Items)
+
+		// List the variables.
+		req, err = http.NewRequest("GET", "/v1/vars?prefix=var/", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.VariablesListRequest(respW, req)
+		require.NoError(t, err)
+		require.Len(t, obj.([]*structs.VariableMetadata), 1)
+
+		// A write with a mismatched path is rejected.
+		buf, err := json.Marshal(sv)
+		require.NoError(t, err)
+		req, err = http.NewRequest("PUT", "/v1/var/other/path", bytes.NewReader(buf))
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.ErrorContains(t, err, "does not match request path")
+
+		// A check-and-set delete with a stale index returns a conflict.
+		req, err = http.NewRequest("DELETE", "/v1/var/"+sv.Path+"?cas=1", nil)
+		require.NoError(t, err)
+		respW = httptest.NewRecorder()
+		obj, err = s.Server.VariableSpecificRequest(respW, req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, respW.Code)
+		require.Equal(t, out.ModifyIndex, obj.(*structs.VariableDecrypted).ModifyIndex)
+
+		// Delete the variable.
+		req, err = http.NewRequest("DELETE", "/v1/var/"+sv.Path, nil)
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.NoError(t, err)
+
+		req, err = http.NewRequest("GET", "/v1/var/"+sv.Path, nil)
+		require.NoError(t, err)
+		_, err = s.Server.VariableSpecificRequest(httptest.NewRecorder(), req)
+		require.EqualError(t, err, "variable not found")
+	})
+}
@@ -0,0 +1,215 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type AllocChecksCommand struct {
+	Meta
+}
+
+func (c *AllocChecksCommand) Help() string {
+	helpText := `
+Usage: nomad alloc checks [options] <allocation>
+
+  Outputs the latest results of the checks of the services of an allocation
+  which use the nomad provider. These checks are run by the Nomad client
+  running the allocation.
+
+  When ACLs are enabled, this command requires a token with the 'read-job' and
+  'list-jobs' capabilities for the allocation's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Checks Options:
+
+  -json
+    Output the check results in its JSON format.
+
+  -t
+    Format and display the check results using a Go template.
+
+  -verbose
+    Show full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *AllocChecksCommand) Synopsis() string {
+	return "Outputs service check results of an allocation"
+}
+
+func (c *AllocChecksCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json":    complete.PredictNothing,
+			"-t":       complete.PredictAnything,
+			"-verbose": complete.PredictNothing,
+		})
+}
+
+func (c *AllocChecksCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Allocs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Allocs]
+	})
+}
+
+func (c *AllocChecksCommand) Name() string { return "alloc checks" }
+
+func (c *AllocChecksCommand) Run(args []string) int {
+	var verbose, json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one alloc
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <alloc-id>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	allocID := args[0]
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Query the allocation info
+	if len(allocID) == 1 {
+		c.Ui.Error("Alloc ID must contain at least two characters.")
+		return 1
+	}
+
+	allocID = sanitizeUUIDPrefix(allocID)
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	allocs, _, err := client.Allocations().PrefixList(allocID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation: %v", err))
+		return 1
+	}
+
+	if len(allocs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No allocation(s) with prefix or id %q found", allocID))
+		return 1
+	}
+
+	if len(allocs) > 1 {
+		// Format the allocs
+		out := formatAllocListStubs(allocs, verbose, length)
+		c.Ui.Error(fmt.Sprintf("Prefix matched multiple allocations\n\n%s", out))
+		return 1
+	}
+
+	// Prefix lookup matched a single allocation
+	q := &api.QueryOptions{Namespace: allocs[0].Namespace}
+	checks, err := client.Allocations().Checks(allocs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying allocation checks: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, checks)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(checks) == 0 {
+		c.Ui.Output("No service checks found")
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[bold]Status of %d Nomad service checks of allocation %q[reset]\n",
+		len(checks), limit(allocs[0].ID, length))))
+	c.Ui.Output(formatAllocChecks(checks))
+	return 0
+}
+
+// formatAllocChecks formats the check results, ordered by group, task, service
+// and check names.
+func formatAllocChecks(checks api.AllocCheckStatuses) string {
+	results := make([]api.AllocCheckStatus, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, check)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Task != b.Task {
+			return a.Task < b.Task
+		}
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Check < b.Check
+	})
+
+	out := make([]string, 0, len(results))
+	for _, check := range results {
+		task := check.Task
+		if task == "" {
+			task = "(group)"
+		}
+		kv := []string{
+			fmt.Sprintf("ID|%s", check.ID),
+			fmt.Sprintf("Check|%s", check.Check),
+			fmt.Sprintf("Group|%s", check.Group),
+			fmt.Sprintf("Task|%s", task),
+			fmt.Sprintf("Service|%s", check.Service),
+			fmt.Sprintf("Status|%s", check.Status),
+		}
+		if check.StatusCode != 0 {
+			kv = append(kv, fmt.Sprintf("Status Code|%d", check.StatusCode))
+		}
+		kv = append(kv,
+			fmt.Sprintf("Timestamp|%s", formatTime(time.Unix(check.Timestamp, 0))),
+			fmt.Sprintf("Output|%s", strings.TrimSpace(check.Output)),
+		)
+		out = append(out, formatKV(kv))
+	}
+	return strings.Join(out, "\n\n")
+}
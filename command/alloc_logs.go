@@ -2,10 +2,12 @@ package command
 
 import (
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"regexp"
 	"strings"
 	"syscall"
 	"time"
@@ -62,7 +64,7 @@ Logs Specific Options:
 
   -c
     Sets the tail location in number of bytes relative to the end of the logs.
-
+` + logsFilterOptionsUsage + `
   Note that the -no-color option applies to Nomad's own output. If the task's
   logs include terminal escape sequences for color codes, Nomad will not
   remove them.
@@ -86,7 +88,7 @@ func (l *AllocLogsCommand) AutocompleteFlags() complete.Flags {
 			"-tail":    complete.PredictAnything,
 			"-n":       complete.PredictAnything,
 			"-c":       complete.PredictAnything,
-		})
+		}, logsFilterAutocompleteFlags)
 }
 
 func (l *AllocLogsCommand) AutocompleteArgs() complete.Predictor {
@@ -110,6 +112,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	var verbose, job, tail, stderr, follow bool
 	var numLines, numBytes int64
 	var task string
+	var filterFlags logsFilterFlags
 
 	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
 	flags.Usage = func() { l.Ui.Output(l.Help()) }
@@ -121,12 +124,23 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	flags.Int64Var(&numLines, "n", -1, "")
 	flags.Int64Var(&numBytes, "c", -1, "")
 	flags.StringVar(&task, "task", "", "")
+	filterFlags.register(flags)
 
 	if err := flags.Parse(args); err != nil {
 		return 1
 	}
 	args = flags.Args()
 
+	filter, err := filterFlags.filter(time.Now())
+	if err != nil {
+		l.Ui.Error(err.Error())
+		return 1
+	}
+	if filter != nil && (tail || numLines != -1 || numBytes != -1) {
+		l.Ui.Error("The -tail, -n and -c options can't be combined with the -since, -until, -grep and -tail-lines options")
+		return 1
+	}
+
 	if numArgs := len(args); numArgs < 1 {
 		if job {
 			l.Ui.Error("A job ID is required")
@@ -222,7 +236,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 	var r io.ReadCloser
 	var readErr error
 	if !tail {
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginStart, 0, filter)
 		if readErr != nil {
 			readErr = fmt.Errorf("Error reading file: %v", readErr)
 		}
@@ -241,7 +255,7 @@ func (l *AllocLogsCommand) Run(args []string) int {
 			numLines = defaultTailLines
 		}
 
-		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset)
+		r, readErr = l.followFile(client, alloc, follow, task, logType, api.OriginEnd, offset, nil)
 
 		// If numLines is set, wrap the reader
 		if numLines != -1 {
@@ -269,12 +283,12 @@ func (l *AllocLogsCommand) Run(args []string) int {
 }
 
 // followFile outputs the contents of the file to stdout relative to the end of
-// the file.
+// the file. The lines are filtered by the client if filter isn't nil.
 func (l *AllocLogsCommand) followFile(client *api.Client, alloc *api.Allocation,
-	follow bool, task, logType, origin string, offset int64) (io.ReadCloser, error) {
+	follow bool, task, logType, origin string, offset int64, filter *api.LogsFilter) (io.ReadCloser, error) {
 
 	cancel := make(chan struct{})
-	frames, errCh := client.AllocFS().Logs(alloc, follow, task, logType, origin, offset, cancel, nil)
+	frames, errCh := client.AllocFS().LogsWithFilter(alloc, follow, task, logType, origin, offset, filter, cancel, nil)
 	select {
 	case err := <-errCh:
 		return nil, err
@@ -317,3 +331,102 @@ func lookupAllocTask(alloc *api.Allocation) (string, error) {
 	fmt.Fprintf(&errStr, "\nPlease specify the task.")
 	return "", errors.New(errStr.String())
 }
+
+// logsFilterOptionsUsage is the usage of the options registered by
+// logsFilterFlags.
+const logsFilterOptionsUsage = `
+  -since <time>
+    Only show the lines written since the given time, either an RFC 3339
+    timestamp or a duration relative to now such as "10m". The time lines were
+    written at is approximated to the second by the client.
+
+  -until <time>
+    Only show the lines written until the given time, either an RFC 3339
+    timestamp or a duration relative to now such as "5m". The output stops
+    once lines are written past this time, even with the -f option.
+
+  -grep <regexp>
+    Only show the lines matching the given regular expression.
+
+  -tail-lines <n>
+    Only show the last n lines matching the other filters, before following
+    new lines with the -f option.
+`
+
+// logsFilterAutocompleteFlags are the flags registered by logsFilterFlags.
+var logsFilterAutocompleteFlags = complete.Flags{
+	"-since":      complete.PredictAnything,
+	"-until":      complete.PredictAnything,
+	"-grep":       complete.PredictAnything,
+	"-tail-lines": complete.PredictAnything,
+}
+
+// logsFilterFlags are the options filtering the lines of logs on the client
+// running the allocation.
+type logsFilterFlags struct {
+	since     string
+	until     string
+	grep      string
+	tailLines int
+}
+
+// register registers the options in the flag set.
+func (f *logsFilterFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.since, "since", "", "")
+	flags.StringVar(&f.until, "until", "", "")
+	flags.StringVar(&f.grep, "grep", "", "")
+	flags.IntVar(&f.tailLines, "tail-lines", 0, "")
+}
+
+// filter returns the filter of the options, or nil if none is set. Durations
+// are relative to now.
+func (f *logsFilterFlags) filter(now time.Time) (*api.LogsFilter, error) {
+	if f.since == "" && f.until == "" && f.grep == "" && f.tailLines == 0 {
+		return nil, nil
+	}
+
+	filter := &api.LogsFilter{
+		Grep:      f.grep,
+		TailLines: f.tailLines,
+	}
+	if f.tailLines < 0 {
+		return nil, fmt.Errorf("Invalid -tail-lines value %d: must not be negative", f.tailLines)
+	}
+	if f.grep != "" {
+		if _, err := regexp.Compile(f.grep); err != nil {
+			return nil, fmt.Errorf("Invalid -grep regular expression: %v", err)
+		}
+	}
+
+	var err error
+	if f.since != "" {
+		if filter.Since, err = parseLogsTime(f.since, now); err != nil {
+			return nil, fmt.Errorf("Invalid -since value: %v", err)
+		}
+	}
+	if f.until != "" {
+		if filter.Until, err = parseLogsTime(f.until, now); err != nil {
+			return nil, fmt.Errorf("Invalid -until value: %v", err)
+		}
+	}
+	if !filter.Since.IsZero() && !filter.Until.IsZero() && filter.Until.Before(filter.Since) {
+		return nil, errors.New("The -until time must not be before the -since time")
+	}
+	return filter, nil
+}
+
+// parseLogsTime parses either an RFC 3339 timestamp or a duration before now.
+func parseLogsTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor an RFC 3339 timestamp", s)
+	}
+	return t, nil
+}
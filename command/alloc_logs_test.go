@@ -3,13 +3,16 @@ package command
 import (
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/nomad/api"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestLogsCommand_Implements(t *testing.T) {
@@ -70,6 +73,87 @@ func TestLogsCommand_Fails(t *testing.T) {
 	}
 }
 
+func TestLogsCommand_FilterFlags(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		flags    logsFilterFlags
+		expected *api.LogsFilter
+		err      string
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:  "durations",
+			flags: logsFilterFlags{since: "10m", until: "1m", tailLines: 5},
+			expected: &api.LogsFilter{
+				Since:     now.Add(-10 * time.Minute),
+				Until:     now.Add(-time.Minute),
+				TailLines: 5,
+			},
+		},
+		{
+			name:  "timestamp",
+			flags: logsFilterFlags{since: "2022-06-01T11:00:00Z", grep: "ERROR"},
+			expected: &api.LogsFilter{
+				Since: time.Date(2022, 6, 1, 11, 0, 0, 0, time.UTC),
+				Grep:  "ERROR",
+			},
+		},
+		{
+			name:  "invalid time",
+			flags: logsFilterFlags{since: "yesterday"},
+			err:   "Invalid -since value",
+		},
+		{
+			name:  "negative duration",
+			flags: logsFilterFlags{until: "-5m"},
+			err:   "must not be negative",
+		},
+		{
+			name:  "until before since",
+			flags: logsFilterFlags{since: "5m", until: "10m"},
+			err:   "must not be before",
+		},
+		{
+			name:  "invalid grep",
+			flags: logsFilterFlags{grep: "("},
+			err:   "Invalid -grep regular expression",
+		},
+		{
+			name:  "negative tail lines",
+			flags: logsFilterFlags{tailLines: -1},
+			err:   "Invalid -tail-lines value",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := tc.flags.filter(now)
+			if tc.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, filter)
+		})
+	}
+}
+
+func TestLogsCommand_FilterWithTail(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &AllocLogsCommand{Meta: Meta{Ui: ui}}
+
+	code := cmd.Run([]string{"-tail", "-grep=ERROR", "foobar"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "can't be combined")
+}
+
 func TestLogsCommand_AutocompleteArgs(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
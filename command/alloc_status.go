@@ -540,6 +540,10 @@ func buildDisplayMessage(event *api.TaskEvent) string {
 		desc = "Leader Task in Group dead"
 	case api.TaskClientReconnected:
 		desc = "Client reconnected"
+	case api.TaskCheckpointed:
+		desc = "Task checkpointed for migration"
+	case api.TaskRestoredCheckpoint:
+		desc = "Task restored from the checkpoint of the previous allocation"
 	default:
 		desc = event.Message
 	}
@@ -0,0 +1,316 @@
+package command
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/posener/complete"
+)
+
+type JobLogsCommand struct {
+	Meta
+}
+
+func (c *JobLogsCommand) Help() string {
+	helpText := `
+Usage: nomad job logs [options] <job>
+
+  Streams the stdout/stderr of the tasks of a job's running allocations. Each
+  line is prefixed by the allocation ID and task name it was written by.
+
+  When ACLs are enabled, this command requires a token with the 'read-logs',
+  'read-job', and 'list-jobs' capabilities for the job's namespace.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Logs Options:
+
+  -stderr
+    Display stderr logs.
+
+  -task <task-name>
+    Only display the logs of the given task.
+
+  -all-allocs
+    Display the logs of all the job's allocations, including those which are
+    no longer running.
+
+  -verbose
+    Prefix the lines with full allocation IDs.
+
+  -f
+    Causes the output to not stop when the end of the logs are reached, but
+    rather to wait for additional output.
+` + logsFilterOptionsUsage + `
+  Note that the -no-color option applies to Nomad's own output. If the tasks'
+  logs include terminal escape sequences for color codes, Nomad will not
+  remove them.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobLogsCommand) Synopsis() string {
+	return "Streams the logs of the tasks of a job"
+}
+
+func (c *JobLogsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-stderr":     complete.PredictNothing,
+			"-task":       complete.PredictAnything,
+			"-all-allocs": complete.PredictNothing,
+			"-verbose":    complete.PredictNothing,
+			"-f":          complete.PredictNothing,
+		}, logsFilterAutocompleteFlags)
+}
+
+func (c *JobLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobLogsCommand) Name() string { return "job logs" }
+
+func (c *JobLogsCommand) Run(args []string) int {
+	var stderr, allAllocs, verbose, follow bool
+	var task string
+	var filterFlags logsFilterFlags
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&stderr, "stderr", false, "")
+	flags.BoolVar(&allAllocs, "all-allocs", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&follow, "f", false, "")
+	flags.StringVar(&task, "task", "", "")
+	filterFlags.register(flags)
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	filter, err := filterFlags.filter(time.Now())
+	if err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	jobID := strings.TrimSpace(args[0])
+
+	// Check if the job exists
+	jobs, _, err := client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error listing jobs: %s", err))
+		return 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return 1
+		}
+	}
+
+	jobID = jobs[0].ID
+	q := &api.QueryOptions{Namespace: jobs[0].JobSummary.Namespace}
+
+	allocs, _, err := client.Jobs().Allocations(jobID, false, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving allocations: %s", err))
+		return 1
+	}
+
+	// Truncate the id unless full length is requested
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	streams := jobLogStreams(allocs, task, allAllocs, length)
+	if len(streams) == 0 {
+		c.Ui.Error(fmt.Sprintf("No started tasks found in the allocations of job %q", jobID))
+		return 1
+	}
+
+	logType := "stdout"
+	if stderr {
+		logType = "stderr"
+	}
+
+	// End the streams on interrupt
+	cancel := make(chan struct{})
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			close(cancel)
+		case <-cancel:
+		}
+	}()
+
+	out := &prefixedWriter{w: os.Stdout}
+	var wg sync.WaitGroup
+	var failed int
+	var failedLock sync.Mutex
+	for _, s := range streams {
+		wg.Add(1)
+		go func(s jobLogStream) {
+			defer wg.Done()
+			if err := s.copy(client, follow, logType, filter, cancel, out); err != nil {
+				failedLock.Lock()
+				c.Ui.Error(fmt.Sprintf("Error reading logs of %s: %s", s.prefix, err))
+				failed++
+				failedLock.Unlock()
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	select {
+	case <-cancel:
+	default:
+		close(cancel)
+	}
+
+	if failed != 0 {
+		return 1
+	}
+	return 0
+}
+
+// jobLogStream is the logs of a task of an allocation of a job.
+type jobLogStream struct {
+	alloc  *api.Allocation
+	task   string
+	prefix string
+}
+
+// jobLogStreams returns the streams of the tasks of the allocations which
+// started, sorted by allocation and task. Only the running allocations are
+// included unless all is true.
+func jobLogStreams(allocs []*api.AllocationListStub, task string, all bool, length int) []jobLogStream {
+	var streams []jobLogStream
+	for _, a := range allocs {
+		if !all && a.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+
+		// Stream the logs through the node running the allocation
+		alloc := &api.Allocation{
+			ID:        a.ID,
+			Namespace: a.Namespace,
+			NodeID:    a.NodeID,
+		}
+		for name, state := range a.TaskStates {
+			if task != "" && name != task {
+				continue
+			}
+			if state == nil || state.StartedAt.IsZero() {
+				continue
+			}
+			streams = append(streams, jobLogStream{
+				alloc:  alloc,
+				task:   name,
+				prefix: fmt.Sprintf("%s/%s", limit(a.ID, length), name),
+			})
+		}
+	}
+
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].alloc.ID != streams[j].alloc.ID {
+			return streams[i].alloc.ID < streams[j].alloc.ID
+		}
+		return streams[i].task < streams[j].task
+	})
+	return streams
+}
+
+// copy writes the lines of the logs prefixed by the stream's prefix until the
+// end of the logs, or until cancel is closed if follow is true.
+func (s jobLogStream) copy(client *api.Client, follow bool, logType string,
+	filter *api.LogsFilter, cancel chan struct{}, out *prefixedWriter) error {
+
+	frames, errCh := client.AllocFS().LogsWithFilter(s.alloc, follow, s.task, logType,
+		api.OriginStart, 0, filter, cancel, &api.QueryOptions{Namespace: s.alloc.Namespace})
+	select {
+	case err := <-errCh:
+		return err
+	default:
+	}
+
+	// Reads end once cancel is closed
+	r := bufio.NewReader(api.NewFrameReader(frames, errCh, cancel))
+	for {
+		line, err := r.ReadString('\n')
+		if line != "" {
+			if !strings.HasSuffix(line, "\n") {
+				line += "\n"
+			}
+			if werr := out.WriteLine(s.prefix, line); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// prefixedWriter writes the lines of several streams with their prefix,
+// without interleaving them.
+type prefixedWriter struct {
+	w io.Writer
+	l sync.Mutex
+}
+
+// WriteLine writes the line prefixed by the prefix.
+func (p *prefixedWriter) WriteLine(prefix, line string) error {
+	p.l.Lock()
+	defer p.l.Unlock()
+	_, err := fmt.Fprintf(p.w, "[%s] %s", prefix, line)
+	return err
+}
@@ -0,0 +1,100 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobLogsCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobLogsCommand{}
+}
+
+func TestJobLogsCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	srv, _, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &JobLogsCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	outerr := ui.ErrorWriter.String()
+	require.Equalf(t, 1, code, "expected exit code 1, got: %d", code)
+	require.Containsf(t, outerr, commandErrorText(cmd), "expected help output, got: %s", outerr)
+
+	ui.ErrorWriter.Reset()
+
+	// Bad filter
+	code = cmd.Run([]string{"-address=" + url, "-grep=[", "foo"})
+	outerr = ui.ErrorWriter.String()
+	require.Equalf(t, 1, code, "expected exit code 1, got: %d", code)
+	require.Containsf(t, outerr, "Invalid -grep regular expression", "expected filter error, got: %s", outerr)
+
+	ui.ErrorWriter.Reset()
+
+	// Bad address
+	code = cmd.Run([]string{"-address=nope", "foo"})
+	outerr = ui.ErrorWriter.String()
+	require.Equalf(t, 1, code, "expected exit code 1, got: %d", code)
+	require.Containsf(t, outerr, "Error listing jobs", "expected failed query error, got: %s", outerr)
+
+	ui.ErrorWriter.Reset()
+
+	// Bad job name
+	code = cmd.Run([]string{"-address=" + url, "foo"})
+	outerr = ui.ErrorWriter.String()
+	require.Equalf(t, 1, code, "expected exit 1, got: %d", code)
+	require.Containsf(t, outerr, "No job(s) with prefix or id \"foo\" found", "expected no job found, got: %s", outerr)
+}
+
+func TestJobLogsCommand_Streams(t *testing.T) {
+	ci.Parallel(t)
+
+	started := &api.TaskState{StartedAt: time.Now()}
+	allocs := []*api.AllocationListStub{
+		{
+			ID:           "bbbbbbbb-0000-0000-0000-000000000000",
+			NodeID:       "node",
+			ClientStatus: api.AllocClientStatusRunning,
+			TaskStates: map[string]*api.TaskState{
+				"web":     started,
+				"sidecar": started,
+				"pending": {},
+			},
+		},
+		{
+			ID:           "aaaaaaaa-0000-0000-0000-000000000000",
+			NodeID:       "node",
+			ClientStatus: api.AllocClientStatusComplete,
+			TaskStates: map[string]*api.TaskState{
+				"web": started,
+			},
+		},
+	}
+
+	prefixes := func(streams []jobLogStream) []string {
+		var out []string
+		for _, s := range streams {
+			out = append(out, s.prefix)
+		}
+		return out
+	}
+
+	// Only the started tasks of running allocations by default
+	streams := jobLogStreams(allocs, "", false, shortId)
+	require.Equal(t, []string{"bbbbbbbb/sidecar", "bbbbbbbb/web"}, prefixes(streams))
+	require.Equal(t, "node", streams[0].alloc.NodeID)
+
+	streams = jobLogStreams(allocs, "web", true, shortId)
+	require.Equal(t, []string{"aaaaaaaa/web", "bbbbbbbb/web"}, prefixes(streams))
+
+	streams = jobLogStreams(allocs, "web", false, fullId)
+	require.Equal(t, []string{"bbbbbbbb-0000-0000-0000-000000000000/web"}, prefixes(streams))
+}
@@ -98,7 +98,12 @@ Plan Options:
     Path to HCL2 file containing user variables.
 
   -verbose
-    Increase diff verbosity.
+    Increase diff verbosity, and display full IDs of the allocations that
+    would be preempted.
+
+  If the job has spreads with a max_skew, the number of allocations each task
+  group would have in the domains of the spread attributes is displayed along
+  with their skew.
 `
 	return strings.TrimSpace(helpText)
 }
@@ -274,20 +279,96 @@ func (c *JobPlanCommand) outputPlannedJob(job *api.Job, resp *api.JobPlanRespons
 
 	// Print preemptions if there are any
 	if resp.Annotations != nil && len(resp.Annotations.PreemptedAllocs) > 0 {
-		c.addPreemptions(resp)
+		c.addPreemptions(resp, verbose)
+	}
+
+	// Print the distribution of max_skew spreads if there are any
+	if resp.Annotations != nil && len(resp.Annotations.SpreadDistributions) > 0 {
+		c.addSpreadDistributions(resp)
 	}
 
 	return getExitCode(resp)
 }
 
+// addSpreadDistributions shows the number of allocations of each task group
+// placed in the domains of its max_skew spreads once the plan is applied. The
+// skew of a domain is its number of allocations minus the minimum number of
+// allocations of the domains within the same parent domain.
+func (c *JobPlanCommand) addSpreadDistributions(resp *api.JobPlanResponse) {
+	c.Ui.Output(c.Colorize().Color("[bold]Spread Distributions:\n[reset]"))
+
+	groups := make([]string, 0, len(resp.Annotations.SpreadDistributions))
+	for group := range resp.Annotations.SpreadDistributions {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	rows := []string{"Task Group|Attribute|Domain|Allocs|Skew|Max Skew"}
+	for _, group := range groups {
+		for _, dist := range resp.Annotations.SpreadDistributions[group] {
+			// Domains are sorted by key, so nested domains are listed
+			// after their parent
+			domains := make([]string, 0, len(dist.Counts))
+			minCounts := make(map[string]int)
+			for domain, count := range dist.Counts {
+				domains = append(domains, domain)
+				parent := spreadDomainParent(domain)
+				if min, ok := minCounts[parent]; !ok || count < min {
+					minCounts[parent] = count
+				}
+			}
+			sort.Strings(domains)
+
+			for _, domain := range domains {
+				attribute := ""
+				if level := strings.Count(domain, "/"); level < len(dist.Attributes) {
+					attribute = dist.Attributes[level]
+				}
+				count := dist.Counts[domain]
+				rows = append(rows, fmt.Sprintf("%s|%s|%s|%d|%d|%d", group, attribute, domain,
+					count, count-minCounts[spreadDomainParent(domain)], dist.MaxSkew))
+			}
+		}
+	}
+	c.Ui.Output(formatList(rows))
+	c.Ui.Output("")
+}
+
+// spreadDomainParent returns the key of the domain a spread domain is nested
+// within, which is empty for the top level domains.
+func spreadDomainParent(domain string) string {
+	if i := strings.LastIndex(domain, "/"); i >= 0 {
+		return domain[:i]
+	}
+	return ""
+}
+
 // addPreemptions shows details about preempted allocations
-func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse) {
+func (c *JobPlanCommand) addPreemptions(resp *api.JobPlanResponse, verbose bool) {
 	c.Ui.Output(c.Colorize().Color("[bold][yellow]Preemptions:\n[reset]"))
 	if len(resp.Annotations.PreemptedAllocs) < preemptionDisplayThreshold {
+		length := shortId
+		if verbose {
+			length = fullId
+		}
+
+		// Sort the allocations by node, so the allocations preempted to
+		// place on the same node are listed together
+		preempted := make([]*api.AllocationListStub, len(resp.Annotations.PreemptedAllocs))
+		copy(preempted, resp.Annotations.PreemptedAllocs)
+		sort.Slice(preempted, func(i, j int) bool {
+			if preempted[i].NodeID != preempted[j].NodeID {
+				return preempted[i].NodeID < preempted[j].NodeID
+			}
+			return preempted[i].ID < preempted[j].ID
+		})
+
 		var allocs []string
-		allocs = append(allocs, "Alloc ID|Job ID|Task Group")
-		for _, alloc := range resp.Annotations.PreemptedAllocs {
-			allocs = append(allocs, fmt.Sprintf("%s|%s|%s", alloc.ID, alloc.JobID, alloc.TaskGroup))
+		allocs = append(allocs, "Alloc ID|Node ID|Namespace|Job ID|Task Group|Alloc Name")
+		for _, alloc := range preempted {
+			allocs = append(allocs, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+				limit(alloc.ID, length), limit(alloc.NodeID, length),
+				alloc.Namespace, alloc.JobID, alloc.TaskGroup, alloc.Name))
 		}
 		c.Ui.Output(formatList(allocs))
 		return
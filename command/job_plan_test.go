@@ -185,19 +185,32 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 			PreemptedAllocs: []*api.AllocationListStub{
 				{
 					ID:        "alloc1",
+					NodeID:    "node2",
 					JobID:     "jobID1",
 					TaskGroup: "meta",
 					JobType:   "batch",
 					Namespace: "test",
 				},
+				{
+					ID:        "alloc2",
+					NodeID:    "node1",
+					JobID:     "jobID2",
+					TaskGroup: "cache",
+					JobType:   "service",
+					Namespace: "test",
+				},
 			},
 		},
 	}
-	cmd.addPreemptions(resp1)
+	cmd.addPreemptions(resp1, false)
 	out := ui.OutputWriter.String()
 	require.Contains(out, "Alloc ID")
+	require.Contains(out, "Node ID")
 	require.Contains(out, "alloc1")
 
+	// Allocations are listed by node
+	require.Less(strings.Index(out, "alloc2"), strings.Index(out, "alloc1"))
+
 	// Less than 10 unique job ids
 	var preemptedAllocs []*api.AllocationListStub
 	for i := 0; i < 12; i++ {
@@ -218,7 +231,7 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 		},
 	}
 	ui.OutputWriter.Reset()
-	cmd.addPreemptions(resp2)
+	cmd.addPreemptions(resp2, false)
 	out = ui.OutputWriter.String()
 	require.Contains(out, "Job ID")
 	require.Contains(out, "Namespace")
@@ -249,13 +262,52 @@ func TestPlanCommad_Preemptions(t *testing.T) {
 		},
 	}
 	ui.OutputWriter.Reset()
-	cmd.addPreemptions(resp3)
+	cmd.addPreemptions(resp3, false)
 	out = ui.OutputWriter.String()
 	require.Contains(out, "Job Type")
 	require.Contains(out, "batch")
 	require.Contains(out, "service")
 }
 
+func TestPlanCommand_SpreadDistributions(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &JobPlanCommand{Meta: Meta{Ui: ui}}
+
+	resp := &api.JobPlanResponse{
+		Annotations: &api.PlanAnnotations{
+			SpreadDistributions: map[string][]*api.SpreadDistribution{
+				"cache": {
+					{
+						Attributes: []string{"${meta.zone}", "${meta.rack}"},
+						MaxSkew:    1,
+						Counts: map[string]int{
+							"zone-b":    1,
+							"zone-a":    2,
+							"zone-a/r2": 1,
+							"zone-a/r1": 1,
+							"zone-b/r3": 1,
+						},
+					},
+				},
+			},
+		},
+	}
+	cmd.addSpreadDistributions(resp)
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Spread Distributions")
+
+	// Nested domains are listed after their parent, with the skew relative
+	// to their siblings
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 8)
+	require.Regexp(t, `cache\s+\$\{meta.zone\}\s+zone-a\s+2\s+1\s+1`, lines[3])
+	require.Regexp(t, `cache\s+\$\{meta.rack\}\s+zone-a/r1\s+1\s+0\s+1`, lines[4])
+	require.Regexp(t, `cache\s+\$\{meta.rack\}\s+zone-a/r2\s+1\s+0\s+1`, lines[5])
+	require.Regexp(t, `cache\s+\$\{meta.zone\}\s+zone-b\s+1\s+0\s+1`, lines[6])
+	require.Regexp(t, `cache\s+\$\{meta.rack\}\s+zone-b/r3\s+1\s+0\s+1`, lines[7])
+}
+
 func TestPlanCommad_JSON(t *testing.T) {
 	ui := cli.NewMockUi()
 	cmd := &JobPlanCommand{
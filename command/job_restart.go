@@ -0,0 +1,882 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/api/contexts"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+const (
+	// jobRestartOnErrorAsk prompts the user whether to continue the restart
+	// when a batch fails.
+	jobRestartOnErrorAsk = "ask"
+
+	// jobRestartOnErrorFail stops the restart when a batch fails.
+	jobRestartOnErrorFail = "fail"
+
+	// jobRestartBatchWaitAsk is the -batch-wait value which prompts the user
+	// before restarting the next batch.
+	jobRestartBatchWaitAsk = "ask"
+
+	// taskStateRunning is the state of a running task.
+	taskStateRunning = "running"
+
+	// checkStatusSuccess is the status of a passing Nomad service check.
+	checkStatusSuccess = "success"
+
+	// defaultHealthyDeadline is the time allowed for an allocation to become
+	// healthy when its task group has no update block.
+	defaultHealthyDeadline = 5 * time.Minute
+)
+
+// jobRestartCheckPollInterval is the interval at which the checks of a
+// restarted allocation are polled while waiting for it to become healthy.
+var jobRestartCheckPollInterval = time.Second
+
+type JobRestartCommand struct {
+	Meta
+
+	// batchSize is the number of allocations restarted at the same time, or
+	// the percentage of the allocations if batchSizePercent is set.
+	batchSize        int
+	batchSizePercent bool
+
+	// batchWait is the time to wait between batches, unless batchWaitAsk is
+	// set, in which case the user is asked before restarting the next batch.
+	batchWait    time.Duration
+	batchWaitAsk bool
+
+	groups     map[string]struct{}
+	tasks      map[string]struct{}
+	allTasks   bool
+	reschedule bool
+	onError    string
+	autoYes    bool
+	length     int
+
+	// since is the time the restart started. Allocations created or
+	// restarted after this time are skipped, which allows an interrupted
+	// restart to resume.
+	since time.Time
+
+	client *api.Client
+	ui     cli.Ui
+}
+
+func (c *JobRestartCommand) Help() string {
+	helpText := `
+Usage: nomad job restart [options] <job>
+
+  Restart the running allocations of a job in batches, without changing the
+  job specification. Each batch of allocations is restarted at the same time,
+  and the next batch is only restarted once the allocations of the previous
+  batch are running and healthy again. Allocations which are part of a
+  deployment are healthy once the client marks them healthy, and other
+  allocations once the checks of their Nomad services pass after the restart.
+  Allocations must become healthy within the healthy_deadline of their task
+  group's update block, which defaults to 5m.
+
+  By default, the running tasks of each allocation are restarted in place. The
+  -reschedule option stops the allocations instead, so that the scheduler
+  places replacement allocations, potentially on other nodes.
+
+  If the restart is interrupted, the command prints the -resume option which
+  continues the restart, skipping the allocations which were already
+  restarted.
+
+  When ACLs are enabled, this command requires a token with the
+  'alloc-lifecycle', 'read-job', and 'list-jobs' capabilities for the job's
+  namespace. The -reschedule option additionally requires the 'submit-job'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault) + `
+
+Restart Options:
+
+  -all-tasks
+    Restart all the tasks of each allocation together, using a single
+    allocation restart, instead of restarting each running task. Cannot be
+    used with -task or -reschedule.
+
+  -batch-size=<n|n%>
+    Number of allocations to restart at the same time. It can be an integer
+    number of allocations, or a percentage of the allocations to restart such
+    as "25%". Defaults to 1.
+
+  -batch-wait=<duration|ask>
+    Time to wait between batches, such as "30s". If set to "ask", the command
+    asks for confirmation before restarting the next batch. Defaults to 0.
+
+  -group=<name>
+    Only restart the allocations of the given task group. Can be specified
+    multiple times.
+
+  -on-error=<ask|fail>
+    Whether to ask for confirmation to continue, or to stop the restart when
+    the allocations of a batch fail to restart or to become healthy. Defaults
+    to "ask".
+
+  -reschedule
+    Stop the allocations so that the scheduler places replacement allocations,
+    instead of restarting their tasks in place. Cannot be used with -task or
+    -all-tasks, or with system jobs.
+
+  -resume=<time>
+    Resume an interrupted restart started at the given time, which is printed
+    when the restart is interrupted. Allocations created or restarted after
+    this time are skipped.
+
+  -task=<name>
+    Only restart the given task within each allocation. Can be specified
+    multiple times. Cannot be used with -all-tasks or -reschedule.
+
+  -yes
+    Automatic yes to prompts.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *JobRestartCommand) Synopsis() string {
+	return "Restart the allocations of a job in batches"
+}
+
+func (c *JobRestartCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-all-tasks":  complete.PredictNothing,
+			"-batch-size": complete.PredictAnything,
+			"-batch-wait": complete.PredictAnything,
+			"-group":      complete.PredictAnything,
+			"-on-error":   complete.PredictSet(jobRestartOnErrorAsk, jobRestartOnErrorFail),
+			"-reschedule": complete.PredictNothing,
+			"-resume":     complete.PredictAnything,
+			"-task":       complete.PredictAnything,
+			"-yes":        complete.PredictNothing,
+			"-verbose":    complete.PredictNothing,
+		})
+}
+
+func (c *JobRestartCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := c.Meta.Client()
+		if err != nil {
+			return nil
+		}
+
+		resp, _, err := client.Search().PrefixSearch(a.Last, contexts.Jobs, nil)
+		if err != nil {
+			return []string{}
+		}
+		return resp.Matches[contexts.Jobs]
+	})
+}
+
+func (c *JobRestartCommand) Name() string { return "job restart" }
+
+func (c *JobRestartCommand) Run(args []string) int {
+	jobID, code := c.parseAndValidate(args)
+	if code != 0 {
+		return code
+	}
+
+	c.ui = &cli.PrefixedUi{
+		InfoPrefix:   "==> ",
+		OutputPrefix: "    ",
+		ErrorPrefix:  "==> ",
+		Ui:           c.Ui,
+	}
+
+	// Get the HTTP client
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+	c.client = client
+
+	job, code := c.lookupJob(jobID)
+	if code != 0 {
+		return code
+	}
+	if err := c.validateJob(job); err != nil {
+		c.Ui.Error(err.Error())
+		return 1
+	}
+
+	allocs, err := c.restartableAllocs(job)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving allocations: %s", err))
+		return 1
+	}
+	if len(allocs) == 0 {
+		c.Ui.Output("No allocations to restart")
+		return 0
+	}
+
+	// A resumed restart keeps the time of the original restart, so that the
+	// allocations restarted before the interruption are still skipped if it
+	// is interrupted again.
+	if c.since.IsZero() {
+		c.since = time.Now().UTC()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	go func() {
+		select {
+		case <-signalCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	batches := c.batches(allocs)
+	c.ui.Info(fmt.Sprintf("%s: Restarting %d allocations of job %q in %d batches",
+		formatTime(time.Now()), len(allocs), *job.ID, len(batches)))
+
+	for i, batch := range batches {
+		if i > 0 {
+			proceed, err := c.waitBetweenBatches(ctx)
+			if err != nil {
+				c.ui.Error(fmt.Sprintf("%s: %s", formatTime(time.Now()), err))
+				c.outputResume()
+				return 1
+			}
+			if !proceed {
+				c.ui.Info(fmt.Sprintf("%s: Job restart cancelled", formatTime(time.Now())))
+				c.outputResume()
+				return 0
+			}
+		}
+
+		c.ui.Output(fmt.Sprintf("%s: Restarting batch %d/%d with %d allocations",
+			formatTime(time.Now()), i+1, len(batches), len(batch)))
+
+		err := c.restartBatch(ctx, batch)
+		if ctx.Err() != nil {
+			c.ui.Error(fmt.Sprintf("%s: Job restart interrupted", formatTime(time.Now())))
+			c.outputResume()
+			return 1
+		}
+		if err != nil {
+			c.ui.Error(fmt.Sprintf("%s: Batch %d/%d failed to restart: %s",
+				formatTime(time.Now()), i+1, len(batches), err))
+
+			if i == len(batches)-1 || !c.continueOnError() {
+				c.outputResume()
+				return 1
+			}
+		}
+	}
+
+	c.ui.Info(fmt.Sprintf("%s: Job restart finished", formatTime(time.Now())))
+	return 0
+}
+
+// parseAndValidate parses the flags of the command and returns the job ID
+// argument, along with a non-zero exit code if the flags are invalid.
+func (c *JobRestartCommand) parseAndValidate(args []string) (string, int) {
+	var batchSize, batchWait, resume string
+	var verbose bool
+
+	c.groups = make(map[string]struct{})
+	c.tasks = make(map[string]struct{})
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&c.allTasks, "all-tasks", false, "")
+	flags.StringVar(&batchSize, "batch-size", "1", "")
+	flags.StringVar(&batchWait, "batch-wait", "0s", "")
+	flags.StringVar(&c.onError, "on-error", jobRestartOnErrorAsk, "")
+	flags.BoolVar(&c.reschedule, "reschedule", false, "")
+	flags.StringVar(&resume, "resume", "", "")
+	flags.BoolVar(&c.autoYes, "yes", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.Var((funcVar)(func(s string) error {
+		c.groups[s] = struct{}{}
+		return nil
+	}), "group", "")
+	flags.Var((funcVar)(func(s string) error {
+		c.tasks[s] = struct{}{}
+		return nil
+	}), "task", "")
+
+	if err := flags.Parse(args); err != nil {
+		return "", 1
+	}
+
+	// Check that we got exactly one job
+	args = flags.Args()
+	if len(args) != 1 {
+		c.Ui.Error("This command takes one argument: <job>")
+		c.Ui.Error(commandErrorText(c))
+		return "", 1
+	}
+
+	// Truncate the id unless full length is requested
+	c.length = shortId
+	if verbose {
+		c.length = fullId
+	}
+
+	var err error
+	if c.batchSize, c.batchSizePercent, err = parseJobRestartBatchSize(batchSize); err != nil {
+		c.Ui.Error(err.Error())
+		return "", 1
+	}
+
+	if batchWait == jobRestartBatchWaitAsk {
+		c.batchWaitAsk = true
+	} else if c.batchWait, err = time.ParseDuration(batchWait); err != nil || c.batchWait < 0 {
+		c.Ui.Error(fmt.Sprintf(
+			"Invalid -batch-wait value %q: must be a positive duration or %q", batchWait, jobRestartBatchWaitAsk))
+		return "", 1
+	}
+
+	switch c.onError {
+	case jobRestartOnErrorAsk, jobRestartOnErrorFail:
+	default:
+		c.Ui.Error(fmt.Sprintf("Invalid -on-error value %q: must be %q or %q",
+			c.onError, jobRestartOnErrorAsk, jobRestartOnErrorFail))
+		return "", 1
+	}
+
+	if resume != "" {
+		if c.since, err = time.Parse(time.RFC3339Nano, resume); err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid -resume value %q: must be a RFC3339 time", resume))
+			return "", 1
+		}
+	}
+
+	switch {
+	case c.allTasks && len(c.tasks) > 0:
+		c.Ui.Error("The -all-tasks option cannot be used with -task")
+		return "", 1
+	case c.reschedule && c.allTasks:
+		c.Ui.Error("The -reschedule option cannot be used with -all-tasks")
+		return "", 1
+	case c.reschedule && len(c.tasks) > 0:
+		c.Ui.Error("The -reschedule option cannot be used with -task")
+		return "", 1
+	}
+
+	return strings.TrimSpace(args[0]), 0
+}
+
+// parseJobRestartBatchSize parses the -batch-size flag, which is either a
+// number of allocations or a percentage of the allocations.
+func parseJobRestartBatchSize(input string) (int, bool, error) {
+	percent := strings.HasSuffix(input, "%")
+	n, err := strconv.Atoi(strings.TrimSuffix(input, "%"))
+	if err != nil || n < 1 || (percent && n > 100) {
+		return 0, false, fmt.Errorf(
+			"Invalid -batch-size value %q: must be a positive number or a percentage", input)
+	}
+	return n, percent, nil
+}
+
+// lookupJob finds the job matching the job ID prefix, and returns a non-zero
+// exit code if there is no single match.
+func (c *JobRestartCommand) lookupJob(jobID string) (*api.Job, int) {
+	jobs, _, err := c.client.Jobs().PrefixList(jobID)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving job: %s", err))
+		return nil, 1
+	}
+	if len(jobs) == 0 {
+		c.Ui.Error(fmt.Sprintf("No job(s) with prefix or id %q found", jobID))
+		return nil, 1
+	}
+	if len(jobs) > 1 {
+		if (jobID != jobs[0].ID) || (c.allNamespaces() && jobs[0].ID == jobs[1].ID) {
+			c.Ui.Error(fmt.Sprintf("Prefix matched multiple jobs\n\n%s", createStatusListOutput(jobs, c.allNamespaces())))
+			return nil, 1
+		}
+	}
+
+	// Prefix lookup matched a single job
+	q := &api.QueryOptions{Namespace: jobs[0].JobSummary.Namespace}
+	job, _, err := c.client.Jobs().Info(jobs[0].ID, q)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error retrieving job: %s", err))
+		return nil, 1
+	}
+	return job, 0
+}
+
+// validateJob checks the groups and tasks to restart exist within the job.
+func (c *JobRestartCommand) validateJob(job *api.Job) error {
+	if c.reschedule && (*job.Type == api.JobTypeSystem || *job.Type == "sysbatch") {
+		return fmt.Errorf("The -reschedule option cannot be used with %s jobs", *job.Type)
+	}
+
+	groups := make(map[string]*api.TaskGroup, len(job.TaskGroups))
+	for _, tg := range job.TaskGroups {
+		groups[*tg.Name] = tg
+	}
+	for name := range c.groups {
+		if _, ok := groups[name]; !ok {
+			return fmt.Errorf("No task group with name %q found in job %q", name, *job.ID)
+		}
+	}
+
+TASKS:
+	for name := range c.tasks {
+		for groupName, tg := range groups {
+			if _, ok := c.groups[groupName]; len(c.groups) > 0 && !ok {
+				continue
+			}
+			for _, task := range tg.Tasks {
+				if task.Name == name {
+					continue TASKS
+				}
+			}
+		}
+		return fmt.Errorf("No task with name %q found in the task groups to restart", name)
+	}
+	return nil
+}
+
+// restartableAllocs returns the running allocations of the job which should
+// be restarted, ordered by their creation.
+func (c *JobRestartCommand) restartableAllocs(job *api.Job) ([]*api.AllocationListStub, error) {
+	q := &api.QueryOptions{Namespace: *job.Namespace}
+	stubs, _, err := c.client.Jobs().Allocations(*job.ID, false, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var allocs []*api.AllocationListStub
+	for _, stub := range stubs {
+		if stub.DesiredStatus != api.AllocDesiredStatusRun ||
+			stub.ClientStatus != api.AllocClientStatusRunning {
+			continue
+		}
+		if _, ok := c.groups[stub.TaskGroup]; len(c.groups) > 0 && !ok {
+			continue
+		}
+
+		// Skip allocations which were created or restarted after the restart
+		// being resumed started.
+		if !c.since.IsZero() && stub.CreateTime >= c.since.UnixNano() {
+			continue
+		}
+
+		if !c.reschedule {
+			tasks := c.tasksToRestart(stub.TaskStates)
+			if len(tasks) == 0 || (!c.since.IsZero() && tasksRestartedSince(stub.TaskStates, tasks, c.since)) {
+				continue
+			}
+		}
+
+		allocs = append(allocs, stub)
+	}
+
+	sort.Slice(allocs, func(i, j int) bool {
+		return allocs[i].CreateIndex < allocs[j].CreateIndex
+	})
+	return allocs, nil
+}
+
+// tasksToRestart returns the names of the tasks to restart in place. These
+// are the tasks selected using -task, otherwise the running tasks.
+func (c *JobRestartCommand) tasksToRestart(states map[string]*api.TaskState) []string {
+	var tasks []string
+	for name, state := range states {
+		if len(c.tasks) > 0 {
+			if _, ok := c.tasks[name]; ok {
+				tasks = append(tasks, name)
+			}
+		} else if state.State == taskStateRunning {
+			tasks = append(tasks, name)
+		}
+	}
+	sort.Strings(tasks)
+	return tasks
+}
+
+// tasksRestartedSince returns whether all the tasks were restarted after the
+// given time.
+func tasksRestartedSince(states map[string]*api.TaskState, tasks []string, since time.Time) bool {
+	for _, name := range tasks {
+		state, ok := states[name]
+		if !ok || state.LastRestart.Before(since) {
+			return false
+		}
+	}
+	return true
+}
+
+// batches splits the allocations into the batches to restart.
+func (c *JobRestartCommand) batches(allocs []*api.AllocationListStub) [][]*api.AllocationListStub {
+	size := c.batchSize
+	if c.batchSizePercent {
+		size = int(math.Ceil(float64(len(allocs)*c.batchSize) / 100))
+	}
+	if size < 1 {
+		size = 1
+	}
+
+	var batches [][]*api.AllocationListStub
+	for len(allocs) > size {
+		batches = append(batches, allocs[:size])
+		allocs = allocs[size:]
+	}
+	return append(batches, allocs)
+}
+
+// waitBetweenBatches waits for the -batch-wait duration, or asks the user
+// whether to proceed. It returns whether the restart should proceed.
+func (c *JobRestartCommand) waitBetweenBatches(ctx context.Context) (bool, error) {
+	if c.batchWaitAsk {
+		if c.autoYes {
+			return true, nil
+		}
+		return c.confirm("Proceed with the next batch? [y/N]")
+	}
+
+	if c.batchWait == 0 {
+		return true, nil
+	}
+
+	c.ui.Output(fmt.Sprintf("%s: Waiting %s before restarting the next batch",
+		formatTime(time.Now()), c.batchWait))
+
+	timer := time.NewTimer(c.batchWait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return false, fmt.Errorf("Job restart interrupted")
+	}
+}
+
+// continueOnError returns whether the restart should continue after a batch
+// failed.
+func (c *JobRestartCommand) continueOnError() bool {
+	if c.onError == jobRestartOnErrorFail {
+		return false
+	}
+	if c.autoYes {
+		return true
+	}
+
+	proceed, err := c.confirm("Continue restarting the remaining batches? [y/N]")
+	if err != nil {
+		c.ui.Error(err.Error())
+		return false
+	}
+	return proceed
+}
+
+// confirm asks the user the question, and returns whether they confirmed.
+func (c *JobRestartCommand) confirm(question string) (bool, error) {
+	answer, err := c.ui.Ask(question)
+	if err != nil {
+		return false, fmt.Errorf("Failed to parse answer: %v", err)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// outputResume outputs how to resume the restart.
+func (c *JobRestartCommand) outputResume() {
+	c.ui.Info(fmt.Sprintf("To resume the restart, run the command again with -resume=%s",
+		c.since.Format(time.RFC3339Nano)))
+}
+
+// restartBatch restarts the allocations of the batch at the same time, and
+// waits for them to be running and healthy again.
+func (c *JobRestartCommand) restartBatch(ctx context.Context, batch []*api.AllocationListStub) error {
+	var mErr *multierror.Error
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, stub := range batch {
+		wg.Add(1)
+		go func(stub *api.AllocationListStub) {
+			defer wg.Done()
+
+			var err error
+			if c.reschedule {
+				err = c.rescheduleAlloc(ctx, stub)
+			} else {
+				err = c.restartAllocInPlace(ctx, stub)
+			}
+			if err != nil {
+				lock.Lock()
+				mErr = multierror.Append(mErr, fmt.Errorf("allocation %q: %v", limit(stub.ID, c.length), err))
+				lock.Unlock()
+			}
+		}(stub)
+	}
+
+	wg.Wait()
+	return mErr.ErrorOrNil()
+}
+
+// restartAllocInPlace restarts the tasks of the allocation, and waits for
+// them to be running and healthy again.
+func (c *JobRestartCommand) restartAllocInPlace(ctx context.Context, stub *api.AllocationListStub) error {
+	q := (&api.QueryOptions{Namespace: stub.Namespace}).WithContext(ctx)
+
+	alloc, _, err := c.client.Allocations().Info(stub.ID, q)
+	if err != nil {
+		return err
+	}
+
+	// The restart counts of the tasks detect when they have been restarted.
+	restartTime := time.Now()
+	tasks := c.tasksToRestart(alloc.TaskStates)
+	restarts := make(map[string]uint64, len(tasks))
+	for _, name := range tasks {
+		restarts[name] = alloc.TaskStates[name].Restarts
+	}
+
+	if c.allTasks {
+		c.ui.Output(fmt.Sprintf("%s: Restarting all tasks of allocation %q",
+			formatTime(time.Now()), limit(alloc.ID, c.length)))
+		if err := c.client.Allocations().Restart(alloc, "", q); err != nil {
+			return err
+		}
+	} else {
+		c.ui.Output(fmt.Sprintf("%s: Restarting tasks %s of allocation %q",
+			formatTime(time.Now()), strings.Join(tasks, ", "), limit(alloc.ID, c.length)))
+		for _, name := range tasks {
+			if err := c.client.Allocations().Restart(alloc, name, q); err != nil {
+				return fmt.Errorf("failed to restart task %q: %v", name, err)
+			}
+		}
+	}
+
+	// The restart may never be reported, for example if the client of the
+	// allocation is down, so the tasks must restart within the healthy
+	// deadline of the allocation.
+	deadline := healthyDeadline(alloc)
+	deadlineCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+	waitQ := q.WithContext(deadlineCtx)
+
+	var index uint64
+	for {
+		alloc, meta, err := c.client.Allocations().Info(stub.ID, withWaitIndex(waitQ, index))
+		if err != nil {
+			if ctx.Err() == nil && deadlineCtx.Err() != nil {
+				return fmt.Errorf("tasks did not restart within %s", deadline)
+			}
+			return err
+		}
+		if alloc.ClientTerminalStatus() {
+			return fmt.Errorf("allocation is %s", alloc.ClientStatus)
+		}
+
+		restarted := true
+		for name, count := range restarts {
+			state := alloc.TaskStates[name]
+			if state == nil {
+				restarted = false
+				continue
+			}
+			if state.Failed {
+				return fmt.Errorf("task %q failed", name)
+			}
+			if state.Restarts <= count || state.State != taskStateRunning {
+				restarted = false
+			}
+		}
+		if restarted {
+			// The deployment health of the allocation is not reset when its
+			// tasks restart, so only its checks tell whether it is healthy.
+			if err := c.waitForChecks(ctx, q, alloc, restartTime); err != nil {
+				return err
+			}
+			c.ui.Output(fmt.Sprintf("%s: Allocation %q restarted successfully",
+				formatTime(time.Now()), limit(alloc.ID, c.length)))
+			return nil
+		}
+		index = meta.LastIndex
+	}
+}
+
+// rescheduleAlloc stops the allocation, and waits for its replacement to be
+// running and healthy.
+func (c *JobRestartCommand) rescheduleAlloc(ctx context.Context, stub *api.AllocationListStub) error {
+	q := (&api.QueryOptions{Namespace: stub.Namespace}).WithContext(ctx)
+
+	alloc, _, err := c.client.Allocations().Info(stub.ID, q)
+	if err != nil {
+		return err
+	}
+
+	c.ui.Output(fmt.Sprintf("%s: Stopping allocation %q",
+		formatTime(time.Now()), limit(alloc.ID, c.length)))
+	if _, err := c.client.Allocations().Stop(alloc, q); err != nil {
+		return err
+	}
+
+	// Wait for the scheduler to place the replacement allocation.
+	var index uint64
+	for alloc.NextAllocation == "" {
+		var meta *api.QueryMeta
+		alloc, meta, err = c.client.Allocations().Info(stub.ID, withWaitIndex(q, index))
+		if err != nil {
+			return err
+		}
+		index = meta.LastIndex
+	}
+
+	replacementID := alloc.NextAllocation
+	c.ui.Output(fmt.Sprintf("%s: Allocation %q replaced by allocation %q",
+		formatTime(time.Now()), limit(alloc.ID, c.length), limit(replacementID, c.length)))
+
+	index = 0
+	for {
+		replacement, meta, err := c.client.Allocations().Info(replacementID, withWaitIndex(q, index))
+		if err != nil {
+			return err
+		}
+		if replacement.ClientTerminalStatus() {
+			return fmt.Errorf("replacement allocation %q is %s",
+				limit(replacementID, c.length), replacement.ClientStatus)
+		}
+
+		if status := replacement.DeploymentStatus; status != nil && status.Healthy != nil && !*status.Healthy {
+			return fmt.Errorf("replacement allocation %q is unhealthy", limit(replacementID, c.length))
+		}
+		if replacement.ClientStatus == api.AllocClientStatusRunning {
+			if err := c.waitForHealthy(ctx, q, replacement); err != nil {
+				return fmt.Errorf("replacement allocation %q: %v", limit(replacementID, c.length), err)
+			}
+			c.ui.Output(fmt.Sprintf("%s: Replacement allocation %q is running and healthy",
+				formatTime(time.Now()), limit(replacementID, c.length)))
+			return nil
+		}
+		index = meta.LastIndex
+	}
+}
+
+// waitForHealthy waits for a newly placed allocation to be healthy. If the
+// allocation is part of a deployment, the client sets its deployment health;
+// otherwise it is healthy once the checks of its Nomad services pass.
+func (c *JobRestartCommand) waitForHealthy(ctx context.Context, q *api.QueryOptions, alloc *api.Allocation) error {
+	if alloc.DeploymentID == "" {
+		return c.waitForChecks(ctx, q, alloc, time.Unix(0, alloc.CreateTime))
+	}
+
+	deadline := healthyDeadline(alloc)
+	deadlineCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+	q = q.WithContext(deadlineCtx)
+
+	var index uint64
+	for {
+		if status := alloc.DeploymentStatus; status != nil && status.Healthy != nil {
+			if !*status.Healthy {
+				return fmt.Errorf("allocation is unhealthy")
+			}
+			return nil
+		}
+		if alloc.ClientTerminalStatus() {
+			return fmt.Errorf("allocation is %s", alloc.ClientStatus)
+		}
+
+		var meta *api.QueryMeta
+		var err error
+		alloc, meta, err = c.client.Allocations().Info(alloc.ID, withWaitIndex(q, index))
+		if err != nil {
+			if ctx.Err() == nil && deadlineCtx.Err() != nil {
+				return fmt.Errorf("allocation did not become healthy within %s", deadline)
+			}
+			return err
+		}
+		index = meta.LastIndex
+	}
+}
+
+// waitForChecks waits for all the checks of the Nomad services of the
+// allocation to pass after the given time. Allocations without checks are
+// healthy once they are running.
+func (c *JobRestartCommand) waitForChecks(ctx context.Context, q *api.QueryOptions, alloc *api.Allocation, since time.Time) error {
+	deadline := healthyDeadline(alloc)
+	timer := time.NewTimer(deadline)
+	defer timer.Stop()
+
+	for {
+		checks, err := c.client.Allocations().Checks(alloc.ID, q)
+		if err != nil {
+			return fmt.Errorf("failed to get checks: %v", err)
+		}
+
+		healthy := true
+		for _, check := range checks {
+			if check.Timestamp <= since.Unix() || check.Status != checkStatusSuccess {
+				healthy = false
+				break
+			}
+		}
+		if healthy {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return fmt.Errorf("allocation did not become healthy within %s", deadline)
+		case <-time.After(jobRestartCheckPollInterval):
+		}
+	}
+}
+
+// healthyDeadline returns the time allowed for the allocation to become
+// healthy, which is the healthy deadline of its task group.
+func healthyDeadline(alloc *api.Allocation) time.Duration {
+	if alloc.Job != nil {
+		if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil &&
+			tg.Update != nil && tg.Update.HealthyDeadline != nil {
+			return *tg.Update.HealthyDeadline
+		}
+		if alloc.Job.Update != nil && alloc.Job.Update.HealthyDeadline != nil {
+			return *alloc.Job.Update.HealthyDeadline
+		}
+	}
+	return defaultHealthyDeadline
+}
+
+// withWaitIndex returns a copy of the query options which blocks until the
+// given index.
+func withWaitIndex(q *api.QueryOptions, index uint64) *api.QueryOptions {
+	opts := q.WithContext(q.Context())
+	opts.WaitIndex = index
+	return opts
+}
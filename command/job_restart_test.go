@@ -0,0 +1,318 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRestartCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &JobRestartCommand{}
+}
+
+func TestJobRestartCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	cases := []struct {
+		args   []string
+		expErr string
+	}{
+		{[]string{"some", "bad", "args"}, commandErrorText(cmd)},
+		{[]string{"-batch-size=0", "job"}, "Invalid -batch-size value"},
+		{[]string{"-batch-size=150%", "job"}, "Invalid -batch-size value"},
+		{[]string{"-batch-wait=soon", "job"}, "Invalid -batch-wait value"},
+		{[]string{"-on-error=ignore", "job"}, "Invalid -on-error value"},
+		{[]string{"-resume=yesterday", "job"}, "Invalid -resume value"},
+		{[]string{"-all-tasks", "-task=web", "job"}, "cannot be used with -task"},
+		{[]string{"-reschedule", "-all-tasks", "job"}, "cannot be used with -all-tasks"},
+		{[]string{"-reschedule", "-task=web", "job"}, "cannot be used with -task"},
+		{[]string{"-address=nope", "job"}, "Error retrieving job"},
+	}
+	for _, tc := range cases {
+		require.Equal(t, 1, cmd.Run(tc.args), "args: %v", tc.args)
+		require.Contains(t, ui.ErrorWriter.String(), tc.expErr)
+		ui.ErrorWriter.Reset()
+	}
+}
+
+func TestJobRestartCommand_ParseBatchSize(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		input      string
+		expSize    int
+		expPercent bool
+		expErr     bool
+	}{
+		{input: "1", expSize: 1},
+		{input: "10", expSize: 10},
+		{input: "25%", expSize: 25, expPercent: true},
+		{input: "100%", expSize: 100, expPercent: true},
+		{input: "0", expErr: true},
+		{input: "-1", expErr: true},
+		{input: "0%", expErr: true},
+		{input: "101%", expErr: true},
+		{input: "half", expErr: true},
+	}
+	for _, tc := range cases {
+		size, percent, err := parseJobRestartBatchSize(tc.input)
+		if tc.expErr {
+			require.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.expSize, size, tc.input)
+		require.Equal(t, tc.expPercent, percent, tc.input)
+	}
+}
+
+func TestJobRestartCommand_Batches(t *testing.T) {
+	ci.Parallel(t)
+
+	allocs := make([]*api.AllocationListStub, 5)
+	for i := range allocs {
+		allocs[i] = &api.AllocationListStub{ID: fmt.Sprintf("alloc-%d", i)}
+	}
+
+	cases := []struct {
+		size     int
+		percent  bool
+		expSizes []int
+	}{
+		{size: 1, expSizes: []int{1, 1, 1, 1, 1}},
+		{size: 2, expSizes: []int{2, 2, 1}},
+		{size: 10, expSizes: []int{5}},
+		{size: 50, percent: true, expSizes: []int{3, 2}},
+		{size: 1, percent: true, expSizes: []int{1, 1, 1, 1, 1}},
+		{size: 100, percent: true, expSizes: []int{5}},
+	}
+	for _, tc := range cases {
+		cmd := &JobRestartCommand{batchSize: tc.size, batchSizePercent: tc.percent}
+		var sizes []int
+		for _, batch := range cmd.batches(allocs) {
+			sizes = append(sizes, len(batch))
+		}
+		require.Equal(t, tc.expSizes, sizes, "size: %d, percent: %v", tc.size, tc.percent)
+	}
+}
+
+// testJobRestartServiceJob returns a service job whose tasks keep running, so
+// that its allocations can be restarted.
+func testJobRestartServiceJob(jobID string, count int) *api.Job {
+	job := testJob(jobID)
+	job.Type = helper.StringToPtr(api.JobTypeService)
+	job.TaskGroups[0].Count = helper.IntToPtr(count)
+	job.TaskGroups[0].Tasks[0].Config["run_for"] = "10m"
+	return job
+}
+
+// waitForJobRestartAllocs waits for the given number of allocations of the
+// job to be running, and returns them.
+func waitForJobRestartAllocs(t *testing.T, client *api.Client, jobID string, count int) []*api.AllocationListStub {
+	var running []*api.AllocationListStub
+	testutil.WaitForResult(func() (bool, error) {
+		allocs, _, err := client.Jobs().Allocations(jobID, false, nil)
+		if err != nil {
+			return false, err
+		}
+		running = nil
+		for _, alloc := range allocs {
+			if alloc.DesiredStatus == api.AllocDesiredStatusRun &&
+				alloc.ClientStatus == api.AllocClientStatusRunning {
+				running = append(running, alloc)
+			}
+		}
+		if len(running) != count {
+			return false, fmt.Errorf("expected %d running allocs, got %d", count, len(running))
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+	return running
+}
+
+func testJobRestartWaitForNode(t *testing.T, client *api.Client) {
+	testutil.WaitForResult(func() (bool, error) {
+		nodes, _, err := client.Nodes().List(nil)
+		if err != nil {
+			return false, err
+		}
+		for _, node := range nodes {
+			if _, ok := node.Drivers["mock_driver"]; ok &&
+				node.Status == structs.NodeStatusReady {
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("no ready nodes")
+	}, func(err error) {
+		t.Fatalf("err: %v", err)
+	})
+}
+
+func TestJobRestartCommand_HealthyDeadline(t *testing.T) {
+	ci.Parallel(t)
+
+	minute := time.Minute
+	hour := time.Hour
+	job := &api.Job{
+		TaskGroups: []*api.TaskGroup{
+			{Name: helper.StringToPtr("web"), Update: &api.UpdateStrategy{HealthyDeadline: &minute}},
+			{Name: helper.StringToPtr("db")},
+		},
+	}
+
+	// The deadline of the group is used, falling back to the job's and then
+	// to the default.
+	require.Equal(t, minute, healthyDeadline(&api.Allocation{Job: job, TaskGroup: "web"}))
+	require.Equal(t, defaultHealthyDeadline, healthyDeadline(&api.Allocation{Job: job, TaskGroup: "db"}))
+
+	job.Update = &api.UpdateStrategy{HealthyDeadline: &hour}
+	require.Equal(t, hour, healthyDeadline(&api.Allocation{Job: job, TaskGroup: "db"}))
+}
+
+func TestJobRestartCommand_RestartTimeout(t *testing.T) {
+	ci.Parallel(t)
+
+	// The allocation's client never reports the restart of its task
+	deadline := 200 * time.Millisecond
+	alloc := &api.Allocation{
+		ID:           "8a3e5c2d-0000-0000-0000-000000000000",
+		Namespace:    api.DefaultNamespace,
+		TaskGroup:    "web",
+		ClientStatus: api.AllocClientStatusRunning,
+		Job:          &api.Job{Update: &api.UpdateStrategy{HealthyDeadline: &deadline}},
+		TaskStates: map[string]*api.TaskState{
+			"task1": {State: taskStateRunning},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/restart"):
+			w.Write([]byte("{}"))
+		case r.URL.Path == "/v1/allocation/"+alloc.ID:
+			if r.URL.Query().Get("index") != "" {
+				<-r.Context().Done()
+				return
+			}
+			w.Header().Set("X-Nomad-Index", "1")
+			json.NewEncoder(w).Encode(alloc)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := api.NewClient(&api.Config{Address: srv.URL})
+	require.NoError(t, err)
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}, client: client, ui: ui, length: shortId}
+
+	// The failure is reported for the batch, which is handled by -on-error
+	stub := &api.AllocationListStub{ID: alloc.ID, Namespace: alloc.Namespace}
+	err = cmd.restartBatch(context.Background(), []*api.AllocationListStub{stub})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "tasks did not restart within 200ms")
+}
+
+func TestJobRestartCommand_Run_InPlace(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	testJobRestartWaitForNode(t, client)
+
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	jobID := "restart_in_place"
+	resp, _, err := client.Jobs().Register(testJobRestartServiceJob(jobID, 3), nil)
+	require.NoError(t, err)
+	if code := waitForSuccess(ui, client, fullId, t, resp.EvalID); code != 0 {
+		t.Fatalf("status code non zero saw %d", code)
+	}
+	before := waitForJobRestartAllocs(t, client, jobID, 3)
+
+	// Fails on unknown groups and tasks
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "-group=nope", jobID}))
+	require.Contains(t, ui.ErrorWriter.String(), `No task group with name "nope"`)
+	ui.ErrorWriter.Reset()
+
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "-task=nope", jobID}))
+	require.Contains(t, ui.ErrorWriter.String(), `No task with name "nope"`)
+	ui.ErrorWriter.Reset()
+
+	code := cmd.Run([]string{"-address=" + url, "-batch-size=2", "-on-error=fail", jobID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "Restarting 3 allocations")
+	require.Contains(t, out, "Restarting batch 2/2 with 1 allocations")
+	require.Contains(t, out, "Job restart finished")
+
+	// The same allocations are running, with their task restarted once.
+	after := waitForJobRestartAllocs(t, client, jobID, 3)
+	require.ElementsMatch(t, allocIDs(before), allocIDs(after))
+	for _, stub := range after {
+		require.Equal(t, uint64(1), stub.TaskStates["task1"].Restarts)
+	}
+
+	// Resuming the restart skips the restarted allocations.
+	ui.OutputWriter.Reset()
+	since := time.Now().Add(-time.Minute).Format(time.RFC3339Nano)
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-resume=" + since, jobID}))
+	require.Contains(t, ui.OutputWriter.String(), "No allocations to restart")
+}
+
+func TestJobRestartCommand_Run_Reschedule(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+	testJobRestartWaitForNode(t, client)
+
+	ui := cli.NewMockUi()
+	cmd := &JobRestartCommand{Meta: Meta{Ui: ui}}
+
+	jobID := "restart_reschedule"
+	resp, _, err := client.Jobs().Register(testJobRestartServiceJob(jobID, 2), nil)
+	require.NoError(t, err)
+	if code := waitForSuccess(ui, client, fullId, t, resp.EvalID); code != 0 {
+		t.Fatalf("status code non zero saw %d", code)
+	}
+	before := waitForJobRestartAllocs(t, client, jobID, 2)
+
+	code := cmd.Run([]string{"-address=" + url, "-reschedule", "-on-error=fail", jobID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Job restart finished")
+
+	// The allocations were replaced.
+	after := waitForJobRestartAllocs(t, client, jobID, 2)
+	for _, id := range allocIDs(after) {
+		require.NotContains(t, allocIDs(before), id)
+	}
+}
+
+func allocIDs(allocs []*api.AllocationListStub) []string {
+	ids := make([]string, 0, len(allocs))
+	for _, alloc := range allocs {
+		ids = append(ids, alloc.ID)
+	}
+	return ids
+}
@@ -175,6 +175,11 @@ func (c *JobStatusCommand) Run(args []string) int {
 	periodic := job.IsPeriodic()
 	parameterized := job.IsParameterized()
 
+	nodePool := ""
+	if job.NodePool != nil {
+		nodePool = *job.NodePool
+	}
+
 	// Format the job info
 	basic := []string{
 		fmt.Sprintf("ID|%s", *job.ID),
@@ -183,6 +188,7 @@ func (c *JobStatusCommand) Run(args []string) int {
 		fmt.Sprintf("Type|%s", *job.Type),
 		fmt.Sprintf("Priority|%d", *job.Priority),
 		fmt.Sprintf("Datacenters|%s", strings.Join(job.Datacenters, ",")),
+		fmt.Sprintf("Node Pool|%s", nodePool),
 		fmt.Sprintf("Namespace|%s", *job.Namespace),
 		fmt.Sprintf("Status|%s", getStatusString(*job.Status, job.Stop)),
 		fmt.Sprintf("Periodic|%v", periodic),
@@ -391,6 +397,12 @@ func (c *JobStatusCommand) outputJobInfo(client *api.Client, job *api.Job) error
 		c.Ui.Output(formatList(evals))
 	}
 
+	if blockedEval {
+		if err := c.outputQueueStatus(client, job); err != nil {
+			return err
+		}
+	}
+
 	if blockedEval && latestFailedPlacement != nil {
 		c.outputFailedPlacements(latestFailedPlacement)
 	}
@@ -641,6 +653,45 @@ func (c *JobStatusCommand) outputReschedulingEvals(client *api.Client, job *api.
 	return nil
 }
 
+// outputQueueStatus prints the position of the job in the job queue, if it is
+// waiting for capacity to place its allocations.
+func (c *JobStatusCommand) outputQueueStatus(client *api.Client, job *api.Job) error {
+	q := &api.QueryOptions{Namespace: *job.Namespace}
+	status, _, err := client.Jobs().QueueStatus(*job.ID, q)
+	if err != nil {
+		return fmt.Errorf("Error querying job queue status: %s", err)
+	}
+	if !status.Queued {
+		return nil
+	}
+
+	queued := make([]string, 0, len(status.QueuedAllocations))
+	for _, tg := range sortedTaskGroupsFromCounts(status.QueuedAllocations) {
+		queued = append(queued, fmt.Sprintf("%s (%d)", tg, status.QueuedAllocations[tg]))
+	}
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Queue[reset]"))
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Position|%d of %d", status.Position, status.QueueLength),
+		fmt.Sprintf("Waiting Reason|%s", status.Reason),
+		fmt.Sprintf("Queued Since|%s", formatTime(time.Unix(0, status.QueuedTime))),
+		fmt.Sprintf("Queued Allocations|%s", strings.Join(queued, ", ")),
+		fmt.Sprintf("Blocked Evaluation|%s", limit(status.EvalID, c.length)),
+	}))
+	return nil
+}
+
+// sortedTaskGroupsFromCounts returns the task group names of the counts in
+// sorted order.
+func sortedTaskGroupsFromCounts(counts map[string]int) []string {
+	tgs := make([]string, 0, len(counts))
+	for tg := range counts {
+		tgs = append(tgs, tg)
+	}
+	sort.Strings(tgs)
+	return tgs
+}
+
 func (c *JobStatusCommand) outputFailedPlacements(failedEval *api.Evaluation) {
 	if failedEval == nil || len(failedEval.FailedTGAllocs) == 0 {
 		return
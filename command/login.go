@@ -0,0 +1,302 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/posener/complete"
+	"github.com/skratchdot/open-golang/open"
+)
+
+const (
+	// defaultOIDCCallbackAddr is the default address of the local listener
+	// the OIDC provider redirects to once the user has authenticated.
+	defaultOIDCCallbackAddr = "localhost:4649"
+
+	// oidcCallbackPath is the path of the local listener the OIDC provider
+	// redirects to.
+	oidcCallbackPath = "/oidc/callback"
+
+	// oidcLoginTimeout is how long the command waits for the user to
+	// authenticate with the OIDC provider.
+	oidcLoginTimeout = 5 * time.Minute
+)
+
+type LoginCommand struct {
+	Meta
+
+	authMethodName   string
+	oidcCallbackAddr string
+	json             bool
+	tmpl             string
+
+	// openURL opens the OIDC provider auth URL in the browser of the user. It
+	// is overridden by tests.
+	openURL func(string) error
+}
+
+func (l *LoginCommand) Help() string {
+	helpText := `
+Usage: nomad login [options]
+
+  The login command will exchange the provided third party credentials with
+  the requested auth method for a newly minted Nomad ACL token. The token
+  expires after the max token TTL of the auth method.
+
+  For OIDC auth methods, the command opens the browser of the user to log in
+  with the identity provider, and waits for the provider to redirect back to a
+  local callback listener.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsNoNamespace) + `
+
+Login Options:
+
+  -method
+    The name of the ACL auth method to login with. If not set, the default
+    auth method will be used.
+
+  -oidc-callback-addr
+    The address to use for the local OIDC callback server. This should be
+    given in the form of <IP>:<PORT> and defaults to "localhost:4649". The
+    resulting redirect URI must be allowed by the auth method.
+
+  -json
+    Output the ACL token in JSON format.
+
+  -t
+    Format and display the ACL token using a Go template.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (l *LoginCommand) Synopsis() string {
+	return "Login to Nomad using an auth method"
+}
+
+func (l *LoginCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(l.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-method":             complete.PredictAnything,
+			"-oidc-callback-addr": complete.PredictAnything,
+			"-json":               complete.PredictNothing,
+			"-t":                  complete.PredictAnything,
+		})
+}
+
+func (l *LoginCommand) AutocompleteArgs() complete.Predictor { return complete.PredictNothing }
+
+func (l *LoginCommand) Name() string { return "login" }
+
+func (l *LoginCommand) Run(args []string) int {
+
+	flags := l.Meta.FlagSet(l.Name(), FlagSetClient)
+	flags.Usage = func() { l.Ui.Output(l.Help()) }
+	flags.StringVar(&l.authMethodName, "method", "", "")
+	flags.StringVar(&l.oidcCallbackAddr, "oidc-callback-addr", defaultOIDCCallbackAddr, "")
+	flags.BoolVar(&l.json, "json", false, "")
+	flags.StringVar(&l.tmpl, "t", "", "")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments.
+	if len(flags.Args()) != 0 {
+		l.Ui.Error("This command takes no arguments")
+		l.Ui.Error(commandErrorText(l))
+		return 1
+	}
+
+	// Get the HTTP client.
+	client, err := l.Meta.Client()
+	if err != nil {
+		l.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// If the caller did not name an auth method, use the default one.
+	authMethodName := l.authMethodName
+	if authMethodName == "" {
+		authMethodName, err = defaultAuthMethodName(client)
+		if err != nil {
+			l.Ui.Error(fmt.Sprintf("Error looking up default ACL auth method: %s", err))
+			return 1
+		}
+	}
+
+	token, err := l.oidcLogin(client, authMethodName)
+	if err != nil {
+		l.Ui.Error(fmt.Sprintf("Error performing login: %s", err))
+		return 1
+	}
+
+	if l.json || len(l.tmpl) > 0 {
+		out, err := Format(l.json, l.tmpl, token)
+		if err != nil {
+			l.Ui.Error(err.Error())
+			return 1
+		}
+
+		l.Ui.Output(out)
+		return 0
+	}
+
+	l.Ui.Output(fmt.Sprintf("Successfully logged in via %s\n", authMethodName))
+	l.Ui.Output(formatKVACLToken(token))
+	return 0
+}
+
+// defaultAuthMethodName returns the name of the default auth method.
+func defaultAuthMethodName(client *api.Client) (string, error) {
+	methods, _, err := client.ACLAuthMethods().List(nil)
+	if err != nil {
+		return "", err
+	}
+	for _, method := range methods {
+		if method.Default {
+			return method.Name, nil
+		}
+	}
+	return "", errors.New("no default auth method found, please specify one using the -method flag")
+}
+
+// oidcCallback is the result of the OIDC provider redirecting the user to the
+// local callback listener.
+type oidcCallback struct {
+	code  string
+	state string
+	err   error
+}
+
+// oidcLogin performs the OIDC authorization code flow. It starts the local
+// callback listener, opens the auth URL in the browser of the user, and
+// exchanges the authorization code passed to the callback for a Nomad ACL
+// token.
+func (l *LoginCommand) oidcLogin(client *api.Client, authMethodName string) (*api.ACLToken, error) {
+	listener, err := net.Listen("tcp", l.oidcCallbackAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start OIDC callback listener: %v", err)
+	}
+	defer listener.Close()
+
+	redirectURI := (&url.URL{
+		Scheme: "http",
+		Host:   l.oidcCallbackAddr,
+		Path:   oidcCallbackPath,
+	}).String()
+
+	// The nonce is included in the ID token issued by the provider, which
+	// allows the servers to ensure it was issued for this login.
+	nonce := uuid.Generate()
+
+	authURLResp, _, err := client.ACLOIDC().GetAuthURL(&api.ACLOIDCAuthURLRequest{
+		AuthMethodName: authMethodName,
+		RedirectURI:    redirectURI,
+		ClientNonce:    nonce,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The provider passes the state of the auth URL back to the callback,
+	// which ensures the callback belongs to this login.
+	authURL, err := url.Parse(authURLResp.AuthURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse auth URL: %v", err)
+	}
+	expectedState := authURL.Query().Get("state")
+
+	callbackCh := make(chan *oidcCallback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oidcCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		callback := oidcCallbackFromRequest(r)
+		if callback.err != nil {
+			http.Error(w, fmt.Sprintf("Login failed: %v", callback.err), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Login successful, you may close this window and return to the terminal.")
+		}
+
+		select {
+		case callbackCh <- callback:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	openURL := l.openURL
+	if openURL == nil {
+		openURL = open.Start
+	}
+	l.Ui.Output(fmt.Sprintf("Complete the login via your OIDC provider. Launching browser to:\n\n    %s\n", authURLResp.AuthURL))
+	if err := openURL(authURLResp.AuthURL); err != nil {
+		l.Ui.Warn(fmt.Sprintf("Failed to open browser, please visit the URL above: %v", err))
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcLoginTimeout)
+	defer cancel()
+
+	var callback *oidcCallback
+	select {
+	case callback = <-callbackCh:
+	case <-signalCh:
+		return nil, errors.New("login interrupted")
+	case <-ctx.Done():
+		return nil, errors.New("timed out waiting for the OIDC provider callback")
+	}
+
+	if callback.err != nil {
+		return nil, callback.err
+	}
+	if callback.state != expectedState {
+		return nil, errors.New("OIDC callback state does not match the login request")
+	}
+
+	token, _, err := client.ACLOIDC().CompleteAuth(&api.ACLOIDCCompleteAuthRequest{
+		AuthMethodName: authMethodName,
+		ClientNonce:    nonce,
+		State:          callback.state,
+		Code:           callback.code,
+		RedirectURI:    redirectURI,
+	}, nil)
+	return token, err
+}
+
+// oidcCallbackFromRequest parses the parameters the OIDC provider passes to
+// the callback listener.
+func oidcCallbackFromRequest(r *http.Request) *oidcCallback {
+	query := r.URL.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		if desc := query.Get("error_description"); desc != "" {
+			return &oidcCallback{err: fmt.Errorf("OIDC provider returned an error: %s: %s", errCode, desc)}
+		}
+		return &oidcCallback{err: fmt.Errorf("OIDC provider returned an error: %s", errCode)}
+	}
+
+	callback := &oidcCallback{
+		code:  query.Get("code"),
+		state: query.Get("state"),
+	}
+	if callback.code == "" {
+		callback.err = errors.New("OIDC callback is missing the authorization code")
+	}
+	return callback
+}
@@ -0,0 +1,108 @@
+package command
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/command/agent"
+	"github.com/hashicorp/nomad/helper/freeport"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	// Build a test server with ACLs enabled.
+	srv, _, url := testServer(t, false, func(c *agent.Config) {
+		c.ACL.Enabled = true
+	})
+	defer srv.Shutdown()
+
+	ports := freeport.MustTake(1)
+	defer freeport.Return(ports)
+	callbackAddr := fmt.Sprintf("127.0.0.1:%d", ports[0])
+
+	ui := cli.NewMockUi()
+	cmd := &LoginCommand{
+		Meta: Meta{
+			Ui:          ui,
+			flagAddress: url,
+		},
+
+		// Visiting the auth URL logs in with the mock identity provider, which
+		// redirects to the callback listener of the command.
+		openURL: func(authURL string) error {
+			resp, err := http.Get(authURL)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		},
+	}
+
+	// Test the basic validation on the command.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "this-command-does-not-take-args"}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Without a default auth method, the auth method must be specified.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url}))
+	require.Contains(t, ui.ErrorWriter.String(), "no default auth method found")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Start the mock identity provider, and create a default auth method and
+	// binding rule which grant engineers a policy.
+	testProvider := oidc.NewTestProvider(t)
+	testProvider.SetClaims(map[string]interface{}{
+		"groups": []string{"engineering"},
+	})
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Default = true
+	authMethod.Config = testProvider.AuthMethodConfig("http://" + callbackAddr + "/oidc/callback")
+	authMethod.Config.ListClaimMappings = map[string]string{"groups": "groups"}
+	authMethod.SetHash()
+
+	policy := mock.ACLPolicy()
+
+	bindingRule := mock.ACLBindingRule()
+	bindingRule.AuthMethod = authMethod.Name
+	bindingRule.Selector = `"engineering" in list.groups`
+	bindingRule.BindType = structs.ACLBindingRuleBindTypePolicy
+	bindingRule.BindName = policy.Name
+	bindingRule.SetHash()
+
+	stateStore := srv.Agent.Server().State()
+	require.NoError(t, stateStore.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+	require.NoError(t, stateStore.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{policy}))
+	require.NoError(t, stateStore.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{bindingRule}, false))
+
+	// Login using the default auth method.
+	require.Equal(t, 0, cmd.Run([]string{"-address=" + url, "-oidc-callback-addr=" + callbackAddr}),
+		ui.ErrorWriter.String())
+	s := ui.OutputWriter.String()
+	require.Contains(t, s, "Successfully logged in via "+authMethod.Name)
+	require.Contains(t, s, "Type         = client")
+	require.Contains(t, s, "Policies     = ["+policy.Name+"]")
+	require.NotContains(t, s, "Expiry Time  = <none>")
+
+	ui.OutputWriter.Reset()
+	ui.ErrorWriter.Reset()
+
+	// Login naming an auth method which does not exist.
+	require.Equal(t, 1, cmd.Run([]string{"-address=" + url, "-oidc-callback-addr=" + callbackAddr, "-method=does-not-exist"}))
+	require.Contains(t, ui.ErrorWriter.String(), "Error performing login")
+}
@@ -354,6 +354,15 @@ func formatAllocMetrics(metrics *api.AllocationMetric, scores bool, prefix strin
 		out += fmt.Sprintf("%s* Quota limit hit %q\n", prefix, dim)
 	}
 
+	// Print gang info
+	if metrics.GangSize > 0 {
+		out += fmt.Sprintf("%s* Gang of %d allocations not placed: only %d fit", prefix, metrics.GangSize, metrics.GangPlaced)
+		if metrics.GangBlockedBy != "" {
+			out += fmt.Sprintf(", blocked by %s", metrics.GangBlockedBy)
+		}
+		out += "\n"
+	}
+
 	// Print scores
 	if scores {
 		if len(metrics.ScoreMetaData) > 0 {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/hcl"
@@ -45,6 +46,10 @@ Apply Options:
   -description
     An optional description for the namespace.
 
+  -fair-share-weight
+    The weight of the namespace when the eval broker dequeues evaluations
+    fairly across namespaces. Defaults to 1.
+
   -json
     Parse the input as a JSON namespace specification.
 `
@@ -54,9 +59,10 @@ Apply Options:
 func (c *NamespaceApplyCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
 		complete.Flags{
-			"-description": complete.PredictAnything,
-			"-quota":       QuotaPredictor(c.Meta.Client),
-			"-json":        complete.PredictNothing,
+			"-description":       complete.PredictAnything,
+			"-fair-share-weight": complete.PredictAnything,
+			"-quota":             QuotaPredictor(c.Meta.Client),
+			"-json":              complete.PredictNothing,
 		})
 }
 
@@ -77,6 +83,7 @@ func (c *NamespaceApplyCommand) Name() string { return "namespace apply" }
 func (c *NamespaceApplyCommand) Run(args []string) int {
 	var jsonInput bool
 	var description, quota *string
+	var fairShareWeight *int
 
 	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
 	flags.Usage = func() { c.Ui.Output(c.Help()) }
@@ -88,6 +95,14 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 		quota = &s
 		return nil
 	}), "quota", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		w, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		fairShareWeight = &w
+		return nil
+	}), "fair-share-weight", "")
 	flags.BoolVar(&jsonInput, "json", false, "")
 
 	if err := flags.Parse(args); err != nil {
@@ -115,7 +130,7 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 	}
 
 	if fi, err := os.Stat(file); (file == "-" || err == nil) && !fi.IsDir() {
-		if quota != nil || description != nil {
+		if quota != nil || description != nil || fairShareWeight != nil {
 			c.Ui.Warn("Flags are ignored when a file is specified!")
 		}
 
@@ -178,6 +193,9 @@ func (c *NamespaceApplyCommand) Run(args []string) int {
 		if quota != nil {
 			namespace.Quota = *quota
 		}
+		if fairShareWeight != nil {
+			namespace.FairShareWeight = *fairShareWeight
+		}
 	}
 	_, err = client.Namespaces().Register(namespace, nil)
 	if err != nil {
@@ -220,6 +238,7 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 	}
 
 	delete(m, "capabilities")
+	delete(m, "eval_rate_limit")
 	delete(m, "meta")
 
 	// Decode the rest
@@ -243,6 +262,21 @@ func parseNamespaceSpecImpl(result *api.Namespace, list *ast.ObjectList) error {
 		}
 	}
 
+	if lObj := list.Filter("eval_rate_limit"); len(lObj.Items) > 0 {
+		for _, o := range lObj.Elem().Items {
+			ot, ok := o.Val.(*ast.ObjectType)
+			if !ok {
+				break
+			}
+			var limit *api.EvalRateLimit
+			if err := hcl.DecodeObject(&limit, ot.List); err != nil {
+				return err
+			}
+			result.EvalRateLimit = limit
+			break
+		}
+	}
+
 	if metaO := list.Filter("meta"); len(metaO.Items) > 0 {
 		for _, o := range metaO.Elem().Items {
 			var m map[string]interface{}
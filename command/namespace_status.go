@@ -132,13 +132,23 @@ func formatNamespaceBasics(ns *api.Namespace) string {
 			disabled_drivers = strings.Join(ns.Capabilities.DisabledTaskDrivers, ",")
 		}
 	}
+	// Namespaces without a fair share weight have the default weight of 1.
+	fairShareWeight := ns.FairShareWeight
+	if fairShareWeight == 0 {
+		fairShareWeight = 1
+	}
 	basic := []string{
 		fmt.Sprintf("Name|%s", ns.Name),
 		fmt.Sprintf("Description|%s", ns.Description),
 		fmt.Sprintf("Quota|%s", ns.Quota),
+		fmt.Sprintf("FairShareWeight|%d", fairShareWeight),
 		fmt.Sprintf("EnabledDrivers|%s", enabled_drivers),
 		fmt.Sprintf("DisabledDrivers|%s", disabled_drivers),
 	}
+	if l := ns.EvalRateLimit; l != nil {
+		basic = append(basic, fmt.Sprintf("EvalRateLimit|namespace_rate=%v namespace_burst=%d job_rate=%v job_burst=%d",
+			l.NamespaceRate, l.NamespaceBurst, l.JobRate, l.JobBurst))
+	}
 
 	return formatKV(basic)
 }
@@ -32,6 +32,10 @@ Usage: nomad node <subcommand> [options] [args]
 
       $ nomad node drain -enable -deadline 4h <node-id>
 
+  List the node pools nodes can be registered into:
+
+      $ nomad node pool list
+
   Please see the individual subcommand help for detailed usage information.
 `
 
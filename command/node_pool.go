@@ -0,0 +1,141 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+type NodePoolCommand struct {
+	Meta
+}
+
+func (c *NodePoolCommand) Help() string {
+	helpText := `
+Usage: nomad node pool <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with node pools. Node pools
+  partition the client nodes of a cluster into groups. Jobs are only placed on
+  nodes that are members of the node pool they target, and each pool can
+  override parts of the scheduler configuration.
+
+  Create or update a node pool:
+
+      $ nomad node pool apply -description "GPU nodes" gpu
+
+  List node pools:
+
+      $ nomad node pool list
+
+  View the details of a node pool:
+
+      $ nomad node pool info <name>
+
+  Delete a node pool:
+
+      $ nomad node pool delete <name>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *NodePoolCommand) Synopsis() string {
+	return "Interact with node pools"
+}
+
+func (c *NodePoolCommand) Name() string { return "node pool" }
+
+func (c *NodePoolCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// NodePoolPredictor returns a node pool predictor that can optionally filter
+// specific node pools.
+func NodePoolPredictor(factory ApiClientFactory, filter map[string]struct{}) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := factory()
+		if err != nil {
+			return nil
+		}
+
+		pools, _, err := client.NodePools().PrefixList(a.Last, nil)
+		if err != nil {
+			return []string{}
+		}
+
+		var names []string
+		for _, pool := range pools {
+			if _, ok := filter[pool.Name]; !ok {
+				names = append(names, pool.Name)
+			}
+		}
+		return names
+	})
+}
+
+// getNodePool returns the node pool that matches the given name, or the set
+// of node pools that match it as a prefix if there is no exact match.
+func getNodePool(client *api.NodePools, name string) (match *api.NodePool, possible []*api.NodePool, err error) {
+	pools, _, err := client.PrefixList(name, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch len(pools) {
+	case 0:
+		return nil, nil, fmt.Errorf("Node pool %q matched no node pools", name)
+	case 1:
+		return pools[0], nil, nil
+	default:
+		// search for an exact match in the returned node pools
+		for _, pool := range pools {
+			if pool.Name == name {
+				return pool, nil, nil
+			}
+		}
+		// if not found, return the fuzzy matches.
+		return nil, pools, nil
+	}
+}
+
+func formatNodePools(pools []*api.NodePool) string {
+	if len(pools) == 0 {
+		return "No node pools found"
+	}
+
+	rows := make([]string, len(pools)+1)
+	rows[0] = "Name|Description"
+	for i, pool := range pools {
+		rows[i+1] = fmt.Sprintf("%s|%s",
+			pool.Name,
+			pool.Description)
+	}
+	return formatList(rows)
+}
+
+// formatNodePoolBasics formats the basic information of the node pool
+func formatNodePoolBasics(pool *api.NodePool) string {
+	algorithm := "<inherited>"
+	memoryOversubscription := "<inherited>"
+	if sc := pool.SchedulerConfiguration; sc != nil {
+		if sc.SchedulerAlgorithm != "" {
+			algorithm = string(sc.SchedulerAlgorithm)
+		}
+		if sc.MemoryOversubscriptionEnabled != nil {
+			memoryOversubscription = fmt.Sprintf("%t", *sc.MemoryOversubscriptionEnabled)
+		}
+	}
+
+	basic := []string{
+		fmt.Sprintf("Name|%s", pool.Name),
+		fmt.Sprintf("Description|%s", pool.Description),
+		fmt.Sprintf("Scheduler Algorithm|%s", algorithm),
+		fmt.Sprintf("Memory Oversubscription|%s", memoryOversubscription),
+	}
+	return formatKV(basic)
+}
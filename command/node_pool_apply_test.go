@@ -0,0 +1,77 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolApplyCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &NodePoolApplyCommand{}
+}
+
+func TestNodePoolApplyCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &NodePoolApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	require.Equal(t, 1, cmd.Run([]string{"some", "bad", "args"}))
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+}
+
+func TestNodePoolApplyCommand_Good(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &NodePoolApplyCommand{Meta: Meta{Ui: ui}}
+
+	// Create a node pool
+	code := cmd.Run([]string{"-address=" + url, "-description=GPU nodes",
+		"-scheduler-algorithm=spread", "-memory-oversubscription=true", "gpu"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+
+	pool, _, err := client.NodePools().Info("gpu", nil)
+	require.NoError(t, err)
+	require.Equal(t, "GPU nodes", pool.Description)
+	require.Equal(t, api.SchedulerAlgorithm("spread"), pool.SchedulerConfiguration.SchedulerAlgorithm)
+	require.True(t, *pool.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+
+	pools, _, err := client.NodePools().List(nil)
+	require.NoError(t, err)
+	require.Len(t, pools, 3)
+}
+
+func TestNodePoolApplyCommand_ParseSpec(t *testing.T) {
+	ci.Parallel(t)
+
+	spec := `
+name        = "gpu"
+description = "GPU nodes"
+
+meta {
+  team = "ml"
+}
+
+scheduler_config {
+  scheduler_algorithm             = "spread"
+  memory_oversubscription_enabled = true
+}
+`
+	pool, err := parseNodePoolSpec([]byte(spec))
+	require.NoError(t, err)
+	require.Equal(t, "gpu", pool.Name)
+	require.Equal(t, "GPU nodes", pool.Description)
+	require.Equal(t, map[string]string{"team": "ml"}, pool.Meta)
+	require.NotNil(t, pool.SchedulerConfiguration)
+	require.Equal(t, api.SchedulerAlgorithm("spread"), pool.SchedulerConfiguration.SchedulerAlgorithm)
+	require.True(t, *pool.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+}
@@ -0,0 +1,34 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorRootCommand struct {
+	Meta
+}
+
+func (c *OperatorRootCommand) Help() string {
+	helpText := `
+Usage: nomad operator root <subcommand> [options]
+
+  This command groups subcommands for interacting with the root keys held by
+  the Nomad servers. Root keys are used to encrypt variables and to sign
+  workload identities.
+
+  Please see the individual subcommand help for detailed usage information.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRootCommand) Synopsis() string {
+	return "Provides access to the root encryption keys"
+}
+
+func (c *OperatorRootCommand) Name() string { return "operator root" }
+
+func (c *OperatorRootCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
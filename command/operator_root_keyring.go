@@ -0,0 +1,71 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+)
+
+// OperatorRootKeyringCommand is a Command implementation that handles
+// querying, rotating, and removing root encryption keys.
+type OperatorRootKeyringCommand struct {
+	Meta
+}
+
+func (c *OperatorRootKeyringCommand) Help() string {
+	helpText := `
+Usage: nomad operator root keyring [options]
+
+  Manages encryption keys used for storing variables and signing workload
+  identities. This command may be used to examine active encryption keys
+  in the cluster, rotate keys, and remove old keys.
+
+  All operations performed by this command can only be run against server
+  nodes. If ACLs are enabled, this command requires a management token.
+
+  List root encryption keys:
+
+      $ nomad operator root keyring list
+
+  Rotate the active root encryption key:
+
+      $ nomad operator root keyring rotate
+
+  Remove an inactive root encryption key:
+
+      $ nomad operator root keyring remove <key ID>
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRootKeyringCommand) Synopsis() string {
+	return "Manages root encryption keys"
+}
+
+func (c *OperatorRootKeyringCommand) Name() string { return "operator root keyring" }
+
+func (c *OperatorRootKeyringCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// formatRootKeyMetas formats the root key metadata returned by the keyring
+// API for display.
+func formatRootKeyMetas(keys []*api.RootKeyMeta, verbose bool) string {
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+	out := make([]string, 0, len(keys)+1)
+	out = append(out, "Key|State|Create Time")
+	for _, k := range keys {
+		out = append(out, fmt.Sprintf("%s|%v|%s",
+			limit(k.KeyID, length), k.State, formatTime(time.Unix(0, k.CreateTime))))
+	}
+	return formatList(out)
+}
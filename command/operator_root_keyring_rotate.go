@@ -0,0 +1,106 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+// OperatorRootKeyringRotateCommand is a Command implementation that rotates
+// the active root encryption key.
+type OperatorRootKeyringRotateCommand struct {
+	Meta
+}
+
+func (c *OperatorRootKeyringRotateCommand) Help() string {
+	helpText := `
+Usage: nomad operator root keyring rotate [options]
+
+  Generate a new root encryption key and make it the active key. All new data
+  is encrypted with the new key. Existing data remains encrypted with the key
+  that was active when it was written, unless the -full flag is used.
+
+  If ACLs are enabled, this command requires a management token.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Keyring Options:
+
+  -algorithm=<algorithm>
+    The encryption algorithm of the new key. Defaults to "aes256-gcm".
+
+  -full
+    Decrypt all existing variables and re-encrypt them with the new key. This
+    runs in the background on the leader, and the previous keys can be removed
+    once it has completed.
+
+  -verbose
+    Show full key ID.
+`
+	return strings.TrimSpace(helpText)
+}
+
+func (c *OperatorRootKeyringRotateCommand) Synopsis() string {
+	return "Rotates the root encryption key"
+}
+
+func (c *OperatorRootKeyringRotateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-algorithm": complete.PredictSet("aes256-gcm"),
+			"-full":      complete.PredictNothing,
+			"-verbose":   complete.PredictNothing,
+		})
+}
+
+func (c *OperatorRootKeyringRotateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorRootKeyringRotateCommand) Name() string {
+	return "operator root keyring rotate"
+}
+
+func (c *OperatorRootKeyringRotateCommand) Run(args []string) int {
+	var algorithm string
+	var full, verbose bool
+
+	flags := c.Meta.FlagSet("root keyring rotate", FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&algorithm, "algorithm", "", "")
+	flags.BoolVar(&full, "full", false, "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 0 {
+		c.Ui.Error("This command requires no arguments.")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating nomad cli client: %s", err))
+		return 1
+	}
+
+	key, _, err := client.Keyring().Rotate(&api.KeyringRotateOptions{
+		Algorithm: api.EncryptionAlgorithm(algorithm),
+		Full:      full,
+	}, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("error: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(formatRootKeyMetas([]*api.RootKeyMeta{key}, verbose))
+	return 0
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorRootKeyringCommands_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorRootKeyringListCommand{}
+	var _ cli.Command = &OperatorRootKeyringRotateCommand{}
+	var _ cli.Command = &OperatorRootKeyringRemoveCommand{}
+}
+
+func TestOperatorRootKeyringCommands(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, url := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	// Wait for the leader to initialize the keyring.
+	var keys []*api.RootKeyMeta
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		keys, _, err = client.Keyring().List(nil)
+		if err != nil {
+			return false, err
+		}
+		return len(keys) == 1, nil
+	}, func(err error) {
+		t.Fatalf("keyring was not initialized: %v", err)
+	})
+	firstKeyID := keys[0].KeyID
+
+	ui := cli.NewMockUi()
+	listCmd := &OperatorRootKeyringListCommand{Meta: Meta{Ui: ui}}
+	code := listCmd.Run([]string{"-address=" + url, "-verbose"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), firstKeyID)
+	ui.OutputWriter.Reset()
+
+	// Misuse
+	removeCmd := &OperatorRootKeyringRemoveCommand{Meta: Meta{Ui: ui}}
+	code = removeCmd.Run([]string{"-address=" + url})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(removeCmd))
+	ui.ErrorWriter.Reset()
+
+	// The active key cannot be removed
+	code = removeCmd.Run([]string{"-address=" + url, firstKeyID})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "active root key cannot be deleted")
+	ui.ErrorWriter.Reset()
+
+	rotateCmd := &OperatorRootKeyringRotateCommand{Meta: Meta{Ui: ui}}
+	code = rotateCmd.Run([]string{"-address=" + url, "-verbose"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "active")
+	require.NotContains(t, out, firstKeyID)
+	ui.OutputWriter.Reset()
+
+	code = listCmd.Run([]string{"-address=" + url, "-json"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Equal(t, 2, strings.Count(ui.OutputWriter.String(), `"KeyID"`))
+	ui.OutputWriter.Reset()
+
+	// The previous key is now inactive and can be removed
+	code = removeCmd.Run([]string{"-address=" + url, firstKeyID})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "Removed encryption key "+firstKeyID)
+	ui.OutputWriter.Reset()
+
+	keys, _, err := client.Keyring().List(nil)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotEqual(t, firstKeyID, keys[0].KeyID)
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/mitchellh/cli"
+)
+
+type OperatorSchedulerCommand struct {
+	Meta
+}
+
+func (c *OperatorSchedulerCommand) Name() string { return "operator scheduler" }
+
+func (c *OperatorSchedulerCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+func (c *OperatorSchedulerCommand) Synopsis() string {
+	return "Provides tools for managing the scheduler configuration"
+}
+
+func (c *OperatorSchedulerCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler <subcommand> [options]
+
+  This command groups subcommands for interacting with Nomad's scheduler
+  subsystem. The command can be used to view or modify the current scheduler
+  configuration, and to view the evaluations waiting to be scheduled in each
+  namespace.
+
+  Get the current scheduler configuration:
+
+      $ nomad operator scheduler get-config
+
+  Set a new scheduler configuration, dequeuing evaluations fairly across
+  namespaces:
+
+      $ nomad operator scheduler set-config -eval-broker-fair-share=true
+
+  Review the allocations a rebalancing round would migrate off under-utilized
+  nodes:
+
+      $ nomad operator scheduler rebalance -dry-run
+
+  Simulate registering a job against a snapshot of the cluster state:
+
+      $ nomad operator scheduler simulate -snapshot backup.snap -job example.nomad
+
+  Please see the individual subcommand help for detailed usage information.
+  `
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerGetConfig struct {
+	Meta
+}
+
+func (c *OperatorSchedulerGetConfig) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-json": complete.PredictNothing,
+			"-t":    complete.PredictAnything,
+		})
+}
+
+func (c *OperatorSchedulerGetConfig) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerGetConfig) Name() string { return "operator scheduler get-config" }
+
+func (c *OperatorSchedulerGetConfig) Run(args []string) int {
+	var json bool
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if args = flags.Args(); len(args) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch the current configuration.
+	resp, _, err := client.Operator().SchedulerGetConfiguration(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying scheduler configuration: %s", err))
+		return 1
+	}
+
+	// If the user has specified to output the scheduler config as JSON or
+	// using a template, perform this action for the entire object and exit
+	// the command.
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, resp)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	// Fetch the state of the eval broker queues.
+	stats, _, err := client.Operator().SchedulerGetBrokerStats(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying eval broker statistics: %s", err))
+		return 1
+	}
+
+	schedConfig := resp.SchedulerConfig
+	c.Ui.Output(formatKV([]string{
+		fmt.Sprintf("Scheduler Algorithm|%s", schedConfig.SchedulerAlgorithm),
+		fmt.Sprintf("Memory Oversubscription|%v", schedConfig.MemoryOversubscriptionEnabled),
+		fmt.Sprintf("Reject Job Registration|%v", schedConfig.RejectJobRegistration),
+		fmt.Sprintf("Eval Broker Fair Share|%v", schedConfig.EvalBrokerFairShareEnabled),
+		fmt.Sprintf("Preemption System Scheduler|%v", schedConfig.PreemptionConfig.SystemSchedulerEnabled),
+		fmt.Sprintf("Preemption SysBatch Scheduler|%v", schedConfig.PreemptionConfig.SysBatchSchedulerEnabled),
+		fmt.Sprintf("Preemption Service Scheduler|%v", schedConfig.PreemptionConfig.ServiceSchedulerEnabled),
+		fmt.Sprintf("Preemption Batch Scheduler|%v", schedConfig.PreemptionConfig.BatchSchedulerEnabled),
+		fmt.Sprintf("Preemption Victim Rules|%s", strings.Join(schedConfig.PreemptionConfig.VictimRules, ",")),
+		fmt.Sprintf("Rebalance|%v", schedConfig.RebalanceConfig.Enabled),
+		fmt.Sprintf("Rebalance Max Migrations|%d", schedConfig.RebalanceConfig.MaxMigrations),
+		fmt.Sprintf("Rebalance Threshold|%v", schedConfig.RebalanceConfig.UtilizationThreshold),
+		fmt.Sprintf("Modify Index|%v", resp.SchedulerConfig.ModifyIndex),
+	}))
+
+	c.Ui.Output(c.Colorize().Color("\n[bold]Eval Broker Namespaces[reset]"))
+	c.Ui.Output(formatSchedulerBrokerNamespaces(stats))
+	return 0
+}
+
+// formatSchedulerBrokerNamespaces formats the eval broker statistics of the
+// namespaces, ordered by name.
+func formatSchedulerBrokerNamespaces(stats *api.SchedulerBrokerStatsResponse) string {
+	if len(stats.Namespaces) == 0 {
+		return "No namespaces"
+	}
+
+	names := make([]string, 0, len(stats.Namespaces))
+	for name := range stats.Namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]string, 0, len(names)+1)
+	rows = append(rows, "Namespace|Weight|Ready")
+	for _, name := range names {
+		ns := stats.Namespaces[name]
+		rows = append(rows, fmt.Sprintf("%s|%d|%d", name, ns.Weight, ns.Ready))
+	}
+	return formatList(rows)
+}
+
+func (c *OperatorSchedulerGetConfig) Synopsis() string {
+	return "Display the current scheduler configuration"
+}
+
+func (c *OperatorSchedulerGetConfig) Help() string {
+	helpText := `
+Usage: nomad operator scheduler get-config [options]
+
+  Displays the current scheduler configuration, along with the number of
+  evaluations waiting to be scheduled in each namespace and the fair share
+  weight of each namespace.
+
+  If ACLs are enabled, this command requires a token with the 'operator:read'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Get Config Options:
+
+  -json
+    Output the scheduler config in its JSON format.
+
+  -t
+    Format and display the scheduler config using a Go template.
+`
+
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerGetConfig_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerGetConfig{}
+}
+
+func TestOperatorSchedulerGetConfig_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerGetConfig{Meta: Meta{Ui: ui}}
+
+	// Run the command, so we get the default output and test this.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr}))
+	s := ui.OutputWriter.String()
+	require.Contains(t, s, "Scheduler Algorithm           = binpack")
+	require.Contains(t, s, "Eval Broker Fair Share        = false")
+	require.Contains(t, s, "Eval Broker Namespaces")
+	require.Contains(t, s, "default")
+	ui.OutputWriter.Reset()
+
+	// Request JSON output and test.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-json"}))
+	s = ui.OutputWriter.String()
+	require.Contains(t, s, `"EvalBrokerFairShareEnabled": false`)
+	ui.OutputWriter.Reset()
+
+	// Test an unsupported argument.
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "extra"}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
@@ -0,0 +1,182 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerRebalanceCommand struct {
+	Meta
+}
+
+func (c *OperatorSchedulerRebalanceCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-dry-run":        complete.PredictNothing,
+			"-max-migrations": complete.PredictAnything,
+			"-json":           complete.PredictNothing,
+			"-t":              complete.PredictAnything,
+			"-verbose":        complete.PredictNothing,
+		})
+}
+
+func (c *OperatorSchedulerRebalanceCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerRebalanceCommand) Name() string { return "operator scheduler rebalance" }
+
+func (c *OperatorSchedulerRebalanceCommand) Run(args []string) int {
+	var dryRun, json, verbose bool
+	var maxMigrations int
+	var tmpl string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.BoolVar(&dryRun, "dry-run", false, "")
+	flags.IntVar(&maxMigrations, "max-migrations", 0, "")
+	flags.BoolVar(&json, "json", false, "")
+	flags.StringVar(&tmpl, "t", "", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if args = flags.Args(); len(args) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if maxMigrations < 0 {
+		c.Ui.Error("Max migrations must be positive")
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	req := &api.SchedulerRebalanceRequest{
+		DryRun:        dryRun,
+		MaxMigrations: maxMigrations,
+	}
+	resp, _, err := client.Operator().SchedulerRebalance(req, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error rebalancing allocations: %s", err))
+		return 1
+	}
+
+	if json || len(tmpl) > 0 {
+		out, err := Format(json, tmpl, resp)
+		if err != nil {
+			c.Ui.Error(err.Error())
+			return 1
+		}
+		c.Ui.Output(out)
+		return 0
+	}
+
+	if len(resp.Migrations) == 0 {
+		c.Ui.Output("No allocations to rebalance")
+		return 0
+	}
+
+	c.Ui.Output(c.Colorize().Color("[bold]Nodes[reset]"))
+	c.Ui.Output(formatRebalanceNodes(resp.Nodes, length))
+	c.Ui.Output(c.Colorize().Color("\n[bold]Migrations[reset]"))
+	c.Ui.Output(formatRebalanceMigrations(resp.Migrations, length))
+
+	if dryRun {
+		c.Ui.Output(fmt.Sprintf("\nDry run: %d allocations would be migrated", len(resp.Migrations)))
+		return 0
+	}
+
+	c.Ui.Output(fmt.Sprintf("\nMigrating %d allocations, with evaluations:", len(resp.Migrations)))
+	for _, id := range resp.EvalIDs {
+		c.Ui.Output(fmt.Sprintf("    %s", limit(id, length)))
+	}
+	return 0
+}
+
+// formatRebalanceNodes formats the nodes emptied by rebalancing.
+func formatRebalanceNodes(nodes []*api.RebalanceNode, length int) string {
+	rows := make([]string, 0, len(nodes)+1)
+	rows = append(rows, "ID|Name|Utilization")
+	for _, node := range nodes {
+		rows = append(rows, fmt.Sprintf("%s|%s|%.0f%%",
+			limit(node.ID, length), node.Name, node.Utilization*100))
+	}
+	return formatList(rows)
+}
+
+// formatRebalanceMigrations formats the allocations migrated by rebalancing.
+func formatRebalanceMigrations(migrations []*api.RebalanceMigration, length int) string {
+	rows := make([]string, 0, len(migrations)+1)
+	rows = append(rows, "Alloc ID|Namespace|Job ID|Task Group|Node ID|Target Node ID")
+	for _, m := range migrations {
+		rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s|%s",
+			limit(m.AllocID, length), m.Namespace, m.JobID, m.TaskGroup,
+			limit(m.NodeID, length), limit(m.TargetNodeID, length)))
+	}
+	return formatList(rows)
+}
+
+func (c *OperatorSchedulerRebalanceCommand) Synopsis() string {
+	return "Migrate allocations off under-utilized nodes"
+}
+
+func (c *OperatorSchedulerRebalanceCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler rebalance [options]
+
+  Runs a round of rebalancing, which migrates the allocations of service jobs
+  off the nodes whose utilization is below the rebalancing threshold of the
+  scheduler configuration, so the nodes can be emptied. Nodes are only emptied
+  if their allocations are estimated to fit on the other nodes. The migrations
+  honor the migrate stanza of the task groups, and at most the maximum number
+  of migrations of the scheduler configuration are made in a round.
+
+  The target nodes are estimates: the scheduler places the replacement
+  allocations, which may land on other nodes.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability, or the 'operator:read' capability for a dry run.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Rebalance Options:
+
+  -dry-run
+    Only display the proposed migrations, without migrating the allocations.
+
+  -max-migrations=<count>
+    The maximum number of allocations migrated in the round, overriding the
+    maximum of the scheduler configuration.
+
+  -json
+    Output the rebalancing round in its JSON format.
+
+  -t
+    Format and display the rebalancing round using a Go template.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,38 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerRebalanceCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerRebalanceCommand{}
+}
+
+func TestOperatorSchedulerRebalanceCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerRebalanceCommand{Meta: Meta{Ui: ui}}
+
+	// A cluster without allocations has nothing to rebalance.
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-dry-run"}))
+	require.Contains(t, ui.OutputWriter.String(), "No allocations to rebalance")
+	ui.OutputWriter.Reset()
+
+	require.EqualValues(t, 0, c.Run([]string{"-address=" + addr, "-dry-run", "-json"}))
+	require.Contains(t, ui.OutputWriter.String(), `"Migrations": null`)
+
+	// Test an invalid budget and an unsupported argument.
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "-max-migrations=-1"}))
+	require.Contains(t, ui.ErrorWriter.String(), "Max migrations must be positive")
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "extra"}))
+	require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+}
@@ -0,0 +1,247 @@
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSetConfig struct {
+	Meta
+}
+
+func (c *OperatorSchedulerSetConfig) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.Meta.AutocompleteFlags(FlagSetClient),
+		complete.Flags{
+			"-scheduler-algorithm": complete.PredictSet(
+				string(api.SchedulerAlgorithmBinpack),
+				string(api.SchedulerAlgorithmSpread),
+			),
+			"-memory-oversubscription":    complete.PredictSet("true", "false"),
+			"-reject-job-registration":    complete.PredictSet("true", "false"),
+			"-eval-broker-fair-share":     complete.PredictSet("true", "false"),
+			"-preempt-batch-scheduler":    complete.PredictSet("true", "false"),
+			"-preempt-service-scheduler":  complete.PredictSet("true", "false"),
+			"-preempt-sysbatch-scheduler": complete.PredictSet("true", "false"),
+			"-preempt-system-scheduler":   complete.PredictSet("true", "false"),
+			"-preempt-victim-rules":       complete.PredictAnything,
+			"-rebalance":                  complete.PredictSet("true", "false"),
+			"-rebalance-max-migrations":   complete.PredictAnything,
+			"-rebalance-threshold":        complete.PredictAnything,
+		})
+}
+
+func (c *OperatorSchedulerSetConfig) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerSetConfig) Name() string { return "operator scheduler set-config" }
+
+func (c *OperatorSchedulerSetConfig) Run(args []string) int {
+	// Like the autopilot flags, the flags assume no default value and are
+	// only applied to the current configuration when set.
+	var memOversub, rejectJobReg, fairShare flaghelper.BoolValue
+	var preemptBatch, preemptService, preemptSysBatch, preemptSystem flaghelper.BoolValue
+	var victimRules *[]string
+	var rebalance flaghelper.BoolValue
+	var rebalanceMaxMigrations *int
+	var rebalanceThreshold *float64
+	var schedAlg *string
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetClient)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		schedAlg = &s
+		return nil
+	}), "scheduler-algorithm", "")
+	flags.Var(&memOversub, "memory-oversubscription", "")
+	flags.Var(&rejectJobReg, "reject-job-registration", "")
+	flags.Var(&fairShare, "eval-broker-fair-share", "")
+	flags.Var(&preemptBatch, "preempt-batch-scheduler", "")
+	flags.Var(&preemptService, "preempt-service-scheduler", "")
+	flags.Var(&preemptSysBatch, "preempt-sysbatch-scheduler", "")
+	flags.Var(&preemptSystem, "preempt-system-scheduler", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		rules := []string{}
+		for _, rule := range strings.Split(s, ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+		victimRules = &rules
+		return nil
+	}), "preempt-victim-rules", "")
+	flags.Var(&rebalance, "rebalance", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		v, err := strconv.Atoi(s)
+		if err != nil || v < 0 {
+			return fmt.Errorf("invalid max migrations %q", s)
+		}
+		rebalanceMaxMigrations = &v
+		return nil
+	}), "rebalance-max-migrations", "")
+	flags.Var((flaghelper.FuncVar)(func(s string) error {
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil || v < 0 || v > 1 {
+			return fmt.Errorf("invalid utilization threshold %q, must be between 0 and 1", s)
+		}
+		rebalanceThreshold = &v
+		return nil
+	}), "rebalance-threshold", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if args = flags.Args(); len(args) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if schedAlg != nil {
+		switch api.SchedulerAlgorithm(*schedAlg) {
+		case api.SchedulerAlgorithmBinpack, api.SchedulerAlgorithmSpread:
+		default:
+			c.Ui.Error(fmt.Sprintf("Invalid scheduler algorithm %q, must be one of %q or %q",
+				*schedAlg, api.SchedulerAlgorithmBinpack, api.SchedulerAlgorithmSpread))
+			return 1
+		}
+	}
+
+	// Set up a client.
+	client, err := c.Meta.Client()
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error initializing client: %s", err))
+		return 1
+	}
+
+	// Fetch the current configuration.
+	operator := client.Operator()
+	resp, _, err := operator.SchedulerGetConfiguration(nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error querying scheduler configuration: %s", err))
+		return 1
+	}
+	schedConfig := resp.SchedulerConfig
+
+	// Update the config values based on the set flags.
+	if schedAlg != nil {
+		schedConfig.SchedulerAlgorithm = api.SchedulerAlgorithm(*schedAlg)
+	}
+	memOversub.Merge(&schedConfig.MemoryOversubscriptionEnabled)
+	rejectJobReg.Merge(&schedConfig.RejectJobRegistration)
+	fairShare.Merge(&schedConfig.EvalBrokerFairShareEnabled)
+	preemptBatch.Merge(&schedConfig.PreemptionConfig.BatchSchedulerEnabled)
+	preemptService.Merge(&schedConfig.PreemptionConfig.ServiceSchedulerEnabled)
+	preemptSysBatch.Merge(&schedConfig.PreemptionConfig.SysBatchSchedulerEnabled)
+	preemptSystem.Merge(&schedConfig.PreemptionConfig.SystemSchedulerEnabled)
+	if victimRules != nil {
+		schedConfig.PreemptionConfig.VictimRules = *victimRules
+	}
+	rebalance.Merge(&schedConfig.RebalanceConfig.Enabled)
+	if rebalanceMaxMigrations != nil {
+		schedConfig.RebalanceConfig.MaxMigrations = *rebalanceMaxMigrations
+	}
+	if rebalanceThreshold != nil {
+		schedConfig.RebalanceConfig.UtilizationThreshold = *rebalanceThreshold
+	}
+
+	// Check-and-set the new configuration.
+	result, _, err := operator.SchedulerCASConfiguration(schedConfig, nil)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error setting scheduler configuration: %s", err))
+		return 1
+	}
+	if result.Updated {
+		c.Ui.Output("Scheduler configuration updated!")
+		return 0
+	}
+	c.Ui.Output("Scheduler configuration could not be atomically updated, please try again")
+	return 1
+}
+
+func (c *OperatorSchedulerSetConfig) Synopsis() string {
+	return "Modify the current scheduler configuration"
+}
+
+func (c *OperatorSchedulerSetConfig) Help() string {
+	helpText := `
+Usage: nomad operator scheduler set-config [options]
+
+  Modifies the current scheduler configuration. Only the options which are set
+  are changed.
+
+  If ACLs are enabled, this command requires a token with the 'operator:write'
+  capability.
+
+General Options:
+
+  ` + generalOptionsUsage(usageOptsDefault|usageOptsNoNamespace) + `
+
+Scheduler Set Config Options:
+
+  -eval-broker-fair-share=[true|false]
+    Specifies whether the eval broker dequeues evaluations fairly across
+    namespaces, by weighted round-robin on the fair share weight of the
+    namespaces, rather than strictly by priority. Evaluations are still
+    dequeued by priority within a namespace.
+
+  -memory-oversubscription=[true|false]
+    When true, tasks may exceed their reserved memory limit, if the client has
+    excess memory capacity. Tasks must specify memory_max to take advantage of
+    memory oversubscription.
+
+  -preempt-batch-scheduler=[true|false]
+    Specifies whether preemption for batch jobs is enabled.
+
+  -preempt-service-scheduler=[true|false]
+    Specifies whether preemption for service jobs is enabled.
+
+  -preempt-sysbatch-scheduler=[true|false]
+    Specifies whether preemption for system batch jobs is enabled.
+
+  -preempt-system-scheduler=[true|false]
+    Specifies whether preemption for system jobs is enabled.
+
+  -preempt-victim-rules=<rules>
+    A comma separated list of the rules used to choose the allocations to
+    preempt, in addition to their job priority and resource fit. The
+    "deployment" rule protects the allocations of active deployments, the
+    "min-healthy" rule protects allocations if preempting them would leave
+    fewer allocations of their task group running than its count minus its
+    migrate max_parallel, and the "ephemeral" rule prefers preempting the
+    allocations of jobs or groups with the "ephemeral" metadata set to true.
+    An empty list disables the rules.
+
+  -rebalance=[true|false]
+    Specifies whether allocations are periodically migrated off nodes whose
+    utilization is below the rebalancing threshold, so the nodes can be
+    emptied. See "nomad operator scheduler rebalance" to review the proposed
+    migrations.
+
+  -rebalance-max-migrations=<count>
+    The maximum number of allocations migrated in a rebalancing round. The
+    migrations also honor the migrate max_parallel of the task groups.
+    Defaults to 5 when set to 0.
+
+  -rebalance-threshold=<fraction>
+    The CPU or memory utilization, between 0 and 1, below which rebalancing
+    empties nodes. Defaults to 0.5 when set to 0.
+
+  -reject-job-registration=[true|false]
+    When true, the server will return permission denied errors for job
+    registration, job dispatch, and job scale APIs, unless the ACL token for
+    the request is a management token.
+
+  -scheduler-algorithm=[binpack|spread]
+    Specifies whether scheduler binpacks or spreads allocations on available
+    nodes.
+`
+	return strings.TrimSpace(helpText)
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerSetConfig_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSetConfig{}
+}
+
+func TestOperatorSchedulerSetConfig_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, client, addr := testServer(t, false, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	c := &OperatorSchedulerSetConfig{Meta: Meta{Ui: ui}}
+
+	bootstrapped, _, err := client.Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+	require.False(t, bootstrapped.SchedulerConfig.EvalBrokerFairShareEnabled)
+
+	// Modify a subset of the config and ensure the rest is untouched.
+	args := []string{
+		"-address=" + addr,
+		"-eval-broker-fair-share=true",
+		"-scheduler-algorithm=spread",
+		"-preempt-batch-scheduler=true",
+		"-preempt-victim-rules=deployment, ephemeral",
+		"-rebalance=true",
+		"-rebalance-max-migrations=3",
+	}
+	require.EqualValues(t, 0, c.Run(args))
+	require.Contains(t, ui.OutputWriter.String(), "Scheduler configuration updated!")
+
+	modified, _, err := client.Operator().SchedulerGetConfiguration(nil)
+	require.NoError(t, err)
+
+	schedConfig := modified.SchedulerConfig
+	require.True(t, schedConfig.EvalBrokerFairShareEnabled)
+	require.Equal(t, api.SchedulerAlgorithmSpread, schedConfig.SchedulerAlgorithm)
+	require.True(t, schedConfig.PreemptionConfig.BatchSchedulerEnabled)
+	require.Equal(t, []string{"deployment", "ephemeral"}, schedConfig.PreemptionConfig.VictimRules)
+	require.True(t, schedConfig.RebalanceConfig.Enabled)
+	require.Equal(t, 3, schedConfig.RebalanceConfig.MaxMigrations)
+	require.Zero(t, schedConfig.RebalanceConfig.UtilizationThreshold)
+	require.Equal(t, bootstrapped.SchedulerConfig.PreemptionConfig.SystemSchedulerEnabled,
+		schedConfig.PreemptionConfig.SystemSchedulerEnabled)
+	require.Equal(t, bootstrapped.SchedulerConfig.MemoryOversubscriptionEnabled,
+		schedConfig.MemoryOversubscriptionEnabled)
+
+	// An invalid scheduler algorithm is rejected before contacting the
+	// servers.
+	ui.ErrorWriter.Reset()
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "-scheduler-algorithm=random"}))
+	require.Contains(t, ui.ErrorWriter.String(), "Invalid scheduler algorithm")
+
+	// Unknown victim rules are rejected by the servers.
+	ui.ErrorWriter.Reset()
+	require.EqualValues(t, 1, c.Run([]string{"-address=" + addr, "-preempt-victim-rules=newest"}))
+	require.Contains(t, ui.ErrorWriter.String(), "invalid preemption victim rule")
+}
@@ -0,0 +1,403 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/command/agent"
+	flaghelper "github.com/hashicorp/nomad/helper/flags"
+	"github.com/hashicorp/nomad/helper/raftutil"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/scheduler"
+	"github.com/posener/complete"
+)
+
+type OperatorSchedulerSimulateCommand struct {
+	Meta
+	JobGetter
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		"-snapshot":         complete.PredictFiles("*"),
+		"-job":              complete.PredictOr(complete.PredictFiles("*.nomad"), complete.PredictFiles("*.hcl")),
+		"-scheduler-config": complete.PredictFiles("*.json"),
+		"-node":             complete.PredictFiles("*.json"),
+		"-verbose":          complete.PredictNothing,
+	}
+}
+
+func (c *OperatorSchedulerSimulateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *OperatorSchedulerSimulateCommand) Name() string { return "operator scheduler simulate" }
+
+func (c *OperatorSchedulerSimulateCommand) Run(args []string) int {
+	var snapshotPath, jobPath, configPath string
+	var nodePaths flaghelper.StringFlag
+	var verbose bool
+
+	flags := c.Meta.FlagSet(c.Name(), FlagSetNone)
+	flags.Usage = func() { c.Ui.Output(c.Help()) }
+	flags.StringVar(&snapshotPath, "snapshot", "", "")
+	flags.StringVar(&jobPath, "job", "", "")
+	flags.StringVar(&configPath, "scheduler-config", "", "")
+	flags.Var(&nodePaths, "node", "")
+	flags.BoolVar(&verbose, "verbose", false, "")
+	flags.BoolVar(&c.JobGetter.JSON, "json", false, "")
+	flags.BoolVar(&c.JobGetter.HCL1, "hcl1", false, "")
+	flags.BoolVar(&c.JobGetter.Strict, "hcl2-strict", true, "")
+	flags.Var(&c.JobGetter.Vars, "var", "")
+	flags.Var(&c.JobGetter.VarFiles, "var-file", "")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	// Check that we got no arguments
+	if args = flags.Args(); len(args) != 0 {
+		c.Ui.Error("This command takes no arguments")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if snapshotPath == "" || jobPath == "" {
+		c.Ui.Error("Both -snapshot and -job must be set")
+		c.Ui.Error(commandErrorText(c))
+		return 1
+	}
+
+	if err := c.JobGetter.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Invalid job options: %s", err))
+		return 1
+	}
+
+	length := shortId
+	if verbose {
+		length = fullId
+	}
+
+	// Parse the job before restoring the snapshot, which may take a while
+	apiJob, err := c.JobGetter.Get(jobPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error getting job struct: %s", err))
+		return 1
+	}
+
+	// Apply the mutators Job.Register applies, such as implied constraints,
+	// so that the job is scheduled as it would be once registered
+	job, _, err := nomad.MutateJob(agent.ApiJobToStructJob(apiJob))
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error mutating job: %s", err))
+		return 1
+	}
+	if err := job.Validate(); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error validating job: %s", err))
+		return 1
+	}
+
+	f, err := os.Open(snapshotPath)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error opening snapshot file: %s", err))
+		return 1
+	}
+	defer f.Close()
+
+	store, meta, err := raftutil.RestoreFromArchive(f)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Failed to read archive file: %s", err))
+		return 1
+	}
+
+	h, err := scheduler.NewSimulationHarness(store, hclog.NewNullLogger())
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error creating simulation: %s", err))
+		return 1
+	}
+
+	// Apply the overrides on top of the snapshot
+	if configPath != "" {
+		var config structs.SchedulerConfiguration
+		if err := decodeJSONFile(configPath, &config); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading scheduler configuration: %s", err))
+			return 1
+		}
+		config.Canonicalize()
+		if err := config.Validate(); err != nil {
+			c.Ui.Error(fmt.Sprintf("Invalid scheduler configuration: %s", err))
+			return 1
+		}
+		if err := store.SchedulerSetConfig(h.NextIndex(), &config); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error setting scheduler configuration: %s", err))
+			return 1
+		}
+	}
+
+	for _, path := range nodePaths {
+		node, err := simulatedNode(path)
+		if err != nil {
+			c.Ui.Error(fmt.Sprintf("Error reading node %q: %s", path, err))
+			return 1
+		}
+		if err := store.UpsertNode(structs.NodeRegisterRequestType, h.NextIndex(), node); err != nil {
+			c.Ui.Error(fmt.Sprintf("Error adding node %q: %s", path, err))
+			return 1
+		}
+	}
+
+	eval, err := simulatedJobRegister(h, job)
+	if err != nil {
+		c.Ui.Error(fmt.Sprintf("Error registering job: %s", err))
+		return 1
+	}
+
+	factory, ok := scheduler.BuiltinSchedulers[eval.Type]
+	if !ok {
+		c.Ui.Error(fmt.Sprintf("No scheduler for job type %q", eval.Type))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(fmt.Sprintf(
+		"[bold]==> Simulating job %q against snapshot at index %d[reset]", job.ID, meta.Index)))
+
+	if err := h.Process(factory, eval); err != nil {
+		c.Ui.Error(fmt.Sprintf("Error running scheduler: %s", err))
+		return 1
+	}
+
+	c.Ui.Output(c.Colorize().Color(formatSimulation(h, store, length)))
+	return 0
+}
+
+// decodeJSONFile decodes the JSON file at path into out.
+func decodeJSONFile(path string, out interface{}) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return json.NewDecoder(f).Decode(out)
+}
+
+// simulatedNode reads a hypothetical node from the JSON file at path, filling
+// in the fields a client would set when registering.
+func simulatedNode(path string) (*structs.Node, error) {
+	var node structs.Node
+	if err := decodeJSONFile(path, &node); err != nil {
+		return nil, err
+	}
+
+	if node.ID == "" {
+		node.ID = uuid.Generate()
+	}
+	if node.Name == "" {
+		node.Name = node.ID
+	}
+	if node.Status == "" {
+		node.Status = structs.NodeStatusReady
+	}
+	node.Canonicalize()
+	if err := node.ComputeClass(); err != nil {
+		return nil, fmt.Errorf("failed to compute node class: %v", err)
+	}
+	return &node, nil
+}
+
+// simulatedJobRegister registers the job in the harness state and returns the
+// evaluation a registration would create.
+func simulatedJobRegister(h *scheduler.Harness, job *structs.Job) (*structs.Evaluation, error) {
+	if err := h.State.UpsertJob(structs.JobRegisterRequestType, h.NextIndex(), job); err != nil {
+		return nil, err
+	}
+
+	// Lookup the job to get its updated indexes
+	job, err := h.State.JobByID(nil, job.Namespace, job.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	eval := &structs.Evaluation{
+		ID:             uuid.Generate(),
+		Namespace:      job.Namespace,
+		Priority:       job.Priority,
+		Type:           job.Type,
+		TriggeredBy:    structs.EvalTriggerJobRegister,
+		JobID:          job.ID,
+		JobModifyIndex: job.JobModifyIndex,
+		Status:         structs.EvalStatusPending,
+	}
+	if err := h.State.UpsertEvals(structs.EvalUpdateRequestType, h.NextIndex(), []*structs.Evaluation{eval}); err != nil {
+		return nil, err
+	}
+	return eval, nil
+}
+
+// formatSimulation formats the placements, stops, preemptions and failures of
+// the plans submitted during the simulation.
+func formatSimulation(h *scheduler.Harness, store *state.StateStore, length int) string {
+	nodeName := func(id string) string {
+		node, err := store.NodeByID(nil, id)
+		if err != nil || node == nil {
+			return ""
+		}
+		return node.Name
+	}
+
+	var placed, stopped, preempted []*structs.Allocation
+	for _, plan := range h.Plans {
+		for _, allocs := range plan.NodeAllocation {
+			placed = append(placed, allocs...)
+		}
+		for _, allocs := range plan.NodeUpdate {
+			stopped = append(stopped, allocs...)
+		}
+		for _, allocs := range plan.NodePreemptions {
+			preempted = append(preempted, allocs...)
+		}
+	}
+	for _, allocs := range [][]*structs.Allocation{placed, stopped, preempted} {
+		sort.Slice(allocs, func(i, j int) bool { return allocs[i].Name < allocs[j].Name })
+	}
+
+	var out string
+	if len(placed) > 0 {
+		rows := make([]string, 0, len(placed)+1)
+		rows = append(rows, "Alloc ID|Name|Task Group|Node ID|Node Name")
+		for _, alloc := range placed {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s",
+				limit(alloc.ID, length), alloc.Name, alloc.TaskGroup,
+				limit(alloc.NodeID, length), nodeName(alloc.NodeID)))
+		}
+		out += fmt.Sprintf("\n[bold]Placements[reset]\n%s\n", formatList(rows))
+	}
+
+	if len(stopped) > 0 {
+		rows := make([]string, 0, len(stopped)+1)
+		rows = append(rows, "Alloc ID|Name|Node ID|Description")
+		for _, alloc := range stopped {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s",
+				limit(alloc.ID, length), alloc.Name,
+				limit(alloc.NodeID, length), alloc.DesiredDescription))
+		}
+		out += fmt.Sprintf("\n[bold]Stops[reset]\n%s\n", formatList(rows))
+	}
+
+	if len(preempted) > 0 {
+		rows := make([]string, 0, len(preempted)+1)
+		rows = append(rows, "Alloc ID|Job ID|Namespace|Task Group|Node ID")
+		for _, alloc := range preempted {
+			rows = append(rows, fmt.Sprintf("%s|%s|%s|%s|%s",
+				limit(alloc.ID, length), alloc.JobID, alloc.Namespace,
+				alloc.TaskGroup, limit(alloc.NodeID, length)))
+		}
+		out += fmt.Sprintf("\n[bold]Preemptions[reset]\n%s\n", formatList(rows))
+	}
+
+	var failed map[string]*structs.AllocMetric
+	if len(h.Evals) > 0 {
+		failed = h.Evals[len(h.Evals)-1].FailedTGAllocs
+	}
+	if len(failed) == 0 {
+		out += "\n[bold][green]- All tasks successfully allocated.[reset]"
+		return out
+	}
+
+	out += "\n[bold][yellow]- WARNING: Failed to place all allocations.[reset]\n"
+	metrics := apiAllocMetrics(failed)
+	for _, tg := range sortedTaskGroupFromMetrics(metrics) {
+		m := metrics[tg]
+		noun := "allocation"
+		if m.CoalescedFailures > 0 {
+			noun += "s"
+		}
+		out += fmt.Sprintf("%s[yellow]Task Group %q (failed to place %d %s):\n[reset]", strings.Repeat(" ", 2), tg, m.CoalescedFailures+1, noun)
+		out += fmt.Sprintf("[yellow]%s[reset]\n", formatAllocMetrics(m, false, strings.Repeat(" ", 4)))
+	}
+	return strings.TrimSuffix(out, "\n")
+}
+
+// apiAllocMetrics converts the allocation metrics of the scheduler to their
+// API representation, which mirrors the field names of the structs, so that
+// they can be formatted like the metrics returned by the HTTP API.
+func apiAllocMetrics(in map[string]*structs.AllocMetric) map[string]*api.AllocationMetric {
+	out := make(map[string]*api.AllocationMetric, len(in))
+	for tg, metric := range in {
+		var m api.AllocationMetric
+		if buf, err := json.Marshal(metric); err == nil {
+			_ = json.Unmarshal(buf, &m)
+		}
+		out[tg] = &m
+	}
+	return out
+}
+
+func (c *OperatorSchedulerSimulateCommand) Synopsis() string {
+	return "Simulate scheduling a job against a snapshot"
+}
+
+func (c *OperatorSchedulerSimulateCommand) Help() string {
+	helpText := `
+Usage: nomad operator scheduler simulate -snapshot <file> -job <file> [options]
+
+  Simulates registering a job against the state of a snapshot, as saved by
+  "nomad operator snapshot save", and displays the allocations the scheduler
+  would place, stop and preempt, as well as the placement failures. The
+  simulation runs the schedulers offline, without contacting a Nomad agent,
+  so it has no effect on a live cluster.
+
+  The scheduler configuration of the snapshot can be overridden, and
+  hypothetical nodes can be added to the snapshot, to review the effect of
+  scheduler configuration changes or of growing the cluster before applying
+  them.
+
+Scheduler Simulate Options:
+
+  -snapshot=<file>
+    The snapshot file to simulate the job against. Required.
+
+  -job=<file>
+    The job file to simulate, in the same format as accepted by "nomad job
+    run". Required.
+
+  -scheduler-config=<file>
+    A JSON file with a scheduler configuration to use instead of the one of
+    the snapshot, such as the "SchedulerConfig" object output by
+    "nomad operator scheduler get-config -json".
+
+  -node=<file>
+    A JSON file with a hypothetical node to add to the snapshot, such as the
+    output of "nomad node status -json <node>". A node ID is generated if the
+    node has none, and the node is ready and eligible unless its status or
+    eligibility are set. This flag can be repeated to add multiple nodes.
+
+  -hcl1
+    Parses the job file as HCLv1.
+
+  -hcl2-strict
+    Whether an error should be produced from the HCL2 parser where a variable
+    has been supplied which is not defined within the root variables. Defaults
+    to true.
+
+  -json
+    Parses the job file as JSON.
+
+  -var 'key=value'
+    Variable for template, can be used multiple times.
+
+  -var-file=path
+    Path to HCL2 file containing user variables.
+
+  -verbose
+    Display full information.
+`
+	return strings.TrimSpace(helpText)
+}
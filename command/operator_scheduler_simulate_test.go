@@ -0,0 +1,150 @@
+package command
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorSchedulerSimulateCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &OperatorSchedulerSimulateCommand{}
+}
+
+func TestOperatorSchedulerSimulateCommand_Run(t *testing.T) {
+	ci.Parallel(t)
+
+	snapPath := generateSnapshotFile(t, nil)
+	tmpDir := t.TempDir()
+
+	jobPath := filepath.Join(tmpDir, "example.nomad")
+	require.NoError(t, ioutil.WriteFile(jobPath, []byte(`
+job "job1" {
+  type        = "service"
+  datacenters = ["dc1"]
+
+  group "group1" {
+    count = 2
+
+    task "task1" {
+      driver = "exec"
+
+      resources {
+        cpu    = 100
+        memory = 64
+      }
+    }
+  }
+}`), 0600))
+
+	node := mock.Node()
+	node.Name = "simulated-node"
+	buf, err := json.Marshal(node)
+	require.NoError(t, err)
+	nodePath := filepath.Join(tmpDir, "node.json")
+	require.NoError(t, ioutil.WriteFile(nodePath, buf, 0600))
+
+	config := &structs.SchedulerConfiguration{SchedulerAlgorithm: structs.SchedulerAlgorithmSpread}
+	buf, err = json.Marshal(config)
+	require.NoError(t, err)
+	configPath := filepath.Join(tmpDir, "config.json")
+	require.NoError(t, ioutil.WriteFile(configPath, buf, 0600))
+
+	t.Run("without nodes", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+		// The snapshot of a server without clients can't place the job.
+		require.EqualValues(t, 0, c.Run([]string{"-snapshot", snapPath, "-job", jobPath}))
+		out := ui.OutputWriter.String()
+		require.Contains(t, out, `Simulating job "job1"`)
+		require.Contains(t, out, `Task Group "group1" (failed to place 2 allocations)`)
+		require.NotContains(t, out, "Placements")
+	})
+
+	t.Run("with nodes", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+		require.EqualValues(t, 0, c.Run([]string{
+			"-snapshot", snapPath,
+			"-job", jobPath,
+			"-node", nodePath,
+			"-scheduler-config", configPath,
+			"-verbose",
+		}))
+		out := ui.OutputWriter.String()
+		require.Contains(t, out, "Placements")
+		require.Contains(t, out, "job1.group1[0]")
+		require.Contains(t, out, "job1.group1[1]")
+		require.Contains(t, out, node.ID)
+		require.Contains(t, out, "simulated-node")
+		require.Contains(t, out, "All tasks successfully allocated")
+	})
+
+	t.Run("implied constraints", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+		// A Nomad service implies a constraint on the service discovery
+		// attribute of the node, which the simulated node doesn't set.
+		servicePath := filepath.Join(tmpDir, "service.nomad")
+		require.NoError(t, ioutil.WriteFile(servicePath, []byte(`
+job "job2" {
+  type        = "service"
+  datacenters = ["dc1"]
+
+  group "group1" {
+    service {
+      name     = "web"
+      provider = "nomad"
+    }
+
+    task "task1" {
+      driver = "exec"
+
+      resources {
+        cpu    = 100
+        memory = 64
+      }
+    }
+  }
+}`), 0600))
+
+		require.EqualValues(t, 0, c.Run([]string{
+			"-snapshot", snapPath,
+			"-job", servicePath,
+			"-node", nodePath,
+		}))
+		out := ui.OutputWriter.String()
+		require.NotContains(t, out, "Placements")
+		require.Contains(t, out, `Task Group "group1" (failed to place 1 allocation)`)
+		require.Contains(t, out, "${attr.nomad.service_discovery}")
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		ui := cli.NewMockUi()
+		c := &OperatorSchedulerSimulateCommand{Meta: Meta{Ui: ui}}
+
+		require.EqualValues(t, 1, c.Run([]string{"-job", jobPath}))
+		require.Contains(t, ui.ErrorWriter.String(), "Both -snapshot and -job must be set")
+		ui.ErrorWriter.Reset()
+
+		require.EqualValues(t, 1, c.Run([]string{"-snapshot", snapPath, "-job", jobPath, "extra"}))
+		require.Contains(t, ui.ErrorWriter.String(), "This command takes no arguments")
+		ui.ErrorWriter.Reset()
+
+		require.EqualValues(t, 1, c.Run([]string{
+			"-snapshot", filepath.Join(tmpDir, "missing.snap"),
+			"-job", jobPath,
+		}))
+		require.Contains(t, ui.ErrorWriter.String(), "no such file")
+	})
+}
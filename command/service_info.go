@@ -111,11 +111,13 @@ func (s *ServiceInfoCommand) Run(args []string) int {
 		return 1
 	}
 
-	// Set up the options to capture any filter passed.
+	// Set up the options to capture any filter passed. Registrations whose
+	// checks are failing are included, so operators can inspect them.
 	opts := api.QueryOptions{
 		Filter:    filter,
 		PerPage:   int32(perPage),
 		NextToken: pageToken,
+		Params:    map[string]string{"include_unhealthy": "true"},
 	}
 
 	serviceInfo, qm, err := client.Services().Get(args[0], &opts)
@@ -195,6 +197,15 @@ func (s *ServiceInfoCommand) formatOutput(jobIDs []string, jobServices map[strin
 	s.Ui.Output(formatList(outputTable))
 }
 
+// formatServiceStatus returns the status of a service registration, which is
+// empty for registrations written by clients predating service checks.
+func formatServiceStatus(status string) string {
+	if status == "" {
+		return "<none>"
+	}
+	return status
+}
+
 func formatAddress(address string, port int) string {
 	if port == 0 {
 		return address
@@ -216,6 +227,7 @@ func (s *ServiceInfoCommand) formatVerboseOutput(jobIDs []string, jobServices ma
 				fmt.Sprintf("Node ID|%s", service.NodeID),
 				fmt.Sprintf("Datacenter|%s", service.Datacenter),
 				fmt.Sprintf("Address|%v", fmt.Sprintf("%s:%v", service.Address, service.Port)),
+				fmt.Sprintf("Status|%s", formatServiceStatus(service.Status)),
 				fmt.Sprintf("Tags|[%s]\n", strings.Join(service.Tags, ",")),
 			}
 			s.Ui.Output(formatKV(out))
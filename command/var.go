@@ -0,0 +1,133 @@
+package command
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+type VarCommand struct {
+	Meta
+}
+
+func (f *VarCommand) Help() string {
+	helpText := `
+Usage: nomad var <subcommand> [options] [args]
+
+  This command groups subcommands for interacting with variables. Variables
+  allow operators to provide credentials and otherwise sensitive material to
+  Nomad jobs at runtime via the template block or directly through the Nomad
+  API and CLI. Variables are encrypted at rest by the Nomad servers.
+
+  Create or update a variable:
+
+      $ nomad var put secret/creds username=admin password=hunter2
+
+  Read a variable:
+
+      $ nomad var get secret/creds
+
+  List variables:
+
+      $ nomad var list secret/
+
+  Purge a variable:
+
+      $ nomad var purge secret/creds
+
+  Please see the individual subcommand help for detailed usage information.
+`
+
+	return strings.TrimSpace(helpText)
+}
+
+func (f *VarCommand) Synopsis() string {
+	return "Interact with variables"
+}
+
+func (f *VarCommand) Name() string { return "var" }
+
+func (f *VarCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
+
+// VariablePathPredictor returns a var predictor
+func VariablePathPredictor(factory ApiClientFactory) complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		client, err := factory()
+		if err != nil {
+			return nil
+		}
+
+		vars, _, err := client.Variables().PrefixList(a.Last, nil)
+		if err != nil {
+			return []string{}
+		}
+
+		paths := make([]string, len(vars))
+		for i, v := range vars {
+			paths[i] = v.Path
+		}
+		return paths
+	})
+}
+
+// formatVariableMetadata formats a list of variables for display.
+func formatVariableMetadata(vars []*api.VariableMetadata, showNamespace bool) string {
+	if len(vars) == 0 {
+		return "No variables found"
+	}
+
+	// Sort the output by namespace and path
+	sort.Slice(vars, func(i, j int) bool {
+		if vars[i].Namespace != vars[j].Namespace {
+			return vars[i].Namespace < vars[j].Namespace
+		}
+		return vars[i].Path < vars[j].Path
+	})
+
+	rows := make([]string, len(vars)+1)
+	if showNamespace {
+		rows[0] = "Namespace|Path|Last Updated"
+	} else {
+		rows[0] = "Path|Last Updated"
+	}
+	for i, v := range vars {
+		if showNamespace {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s",
+				v.Namespace, v.Path, formatUnixNanoTime(v.ModifyTime))
+		} else {
+			rows[i+1] = fmt.Sprintf("%s|%s",
+				v.Path, formatUnixNanoTime(v.ModifyTime))
+		}
+	}
+	return formatList(rows)
+}
+
+// formatVariable formats a variable and its items for display.
+func formatVariable(v *api.Variable) string {
+	out := []string{
+		fmt.Sprintf("Namespace|%s", v.Namespace),
+		fmt.Sprintf("Path|%s", v.Path),
+		fmt.Sprintf("Create Time|%s", formatUnixNanoTime(v.CreateTime)),
+		fmt.Sprintf("Modify Time|%s", formatUnixNanoTime(v.ModifyTime)),
+		fmt.Sprintf("Check Index|%d", v.ModifyIndex),
+	}
+
+	keys := make([]string, 0, len(v.Items))
+	for k := range v.Items {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	items := make([]string, len(keys))
+	for i, k := range keys {
+		items[i] = fmt.Sprintf("%s|%s", k, v.Items[k])
+	}
+
+	return fmt.Sprintf("%s\n\n[bold]Items[reset]\n%s", formatKV(out), formatKV(items))
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarGetCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarGetCommand{}
+}
+
+func TestVarGetCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on conflicting flags
+	code = cmd.Run([]string{"-item=foo", "-json", "some/path"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "cannot be used with")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "some/path"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error retrieving variable")
+}
+
+func TestVarGetCommand_Good(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarGetCommand{Meta: Meta{Ui: ui}}
+
+	// Create a variable to read, once the keyring has been initialized.
+	sv := api.NewVariable("test/var")
+	sv.Items["k1"] = "v1"
+	testutil.WaitForResult(func() (bool, error) {
+		_, _, err := client.Variables().Create(sv, nil)
+		return err == nil, err
+	}, func(err error) {
+		t.Fatalf("failed to create variable: %v", err)
+	})
+
+	code := cmd.Run([]string{"-address=" + url, "test/var"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "test/var")
+	require.Contains(t, out, "k1")
+	require.Contains(t, out, "v1")
+	ui.OutputWriter.Reset()
+
+	// Output a single item
+	code = cmd.Run([]string{"-address=" + url, "-item=k1", "test/var"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Equal(t, "v1\n", ui.OutputWriter.String())
+	ui.OutputWriter.Reset()
+
+	// Fails on missing variables
+	code = cmd.Run([]string{"-address=" + url, "does/not/exist"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "not found")
+}
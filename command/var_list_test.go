@@ -0,0 +1,69 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarListCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarListCommand{}
+}
+
+func TestVarListCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarListCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error retrieving variables")
+}
+
+func TestVarListCommand_Good(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarListCommand{Meta: Meta{Ui: ui}}
+
+	// No variables exist yet
+	code := cmd.Run([]string{"-address=" + url})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), "No variables found")
+	ui.OutputWriter.Reset()
+
+	// Create some variables, once the keyring has been initialized.
+	for _, path := range []string{"a/one", "a/two", "b/three"} {
+		sv := api.NewVariable(path)
+		sv.Items["k"] = "v"
+		testutil.WaitForResult(func() (bool, error) {
+			_, _, err := client.Variables().Create(sv, nil)
+			return err == nil, err
+		}, func(err error) {
+			t.Fatalf("failed to create variable: %v", err)
+		})
+	}
+
+	code = cmd.Run([]string{"-address=" + url, "a/"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	out := ui.OutputWriter.String()
+	require.Contains(t, out, "a/one")
+	require.Contains(t, out, "a/two")
+	require.NotContains(t, out, "b/three")
+}
@@ -0,0 +1,70 @@
+package command
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/nomad/api"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPurgeCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPurgeCommand{}
+}
+
+func TestVarPurgeCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some", "bad", "args"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "some/path"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error purging variable")
+}
+
+func TestVarPurgeCommand_Good(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarPurgeCommand{Meta: Meta{Ui: ui}}
+
+	// Create a variable to purge, once the keyring has been initialized.
+	sv := api.NewVariable("test/var")
+	sv.Items["k1"] = "v1"
+	var out *api.Variable
+	testutil.WaitForResult(func() (bool, error) {
+		var err error
+		out, _, err = client.Variables().Create(sv, nil)
+		return err == nil, err
+	}, func(err error) {
+		t.Fatalf("failed to create variable: %v", err)
+	})
+
+	// A stale check index results in a conflict.
+	code := cmd.Run([]string{"-address=" + url, "-check-index=1", "test/var"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Check-index conflict")
+	ui.ErrorWriter.Reset()
+
+	code = cmd.Run([]string{"-address=" + url, fmt.Sprintf("-check-index=%d", out.ModifyIndex), "test/var"})
+	require.Equal(t, 0, code, ui.ErrorWriter.String())
+	require.Contains(t, ui.OutputWriter.String(), `Successfully purged variable "test/var"`)
+
+	_, _, err := client.Variables().Read("test/var", nil)
+	require.ErrorIs(t, err, api.ErrVariablePathNotFound)
+}
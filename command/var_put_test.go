@@ -0,0 +1,77 @@
+package command
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVarPutCommand_Implements(t *testing.T) {
+	ci.Parallel(t)
+	var _ cli.Command = &VarPutCommand{}
+}
+
+func TestVarPutCommand_Fails(t *testing.T) {
+	ci.Parallel(t)
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+
+	// Fails on misuse
+	code := cmd.Run([]string{"some/path"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), commandErrorText(cmd))
+	ui.ErrorWriter.Reset()
+
+	// Fails on malformed items
+	code = cmd.Run([]string{"some/path", "novalue"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "must be in the form")
+	ui.ErrorWriter.Reset()
+
+	// Fails on a malformed check index
+	code = cmd.Run([]string{"-check-index=foo", "some/path", "k=v"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error parsing check-index")
+	ui.ErrorWriter.Reset()
+
+	// Fails on connection failure
+	code = cmd.Run([]string{"-address=nope", "some/path", "k=v"})
+	require.Equal(t, 1, code)
+	require.Contains(t, ui.ErrorWriter.String(), "Error writing variable")
+}
+
+func TestVarPutCommand_Good(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create a server
+	srv, client, url := testServer(t, true, nil)
+	defer srv.Shutdown()
+
+	ui := cli.NewMockUi()
+	cmd := &VarPutCommand{Meta: Meta{Ui: ui}}
+
+	// Writing requires the keyring to be initialized by the leader.
+	testutil.WaitForResult(func() (bool, error) {
+		ui.ErrorWriter.Reset()
+		code := cmd.Run([]string{"-address=" + url, "test/var", "k1=v1", "k2=a=b"})
+		return code == 0, nil
+	}, func(err error) {
+		t.Fatalf("failed to write variable: %s", ui.ErrorWriter.String())
+	})
+	require.Contains(t, ui.OutputWriter.String(), `Successfully wrote variable "test/var"`)
+
+	sv, _, err := client.Variables().Read("test/var", nil)
+	require.NoError(t, err)
+	require.Equal(t, "v1", sv.Items["k1"])
+	require.Equal(t, "a=b", sv.Items["k2"])
+
+	// A stale check index results in a conflict.
+	ui.OutputWriter.Reset()
+	code := cmd.Run([]string{"-address=" + url, "-check-index=1", "test/var", "k1=v2"})
+	require.Equal(t, 1, code)
+	require.True(t, strings.Contains(ui.ErrorWriter.String(), "Check-index conflict"))
+}
@@ -0,0 +1,50 @@
+package exec
+
+import (
+	"os/exec"
+	"regexp"
+)
+
+// criuVersionRe matches the version printed by criu --version, such as
+// "Version: 3.16.1".
+var criuVersionRe = regexp.MustCompile(`Version: ([0-9][0-9a-zA-Z.\-]*)`)
+
+// detectCRIUVersion returns the version of the criu binary in the PATH, which
+// checkpoints and restores the processes of tasks, or an empty string if it
+// isn't installed or can't be run.
+func detectCRIUVersion() string {
+	path, err := exec.LookPath("criu")
+	if err != nil {
+		return ""
+	}
+	out, err := exec.Command(path, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return parseCRIUVersion(string(out))
+}
+
+// parseCRIUVersion returns the version in the output of criu --version, or an
+// empty string if there is none.
+func parseCRIUVersion(out string) string {
+	m := criuVersionRe.FindStringSubmatch(out)
+	if m == nil {
+		return ""
+	}
+	return m[1]
+}
+
+// setCRIUVersion records the version of CRIU found by the last fingerprint.
+func (d *Driver) setCRIUVersion(version string) {
+	d.fingerprintLock.Lock()
+	d.criuVersion = version
+	d.fingerprintLock.Unlock()
+}
+
+// checkpointSupported returns whether the last fingerprint found CRIU, which
+// is required to checkpoint and restore tasks.
+func (d *Driver) checkpointSupported() bool {
+	d.fingerprintLock.Lock()
+	defer d.fingerprintLock.Unlock()
+	return d.criuVersion != ""
+}
@@ -0,0 +1,32 @@
+package exec
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCRIUVersion(t *testing.T) {
+	ci.Parallel(t)
+
+	require.Equal(t, "3.16.1", parseCRIUVersion("Version: 3.16.1\n"))
+	require.Equal(t, "3.17", parseCRIUVersion("Version: 3.17\nGitID: v3.17\n"))
+	require.Equal(t, "", parseCRIUVersion("criu: unknown option\n"))
+}
+
+func TestDriver_Capabilities_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	d := &Driver{}
+	caps, err := d.Capabilities()
+	require.NoError(t, err)
+	require.False(t, caps.Checkpoint)
+
+	// Checkpointing is only advertised once a fingerprint found CRIU
+	d.setCRIUVersion("3.16.1")
+	caps, err = d.Capabilities()
+	require.NoError(t, err)
+	require.True(t, caps.Checkpoint)
+	require.False(t, driverCapabilities.Checkpoint)
+}
@@ -3,6 +3,7 @@ package exec
 import (
 	"context"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -78,17 +79,20 @@ var (
 			hclspec.NewAttr("allow_caps", "list(string)", false),
 			hclspec.NewLiteral(capabilities.HCLSpecLiteral),
 		),
+		"user_namespace_id_start": hclspec.NewAttr("user_namespace_id_start", "number", false),
+		"user_namespace_id_count": hclspec.NewAttr("user_namespace_id_count", "number", false),
 	})
 
 	// taskConfigSpec is the hcl specification for the driver config section of
 	// a task within a job. It is returned in the TaskConfigSchema RPC
 	taskConfigSpec = hclspec.NewObject(map[string]*hclspec.Spec{
-		"command":  hclspec.NewAttr("command", "string", true),
-		"args":     hclspec.NewAttr("args", "list(string)", false),
-		"pid_mode": hclspec.NewAttr("pid_mode", "string", false),
-		"ipc_mode": hclspec.NewAttr("ipc_mode", "string", false),
-		"cap_add":  hclspec.NewAttr("cap_add", "list(string)", false),
-		"cap_drop": hclspec.NewAttr("cap_drop", "list(string)", false),
+		"command":        hclspec.NewAttr("command", "string", true),
+		"args":           hclspec.NewAttr("args", "list(string)", false),
+		"pid_mode":       hclspec.NewAttr("pid_mode", "string", false),
+		"ipc_mode":       hclspec.NewAttr("ipc_mode", "string", false),
+		"cap_add":        hclspec.NewAttr("cap_add", "list(string)", false),
+		"cap_drop":       hclspec.NewAttr("cap_drop", "list(string)", false),
+		"user_namespace": hclspec.NewAttr("user_namespace", "bool", false),
 	})
 
 	// driverCapabilities represents the RPC response for what features are
@@ -128,10 +132,17 @@ type Driver struct {
 	// logger will log to the Nomad agent
 	logger hclog.Logger
 
+	// userNamespaces allocates the host ids of the user namespaces of tasks
+	userNamespaces *idRangePool
+
 	// A tri-state boolean to know if the fingerprinting has happened and
 	// whether it has been successful
 	fingerprintSuccess *bool
 	fingerprintLock    sync.Mutex
+
+	// criuVersion is the version of CRIU found by the last fingerprint, or
+	// empty if tasks can't be checkpointed
+	criuVersion string
 }
 
 // Config is the driver configuration set by the SetConfig RPC call
@@ -151,6 +162,27 @@ type Config struct {
 	// AllowCaps configures which Linux Capabilities are enabled for tasks
 	// running on this node.
 	AllowCaps []string `codec:"allow_caps"`
+
+	// UserNamespaceIDStart is the first of the host uids and gids the user
+	// namespaces of tasks are mapped to.
+	UserNamespaceIDStart int64 `codec:"user_namespace_id_start"`
+
+	// UserNamespaceIDCount is the number of host uids and gids the user
+	// namespaces of tasks are mapped to, in ranges of 65536 ids per task.
+	UserNamespaceIDCount int64 `codec:"user_namespace_id_count"`
+}
+
+// userNamespaceIDs returns the range of host ids the user namespaces of tasks
+// are mapped to, defaulting the unset bounds.
+func (c *Config) userNamespaceIDs() (int64, int64) {
+	start, count := c.UserNamespaceIDStart, c.UserNamespaceIDCount
+	if start == 0 {
+		start = defaultUserNamespaceIDStart
+	}
+	if count == 0 {
+		count = defaultUserNamespaceIDCount
+	}
+	return start, count
 }
 
 func (c *Config) validate() error {
@@ -171,6 +203,17 @@ func (c *Config) validate() error {
 		return fmt.Errorf("allow_caps configured with capabilities not supported by system: %s", badCaps)
 	}
 
+	start, count := c.userNamespaceIDs()
+	if start < 1 {
+		return fmt.Errorf("user_namespace_id_start must be greater than 0, got %d", start)
+	}
+	if count < userNamespaceSize {
+		return fmt.Errorf("user_namespace_id_count must be at least %d, got %d", userNamespaceSize, count)
+	}
+	if start+count > math.MaxUint32 {
+		return fmt.Errorf("user_namespace_id_start and user_namespace_id_count must be within the range of uids, got %d ids from %d", count, start)
+	}
+
 	return nil
 }
 
@@ -195,6 +238,10 @@ type TaskConfig struct {
 
 	// CapDrop is a set of linux capabilities to disable.
 	CapDrop []string `codec:"cap_drop"`
+
+	// UserNamespace runs the task in a user namespace, mapping its uids and
+	// gids to a range of unprivileged host ids.
+	UserNamespace bool `codec:"user_namespace"`
 }
 
 func (tc *TaskConfig) validate() error {
@@ -231,16 +278,18 @@ type TaskState struct {
 	TaskConfig     *drivers.TaskConfig
 	Pid            int
 	StartedAt      time.Time
+	UserNamespace  *executor.UserNamespace
 }
 
 // NewExecDriver returns a new DrivePlugin implementation
 func NewExecDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugin {
 	logger = logger.Named(pluginName)
 	return &Driver{
-		eventer: eventer.NewEventer(ctx, logger),
-		tasks:   newTaskStore(),
-		ctx:     ctx,
-		logger:  logger,
+		eventer:        eventer.NewEventer(ctx, logger),
+		tasks:          newTaskStore(),
+		ctx:            ctx,
+		logger:         logger,
+		userNamespaces: newIDRangePool(defaultUserNamespaceIDStart, defaultUserNamespaceIDCount),
 	}
 }
 
@@ -285,7 +334,18 @@ func (d *Driver) SetConfig(cfg *base.Config) error {
 	if err := config.validate(); err != nil {
 		return err
 	}
+
+	// The ids are allocated by the driver, so they must not be subordinate
+	// ids the host allocated to its users
+	start, count := config.userNamespaceIDs()
+	for _, path := range []string{subUIDFile, subGIDFile} {
+		if err := checkSubordinateIDs(path, start, count); err != nil {
+			return err
+		}
+	}
+
 	d.config = config
+	d.userNamespaces = newIDRangePool(uint32(start), uint32(count))
 
 	if cfg != nil && cfg.AgentConfig != nil {
 		d.nomadConfig = cfg.AgentConfig.Driver
@@ -300,7 +360,9 @@ func (d *Driver) TaskConfigSchema() (*hclspec.Spec, error) {
 // Capabilities is returned by the Capabilities RPC and indicates what
 // optional features this driver supports
 func (d *Driver) Capabilities() (*drivers.Capabilities, error) {
-	return driverCapabilities, nil
+	caps := *driverCapabilities
+	caps.Checkpoint = d.checkpointSupported()
+	return &caps, nil
 }
 
 func (d *Driver) Fingerprint(ctx context.Context) (<-chan *drivers.Fingerprint, error) {
@@ -366,6 +428,14 @@ func (d *Driver) buildFingerprint() *drivers.Fingerprint {
 	}
 
 	fp.Attributes["driver.exec"] = pstructs.NewBoolAttribute(true)
+	fp.Attributes["driver.exec.user_namespace"] = pstructs.NewBoolAttribute(userNamespacesSupported())
+
+	criuVersion := detectCRIUVersion()
+	d.setCRIUVersion(criuVersion)
+	fp.Attributes["driver.exec.checkpoint"] = pstructs.NewBoolAttribute(criuVersion != "")
+	if criuVersion != "" {
+		fp.Attributes["driver.exec.criu.version"] = pstructs.NewStringAttribute(criuVersion)
+	}
 	d.setFingerprintSuccess()
 	return fp
 }
@@ -405,6 +475,14 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 		return fmt.Errorf("failed to reattach to executor: %v", err)
 	}
 
+	// The ids of the task's user namespace must not be allocated to another
+	// task, but the task keeps running if they're no longer configured
+	if taskState.UserNamespace != nil {
+		if err := d.userNamespaces.reserve(handle.Config.ID, taskState.UserNamespace); err != nil {
+			d.logger.Warn("failed to reserve user namespace ids of recovered task", "error", err, "task_id", handle.Config.ID)
+		}
+	}
+
 	h := &taskHandle{
 		exec:         exec,
 		pid:          taskState.Pid,
@@ -423,6 +501,18 @@ func (d *Driver) RecoverTask(handle *drivers.TaskHandle) error {
 }
 
 func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, "")
+}
+
+// RestoreTask starts the task by restoring its process from the checkpoint
+// images in imageDir.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, imageDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	return d.startTask(cfg, imageDir)
+}
+
+// startTask launches the task, or restores it from the checkpoint images in
+// imageDir if set.
+func (d *Driver) startTask(cfg *drivers.TaskConfig, imageDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
 	if _, ok := d.tasks.Get(cfg.ID); ok {
 		return nil, nil, fmt.Errorf("task with ID %q already started", cfg.ID)
 	}
@@ -475,6 +565,12 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 	}
 	d.logger.Debug("task capabilities", "capabilities", caps)
 
+	userNamespace, err := d.userNamespace(cfg, &driverConfig)
+	if err != nil {
+		pluginClient.Kill()
+		return nil, nil, err
+	}
+
 	execCmd := &executor.ExecCommand{
 		Cmd:              driverConfig.Command,
 		Args:             driverConfig.Args,
@@ -492,12 +588,25 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		ModePID:          executor.IsolationMode(d.config.DefaultModePID, driverConfig.ModePID),
 		ModeIPC:          executor.IsolationMode(d.config.DefaultModeIPC, driverConfig.ModeIPC),
 		Capabilities:     caps,
+		Checkpointable:   cfg.Checkpointable,
+		UserNamespace:    userNamespace,
 	}
 
-	ps, err := exec.Launch(execCmd)
-	if err != nil {
-		pluginClient.Kill()
-		return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
+	var ps *executor.ProcessState
+	if imageDir != "" {
+		ps, err = exec.Restore(execCmd, imageDir)
+		if err != nil {
+			pluginClient.Kill()
+			d.userNamespaces.release(cfg.ID)
+			return nil, nil, fmt.Errorf("failed to restore command with executor: %v", err)
+		}
+	} else {
+		ps, err = exec.Launch(execCmd)
+		if err != nil {
+			pluginClient.Kill()
+			d.userNamespaces.release(cfg.ID)
+			return nil, nil, fmt.Errorf("failed to launch command with executor: %v", err)
+		}
 	}
 
 	h := &taskHandle{
@@ -515,12 +624,14 @@ func (d *Driver) StartTask(cfg *drivers.TaskConfig) (*drivers.TaskHandle, *drive
 		Pid:            ps.Pid,
 		TaskConfig:     cfg,
 		StartedAt:      h.startedAt,
+		UserNamespace:  userNamespace,
 	}
 
 	if err := handle.SetDriverState(&driverState); err != nil {
 		d.logger.Error("failed to start task, error setting driver state", "error", err)
 		_ = exec.Shutdown("", 0)
 		pluginClient.Kill()
+		d.userNamespaces.release(cfg.ID)
 		return nil, nil, fmt.Errorf("failed to set driver state: %v", err)
 	}
 
@@ -565,6 +676,21 @@ func (d *Driver) handleWait(ctx context.Context, handle *taskHandle, ch chan *dr
 	}
 }
 
+// CheckpointTask checkpoints the process of the task into imageDir with CRIU,
+// stopping it.
+func (d *Driver) CheckpointTask(taskID string, imageDir string) error {
+	handle, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := handle.exec.Checkpoint(imageDir); err != nil {
+		return fmt.Errorf("executor Checkpoint failed: %v", err)
+	}
+
+	return nil
+}
+
 func (d *Driver) StopTask(taskID string, timeout time.Duration, signal string) error {
 	handle, ok := d.tasks.Get(taskID)
 	if !ok {
@@ -621,6 +747,7 @@ func (d *Driver) DestroyTask(taskID string, force bool) error {
 	// workaround for the case where DestroyTask was issued on task restart
 	d.resetCgroup(handle)
 
+	d.userNamespaces.release(taskID)
 	d.tasks.Delete(taskID)
 	return nil
 }
@@ -691,6 +818,7 @@ func (d *Driver) ExecTask(taskID string, cmd []string, timeout time.Duration) (*
 }
 
 var _ drivers.ExecTaskStreamingRawDriver = (*Driver)(nil)
+var _ drivers.DriverCheckpointer = (*Driver)(nil)
 
 func (d *Driver) ExecTaskStreamingRaw(ctx context.Context,
 	taskID string,
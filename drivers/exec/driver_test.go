@@ -516,6 +516,69 @@ func TestExecDriver_Start_Wait_AllocDir(t *testing.T) {
 	require.NoError(t, harness.DestroyTask(task.ID, true))
 }
 
+func TestExecDriver_UserNamespace(t *testing.T) {
+	ci.Parallel(t)
+	ctestutils.ExecCompatible(t)
+	if !userNamespacesSupported() {
+		t.Skip("user namespaces not supported by the kernel")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewExecDriver(ctx, testlog.HCLogger(t))
+	harness := dtestutil.NewDriverHarness(t, d)
+	allocID := uuid.Generate()
+	task := &drivers.TaskConfig{
+		AllocID:   allocID,
+		ID:        uuid.Generate(),
+		Name:      "userns",
+		Resources: testResources(allocID, "userns"),
+	}
+	cleanup := harness.MkAllocDir(task, false)
+	defer cleanup()
+
+	// The root of the user namespace must be able to traverse the alloc dir,
+	// as with the client's alloc dir
+	require.NoError(t, os.Chmod(filepath.Dir(task.AllocDir), 0711))
+
+	tc := &TaskConfig{
+		Command:       "/bin/sh",
+		Args:          []string{"-c", "cat /proc/self/uid_map > /local/uid_map"},
+		UserNamespace: true,
+	}
+	require.NoError(t, task.EncodeConcreteDriverConfig(&tc))
+
+	handle, _, err := harness.StartTask(task)
+	require.NoError(t, err)
+	require.NotNil(t, handle)
+
+	waitCh, err := harness.WaitTask(context.Background(), task.ID)
+	require.NoError(t, err)
+	select {
+	case res := <-waitCh:
+		require.True(t, res.Successful(), "task should have exited successfully: %v", res)
+	case <-time.After(time.Duration(testutil.TestMultiplier()*5) * time.Second):
+		require.Fail(t, "timeout waiting for task")
+	}
+
+	// The ids of the task are mapped to the first range of host ids, which
+	// own the task's local dir
+	uidMap, err := ioutil.ReadFile(filepath.Join(task.TaskDir().LocalDir, "uid_map"))
+	require.NoError(t, err)
+	require.Equal(t, []string{"0", "1000000000", "65536"}, strings.Fields(string(uidMap)))
+
+	fi, err := os.Stat(task.TaskDir().LocalDir)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1000000000), fi.Sys().(*syscall.Stat_t).Uid)
+
+	// The ids are released with the task
+	require.NoError(t, harness.DestroyTask(task.ID, true))
+	ns, err := d.(*Driver).userNamespaces.allocate("other")
+	require.NoError(t, err)
+	require.Equal(t, uint32(1000000000), ns.HostID)
+}
+
 func TestExecDriver_User(t *testing.T) {
 	ci.Parallel(t)
 	ctestutils.ExecCompatible(t)
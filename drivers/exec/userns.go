@@ -0,0 +1,186 @@
+package exec
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/hashicorp/nomad/plugins/drivers"
+)
+
+const (
+	// subUIDFile and subGIDFile are the files allocating the subordinate
+	// uids and gids of the users of the host.
+	subUIDFile = "/etc/subuid"
+	subGIDFile = "/etc/subgid"
+
+	// userNamespaceSize is the number of uids and gids mapped in the user
+	// namespace of each task, covering the ids of the users of the chroot
+	// such as nobody.
+	userNamespaceSize = 65536
+
+	// defaultUserNamespaceIDStart is the first of the host ids the user
+	// namespaces of tasks are mapped to by default, above the subordinate ids
+	// usually allocated to the users of the host.
+	defaultUserNamespaceIDStart = 1000000000
+
+	// defaultUserNamespaceIDCount is the number of host ids the user
+	// namespaces of tasks are mapped to by default, allowing for 1024 tasks.
+	defaultUserNamespaceIDCount = 1024 * userNamespaceSize
+)
+
+// userNamespacesSupported returns whether the kernel allows creating user
+// namespaces.
+func userNamespacesSupported() bool {
+	if _, err := os.Stat("/proc/self/ns/user"); err != nil {
+		return false
+	}
+
+	// The number of user namespaces may be limited to none
+	data, err := ioutil.ReadFile("/proc/sys/user/max_user_namespaces")
+	if err != nil {
+		return os.IsNotExist(err)
+	}
+	max, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return err != nil || max > 0
+}
+
+// userNamespace allocates the host ids of the user namespace of the task if
+// it runs in one. Tasks requiring a user namespace fail to start if the kernel
+// doesn't support user namespaces, rather than running without one.
+func (d *Driver) userNamespace(cfg *drivers.TaskConfig, driverConfig *TaskConfig) (*executor.UserNamespace, error) {
+	if !driverConfig.UserNamespace {
+		return nil, nil
+	}
+
+	if !userNamespacesSupported() {
+		return nil, fmt.Errorf("user_namespace is set but user namespaces aren't supported by the kernel; " +
+			"constrain the job on ${attr.driver.exec.user_namespace}")
+	}
+
+	return d.userNamespaces.allocate(cfg.ID)
+}
+
+// checkSubordinateIDs returns an error if the count ids starting from start
+// overlap the subordinate ids allocated to the users of the host in path, in
+// the /etc/subuid format. A missing file allocates no ids.
+func checkSubordinateIDs(path string, start, count int64) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read subordinate ids: %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.Split(line, ":")
+		if len(parts) != 3 {
+			continue
+		}
+		subStart, err1 := strconv.ParseInt(parts[1], 10, 64)
+		subCount, err2 := strconv.ParseInt(parts[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if subStart < start+count && start < subStart+subCount {
+			return fmt.Errorf("user namespace ids %d to %d overlap the subordinate ids of %q in %s; "+
+				"set user_namespace_id_start and user_namespace_id_count to a range unused by the host",
+				start, start+count-1, parts[0], path)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read subordinate ids: %v", err)
+	}
+	return nil
+}
+
+// idRangePool allocates the ranges of host uids and gids mapped in the user
+// namespaces of tasks from the ids configured for the driver. The pool is local
+// to the driver, and doesn't allocate ids from /etc/subuid or /etc/subgid.
+type idRangePool struct {
+	start uint32
+	count uint32
+
+	// ranges maps the IDs of the tasks to the index of their range
+	ranges map[string]uint32
+	used   map[uint32]struct{}
+	lock   sync.Mutex
+}
+
+// newIDRangePool returns a pool of the ranges within the count ids starting
+// from start.
+func newIDRangePool(start, count uint32) *idRangePool {
+	return &idRangePool{
+		start:  start,
+		count:  count / userNamespaceSize,
+		ranges: make(map[string]uint32),
+		used:   make(map[uint32]struct{}),
+	}
+}
+
+// allocate returns an unused range for the task.
+func (p *idRangePool) allocate(taskID string) (*executor.UserNamespace, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if idx, ok := p.ranges[taskID]; ok {
+		return p.userNamespace(idx), nil
+	}
+
+	for idx := uint32(0); idx < p.count; idx++ {
+		if _, ok := p.used[idx]; ok {
+			continue
+		}
+		p.ranges[taskID] = idx
+		p.used[idx] = struct{}{}
+		return p.userNamespace(idx), nil
+	}
+	return nil, fmt.Errorf("no user namespace ids left: all %d ranges are used", p.count)
+}
+
+// reserve marks the range of a recovered task as used.
+func (p *idRangePool) reserve(taskID string, ns *executor.UserNamespace) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if ns.HostID < p.start || (ns.HostID-p.start)%userNamespaceSize != 0 {
+		return fmt.Errorf("user namespace ids starting at %d aren't a range of the driver", ns.HostID)
+	}
+	idx := (ns.HostID - p.start) / userNamespaceSize
+	if idx >= p.count {
+		return fmt.Errorf("user namespace ids starting at %d aren't a range of the driver", ns.HostID)
+	}
+
+	p.ranges[taskID] = idx
+	p.used[idx] = struct{}{}
+	return nil
+}
+
+// release frees the range of the task, if any.
+func (p *idRangePool) release(taskID string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if idx, ok := p.ranges[taskID]; ok {
+		delete(p.ranges, taskID)
+		delete(p.used, idx)
+	}
+}
+
+func (p *idRangePool) userNamespace(idx uint32) *executor.UserNamespace {
+	return &executor.UserNamespace{
+		HostID: p.start + idx*userNamespaceSize,
+		Size:   userNamespaceSize,
+	}
+}
@@ -0,0 +1,108 @@
+package exec
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/drivers/shared/executor"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDRangePool(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := newIDRangePool(100000, 3*userNamespaceSize)
+
+	a, err := pool.allocate("a")
+	require.NoError(t, err)
+	require.Equal(t, &executor.UserNamespace{HostID: 100000, Size: userNamespaceSize}, a)
+
+	// Allocating the range of a task again returns the same range
+	again, err := pool.allocate("a")
+	require.NoError(t, err)
+	require.Equal(t, a, again)
+
+	// Recovered tasks keep their range
+	require.NoError(t, pool.reserve("c", &executor.UserNamespace{HostID: 100000 + 2*userNamespaceSize, Size: userNamespaceSize}))
+
+	b, err := pool.allocate("b")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000+userNamespaceSize), b.HostID)
+
+	_, err = pool.allocate("d")
+	require.Error(t, err)
+
+	// Released ranges are allocated again
+	pool.release("a")
+	d, err := pool.allocate("d")
+	require.NoError(t, err)
+	require.Equal(t, uint32(100000), d.HostID)
+}
+
+func TestIDRangePool_Reserve_Invalid(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := newIDRangePool(100000, 2*userNamespaceSize)
+
+	for _, hostID := range []uint32{0, 100001, 100000 + 2*userNamespaceSize} {
+		ns := &executor.UserNamespace{HostID: hostID, Size: userNamespaceSize}
+		require.Error(t, pool.reserve("a", ns), "host id %d", hostID)
+	}
+}
+
+func TestConfig_validate_UserNamespaceIDs(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name  string
+		start int64
+		count int64
+		err   string
+	}{
+		{name: "defaults"},
+		{name: "custom", start: 200000, count: 2 * userNamespaceSize},
+		{name: "negative start", start: -1, err: "user_namespace_id_start must be greater than 0"},
+		{name: "small count", count: 1000, err: "user_namespace_id_count must be at least 65536"},
+		{name: "past max uid", start: 4294000000, err: "must be within the range of uids"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{
+				DefaultModePID:       executor.IsolationModePrivate,
+				DefaultModeIPC:       executor.IsolationModePrivate,
+				UserNamespaceIDStart: tc.start,
+				UserNamespaceIDCount: tc.count,
+			}
+			err := c.validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
+func TestCheckSubordinateIDs(t *testing.T) {
+	ci.Parallel(t)
+
+	path := filepath.Join(t.TempDir(), "subuid")
+	require.NoError(t, ioutil.WriteFile(path, []byte("# comment\nalice:100000:65536\nbob:165536:65536\n"), 0644))
+
+	// Ranges apart from the subordinate ids are allowed
+	require.NoError(t, checkSubordinateIDs(path, 1000000000, 65536))
+	require.NoError(t, checkSubordinateIDs(path, 231072, 65536))
+	require.NoError(t, checkSubordinateIDs(path, 34464, 65536))
+
+	// Overlapping ranges are rejected
+	err := checkSubordinateIDs(path, 200000, 65536)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `"bob"`)
+	require.Error(t, checkSubordinateIDs(path, 0, 1000000))
+
+	// A missing file allocates no ids
+	require.NoError(t, checkSubordinateIDs(filepath.Join(t.TempDir(), "missing"), 0, 1000000))
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -153,6 +155,7 @@ func NewMockDriver(ctx context.Context, logger hclog.Logger) drivers.DriverPlugi
 		Exec:         true,
 		FSIsolation:  drivers.FSIsolationNone,
 		MountConfigs: drivers.MountConfigSupportNone,
+		Checkpoint:   true,
 	}
 
 	return &Driver{
@@ -670,6 +673,52 @@ func (d *Driver) GetHandle(taskID string) *taskHandle {
 	return h
 }
 
+var _ drivers.DriverCheckpointer = (*Driver)(nil)
+
+// checkpointFile is the file written by CheckpointTask in the image dir.
+const checkpointFile = "mock-checkpoint"
+
+// CheckpointTask kills the task after writing its name to a file in imageDir.
+func (d *Driver) CheckpointTask(taskID string, imageDir string) error {
+	h, ok := d.tasks.Get(taskID)
+	if !ok {
+		return drivers.ErrTaskNotFound
+	}
+
+	if err := os.MkdirAll(imageDir, 0700); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(imageDir, checkpointFile), []byte(h.taskConfig.Name), 0600); err != nil {
+		return err
+	}
+
+	d.logger.Debug("checkpointing task", "task_name", h.taskConfig.Name)
+	h.kill()
+	<-h.waitCh
+	return nil
+}
+
+// RestoreTask starts the task if imageDir holds the file written by
+// CheckpointTask for it.
+func (d *Driver) RestoreTask(cfg *drivers.TaskConfig, imageDir string) (*drivers.TaskHandle, *drivers.DriverNetwork, error) {
+	name, err := ioutil.ReadFile(filepath.Join(imageDir, checkpointFile))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	if string(name) != cfg.Name {
+		return nil, nil, fmt.Errorf("checkpoint of task %q can't be restored as task %q", name, cfg.Name)
+	}
+
+	handle, net, err := d.StartTask(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if h, ok := d.tasks.Get(cfg.ID); ok {
+		h.Restored = true
+	}
+	return handle, net, nil
+}
+
 var _ drivers.DriverNetworkManager = (*Driver)(nil)
 
 func (d *Driver) CreateNetwork(allocID string, request *drivers.NetworkCreateRequest) (*drivers.NetworkIsolationSpec, bool, error) {
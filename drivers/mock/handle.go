@@ -36,6 +36,10 @@ type taskHandle struct {
 
 	// Recovered is set to true if the handle was created while being recovered
 	Recovered bool
+
+	// Restored is set to true if the handle was created while being restored
+	// from a checkpoint
+	Restored bool
 }
 
 func (h *taskHandle) TaskStatus() *drivers.TaskStatus {
@@ -2,6 +2,7 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -47,6 +48,10 @@ var (
 	// The statistics the basic executor exposes
 	ExecutorBasicMeasuredMemStats = []string{"RSS", "Swap"}
 	ExecutorBasicMeasuredCpuStats = []string{"System Mode", "User Mode", "Percent"}
+
+	// ErrCheckpointNotSupported is returned by executors which can't
+	// checkpoint and restore processes
+	ErrCheckpointNotSupported = errors.New("checkpointing is not supported by this executor")
 )
 
 // Executor is the interface which allows a driver to launch and supervise
@@ -88,6 +93,15 @@ type Executor interface {
 
 	ExecStreaming(ctx context.Context, cmd []string, tty bool,
 		stream drivers.ExecTaskStream) error
+
+	// Checkpoint writes the checkpoint images of the user process to the
+	// given directory, which stops the process. The process must have been
+	// launched with a checkpointable ExecCommand.
+	Checkpoint(imageDir string) error
+
+	// Restore a user process configured by the given ExecCommand from the
+	// checkpoint images in the given directory instead of launching it
+	Restore(restoreCmd *ExecCommand, imageDir string) (*ProcessState, error)
 }
 
 // ExecCommand holds the user command, args, and other isolation related
@@ -154,6 +168,26 @@ type ExecCommand struct {
 
 	// Capabilities are the linux capabilities to be enabled by the task driver.
 	Capabilities []string
+
+	// Checkpointable is set if the process may be checkpointed, which
+	// connects its stdout and stderr to pipes as CRIU can't reconnect the
+	// restored process to the fifos.
+	Checkpointable bool
+
+	// UserNamespace runs the process in a user namespace mapping its uids
+	// and gids to a range of host ids if set. It is only supported by the
+	// libcontainer executor.
+	UserNamespace *UserNamespace
+}
+
+// UserNamespace is the range of host uids and gids the uids and gids of a
+// process are mapped to in its user namespace, starting from 0 inside it.
+type UserNamespace struct {
+	// HostID is the first host uid and gid of the range
+	HostID uint32
+
+	// Size is the number of ids in the range
+	Size uint32
 }
 
 // SetWriters sets the writer for the process stdout and stderr. This should
@@ -595,6 +629,18 @@ func (e *UniversalExecutor) Shutdown(signal string, grace time.Duration) error {
 	return nil
 }
 
+// Checkpoint isn't supported by the universal executor, whose processes
+// aren't isolated in containers.
+func (e *UniversalExecutor) Checkpoint(imageDir string) error {
+	return ErrCheckpointNotSupported
+}
+
+// Restore isn't supported by the universal executor, whose processes aren't
+// isolated in containers.
+func (e *UniversalExecutor) Restore(command *ExecCommand, imageDir string) (*ProcessState, error) {
+	return nil, ErrCheckpointNotSupported
+}
+
 // Signal sends the passed signal to the task
 func (e *UniversalExecutor) Signal(s os.Signal) error {
 	if e.childCmd.Process == nil {
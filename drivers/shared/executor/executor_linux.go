@@ -12,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -66,6 +67,10 @@ type LibcontainerExecutor struct {
 	userProc       *libcontainer.Process
 	userProcExited chan interface{}
 	exitState      *ProcessState
+
+	// stdioCopies tracks the copies of the output of checkpointable
+	// processes to the stdout and stderr of the command
+	stdioCopies sync.WaitGroup
 }
 
 func NewExecutorWithIsolation(logger hclog.Logger) Executor {
@@ -87,38 +92,10 @@ func NewExecutorWithIsolation(logger hclog.Logger) Executor {
 func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, error) {
 	l.logger.Trace("preparing to launch command", "command", command.Cmd, "args", strings.Join(command.Args, " "))
 
-	if command.Resources == nil {
-		command.Resources = &drivers.Resources{
-			NomadResources: &structs.AllocatedTaskResources{},
-		}
-	}
-
-	l.command = command
-
-	// create a new factory which will store the container state in the allocDir
-	factory, err := libcontainer.New(
-		path.Join(command.TaskDir, "../alloc/container"),
-		libcontainer.Cgroupfs,
-		// note that os.Args[0] refers to the executor shim typically
-		// and first args arguments is ignored now due
-		// until https://github.com/opencontainers/runc/pull/1888 is merged
-		libcontainer.InitArgs(os.Args[0], "libcontainer-shim"),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create factory: %v", err)
-	}
-
-	// A container groups processes under the same isolation enforcement
-	containerCfg, err := newLibcontainerConfig(command)
+	container, err := l.createContainer(command)
 	if err != nil {
-		return nil, fmt.Errorf("failed to configure container(%s): %v", l.id, err)
-	}
-
-	container, err := factory.Create(l.id, containerCfg)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create container(%s): %v", l.id, err)
+		return nil, err
 	}
-	l.container = container
 
 	// Look up the binary path and make it executable
 	absPath, err := lookupTaskBin(command)
@@ -147,11 +124,7 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	path = "/" + rel
 
 	combined := append([]string{path}, command.Args...)
-	stdout, err := command.Stdout()
-	if err != nil {
-		return nil, err
-	}
-	stderr, err := command.Stderr()
+	stdout, stderr, err := l.processStdio(command)
 	if err != nil {
 		return nil, err
 	}
@@ -177,14 +150,122 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	l.systemCpuStats = stats.NewCpuStats()
 
 	// Starts the task
-	if err := container.Run(process); err != nil {
+	err = container.Run(process)
+	closeStdioPipes(process)
+	if err != nil {
 		container.Destroy()
 		return nil, err
 	}
 
+	return l.started(process)
+}
+
+// createContainer creates the container of the command in libcontainer.
+func (l *LibcontainerExecutor) createContainer(command *ExecCommand) (libcontainer.Container, error) {
+	if command.Resources == nil {
+		command.Resources = &drivers.Resources{
+			NomadResources: &structs.AllocatedTaskResources{},
+		}
+	}
+
+	l.command = command
+
+	// create a new factory which will store the container state in the allocDir
+	factory, err := libcontainer.New(
+		path.Join(command.TaskDir, "../alloc/container"),
+		libcontainer.Cgroupfs,
+		// note that os.Args[0] refers to the executor shim typically
+		// and first args arguments is ignored now due
+		// until https://github.com/opencontainers/runc/pull/1888 is merged
+		libcontainer.InitArgs(os.Args[0], "libcontainer-shim"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create factory: %v", err)
+	}
+
+	// A container groups processes under the same isolation enforcement
+	containerCfg, err := newLibcontainerConfig(command)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure container(%s): %v", l.id, err)
+	}
+
+	// The task dir must be owned by the root of the user namespace for the
+	// process to write to it
+	if ns := command.UserNamespace; ns != nil {
+		if err := chownTaskDir(command.TaskDir, int(ns.HostID), int(ns.HostID)); err != nil {
+			return nil, fmt.Errorf("failed to chown task dir to user namespace: %v", err)
+		}
+	}
+
+	container, err := factory.Create(l.id, containerCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container(%s): %v", l.id, err)
+	}
+	l.container = container
+	return container, nil
+}
+
+// processStdio returns the stdout and stderr of the process of the command.
+// The output of checkpointable processes is written to pipes copied to the
+// command's stdout and stderr, as CRIU can only connect the restored process
+// to pipes.
+func (l *LibcontainerExecutor) processStdio(command *ExecCommand) (io.Writer, io.Writer, error) {
+	stdout, err := command.Stdout()
+	if err != nil {
+		return nil, nil, err
+	}
+	stderr, err := command.Stderr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !command.Checkpointable {
+		return stdout, stderr, nil
+	}
+
+	stdoutPipe, err := l.copyPipe(stdout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create stdout pipe: %v", err)
+	}
+	stderrPipe, err := l.copyPipe(stderr)
+	if err != nil {
+		stdoutPipe.Close()
+		return nil, nil, fmt.Errorf("failed to create stderr pipe: %v", err)
+	}
+	return stdoutPipe, stderrPipe, nil
+}
+
+// copyPipe returns the write end of a pipe whose content is copied to w until
+// every copy of the write end is closed.
+func (l *LibcontainerExecutor) copyPipe(w io.Writer) (*os.File, error) {
+	pr, pw, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	l.stdioCopies.Add(1)
+	go func() {
+		defer l.stdioCopies.Done()
+		defer pr.Close()
+		io.Copy(w, pr)
+	}()
+	return pw, nil
+}
+
+// closeStdioPipes closes the executor's copy of the pipes the process writes
+// to once it is started, so that copying them ends when the process exits.
+func closeStdioPipes(process *libcontainer.Process) {
+	for _, w := range []io.Writer{process.Stdout, process.Stderr} {
+		if pw, ok := w.(*os.File); ok && pw != nil {
+			pw.Close()
+		}
+	}
+}
+
+// started starts waiting on the started process of the container.
+func (l *LibcontainerExecutor) started(process *libcontainer.Process) (*ProcessState, error) {
 	pid, err := process.Pid()
 	if err != nil {
-		container.Destroy()
+		l.container.Destroy()
 		return nil, err
 	}
 
@@ -201,6 +282,81 @@ func (l *LibcontainerExecutor) Launch(command *ExecCommand) (*ProcessState, erro
 	}, nil
 }
 
+// Checkpoint writes the checkpoint images of the container to imageDir with
+// CRIU, which stops the process.
+func (l *LibcontainerExecutor) Checkpoint(imageDir string) error {
+	if l.container == nil {
+		return fmt.Errorf("no container to checkpoint")
+	}
+	if !l.command.Checkpointable {
+		return fmt.Errorf("process wasn't launched to be checkpointed")
+	}
+
+	// The image dir must not exist so that CRIU never dumps the images
+	// through a directory or symlink it didn't create
+	if err := os.Mkdir(imageDir, 0700); err != nil {
+		return fmt.Errorf("failed to create checkpoint image dir: %v", err)
+	}
+
+	l.logger.Debug("checkpointing container", "image_dir", imageDir)
+	opts := &libcontainer.CriuOpts{
+		ImagesDirectory: imageDir,
+		FileLocks:       true,
+	}
+	if err := l.container.Checkpoint(opts); err != nil {
+		return fmt.Errorf("failed to checkpoint container(%s): %v", l.id, err)
+	}
+	return nil
+}
+
+// Restore creates a new container in libcontainer and restores the process
+// of the command from the checkpoint images in imageDir with CRIU.
+func (l *LibcontainerExecutor) Restore(command *ExecCommand, imageDir string) (*ProcessState, error) {
+	l.logger.Trace("preparing to restore command", "command", command.Cmd, "image_dir", imageDir)
+
+	if !command.Checkpointable {
+		return nil, fmt.Errorf("process can't be restored unless checkpointable")
+	}
+
+	container, err := l.createContainer(command)
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, stderr, err := l.processStdio(command)
+	if err != nil {
+		container.Destroy()
+		return nil, err
+	}
+
+	l.logger.Debug("restoring", "command", command.Cmd, "image_dir", imageDir)
+
+	// the restored process replaces the container's init process
+	process := &libcontainer.Process{
+		Stdout: stdout,
+		Stderr: stderr,
+		Init:   true,
+	}
+	l.userProc = process
+
+	l.totalCpuStats = stats.NewCpuStats()
+	l.userCpuStats = stats.NewCpuStats()
+	l.systemCpuStats = stats.NewCpuStats()
+
+	opts := &libcontainer.CriuOpts{
+		ImagesDirectory: imageDir,
+		FileLocks:       true,
+	}
+	err = container.Restore(process, opts)
+	closeStdioPipes(process)
+	if err != nil {
+		container.Destroy()
+		return nil, fmt.Errorf("failed to restore container(%s): %v", l.id, err)
+	}
+
+	return l.started(process)
+}
+
 func (l *LibcontainerExecutor) getAllPids() (resources.PIDs, error) {
 	pids, err := l.container.Processes()
 	if err != nil {
@@ -239,6 +395,9 @@ func (l *LibcontainerExecutor) wait() {
 		}
 	}
 
+	// Copy the remaining output before closing the command's stdout and
+	// stderr
+	l.stdioCopies.Wait()
 	l.command.Close()
 
 	exitCode := 1
@@ -653,6 +812,71 @@ func configureIsolation(cfg *lconfigs.Config, command *ExecCommand) error {
 		cfg.Mounts = append(cfg.Mounts, cmdMounts(command.Mounts)...)
 	}
 
+	if command.UserNamespace != nil {
+		configureUserNamespace(cfg, command)
+	}
+
+	return nil
+}
+
+// configureUserNamespace runs the container in a user namespace mapping its
+// uids and gids to the range of host ids of the command, so that root inside
+// the container is unprivileged on the host. Filesystems which can only be
+// mounted by the user namespace owning the network, PID or IPC namespace are
+// bind mounted from the host, or left out, when they aren't created along
+// with it.
+func configureUserNamespace(cfg *lconfigs.Config, command *ExecCommand) {
+	ns := command.UserNamespace
+	cfg.Namespaces = append(cfg.Namespaces, lconfigs.Namespace{Type: lconfigs.NEWUSER})
+	cfg.UidMappings = []lconfigs.IDMap{{ContainerID: 0, HostID: int(ns.HostID), Size: int(ns.Size)}}
+	cfg.GidMappings = []lconfigs.IDMap{{ContainerID: 0, HostID: int(ns.HostID), Size: int(ns.Size)}}
+
+	bindFlags := unix.MS_BIND | unix.MS_REC | syscall.MS_NOEXEC | syscall.MS_NOSUID | syscall.MS_NODEV
+	mounts := make([]*lconfigs.Mount, 0, len(cfg.Mounts))
+	for _, m := range cfg.Mounts {
+		switch {
+		case m.Device == "sysfs":
+			// the network namespace is the host's or the group's
+			m = &lconfigs.Mount{
+				Source:      "/sys",
+				Destination: m.Destination,
+				Device:      "bind",
+				Flags:       bindFlags | syscall.MS_RDONLY,
+			}
+		case m.Device == "proc" && command.ModePID != IsolationModePrivate:
+			m = &lconfigs.Mount{
+				Source:      "/proc",
+				Destination: m.Destination,
+				Device:      "bind",
+				Flags:       bindFlags,
+			}
+		case m.Device == "mqueue" && command.ModeIPC != IsolationModePrivate:
+			continue
+		}
+		mounts = append(mounts, m)
+	}
+	cfg.Mounts = mounts
+}
+
+// chownTaskDir changes the owner of the task dir and of the task's local,
+// secrets and tmp dirs and their content to uid and gid. The rest of the task
+// dir holds the files of the chroot, which are linked to the host's files.
+func chownTaskDir(taskDir string, uid, gid int) error {
+	if err := os.Lchown(taskDir, uid, gid); err != nil {
+		return err
+	}
+
+	for _, dir := range []string{allocdir.TaskLocal, allocdir.TaskSecrets, allocdir.TmpDirName} {
+		err := filepath.Walk(filepath.Join(taskDir, dir), func(path string, _ os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Lchown(path, uid, gid)
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -128,6 +129,100 @@ func TestExecutor_configureNamespaces(t *testing.T) {
 	})
 }
 
+func TestExecutor_configureUserNamespace(t *testing.T) {
+	ci.Parallel(t)
+
+	mountsByDest := func(cfg *lconfigs.Config) map[string]*lconfigs.Mount {
+		mounts := make(map[string]*lconfigs.Mount)
+		for _, m := range cfg.Mounts {
+			mounts[m.Destination] = m
+		}
+		return mounts
+	}
+
+	t.Run("private", func(t *testing.T) {
+		cfg := &lconfigs.Config{}
+		command := &ExecCommand{
+			TaskDir:       t.TempDir(),
+			ModePID:       IsolationModePrivate,
+			ModeIPC:       IsolationModePrivate,
+			UserNamespace: &UserNamespace{HostID: 100000, Size: 65536},
+		}
+		require.NoError(t, configureIsolation(cfg, command))
+
+		require.True(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+		expected := []lconfigs.IDMap{{ContainerID: 0, HostID: 100000, Size: 65536}}
+		require.Equal(t, expected, cfg.UidMappings)
+		require.Equal(t, expected, cfg.GidMappings)
+
+		mounts := mountsByDest(cfg)
+		require.Equal(t, "bind", mounts["/sys"].Device)
+		require.Equal(t, "/sys", mounts["/sys"].Source)
+		require.NotZero(t, mounts["/sys"].Flags&unix.MS_RDONLY)
+		require.Equal(t, "proc", mounts["/proc"].Device)
+		require.Equal(t, "mqueue", mounts["/dev/mqueue"].Device)
+	})
+
+	t.Run("host", func(t *testing.T) {
+		cfg := &lconfigs.Config{}
+		command := &ExecCommand{
+			TaskDir:       t.TempDir(),
+			ModePID:       IsolationModeHost,
+			ModeIPC:       IsolationModeHost,
+			UserNamespace: &UserNamespace{HostID: 100000, Size: 65536},
+		}
+		require.NoError(t, configureIsolation(cfg, command))
+
+		mounts := mountsByDest(cfg)
+		require.Equal(t, "bind", mounts["/proc"].Device)
+		require.Equal(t, "/proc", mounts["/proc"].Source)
+		require.NotContains(t, mounts, "/dev/mqueue")
+	})
+
+	t.Run("none", func(t *testing.T) {
+		cfg := &lconfigs.Config{}
+		command := &ExecCommand{
+			TaskDir: t.TempDir(),
+			ModePID: IsolationModePrivate,
+			ModeIPC: IsolationModePrivate,
+		}
+		require.NoError(t, configureIsolation(cfg, command))
+
+		require.False(t, cfg.Namespaces.Contains(lconfigs.NEWUSER))
+		require.Nil(t, cfg.UidMappings)
+		require.Equal(t, "sysfs", mountsByDest(cfg)["/sys"].Device)
+	})
+}
+
+func TestExecutor_chownTaskDir(t *testing.T) {
+	ci.Parallel(t)
+	testutil.RequireRoot(t)
+
+	taskDir := t.TempDir()
+	for _, dir := range []string{"local/nested", "secrets", "bin"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(taskDir, dir), 0777))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(taskDir, "local/nested/file"), []byte("hi"), 0644))
+
+	require.NoError(t, chownTaskDir(taskDir, 100000, 100001))
+
+	owner := func(path string) (uint32, uint32) {
+		fi, err := os.Lstat(filepath.Join(taskDir, path))
+		require.NoError(t, err)
+		st := fi.Sys().(*syscall.Stat_t)
+		return st.Uid, st.Gid
+	}
+	for _, path := range []string{".", "local", "local/nested/file", "secrets"} {
+		uid, gid := owner(path)
+		require.Equal(t, uint32(100000), uid, path)
+		require.Equal(t, uint32(100001), gid, path)
+	}
+
+	// The files of the chroot are left alone
+	uid, _ := owner("bin")
+	require.Equal(t, uint32(0), uid)
+}
+
 func TestExecutor_Isolation_PID_and_IPC_hostMode(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
@@ -411,6 +411,16 @@ func TestUniversalExecutor_MakeExecutable(t *testing.T) {
 	}
 }
 
+func TestUniversalExecutor_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	executor := NewExecutor(testlog.HCLogger(t))
+	require.Equal(t, ErrCheckpointNotSupported, executor.Checkpoint(t.TempDir()))
+
+	_, err := executor.Restore(&ExecCommand{Cmd: "/bin/true", Checkpointable: true}, t.TempDir())
+	require.Equal(t, ErrCheckpointNotSupported, err)
+}
+
 func TestUniversalExecutor_LookupPath(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
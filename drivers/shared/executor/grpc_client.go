@@ -32,7 +32,21 @@ type grpcExecutorClient struct {
 
 func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 	ctx := context.Background()
-	req := &proto.LaunchRequest{
+	req := launchRequestToProto(cmd)
+	resp, err := c.client.Launch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := processStateFromProto(resp.Process)
+	if err != nil {
+		return nil, err
+	}
+	return ps, nil
+}
+
+func launchRequestToProto(cmd *ExecCommand) *proto.LaunchRequest {
+	return &proto.LaunchRequest{
 		Cmd:                cmd.Cmd,
 		Args:               cmd.Args,
 		Resources:          drivers.ResourcesToProto(cmd.Resources),
@@ -50,17 +64,19 @@ func (c *grpcExecutorClient) Launch(cmd *ExecCommand) (*ProcessState, error) {
 		DefaultPidMode:     cmd.ModePID,
 		DefaultIpcMode:     cmd.ModeIPC,
 		Capabilities:       cmd.Capabilities,
+		Checkpointable:     cmd.Checkpointable,
+		UserNamespace:      userNamespaceToProto(cmd.UserNamespace),
 	}
-	resp, err := c.client.Launch(ctx, req)
-	if err != nil {
-		return nil, err
-	}
+}
 
-	ps, err := processStateFromProto(resp.Process)
-	if err != nil {
-		return nil, err
+func userNamespaceToProto(ns *UserNamespace) *proto.UserNamespace {
+	if ns == nil {
+		return nil
+	}
+	return &proto.UserNamespace{
+		HostId: ns.HostID,
+		Size:   ns.Size,
 	}
-	return ps, nil
 }
 
 func (c *grpcExecutorClient) Wait(ctx context.Context) (*ProcessState, error) {
@@ -175,6 +191,32 @@ func (c *grpcExecutorClient) Signal(s os.Signal) error {
 	return nil
 }
 
+func (c *grpcExecutorClient) Checkpoint(imageDir string) error {
+	ctx := context.Background()
+	req := &proto.CheckpointRequest{
+		ImageDir: imageDir,
+	}
+	if _, err := c.client.Checkpoint(ctx, req); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *grpcExecutorClient) Restore(cmd *ExecCommand, imageDir string) (*ProcessState, error) {
+	ctx := context.Background()
+	req := &proto.RestoreRequest{
+		Command:  launchRequestToProto(cmd),
+		ImageDir: imageDir,
+	}
+	resp, err := c.client.Restore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return processStateFromProto(resp.Process)
+}
+
 func (c *grpcExecutorClient) Exec(deadline time.Time, cmd string, args []string) ([]byte, int, error) {
 	ctx := context.Background()
 	pbDeadline, err := ptypes.TimestampProto(deadline)
@@ -20,7 +20,23 @@ type grpcExecutorServer struct {
 }
 
 func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchRequest) (*proto.LaunchResponse, error) {
-	ps, err := s.impl.Launch(&ExecCommand{
+	ps, err := s.impl.Launch(execCommandFromProto(req))
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := processStateToProto(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.LaunchResponse{
+		Process: process,
+	}, nil
+}
+
+func execCommandFromProto(req *proto.LaunchRequest) *ExecCommand {
+	return &ExecCommand{
 		Cmd:                req.Cmd,
 		Args:               req.Args,
 		Resources:          drivers.ResourcesFromProto(req.Resources),
@@ -38,20 +54,19 @@ func (s *grpcExecutorServer) Launch(ctx context.Context, req *proto.LaunchReques
 		ModePID:            req.DefaultPidMode,
 		ModeIPC:            req.DefaultIpcMode,
 		Capabilities:       req.Capabilities,
-	})
-
-	if err != nil {
-		return nil, err
+		Checkpointable:     req.Checkpointable,
+		UserNamespace:      userNamespaceFromProto(req.UserNamespace),
 	}
+}
 
-	process, err := processStateToProto(ps)
-	if err != nil {
-		return nil, err
+func userNamespaceFromProto(pb *proto.UserNamespace) *UserNamespace {
+	if pb == nil {
+		return nil
+	}
+	return &UserNamespace{
+		HostID: pb.HostId,
+		Size:   pb.Size,
 	}
-
-	return &proto.LaunchResponse{
-		Process: process,
-	}, nil
 }
 
 func (s *grpcExecutorServer) Wait(ctx context.Context, req *proto.WaitRequest) (*proto.WaitResponse, error) {
@@ -144,6 +159,29 @@ func (s *grpcExecutorServer) Signal(ctx context.Context, req *proto.SignalReques
 	return &proto.SignalResponse{}, nil
 }
 
+func (s *grpcExecutorServer) Checkpoint(ctx context.Context, req *proto.CheckpointRequest) (*proto.CheckpointResponse, error) {
+	if err := s.impl.Checkpoint(req.ImageDir); err != nil {
+		return nil, err
+	}
+	return &proto.CheckpointResponse{}, nil
+}
+
+func (s *grpcExecutorServer) Restore(ctx context.Context, req *proto.RestoreRequest) (*proto.RestoreResponse, error) {
+	ps, err := s.impl.Restore(execCommandFromProto(req.Command), req.ImageDir)
+	if err != nil {
+		return nil, err
+	}
+
+	process, err := processStateToProto(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proto.RestoreResponse{
+		Process: process,
+	}, nil
+}
+
 func (s *grpcExecutorServer) Exec(ctx context.Context, req *proto.ExecRequest) (*proto.ExecResponse, error) {
 	deadline, err := ptypes.Timestamp(req.Deadline)
 	if err != nil {
@@ -46,6 +46,8 @@ type LaunchRequest struct {
 	CpusetCgroup         string                       `protobuf:"bytes,17,opt,name=cpuset_cgroup,json=cpusetCgroup,proto3" json:"cpuset_cgroup,omitempty"`
 	AllowCaps            []string                     `protobuf:"bytes,18,rep,name=allow_caps,json=allowCaps,proto3" json:"allow_caps,omitempty"`
 	Capabilities         []string                     `protobuf:"bytes,19,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	Checkpointable       bool                         `protobuf:"varint,20,opt,name=checkpointable,proto3" json:"checkpointable,omitempty"`
+	UserNamespace        *UserNamespace               `protobuf:"bytes,21,opt,name=user_namespace,json=userNamespace,proto3" json:"user_namespace,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
 	XXX_unrecognized     []byte                       `json:"-"`
 	XXX_sizecache        int32                        `json:"-"`
@@ -209,6 +211,67 @@ func (m *LaunchRequest) GetCapabilities() []string {
 	return nil
 }
 
+func (m *LaunchRequest) GetCheckpointable() bool {
+	if m != nil {
+		return m.Checkpointable
+	}
+	return false
+}
+
+func (m *LaunchRequest) GetUserNamespace() *UserNamespace {
+	if m != nil {
+		return m.UserNamespace
+	}
+	return nil
+}
+
+type UserNamespace struct {
+	HostId               uint32   `protobuf:"varint,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	Size                 uint32   `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserNamespace) Reset()         { *m = UserNamespace{} }
+func (m *UserNamespace) String() string { return proto.CompactTextString(m) }
+func (*UserNamespace) ProtoMessage()    {}
+func (*UserNamespace) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{1}
+}
+
+func (m *UserNamespace) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserNamespace.Unmarshal(m, b)
+}
+func (m *UserNamespace) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserNamespace.Marshal(b, m, deterministic)
+}
+func (m *UserNamespace) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserNamespace.Merge(m, src)
+}
+func (m *UserNamespace) XXX_Size() int {
+	return xxx_messageInfo_UserNamespace.Size(m)
+}
+func (m *UserNamespace) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserNamespace.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserNamespace proto.InternalMessageInfo
+
+func (m *UserNamespace) GetHostId() uint32 {
+	if m != nil {
+		return m.HostId
+	}
+	return 0
+}
+
+func (m *UserNamespace) GetSize() uint32 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
 type LaunchResponse struct {
 	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -220,7 +283,7 @@ func (m *LaunchResponse) Reset()         { *m = LaunchResponse{} }
 func (m *LaunchResponse) String() string { return proto.CompactTextString(m) }
 func (*LaunchResponse) ProtoMessage()    {}
 func (*LaunchResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{1}
+	return fileDescriptor_66b85426380683f3, []int{2}
 }
 
 func (m *LaunchResponse) XXX_Unmarshal(b []byte) error {
@@ -258,7 +321,7 @@ func (m *WaitRequest) Reset()         { *m = WaitRequest{} }
 func (m *WaitRequest) String() string { return proto.CompactTextString(m) }
 func (*WaitRequest) ProtoMessage()    {}
 func (*WaitRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{2}
+	return fileDescriptor_66b85426380683f3, []int{3}
 }
 
 func (m *WaitRequest) XXX_Unmarshal(b []byte) error {
@@ -290,7 +353,7 @@ func (m *WaitResponse) Reset()         { *m = WaitResponse{} }
 func (m *WaitResponse) String() string { return proto.CompactTextString(m) }
 func (*WaitResponse) ProtoMessage()    {}
 func (*WaitResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{3}
+	return fileDescriptor_66b85426380683f3, []int{4}
 }
 
 func (m *WaitResponse) XXX_Unmarshal(b []byte) error {
@@ -330,7 +393,7 @@ func (m *ShutdownRequest) Reset()         { *m = ShutdownRequest{} }
 func (m *ShutdownRequest) String() string { return proto.CompactTextString(m) }
 func (*ShutdownRequest) ProtoMessage()    {}
 func (*ShutdownRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{4}
+	return fileDescriptor_66b85426380683f3, []int{5}
 }
 
 func (m *ShutdownRequest) XXX_Unmarshal(b []byte) error {
@@ -375,7 +438,7 @@ func (m *ShutdownResponse) Reset()         { *m = ShutdownResponse{} }
 func (m *ShutdownResponse) String() string { return proto.CompactTextString(m) }
 func (*ShutdownResponse) ProtoMessage()    {}
 func (*ShutdownResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{5}
+	return fileDescriptor_66b85426380683f3, []int{6}
 }
 
 func (m *ShutdownResponse) XXX_Unmarshal(b []byte) error {
@@ -407,7 +470,7 @@ func (m *UpdateResourcesRequest) Reset()         { *m = UpdateResourcesRequest{}
 func (m *UpdateResourcesRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesRequest) ProtoMessage()    {}
 func (*UpdateResourcesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{6}
+	return fileDescriptor_66b85426380683f3, []int{7}
 }
 
 func (m *UpdateResourcesRequest) XXX_Unmarshal(b []byte) error {
@@ -445,7 +508,7 @@ func (m *UpdateResourcesResponse) Reset()         { *m = UpdateResourcesResponse
 func (m *UpdateResourcesResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateResourcesResponse) ProtoMessage()    {}
 func (*UpdateResourcesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{7}
+	return fileDescriptor_66b85426380683f3, []int{8}
 }
 
 func (m *UpdateResourcesResponse) XXX_Unmarshal(b []byte) error {
@@ -476,7 +539,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{8}
+	return fileDescriptor_66b85426380683f3, []int{9}
 }
 
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
@@ -508,7 +571,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{9}
+	return fileDescriptor_66b85426380683f3, []int{10}
 }
 
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
@@ -547,7 +610,7 @@ func (m *StatsRequest) Reset()         { *m = StatsRequest{} }
 func (m *StatsRequest) String() string { return proto.CompactTextString(m) }
 func (*StatsRequest) ProtoMessage()    {}
 func (*StatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{10}
+	return fileDescriptor_66b85426380683f3, []int{11}
 }
 
 func (m *StatsRequest) XXX_Unmarshal(b []byte) error {
@@ -586,7 +649,7 @@ func (m *StatsResponse) Reset()         { *m = StatsResponse{} }
 func (m *StatsResponse) String() string { return proto.CompactTextString(m) }
 func (*StatsResponse) ProtoMessage()    {}
 func (*StatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{11}
+	return fileDescriptor_66b85426380683f3, []int{12}
 }
 
 func (m *StatsResponse) XXX_Unmarshal(b []byte) error {
@@ -625,7 +688,7 @@ func (m *SignalRequest) Reset()         { *m = SignalRequest{} }
 func (m *SignalRequest) String() string { return proto.CompactTextString(m) }
 func (*SignalRequest) ProtoMessage()    {}
 func (*SignalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{12}
+	return fileDescriptor_66b85426380683f3, []int{13}
 }
 
 func (m *SignalRequest) XXX_Unmarshal(b []byte) error {
@@ -663,7 +726,7 @@ func (m *SignalResponse) Reset()         { *m = SignalResponse{} }
 func (m *SignalResponse) String() string { return proto.CompactTextString(m) }
 func (*SignalResponse) ProtoMessage()    {}
 func (*SignalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{13}
+	return fileDescriptor_66b85426380683f3, []int{14}
 }
 
 func (m *SignalResponse) XXX_Unmarshal(b []byte) error {
@@ -697,7 +760,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{14}
+	return fileDescriptor_66b85426380683f3, []int{15}
 }
 
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
@@ -751,7 +814,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{15}
+	return fileDescriptor_66b85426380683f3, []int{16}
 }
 
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
@@ -786,6 +849,162 @@ func (m *ExecResponse) GetExitCode() int32 {
 	return 0
 }
 
+type CheckpointRequest struct {
+	ImageDir             string   `protobuf:"bytes,1,opt,name=image_dir,json=imageDir,proto3" json:"image_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointRequest) Reset()         { *m = CheckpointRequest{} }
+func (m *CheckpointRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointRequest) ProtoMessage()    {}
+func (*CheckpointRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{17}
+}
+
+func (m *CheckpointRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointRequest.Unmarshal(m, b)
+}
+func (m *CheckpointRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointRequest.Merge(m, src)
+}
+func (m *CheckpointRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointRequest.Size(m)
+}
+func (m *CheckpointRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointRequest proto.InternalMessageInfo
+
+func (m *CheckpointRequest) GetImageDir() string {
+	if m != nil {
+		return m.ImageDir
+	}
+	return ""
+}
+
+type CheckpointResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointResponse) Reset()         { *m = CheckpointResponse{} }
+func (m *CheckpointResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointResponse) ProtoMessage()    {}
+func (*CheckpointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{18}
+}
+
+func (m *CheckpointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointResponse.Unmarshal(m, b)
+}
+func (m *CheckpointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointResponse.Merge(m, src)
+}
+func (m *CheckpointResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointResponse.Size(m)
+}
+func (m *CheckpointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointResponse proto.InternalMessageInfo
+
+type RestoreRequest struct {
+	Command              *LaunchRequest `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	ImageDir             string         `protobuf:"bytes,2,opt,name=image_dir,json=imageDir,proto3" json:"image_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{19}
+}
+
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreRequest.Unmarshal(m, b)
+}
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
+}
+func (m *RestoreRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreRequest.Size(m)
+}
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
+
+func (m *RestoreRequest) GetCommand() *LaunchRequest {
+	if m != nil {
+		return m.Command
+	}
+	return nil
+}
+
+func (m *RestoreRequest) GetImageDir() string {
+	if m != nil {
+		return m.ImageDir
+	}
+	return ""
+}
+
+type RestoreResponse struct {
+	Process              *ProcessState `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_66b85426380683f3, []int{20}
+}
+
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreResponse.Unmarshal(m, b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreResponse.Size(m)
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
+
+func (m *RestoreResponse) GetProcess() *ProcessState {
+	if m != nil {
+		return m.Process
+	}
+	return nil
+}
+
 type ProcessState struct {
 	Pid                  int32                `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
 	ExitCode             int32                `protobuf:"varint,2,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
@@ -800,7 +1019,7 @@ func (m *ProcessState) Reset()         { *m = ProcessState{} }
 func (m *ProcessState) String() string { return proto.CompactTextString(m) }
 func (*ProcessState) ProtoMessage()    {}
 func (*ProcessState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_66b85426380683f3, []int{16}
+	return fileDescriptor_66b85426380683f3, []int{21}
 }
 
 func (m *ProcessState) XXX_Unmarshal(b []byte) error {
@@ -851,6 +1070,7 @@ func (m *ProcessState) GetTime() *timestamp.Timestamp {
 
 func init() {
 	proto.RegisterType((*LaunchRequest)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchRequest")
+	proto.RegisterType((*UserNamespace)(nil), "hashicorp.nomad.plugins.executor.proto.UserNamespace")
 	proto.RegisterType((*LaunchResponse)(nil), "hashicorp.nomad.plugins.executor.proto.LaunchResponse")
 	proto.RegisterType((*WaitRequest)(nil), "hashicorp.nomad.plugins.executor.proto.WaitRequest")
 	proto.RegisterType((*WaitResponse)(nil), "hashicorp.nomad.plugins.executor.proto.WaitResponse")
@@ -866,6 +1086,10 @@ func init() {
 	proto.RegisterType((*SignalResponse)(nil), "hashicorp.nomad.plugins.executor.proto.SignalResponse")
 	proto.RegisterType((*ExecRequest)(nil), "hashicorp.nomad.plugins.executor.proto.ExecRequest")
 	proto.RegisterType((*ExecResponse)(nil), "hashicorp.nomad.plugins.executor.proto.ExecResponse")
+	proto.RegisterType((*CheckpointRequest)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointRequest")
+	proto.RegisterType((*CheckpointResponse)(nil), "hashicorp.nomad.plugins.executor.proto.CheckpointResponse")
+	proto.RegisterType((*RestoreRequest)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "hashicorp.nomad.plugins.executor.proto.RestoreResponse")
 	proto.RegisterType((*ProcessState)(nil), "hashicorp.nomad.plugins.executor.proto.ProcessState")
 }
 
@@ -874,74 +1098,84 @@ func init() {
 }
 
 var fileDescriptor_66b85426380683f3 = []byte{
-	// 1058 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x55, 0x6d, 0x6f, 0x1b, 0x45,
-	0x10, 0xee, 0xc5, 0x89, 0x5f, 0xc6, 0x76, 0xe2, 0x2e, 0xa8, 0x5c, 0x8d, 0x50, 0xcd, 0x21, 0x51,
-	0x0b, 0xca, 0x25, 0x4a, 0xdf, 0x90, 0x90, 0x28, 0x22, 0x2d, 0xa8, 0x52, 0x1a, 0x45, 0x97, 0x42,
-	0x25, 0x3e, 0x70, 0x6c, 0xee, 0xb6, 0xf6, 0x2a, 0xf6, 0xed, 0xb2, 0xbb, 0xe7, 0x04, 0x09, 0x89,
-	0x4f, 0xfc, 0x03, 0x90, 0xf8, 0x31, 0xfc, 0x38, 0xb4, 0x6f, 0x17, 0x3b, 0x2d, 0xd5, 0xb9, 0x88,
-	0x4f, 0xbe, 0x1d, 0x3f, 0xcf, 0xcc, 0xec, 0xce, 0xcc, 0x33, 0x70, 0x27, 0x17, 0x74, 0x41, 0x84,
-	0xdc, 0x95, 0x53, 0x2c, 0x48, 0xbe, 0x4b, 0x2e, 0x48, 0x56, 0x2a, 0x26, 0x76, 0xb9, 0x60, 0x8a,
-	0x55, 0xc7, 0xd8, 0x1c, 0xd1, 0xc7, 0x53, 0x2c, 0xa7, 0x34, 0x63, 0x82, 0xc7, 0x05, 0x9b, 0xe3,
-	0x3c, 0xe6, 0xb3, 0x72, 0x42, 0x0b, 0x19, 0xaf, 0xe2, 0x86, 0xb7, 0x26, 0x8c, 0x4d, 0x66, 0xc4,
-	0x3a, 0x39, 0x2d, 0x5f, 0xee, 0x2a, 0x3a, 0x27, 0x52, 0xe1, 0x39, 0x77, 0x80, 0xc8, 0x11, 0x77,
-	0x7d, 0x78, 0x1b, 0xce, 0x9e, 0x2c, 0x26, 0xfa, 0xbb, 0x09, 0xfd, 0x43, 0x5c, 0x16, 0xd9, 0x34,
-	0x21, 0x3f, 0x97, 0x44, 0x2a, 0x34, 0x80, 0x46, 0x36, 0xcf, 0xc3, 0x60, 0x14, 0x8c, 0x3b, 0x89,
-	0xfe, 0x44, 0x08, 0x36, 0xb1, 0x98, 0xc8, 0x70, 0x63, 0xd4, 0x18, 0x77, 0x12, 0xf3, 0x8d, 0x8e,
-	0xa0, 0x23, 0x88, 0x64, 0xa5, 0xc8, 0x88, 0x0c, 0x1b, 0xa3, 0x60, 0xdc, 0xdd, 0xdf, 0x8b, 0xff,
-	0x2d, 0x71, 0x17, 0xdf, 0x86, 0x8c, 0x13, 0xcf, 0x4b, 0x2e, 0x5d, 0xa0, 0x5b, 0xd0, 0x95, 0x2a,
-	0x67, 0xa5, 0x4a, 0x39, 0x56, 0xd3, 0x70, 0xd3, 0x44, 0x07, 0x6b, 0x3a, 0xc6, 0x6a, 0xea, 0x00,
-	0x44, 0x08, 0x0b, 0xd8, 0xaa, 0x00, 0x44, 0x08, 0x03, 0x18, 0x40, 0x83, 0x14, 0x8b, 0xb0, 0x69,
-	0x92, 0xd4, 0x9f, 0x3a, 0xef, 0x52, 0x12, 0x11, 0xb6, 0x0c, 0xd6, 0x7c, 0xa3, 0x9b, 0xd0, 0x56,
-	0x58, 0x9e, 0xa5, 0x39, 0x15, 0x61, 0xdb, 0xd8, 0x5b, 0xfa, 0xfc, 0x98, 0x0a, 0x74, 0x1b, 0x76,
-	0x7c, 0x3e, 0xe9, 0x8c, 0xce, 0xa9, 0x92, 0x61, 0x67, 0x14, 0x8c, 0xdb, 0xc9, 0xb6, 0x37, 0x1f,
-	0x1a, 0x2b, 0xda, 0x83, 0x77, 0x4f, 0xb1, 0xa4, 0x59, 0xca, 0x05, 0xcb, 0x88, 0x94, 0x69, 0x36,
-	0x11, 0xac, 0xe4, 0x21, 0x18, 0x34, 0x32, 0xff, 0x1d, 0xdb, 0xbf, 0x0e, 0xcc, 0x3f, 0xe8, 0x31,
-	0x34, 0xe7, 0xac, 0x2c, 0x94, 0x0c, 0xbb, 0xa3, 0xc6, 0xb8, 0xbb, 0x7f, 0xa7, 0xe6, 0x53, 0x3d,
-	0xd3, 0xa4, 0xc4, 0x71, 0xd1, 0xb7, 0xd0, 0xca, 0xc9, 0x82, 0xea, 0x17, 0xef, 0x19, 0x37, 0x9f,
-	0xd5, 0x74, 0xf3, 0xd8, 0xb0, 0x12, 0xcf, 0x46, 0x53, 0xb8, 0x5e, 0x10, 0x75, 0xce, 0xc4, 0x59,
-	0x4a, 0x25, 0x9b, 0x61, 0x45, 0x59, 0x11, 0xf6, 0x4d, 0x11, 0xbf, 0xa8, 0xe9, 0xf2, 0xc8, 0xf2,
-	0x9f, 0x7a, 0xfa, 0x09, 0x27, 0x59, 0x32, 0x28, 0xae, 0x58, 0x51, 0x04, 0xfd, 0x82, 0xa5, 0x9c,
-	0x2e, 0x98, 0x4a, 0x05, 0x63, 0x2a, 0xdc, 0x36, 0x6f, 0xd4, 0x2d, 0xd8, 0xb1, 0xb6, 0x25, 0x8c,
-	0x29, 0x34, 0x86, 0x41, 0x4e, 0x5e, 0xe2, 0x72, 0xa6, 0x52, 0x4e, 0xf3, 0x74, 0xce, 0x72, 0x12,
-	0xee, 0x98, 0xd2, 0x6c, 0x3b, 0xfb, 0x31, 0xcd, 0x9f, 0xb1, 0x9c, 0x2c, 0x23, 0x29, 0xcf, 0x2c,
-	0x72, 0xb0, 0x82, 0x7c, 0xca, 0x33, 0x83, 0xfc, 0x08, 0xfa, 0x19, 0x2f, 0x25, 0x51, 0xbe, 0x36,
-	0xd7, 0x0d, 0xac, 0x67, 0x8d, 0xae, 0x2a, 0x1f, 0x00, 0xe0, 0xd9, 0x8c, 0x9d, 0xa7, 0x19, 0xe6,
-	0x32, 0x44, 0xa6, 0x71, 0x3a, 0xc6, 0x72, 0x80, 0xb9, 0x44, 0x11, 0xf4, 0x32, 0xcc, 0xf1, 0x29,
-	0x9d, 0x51, 0x45, 0x89, 0x0c, 0xdf, 0x31, 0x80, 0x15, 0x5b, 0xf4, 0x13, 0x6c, 0xfb, 0xe9, 0x91,
-	0x9c, 0x15, 0x92, 0xa0, 0x23, 0x68, 0xb9, 0xb6, 0x30, 0x23, 0xd4, 0xdd, 0xbf, 0x17, 0xd7, 0x9b,
-	0xe7, 0xd8, 0xb5, 0xcc, 0x89, 0xc2, 0x8a, 0x24, 0xde, 0x49, 0xd4, 0x87, 0xee, 0x0b, 0x4c, 0x95,
-	0x9b, 0xce, 0xe8, 0x47, 0xe8, 0xd9, 0xe3, 0xff, 0x14, 0xee, 0x10, 0x76, 0x4e, 0xa6, 0xa5, 0xca,
-	0xd9, 0x79, 0xe1, 0x05, 0xe1, 0x06, 0x34, 0x25, 0x9d, 0x14, 0x78, 0xe6, 0x34, 0xc1, 0x9d, 0xd0,
-	0x87, 0xd0, 0x9b, 0x08, 0x9c, 0x91, 0x94, 0x13, 0x41, 0x59, 0x1e, 0x6e, 0x8c, 0x82, 0x71, 0x23,
-	0xe9, 0x1a, 0xdb, 0xb1, 0x31, 0x45, 0x08, 0x06, 0x97, 0xde, 0x6c, 0xc6, 0xd1, 0x14, 0x6e, 0x7c,
-	0xc7, 0x73, 0x1d, 0xb4, 0xd2, 0x01, 0x17, 0x68, 0x45, 0x53, 0x82, 0xff, 0xac, 0x29, 0xd1, 0x4d,
-	0x78, 0xef, 0x95, 0x48, 0x2e, 0x89, 0x01, 0x6c, 0x7f, 0x4f, 0x84, 0xa4, 0xcc, 0xdf, 0x32, 0xfa,
-	0x14, 0x76, 0x2a, 0x8b, 0x7b, 0xdb, 0x10, 0x5a, 0x0b, 0x6b, 0x72, 0x37, 0xf7, 0xc7, 0xe8, 0x13,
-	0xe8, 0xe9, 0x77, 0xab, 0x32, 0x1f, 0x42, 0x9b, 0x16, 0x8a, 0x88, 0x85, 0x7b, 0xa4, 0x46, 0x52,
-	0x9d, 0xa3, 0x17, 0xd0, 0x77, 0x58, 0xe7, 0xf6, 0x1b, 0xd8, 0x92, 0xda, 0xb0, 0xe6, 0x15, 0x9f,
-	0x63, 0x79, 0x66, 0x1d, 0x59, 0x7a, 0x74, 0x1b, 0xfa, 0x27, 0xa6, 0x12, 0xaf, 0x2f, 0xd4, 0x96,
-	0x2f, 0x94, 0xbe, 0xac, 0x07, 0xba, 0xeb, 0x9f, 0x41, 0xf7, 0xc9, 0x05, 0xc9, 0x3c, 0xf1, 0x01,
-	0xb4, 0x73, 0x82, 0xf3, 0x19, 0x2d, 0x88, 0x4b, 0x6a, 0x18, 0xdb, 0xe5, 0x12, 0xfb, 0xe5, 0x12,
-	0x3f, 0xf7, 0xcb, 0x25, 0xa9, 0xb0, 0x7e, 0x55, 0x6c, 0xbc, 0xba, 0x2a, 0x1a, 0x97, 0xab, 0x22,
-	0x3a, 0x80, 0x9e, 0x0d, 0xe6, 0xee, 0x7f, 0x03, 0x9a, 0xac, 0x54, 0xbc, 0x54, 0x26, 0x56, 0x2f,
-	0x71, 0x27, 0xf4, 0x3e, 0x74, 0xc8, 0x05, 0x55, 0x69, 0xa6, 0xc7, 0x7a, 0xc3, 0xdc, 0xa0, 0xad,
-	0x0d, 0x07, 0x2c, 0x27, 0xd1, 0xef, 0x01, 0xf4, 0x96, 0x3b, 0x56, 0xc7, 0xe6, 0x34, 0x77, 0x37,
-	0xd5, 0x9f, 0x6f, 0xe4, 0x2f, 0xbd, 0x4d, 0x63, 0xf9, 0x6d, 0x50, 0x0c, 0x9b, 0x7a, 0x6d, 0x9a,
-	0x85, 0xf3, 0xe6, 0x6b, 0x1b, 0xdc, 0xfe, 0x9f, 0x1d, 0x68, 0x3f, 0x71, 0x83, 0x84, 0x7e, 0x81,
-	0xa6, 0x9d, 0x7e, 0x74, 0xbf, 0xee, 0xd4, 0xad, 0xec, 0xda, 0xe1, 0x83, 0x75, 0x69, 0xae, 0x7e,
-	0xd7, 0x90, 0x84, 0x4d, 0xad, 0x03, 0xe8, 0x6e, 0x5d, 0x0f, 0x4b, 0x22, 0x32, 0xbc, 0xb7, 0x1e,
-	0xa9, 0x0a, 0xfa, 0x1b, 0xb4, 0xfd, 0x38, 0xa3, 0x87, 0x75, 0x7d, 0x5c, 0x91, 0x93, 0xe1, 0xe7,
-	0xeb, 0x13, 0xab, 0x04, 0xfe, 0x08, 0x60, 0xe7, 0xca, 0x48, 0xa3, 0x2f, 0xeb, 0xfa, 0x7b, 0xbd,
-	0xea, 0x0c, 0x1f, 0xbd, 0x35, 0xbf, 0x4a, 0xeb, 0x57, 0x68, 0x39, 0xed, 0x40, 0xb5, 0x2b, 0xba,
-	0x2a, 0x3f, 0xc3, 0x87, 0x6b, 0xf3, 0xaa, 0xe8, 0x17, 0xb0, 0x65, 0x74, 0x01, 0xd5, 0x2e, 0xeb,
-	0xb2, 0x76, 0x0d, 0xef, 0xaf, 0xc9, 0xf2, 0x71, 0xf7, 0x02, 0xdd, 0xff, 0x56, 0x58, 0xea, 0xf7,
-	0xff, 0x8a, 0x62, 0xd5, 0xef, 0xff, 0x2b, 0xfa, 0x65, 0xfa, 0x5f, 0x8f, 0x61, 0xfd, 0xfe, 0x5f,
-	0xd2, 0xbb, 0xfa, 0xfd, 0xbf, 0xac, 0x5b, 0xd1, 0x35, 0xf4, 0x57, 0x00, 0x7d, 0x6d, 0x3a, 0x51,
-	0x82, 0xe0, 0x39, 0x2d, 0x26, 0xe8, 0x51, 0x4d, 0xf1, 0xd6, 0x2c, 0x2b, 0xe0, 0x8e, 0xe9, 0x53,
-	0xf9, 0xea, 0xed, 0x1d, 0xf8, 0xb4, 0xc6, 0xc1, 0x5e, 0xf0, 0x75, 0xeb, 0x87, 0x2d, 0xab, 0x59,
-	0x4d, 0xf3, 0x73, 0xf7, 0x9f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x63, 0xc4, 0xd3, 0x74, 0x0c,
-	0x00, 0x00,
+	// 1230 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0x7b, 0x6f, 0xdc, 0x44,
+	0x10, 0xc7, 0xb9, 0xe4, 0x1e, 0x73, 0x8f, 0x5c, 0x97, 0xd2, 0xba, 0xae, 0x50, 0x83, 0x91, 0xda,
+	0x13, 0x14, 0x27, 0x4a, 0x5f, 0xbc, 0x44, 0x11, 0x49, 0x41, 0x95, 0xda, 0x10, 0x39, 0x2d, 0x95,
+	0x10, 0xc2, 0x6c, 0xec, 0xed, 0xdd, 0x2a, 0x67, 0xaf, 0xd9, 0x5d, 0x5f, 0x03, 0x42, 0xf0, 0x57,
+	0xbf, 0x01, 0x7f, 0x20, 0xf1, 0x6d, 0xf8, 0x64, 0xc8, 0xfb, 0x70, 0xee, 0x92, 0x02, 0xbe, 0xa0,
+	0xfe, 0x75, 0x3b, 0xe3, 0xf9, 0xcd, 0xcc, 0xce, 0xcc, 0xfe, 0xe6, 0xe0, 0x66, 0xc2, 0xe9, 0x8c,
+	0x70, 0xb1, 0x29, 0x26, 0x98, 0x93, 0x64, 0x93, 0x1c, 0x93, 0xb8, 0x90, 0x8c, 0x6f, 0xe6, 0x9c,
+	0x49, 0x56, 0x89, 0x81, 0x12, 0xd1, 0xf5, 0x09, 0x16, 0x13, 0x1a, 0x33, 0x9e, 0x07, 0x19, 0x4b,
+	0x71, 0x12, 0xe4, 0xd3, 0x62, 0x4c, 0x33, 0x11, 0x2c, 0xda, 0x79, 0xd7, 0xc6, 0x8c, 0x8d, 0xa7,
+	0x44, 0x3b, 0x39, 0x2c, 0x9e, 0x6f, 0x4a, 0x9a, 0x12, 0x21, 0x71, 0x9a, 0x1b, 0x03, 0xdf, 0x00,
+	0x37, 0x6d, 0x78, 0x1d, 0x4e, 0x4b, 0xda, 0xc6, 0xff, 0xab, 0x05, 0xfd, 0x47, 0xb8, 0xc8, 0xe2,
+	0x49, 0x48, 0x7e, 0x2c, 0x88, 0x90, 0x68, 0x08, 0x8d, 0x38, 0x4d, 0x5c, 0x67, 0xc3, 0x19, 0x75,
+	0xc2, 0xf2, 0x88, 0x10, 0xac, 0x62, 0x3e, 0x16, 0xee, 0xca, 0x46, 0x63, 0xd4, 0x09, 0xd5, 0x19,
+	0xed, 0x41, 0x87, 0x13, 0xc1, 0x0a, 0x1e, 0x13, 0xe1, 0x36, 0x36, 0x9c, 0x51, 0x77, 0x7b, 0x2b,
+	0xf8, 0xa7, 0xc4, 0x4d, 0x7c, 0x1d, 0x32, 0x08, 0x2d, 0x2e, 0x3c, 0x71, 0x81, 0xae, 0x41, 0x57,
+	0xc8, 0x84, 0x15, 0x32, 0xca, 0xb1, 0x9c, 0xb8, 0xab, 0x2a, 0x3a, 0x68, 0xd5, 0x3e, 0x96, 0x13,
+	0x63, 0x40, 0x38, 0xd7, 0x06, 0x6b, 0x95, 0x01, 0xe1, 0x5c, 0x19, 0x0c, 0xa1, 0x41, 0xb2, 0x99,
+	0xdb, 0x54, 0x49, 0x96, 0xc7, 0x32, 0xef, 0x42, 0x10, 0xee, 0xb6, 0x94, 0xad, 0x3a, 0xa3, 0x2b,
+	0xd0, 0x96, 0x58, 0x1c, 0x45, 0x09, 0xe5, 0x6e, 0x5b, 0xe9, 0x5b, 0xa5, 0xbc, 0x4b, 0x39, 0xba,
+	0x01, 0xeb, 0x36, 0x9f, 0x68, 0x4a, 0x53, 0x2a, 0x85, 0xdb, 0xd9, 0x70, 0x46, 0xed, 0x70, 0x60,
+	0xd5, 0x8f, 0x94, 0x16, 0x6d, 0xc1, 0xc5, 0x43, 0x2c, 0x68, 0x1c, 0xe5, 0x9c, 0xc5, 0x44, 0x88,
+	0x28, 0x1e, 0x73, 0x56, 0xe4, 0x2e, 0x28, 0x6b, 0xa4, 0xbe, 0xed, 0xeb, 0x4f, 0x3b, 0xea, 0x0b,
+	0xda, 0x85, 0x66, 0xca, 0x8a, 0x4c, 0x0a, 0xb7, 0xbb, 0xd1, 0x18, 0x75, 0xb7, 0x6f, 0xd6, 0x2c,
+	0xd5, 0xe3, 0x12, 0x14, 0x1a, 0x2c, 0xfa, 0x0a, 0x5a, 0x09, 0x99, 0xd1, 0xb2, 0xe2, 0x3d, 0xe5,
+	0xe6, 0x83, 0x9a, 0x6e, 0x76, 0x15, 0x2a, 0xb4, 0x68, 0x34, 0x81, 0x0b, 0x19, 0x91, 0x2f, 0x18,
+	0x3f, 0x8a, 0xa8, 0x60, 0x53, 0x2c, 0x29, 0xcb, 0xdc, 0xbe, 0x6a, 0xe2, 0x27, 0x35, 0x5d, 0xee,
+	0x69, 0xfc, 0x43, 0x0b, 0x3f, 0xc8, 0x49, 0x1c, 0x0e, 0xb3, 0x53, 0x5a, 0xe4, 0x43, 0x3f, 0x63,
+	0x51, 0x4e, 0x67, 0x4c, 0x46, 0x9c, 0x31, 0xe9, 0x0e, 0x54, 0x8d, 0xba, 0x19, 0xdb, 0x2f, 0x75,
+	0x21, 0x63, 0x12, 0x8d, 0x60, 0x98, 0x90, 0xe7, 0xb8, 0x98, 0xca, 0x28, 0xa7, 0x49, 0x94, 0xb2,
+	0x84, 0xb8, 0xeb, 0xaa, 0x35, 0x03, 0xa3, 0xdf, 0xa7, 0xc9, 0x63, 0x96, 0x90, 0x79, 0x4b, 0x9a,
+	0xc7, 0xda, 0x72, 0xb8, 0x60, 0xf9, 0x30, 0x8f, 0x95, 0xe5, 0xbb, 0xd0, 0x8f, 0xf3, 0x42, 0x10,
+	0x69, 0x7b, 0x73, 0x41, 0x99, 0xf5, 0xb4, 0xd2, 0x74, 0xe5, 0x6d, 0x00, 0x3c, 0x9d, 0xb2, 0x17,
+	0x51, 0x8c, 0x73, 0xe1, 0x22, 0x35, 0x38, 0x1d, 0xa5, 0xd9, 0xc1, 0xb9, 0x40, 0x3e, 0xf4, 0x62,
+	0x9c, 0xe3, 0x43, 0x3a, 0xa5, 0x92, 0x12, 0xe1, 0xbe, 0xa9, 0x0c, 0x16, 0x74, 0xe8, 0x3a, 0x0c,
+	0xe2, 0x09, 0x89, 0x8f, 0x72, 0x46, 0x33, 0x89, 0x0f, 0xa7, 0xc4, 0xbd, 0xa8, 0x47, 0x66, 0x51,
+	0x8b, 0xbe, 0x83, 0x41, 0x39, 0x7e, 0x51, 0x86, 0x53, 0x22, 0x72, 0x1c, 0x13, 0xf7, 0x2d, 0x55,
+	0xee, 0x3b, 0x41, 0xbd, 0xc7, 0x1e, 0x3c, 0x15, 0x84, 0xef, 0x59, 0x70, 0xd8, 0x2f, 0xe6, 0x45,
+	0xff, 0x53, 0xe8, 0x2f, 0x7c, 0x47, 0x97, 0xa1, 0x35, 0x61, 0x42, 0x46, 0x54, 0xbf, 0xe3, 0x7e,
+	0xd8, 0x2c, 0xc5, 0x87, 0xea, 0x29, 0x0b, 0xfa, 0x33, 0x71, 0x57, 0x94, 0x56, 0x9d, 0xfd, 0x1f,
+	0x60, 0x60, 0x19, 0x40, 0xe4, 0x2c, 0x13, 0x04, 0xed, 0x41, 0xcb, 0x8c, 0xb6, 0x82, 0x77, 0xb7,
+	0x6f, 0xd7, 0x4d, 0xd3, 0x8c, 0xfd, 0x81, 0xc4, 0x92, 0x84, 0xd6, 0x89, 0xdf, 0x87, 0xee, 0x33,
+	0x4c, 0xa5, 0x61, 0x18, 0xff, 0x7b, 0xe8, 0x69, 0xf1, 0x35, 0x85, 0x7b, 0x04, 0xeb, 0x07, 0x93,
+	0x42, 0x26, 0xec, 0x45, 0x66, 0x49, 0xed, 0x12, 0x34, 0x05, 0x1d, 0x67, 0x78, 0x6a, 0x78, 0xcd,
+	0x48, 0xe8, 0x1d, 0xe8, 0x8d, 0x39, 0x8e, 0x49, 0x94, 0x13, 0x4e, 0x59, 0xa2, 0xea, 0xd2, 0x08,
+	0xbb, 0x4a, 0xb7, 0xaf, 0x54, 0x3e, 0x82, 0xe1, 0x89, 0x37, 0x9d, 0xb1, 0x3f, 0x81, 0x4b, 0x4f,
+	0xf3, 0xa4, 0x0c, 0x5a, 0x71, 0x99, 0x09, 0xb4, 0xc0, 0x8b, 0xce, 0xff, 0xe6, 0x45, 0xff, 0x0a,
+	0x5c, 0x3e, 0x13, 0xc9, 0x24, 0x31, 0x84, 0xc1, 0x37, 0x84, 0x0b, 0xca, 0xec, 0x2d, 0xfd, 0xf7,
+	0x61, 0xbd, 0xd2, 0x98, 0xda, 0xba, 0xd0, 0x9a, 0x69, 0x95, 0xb9, 0xb9, 0x15, 0xfd, 0xf7, 0xa0,
+	0x57, 0xd6, 0xad, 0xca, 0xdc, 0x83, 0x36, 0xcd, 0x24, 0xe1, 0x33, 0x53, 0xa4, 0x46, 0x58, 0xc9,
+	0xfe, 0x33, 0xe8, 0x1b, 0x5b, 0xe3, 0xf6, 0x4b, 0x58, 0x13, 0xa5, 0x62, 0xc9, 0x2b, 0x3e, 0xc1,
+	0xe2, 0x48, 0x3b, 0xd2, 0x70, 0xff, 0x06, 0xf4, 0x0f, 0x54, 0x27, 0x5e, 0xdd, 0xa8, 0x35, 0xdb,
+	0xa8, 0xf2, 0xb2, 0xd6, 0xd0, 0x5c, 0xff, 0x08, 0xba, 0x0f, 0x8e, 0x49, 0x6c, 0x81, 0x77, 0xa1,
+	0x9d, 0x10, 0x9c, 0x4c, 0x69, 0x46, 0x4c, 0x52, 0x5e, 0xa0, 0x17, 0x64, 0x60, 0x17, 0x64, 0xf0,
+	0xc4, 0x2e, 0xc8, 0xb0, 0xb2, 0xb5, 0xeb, 0x6e, 0xe5, 0xec, 0xba, 0x6b, 0x9c, 0xac, 0x3b, 0x7f,
+	0x07, 0x7a, 0x3a, 0x98, 0xb9, 0xff, 0x25, 0x68, 0xb2, 0x42, 0xe6, 0x85, 0x54, 0xb1, 0x7a, 0xa1,
+	0x91, 0xd0, 0x55, 0xe8, 0x90, 0x63, 0x2a, 0xa3, 0xb8, 0xa4, 0xa6, 0x15, 0x75, 0x83, 0x76, 0xa9,
+	0xd8, 0x61, 0x09, 0xf1, 0xb7, 0xe0, 0xc2, 0x4e, 0x45, 0x0b, 0x36, 0xef, 0xab, 0xd0, 0xa1, 0x29,
+	0x1e, 0x13, 0xb5, 0x91, 0x74, 0x8b, 0xda, 0x4a, 0xb1, 0x4b, 0xb9, 0x7f, 0x11, 0xd0, 0x3c, 0xc2,
+	0xdc, 0xfc, 0x57, 0x18, 0x84, 0x44, 0x48, 0xc6, 0x89, 0x75, 0xf2, 0x35, 0xb4, 0x62, 0x96, 0xa6,
+	0x38, 0x4b, 0x5c, 0x67, 0x39, 0x5e, 0x59, 0xd8, 0xfd, 0xa1, 0xf5, 0xb2, 0x98, 0xd5, 0xca, 0xa9,
+	0xac, 0x30, 0xac, 0x57, 0xf1, 0x5f, 0xd3, 0x13, 0x7e, 0xe9, 0x40, 0x6f, 0xfe, 0x4b, 0xd9, 0xa6,
+	0xdc, 0xb0, 0xd9, 0x5a, 0x58, 0x1e, 0xff, 0xb5, 0xd4, 0x73, 0x63, 0xd4, 0x98, 0x1f, 0x23, 0x14,
+	0xc0, 0x6a, 0xf9, 0x2f, 0xc9, 0x5d, 0xfd, 0xcf, 0x09, 0x51, 0x76, 0xdb, 0x7f, 0x76, 0xa1, 0xfd,
+	0xc0, 0x24, 0x8c, 0x7e, 0x82, 0xa6, 0x2e, 0x17, 0x3a, 0x5f, 0x79, 0xbd, 0xbb, 0xcb, 0xc2, 0x4c,
+	0xc3, 0xdf, 0x40, 0x02, 0x56, 0x4b, 0xca, 0x44, 0xb7, 0xea, 0x7a, 0x98, 0xe3, 0x5b, 0xef, 0xf6,
+	0x72, 0xa0, 0x2a, 0xe8, 0x6f, 0xd0, 0xb6, 0xcc, 0x87, 0xee, 0xd5, 0xf5, 0x71, 0x8a, 0x79, 0xbd,
+	0x0f, 0x97, 0x07, 0x56, 0x09, 0xfc, 0xee, 0xc0, 0xfa, 0x29, 0xf6, 0x43, 0x9f, 0xd5, 0xde, 0x98,
+	0xaf, 0x24, 0x68, 0xef, 0xfe, 0xb9, 0xf1, 0x55, 0x5a, 0xbf, 0x40, 0xcb, 0xd0, 0x2c, 0xaa, 0xdd,
+	0xd1, 0x45, 0xa6, 0xf6, 0xee, 0x2d, 0x8d, 0xab, 0xa2, 0x1f, 0xc3, 0x9a, 0xa2, 0x50, 0x54, 0xbb,
+	0xad, 0xf3, 0x34, 0xef, 0xdd, 0x59, 0x12, 0x65, 0xe3, 0x6e, 0x39, 0xe5, 0xfc, 0x6b, 0x0e, 0xae,
+	0x3f, 0xff, 0x0b, 0xe4, 0xee, 0xdd, 0x5d, 0x16, 0x36, 0x3f, 0xff, 0xe5, 0x33, 0xac, 0x3f, 0xff,
+	0x73, 0xab, 0xc1, 0xbb, 0xbd, 0x1c, 0xa8, 0x0a, 0xfa, 0xd2, 0x01, 0x38, 0xa1, 0x5f, 0xf4, 0x51,
+	0x5d, 0x37, 0x67, 0x48, 0xde, 0xfb, 0xf8, 0x3c, 0xd0, 0xf9, 0x79, 0x33, 0x7c, 0x5b, 0x7f, 0xde,
+	0x16, 0x17, 0x84, 0x77, 0x6f, 0x69, 0x5c, 0x15, 0xfd, 0x0f, 0x07, 0xfa, 0x65, 0x61, 0x0e, 0x24,
+	0x27, 0x38, 0xa5, 0xd9, 0x18, 0xdd, 0xaf, 0xb9, 0xed, 0x4b, 0x94, 0xde, 0xf8, 0x06, 0x69, 0xb3,
+	0xf9, 0xfc, 0xfc, 0x0e, 0x6c, 0x5a, 0x23, 0x67, 0xcb, 0xf9, 0xa2, 0xf5, 0xed, 0x9a, 0x66, 0xee,
+	0xa6, 0xfa, 0xb9, 0xf5, 0xf7, 0x00, 0x42, 0x24, 0xac, 0x00, 0x69, 0x0f, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -964,6 +1198,8 @@ type ExecutorClient interface {
 	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (Executor_StatsClient, error)
 	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*SignalResponse, error)
 	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error)
 }
@@ -1071,6 +1307,24 @@ func (c *executorClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *executorClient) Checkpoint(ctx context.Context, in *CheckpointRequest, opts ...grpc.CallOption) (*CheckpointResponse, error) {
+	out := new(CheckpointResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *executorClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.executor.proto.Executor/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *executorClient) ExecStreaming(ctx context.Context, opts ...grpc.CallOption) (Executor_ExecStreamingClient, error) {
 	stream, err := c.cc.NewStream(ctx, &_Executor_serviceDesc.Streams[1], "/hashicorp.nomad.plugins.executor.proto.Executor/ExecStreaming", opts...)
 	if err != nil {
@@ -1112,6 +1366,8 @@ type ExecutorServer interface {
 	Stats(*StatsRequest, Executor_StatsServer) error
 	Signal(context.Context, *SignalRequest) (*SignalResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	Checkpoint(context.Context, *CheckpointRequest) (*CheckpointResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
 	// buf:lint:ignore RPC_REQUEST_RESPONSE_UNIQUE
 	ExecStreaming(Executor_ExecStreamingServer) error
 }
@@ -1144,6 +1400,12 @@ func (*UnimplementedExecutorServer) Signal(ctx context.Context, req *SignalReque
 func (*UnimplementedExecutorServer) Exec(ctx context.Context, req *ExecRequest) (*ExecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
 }
+func (*UnimplementedExecutorServer) Checkpoint(ctx context.Context, req *CheckpointRequest) (*CheckpointResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Checkpoint not implemented")
+}
+func (*UnimplementedExecutorServer) Restore(ctx context.Context, req *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
 func (*UnimplementedExecutorServer) ExecStreaming(srv Executor_ExecStreamingServer) error {
 	return status.Errorf(codes.Unimplemented, "method ExecStreaming not implemented")
 }
@@ -1299,6 +1561,42 @@ func _Executor_Exec_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Executor_Checkpoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Checkpoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Checkpoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Checkpoint(ctx, req.(*CheckpointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Executor_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExecutorServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.executor.proto.Executor/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExecutorServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Executor_ExecStreaming_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(ExecutorServer).ExecStreaming(&executorExecStreamingServer{stream})
 }
@@ -1357,6 +1655,14 @@ var _Executor_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Exec",
 			Handler:    _Executor_Exec_Handler,
 		},
+		{
+			MethodName: "Checkpoint",
+			Handler:    _Executor_Checkpoint_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _Executor_Restore_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
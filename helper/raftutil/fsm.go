@@ -80,12 +80,20 @@ func dummyFSM(logger hclog.Logger) (nomadFSM, error) {
 		return nil, err
 	}
 
+	// The keyring is only held in memory, so inspecting the raft state
+	// never writes to the keystore of the server.
+	encrypter, err := nomad.NewEncrypter(nil, "")
+	if err != nil {
+		return nil, err
+	}
+
 	fsmConfig := &nomad.FSMConfig{
 		EvalBroker: evalBroker,
 		Periodic:   periodicDispatch,
 		Blocked:    blockedEvals,
 		Logger:     logger,
 		Region:     "default",
+		Encrypter:  encrypter,
 	}
 
 	return nomad.NewFSM(fsmConfig)
@@ -203,6 +211,7 @@ func StateAsMap(store *state.StateStore) map[string][]interface{} {
 		"SITokenAccessors": toArray(store.SITokenAccessors(nil)),
 		"ScalingEvents":    toArray(store.ScalingEvents(nil)),
 		"ScalingPolicies":  toArray(store.ScalingPolicies(nil)),
+		"Variables":        toArray(store.GetVariables(nil)),
 		"VaultAccessors":   toArray(store.VaultAccessors(nil)),
 	}
 
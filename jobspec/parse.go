@@ -305,6 +305,9 @@ func parseSpread(result *[]*api.Spread, list *ast.ObjectList) error {
 			"attribute",
 			"weight",
 			"target",
+			"max_skew",
+			"when_unsatisfiable",
+			"nested_attributes",
 		}
 		if err := checkHCLKeys(o.Val, valid); err != nil {
 			return err
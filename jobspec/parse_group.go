@@ -58,6 +58,7 @@ func parseGroups(result *api.Job, list *ast.ObjectList) error {
 			"scaling",
 			"stop_after_client_disconnect",
 			"max_client_disconnect",
+			"gang",
 		}
 		if err := checkHCLKeys(listVal, valid); err != nil {
 			return multierror.Prefix(err, fmt.Sprintf("'%s' ->", n))
@@ -283,6 +284,7 @@ func parseEphemeralDisk(result **api.EphemeralDisk, list *ast.ObjectList) error
 		"sticky",
 		"size",
 		"migrate",
+		"checkpoint",
 	}
 	if err := checkHCLKeys(obj.Val, valid); err != nil {
 		return err
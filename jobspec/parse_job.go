@@ -68,9 +68,11 @@ func parseJob(result *api.Job, list *ast.ObjectList) error {
 		"meta",
 		"migrate",
 		"name",
+		"node_pool",
 		"namespace",
 		"parameterized",
 		"periodic",
+		"preemptible",
 		"priority",
 		"region",
 		"reschedule",
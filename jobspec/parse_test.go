@@ -39,6 +39,7 @@ func TestParse(t *testing.T) {
 				Name:        stringToPtr("binstore-storagelocker"),
 				Type:        stringToPtr("batch"),
 				Priority:    intToPtr(52),
+				Preemptible: boolToPtr(false),
 				AllAtOnce:   boolToPtr(true),
 				Datacenters: []string{"us2", "eu1"},
 				Region:      stringToPtr("fooregion"),
@@ -207,8 +208,10 @@ func TestParse(t *testing.T) {
 							Attempts: intToPtr(5),
 						},
 						EphemeralDisk: &api.EphemeralDisk{
-							Sticky: boolToPtr(true),
-							SizeMB: intToPtr(150),
+							Sticky:     boolToPtr(true),
+							Migrate:    boolToPtr(true),
+							Checkpoint: true,
+							SizeMB:     intToPtr(150),
 						},
 						Update: &api.UpdateStrategy{
 							MaxParallel:      intToPtr(3),
@@ -519,6 +522,23 @@ func TestParse(t *testing.T) {
 			false,
 		},
 
+		{
+			"spread-max-skew.hcl",
+			&api.Job{
+				ID:   stringToPtr("foo"),
+				Name: stringToPtr("foo"),
+				Spreads: []*api.Spread{
+					{
+						Attribute:         "${meta.zone}",
+						MaxSkew:           1,
+						WhenUnsatisfiable: "do_not_schedule",
+						NestedAttributes:  []string{"${meta.rack}"},
+					},
+				},
+			},
+			false,
+		},
+
 		{
 			"periodic-cron.hcl",
 			&api.Job{
@@ -1767,6 +1787,99 @@ func TestParse(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"resources-numa.hcl",
+			&api.Job{
+				ID:   stringToPtr("numa-test"),
+				Name: stringToPtr("numa-test"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Count: intToPtr(5),
+						Name:  stringToPtr("group"),
+						Tasks: []*api.Task{
+							{
+								Name:   "task",
+								Driver: "docker",
+								Resources: &api.Resources{
+									Cores:    intToPtr(4),
+									MemoryMB: intToPtr(128),
+									NUMA: &api.NUMA{
+										Affinity: "require",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"logs-sinks.hcl",
+			&api.Job{
+				ID:   stringToPtr("logs-sinks"),
+				Name: stringToPtr("logs-sinks"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("group"),
+						Tasks: []*api.Task{
+							{
+								Name:   "task",
+								Driver: "docker",
+								LogConfig: &api.LogConfig{
+									MaxFiles: intToPtr(5),
+									Sinks: []*api.LogSink{
+										{
+											Name:     "siem",
+											Type:     "syslog",
+											Address:  "tcp://10.0.0.1:514",
+											Facility: "local3",
+											Tag:      "web",
+										},
+										{
+											Name:      "collector",
+											Type:      "otlp",
+											Endpoint:  "http://127.0.0.1:4318/v1/logs",
+											Headers:   map[string]string{"X-Scope": "ops"},
+											BatchSize: intToPtr(50),
+											BatchWait: timeToPtr(2 * time.Second),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"logs-rotation.hcl",
+			&api.Job{
+				ID:   stringToPtr("logs-rotation"),
+				Name: stringToPtr("logs-rotation"),
+				TaskGroups: []*api.TaskGroup{
+					{
+						Name: stringToPtr("group"),
+						Tasks: []*api.Task{
+							{
+								Name:   "task",
+								Driver: "docker",
+								LogConfig: &api.LogConfig{
+									MaxFiles:       intToPtr(20),
+									MaxFileSizeMB:  intToPtr(5),
+									RotateEvery:    timeToPtr(time.Hour),
+									Compression:    stringToPtr("zstd"),
+									MaxTotalSizeMB: intToPtr(50),
+									MaxAge:         timeToPtr(72 * time.Hour),
+								},
+							},
+						},
+					},
+				},
+			},
+			false,
+		},
 		{
 			"service-provider.hcl",
 			&api.Job{
@@ -3,6 +3,7 @@ job "binstore-storagelocker" {
   namespace    = "foonamespace"
   type         = "batch"
   priority     = 52
+  preemptible  = false
   all_at_once  = true
   datacenters  = ["us2", "eu1"]
   consul_token = "abc"
@@ -111,8 +112,10 @@ job "binstore-storagelocker" {
     }
 
     ephemeral_disk {
-      sticky = true
-      size   = 150
+      sticky     = true
+      migrate    = true
+      checkpoint = true
+      size       = 150
     }
 
     update {
@@ -0,0 +1,16 @@
+job "logs-rotation" {
+  group "group" {
+    task "task" {
+      driver = "docker"
+
+      logs {
+        max_files      = 20
+        max_file_size  = 5
+        rotate_every   = "1h"
+        compression    = "zstd"
+        max_total_size = 50
+        max_age        = "72h"
+      }
+    }
+  }
+}
@@ -0,0 +1,29 @@
+job "logs-sinks" {
+  group "group" {
+    task "task" {
+      driver = "docker"
+
+      logs {
+        max_files = 5
+
+        sink "siem" {
+          type     = "syslog"
+          address  = "tcp://10.0.0.1:514"
+          facility = "local3"
+          tag      = "web"
+        }
+
+        sink "collector" {
+          type       = "otlp"
+          endpoint   = "http://127.0.0.1:4318/v1/logs"
+          batch_size = 50
+          batch_wait = "2s"
+
+          headers = {
+            "X-Scope" = "ops"
+          }
+        }
+      }
+    }
+  }
+}
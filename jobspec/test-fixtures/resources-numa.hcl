@@ -0,0 +1,18 @@
+job "numa-test" {
+  group "group" {
+    count = 5
+
+    task "task" {
+      driver = "docker"
+
+      resources {
+        cores  = 4
+        memory = 128
+
+        numa {
+          affinity = "require"
+        }
+      }
+    }
+  }
+}
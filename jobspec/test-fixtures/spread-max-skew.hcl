@@ -0,0 +1,8 @@
+job "foo" {
+  spread {
+    attribute          = "${meta.zone}"
+    max_skew           = 1
+    when_unsatisfiable = "do_not_schedule"
+    nested_attributes  = ["${meta.rack}"]
+  }
+}
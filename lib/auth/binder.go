@@ -0,0 +1,157 @@
+// Package auth contains the logic shared by ACL auth methods, such as
+// evaluating the binding rules of an auth method against the claims of an
+// authenticated identity.
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// BinderStateStore is the subset of the state store the Binder uses to look up
+// binding rules and the roles and policies they link to.
+type BinderStateStore interface {
+	GetACLBindingRulesByAuthMethod(ws memdb.WatchSet, authMethod string) (memdb.ResultIterator, error)
+	GetACLRoleByName(ws memdb.WatchSet, roleName string) (*structs.ACLRole, error)
+	ACLPolicyByName(ws memdb.WatchSet, name string) (*structs.ACLPolicy, error)
+}
+
+// Binder computes the roles and policies linked to the tokens of identities
+// authenticated by an auth method.
+type Binder struct {
+	store BinderStateStore
+}
+
+// NewBinder returns a Binder which reads from the passed state store.
+func NewBinder(store BinderStateStore) *Binder {
+	return &Binder{store: store}
+}
+
+// Bindings are the roles and policies an authenticated identity is granted.
+type Bindings struct {
+	Roles    []*structs.ACLTokenRoleLink
+	Policies []string
+}
+
+// None returns whether the identity was not granted any role or policy, in
+// which case it must not be issued a token.
+func (b *Bindings) None() bool {
+	return b == nil || (len(b.Roles) == 0 && len(b.Policies) == 0)
+}
+
+// Bind evaluates the binding rules of the auth method against the claims of
+// the identity. Binding rules linking to a role or policy that does not exist
+// are ignored, since they don't grant any privilege.
+func (b *Binder) Bind(authMethod *structs.ACLAuthMethod, claims *structs.ACLAuthClaims) (*Bindings, error) {
+	iter, err := b.store.GetACLBindingRulesByAuthMethod(nil, authMethod.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	var bindings Bindings
+	seenRoles := make(map[string]struct{})
+	seenPolicies := make(map[string]struct{})
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		rule := raw.(*structs.ACLBindingRule)
+
+		matched, err := selectorMatches(rule.Selector, claims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate selector of binding rule %s: %v", rule.ID, err)
+		}
+		if !matched {
+			continue
+		}
+
+		bindName, ok := interpolateBindName(rule.BindName, claims)
+		if !ok {
+			continue
+		}
+
+		switch rule.BindType {
+		case structs.ACLBindingRuleBindTypeRole:
+			role, err := b.store.GetACLRoleByName(nil, bindName)
+			if err != nil {
+				return nil, err
+			}
+			if role == nil {
+				continue
+			}
+			if _, ok := seenRoles[role.ID]; !ok {
+				seenRoles[role.ID] = struct{}{}
+				bindings.Roles = append(bindings.Roles, &structs.ACLTokenRoleLink{ID: role.ID, Name: role.Name})
+			}
+
+		case structs.ACLBindingRuleBindTypePolicy:
+			policy, err := b.store.ACLPolicyByName(nil, bindName)
+			if err != nil {
+				return nil, err
+			}
+			if policy == nil {
+				continue
+			}
+			if _, ok := seenPolicies[policy.Name]; !ok {
+				seenPolicies[policy.Name] = struct{}{}
+				bindings.Policies = append(bindings.Policies, policy.Name)
+			}
+		}
+	}
+
+	return &bindings, nil
+}
+
+// selectorMatches evaluates the selector of a binding rule against the claims.
+// An empty selector matches all identities, while a selector referencing a
+// claim the identity does not have does not match it.
+func selectorMatches(selector string, claims *structs.ACLAuthClaims) (bool, error) {
+	if selector == "" {
+		return true, nil
+	}
+
+	eval, err := bexpr.CreateEvaluator(selector)
+	if err != nil {
+		return false, err
+	}
+	matched, err := eval.Evaluate(claims)
+	if err != nil {
+		return false, nil
+	}
+	return matched, nil
+}
+
+// interpolateBindName replaces the "${value.<name>}" references within the
+// bind name with the mapped claim values. It returns false when the bind name
+// references a value the identity does not have.
+func interpolateBindName(bindName string, claims *structs.ACLAuthClaims) (string, bool) {
+	var out strings.Builder
+	for {
+		start := strings.Index(bindName, "${")
+		if start < 0 {
+			out.WriteString(bindName)
+			return out.String(), true
+		}
+		end := strings.Index(bindName[start:], "}")
+		if end < 0 {
+			out.WriteString(bindName)
+			return out.String(), true
+		}
+		end += start
+
+		ref := strings.TrimSpace(bindName[start+2 : end])
+		if !strings.HasPrefix(ref, "value.") {
+			return "", false
+		}
+		value, ok := claims.Value[strings.TrimPrefix(ref, "value.")]
+		if !ok {
+			return "", false
+		}
+
+		out.WriteString(bindName[:start])
+		out.WriteString(value)
+		bindName = bindName[end+1:]
+	}
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinder_Bind(t *testing.T) {
+	ci.Parallel(t)
+
+	testStore := state.TestStateStore(t)
+
+	// Create the policies and roles the binding rules link to.
+	policy := mock.ACLPolicy()
+	policy.Name = "team-platform"
+	fooPolicy := mock.ACLPolicy()
+	fooPolicy.Name = "foo"
+	barPolicy := mock.ACLPolicy()
+	barPolicy.Name = "bar"
+	require.NoError(t, testStore.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy, fooPolicy, barPolicy}))
+
+	role := mock.ACLRole()
+	require.NoError(t, testStore.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{role}, false))
+
+	authMethod := mock.ACLAuthMethod()
+	otherAuthMethod := mock.ACLAuthMethod()
+	require.NoError(t, testStore.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLAuthMethod{authMethod, otherAuthMethod}))
+
+	rules := []*structs.ACLBindingRule{
+		// Matches engineers and binds the role.
+		{
+			ID:         "rule-role",
+			AuthMethod: authMethod.Name,
+			Selector:   `"engineering" in list.groups`,
+			BindType:   structs.ACLBindingRuleBindTypeRole,
+			BindName:   role.Name,
+		},
+		// Matches everyone and binds the policy of their team.
+		{
+			ID:         "rule-team",
+			AuthMethod: authMethod.Name,
+			BindType:   structs.ACLBindingRuleBindTypePolicy,
+			BindName:   "team-${value.team}",
+		},
+		// Links to a policy which does not exist.
+		{
+			ID:         "rule-missing",
+			AuthMethod: authMethod.Name,
+			BindType:   structs.ACLBindingRuleBindTypePolicy,
+			BindName:   "does-not-exist",
+		},
+		// Belongs to another auth method.
+		{
+			ID:         "rule-other",
+			AuthMethod: otherAuthMethod.Name,
+			BindType:   structs.ACLBindingRuleBindTypePolicy,
+			BindName:   fooPolicy.Name,
+		},
+	}
+	for _, rule := range rules {
+		rule.SetHash()
+	}
+	require.NoError(t, testStore.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 40, rules, false))
+
+	binder := NewBinder(testStore)
+
+	// An engineer of the platform team gets the role and the team policy.
+	bindings, err := binder.Bind(authMethod, &structs.ACLAuthClaims{
+		Value: map[string]string{"team": "platform"},
+		List:  map[string][]string{"groups": {"engineering"}},
+	})
+	require.NoError(t, err)
+	require.False(t, bindings.None())
+	require.Equal(t, []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, bindings.Roles)
+	require.Equal(t, []string{"team-platform"}, bindings.Policies)
+
+	// An identity without groups or a team gets nothing.
+	bindings, err = binder.Bind(authMethod, &structs.ACLAuthClaims{})
+	require.NoError(t, err)
+	require.True(t, bindings.None())
+}
+
+func TestInterpolateBindName(t *testing.T) {
+	ci.Parallel(t)
+
+	claims := &structs.ACLAuthClaims{
+		Value: map[string]string{"team": "platform", "env": "prod"},
+	}
+
+	cases := []struct {
+		bindName string
+		expected string
+		ok       bool
+	}{
+		{"static", "static", true},
+		{"team-${value.team}", "team-platform", true},
+		{"${value.team}-${ value.env }", "platform-prod", true},
+		{"team-${value.missing}", "", false},
+		{"team-${list.groups}", "", false},
+		{"unterminated-${value.team", "unterminated-${value.team", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.bindName, func(t *testing.T) {
+			out, ok := interpolateBindName(tc.bindName, claims)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.expected, out)
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package oidc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ProviderCache caches the providers of auth methods, so OIDC discovery and
+// the fetching of signing keys is not performed on every login. A provider is
+// recreated whenever its auth method is modified.
+type ProviderCache struct {
+	providers map[string]*cachedProvider
+	lock      sync.Mutex
+}
+
+// cachedProvider is a provider along with the modify index of the auth method
+// it was created from.
+type cachedProvider struct {
+	modifyIndex uint64
+	provider    *Provider
+}
+
+// NewProviderCache returns an empty ProviderCache.
+func NewProviderCache() *ProviderCache {
+	return &ProviderCache{
+		providers: make(map[string]*cachedProvider),
+	}
+}
+
+// Get returns the provider of the auth method, creating it if it is not
+// cached or the cached provider was created from an older version of the auth
+// method.
+func (c *ProviderCache) Get(ctx context.Context, authMethod *structs.ACLAuthMethod) (*Provider, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.providers[authMethod.Name]; ok && cached.modifyIndex == authMethod.ModifyIndex {
+		return cached.provider, nil
+	}
+
+	provider, err := NewProvider(ctx, authMethod.Config)
+	if err != nil {
+		return nil, err
+	}
+	c.providers[authMethod.Name] = &cachedProvider{
+		modifyIndex: authMethod.ModifyIndex,
+		provider:    provider,
+	}
+	return provider, nil
+}
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// SelectorData applies the claim mappings of the auth method to the claims of
+// an ID token, returning the data binding rules are evaluated against. Claims
+// which are mapped but missing from the ID token are skipped.
+//
+// A claim is referenced by its name, or by a JSON pointer such as
+// "/groups/0" when it is nested within another claim.
+func SelectorData(authMethod *structs.ACLAuthMethod, claims map[string]interface{}) (*structs.ACLAuthClaims, error) {
+	out := &structs.ACLAuthClaims{
+		Value: make(map[string]string),
+		List:  make(map[string][]string),
+	}
+	if authMethod.Config == nil {
+		return out, nil
+	}
+
+	for claim, name := range authMethod.Config.ClaimMappings {
+		raw, ok := getClaim(claims, claim)
+		if !ok {
+			continue
+		}
+		value, ok := stringifyClaim(raw)
+		if !ok {
+			return nil, fmt.Errorf("claim %q cannot be converted to a string", claim)
+		}
+		out.Value[name] = value
+	}
+
+	for claim, name := range authMethod.Config.ListClaimMappings {
+		raw, ok := getClaim(claims, claim)
+		if !ok {
+			continue
+		}
+		rawList, ok := raw.([]interface{})
+		if !ok {
+			rawList = []interface{}{raw}
+		}
+		list := make([]string, 0, len(rawList))
+		for _, rawValue := range rawList {
+			value, ok := stringifyClaim(rawValue)
+			if !ok {
+				return nil, fmt.Errorf("claim %q cannot be converted to a list of strings", claim)
+			}
+			list = append(list, value)
+		}
+		out.List[name] = list
+	}
+
+	return out, nil
+}
+
+// getClaim returns the claim referenced by name or JSON pointer.
+func getClaim(claims map[string]interface{}, claim string) (interface{}, bool) {
+	if !strings.HasPrefix(claim, "/") {
+		raw, ok := claims[claim]
+		return raw, ok
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(strings.TrimPrefix(claim, "/"), "/") {
+		// Unescape the part, as defined by RFC 6901.
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[part]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case []interface{}:
+			idx, err := strconv.Atoi(part)
+			if err != nil || idx < 0 || idx >= len(v) {
+				return nil, false
+			}
+			current = v[idx]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// stringifyClaim converts a scalar claim value into a string.
+func stringifyClaim(raw interface{}) (string, bool) {
+	switch v := raw.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectorData(t *testing.T) {
+	ci.Parallel(t)
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Config.ClaimMappings = map[string]string{
+		"email":           "email",
+		"/profile/team":   "team",
+		"admin":           "admin",
+		"missing":         "missing",
+		"/profile/nope/0": "nope",
+	}
+	authMethod.Config.ListClaimMappings = map[string]string{
+		"groups":  "groups",
+		"project": "projects",
+	}
+
+	claims := map[string]interface{}{
+		"email":   "alice@example.com",
+		"admin":   true,
+		"profile": map[string]interface{}{"team": "platform"},
+		"groups":  []interface{}{"engineering", "oncall"},
+		"project": "nomad",
+	}
+
+	data, err := SelectorData(authMethod, claims)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"email": "alice@example.com",
+		"team":  "platform",
+		"admin": "true",
+	}, data.Value)
+	require.Equal(t, map[string][]string{
+		"groups":   {"engineering", "oncall"},
+		"projects": {"nomad"},
+	}, data.List)
+
+	// Claims which are not scalars cannot be mapped to values.
+	authMethod.Config.ClaimMappings = map[string]string{"groups": "groups"}
+	_, err = SelectorData(authMethod, claims)
+	require.ErrorContains(t, err, "cannot be converted to a string")
+}
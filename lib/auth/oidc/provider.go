@@ -0,0 +1,302 @@
+// Package oidc implements the OpenID Connect authorization code flow used by
+// ACL auth methods of type OIDC. It discovers the configuration of the
+// identity provider, generates the URL users authenticate with, and exchanges
+// the authorization code for a verified set of ID token claims.
+package oidc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/oauth2"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// discoveryPath is appended to the discovery URL of the auth method to
+	// retrieve the configuration of the identity provider.
+	discoveryPath = "/.well-known/openid-configuration"
+
+	// requestTimeout is the timeout of the requests made to the identity
+	// provider.
+	requestTimeout = 30 * time.Second
+
+	// claimsLeeway is the leeway allowed when checking the time based claims
+	// of ID tokens, to account for clock skew.
+	claimsLeeway = time.Minute
+)
+
+// discoveryDocument is the subset of the OpenID provider metadata used by the
+// authorization code flow.
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider performs the authorization code flow against the identity provider
+// of a single ACL auth method.
+type Provider struct {
+	config     *structs.ACLAuthMethodConfig
+	discovery  *discoveryDocument
+	httpClient *http.Client
+
+	// keys is the cached key set of the identity provider, which is
+	// refreshed when an ID token is signed by an unknown key.
+	keys     *jose.JSONWebKeySet
+	keysLock sync.Mutex
+}
+
+// NewProvider creates a Provider for the configuration of an auth method. It
+// performs OIDC discovery, so the identity provider must be reachable.
+func NewProvider(ctx context.Context, config *structs.ACLAuthMethodConfig) (*Provider, error) {
+	if config == nil {
+		return nil, errors.New("missing auth method config")
+	}
+
+	httpClient, err := newHTTPClient(config.DiscoveryCaPem)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Provider{
+		config:     config,
+		httpClient: httpClient,
+	}
+
+	discoveryURL := strings.TrimSuffix(config.OIDCDiscoveryURL, "/") + discoveryPath
+	var doc discoveryDocument
+	if err := p.getJSON(ctx, discoveryURL, &doc); err != nil {
+		return nil, fmt.Errorf("failed to query OIDC discovery URL: %v", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(config.OIDCDiscoveryURL, "/") {
+		return nil, fmt.Errorf("OIDC issuer %q does not match discovery URL %q", doc.Issuer, config.OIDCDiscoveryURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing required endpoints")
+	}
+	p.discovery = &doc
+
+	return p, nil
+}
+
+// newHTTPClient returns the client used to talk to the identity provider. If
+// CA certificates are passed, they are used instead of the system pool.
+func newHTTPClient(caPems []string) (*http.Client, error) {
+	transport := cleanhttp.DefaultPooledTransport()
+	if len(caPems) > 0 {
+		pool := x509.NewCertPool()
+		for _, caPem := range caPems {
+			if !pool.AppendCertsFromPEM([]byte(caPem)) {
+				return nil, errors.New("could not parse discovery CA certificate")
+			}
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return &http.Client{Transport: transport, Timeout: requestTimeout}, nil
+}
+
+// oauth2Config returns the OAuth2 configuration of the provider for the given
+// redirect URI.
+func (p *Provider) oauth2Config(redirectURI string) *oauth2.Config {
+	scopes := append([]string{"openid"}, p.config.OIDCScopes...)
+	return &oauth2.Config{
+		ClientID:     p.config.OIDCClientID,
+		ClientSecret: p.config.OIDCClientSecret,
+		RedirectURL:  redirectURI,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.discovery.AuthorizationEndpoint,
+			TokenURL: p.discovery.TokenEndpoint,
+		},
+	}
+}
+
+// AuthURL returns the URL the user should visit to authenticate with the
+// identity provider. The state is passed back to the redirect URI, while the
+// nonce is embedded in the issued ID token.
+func (p *Provider) AuthURL(redirectURI, state, nonce string) (string, error) {
+	if err := p.validateRedirectURI(redirectURI); err != nil {
+		return "", err
+	}
+	return p.oauth2Config(redirectURI).AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce)), nil
+}
+
+// Exchange exchanges the authorization code for an ID token, verifies it and
+// returns its claims. The nonce must match the nonce used to generate the auth
+// URL.
+func (p *Provider) Exchange(ctx context.Context, redirectURI, code, nonce string) (map[string]interface{}, error) {
+	if err := p.validateRedirectURI(redirectURI); err != nil {
+		return nil, err
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2Config(redirectURI).Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %v", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response did not contain an ID token")
+	}
+
+	return p.VerifyIDToken(ctx, rawIDToken, nonce)
+}
+
+// VerifyIDToken verifies the signature and the claims of the raw ID token and
+// returns its claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (map[string]interface{}, error) {
+	token, err := jwt.ParseSigned(rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ID token: %v", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, errors.New("ID token must have a single signature")
+	}
+	header := token.Headers[0]
+
+	if !p.allowedSigningAlg(header.Algorithm) {
+		return nil, fmt.Errorf("ID token signed with unsupported algorithm %q", header.Algorithm)
+	}
+
+	keys, err := p.verificationKeys(ctx, header.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims jwt.Claims
+	var allClaims map[string]interface{}
+	var verifyErr error
+	for _, key := range keys {
+		if verifyErr = token.Claims(key.Key, &claims, &allClaims); verifyErr == nil {
+			break
+		}
+	}
+	if verifyErr != nil {
+		return nil, fmt.Errorf("failed to verify ID token signature: %v", verifyErr)
+	}
+
+	expected := jwt.Expected{
+		Issuer: p.discovery.Issuer,
+		Time:   time.Now(),
+	}
+	if err := claims.ValidateWithLeeway(expected, claimsLeeway); err != nil {
+		return nil, fmt.Errorf("failed to validate ID token claims: %v", err)
+	}
+	if claims.Expiry == nil {
+		return nil, errors.New("ID token is missing the exp claim")
+	}
+
+	audiences := p.config.BoundAudiences
+	if len(audiences) == 0 {
+		audiences = []string{p.config.OIDCClientID}
+	}
+	var audienceMatched bool
+	for _, aud := range audiences {
+		if claims.Audience.Contains(aud) {
+			audienceMatched = true
+			break
+		}
+	}
+	if !audienceMatched {
+		return nil, errors.New("ID token audience does not match the bound audiences")
+	}
+
+	if tokenNonce, _ := allClaims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("ID token nonce does not match the client nonce")
+	}
+
+	return allClaims, nil
+}
+
+// allowedSigningAlg returns whether ID tokens signed using the algorithm are
+// accepted. RS256 is the only algorithm accepted when none are configured, as
+// it is the default of the OIDC specification.
+func (p *Provider) allowedSigningAlg(alg string) bool {
+	algs := p.config.SigningAlgs
+	if len(algs) == 0 {
+		algs = []string{string(jose.RS256)}
+	}
+	for _, allowed := range algs {
+		if alg == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// verificationKeys returns the keys of the identity provider which may have
+// signed a token with the key ID. The key set is refreshed once if no key
+// matches, to support key rotation by the identity provider.
+func (p *Provider) verificationKeys(ctx context.Context, keyID string) ([]jose.JSONWebKey, error) {
+	p.keysLock.Lock()
+	defer p.keysLock.Unlock()
+
+	for refreshed := false; ; refreshed = true {
+		if p.keys == nil || refreshed {
+			var keys jose.JSONWebKeySet
+			if err := p.getJSON(ctx, p.discovery.JWKSURI, &keys); err != nil {
+				return nil, fmt.Errorf("failed to fetch OIDC signing keys: %v", err)
+			}
+			p.keys = &keys
+		}
+
+		var keys []jose.JSONWebKey
+		if keyID == "" {
+			keys = p.keys.Keys
+		} else {
+			keys = p.keys.Key(keyID)
+		}
+		if len(keys) > 0 {
+			return keys, nil
+		}
+		if refreshed {
+			return nil, fmt.Errorf("no OIDC signing key found with ID %q", keyID)
+		}
+	}
+}
+
+// validateRedirectURI ensures the redirect URI is one of the allowed redirect
+// URIs of the auth method.
+func (p *Provider) validateRedirectURI(redirectURI string) error {
+	for _, allowed := range p.config.AllowedRedirectURIs {
+		if redirectURI == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("redirect URI %q is not allowed", redirectURI)
+}
+
+// getJSON performs a GET request against the identity provider and decodes
+// the JSON response body into out.
+func (p *Provider) getJSON(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("unexpected response code %d: %s", resp.StatusCode, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
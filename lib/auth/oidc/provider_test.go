@@ -0,0 +1,117 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+const testRedirectURI = "http://127.0.0.1:4649/oidc/callback"
+
+// testAuthorize visits the auth URL and returns the authorization code and
+// state the TestProvider redirects to the redirect URI with.
+func testAuthorize(t *testing.T, authURL string) (string, string) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+	testProvider.SetClaims(map[string]interface{}{
+		"groups": []string{"engineering"},
+	})
+
+	provider, err := NewProvider(context.Background(), testProvider.AuthMethodConfig(testRedirectURI))
+	require.NoError(t, err)
+
+	// Redirect URIs which are not allowed should be rejected.
+	_, err = provider.AuthURL("http://evil.example.com/callback", "state", "nonce")
+	require.ErrorContains(t, err, "is not allowed")
+
+	authURL, err := provider.AuthURL(testRedirectURI, "my-state", "my-nonce")
+	require.NoError(t, err)
+
+	code, state := testAuthorize(t, authURL)
+	require.NotEmpty(t, code)
+	require.Equal(t, "my-state", state)
+
+	claims, err := provider.Exchange(context.Background(), testRedirectURI, code, "my-nonce")
+	require.NoError(t, err)
+	require.Equal(t, "alice", claims["sub"])
+	require.Equal(t, []interface{}{"engineering"}, claims["groups"])
+
+	// Authorization codes can only be used once.
+	_, err = provider.Exchange(context.Background(), testRedirectURI, code, "my-nonce")
+	require.Error(t, err)
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+	config := testProvider.AuthMethodConfig(testRedirectURI)
+
+	provider, err := NewProvider(context.Background(), config)
+	require.NoError(t, err)
+
+	idToken, err := testProvider.IssueIDToken("my-nonce")
+	require.NoError(t, err)
+
+	// A valid token should be accepted.
+	_, err = provider.VerifyIDToken(context.Background(), idToken, "my-nonce")
+	require.NoError(t, err)
+
+	// A different nonce indicates a replayed token.
+	_, err = provider.VerifyIDToken(context.Background(), idToken, "other-nonce")
+	require.ErrorContains(t, err, "nonce")
+
+	// A token issued by another provider should not verify.
+	otherProvider := NewTestProvider(t)
+	otherToken, err := otherProvider.IssueIDToken("my-nonce")
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(context.Background(), otherToken, "my-nonce")
+	require.Error(t, err)
+
+	// The audience must match one of the bound audiences.
+	config.BoundAudiences = []string{"someone-else"}
+	provider, err = NewProvider(context.Background(), config)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(context.Background(), idToken, "my-nonce")
+	require.ErrorContains(t, err, "audience")
+
+	// Only the allowed signing algorithms are accepted.
+	config.BoundAudiences = nil
+	config.SigningAlgs = []string{"ES256"}
+	provider, err = NewProvider(context.Background(), config)
+	require.NoError(t, err)
+	_, err = provider.VerifyIDToken(context.Background(), idToken, "my-nonce")
+	require.ErrorContains(t, err, "unsupported algorithm")
+}
+
+func TestNewProvider_InvalidDiscovery(t *testing.T) {
+	ci.Parallel(t)
+
+	testProvider := NewTestProvider(t)
+	config := testProvider.AuthMethodConfig(testRedirectURI)
+	config.OIDCDiscoveryURL = testProvider.Issuer() + "/nope"
+
+	_, err := NewProvider(context.Background(), config)
+	require.ErrorContains(t, err, "failed to query OIDC discovery URL")
+}
@@ -0,0 +1,236 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"gopkg.in/square/go-jose.v2"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+const (
+	// TestClientID and TestClientSecret are the OAuth client credentials the
+	// TestProvider accepts.
+	TestClientID     = "nomad-test-client"
+	TestClientSecret = "nomad-test-secret"
+)
+
+// TestProvider is a mock OIDC identity provider for use in tests. Users are
+// authenticated immediately when visiting the authorization endpoint, which
+// redirects back to the redirect URI with an authorization code.
+type TestProvider struct {
+	server  *httptest.Server
+	key     *rsa.PrivateKey
+	keyID   string
+	subject string
+
+	// claims are the custom claims added to issued ID tokens.
+	claims map[string]interface{}
+
+	// codes maps issued authorization codes to the nonce and redirect URI of
+	// the request they were issued for.
+	codes map[string]testAuthRequest
+
+	lock sync.Mutex
+}
+
+// testAuthRequest is the authorization request an authorization code was
+// issued for.
+type testAuthRequest struct {
+	nonce       string
+	redirectURI string
+}
+
+// NewTestProvider starts a TestProvider, which is stopped when the test
+// completes.
+func NewTestProvider(t testing.TB) *TestProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	p := &TestProvider{
+		key:     key,
+		keyID:   uuid.Generate(),
+		subject: "alice",
+		claims:  make(map[string]interface{}),
+		codes:   make(map[string]testAuthRequest),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, p.handleDiscovery)
+	mux.HandleFunc("/keys", p.handleKeys)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// Issuer returns the issuer of the TestProvider, which is also its discovery
+// URL.
+func (p *TestProvider) Issuer() string {
+	return p.server.URL
+}
+
+// SetClaims sets the custom claims added to the ID tokens issued after the
+// call.
+func (p *TestProvider) SetClaims(claims map[string]interface{}) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.claims = claims
+}
+
+// AuthMethodConfig returns an auth method config which authenticates against
+// the TestProvider using the passed redirect URIs.
+func (p *TestProvider) AuthMethodConfig(redirectURIs ...string) *structs.ACLAuthMethodConfig {
+	return &structs.ACLAuthMethodConfig{
+		OIDCDiscoveryURL:    p.Issuer(),
+		OIDCClientID:        TestClientID,
+		OIDCClientSecret:    TestClientSecret,
+		AllowedRedirectURIs: redirectURIs,
+	}
+}
+
+// IssueIDToken returns a signed ID token for the given nonce, including the
+// custom claims of the provider.
+func (p *TestProvider) IssueIDToken(nonce string) (string, error) {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{
+			Algorithm: jose.RS256,
+			Key:       jose.JSONWebKey{Key: p.key, KeyID: p.keyID},
+		},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.Claims{
+		Issuer:   p.Issuer(),
+		Subject:  p.subject,
+		Audience: jwt.Audience{TestClientID},
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(5 * time.Minute)),
+	}
+
+	p.lock.Lock()
+	custom := make(map[string]interface{}, len(p.claims)+1)
+	for k, v := range p.claims {
+		custom[k] = v
+	}
+	p.lock.Unlock()
+	custom["nonce"] = nonce
+
+	return jwt.Signed(signer).Claims(claims).Claims(custom).CompactSerialize()
+}
+
+func (p *TestProvider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeTestJSON(w, http.StatusOK, &discoveryDocument{
+		Issuer:                p.Issuer(),
+		AuthorizationEndpoint: p.Issuer() + "/authorize",
+		TokenEndpoint:         p.Issuer() + "/token",
+		JWKSURI:               p.Issuer() + "/keys",
+	})
+}
+
+func (p *TestProvider) handleKeys(w http.ResponseWriter, _ *http.Request) {
+	writeTestJSON(w, http.StatusOK, &jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{
+			Key:       p.key.Public(),
+			KeyID:     p.keyID,
+			Algorithm: string(jose.RS256),
+			Use:       "sig",
+		}},
+	})
+}
+
+func (p *TestProvider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != TestClientID {
+		http.Error(w, "invalid client_id", http.StatusBadRequest)
+		return
+	}
+	if query.Get("response_type") != "code" {
+		http.Error(w, "invalid response_type", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := uuid.Generate()
+	p.lock.Lock()
+	p.codes[code] = testAuthRequest{
+		nonce:       query.Get("nonce"),
+		redirectURI: redirectURI.String(),
+	}
+	p.lock.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *TestProvider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != TestClientID || clientSecret != TestClientSecret {
+		writeTestJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.lock.Lock()
+	authReq, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	p.lock.Unlock()
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != authReq.redirectURI {
+		writeTestJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := p.IssueIDToken(authReq.nonce)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to issue ID token: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeTestJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": uuid.Generate(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func writeTestJSON(w http.ResponseWriter, code int, out interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(out)
+}
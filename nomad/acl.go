@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"strings"
 	"time"
 
 	metrics "github.com/armon/go-metrics"
@@ -31,10 +32,82 @@ func (s *Server) ResolveToken(secretID string) (*acl.ACL, error) {
 		return nil, err
 	}
 
+	// Check if the secret ID is a workload identity, in which case the ACL
+	// is built from the implicit policy of the workload.
+	if isWorkloadIdentity(secretID) {
+		claims, err := s.encrypter.VerifyClaim(secretID)
+		if err != nil {
+			s.logger.Debug("failed to verify workload identity", "error", err)
+			return nil, structs.ErrTokenNotFound
+		}
+		return resolveClaimsFromSnapshotCache(snap, s.aclCache, claims)
+	}
+
 	// Resolve the ACL
 	return resolveTokenFromSnapshotCache(snap, s.aclCache, secretID)
 }
 
+// isWorkloadIdentity returns whether the secret ID is a workload identity
+// JWT rather than the UUID secret ID of an ACL token.
+func isWorkloadIdentity(secretID string) bool {
+	return strings.Count(secretID, ".") == 2
+}
+
+// resolveClaimsFromSnapshotCache is used to resolve the ACL object of a
+// workload identity from a snapshot of state. The identity is only valid
+// while its allocation is running, and grants the implicit policy of the
+// workload, which allows reading the variables of its job, group and task.
+// The service registration endpoints also let workloads read the services of
+// their own namespace.
+func resolveClaimsFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueueCache, claims *structs.IdentityClaims) (*acl.ACL, error) {
+	alloc, err := snap.AllocByID(nil, claims.AllocationID)
+	if err != nil {
+		return nil, err
+	}
+	if alloc == nil || alloc.TerminalStatus() ||
+		alloc.Namespace != claims.Namespace || alloc.JobID != claims.JobID ||
+		alloc.TaskGroup != claims.TaskGroup {
+		return nil, structs.ErrTokenNotFound
+	}
+
+	// The implicit policy only depends on the namespace, job, group and task,
+	// so the compiled ACL object can be shared by all allocations of the job.
+	cacheKey := "workload-identity:" + claims.Namespace + "/" + claims.JobID +
+		"/" + claims.TaskGroup + "/" + claims.TaskName
+	if raw, ok := cache.Get(cacheKey); ok {
+		return raw.(*acl.ACL), nil
+	}
+
+	aclObj, err := acl.NewACL(false, []*acl.Policy{workloadIdentityPolicy(claims)})
+	if err != nil {
+		return nil, err
+	}
+	cache.Add(cacheKey, aclObj)
+	return aclObj, nil
+}
+
+// workloadIdentityPolicy returns the implicit policy of a workload identity,
+// which grants access to the variables of its job, of its group within the
+// job and of its task within the group.
+func workloadIdentityPolicy(claims *structs.IdentityClaims) *acl.Policy {
+	jobPath := structs.VariablesJobsPrefix + "/" + claims.JobID
+	groupPath := jobPath + "/" + claims.TaskGroup
+	taskPath := groupPath + "/" + claims.TaskName
+	capabilities := []string{acl.VariablesCapabilityRead, acl.VariablesCapabilityList}
+	return &acl.Policy{
+		Namespaces: []*acl.NamespacePolicy{{
+			Name: claims.Namespace,
+			Variables: &acl.VariablesPolicy{
+				Paths: []*acl.VariablesPathPolicy{
+					{PathSpec: jobPath, Capabilities: capabilities},
+					{PathSpec: groupPath, Capabilities: capabilities},
+					{PathSpec: taskPath, Capabilities: capabilities},
+				},
+			},
+		}},
+	}
+}
+
 // resolveTokenFromSnapshotCache is used to resolve an ACL object from a snapshot of state,
 // using a cache to avoid parsing and ACL construction when possible. It is split from resolveToken
 // to simplify testing.
@@ -54,6 +127,9 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		if token == nil {
 			return nil, structs.ErrTokenNotFound
 		}
+		if token.IsExpired(time.Now().UTC()) {
+			return nil, structs.ErrTokenExpired
+		}
 	}
 
 	// Check if this is a management token
@@ -61,9 +137,14 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		return acl.ManagementACL, nil
 	}
 
-	// Get all associated policies
-	policies := make([]*structs.ACLPolicy, 0, len(token.Policies))
-	for _, policyName := range token.Policies {
+	// Get all associated policies, including those granted through the
+	// token's roles
+	policyNames, err := tokenPolicyNames(snap, token)
+	if err != nil {
+		return nil, err
+	}
+	policies := make([]*structs.ACLPolicy, 0, len(policyNames))
+	for _, policyName := range policyNames {
 		policy, err := snap.ACLPolicyByName(nil, policyName)
 		if err != nil {
 			return nil, err
@@ -77,7 +158,9 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 		policies = append(policies, policy)
 	}
 
-	// Compile and cache the ACL object
+	// Compile and cache the ACL object. The cache is keyed on the names and
+	// modify indexes of the policies, so tokens resolving to the same set of
+	// policies through their roles share the compiled object.
 	aclObj, err := structs.CompileACLObject(cache, policies)
 	if err != nil {
 		return nil, err
@@ -85,6 +168,41 @@ func resolveTokenFromSnapshotCache(snap *state.StateSnapshot, cache *lru.TwoQueu
 	return aclObj, nil
 }
 
+// tokenPolicyNames returns the deduplicated names of the policies linked to
+// the token, either directly or through its roles. Roles which don't exist
+// are ignored, since they don't grant any more privilege.
+func tokenPolicyNames(snap *state.StateSnapshot, token *structs.ACLToken) ([]string, error) {
+	if len(token.Roles) == 0 {
+		return token.Policies, nil
+	}
+
+	seen := make(map[string]struct{}, len(token.Policies))
+	names := make([]string, 0, len(token.Policies))
+	add := func(name string) {
+		if _, ok := seen[name]; !ok {
+			seen[name] = struct{}{}
+			names = append(names, name)
+		}
+	}
+
+	for _, policyName := range token.Policies {
+		add(policyName)
+	}
+	for _, roleLink := range token.Roles {
+		role, err := snap.GetACLRoleByID(nil, roleLink.ID)
+		if err != nil {
+			return nil, err
+		}
+		if role == nil {
+			continue
+		}
+		for _, policyLink := range role.Policies {
+			add(policyLink.Name)
+		}
+	}
+	return names, nil
+}
+
 // ResolveSecretToken is used to translate an ACL Token Secret ID into
 // an ACLToken object, nil if ACLs are disabled, or an error.
 func (s *Server) ResolveSecretToken(secretID string) (*structs.ACLToken, error) {
@@ -114,6 +232,9 @@ func (s *Server) ResolveSecretToken(secretID string) (*structs.ACLToken, error)
 		if token == nil {
 			return nil, structs.ErrTokenNotFound
 		}
+		if token.IsExpired(time.Now().UTC()) {
+			return nil, structs.ErrTokenExpired
+		}
 	}
 
 	return token, nil
@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -14,6 +15,8 @@ import (
 	memdb "github.com/hashicorp/go-memdb"
 	policy "github.com/hashicorp/nomad/acl"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/state/paginator"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -28,6 +31,10 @@ const (
 	// aclBootstrapReset is the file name to create in the data dir. It's only contents
 	// should be the reset index
 	aclBootstrapReset = "acl-bootstrap-reset"
+
+	// oidcRequestTimeout is the timeout of the requests made to the identity
+	// provider of an OIDC auth method during login.
+	oidcRequestTimeout = 30 * time.Second
 )
 
 // ACL endpoint is used for manipulating ACL tokens and policies
@@ -147,8 +154,12 @@ func (a *ACL) ListPolicies(args *structs.ACLPolicyListRequest, reply *structs.AC
 			return structs.ErrTokenNotFound
 		}
 
-		policies = make(map[string]struct{}, len(token.Policies))
-		for _, p := range token.Policies {
+		policyNames, err := a.requestACLTokenPolicyNames(token)
+		if err != nil {
+			return err
+		}
+		policies = make(map[string]struct{}, len(policyNames))
+		for _, p := range policyNames {
 			policies[p] = struct{}{}
 		}
 	}
@@ -231,14 +242,10 @@ func (a *ACL) GetPolicy(args *structs.ACLPolicySpecificRequest, reply *structs.S
 			return structs.ErrTokenNotFound
 		}
 
-		found := false
-		for _, p := range token.Policies {
-			if p == args.Name {
-				found = true
-				break
-			}
+		found, err := a.requestACLTokenHasPolicies(token, []string{args.Name})
+		if err != nil {
+			return err
 		}
-
 		if !found {
 			return structs.ErrPermissionDenied
 		}
@@ -288,7 +295,51 @@ func (a *ACL) requestACLToken(secretID string) (*structs.ACLToken, error) {
 		return nil, err
 	}
 
-	return snap.ACLTokenBySecretID(nil, secretID)
+	token, err := snap.ACLTokenBySecretID(nil, secretID)
+	if err != nil {
+		return nil, err
+	}
+	if token.IsExpired(time.Now().UTC()) {
+		return nil, structs.ErrTokenExpired
+	}
+	return token, nil
+}
+
+// requestACLTokenPolicyNames returns the names of the policies linked to the
+// token, either directly or through its roles.
+func (a *ACL) requestACLTokenPolicyNames(token *structs.ACLToken) ([]string, error) {
+	snap, err := a.srv.fsm.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return tokenPolicyNames(snap, token)
+}
+
+// requestACLTokenHasPolicies checks if a given set of policies is a subset of
+// the policies linked to the token, either directly or through its roles.
+func (a *ACL) requestACLTokenHasPolicies(token *structs.ACLToken, policies []string) (bool, error) {
+	// Hot-path tokens which are directly linked to all the policies.
+	if token.PolicySubset(policies) {
+		return true, nil
+	}
+	if len(token.Roles) == 0 {
+		return false, nil
+	}
+
+	policyNames, err := a.requestACLTokenPolicyNames(token)
+	if err != nil {
+		return false, err
+	}
+	associated := make(map[string]struct{}, len(policyNames))
+	for _, policyName := range policyNames {
+		associated[policyName] = struct{}{}
+	}
+	for _, policyName := range policies {
+		if _, ok := associated[policyName]; !ok {
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 // GetPolicies is used to get a set of policies
@@ -312,8 +363,14 @@ func (a *ACL) GetPolicies(args *structs.ACLPolicySetRequest, reply *structs.ACLP
 	if token == nil {
 		return structs.ErrTokenNotFound
 	}
-	if token.Type != structs.ACLManagementToken && !token.PolicySubset(args.Names) {
-		return structs.ErrPermissionDenied
+	if token.Type != structs.ACLManagementToken {
+		found, err := a.requestACLTokenHasPolicies(token, args.Names)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return structs.ErrPermissionDenied
+		}
 	}
 
 	// Setup the blocking query
@@ -505,12 +562,30 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
 		}
 
+		// Resolve the role links, so they always reference the role ID
+		roleLinks, err := resolveTokenRoleLinks(state, token.Roles)
+		if err != nil {
+			return structs.NewErrRPCCodedf(400, "token %d invalid: %v", idx, err)
+		}
+		token.Roles = roleLinks
+
 		// Generate an accessor and secret ID if new
 		if token.AccessorID == "" {
 			token.AccessorID = uuid.Generate()
 			token.SecretID = uuid.Generate()
 			token.CreateTime = time.Now().UTC()
 
+			// Set the expiration time from the TTL, so that it is
+			// relative to the creation time. Stored tokens keep both, so
+			// only new tokens may not set both.
+			if token.ExpirationTTL != 0 && token.HasExpirationTime() {
+				return structs.NewErrRPCCodedf(400, "token %d invalid: expiration time and TTL cannot both be set", idx)
+			}
+			token.CanonicalizeExpiration()
+			if token.HasExpirationTime() && !token.ExpirationTime.After(token.CreateTime) {
+				return structs.NewErrRPCCodedf(400, "token %d invalid: expiration time must be in the future", idx)
+			}
+
 		} else {
 			// Verify the token exists
 			out, err := state.ACLTokenByAccessorID(nil, token.AccessorID)
@@ -525,6 +600,11 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 			if token.Global != out.Global {
 				return structs.NewErrRPCCodedf(400, "cannot toggle global mode of %s", token.AccessorID)
 			}
+
+			// The expiration time of a token cannot be modified, so updates
+			// cannot extend the lifetime of short-lived tokens
+			token.ExpirationTime = out.ExpirationTime
+			token.ExpirationTTL = out.ExpirationTTL
 		}
 
 		// Compute the token hash
@@ -556,6 +636,46 @@ func (a *ACL) UpsertTokens(args *structs.ACLTokenUpsertRequest, reply *structs.A
 	return nil
 }
 
+// resolveTokenRoleLinks ensures the roles linked to a token exist. Links may
+// reference a role by ID or by name, and are returned with both set to the
+// current values of the role. Duplicate links are removed.
+func resolveTokenRoleLinks(snap *state.StateSnapshot, links []*structs.ACLTokenRoleLink) ([]*structs.ACLTokenRoleLink, error) {
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]struct{}, len(links))
+	resolved := make([]*structs.ACLTokenRoleLink, 0, len(links))
+	for _, link := range links {
+		var role *structs.ACLRole
+		var err error
+		switch {
+		case link.ID != "":
+			role, err = snap.GetACLRoleByID(nil, link.ID)
+		case link.Name != "":
+			role, err = snap.GetACLRoleByName(nil, link.Name)
+		default:
+			return nil, fmt.Errorf("role link must specify an ID or name")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("role lookup failed: %v", err)
+		}
+		if role == nil {
+			if link.ID != "" {
+				return nil, fmt.Errorf("cannot find role %s", link.ID)
+			}
+			return nil, fmt.Errorf("cannot find role %s", link.Name)
+		}
+
+		if _, ok := seen[role.ID]; ok {
+			continue
+		}
+		seen[role.ID] = struct{}{}
+		resolved = append(resolved, &structs.ACLTokenRoleLink{ID: role.ID, Name: role.Name})
+	}
+	return resolved, nil
+}
+
 // DeleteTokens is used to delete tokens
 func (a *ACL) DeleteTokens(args *structs.ACLTokenDeleteRequest, reply *structs.GenericResponse) error {
 	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
@@ -853,6 +973,12 @@ func (a *ACL) ResolveToken(args *structs.ResolveACLTokenRequest, reply *structs.
 		return err
 	}
 
+	// Expired tokens are treated as an error, rather than as a missing token,
+	// so callers can tell the user why the token is rejected
+	if out.IsExpired(time.Now().UTC()) {
+		return structs.ErrTokenExpired
+	}
+
 	// Setup the output
 	reply.Token = out
 	if out != nil {
@@ -1010,3 +1136,1048 @@ func (a *ACL) ExpireOneTimeTokens(args *structs.OneTimeTokenExpireRequest, reply
 	reply.Index = index
 	return nil
 }
+
+// UpsertRoles is used to create or update a set of ACL roles. Roles are
+// global, so the request is always forwarded to the authoritative region.
+func (a *ACL) UpsertRoles(args *structs.ACLRolesUpsertRequest, reply *structs.ACLRolesUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_roles"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of roles
+	if len(args.ACLRoles) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one role")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each role, ensuring the linked policies exist, and compute the
+	// hash. The names are tracked to catch duplicates within the request.
+	names := make(map[string]struct{}, len(args.ACLRoles))
+	for idx, role := range args.ACLRoles {
+		if err := role.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role %d invalid: %v", idx, err)
+		}
+		if _, ok := names[role.Name]; ok {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role %d invalid: duplicate name %s", idx, role.Name)
+		}
+		names[role.Name] = struct{}{}
+
+		if err := state.ValidateACLRolePolicyLinks(role); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role %d invalid: %v", idx, err)
+		}
+
+		// Verify the role exists if it is being updated
+		if role.ID != "" {
+			out, err := state.GetACLRoleByID(nil, role.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "role lookup failed: %v", err)
+			}
+			if out == nil {
+				return structs.NewErrRPCCodedf(http.StatusNotFound, "cannot find role %s", role.ID)
+			}
+		}
+
+		// Ensure the name is not in use by another role
+		existing, err := state.GetACLRoleByName(nil, role.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role lookup failed: %v", err)
+		}
+		if existing != nil && existing.ID != role.ID {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role with name %s already exists", role.Name)
+		}
+
+		role.Canonicalize()
+		role.SetHash()
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLRolesUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create and modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, role := range args.ACLRoles {
+		out, err := state.GetACLRoleByID(nil, role.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "role lookup failed: %v", err)
+		}
+		reply.ACLRoles = append(reply.ACLRoles, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteRoles is used to delete a set of ACL roles using their IDs. Tokens
+// linked to a deleted role are not modified, but no longer inherit the
+// policies of the role.
+func (a *ACL) DeleteRoles(args *structs.ACLRolesDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_roles"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of roles
+	if len(args.ACLRoleIDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one role")
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLRolesDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListRoles is used to list the ACL roles. Management tokens can list all
+// roles, while client tokens can only list the roles they are linked to.
+func (a *ACL) ListRoles(args *structs.ACLRolesListRequest, reply *structs.ACLRolesListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLListRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_roles"}, time.Now())
+
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If it is not a management token determine the roles that may be listed
+	mgt := acl.IsManagement()
+	var roles map[string]struct{}
+	if !mgt {
+		token, err := a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+
+		roles = make(map[string]struct{}, len(token.Roles))
+		for _, roleLink := range token.Roles {
+			roles[roleLink.ID] = struct{}{}
+		}
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Iterate over all the roles
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = stateStore.GetACLRoleByIDPrefix(ws, prefix)
+			} else {
+				iter, err = stateStore.GetACLRoles(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Convert all the roles to a list stub
+			reply.ACLRoles = []*structs.ACLRoleListStub{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				role := raw.(*structs.ACLRole)
+				if _, ok := roles[role.ID]; ok || mgt {
+					reply.ACLRoles = append(reply.ACLRoles, role.Stub())
+				}
+			}
+
+			// Use the last index that affected the roles table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLRoles, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetRole is used to get a specific ACL role using either its ID or name.
+// Client tokens can only get the roles they are linked to.
+func (a *ACL) GetRole(args *structs.ACLRoleSpecificRequest, reply *structs.ACLRoleSpecificResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetRoleRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_role"}, time.Now())
+
+	if args.RoleID == "" && args.RoleName == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify a role ID or name")
+	}
+
+	acl, err := a.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if acl == nil {
+		return structs.ErrPermissionDenied
+	}
+
+	// If it is not a management token, resolve the token so we can check it
+	// is linked to the role once it has been looked up.
+	var token *structs.ACLToken
+	if !acl.IsManagement() {
+		token, err = a.requestACLToken(args.AuthToken)
+		if err != nil {
+			return err
+		}
+		if token == nil {
+			return structs.ErrTokenNotFound
+		}
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Look for the role
+			var out *structs.ACLRole
+			var err error
+			if args.RoleID != "" {
+				out, err = stateStore.GetACLRoleByID(ws, args.RoleID)
+			} else {
+				out, err = stateStore.GetACLRoleByName(ws, args.RoleName)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Client tokens may only read the roles they are linked to. The
+			// same error is returned for missing roles to avoid leaking which
+			// roles exist.
+			if token != nil && (out == nil || !tokenHasRole(token, out.ID)) {
+				return structs.ErrPermissionDenied
+			}
+
+			// Setup the output
+			reply.ACLRole = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+
+			// Use the last index that affected the roles table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLRoles, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetRoles is used to get a set of ACL roles using their IDs. Clients use it
+// to resolve the roles of a token, so client tokens may get the roles they
+// are linked to. It is also used to replicate roles from the authoritative
+// region.
+func (a *ACL) GetRoles(args *structs.ACLRolesByIDRequest, reply *structs.ACLRolesByIDResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetRolesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_roles"}, time.Now())
+
+	token, err := a.requestACLToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return structs.ErrTokenNotFound
+	}
+	if token.Type != structs.ACLManagementToken {
+		for _, roleID := range args.ACLRoleIDs {
+			if !tokenHasRole(token, roleID) {
+				return structs.ErrPermissionDenied
+			}
+		}
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Setup the output
+			reply.ACLRoles = make(map[string]*structs.ACLRole, len(args.ACLRoleIDs))
+
+			// Look for the roles
+			for _, roleID := range args.ACLRoleIDs {
+				out, err := stateStore.GetACLRoleByID(ws, roleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.ACLRoles[out.ID] = out
+				}
+			}
+
+			// Use the last index that affected the roles table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLRoles, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// tokenHasRole returns whether the token is linked to the role with the given
+// ID.
+func tokenHasRole(token *structs.ACLToken, roleID string) bool {
+	for _, roleLink := range token.Roles {
+		if roleLink.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// UpsertAuthMethods is used to create or update a set of ACL auth methods.
+// Auth methods are global, so the request is always forwarded to the
+// authoritative region.
+func (a *ACL) UpsertAuthMethods(args *structs.ACLAuthMethodsUpsertRequest, reply *structs.ACLAuthMethodsUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.AuthMethods) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one auth method")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each auth method and compute the hash. The names are tracked to
+	// catch duplicates within the request, along with the default auth method
+	// as only one can exist.
+	names := make(map[string]bool, len(args.AuthMethods))
+	var defaultName string
+	for idx, authMethod := range args.AuthMethods {
+		if err := authMethod.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method %d invalid: %v", idx, err)
+		}
+		if _, ok := names[authMethod.Name]; ok {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method %d invalid: duplicate name %s", idx, authMethod.Name)
+		}
+		names[authMethod.Name] = authMethod.Default
+
+		if authMethod.Default {
+			if defaultName != "" {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method %d invalid: only one default auth method is allowed", idx)
+			}
+			defaultName = authMethod.Name
+		}
+
+		authMethod.Canonicalize()
+		authMethod.SetHash()
+	}
+
+	// Ensure the request does not add a second default auth method, unless it
+	// also unsets the current default.
+	if defaultName != "" {
+		existing, err := state.GetDefaultACLAuthMethod(nil)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method lookup failed: %v", err)
+		}
+		if existing != nil && existing.Name != defaultName {
+			if isDefault, ok := names[existing.Name]; !ok || isDefault {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest,
+					"default auth method already exists: %s", existing.Name)
+			}
+		}
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLAuthMethodsUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create and modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, authMethod := range args.AuthMethods {
+		out, err := state.GetACLAuthMethodByName(nil, authMethod.Name)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method lookup failed: %v", err)
+		}
+		reply.AuthMethods = append(reply.AuthMethods, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteAuthMethods is used to delete a set of ACL auth methods using their
+// names. The binding rules of the deleted auth methods are also deleted, while
+// tokens minted by them remain valid until they expire.
+func (a *ACL) DeleteAuthMethods(args *structs.ACLAuthMethodsDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of auth methods
+	if len(args.Names) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one auth method")
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLAuthMethodsDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListAuthMethods is used to list the ACL auth methods. Listing does not
+// require a management token, as users need to discover the auth methods
+// before they can log in. The list stubs do not include the auth method
+// configuration.
+func (a *ACL) ListAuthMethods(args *structs.ACLAuthMethodsListRequest, reply *structs.ACLAuthMethodsListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLListAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_auth_methods"}, time.Now())
+
+	// Ensure the token is valid, which includes the anonymous token
+	if _, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			iter, err := stateStore.GetACLAuthMethods(ws)
+			if err != nil {
+				return err
+			}
+
+			// Convert all the auth methods to a list stub
+			reply.AuthMethods = []*structs.ACLAuthMethodListStub{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				authMethod := raw.(*structs.ACLAuthMethod)
+				reply.AuthMethods = append(reply.AuthMethods, authMethod.Stub())
+			}
+
+			// Use the last index that affected the auth methods table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLAuthMethods, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetAuthMethod is used to get a specific ACL auth method using its name. The
+// auth method configuration includes the OIDC client secret, so a management
+// token is required.
+func (a *ACL) GetAuthMethod(args *structs.ACLAuthMethodSpecificRequest, reply *structs.ACLAuthMethodSpecificResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetAuthMethodRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_method"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			out, err := stateStore.GetACLAuthMethodByName(ws, args.Name)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.AuthMethod = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+
+			// Use the last index that affected the auth methods table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLAuthMethods, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetAuthMethods is used to get a set of ACL auth methods using their names.
+// It is used to replicate auth methods from the authoritative region.
+func (a *ACL) GetAuthMethods(args *structs.ACLAuthMethodsByNameRequest, reply *structs.ACLAuthMethodsByNameResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetAuthMethodsRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_auth_methods"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Setup the output
+			reply.AuthMethods = make(map[string]*structs.ACLAuthMethod, len(args.Names))
+
+			// Look for the auth methods
+			for _, name := range args.Names {
+				out, err := stateStore.GetACLAuthMethodByName(ws, name)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.AuthMethods[out.Name] = out
+				}
+			}
+
+			// Use the last index that affected the auth methods table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLAuthMethods, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// UpsertBindingRules is used to create or update a set of ACL binding rules.
+// Binding rules are global, so the request is always forwarded to the
+// authoritative region.
+func (a *ACL) UpsertBindingRules(args *structs.ACLBindingRulesUpsertRequest, reply *structs.ACLBindingRulesUpsertResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLUpsertBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "upsert_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules
+	if len(args.ACLBindingRules) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one binding rule")
+	}
+
+	// Snapshot the state
+	state, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+
+	// Validate each binding rule, ensuring the auth method exists, and
+	// compute the hash.
+	for idx, rule := range args.ACLBindingRules {
+		if err := rule.Validate(); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "binding rule %d invalid: %v", idx, err)
+		}
+
+		// Verify the binding rule exists if it is being updated
+		if rule.ID != "" {
+			out, err := state.GetACLBindingRule(nil, rule.ID)
+			if err != nil {
+				return structs.NewErrRPCCodedf(http.StatusBadRequest, "binding rule lookup failed: %v", err)
+			}
+			if out == nil {
+				return structs.NewErrRPCCodedf(http.StatusNotFound, "cannot find binding rule %s", rule.ID)
+			}
+		}
+
+		authMethod, err := state.GetACLAuthMethodByName(nil, rule.AuthMethod)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method lookup failed: %v", err)
+		}
+		if authMethod == nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest,
+				"binding rule %d invalid: cannot find auth method %s", idx, rule.AuthMethod)
+		}
+
+		rule.Canonicalize()
+		rule.SetHash()
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLBindingRulesUpsertRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create and modify indexes.
+	state, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	for _, rule := range args.ACLBindingRules {
+		out, err := state.GetACLBindingRule(nil, rule.ID)
+		if err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "binding rule lookup failed: %v", err)
+		}
+		reply.ACLBindingRules = append(reply.ACLBindingRules, out)
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// DeleteBindingRules is used to delete a set of ACL binding rules using their
+// IDs.
+func (a *ACL) DeleteBindingRules(args *structs.ACLBindingRulesDeleteRequest, reply *structs.GenericResponse) error {
+	// Ensure ACLs are enabled, and always flow modification requests to the authoritative region
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	args.Region = a.srv.config.AuthoritativeRegion
+
+	if done, err := a.srv.forward(structs.ACLDeleteBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "delete_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Validate non-zero set of binding rules
+	if len(args.ACLBindingRuleIDs) == 0 {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "must specify as least one binding rule")
+	}
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLBindingRulesDeleteRequestType, args)
+	if err != nil {
+		return err
+	}
+
+	// Check if there was an error when applying.
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Update the index
+	reply.Index = index
+	return nil
+}
+
+// ListBindingRules is used to list the ACL binding rules.
+func (a *ACL) ListBindingRules(args *structs.ACLBindingRulesListRequest, reply *structs.ACLBindingRulesListResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLListBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "list_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Iterate over all the binding rules
+			var err error
+			var iter memdb.ResultIterator
+			if prefix := args.QueryOptions.Prefix; prefix != "" {
+				iter, err = stateStore.GetACLBindingRuleByIDPrefix(ws, prefix)
+			} else {
+				iter, err = stateStore.GetACLBindingRules(ws)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Convert all the binding rules to a list stub
+			reply.ACLBindingRules = []*structs.ACLBindingRuleListStub{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				rule := raw.(*structs.ACLBindingRule)
+				reply.ACLBindingRules = append(reply.ACLBindingRules, rule.Stub())
+			}
+
+			// Use the last index that affected the binding rules table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLBindingRules, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetBindingRule is used to get a specific ACL binding rule using its ID.
+func (a *ACL) GetBindingRule(args *structs.ACLBindingRuleSpecificRequest, reply *structs.ACLBindingRuleSpecificResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetBindingRuleRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rule"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			out, err := stateStore.GetACLBindingRule(ws, args.ACLBindingRuleID)
+			if err != nil {
+				return err
+			}
+
+			// Setup the output
+			reply.ACLBindingRule = out
+			if out != nil {
+				reply.Index = out.ModifyIndex
+				return nil
+			}
+
+			// Use the last index that affected the binding rules table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLBindingRules, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// GetBindingRules is used to get a set of ACL binding rules using their IDs.
+// It is used to replicate binding rules from the authoritative region.
+func (a *ACL) GetBindingRules(args *structs.ACLBindingRulesByIDRequest, reply *structs.ACLBindingRulesByIDResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if done, err := a.srv.forward(structs.ACLGetBindingRulesRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "get_binding_rules"}, time.Now())
+
+	// Check management level permissions
+	if acl, err := a.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if acl == nil || !acl.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	// Setup the blocking query
+	opts := blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			// Setup the output
+			reply.ACLBindingRules = make(map[string]*structs.ACLBindingRule, len(args.ACLBindingRuleIDs))
+
+			// Look for the binding rules
+			for _, ruleID := range args.ACLBindingRuleIDs {
+				out, err := stateStore.GetACLBindingRule(ws, ruleID)
+				if err != nil {
+					return err
+				}
+				if out != nil {
+					reply.ACLBindingRules[out.ID] = out
+				}
+			}
+
+			// Use the last index that affected the binding rules table
+			return a.srv.setReplyQueryMeta(stateStore, state.TableACLBindingRules, &reply.QueryMeta)
+		}}
+	return a.srv.blockingRPC(&opts)
+}
+
+// OIDCAuthURL starts the OIDC login flow by generating the URL of the identity
+// provider of the auth method. No token is required, as the caller is not yet
+// logged in.
+func (a *ACL) OIDCAuthURL(args *structs.ACLOIDCAuthURLRequest, reply *structs.ACLOIDCAuthURLResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid OIDC auth-url request: %v", err)
+	}
+	if done, err := a.srv.forward(structs.ACLOIDCAuthURLRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "oidc_auth_url"}, time.Now())
+
+	authMethod, err := a.oidcAuthMethod(args.AuthMethodName)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+	defer cancel()
+
+	provider, err := a.srv.oidcProviderCache.Get(ctx, authMethod)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "failed to create OIDC provider: %v", err)
+	}
+
+	// The state is passed back to the redirect URI, and allows the caller to
+	// ensure the redirect belongs to the login it started.
+	authURL, err := provider.AuthURL(args.RedirectURI, uuid.Generate(), args.ClientNonce)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to generate OIDC auth URL: %v", err)
+	}
+
+	reply.AuthURL = authURL
+	return nil
+}
+
+// OIDCCompleteAuth completes the OIDC login flow. It exchanges the
+// authorization code for an ID token, evaluates the binding rules of the auth
+// method against its claims, and mints an ACL token linked to the bound roles
+// and policies which expires after the max token TTL of the auth method.
+func (a *ACL) OIDCCompleteAuth(args *structs.ACLOIDCCompleteAuthRequest, reply *structs.ACLLoginResponse) error {
+	if !a.srv.config.ACLEnabled {
+		return aclDisabled
+	}
+	if err := args.Validate(); err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "invalid OIDC complete-auth request: %v", err)
+	}
+	if done, err := a.srv.forward(structs.ACLOIDCCompleteAuthRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "acl", "oidc_complete_auth"}, time.Now())
+
+	authMethod, err := a.oidcAuthMethod(args.AuthMethodName)
+	if err != nil {
+		return err
+	}
+
+	// Global tokens can only be written within the authoritative region. Auth
+	// methods are replicated, so the login can be completed there.
+	if authMethod.TokenLocalityIsGlobal() && a.srv.config.Region != a.srv.config.AuthoritativeRegion {
+		args.Region = a.srv.config.AuthoritativeRegion
+		_, err := a.srv.forward(structs.ACLOIDCCompleteAuthRPCMethod, args, args, reply)
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), oidcRequestTimeout)
+	defer cancel()
+
+	provider, err := a.srv.oidcProviderCache.Get(ctx, authMethod)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusInternalServerError, "failed to create OIDC provider: %v", err)
+	}
+
+	claims, err := provider.Exchange(ctx, args.RedirectURI, args.Code, args.ClientNonce)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to complete OIDC login: %v", err)
+	}
+
+	selectorData, err := oidc.SelectorData(authMethod, claims)
+	if err != nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "failed to map OIDC claims: %v", err)
+	}
+
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	bindings, err := auth.NewBinder(snap).Bind(authMethod, selectorData)
+	if err != nil {
+		return err
+	}
+	if bindings.None() {
+		return structs.NewErrRPCCoded(http.StatusForbidden, "no role or policy bindings matched")
+	}
+
+	token := &structs.ACLToken{
+		AccessorID:    uuid.Generate(),
+		SecretID:      uuid.Generate(),
+		Name:          "OIDC-" + authMethod.Name,
+		Type:          structs.ACLClientToken,
+		Policies:      bindings.Policies,
+		Roles:         bindings.Roles,
+		Global:        authMethod.TokenLocalityIsGlobal(),
+		CreateTime:    time.Now().UTC(),
+		ExpirationTTL: authMethod.MaxTokenTTL,
+	}
+	token.CanonicalizeExpiration()
+	token.SetHash()
+
+	// Update via Raft
+	out, index, err := a.srv.raftApply(structs.ACLTokenUpsertRequestType, &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+	})
+	if err != nil {
+		return err
+	}
+	if err, ok := out.(error); ok && err != nil {
+		return err
+	}
+
+	// Populate the response. We do a lookup against the state to pick up the
+	// proper create and modify indexes.
+	snap, err = a.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	reply.ACLToken, err = snap.ACLTokenByAccessorID(nil, token.AccessorID)
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	return nil
+}
+
+// oidcAuthMethod looks up the named auth method, ensuring it exists and is of
+// type OIDC.
+func (a *ACL) oidcAuthMethod(name string) (*structs.ACLAuthMethod, error) {
+	snap, err := a.srv.State().Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	authMethod, err := snap.GetACLAuthMethodByName(nil, name)
+	if err != nil {
+		return nil, err
+	}
+	if authMethod == nil {
+		return nil, structs.NewErrRPCCodedf(http.StatusNotFound, "auth method %s not found", name)
+	}
+	if authMethod.Type != structs.ACLAuthMethodTypeOIDC {
+		return nil, structs.NewErrRPCCodedf(http.StatusBadRequest, "auth method %s is not of type %s",
+			name, structs.ACLAuthMethodTypeOIDC)
+	}
+	return authMethod, nil
+}
@@ -3,6 +3,8 @@ package nomad
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
 	"github.com/hashicorp/nomad/ci"
 	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/mock"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
@@ -1467,6 +1470,91 @@ func TestACLEndpoint_UpsertTokens(t *testing.T) {
 	assert.Equal(t, created, out)
 }
 
+func TestACLEndpoint_UpsertTokens_Expiration(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create a token with an expiration TTL.
+	token := mock.ACLToken()
+	token.AccessorID = ""
+	token.ExpirationTTL = time.Hour
+
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Len(t, resp.Tokens, 1)
+
+	// The expiration time should be relative to the creation time.
+	created := resp.Tokens[0]
+	require.NotNil(t, created.ExpirationTime)
+	require.Equal(t, created.CreateTime.Add(time.Hour), *created.ExpirationTime)
+	require.Equal(t, time.Hour, created.ExpirationTTL)
+
+	// Reading the token and upserting it back, as the CLI does, updates it
+	// without changing its expiration.
+	get := &structs.ACLTokenSpecificRequest{
+		AccessorID: created.AccessorID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var getResp structs.SingleACLTokenResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetToken", get, &getResp))
+	update := getResp.Token
+	update.Name = "updated"
+	req.Tokens = []*structs.ACLToken{update}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Equal(t, "updated", resp.Tokens[0].Name)
+	require.Equal(t, created.ExpirationTime, resp.Tokens[0].ExpirationTime)
+
+	// Updates cannot change the expiration.
+	update = resp.Tokens[0].Copy()
+	later := created.ExpirationTime.Add(time.Hour)
+	update.ExpirationTime = &later
+	update.ExpirationTTL = 0
+	req.Tokens = []*structs.ACLToken{update}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Equal(t, created.ExpirationTime, resp.Tokens[0].ExpirationTime)
+
+	// New tokens cannot set both an expiration time and TTL.
+	future := time.Now().UTC().Add(time.Hour)
+	both := mock.ACLToken()
+	both.AccessorID = ""
+	both.ExpirationTime = &future
+	both.ExpirationTTL = time.Hour
+	req.Tokens = []*structs.ACLToken{both}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "cannot both be set")
+
+	// Tokens cannot be created already expired.
+	past := time.Now().UTC().Add(-time.Minute)
+	expired := mock.ACLToken()
+	expired.AccessorID = ""
+	expired.ExpirationTime = &past
+	req.Tokens = []*structs.ACLToken{expired}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "expiration time must be in the future")
+
+	// A negative TTL is invalid.
+	negative := mock.ACLToken()
+	negative.AccessorID = ""
+	negative.ExpirationTTL = -time.Hour
+	req.Tokens = []*structs.ACLToken{negative}
+	err = msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "cannot be negative")
+}
+
 func TestACLEndpoint_UpsertTokens_Invalid(t *testing.T) {
 	ci.Parallel(t)
 
@@ -1645,3 +1733,742 @@ func TestACLEndpoint_OneTimeToken(t *testing.T) {
 	require.NoError(t, err)
 	require.Nil(t, ott)
 }
+
+func TestACLEndpoint_UpsertRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Create the policies our roles will link to.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "foo"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "bar"
+	require.NoError(t, s1.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	role := mock.ACLRole()
+	role.ID = ""
+	req := &structs.ACLRolesUpsertRequest{
+		ACLRoles: []*structs.ACLRole{role},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+
+	// An unauthenticated request should be rejected.
+	req.AuthToken = ""
+	var resp structs.ACLRolesUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Create the role with a management token.
+	req.AuthToken = root.SecretID
+	resp = structs.ACLRolesUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+	require.Len(t, resp.ACLRoles, 1)
+	require.NotEmpty(t, resp.ACLRoles[0].ID)
+	require.Equal(t, role.Name, resp.ACLRoles[0].Name)
+
+	out, err := s1.fsm.State().GetACLRoleByID(nil, resp.ACLRoles[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Update the role using its ID.
+	update := out.Copy()
+	update.Description = "updated"
+	req.ACLRoles = []*structs.ACLRole{update}
+	resp = structs.ACLRolesUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp))
+	require.Len(t, resp.ACLRoles, 1)
+	require.Equal(t, "updated", resp.ACLRoles[0].Description)
+	require.Equal(t, out.CreateIndex, resp.ACLRoles[0].CreateIndex)
+
+	// A role using the name of an existing role should be rejected.
+	duplicate := mock.ACLRole()
+	duplicate.ID = ""
+	duplicate.Name = role.Name
+	req.ACLRoles = []*structs.ACLRole{duplicate}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "already exists")
+
+	// A role linking to a missing policy should be rejected.
+	missing := mock.ACLRole()
+	missing.ID = ""
+	missing.Policies = []*structs.ACLRolePolicyLink{{Name: "baz"}}
+	req.ACLRoles = []*structs.ACLRole{missing}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "cannot find policy baz")
+
+	// Updating a role which does not exist should be rejected.
+	unknown := mock.ACLRole()
+	req.ACLRoles = []*structs.ACLRole{unknown}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertRolesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "cannot find role")
+}
+
+func TestACLEndpoint_DeleteRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	roles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, roles, true))
+
+	req := &structs.ACLRolesDeleteRequest{
+		ACLRoleIDs: []string{roles[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLDeleteRolesRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+
+	out, err := s1.fsm.State().GetACLRoleByID(nil, roles[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Deleting a role which no longer exists should error.
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteRolesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "ACL role not found")
+}
+
+func TestACLEndpoint_ListRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	roles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, roles, true))
+
+	// A management token can list all roles.
+	req := &structs.ACLRolesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLRolesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLListRolesRPCMethod, req, &resp))
+	require.Equal(t, uint64(10), resp.Index)
+	require.Len(t, resp.ACLRoles, 2)
+
+	// A client token can only list the roles it is linked to.
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: roles[1].ID}}
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	req.AuthToken = token.SecretID
+	resp = structs.ACLRolesListResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLListRolesRPCMethod, req, &resp))
+	require.Len(t, resp.ACLRoles, 1)
+	require.Equal(t, roles[1].ID, resp.ACLRoles[0].ID)
+}
+
+func TestACLEndpoint_GetRole(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	roles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, roles, true))
+
+	// Lookup the role by ID and by name.
+	req := &structs.ACLRoleSpecificRequest{
+		RoleID: roles[0].ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLRoleSpecificResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleRPCMethod, req, &resp))
+	require.Equal(t, roles[0], resp.ACLRole)
+
+	req.RoleID = ""
+	req.RoleName = roles[1].Name
+	resp = structs.ACLRoleSpecificResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleRPCMethod, req, &resp))
+	require.Equal(t, roles[1], resp.ACLRole)
+
+	// A missing role returns a nil object.
+	req.RoleName = "not-a-role"
+	resp = structs.ACLRoleSpecificResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleRPCMethod, req, &resp))
+	require.Nil(t, resp.ACLRole)
+
+	// A client token can only read the roles it is linked to.
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: roles[0].ID}}
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	req.AuthToken = token.SecretID
+	req.RoleName = ""
+	req.RoleID = roles[0].ID
+	resp = structs.ACLRoleSpecificResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleRPCMethod, req, &resp))
+	require.Equal(t, roles[0], resp.ACLRole)
+
+	req.RoleID = roles[1].ID
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetRoleRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_GetRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	roles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(structs.MsgTypeTestSetup, 10, roles, true))
+
+	req := &structs.ACLRolesByIDRequest{
+		ACLRoleIDs: []string{roles[0].ID, roles[1].ID, "not-a-role"},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLRolesByIDResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetRolesRPCMethod, req, &resp))
+	require.Equal(t, uint64(10), resp.Index)
+	require.Len(t, resp.ACLRoles, 2)
+	require.Equal(t, roles[0], resp.ACLRoles[roles[0].ID])
+
+	// A client token can only read the roles it is linked to.
+	token := mock.ACLToken()
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: roles[0].ID}}
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	req.AuthToken = token.SecretID
+	req.ACLRoleIDs = []string{roles[1].ID}
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetRolesRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_UpsertTokens_WithRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	policy := mock.ACLPolicy()
+	require.NoError(t, s1.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy}))
+
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy.Name}}
+	require.NoError(t, s1.fsm.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLRole{role}, false))
+
+	// Create a token which links to the role using only its name.
+	token := mock.ACLToken()
+	token.AccessorID = ""
+	token.Policies = nil
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: role.Name}}
+	req := &structs.ACLTokenUpsertRequest{
+		Tokens: []*structs.ACLToken{token},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLTokenUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp))
+	require.Len(t, resp.Tokens, 1)
+	require.Equal(t, []*structs.ACLTokenRoleLink{{ID: role.ID, Name: role.Name}}, resp.Tokens[0].Roles)
+
+	// The token should be able to read the policy granted by its role.
+	get := &structs.ACLPolicySetRequest{
+		Names: []string{policy.Name},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: resp.Tokens[0].SecretID,
+		},
+	}
+	var getResp structs.ACLPolicySetResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "ACL.GetPolicies", get, &getResp))
+	require.Equal(t, policy, getResp.Policies[policy.Name])
+
+	// Linking to a role which does not exist should be rejected.
+	token = mock.ACLToken()
+	token.AccessorID = ""
+	token.Roles = []*structs.ACLTokenRoleLink{{Name: "not-a-role"}}
+	req.Tokens = []*structs.ACLToken{token}
+	err := msgpackrpc.CallWithCodec(codec, "ACL.UpsertTokens", req, &resp)
+	require.ErrorContains(t, err, "cannot find role not-a-role")
+}
+
+func TestACLEndpoint_ResolveToken_Expired(t *testing.T) {
+	ci.Parallel(t)
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	past := time.Now().UTC().Add(-time.Minute)
+	token := mock.ACLToken()
+	token.ExpirationTime = &past
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLToken{token}))
+
+	get := &structs.ResolveACLTokenRequest{
+		SecretID:     token.SecretID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var resp structs.ResolveACLTokenResponse
+	err := msgpackrpc.CallWithCodec(codec, "ACL.ResolveToken", get, &resp)
+	require.EqualError(t, err, structs.ErrTokenExpired.Error())
+}
+
+func TestACLEndpoint_UpsertAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	authMethod := mock.ACLAuthMethod()
+	req := &structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{authMethod},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+
+	// An unauthenticated request should be rejected.
+	req.AuthToken = ""
+	var resp structs.ACLAuthMethodsUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Create the auth method with a management token.
+	req.AuthToken = root.SecretID
+	resp = structs.ACLAuthMethodsUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+	require.Len(t, resp.AuthMethods, 1)
+	require.Equal(t, authMethod.Name, resp.AuthMethods[0].Name)
+
+	// Update the auth method and make it the default.
+	update := resp.AuthMethods[0].Copy()
+	update.Default = true
+	req.AuthMethods = []*structs.ACLAuthMethod{update}
+	resp = structs.ACLAuthMethodsUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, req, &resp))
+	require.Len(t, resp.AuthMethods, 1)
+	require.True(t, resp.AuthMethods[0].Default)
+	require.Equal(t, update.CreateIndex, resp.AuthMethods[0].CreateIndex)
+
+	// A second default auth method should be rejected.
+	other := mock.ACLAuthMethod()
+	other.Default = true
+	req.AuthMethods = []*structs.ACLAuthMethod{other}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "default auth method already exists")
+
+	// An invalid auth method should be rejected.
+	invalid := mock.ACLAuthMethod()
+	invalid.Config = nil
+	req.AuthMethods = []*structs.ACLAuthMethod{invalid}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertAuthMethodsRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "missing config")
+}
+
+func TestACLEndpoint_DeleteAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, s1.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	req := &structs.ACLAuthMethodsDeleteRequest{
+		Names: []string{authMethods[0].Name},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLDeleteAuthMethodsRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+
+	out, err := s1.fsm.State().GetACLAuthMethodByName(nil, authMethods[0].Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Deleting an auth method which no longer exists should error.
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteAuthMethodsRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "ACL auth method not found")
+}
+
+func TestACLEndpoint_ListAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, s1.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	// Listing does not require a token, so users can discover the auth
+	// methods before logging in.
+	req := &structs.ACLAuthMethodsListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.ACLAuthMethodsListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLListAuthMethodsRPCMethod, req, &resp))
+	require.Equal(t, uint64(10), resp.Index)
+	require.Len(t, resp.AuthMethods, 2)
+}
+
+func TestACLEndpoint_GetAuthMethod(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	authMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, s1.fsm.State().UpsertACLAuthMethods(structs.MsgTypeTestSetup, 10, authMethods))
+
+	req := &structs.ACLAuthMethodSpecificRequest{
+		Name: authMethods[0].Name,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLAuthMethodSpecificResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodRPCMethod, req, &resp))
+	require.Equal(t, authMethods[0], resp.AuthMethod)
+
+	// Lookup a set of auth methods by name.
+	setReq := &structs.ACLAuthMethodsByNameRequest{
+		Names: []string{authMethods[0].Name, authMethods[1].Name, "not-an-auth-method"},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var setResp structs.ACLAuthMethodsByNameResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodsRPCMethod, setReq, &setResp))
+	require.Len(t, setResp.AuthMethods, 2)
+
+	// The config includes the client secret, so a client token cannot read
+	// it.
+	token := mock.ACLToken()
+	require.NoError(t, s1.fsm.State().UpsertACLTokens(structs.MsgTypeTestSetup, 20, []*structs.ACLToken{token}))
+
+	req.AuthToken = token.SecretID
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLGetAuthMethodRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_UpsertBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	authMethod := mock.ACLAuthMethod()
+	require.NoError(t, s1.fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	rule := mock.ACLBindingRule()
+	rule.ID = ""
+	rule.AuthMethod = authMethod.Name
+	req := &structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules: []*structs.ACLBindingRule{rule},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLBindingRulesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+	require.Len(t, resp.ACLBindingRules, 1)
+	require.NotEmpty(t, resp.ACLBindingRules[0].ID)
+
+	// Update the binding rule using its ID.
+	update := resp.ACLBindingRules[0].Copy()
+	update.Description = "updated"
+	req.ACLBindingRules = []*structs.ACLBindingRule{update}
+	resp = structs.ACLBindingRulesUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, req, &resp))
+	require.Len(t, resp.ACLBindingRules, 1)
+	require.Equal(t, "updated", resp.ACLBindingRules[0].Description)
+	require.Equal(t, update.CreateIndex, resp.ACLBindingRules[0].CreateIndex)
+
+	// A binding rule of a missing auth method should be rejected.
+	missing := mock.ACLBindingRule()
+	missing.ID = ""
+	req.ACLBindingRules = []*structs.ACLBindingRule{missing}
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "cannot find auth method auth0")
+
+	// Updating a binding rule which does not exist should be rejected.
+	unknown := mock.ACLBindingRule()
+	unknown.AuthMethod = authMethod.Name
+	req.ACLBindingRules = []*structs.ACLBindingRule{unknown}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "cannot find binding rule")
+
+	// A binding rule with an invalid selector should be rejected.
+	invalid := mock.ACLBindingRule()
+	invalid.ID = ""
+	invalid.AuthMethod = authMethod.Name
+	invalid.Selector = "not a selector"
+	req.ACLBindingRules = []*structs.ACLBindingRule{invalid}
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLUpsertBindingRulesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "invalid selector")
+}
+
+func TestACLEndpoint_DeleteBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	rules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, s1.fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, rules, true))
+
+	req := &structs.ACLBindingRulesDeleteRequest{
+		ACLBindingRuleIDs: []string{rules[0].ID},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLDeleteBindingRulesRPCMethod, req, &resp))
+	require.NotEqual(t, uint64(0), resp.Index)
+
+	out, err := s1.fsm.State().GetACLBindingRule(nil, rules[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	// Deleting a binding rule which no longer exists should error.
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLDeleteBindingRulesRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "ACL binding rule not found")
+}
+
+func TestACLEndpoint_ListBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	rules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	rules[0].ID = "10000000-0000-0000-0000-000000000000"
+	rules[1].ID = "20000000-0000-0000-0000-000000000000"
+	require.NoError(t, s1.fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, rules, true))
+
+	req := &structs.ACLBindingRulesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLBindingRulesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLListBindingRulesRPCMethod, req, &resp))
+	require.Equal(t, uint64(10), resp.Index)
+	require.Len(t, resp.ACLBindingRules, 2)
+
+	// Filter the binding rules using a prefix.
+	req.Prefix = "1"
+	resp = structs.ACLBindingRulesListResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLListBindingRulesRPCMethod, req, &resp))
+	require.Len(t, resp.ACLBindingRules, 1)
+	require.Equal(t, rules[0].ID, resp.ACLBindingRules[0].ID)
+
+	// Binding rules can only be listed using a management token.
+	req.AuthToken = ""
+	err := msgpackrpc.CallWithCodec(codec, structs.ACLListBindingRulesRPCMethod, req, &resp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
+
+func TestACLEndpoint_GetBindingRule(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	rules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, s1.fsm.State().UpsertACLBindingRules(structs.MsgTypeTestSetup, 10, rules, true))
+
+	req := &structs.ACLBindingRuleSpecificRequest{
+		ACLBindingRuleID: rules[0].ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.ACLBindingRuleSpecificResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetBindingRuleRPCMethod, req, &resp))
+	require.Equal(t, rules[0], resp.ACLBindingRule)
+
+	// A missing binding rule returns a nil object.
+	req.ACLBindingRuleID = uuid.Generate()
+	resp = structs.ACLBindingRuleSpecificResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetBindingRuleRPCMethod, req, &resp))
+	require.Nil(t, resp.ACLBindingRule)
+
+	// Lookup a set of binding rules by ID.
+	setReq := &structs.ACLBindingRulesByIDRequest{
+		ACLBindingRuleIDs: []string{rules[0].ID, rules[1].ID},
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var setResp structs.ACLBindingRulesByIDResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLGetBindingRulesRPCMethod, setReq, &setResp))
+	require.Len(t, setResp.ACLBindingRules, 2)
+	require.Equal(t, rules[1], setResp.ACLBindingRules[rules[1].ID])
+}
+
+func TestACLEndpoint_OIDCLogin(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Start the mock identity provider, which issues ID tokens that place the
+	// user within the engineering group.
+	redirectURI := "http://127.0.0.1:4649/oidc/callback"
+	testProvider := oidc.NewTestProvider(t)
+	testProvider.SetClaims(map[string]interface{}{
+		"groups": []string{"engineering"},
+	})
+
+	authMethod := mock.ACLAuthMethod()
+	authMethod.Config = testProvider.AuthMethodConfig(redirectURI)
+	authMethod.Config.ListClaimMappings = map[string]string{"groups": "groups"}
+	authMethod.SetHash()
+	require.NoError(t, s1.fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	policy := mock.ACLPolicy()
+	require.NoError(t, s1.fsm.State().UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLPolicy{policy}))
+
+	// Generate the auth URL and follow it to the mock identity provider,
+	// which redirects back with the authorization code.
+	authURLReq := &structs.ACLOIDCAuthURLRequest{
+		AuthMethodName: authMethod.Name,
+		RedirectURI:    redirectURI,
+		ClientNonce:    "my-nonce",
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+	var authURLResp structs.ACLOIDCAuthURLResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLOIDCAuthURLRPCMethod, authURLReq, &authURLResp))
+
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	httpResp, err := client.Get(authURLResp.AuthURL)
+	require.NoError(t, err)
+	_ = httpResp.Body.Close()
+	location, err := url.Parse(httpResp.Header.Get("Location"))
+	require.NoError(t, err)
+
+	completeReq := &structs.ACLOIDCCompleteAuthRequest{
+		AuthMethodName: authMethod.Name,
+		ClientNonce:    "my-nonce",
+		State:          location.Query().Get("state"),
+		Code:           location.Query().Get("code"),
+		RedirectURI:    redirectURI,
+		WriteRequest:   structs.WriteRequest{Region: "global"},
+	}
+
+	// Without a binding rule matching the identity, no token is minted.
+	var completeResp structs.ACLLoginResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLOIDCCompleteAuthRPCMethod, completeReq, &completeResp)
+	require.ErrorContains(t, err, "no role or policy bindings matched")
+
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = authMethod.Name
+	rule.Selector = `"engineering" in list.groups`
+	rule.BindType = structs.ACLBindingRuleBindTypePolicy
+	rule.BindName = policy.Name
+	rule.SetHash()
+	require.NoError(t, s1.fsm.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLBindingRule{rule}, false))
+
+	// Authorization codes can only be used once, so log in again.
+	httpResp, err = client.Get(authURLResp.AuthURL)
+	require.NoError(t, err)
+	_ = httpResp.Body.Close()
+	location, err = url.Parse(httpResp.Header.Get("Location"))
+	require.NoError(t, err)
+	completeReq.Code = location.Query().Get("code")
+
+	completeResp = structs.ACLLoginResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.ACLOIDCCompleteAuthRPCMethod, completeReq, &completeResp))
+	token := completeResp.ACLToken
+	require.NotNil(t, token)
+	require.Equal(t, structs.ACLClientToken, token.Type)
+	require.Equal(t, []string{policy.Name}, token.Policies)
+	require.False(t, token.Global)
+	require.NotNil(t, token.ExpirationTime)
+	require.WithinDuration(t, time.Now().Add(authMethod.MaxTokenTTL), *token.ExpirationTime, time.Minute)
+
+	// The minted token should be usable.
+	aclObj, err := s1.ResolveToken(token.SecretID)
+	require.NoError(t, err)
+	require.NotNil(t, aclObj)
+
+	// A mismatched nonce should be rejected.
+	completeReq.ClientNonce = "other-nonce"
+	err = msgpackrpc.CallWithCodec(codec, structs.ACLOIDCCompleteAuthRPCMethod, completeReq, &completeResp)
+	require.Error(t, err)
+}
@@ -2,6 +2,7 @@ package nomad
 
 import (
 	"testing"
+	"time"
 
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/hashicorp/nomad/acl"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/nomad/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveACLToken(t *testing.T) {
@@ -93,6 +95,99 @@ func TestResolveACLToken(t *testing.T) {
 	}
 }
 
+func TestResolveACLToken_Roles(t *testing.T) {
+	ci.Parallel(t)
+
+	testState := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(t, err)
+
+	// Create a policy linked to the token directly, and one which is linked
+	// via a role.
+	policy1 := mock.ACLPolicy()
+	policy2 := mock.ACLPolicy()
+	policy2.Rules = `namespace "other" { policy = "read" }`
+	policy2.SetHash()
+	require.NoError(t, testState.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLPolicy{policy1, policy2}))
+
+	role := mock.ACLRole()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy2.Name}}
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLRole{role}, false))
+
+	token := mock.ACLToken()
+	token.Policies = []string{policy1.Name}
+	token.Roles = []*structs.ACLTokenRoleLink{{ID: role.ID}, {ID: uuid.Generate()}}
+	require.NoError(t, testState.UpsertACLTokens(
+		structs.MsgTypeTestSetup, 120, []*structs.ACLToken{token}))
+
+	snap, err := testState.Snapshot()
+	require.NoError(t, err)
+
+	// The token should have the permissions of both policies. The link to
+	// the role which does not exist is ignored.
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj.AllowNamespaceOperation("default", acl.NamespaceCapabilityListJobs))
+	require.True(t, aclObj.AllowNamespaceOperation("other", acl.NamespaceCapabilityListJobs))
+
+	// Resolving again should use the cached value.
+	aclObj2, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.Same(t, aclObj, aclObj2)
+
+	// Removing the policy from the role should remove the permissions.
+	role = role.Copy()
+	role.Policies = []*structs.ACLRolePolicyLink{{Name: policy1.Name}}
+	role.SetHash()
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 130, []*structs.ACLRole{role}, false))
+	snap, err = testState.Snapshot()
+	require.NoError(t, err)
+
+	aclObj3, err := resolveTokenFromSnapshotCache(snap, cache, token.SecretID)
+	require.NoError(t, err)
+	require.True(t, aclObj3.AllowNamespaceOperation("default", acl.NamespaceCapabilityListJobs))
+	require.False(t, aclObj3.AllowNamespaceOperation("other", acl.NamespaceCapabilityListJobs))
+}
+
+func TestResolveACLToken_Expired(t *testing.T) {
+	ci.Parallel(t)
+
+	testState := state.TestStateStore(t)
+	cache, err := lru.New2Q(16)
+	require.NoError(t, err)
+
+	policy := mock.ACLPolicy()
+	require.NoError(t, testState.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLPolicy{policy}))
+
+	// Create a token which has not yet expired, and one which has.
+	future := time.Now().UTC().Add(time.Hour)
+	past := time.Now().UTC().Add(-time.Minute)
+
+	validToken := mock.ACLToken()
+	validToken.Policies = []string{policy.Name}
+	validToken.ExpirationTime = &future
+	expiredToken := mock.ACLToken()
+	expiredToken.Policies = []string{policy.Name}
+	expiredToken.ExpirationTime = &past
+	require.NoError(t, testState.UpsertACLTokens(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLToken{validToken, expiredToken}))
+
+	snap, err := testState.Snapshot()
+	require.NoError(t, err)
+
+	aclObj, err := resolveTokenFromSnapshotCache(snap, cache, validToken.SecretID)
+	require.NoError(t, err)
+	require.NotNil(t, aclObj)
+
+	aclObj, err = resolveTokenFromSnapshotCache(snap, cache, expiredToken.SecretID)
+	require.Equal(t, structs.ErrTokenExpired, err)
+	require.Nil(t, aclObj)
+}
+
 func TestResolveACLToken_LeaderToken(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
@@ -132,3 +227,83 @@ func TestResolveSecretToken(t *testing.T) {
 	}
 
 }
+
+func TestResolveSecretToken_Expired(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+
+	past := time.Now().UTC().Add(-time.Minute)
+	token := mock.ACLToken()
+	token.ExpirationTime = &past
+	require.NoError(t, s1.State().UpsertACLTokens(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLToken{token}))
+
+	respToken, err := s1.ResolveSecretToken(token.SecretID)
+	require.Equal(t, structs.ErrTokenExpired, err)
+	require.Nil(t, respToken)
+}
+
+func TestResolveWorkloadIdentity(t *testing.T) {
+	ci.Parallel(t)
+	s1, _, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	state := s1.fsm.State()
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1000, alloc.Job))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, []*structs.Allocation{alloc}))
+
+	claims := structs.NewIdentityClaims(alloc.Job, alloc, "web", time.Now())
+	token, err := s1.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+
+	// The identity grants the implicit policy of the job
+	aclObj, err := s1.ResolveToken(token)
+	require.NoError(t, err)
+	require.NotNil(t, aclObj)
+	require.False(t, aclObj.IsManagement())
+
+	jobPath := structs.VariablesJobsPrefix + "/" + alloc.JobID
+	require.True(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath, acl.VariablesCapabilityRead))
+	require.True(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath+"/web", acl.VariablesCapabilityRead))
+	require.True(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath+"/web/web", acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath+"/web/other", acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath+"/other", acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowVariableOperation(alloc.Namespace, structs.VariablesJobsPrefix+"/other", acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowVariableOperation(alloc.Namespace, jobPath, acl.VariablesCapabilityWrite))
+	require.False(t, aclObj.AllowVariableOperation(alloc.Namespace, "other", acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowVariableOperation("other", jobPath, acl.VariablesCapabilityRead))
+	require.False(t, aclObj.AllowNsOp(alloc.Namespace, acl.NamespaceCapabilitySubmitJob))
+
+	// The identity is rejected if its group isn't the group of the allocation
+	claims = structs.NewIdentityClaims(alloc.Job, alloc, "web", time.Now())
+	claims.TaskGroup = "other"
+	otherToken, err := s1.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+	_, err = s1.ResolveToken(otherToken)
+	require.Equal(t, structs.ErrTokenNotFound, err)
+
+	// A malformed identity is rejected
+	_, err = s1.ResolveToken("foo.bar.baz")
+	require.Equal(t, structs.ErrTokenNotFound, err)
+
+	// The identity is rejected once the allocation is terminal
+	stopped := alloc.Copy()
+	stopped.ClientStatus = structs.AllocClientStatusComplete
+	require.NoError(t, state.UpdateAllocsFromClient(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{stopped}))
+	_, err = s1.ResolveToken(token)
+	require.Equal(t, structs.ErrTokenNotFound, err)
+
+	// The identity is rejected for an allocation that does not exist
+	claims = structs.NewIdentityClaims(alloc.Job, mock.Alloc(), "web", time.Now())
+	token, err = s1.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+	_, err = s1.ResolveToken(token)
+	require.Equal(t, structs.ErrTokenNotFound, err)
+}
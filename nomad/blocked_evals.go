@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -29,6 +30,11 @@ const (
 // blocked state when it is run through the scheduler and produced failed
 // allocations. It is unblocked when the capacity of a node that could run the
 // failed allocation becomes available.
+//
+// The blocked evaluations also form the job queue: they are ordered by
+// priority and then by the submit time of their job, and when the capacity
+// available on a node is known, only as many evaluations as could plausibly
+// fit in it are unblocked, in queue order.
 type BlockedEvals struct {
 	// logger is the logger to use by the blocked eval tracker.
 	logger log.Logger
@@ -86,6 +92,12 @@ type capacityUpdate struct {
 	computedClass string
 	quotaChange   string
 	index         uint64
+
+	// capacity returns the capacity available on the node whose change
+	// triggered the update. It is only called when there are evaluations to
+	// unblock. If it is nil or returns nil, the capacity is unknown and every
+	// evaluation that could make progress is unblocked.
+	capacity func() *structs.ComparableResources
 }
 
 // wrappedEval captures both the evaluation and the optional token
@@ -453,6 +465,15 @@ func (b *BlockedEvals) UnblockQuota(quota string, index uint64) {
 // progress on a capacity change on the passed computed node class or quota to
 // be enqueued into the eval broker.
 func (b *BlockedEvals) UnblockClassAndQuota(class, quota string, index uint64) {
+	b.UnblockClassAndQuotaCapacity(class, quota, nil, index)
+}
+
+// UnblockClassAndQuotaCapacity is like UnblockClassAndQuota, but only enqueues
+// as many evaluations as could plausibly fit in the capacity available on the
+// node which changed, in queue order. The capacity is computed lazily, and
+// only if the tracker is enabled and evaluations could be unblocked. A nil
+// capacity enqueues every evaluation that could potentially make progress.
+func (b *BlockedEvals) UnblockClassAndQuotaCapacity(class, quota string, capacity func() *structs.ComparableResources, index uint64) {
 	b.l.Lock()
 
 	// Do nothing if not enabled
@@ -478,6 +499,7 @@ func (b *BlockedEvals) UnblockClassAndQuota(class, quota string, index uint64) {
 		computedClass: class,
 		quotaChange:   quota,
 		index:         index,
+		capacity:      capacity,
 	}
 }
 
@@ -510,12 +532,12 @@ func (b *BlockedEvals) watchCapacity(stopCh <-chan struct{}, changeCh <-chan *ca
 		case <-stopCh:
 			return
 		case update := <-changeCh:
-			b.unblock(update.computedClass, update.quotaChange, update.index)
+			b.unblock(update.computedClass, update.quotaChange, update.capacity, update.index)
 		}
 	}
 }
 
-func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
+func (b *BlockedEvals) unblock(computedClass, quota string, capacityFn func() *structs.ComparableResources, index uint64) {
 	b.l.Lock()
 	defer b.l.Unlock()
 
@@ -526,19 +548,10 @@ func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
 
 	// Every eval that has escaped computed node class has to be unblocked
 	// because any node could potentially be feasible.
-	numEscaped := len(b.escaped)
-	numQuotaLimit := 0
-	unblocked := make(map[*structs.Evaluation]string, helper.MaxInt(numEscaped, 4))
-
-	if numEscaped != 0 && computedClass != "" {
-		for id, wrapped := range b.escaped {
-			unblocked[wrapped.eval] = wrapped.token
-			delete(b.escaped, id)
-			delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
-
-			if wrapped.eval.QuotaLimitReached != "" {
-				numQuotaLimit++
-			}
+	candidates := make([]wrappedEval, 0, helper.MaxInt(len(b.escaped), 4))
+	if computedClass != "" {
+		for _, wrapped := range b.escaped {
+			candidates = append(candidates, wrapped)
 		}
 	}
 
@@ -547,7 +560,7 @@ func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
 	// when the evaluation was originally run through the scheduler, that it
 	// never saw a node with the given computed class and thus needs to be
 	// unblocked for correctness.
-	for id, wrapped := range b.captured {
+	for _, wrapped := range b.captured {
 		if quota != "" && wrapped.eval.QuotaLimitReached != quota {
 			// We are unblocking based on quota and this eval doesn't match
 			continue
@@ -560,25 +573,188 @@ func (b *BlockedEvals) unblock(computedClass, quota string, index uint64) {
 		// Unblock the evaluation because it is either for the matching quota,
 		// is eligible based on the computed node class, or never seen the
 		// computed node class.
-		unblocked[wrapped.eval] = wrapped.token
-		delete(b.jobs, structs.NewNamespacedID(wrapped.eval.JobID, wrapped.eval.Namespace))
-		delete(b.captured, id)
-		if wrapped.eval.QuotaLimitReached != "" {
-			numQuotaLimit++
+		candidates = append(candidates, wrapped)
+	}
+
+	if len(candidates) == 0 {
+		return
+	}
+
+	// If the capacity of the node is known, only unblock the evals whose
+	// pending allocations could plausibly fit in it, in queue order, rather
+	// than having every eval retried and most of them blocked again.
+	if capacityFn != nil {
+		if capacity := capacityFn(); capacity != nil {
+			candidates = fitCapacity(candidates, computedClass, capacity)
+			if len(candidates) == 0 {
+				return
+			}
 		}
 	}
 
-	if len(unblocked) != 0 {
+	unblocked := make(map[*structs.Evaluation]string, len(candidates))
+	for _, wrapped := range candidates {
+		eval := wrapped.eval
+		unblocked[eval] = wrapped.token
+		delete(b.jobs, structs.NewNamespacedID(eval.JobID, eval.Namespace))
+		if _, ok := b.escaped[eval.ID]; ok {
+			delete(b.escaped, eval.ID)
+			b.stats.TotalEscaped--
+		} else {
+			delete(b.captured, eval.ID)
+		}
+
 		// Update the counters
-		b.stats.TotalEscaped = 0
-		b.stats.TotalQuotaLimit -= numQuotaLimit
-		for eval := range unblocked {
-			b.stats.Unblock(eval)
+		if eval.QuotaLimitReached != "" {
+			b.stats.TotalQuotaLimit--
 		}
+		b.stats.Unblock(eval)
+	}
 
-		// Enqueue all the unblocked evals into the broker.
-		b.evalBroker.EnqueueAll(unblocked)
+	// Enqueue all the unblocked evals into the broker.
+	b.evalBroker.EnqueueAll(unblocked)
+}
+
+// fitCapacity returns the blocked evals, in queue order, whose smallest pending
+// allocation fits in the capacity left over by the evals before them. Only the
+// evals which are known to be eligible for the computed class of the node use
+// up its capacity; evals which escaped computed node classes or never saw the
+// class may not be feasible on the node, so they are unblocked if they fit
+// without taking capacity away from the evals behind them.
+func fitCapacity(evals []wrappedEval, computedClass string, capacity *structs.ComparableResources) []wrappedEval {
+	sortQueue(evals)
+
+	cpu := capacity.Flattened.Cpu.CpuShares
+	memory := capacity.Flattened.Memory.MemoryMB
+
+	fit := evals[:0]
+	for _, wrapped := range evals {
+		askCPU, askMemory := placementAsk(wrapped.eval)
+		if askCPU > cpu || askMemory > memory {
+			continue
+		}
+		if !wrapped.eval.EscapedComputedClass && wrapped.eval.ClassEligibility[computedClass] {
+			cpu -= askCPU
+			memory -= askMemory
+		}
+		fit = append(fit, wrapped)
+	}
+	return fit
+}
+
+// placementAsk returns the resources exhausted by the smallest allocation that
+// the eval failed to place, which is a lower bound of the resources it needs
+// to make progress. It is zero if no resources were exhausted.
+func placementAsk(eval *structs.Evaluation) (cpu, memoryMB int64) {
+	first := true
+	for _, metric := range eval.FailedTGAllocs {
+		if metric == nil {
+			continue
+		}
+
+		var tgCPU, tgMemory int64
+		for _, r := range metric.ResourcesExhausted {
+			if r == nil {
+				continue
+			}
+			tgCPU += int64(r.CPU)
+			tgMemory += int64(r.MemoryMB)
+		}
+
+		if first || tgCPU+tgMemory < cpu+memoryMB {
+			cpu, memoryMB = tgCPU, tgMemory
+			first = false
+		}
+	}
+	return cpu, memoryMB
+}
+
+// sortQueue sorts the blocked evals in queue order: by descending priority,
+// then by the submit time of their job, then by creation.
+func sortQueue(evals []wrappedEval) {
+	sort.Slice(evals, func(i, j int) bool {
+		return queueLess(evals[i].eval, evals[j].eval)
+	})
+}
+
+// queueLess returns whether the blocked eval a is ahead of b in the queue.
+func queueLess(a, b *structs.Evaluation) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if a.JobSubmitTime != b.JobSubmitTime {
+		return a.JobSubmitTime < b.JobSubmitTime
+	}
+	if a.CreateIndex != b.CreateIndex {
+		return a.CreateIndex < b.CreateIndex
+	}
+	return a.ID < b.ID
+}
+
+// QueueStatus returns the position of the job in the job queue, formed by the
+// blocked evaluations. The returned status is not queued if the job has no
+// blocked evaluation.
+func (b *BlockedEvals) QueueStatus(namespace, jobID string) *structs.JobQueueStatus {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	status := &structs.JobQueueStatus{
+		JobID:     jobID,
+		Namespace: namespace,
+	}
+
+	evalID, ok := b.jobs[structs.NewNamespacedID(jobID, namespace)]
+	if !ok {
+		return status
+	}
+	wrapped, ok := b.captured[evalID]
+	if !ok {
+		if wrapped, ok = b.escaped[evalID]; !ok {
+			return status
+		}
+	}
+	eval := wrapped.eval
+
+	// The position is one plus the number of jobs whose blocked eval is ahead
+	// of the job's eval.
+	position, length := 1, 0
+	for _, id := range b.jobs {
+		other, ok := b.captured[id]
+		if !ok {
+			if other, ok = b.escaped[id]; !ok {
+				continue
+			}
+		}
+		length++
+		if other.eval.ID != eval.ID && queueLess(other.eval, eval) {
+			position++
+		}
+	}
+
+	status.Queued = true
+	status.Position = position
+	status.QueueLength = length
+	status.EvalID = eval.ID
+	status.Reason = eval.BlockedReason()
+	status.QueuedTime = eval.JobSubmitTime
+	if status.QueuedTime == 0 {
+		status.QueuedTime = eval.CreateTime
+	}
+	status.QueuedAllocations = queuedAllocations(eval)
+	return status
+}
+
+// queuedAllocations returns the number of allocations the blocked eval failed
+// to place by task group.
+func queuedAllocations(eval *structs.Evaluation) map[string]int {
+	queued := make(map[string]int, len(eval.FailedTGAllocs))
+	for tg, metric := range eval.FailedTGAllocs {
+		if metric == nil {
+			continue
+		}
+		queued[tg] = metric.CoalescedFailures + 1
 	}
+	return queued
 }
 
 // UnblockFailed unblocks all blocked evaluation that were due to scheduler
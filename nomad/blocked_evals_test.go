@@ -729,3 +729,163 @@ func TestBlockedEvals_SystemDisableFlush(t *testing.T) {
 	require.Empty(blocked.system.byJob)
 	require.Empty(blocked.system.byNode)
 }
+
+func TestBlockedEvals_UnblockCapacity(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// Create blocked evals that each need 1024MB of memory, submitted in
+	// order, with the latest one at a higher priority.
+	var evals []*structs.Evaluation
+	for i := 0; i < 4; i++ {
+		e := mock.BlockedEval()
+		e.JobSubmitTime = int64(i + 1)
+		e.ClassEligibility = map[string]bool{"v1:123": true}
+		evals = append(evals, e)
+	}
+	evals[3].Priority = 80
+	for _, e := range evals {
+		blocked.Block(e)
+	}
+	require.Equal(4, blocked.Stats().TotalBlocked)
+
+	// Free enough capacity for two of the evals.
+	capacity := &structs.ComparableResources{
+		Flattened: structs.AllocatedTaskResources{
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 1000},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 2500},
+		},
+	}
+	blocked.UnblockClassAndQuotaCapacity("v1:123", "", func() *structs.ComparableResources {
+		return capacity
+	}, 1000)
+
+	testutil.WaitForResult(func() (bool, error) {
+		if ready := broker.Stats().TotalReady; ready != 2 {
+			return false, fmt.Errorf("expected 2 ready evals, got %d", ready)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatalf("err: %s", err)
+	})
+
+	// The evals at the head of the queue were unblocked: the high priority
+	// one, then the earliest submitted one.
+	var unblocked []string
+	for i := 0; i < 2; i++ {
+		out, _, err := broker.Dequeue(defaultSched, time.Second)
+		require.NoError(err)
+		unblocked = append(unblocked, out.ID)
+	}
+	require.ElementsMatch([]string{evals[3].ID, evals[0].ID}, unblocked)
+
+	blockedStats := blocked.Stats()
+	require.Equal(2, blockedStats.TotalBlocked)
+
+	// The remaining evals moved up the queue.
+	status := blocked.QueueStatus(evals[2].Namespace, evals[2].JobID)
+	require.True(status.Queued)
+	require.Equal(2, status.Position)
+	require.Equal(2, status.QueueLength)
+
+	// Unblocking without a known capacity unblocks the rest.
+	blocked.Unblock("v1:123", 1001)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 2)
+}
+
+func TestBlockedEvals_UnblockCapacity_Ineligible(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	blocked, broker := testBlockedEvals(t)
+
+	// An escaped eval and an eval that never saw the class at the head of
+	// the queue, followed by an eval eligible for the class. Each needs
+	// 1024MB of memory.
+	escaped := mock.BlockedEval()
+	escaped.Priority = 90
+	escaped.EscapedComputedClass = true
+	unseen := mock.BlockedEval()
+	unseen.Priority = 80
+	unseen.ClassEligibility = map[string]bool{"v1:456": true}
+	eligible := mock.BlockedEval()
+	eligible.ClassEligibility = map[string]bool{"v1:123": true}
+	for _, e := range []*structs.Evaluation{escaped, unseen, eligible} {
+		blocked.Block(e)
+	}
+
+	// The capacity is only enough for one eval, but the evals which may not
+	// be feasible on the node don't use it up, so all of them are unblocked.
+	capacity := &structs.ComparableResources{
+		Flattened: structs.AllocatedTaskResources{
+			Cpu:    structs.AllocatedCpuResources{CpuShares: 1000},
+			Memory: structs.AllocatedMemoryResources{MemoryMB: 1500},
+		},
+	}
+	blocked.UnblockClassAndQuotaCapacity("v1:123", "", func() *structs.ComparableResources {
+		return capacity
+	}, 1000)
+	requireBlockedEvalsEnqueued(t, blocked, broker, 3)
+	require.Equal(0, blocked.Stats().TotalBlocked)
+}
+
+func TestBlockedEvals_UnblockCapacity_Disabled(t *testing.T) {
+	ci.Parallel(t)
+
+	blocked, _ := testBlockedEvals(t)
+	blocked.SetEnabled(false)
+
+	// The capacity is never computed when the tracker is disabled, as on
+	// followers.
+	blocked.UnblockClassAndQuotaCapacity("v1:123", "", func() *structs.ComparableResources {
+		t.Fatal("capacity computed while disabled")
+		return nil
+	}, 1000)
+}
+
+func TestBlockedEvals_QueueStatus(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	blocked, _ := testBlockedEvals(t)
+
+	low := mock.BlockedEval()
+	low.Priority = 10
+	low.JobSubmitTime = 1
+	blocked.Block(low)
+
+	late := mock.BlockedEval()
+	late.JobSubmitTime = 3
+	blocked.Block(late)
+
+	early := mock.BlockedEval()
+	early.JobSubmitTime = 2
+	early.QuotaLimitReached = "q1"
+	blocked.Block(early)
+
+	// The queue is ordered by priority and then by submit time.
+	for i, e := range []*structs.Evaluation{early, late, low} {
+		status := blocked.QueueStatus(e.Namespace, e.JobID)
+		require.True(status.Queued)
+		require.Equal(i+1, status.Position)
+		require.Equal(3, status.QueueLength)
+		require.Equal(e.ID, status.EvalID)
+		require.Equal(e.JobSubmitTime, status.QueuedTime)
+		require.Equal(map[string]int{"cache": 1}, status.QueuedAllocations)
+	}
+
+	require.Equal(`quota "q1" limit reached`, blocked.QueueStatus(early.Namespace, early.JobID).Reason)
+	require.Equal("resources exhausted: memory", blocked.QueueStatus(late.Namespace, late.JobID).Reason)
+
+	// A job without a blocked eval is not queued.
+	status := blocked.QueueStatus(structs.DefaultNamespace, "unknown")
+	require.False(status.Queued)
+	require.Zero(status.Position)
+
+	blocked.Untrack(early.JobID, early.Namespace)
+	status = blocked.QueueStatus(late.Namespace, late.JobID)
+	require.Equal(1, status.Position)
+	require.Equal(2, status.QueueLength)
+}
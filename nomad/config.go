@@ -141,6 +141,10 @@ type Config struct {
 	// that the workers dequeue for processing.
 	EnabledSchedulers []string
 
+	// ScorePlugins are the scoring plugins the schedulers use to rank nodes
+	// alongside the built in scoring iterators.
+	ScorePlugins []*config.ScorePluginConfig
+
 	// ReconcileInterval controls how often we reconcile the strongly
 	// consistent store with the Serf info. This is used to handle nodes
 	// that are force removed, as well as intermittent unavailability during
@@ -192,10 +196,41 @@ type Config struct {
 	// eligible for GC. This gives users some time to debug volumes.
 	CSIVolumeClaimGCThreshold time.Duration
 
+	// RootKeyGCInterval is how often we dispatch a job to rotate the active
+	// root key and GC inactive root keys
+	RootKeyGCInterval time.Duration
+
+	// RootKeyGCThreshold is how "old" an inactive root key must be to be
+	// eligible for GC. This gives servers time to replicate rekeyed data
+	// before the key is removed.
+	RootKeyGCThreshold time.Duration
+
+	// RootKeyRotationThreshold is how "old" the active root key must be
+	// before it is rotated, and its data rekeyed with the new key
+	RootKeyRotationThreshold time.Duration
+
+	// KeyringReplicationToken is the secret shared by the servers which they
+	// present to each other to fetch the key material of the root keys. The
+	// key material is only replicated when it is set.
+	KeyringReplicationToken string
+
+	// KeystoreKey is the key used to encrypt the root keys in the local
+	// keystore. If nil, a key is generated and written into the keystore.
+	KeystoreKey []byte
+
+	// RebalanceInterval is how often we dispatch a job to migrate
+	// allocations off under-utilized nodes, when rebalancing is enabled in
+	// the scheduler configuration.
+	RebalanceInterval time.Duration
+
 	// OneTimeTokenGCInterval is how often we dispatch a job to GC
 	// one-time tokens.
 	OneTimeTokenGCInterval time.Duration
 
+	// ACLTokenExpirationGCInterval is how often we dispatch a job to GC
+	// expired ACL tokens.
+	ACLTokenExpirationGCInterval time.Duration
+
 	// EvalNackTimeout controls how long we allow a sub-scheduler to
 	// work on an evaluation before we consider it failed and Nack it.
 	// This allows that evaluation to be handed to another sub-scheduler
@@ -349,6 +384,12 @@ type Config struct {
 	// DeploymentQueryRateLimit is in queries per second and is used by the
 	// DeploymentWatcher to throttle the amount of simultaneously deployments
 	DeploymentQueryRateLimit float64
+
+	// EvalRateLimit limits the rate at which the job register, evaluate,
+	// dispatch and scale endpoints are called, per namespace and per job.
+	// Namespaces can override the limits. Nil disables the limits unless
+	// set by a namespace.
+	EvalRateLimit *structs.EvalRateLimit
 }
 
 // DefaultConfig returns the default configuration. Only used as the basis for
@@ -385,6 +426,11 @@ func DefaultConfig() *Config {
 		CSIVolumeClaimGCInterval:         5 * time.Minute,
 		CSIVolumeClaimGCThreshold:        5 * time.Minute,
 		OneTimeTokenGCInterval:           10 * time.Minute,
+		ACLTokenExpirationGCInterval:     5 * time.Minute,
+		RootKeyGCInterval:                10 * time.Minute,
+		RootKeyGCThreshold:               1 * time.Hour,
+		RootKeyRotationThreshold:         720 * time.Hour,
+		RebalanceInterval:                5 * time.Minute,
 		EvalNackTimeout:                  60 * time.Second,
 		EvalDeliveryLimit:                3,
 		EvalNackInitialReenqueueDelay:    1 * time.Second,
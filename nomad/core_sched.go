@@ -1,6 +1,7 @@
 package nomad
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 	"strings"
@@ -58,6 +59,14 @@ func (c *CoreScheduler) Process(eval *structs.Evaluation) error {
 		return c.csiPluginGC(eval)
 	case structs.CoreJobOneTimeTokenGC:
 		return c.expiredOneTimeTokenGC(eval)
+	case structs.CoreJobExpiredACLTokenGC:
+		return c.expiredACLTokenGC(eval)
+	case structs.CoreJobRootKeyRotateOrGC:
+		return c.rootKeyRotateOrGC(eval)
+	case structs.CoreJobVariablesRekey:
+		return c.variablesRekey(eval)
+	case structs.CoreJobRebalance:
+		return c.rebalance(eval)
 	case structs.CoreJobForceGC:
 		return c.forceGC(eval)
 	default:
@@ -85,6 +94,12 @@ func (c *CoreScheduler) forceGC(eval *structs.Evaluation) error {
 	if err := c.expiredOneTimeTokenGC(eval); err != nil {
 		return err
 	}
+	if err := c.expiredACLTokenGC(eval); err != nil {
+		return err
+	}
+	if err := c.rootKeyGC(eval); err != nil {
+		return err
+	}
 	// Node GC must occur after the others to ensure the allocations are
 	// cleared.
 	return c.nodeGC(eval)
@@ -858,3 +873,357 @@ func (c *CoreScheduler) expiredOneTimeTokenGC(eval *structs.Evaluation) error {
 	}
 	return c.srv.RPC("ACL.ExpireOneTimeTokens", req, &structs.GenericResponse{})
 }
+
+// expiredACLTokenGC is used to garbage collect ACL tokens which have passed
+// their expiration time. Global tokens are only collected within the
+// authoritative region, and replicated deletions remove them from the other
+// regions.
+func (c *CoreScheduler) expiredACLTokenGC(eval *structs.Evaluation) error {
+	// ACL tokens only exist when ACLs are enabled
+	if !c.srv.config.ACLEnabled {
+		return nil
+	}
+
+	now := time.Now().UTC()
+
+	numLocal, err := c.reapExpiredACLTokens(eval, false, now)
+	if err != nil {
+		return err
+	}
+
+	var numGlobal int
+	if c.srv.config.Region == c.srv.config.AuthoritativeRegion {
+		if numGlobal, err = c.reapExpiredACLTokens(eval, true, now); err != nil {
+			return err
+		}
+	}
+
+	if numLocal+numGlobal > 0 {
+		c.logger.Info("expired ACL token GC removed tokens",
+			"local", numLocal, "global", numGlobal)
+	}
+	return nil
+}
+
+// reapExpiredACLTokens deletes the local or global ACL tokens which expired
+// before now, and returns the number of tokens deleted. Local and global
+// tokens cannot be deleted within the same request.
+func (c *CoreScheduler) reapExpiredACLTokens(eval *structs.Evaluation, global bool, now time.Time) (int, error) {
+	iter, err := c.snap.ACLTokensByExpired(global)
+	if err != nil {
+		return 0, err
+	}
+
+	// The tokens are ordered by expiration time, so stop at the first token
+	// which has not yet expired
+	var accessorIDs []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		token := raw.(*structs.ACLToken)
+		if !token.IsExpired(now) {
+			break
+		}
+		accessorIDs = append(accessorIDs, token.AccessorID)
+	}
+
+	if len(accessorIDs) == 0 {
+		return 0, nil
+	}
+	c.logger.Debug("expired ACL token GC found eligible tokens",
+		"tokens", len(accessorIDs), "global", global)
+
+	for _, ids := range partitionAll(maxIdsPerReap, accessorIDs) {
+		req := &structs.ACLTokenDeleteRequest{
+			AccessorIDs: ids,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC("ACL.DeleteTokens", req, &structs.GenericResponse{}); err != nil {
+			c.logger.Error("expired ACL token GC failed", "error", err)
+			return 0, err
+		}
+	}
+	return len(accessorIDs), nil
+}
+
+// rootKeyRotateOrGC is used to rotate the active root key once it is older
+// than the rotation threshold, to resume the rekeying of root keys, and to
+// garbage collect the inactive root keys which are no longer in use.
+func (c *CoreScheduler) rootKeyRotateOrGC(eval *structs.Evaluation) error {
+	rotated, err := c.rootKeyRotate(eval)
+	if err != nil {
+		return err
+	}
+
+	// A rotation starts rekeying the data of the old keys, so there is
+	// nothing to GC until a later run.
+	if rotated {
+		return nil
+	}
+	if err := c.rootKeyRekeyResume(eval); err != nil {
+		return err
+	}
+	return c.rootKeyGC(eval)
+}
+
+// rootKeyRekeyResume enqueues a rekey job if any root key is still being
+// rekeyed. The rekey job is only enqueued in memory by the rotation, so it is
+// lost if the leader fails or the job fails before all the keys are rekeyed.
+func (c *CoreScheduler) rootKeyRekeyResume(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.RootKeyMetas(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+		if keyMeta.Rekeying() {
+			c.logger.Debug("resuming root key rekey", "key_id", keyMeta.KeyID)
+			c.srv.evalBroker.Enqueue(c.srv.coreJobEval(structs.CoreJobVariablesRekey, eval.ModifyIndex))
+			return nil
+		}
+	}
+	return nil
+}
+
+// rootKeyRotate rotates the active root key if it is older than the rotation
+// threshold. The rotation is a full rotation, so the data encrypted with the
+// old keys is rekeyed and the keys can be garbage collected afterwards.
+func (c *CoreScheduler) rootKeyRotate(eval *structs.Evaluation) (bool, error) {
+	ws := memdb.NewWatchSet()
+	activeKey, err := c.snap.GetActiveRootKeyMeta(ws)
+	if err != nil {
+		return false, err
+	}
+	if activeKey == nil {
+		return false, nil // the keyring has not been initialized yet
+	}
+
+	cutoff := time.Now().UTC().Add(-1 * c.srv.config.RootKeyRotationThreshold)
+	if activeKey.CreateTime > cutoff.UnixNano() {
+		return false, nil
+	}
+
+	req := &structs.KeyringRotateRootKeyRequest{
+		Full: true,
+		WriteRequest: structs.WriteRequest{
+			Region:    c.srv.Region(),
+			AuthToken: eval.LeaderACL,
+		},
+	}
+	if err := c.srv.RPC(structs.KeyringRotateRPCMethod, req, &structs.KeyringRotateRootKeyResponse{}); err != nil {
+		c.logger.Error("root key rotation failed", "error", err)
+		return false, err
+	}
+	c.logger.Info("rotated root key", "key_id", activeKey.KeyID,
+		"root_key_rotation_threshold", c.srv.config.RootKeyRotationThreshold)
+	return true, nil
+}
+
+// rootKeyGC is used to garbage collect inactive root keys which are no
+// longer used to encrypt variables or to sign the identities of running
+// allocations.
+func (c *CoreScheduler) rootKeyGC(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.RootKeyMetas(ws)
+	if err != nil {
+		return err
+	}
+
+	var oldThreshold uint64
+	if eval.JobID == structs.CoreJobForceGC {
+		// The GC was forced, so set the threshold to its maximum so everything
+		// will GC.
+		oldThreshold = math.MaxUint64
+		c.logger.Debug("forced root key GC")
+	} else {
+		// Compute the old threshold limit for GC using the FSM
+		// time table.  This is a rough mapping of a time to the
+		// Raft index it belongs to.
+		tt := c.srv.fsm.TimeTable()
+		cutoff := time.Now().UTC().Add(-1 * c.srv.config.RootKeyGCThreshold)
+		oldThreshold = tt.NearestIndex(cutoff)
+		c.logger.Debug("root key GC scanning before cutoff index",
+			"index", oldThreshold, "root_key_gc_threshold", c.srv.config.RootKeyGCThreshold)
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+		if !keyMeta.Inactive() || keyMeta.ModifyIndex > oldThreshold {
+			continue
+		}
+
+		inUse, err := c.rootKeyInUse(keyMeta)
+		if err != nil {
+			return err
+		}
+		if inUse {
+			continue
+		}
+
+		req := &structs.KeyringDeleteRootKeyRequest{
+			KeyID: keyMeta.KeyID,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC(structs.KeyringDeleteRPCMethod, req, &structs.KeyringDeleteRootKeyResponse{}); err != nil {
+			c.logger.Error("root key delete failed", "key_id", keyMeta.KeyID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rootKeyInUse returns whether the root key is still used to encrypt any
+// variables, or may have signed the identities of a running allocation. The
+// identities of an allocation are signed with the key that was active when
+// the allocation was created.
+func (c *CoreScheduler) rootKeyInUse(keyMeta *structs.RootKeyMeta) (bool, error) {
+	ws := memdb.NewWatchSet()
+	vars, err := c.snap.GetVariablesByKeyID(ws, keyMeta.KeyID)
+	if err != nil {
+		return false, err
+	}
+	if vars.Next() != nil {
+		return true, nil
+	}
+
+	allocs, err := c.snap.Allocs(ws, state.SortDefault)
+	if err != nil {
+		return false, err
+	}
+	for raw := allocs.Next(); raw != nil; raw = allocs.Next() {
+		alloc := raw.(*structs.Allocation)
+		if alloc.TerminalStatus() || len(alloc.SignedIdentities) == 0 {
+			continue
+		}
+		if alloc.CreateIndex >= keyMeta.CreateIndex && alloc.CreateIndex <= keyMeta.ModifyIndex {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// variablesRekey is used to re-encrypt the variables encrypted with root keys
+// which are being rekeyed, using the active root key. Once all the variables
+// of a key have been re-encrypted, the key is marked as inactive so it can be
+// garbage collected.
+func (c *CoreScheduler) variablesRekey(eval *structs.Evaluation) error {
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.RootKeyMetas(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+		if !keyMeta.Rekeying() {
+			continue
+		}
+
+		if err := c.rekeyVariables(eval, keyMeta.KeyID); err != nil {
+			return err
+		}
+
+		keyMeta = keyMeta.Copy()
+		keyMeta.State = structs.RootKeyStateInactive
+		req := &structs.KeyringUpdateRootKeyMetaRequest{
+			RootKeyMeta: keyMeta,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC(structs.KeyringUpdateRPCMethod, req, &structs.KeyringUpdateRootKeyMetaResponse{}); err != nil {
+			c.logger.Error("root key update failed", "key_id", keyMeta.KeyID, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rekeyVariables re-encrypts all the variables encrypted with the root key
+// with the given ID. The variables are written with check-and-set, so any
+// variable written concurrently is left alone, as it has already been
+// encrypted with the active key.
+func (c *CoreScheduler) rekeyVariables(eval *structs.Evaluation, keyID string) error {
+	ws := memdb.NewWatchSet()
+	iter, err := c.snap.GetVariablesByKeyID(ws, keyID)
+	if err != nil {
+		return err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		encrypted := raw.(*structs.VariableEncrypted)
+
+		cleartext, err := c.srv.encrypter.Decrypt(encrypted.Data, encrypted.KeyID)
+		if err != nil {
+			return err
+		}
+		variable := &structs.VariableDecrypted{
+			VariableMetadata: encrypted.VariableMetadata,
+		}
+		if err := json.Unmarshal(cleartext, &variable.Items); err != nil {
+			return err
+		}
+
+		checkIndex := encrypted.ModifyIndex
+		req := &structs.VariablesUpsertRequest{
+			Var:        variable,
+			CheckIndex: &checkIndex,
+			WriteRequest: structs.WriteRequest{
+				Region:    c.srv.Region(),
+				Namespace: encrypted.Namespace,
+				AuthToken: eval.LeaderACL,
+			},
+		}
+		if err := c.srv.RPC(structs.VariablesUpsertRPCMethod, req, &structs.VariablesUpsertResponse{}); err != nil {
+			c.logger.Error("variable rekey failed",
+				"namespace", encrypted.Namespace, "path", encrypted.Path, "error", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// rebalance migrates allocations off under-utilized nodes, within the
+// disruption budget of the scheduler configuration, if rebalancing is enabled.
+func (c *CoreScheduler) rebalance(eval *structs.Evaluation) error {
+	_, schedConfig, err := c.snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	if schedConfig == nil || !schedConfig.RebalanceConfig.Enabled {
+		return nil
+	}
+
+	config := &schedConfig.RebalanceConfig
+	nodes, migrations, err := computeRebalance(c.snap, config, config.EffectiveMaxMigrations())
+	if err != nil {
+		return err
+	}
+	if len(migrations) == 0 {
+		return nil
+	}
+
+	req, err := rebalanceTransitionRequest(c.snap, migrations)
+	if err != nil {
+		return err
+	}
+	req.WriteRequest = structs.WriteRequest{
+		Region:    c.srv.config.Region,
+		AuthToken: eval.LeaderACL,
+	}
+
+	var resp structs.GenericResponse
+	if err := c.srv.RPC("Alloc.UpdateDesiredTransition", req, &resp); err != nil {
+		c.logger.Error("rebalance migrations failed", "error", err)
+		return err
+	}
+	c.logger.Debug("migrating allocations to rebalance nodes", "nodes", len(nodes), "allocs", len(migrations))
+	return nil
+}
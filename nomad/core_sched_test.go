@@ -2510,3 +2510,309 @@ func TestCoreScheduler_FailLoop(t *testing.T) {
 			out.TriggeredBy)
 	}
 }
+
+func TestCoreScheduler_VariablesRekey(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	store := srv.fsm.State()
+	oldKey, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	sv := mock.Variable()
+	upsertReq := &structs.VariablesUpsertRequest{
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.VariablesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+
+	// A full rotation marks the old key as rekeying
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		Full:         true,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringRotateRPCMethod, rotateReq, &rotateResp))
+	newKey := rotateResp.Key
+
+	out, err := store.RootKeyMetaByID(nil, oldKey.KeyID)
+	require.NoError(t, err)
+	require.True(t, out.Rekeying())
+
+	// Process the rekey job
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobVariablesRekey, rotateResp.Index)))
+
+	// The variable is encrypted with the new key, and the old key is
+	// inactive
+	encrypted, err := store.GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, newKey.KeyID, encrypted.KeyID)
+
+	readReq := &structs.VariablesReadRequest{
+		Path:         sv.Path,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var readResp structs.VariablesReadResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Equal(t, sv.Items, readResp.Data.Items)
+
+	out, err = store.RootKeyMetaByID(nil, oldKey.KeyID)
+	require.NoError(t, err)
+	require.True(t, out.Inactive())
+}
+
+func TestCoreScheduler_RootKeyRotateOrGC(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	store := srv.fsm.State()
+	key1, err := store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	// Write a variable with the first key, and rotate twice so the first two
+	// keys are inactive.
+	sv := mock.Variable()
+	upsertReq := &structs.VariablesUpsertRequest{
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.VariablesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+
+	rotate := func() *structs.RootKeyMeta {
+		req := &structs.KeyringRotateRootKeyRequest{
+			WriteRequest: structs.WriteRequest{Region: "global"},
+		}
+		var resp structs.KeyringRotateRootKeyResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringRotateRPCMethod, req, &resp))
+		return resp.Key
+	}
+	key2 := rotate()
+	key3 := rotate()
+
+	// Reset the FSM time table, which has witnessed the raft applies, and
+	// update it to make this work
+	srv.fsm.timetable.table = make([]TimeTableEntry, 1, 10)
+	tt := srv.fsm.TimeTable()
+	tt.Witness(2000, time.Now().UTC().Add(-1*srv.config.RootKeyGCThreshold))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, 2000)))
+
+	// The first key is still used by the variable, so only the second key is
+	// garbage collected. The active key has not reached the rotation
+	// threshold, so it is not rotated.
+	out, err := store.RootKeyMetaByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	out, err = store.RootKeyMetaByID(nil, key2.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	_, err = srv.encrypter.GetKey(key2.KeyID)
+	require.Error(t, err)
+	out, err = store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key3.KeyID, out.KeyID)
+
+	// Once the active key reaches the rotation threshold it is rotated, and
+	// a rekey job is enqueued
+	srv.config.RootKeyRotationThreshold = 0
+	snap, err = store.Snapshot()
+	require.NoError(t, err)
+	core = NewCoreScheduler(srv, snap)
+	require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, 2000)))
+
+	out, err = store.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, key3.KeyID, out.KeyID)
+
+	for _, keyID := range []string{key1.KeyID, key3.KeyID} {
+		out, err := store.RootKeyMetaByID(nil, keyID)
+		require.NoError(t, err)
+		require.True(t, out.Rekeying())
+	}
+
+	evalOut, token, err := srv.evalBroker.Dequeue([]string{structs.JobTypeCore}, time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+	require.Equal(t, structs.CoreJobVariablesRekey, evalOut.JobID)
+
+	// If the rekey job is lost before the keys are rekeyed, the next run
+	// enqueues it again
+	require.NoError(t, srv.evalBroker.Ack(evalOut.ID, token))
+	srv.config.RootKeyRotationThreshold = time.Hour
+	snap, err = store.Snapshot()
+	require.NoError(t, err)
+	core = NewCoreScheduler(srv, snap)
+	require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRootKeyRotateOrGC, 2000)))
+
+	evalOut, _, err = srv.evalBroker.Dequeue([]string{structs.JobTypeCore}, time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, evalOut)
+	require.Equal(t, structs.CoreJobVariablesRekey, evalOut.JobID)
+}
+
+func TestCoreScheduler_ExpiredACLTokenGC(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, cleanupSrv := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	past := time.Now().UTC().Add(-time.Minute)
+	future := time.Now().UTC().Add(time.Hour)
+
+	// Create expired local and global tokens, along with tokens which have
+	// not expired or have no expiry.
+	expiredLocal := mock.ACLToken()
+	expiredLocal.ExpirationTime = &past
+	expiredGlobal := mock.ACLToken()
+	expiredGlobal.Global = true
+	expiredGlobal.ExpirationTime = &past
+	unexpired := mock.ACLToken()
+	unexpired.ExpirationTime = &future
+	unexpiredGlobal := mock.ACLToken()
+	unexpiredGlobal.Global = true
+	unexpiredGlobal.ExpirationTime = &future
+	noExpiry := mock.ACLToken()
+
+	store := srv.fsm.State()
+	require.NoError(t, store.UpsertACLTokens(structs.MsgTypeTestSetup, 1000,
+		[]*structs.ACLToken{expiredLocal, expiredGlobal, unexpired, unexpiredGlobal, noExpiry}))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap)
+	require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobExpiredACLTokenGC, 1000)))
+
+	for _, token := range []*structs.ACLToken{expiredLocal, expiredGlobal} {
+		out, err := store.ACLTokenByAccessorID(nil, token.AccessorID)
+		require.NoError(t, err)
+		require.Nil(t, out)
+	}
+	for _, token := range []*structs.ACLToken{unexpired, unexpiredGlobal, noExpiry} {
+		out, err := store.ACLTokenByAccessorID(nil, token.AccessorID)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+	}
+}
+
+func TestCoreScheduler_ReapExpiredACLTokens(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, _, cleanupSrv := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	now := time.Now().UTC()
+
+	// Create local tokens which expire at different times, so that some of
+	// them have expired by the time of the GC.
+	var tokens []*structs.ACLToken
+	for i := -3; i < 3; i++ {
+		expirationTime := now.Add(time.Duration(i) * time.Minute)
+		token := mock.ACLToken()
+		token.ExpirationTime = &expirationTime
+		tokens = append(tokens, token)
+	}
+	globalToken := mock.ACLToken()
+	globalToken.Global = true
+	globalToken.ExpirationTime = &now
+	tokens = append(tokens, globalToken)
+
+	store := srv.fsm.State()
+	require.NoError(t, store.UpsertACLTokens(structs.MsgTypeTestSetup, 1000, tokens))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	core := NewCoreScheduler(srv, snap).(*CoreScheduler)
+	eval := srv.coreJobEval(structs.CoreJobExpiredACLTokenGC, 1000)
+
+	// The tokens which expired before the GC should be reported and deleted.
+	num, err := core.reapExpiredACLTokens(eval, false, now)
+	require.NoError(t, err)
+	require.Equal(t, 3, num)
+
+	num, err = core.reapExpiredACLTokens(eval, true, now.Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 1, num)
+
+	for i, token := range tokens {
+		out, err := store.ACLTokenByAccessorID(nil, token.AccessorID)
+		require.NoError(t, err)
+		if i < 3 || token.Global {
+			require.Nil(t, out)
+		} else {
+			require.NotNil(t, out)
+		}
+	}
+}
+
+func TestCoreScheduler_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+
+	store := srv.fsm.State()
+	_, _, job, allocs := upsertRebalanceTestCluster(t, store)
+
+	rebalance := func() {
+		snap, err := store.Snapshot()
+		require.NoError(t, err)
+		core := NewCoreScheduler(srv, snap)
+		require.NoError(t, core.Process(srv.coreJobEval(structs.CoreJobRebalance, 2000)))
+	}
+
+	// Rebalancing is disabled by default, so nothing is migrated.
+	rebalance()
+	out, err := store.AllocByID(nil, allocs[0].ID)
+	require.NoError(t, err)
+	require.False(t, out.DesiredTransition.ShouldMigrate())
+
+	require.NoError(t, store.SchedulerSetConfig(1000, &structs.SchedulerConfiguration{
+		RebalanceConfig: structs.RebalanceConfig{Enabled: true},
+	}))
+
+	// Once enabled, the allocation of the under-utilized node is migrated
+	// with a rebalance evaluation.
+	rebalance()
+	out, err = store.AllocByID(nil, allocs[0].ID)
+	require.NoError(t, err)
+	require.True(t, out.DesiredTransition.ShouldMigrate())
+	out, err = store.AllocByID(nil, allocs[1].ID)
+	require.NoError(t, err)
+	require.False(t, out.DesiredTransition.ShouldMigrate())
+
+	evals, err := store.EvalsByJob(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Len(t, evals, 1)
+	require.Equal(t, structs.EvalTriggerRebalance, evals[0].TriggeredBy)
+}
@@ -0,0 +1,622 @@
+package nomad
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Encrypter is the keyring for encrypting and decrypting data, such as
+// variables, and for signing workload identities. The metadata of the root
+// keys is held in the state store, while the key material is held in memory
+// and persisted to the local keystore, so it is available when the server
+// restarts. The key material in the keystore is encrypted with a key
+// encryption key which is unique to the server.
+type Encrypter struct {
+	srv          *Server
+	keystorePath string
+
+	// kek is the key encryption key of the keystore.
+	kek cipher.AEAD
+
+	// keysets holds the cipher and signing key of each root key by key ID.
+	keysets map[string]*keyset
+	lock    sync.RWMutex
+}
+
+// keyset holds the cipher and signing key derived from a root key.
+type keyset struct {
+	rootKey    *structs.RootKey
+	cipher     cipher.AEAD
+	privateKey ed25519.PrivateKey
+}
+
+// NewEncrypter loads or creates a new local keystore and returns an encrypter
+// which uses it. An empty keystore path keeps the keys in memory only, which
+// is used by servers in dev mode.
+func NewEncrypter(srv *Server, keystorePath string) (*Encrypter, error) {
+	encrypter := &Encrypter{
+		srv:          srv,
+		keystorePath: keystorePath,
+		keysets:      make(map[string]*keyset),
+	}
+	if keystorePath == "" {
+		return encrypter, nil
+	}
+
+	if err := os.MkdirAll(keystorePath, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create keystore: %v", err)
+	}
+	if err := encrypter.loadKEK(); err != nil {
+		return nil, err
+	}
+	if err := encrypter.loadKeystore(); err != nil {
+		return nil, err
+	}
+	if err := encrypter.migrateKEK(); err != nil {
+		return nil, err
+	}
+	return encrypter, nil
+}
+
+// Encrypt encrypts the cleartext with the currently active root key. It
+// returns the ciphertext along with the ID of the key used, which must be
+// stored alongside the ciphertext so it can be decrypted.
+func (e *Encrypter) Encrypt(cleartext []byte) ([]byte, string, error) {
+	ks, err := e.activeKeyset()
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce := make([]byte, ks.cipher.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, "", fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	// The nonce is prepended to the ciphertext, so it is available when
+	// decrypting.
+	return ks.cipher.Seal(nonce, nonce, cleartext, nil), ks.rootKey.Meta.KeyID, nil
+}
+
+// Decrypt decrypts the ciphertext using the root key with the given ID.
+func (e *Encrypter) Decrypt(ciphertext []byte, keyID string) ([]byte, error) {
+	ks, err := e.keysetByID(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := ks.cipher.NonceSize()
+	if len(ciphertext) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:nonceSize], ciphertext[nonceSize:]
+
+	cleartext, err := ks.cipher.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt with key %s: %v", keyID, err)
+	}
+	return cleartext, nil
+}
+
+// SignClaims signs the workload identity claims with the currently active
+// root key, and returns the resulting JWT. The ID of the key is set in the
+// header of the token, so it can be verified after the key is rotated.
+func (e *Encrypter) SignClaims(claims *structs.IdentityClaims) (string, error) {
+	ks, err := e.activeKeyset()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = ks.rootKey.Meta.KeyID
+
+	return token.SignedString(ks.privateKey)
+}
+
+// VerifyClaim verifies the signature of the workload identity JWT and
+// returns its claims.
+func (e *Encrypter) VerifyClaim(tokenString string) (*structs.IdentityClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &structs.IdentityClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Method.Alg())
+		}
+		keyID, ok := token.Header["kid"].(string)
+		if !ok || keyID == "" {
+			return nil, fmt.Errorf("missing key ID header")
+		}
+		ks, err := e.keysetByID(keyID)
+		if err != nil {
+			return nil, err
+		}
+		return ks.privateKey.Public(), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %v", err)
+	}
+
+	claims, ok := token.Claims.(*structs.IdentityClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("failed to verify token: invalid token")
+	}
+	return claims, nil
+}
+
+// PublicKey returns the public key of the root key with the given ID, which
+// is used to verify the workload identities it signed.
+func (e *Encrypter) PublicKey(keyMeta *structs.RootKeyMeta) (*structs.KeyringPublicKey, error) {
+	ks, err := e.keysetByID(keyMeta.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	return &structs.KeyringPublicKey{
+		KeyID:      keyMeta.KeyID,
+		PublicKey:  ks.privateKey.Public().(ed25519.PublicKey),
+		Algorithm:  structs.WorkloadIdentityAlgorithm,
+		Use:        structs.WorkloadIdentityKeyUse,
+		CreateTime: keyMeta.CreateTime,
+	}, nil
+}
+
+// AddKey adds a root key to the keyring and persists it to the keystore.
+// Adding a key which is already present is a no-op, so it is safe to call
+// when raft logs are replayed.
+func (e *Encrypter) AddKey(rootKey *structs.RootKey) error {
+	if err := rootKey.Validate(); err != nil {
+		return err
+	}
+
+	e.lock.RLock()
+	_, ok := e.keysets[rootKey.Meta.KeyID]
+	e.lock.RUnlock()
+	if ok {
+		return nil
+	}
+
+	ks, err := newKeyset(rootKey)
+	if err != nil {
+		return err
+	}
+	if err := e.saveKeyToStore(rootKey); err != nil {
+		return err
+	}
+
+	e.lock.Lock()
+	e.keysets[rootKey.Meta.KeyID] = ks
+	e.lock.Unlock()
+	return nil
+}
+
+// HasKey returns whether the root key with the given ID is in the keyring.
+func (e *Encrypter) HasKey(keyID string) bool {
+	_, err := e.keysetByID(keyID)
+	return err == nil
+}
+
+// GetKey returns the root key with the given ID from the keyring.
+func (e *Encrypter) GetKey(keyID string) (*structs.RootKey, error) {
+	ks, err := e.keysetByID(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return ks.rootKey.Copy(), nil
+}
+
+// RemoveKey removes the root key with the given ID from the keyring and the
+// keystore.
+func (e *Encrypter) RemoveKey(keyID string) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.keysets, keyID)
+	if e.keystorePath == "" {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(e.keystorePath, keyID+keystoreExtension))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove key %s from keystore: %v", keyID, err)
+	}
+	return nil
+}
+
+// activeKeyset returns the keyset of the currently active root key.
+func (e *Encrypter) activeKeyset() (*keyset, error) {
+	keyMeta, err := e.srv.fsm.State().GetActiveRootKeyMeta(nil)
+	if err != nil {
+		return nil, err
+	}
+	if keyMeta == nil {
+		return nil, fmt.Errorf("keyring has not been initialized yet")
+	}
+	return e.keysetByID(keyMeta.KeyID)
+}
+
+// keysetByID returns the keyset for the root key with the given ID.
+func (e *Encrypter) keysetByID(keyID string) (*keyset, error) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	ks, ok := e.keysets[keyID]
+	if !ok {
+		return nil, fmt.Errorf("root key %s not found in keyring", keyID)
+	}
+	return ks, nil
+}
+
+// newKeyset builds the cipher and signing key for the given root key.
+func newKeyset(rootKey *structs.RootKey) (*keyset, error) {
+	keyID := rootKey.Meta.KeyID
+
+	ks := &keyset{rootKey: rootKey.Copy()}
+	switch rootKey.Meta.Algorithm {
+	case structs.EncryptionAlgorithmAES256GCM:
+		block, err := aes.NewCipher(rootKey.Key)
+		if err != nil {
+			return nil, fmt.Errorf("could not create cipher for key %s: %v", keyID, err)
+		}
+		ks.cipher, err = cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("could not create cipher for key %s: %v", keyID, err)
+		}
+	default:
+		return nil, fmt.Errorf("root key %s uses unsupported algorithm %q", keyID, rootKey.Meta.Algorithm)
+	}
+
+	// The signing key is derived from the root key material, which is the
+	// same size as an Ed25519 seed.
+	if len(rootKey.Key) != ed25519.SeedSize {
+		return nil, fmt.Errorf("root key %s cannot be used for signing", keyID)
+	}
+	ks.privateKey = ed25519.NewKeyFromSeed(rootKey.Key)
+	return ks, nil
+}
+
+const (
+	// keystoreExtension is the file extension of root keys in the keystore.
+	keystoreExtension = ".nks.json"
+
+	// keystoreKEKFile is the name of the file holding the key encryption key
+	// of the keystore.
+	keystoreKEKFile = "keystore.kek"
+)
+
+// wrappedRootKey is the form of a root key written to the keystore. The key
+// material is encrypted with the key encryption key of the keystore, using
+// the key ID as additional data so the file can't be swapped for another.
+type wrappedRootKey struct {
+	Meta         *structs.RootKeyMeta
+	EncryptedKey []byte
+}
+
+// loadKEK reads the key encryption key of the keystore, creating it if the
+// keystore is new. The generated key is written into the keystore alongside
+// the keys it encrypts, so it gives no protection to a copy of the whole data
+// dir; servers which need the keystore encrypted at rest configure the key
+// instead, which is never written to disk.
+func (e *Encrypter) loadKEK() error {
+	path := filepath.Join(e.keystorePath, keystoreKEKFile)
+	kek, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		if key := e.configuredKEK(); key != nil {
+			e.kek, err = newKEK(key)
+			return err
+		}
+		kek = make([]byte, 32)
+		if _, err := rand.Read(kek); err != nil {
+			return fmt.Errorf("failed to generate keystore key encryption key: %v", err)
+		}
+		if err := writeFileAtomic(path, kek); err != nil {
+			return fmt.Errorf("failed to write keystore key encryption key: %v", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to read keystore key encryption key: %v", err)
+	}
+
+	e.kek, err = newKEK(kek)
+	return err
+}
+
+// migrateKEK re-encrypts the keystore with the configured key encryption key
+// if it was encrypted with a generated key, and removes the generated key.
+func (e *Encrypter) migrateKEK() error {
+	key := e.configuredKEK()
+	if key == nil {
+		return nil
+	}
+	path := filepath.Join(e.keystorePath, keystoreKEKFile)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	kek, err := newKEK(key)
+	if err != nil {
+		return err
+	}
+	e.kek = kek
+	for _, ks := range e.keysets {
+		if err := e.saveKeyToStore(ks.rootKey); err != nil {
+			return err
+		}
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove keystore key encryption key: %v", err)
+	}
+	return nil
+}
+
+// configuredKEK returns the key encryption key configured on the server, or
+// nil if the key is generated.
+func (e *Encrypter) configuredKEK() []byte {
+	if e.srv == nil || len(e.srv.config.KeystoreKey) == 0 {
+		return nil
+	}
+	return e.srv.config.KeystoreKey
+}
+
+// newKEK returns the cipher of a key encryption key.
+func newKEK(kek []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(kek)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore key encryption key: %v", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keystore key encryption key: %v", err)
+	}
+	return aead, nil
+}
+
+// saveKeyToStore writes the root key to the keystore, with the key material
+// encrypted by the key encryption key. The file is written atomically, so a
+// crash never leaves a partially written key behind.
+func (e *Encrypter) saveKeyToStore(rootKey *structs.RootKey) error {
+	if e.keystorePath == "" {
+		return nil
+	}
+
+	nonce := make([]byte, e.kek.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	wrapped := &wrappedRootKey{
+		Meta:         rootKey.Meta,
+		EncryptedKey: e.kek.Seal(nonce, nonce, rootKey.Key, []byte(rootKey.Meta.KeyID)),
+	}
+
+	buf, err := json.Marshal(wrapped)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(e.keystorePath, rootKey.Meta.KeyID+keystoreExtension)
+	if err := writeFileAtomic(path, buf); err != nil {
+		return fmt.Errorf("failed to write key %s to keystore: %v", rootKey.Meta.KeyID, err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes the file readable only by the owner, through a
+// temporary file which is renamed into place.
+func writeFileAtomic(path string, buf []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, buf, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// loadKeystore reads all the root keys held in the keystore into the
+// keyring.
+func (e *Encrypter) loadKeystore() error {
+	entries, err := os.ReadDir(e.keystorePath)
+	if err != nil {
+		return fmt.Errorf("failed to read keystore: %v", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), keystoreExtension) {
+			continue
+		}
+
+		path := filepath.Join(e.keystorePath, entry.Name())
+		buf, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read key from keystore: %v", err)
+		}
+
+		var wrapped wrappedRootKey
+		if err := json.Unmarshal(buf, &wrapped); err != nil {
+			return fmt.Errorf("failed to decode key %s from keystore: %v", path, err)
+		}
+		if wrapped.Meta == nil ||
+			wrapped.Meta.KeyID+keystoreExtension != entry.Name() {
+			return fmt.Errorf("root key in %s does not match its file name", path)
+		}
+
+		nonceSize := e.kek.NonceSize()
+		if len(wrapped.EncryptedKey) < nonceSize {
+			return fmt.Errorf("failed to decrypt key %s from keystore: ciphertext too short", path)
+		}
+		nonce, ciphertext := wrapped.EncryptedKey[:nonceSize], wrapped.EncryptedKey[nonceSize:]
+		key, err := e.kek.Open(nil, nonce, ciphertext, []byte(wrapped.Meta.KeyID))
+		if err != nil {
+			return fmt.Errorf("failed to decrypt key %s from keystore: %v", path, err)
+		}
+
+		ks, err := newKeyset(&structs.RootKey{Meta: wrapped.Meta, Key: key})
+		if err != nil {
+			return err
+		}
+		e.keysets[wrapped.Meta.KeyID] = ks
+	}
+	return nil
+}
+
+// initializeKeyring creates the first root key if the cluster does not
+// already have an active one. It waits until all servers are able to apply
+// the root key to their state, and is therefore run in its own goroutine
+// when leadership is established.
+func (s *Server) initializeKeyring(stopCh <-chan struct{}) {
+	logger := s.logger.Named("keyring")
+
+	keyMeta, err := s.fsm.State().GetActiveRootKeyMeta(nil)
+	if err != nil {
+		logger.Error("failed to get active root key", "error", err)
+		return
+	}
+	if keyMeta != nil {
+		return
+	}
+
+	logger.Trace("verifying cluster is ready to initialize keyring")
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for !ServersMeetMinimumVersion(s.Members(), minKeyringVersion, true) {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+
+	rootKey, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	if err != nil {
+		logger.Error("could not initialize keyring", "error", err)
+		return
+	}
+
+	// The key material is added to the local keyring before the metadata is
+	// written to raft, so the other servers can replicate it from the leader.
+	if err := s.encrypter.AddKey(rootKey); err != nil {
+		logger.Error("could not initialize keyring", "error", err)
+		return
+	}
+
+	req := structs.RootKeyMetaUpsertRequest{RootKeyMeta: rootKey.Meta}
+	if _, _, err := s.raftApply(structs.RootKeyMetaUpsertRequestType, req); err != nil {
+		logger.Error("could not initialize keyring", "error", err)
+		return
+	}
+
+	logger.Info("initialized keyring", "id", rootKey.Meta.KeyID)
+}
+
+// replicateKeyring runs on every server and fetches the key material of the
+// root keys written to state, which is not written to raft, from the leader or
+// any other server which holds it.
+func (s *Server) replicateKeyring(ctx context.Context) {
+	logger := s.logger.Named("keyring")
+
+	for {
+		store := s.fsm.State()
+		ws := memdb.NewWatchSet()
+		ws.Add(store.AbandonCh())
+
+		var failed bool
+		iter, err := store.RootKeyMetas(ws)
+		if err != nil {
+			logger.Error("failed to list root keys", "error", err)
+			failed = true
+		} else {
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				keyMeta := raw.(*structs.RootKeyMeta)
+				if s.encrypter.HasKey(keyMeta.KeyID) {
+					continue
+				}
+				if !s.keyringReplicationEnabled() {
+					logger.Error("root key is missing and can't be replicated without a keyring replication token",
+						"id", keyMeta.KeyID)
+					continue
+				}
+				if err := s.replicateKey(keyMeta); err != nil {
+					logger.Warn("failed to replicate root key", "id", keyMeta.KeyID, "error", err)
+					failed = true
+					continue
+				}
+				logger.Debug("replicated root key", "id", keyMeta.KeyID)
+			}
+		}
+
+		// Keys which could not be replicated are retried, for example if they
+		// were rotated while the leader changed.
+		if failed {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(keyringReplicationRetryInterval):
+			}
+			continue
+		}
+
+		if err := ws.WatchCtx(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// keyringReplicationEnabled returns whether the servers can replicate the key
+// material of the root keys, which requires the keyring replication token they
+// share.
+func (s *Server) keyringReplicationEnabled() bool {
+	return s.config.KeyringReplicationToken != ""
+}
+
+// keyringReplicationRetryInterval is the interval at which the replication of
+// root keys which could not be fetched from any server is retried.
+const keyringReplicationRetryInterval = 5 * time.Second
+
+// replicateKey fetches the key material of a root key from the leader,
+// falling back to the other servers, and adds it to the local keyring.
+func (s *Server) replicateKey(keyMeta *structs.RootKeyMeta) error {
+	req := &structs.KeyringGetRootKeyRequest{
+		KeyID: keyMeta.KeyID,
+		QueryOptions: structs.QueryOptions{
+			Region:    s.config.Region,
+			AuthToken: s.config.KeyringReplicationToken,
+		},
+	}
+	var resp structs.KeyringGetRootKeyResponse
+	err := s.RPC(structs.KeyringGetRPCMethod, req, &resp)
+	if err != nil || resp.Key == nil {
+		// The key may have been rotated by a previous leader which failed
+		// before the current leader replicated it, so every server is asked
+		// for it directly.
+		s.peerLock.RLock()
+		servers := make([]*serverParts, 0, len(s.localPeers))
+		for _, server := range s.localPeers {
+			servers = append(servers, server)
+		}
+		s.peerLock.RUnlock()
+
+		req.AllowStale = true
+		for _, server := range servers {
+			resp = structs.KeyringGetRootKeyResponse{}
+			err = s.forwardServer(server, structs.KeyringGetRPCMethod, req, &resp)
+			if err == nil && resp.Key != nil {
+				break
+			}
+		}
+	}
+	if resp.Key == nil {
+		if err == nil {
+			err = fmt.Errorf("no server holds the key material")
+		}
+		return err
+	}
+
+	return s.encrypter.AddKey(resp.Key)
+}
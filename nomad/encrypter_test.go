@@ -0,0 +1,140 @@
+package nomad
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncrypter_SignVerifyClaims(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, nil)
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	alloc := mock.Alloc()
+	claims := structs.NewIdentityClaims(alloc.Job, alloc, "web", time.Now())
+
+	token, err := srv.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+	require.True(t, isWorkloadIdentity(token))
+
+	got, err := srv.encrypter.VerifyClaim(token)
+	require.NoError(t, err)
+	require.Equal(t, alloc.Namespace, got.Namespace)
+	require.Equal(t, alloc.JobID, got.JobID)
+	require.Equal(t, alloc.TaskGroup, got.TaskGroup)
+	require.Equal(t, "web", got.TaskName)
+	require.Equal(t, alloc.ID, got.AllocationID)
+
+	// A token with a tampered signature is rejected
+	_, err = srv.encrypter.VerifyClaim(token[:len(token)-4] + "AAAA")
+	require.Error(t, err)
+
+	// A token signed by an unknown key is rejected
+	other, cleanupOther := TestServer(t, nil)
+	defer cleanupOther()
+	testutil.WaitForLeader(t, other.RPC)
+	waitForKeyring(t, other)
+
+	otherToken, err := other.encrypter.SignClaims(claims)
+	require.NoError(t, err)
+	_, err = srv.encrypter.VerifyClaim(otherToken)
+	require.Error(t, err)
+}
+
+func TestEncrypter_PublicKey(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, nil)
+	defer cleanupSrv()
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	keyMeta, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	pubKey, err := srv.encrypter.PublicKey(keyMeta)
+	require.NoError(t, err)
+	require.Equal(t, keyMeta.KeyID, pubKey.KeyID)
+	require.Equal(t, structs.WorkloadIdentityAlgorithm, pubKey.Algorithm)
+	require.Equal(t, structs.WorkloadIdentityKeyUse, pubKey.Use)
+	require.Len(t, pubKey.PublicKey, 32)
+}
+
+func TestEncrypter_Keystore(t *testing.T) {
+	ci.Parallel(t)
+	keystorePath := t.TempDir()
+
+	encrypter, err := NewEncrypter(nil, keystorePath)
+	require.NoError(t, err)
+
+	key := mock.RootKey()
+	require.NoError(t, encrypter.AddKey(key))
+	require.FileExists(t, filepath.Join(keystorePath, key.Meta.KeyID+keystoreExtension))
+	require.FileExists(t, filepath.Join(keystorePath, keystoreKEKFile))
+
+	// The key material is encrypted in the keystore
+	buf, err := os.ReadFile(filepath.Join(keystorePath, key.Meta.KeyID+keystoreExtension))
+	require.NoError(t, err)
+	require.NotContains(t, string(buf), base64.StdEncoding.EncodeToString(key.Key))
+
+	// Adding the same key again is a no-op
+	require.NoError(t, encrypter.AddKey(key))
+
+	// A new encrypter loads the keys from the keystore
+	encrypter, err = NewEncrypter(nil, keystorePath)
+	require.NoError(t, err)
+
+	out, err := encrypter.GetKey(key.Meta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, key.Key, out.Key)
+
+	// Removing the key removes it from the keystore
+	require.NoError(t, encrypter.RemoveKey(key.Meta.KeyID))
+	require.NoFileExists(t, filepath.Join(keystorePath, key.Meta.KeyID+keystoreExtension))
+	_, err = encrypter.GetKey(key.Meta.KeyID)
+	require.Error(t, err)
+
+	encrypter, err = NewEncrypter(nil, keystorePath)
+	require.NoError(t, err)
+	_, err = encrypter.GetKey(key.Meta.KeyID)
+	require.Error(t, err)
+}
+
+func TestEncrypter_Keystore_ConfiguredKEK(t *testing.T) {
+	ci.Parallel(t)
+	keystorePath := t.TempDir()
+
+	// A keystore encrypted with a generated key
+	encrypter, err := NewEncrypter(nil, keystorePath)
+	require.NoError(t, err)
+	key := mock.RootKey()
+	require.NoError(t, encrypter.AddKey(key))
+
+	// Configuring a key re-encrypts the keystore and removes the generated key
+	srv := &Server{config: DefaultConfig()}
+	srv.config.KeystoreKey = make([]byte, 32)
+	encrypter, err = NewEncrypter(srv, keystorePath)
+	require.NoError(t, err)
+	require.NoFileExists(t, filepath.Join(keystorePath, keystoreKEKFile))
+
+	encrypter, err = NewEncrypter(srv, keystorePath)
+	require.NoError(t, err)
+	out, err := encrypter.GetKey(key.Meta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, key.Key, out.Key)
+
+	// The keystore can't be read with another key
+	srv.config.KeystoreKey = []byte("01234567890123456789012345678901")
+	_, err = NewEncrypter(srv, keystorePath)
+	require.Error(t, err)
+}
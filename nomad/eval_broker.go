@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -41,6 +42,11 @@ var (
 // to only dequeue work they know how to handle. The broker is designed to be entirely
 // in-memory and is managed by the leader node.
 //
+// In fair-share mode, the ready evaluations are instead dequeued from the
+// namespaces in turn by weighted round-robin, so a namespace with many ready
+// evaluations cannot starve the others. Evaluations are still dequeued by
+// priority within a namespace.
+//
 // The broker must provide at-least-once delivery semantics. It relies on explicit
 // Ack/Nack messages to handle this. If a delivery is not Ack'd in a sufficient time
 // span, it will be assumed Nack'd.
@@ -62,8 +68,21 @@ type EvalBroker struct {
 	// blocked tracks the blocked evaluations by JobID in a priority queue
 	blocked map[structs.NamespacedID]PendingEvaluations
 
-	// ready tracks the ready jobs by scheduler in a priority queue
-	ready map[string]PendingEvaluations
+	// ready tracks the ready jobs by scheduler in a priority queue per
+	// namespace
+	ready map[string]readyEvals
+
+	// fairShare enables dequeuing the ready evaluations fairly across
+	// namespaces rather than strictly by priority.
+	fairShare bool
+
+	// fairShareWeights are the weights of the namespaces in fair-share mode.
+	// Namespaces without a weight have a weight of 1.
+	fairShareWeights map[string]int
+
+	// fairShareCredits are the current credits of the namespaces with ready
+	// evaluations, used to implement smooth weighted round-robin.
+	fairShareCredits map[string]int
 
 	// unack is a map of evalID to an un-acknowledged evaluation
 	unack map[string]*unackEval
@@ -116,6 +135,10 @@ type unackEval struct {
 // priority queue
 type PendingEvaluations []*structs.Evaluation
 
+// readyEvals is the ready evaluations of a scheduler, in a priority queue per
+// namespace.
+type readyEvals map[string]PendingEvaluations
+
 // NewEvalBroker creates a new evaluation broker. This is parameterized
 // with the timeout used for messages that are not acknowledged before we
 // assume a Nack and attempt to redeliver as well as the deliveryLimit
@@ -135,7 +158,9 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		evals:                make(map[string]int),
 		jobEvals:             make(map[structs.NamespacedID]string),
 		blocked:              make(map[structs.NamespacedID]PendingEvaluations),
-		ready:                make(map[string]PendingEvaluations),
+		ready:                make(map[string]readyEvals),
+		fairShareWeights:     make(map[string]int),
+		fairShareCredits:     make(map[string]int),
 		unack:                make(map[string]*unackEval),
 		waiting:              make(map[string]chan struct{}),
 		requeue:              make(map[string]*structs.Evaluation),
@@ -146,6 +171,7 @@ func NewEvalBroker(timeout, initialNackDelay, subsequentNackDelay time.Duration,
 		delayedEvalsUpdateCh: make(chan struct{}, 1),
 	}
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 
 	return b, nil
@@ -178,6 +204,37 @@ func (b *EvalBroker) SetEnabled(enabled bool) {
 	}
 }
 
+// SetFairShare is used to control if the broker dequeues the ready evaluations
+// fairly across namespaces, and the weights of the namespaces. Namespaces
+// without a positive weight have a weight of 1. The configuration is kept when
+// the broker is disabled.
+func (b *EvalBroker) SetFairShare(enabled bool, weights map[string]int) {
+	b.l.Lock()
+	defer b.l.Unlock()
+
+	b.fairShare = enabled
+	b.fairShareWeights = make(map[string]int, len(weights))
+	for namespace, weight := range weights {
+		if weight > 0 {
+			b.fairShareWeights[namespace] = weight
+		}
+	}
+	b.fairShareCredits = make(map[string]int)
+}
+
+// FairShare returns whether the broker dequeues the ready evaluations fairly
+// across namespaces, and the weights of the namespaces.
+func (b *EvalBroker) FairShare() (bool, map[string]int) {
+	b.l.RLock()
+	defer b.l.RUnlock()
+
+	weights := make(map[string]int, len(b.fairShareWeights))
+	for namespace, weight := range b.fairShareWeights {
+		weights[namespace] = weight
+	}
+	return b.fairShare, weights
+}
+
 // Enqueue is used to enqueue a new evaluation
 func (b *EvalBroker) Enqueue(eval *structs.Evaluation) {
 	b.l.Lock()
@@ -299,17 +356,17 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 	}
 
 	// Find the pending by scheduler class
-	pending, ok := b.ready[queue]
+	ready, ok := b.ready[queue]
 	if !ok {
-		pending = make([]*structs.Evaluation, 0, 16)
+		ready = make(readyEvals)
+		b.ready[queue] = ready
 		if _, ok := b.waiting[queue]; !ok {
 			b.waiting[queue] = make(chan struct{}, 1)
 		}
 	}
 
 	// Push onto the heap
-	heap.Push(&pending, eval)
-	b.ready[queue] = pending
+	ready.push(eval)
 
 	// Update the stats
 	b.stats.TotalReady += 1
@@ -319,6 +376,12 @@ func (b *EvalBroker) enqueueLocked(eval *structs.Evaluation, queue string) {
 		b.stats.ByScheduler[queue] = bySched
 	}
 	bySched.Ready += 1
+	byNamespace, ok := b.stats.ByNamespace[eval.Namespace]
+	if !ok {
+		byNamespace = &NamespaceStats{}
+		b.stats.ByNamespace[eval.Namespace] = byNamespace
+	}
+	byNamespace.Ready += 1
 
 	// Unblock any blocked dequeues
 	select {
@@ -378,6 +441,15 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		return nil, "", fmt.Errorf("eval broker disabled")
 	}
 
+	// In fair-share mode, only scan the namespace whose turn it is
+	var namespace string
+	if b.fairShare {
+		namespace = b.nextFairShareNamespace(schedulers)
+		if namespace == "" {
+			return nil, "", nil
+		}
+	}
+
 	// Scan for eligible work
 	var eligibleSched []string
 	var eligiblePriority int
@@ -389,7 +461,7 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 		}
 
 		// Peek at the next item
-		ready := pending.Peek()
+		ready, _ := pending.peek(namespace)
 		if ready == nil {
 			continue
 		}
@@ -415,24 +487,72 @@ func (b *EvalBroker) scanForSchedulers(schedulers []string) (*structs.Evaluation
 
 	case 1:
 		// Only a single task, dequeue
-		return b.dequeueForSched(eligibleSched[0])
+		return b.dequeueForSched(eligibleSched[0], namespace)
 
 	default:
 		// Multiple tasks. We pick a random task so that we fairly
 		// distribute work.
 		offset := rand.Intn(n)
-		return b.dequeueForSched(eligibleSched[offset])
+		return b.dequeueForSched(eligibleSched[offset], namespace)
 	}
 }
 
-// dequeueForSched is used to dequeue the next work item for a given scheduler.
-// This assumes locks are held and that this scheduler has work
-func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string, error) {
+// nextFairShareNamespace returns the namespace to dequeue from next in
+// fair-share mode, among the namespaces with ready evaluations for any of the
+// schedulers. The namespaces are served by smooth weighted round-robin, which
+// interleaves them in proportion to their weights. An empty string is
+// returned if there is no ready evaluation. This assumes locks are held.
+func (b *EvalBroker) nextFairShareNamespace(schedulers []string) string {
+	candidates := make(map[string]struct{})
+	for _, sched := range schedulers {
+		for namespace, pending := range b.ready[sched] {
+			if len(pending) > 0 {
+				candidates[namespace] = struct{}{}
+			}
+		}
+	}
+
+	// Namespaces without ready evaluations lose their credits, so they do not
+	// accumulate a burst of turns while idle.
+	for namespace := range b.fairShareCredits {
+		if _, ok := candidates[namespace]; !ok {
+			delete(b.fairShareCredits, namespace)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	// Iterate in a stable order, so equal credits are broken by name.
+	namespaces := make([]string, 0, len(candidates))
+	for namespace := range candidates {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	var next string
+	total := 0
+	for _, namespace := range namespaces {
+		weight := b.fairShareWeights[namespace]
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+		b.fairShareCredits[namespace] += weight
+		if next == "" || b.fairShareCredits[namespace] > b.fairShareCredits[next] {
+			next = namespace
+		}
+	}
+	b.fairShareCredits[next] -= total
+	return next
+}
+
+// dequeueForSched is used to dequeue the next work item for a given scheduler,
+// from the given namespace or from any namespace if empty. This assumes locks
+// are held and that this scheduler has work
+func (b *EvalBroker) dequeueForSched(sched, namespace string) (*structs.Evaluation, string, error) {
 	// Get the pending queue
-	pending := b.ready[sched]
-	raw := heap.Pop(&pending)
-	b.ready[sched] = pending
-	eval := raw.(*structs.Evaluation)
+	eval := b.ready[sched].pop(namespace)
 
 	// Generate a UUID for the token
 	token := uuid.Generate()
@@ -458,6 +578,12 @@ func (b *EvalBroker) dequeueForSched(sched string) (*structs.Evaluation, string,
 	bySched := b.stats.ByScheduler[sched]
 	bySched.Ready -= 1
 	bySched.Unacked += 1
+	if byNamespace := b.stats.ByNamespace[eval.Namespace]; byNamespace != nil {
+		byNamespace.Ready -= 1
+		if byNamespace.Ready <= 0 {
+			delete(b.stats.ByNamespace, eval.Namespace)
+		}
+	}
 
 	return eval, token, nil
 }
@@ -727,10 +853,12 @@ func (b *EvalBroker) flush() {
 	b.stats.TotalWaiting = 0
 	b.stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	b.stats.ByScheduler = make(map[string]*SchedulerStats)
+	b.stats.ByNamespace = make(map[string]*NamespaceStats)
 	b.evals = make(map[string]int)
 	b.jobEvals = make(map[structs.NamespacedID]string)
 	b.blocked = make(map[structs.NamespacedID]PendingEvaluations)
-	b.ready = make(map[string]PendingEvaluations)
+	b.ready = make(map[string]readyEvals)
+	b.fairShareCredits = make(map[string]int)
 	b.unack = make(map[string]*unackEval)
 	b.timeWait = make(map[string]*time.Timer)
 	b.delayHeap = delayheap.NewDelayHeap()
@@ -813,6 +941,7 @@ func (b *EvalBroker) Stats() *BrokerStats {
 	stats := new(BrokerStats)
 	stats.DelayedEvals = make(map[string]*structs.Evaluation)
 	stats.ByScheduler = make(map[string]*SchedulerStats)
+	stats.ByNamespace = make(map[string]*NamespaceStats)
 
 	b.l.RLock()
 	defer b.l.RUnlock()
@@ -830,6 +959,10 @@ func (b *EvalBroker) Stats() *BrokerStats {
 		subStatCopy := *subStat
 		stats.ByScheduler[sched] = &subStatCopy
 	}
+	for namespace, subStat := range b.stats.ByNamespace {
+		subStatCopy := *subStat
+		stats.ByNamespace[namespace] = &subStatCopy
+	}
 	return stats
 }
 
@@ -861,6 +994,11 @@ func (b *EvalBroker) EmitStats(period time.Duration, stopCh <-chan struct{}) {
 				metrics.SetGauge([]string{"nomad", "broker", sched, "ready"}, float32(schedStats.Ready))
 				metrics.SetGauge([]string{"nomad", "broker", sched, "unacked"}, float32(schedStats.Unacked))
 			}
+			for namespace, namespaceStats := range stats.ByNamespace {
+				metrics.SetGaugeWithLabels([]string{"nomad", "broker", "namespace_ready"},
+					float32(namespaceStats.Ready),
+					[]metrics.Label{{Name: "namespace", Value: namespace}})
+			}
 
 		case <-stopCh:
 			return
@@ -876,6 +1014,7 @@ type BrokerStats struct {
 	TotalWaiting int
 	DelayedEvals map[string]*structs.Evaluation
 	ByScheduler  map[string]*SchedulerStats
+	ByNamespace  map[string]*NamespaceStats
 }
 
 // SchedulerStats returns the stats per scheduler
@@ -884,6 +1023,12 @@ type SchedulerStats struct {
 	Unacked int
 }
 
+// NamespaceStats returns the stats per namespace. Only namespaces with ready
+// evaluations are tracked.
+type NamespaceStats struct {
+	Ready int
+}
+
 // Len is for the sorting interface
 func (p PendingEvaluations) Len() int {
 	return len(p)
@@ -926,3 +1071,44 @@ func (p PendingEvaluations) Peek() *structs.Evaluation {
 	}
 	return p[n-1]
 }
+
+// push is used to add a new evaluation to the queue of its namespace
+func (r readyEvals) push(eval *structs.Evaluation) {
+	pending := r[eval.Namespace]
+	heap.Push(&pending, eval)
+	r[eval.Namespace] = pending
+}
+
+// peek returns the next evaluation of the namespace, or the highest priority
+// next evaluation across the namespaces if the namespace is empty, along with
+// its namespace.
+func (r readyEvals) peek(namespace string) (*structs.Evaluation, string) {
+	if namespace != "" {
+		return r[namespace].Peek(), namespace
+	}
+
+	var next *structs.Evaluation
+	for ns, pending := range r {
+		eval := pending.Peek()
+		if eval == nil {
+			continue
+		}
+		if next == nil || PendingEvaluations([]*structs.Evaluation{next, eval}).Less(1, 0) {
+			next, namespace = eval, ns
+		}
+	}
+	return next, namespace
+}
+
+// pop removes and returns the evaluation peek would return.
+func (r readyEvals) pop(namespace string) *structs.Evaluation {
+	_, namespace = r.peek(namespace)
+	pending := r[namespace]
+	eval := heap.Pop(&pending).(*structs.Evaluation)
+	if len(pending) == 0 {
+		delete(r, namespace)
+	} else {
+		r[namespace] = pending
+	}
+	return eval
+}
@@ -1506,3 +1506,90 @@ func TestEvalBroker_NamespacedJobs(t *testing.T) {
 	require.Equal(1, len(b.blocked))
 
 }
+
+func TestEvalBroker_FairShare(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+	b.SetFairShare(true, map[string]int{"heavy": 2, "ignored": -1})
+
+	enabled, weights := b.FairShare()
+	require.True(t, enabled)
+	require.Equal(t, map[string]int{"heavy": 2}, weights)
+
+	// Enqueue a burst of high priority evals in the "busy" namespace before
+	// the evals of the other namespaces.
+	var index uint64
+	enqueue := func(namespace string, priority int) *structs.Evaluation {
+		index++
+		eval := mock.Eval()
+		eval.Namespace = namespace
+		eval.Priority = priority
+		eval.CreateIndex = index
+		b.Enqueue(eval)
+		return eval
+	}
+	for i := 0; i < 6; i++ {
+		enqueue("busy", 90)
+	}
+	enqueue("heavy", 50)
+	enqueue("heavy", 50)
+	heavyHigh := enqueue("heavy", 70)
+	enqueue("light", 10)
+
+	stats := b.Stats()
+	require.Equal(t, 10, stats.TotalReady)
+	require.Equal(t, 6, stats.ByNamespace["busy"].Ready)
+	require.Equal(t, 3, stats.ByNamespace["heavy"].Ready)
+	require.Equal(t, 1, stats.ByNamespace["light"].Ready)
+
+	var namespaces []string
+	var evals []*structs.Evaluation
+	for i := 0; i < 10; i++ {
+		out, _, err := b.Dequeue(defaultSched, time.Second)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		namespaces = append(namespaces, out.Namespace)
+		evals = append(evals, out)
+	}
+
+	// The namespaces are interleaved according to their weights, and the
+	// namespaces without ready evals no longer take turns.
+	require.Equal(t, []string{
+		"heavy", "busy", "light", "heavy", "heavy", "busy",
+		"busy", "busy", "busy", "busy",
+	}, namespaces)
+
+	// The priority is respected within a namespace.
+	require.Equal(t, heavyHigh.ID, evals[0].ID)
+
+	stats = b.Stats()
+	require.Equal(t, 0, stats.TotalReady)
+	require.Empty(t, stats.ByNamespace)
+}
+
+func TestEvalBroker_FairShare_Disabled(t *testing.T) {
+	ci.Parallel(t)
+	b := testBroker(t, 0)
+	b.SetEnabled(true)
+
+	// Without fair-share, the evals are dequeued strictly by priority across
+	// namespaces.
+	low := mock.Eval()
+	low.Namespace = "low"
+	low.Priority = 10
+	b.Enqueue(low)
+
+	high := mock.Eval()
+	high.Namespace = "high"
+	high.Priority = 90
+	b.Enqueue(high)
+
+	out, _, err := b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, high.ID, out.ID)
+
+	out, _, err = b.Dequeue(defaultSched, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, low.ID, out.ID)
+}
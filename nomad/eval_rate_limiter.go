@@ -0,0 +1,173 @@
+package nomad
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// evalRateLimiterPruneInterval is the interval at which the token buckets
+	// of idle namespaces and jobs are removed.
+	evalRateLimiterPruneInterval = time.Minute
+)
+
+// evalRateLimiter limits the rate at which the job endpoints create
+// evaluations, with a token bucket per namespace and per job. The limits of
+// the server configuration can be overridden by each namespace.
+type evalRateLimiter struct {
+	// config is the rate limits of the server configuration
+	config *structs.EvalRateLimit
+
+	l sync.Mutex
+
+	// buckets are the token buckets of the namespaces and jobs. The buckets
+	// of namespaces are keyed with an empty job ID.
+	buckets   map[structs.NamespacedID]*evalRateBucket
+	lastPrune time.Time
+}
+
+// evalRateBucket is the token bucket of a namespace or a job.
+type evalRateBucket struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// newEvalRateLimiter returns a rate limiter for the limits of the server
+// configuration, which may be nil if only namespaces set limits.
+func newEvalRateLimiter(config *structs.EvalRateLimit) *evalRateLimiter {
+	return &evalRateLimiter{
+		config:    config.Copy(),
+		buckets:   make(map[structs.NamespacedID]*evalRateBucket),
+		lastPrune: time.Now(),
+	}
+}
+
+// Check takes a token from the buckets of the namespace and the job of a call
+// to the endpoint, and returns a coded error with the delay after which the
+// call can be retried if either bucket is empty. Throttled calls are counted
+// in metrics labeled with the endpoint and namespace.
+func (e *evalRateLimiter) Check(store *state.StateStore, endpoint, namespace, jobID string) error {
+	limits, err := e.limits(store, namespace)
+	if err != nil {
+		return err
+	}
+	if limits == nil || (limits.NamespaceRate <= 0 && limits.JobRate <= 0) {
+		return nil
+	}
+
+	e.l.Lock()
+	defer e.l.Unlock()
+
+	now := time.Now()
+	e.pruneLocked(now)
+
+	var reservations []*rate.Reservation
+	cancel := func() {
+		for _, r := range reservations {
+			r.CancelAt(now)
+		}
+	}
+
+	if limits.NamespaceRate > 0 {
+		key := structs.NewNamespacedID("", namespace)
+		b := e.bucketLocked(key, limits.NamespaceRate, limits.NamespaceBurst, now)
+		r := b.limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if delay := r.DelayFrom(now); delay > 0 {
+			cancel()
+			e.emitThrottled(endpoint, namespace, "namespace")
+			return structs.NewErrEvalRateLimited(fmt.Sprintf("namespace %q", namespace), delay)
+		}
+	}
+
+	if limits.JobRate > 0 {
+		key := structs.NewNamespacedID(jobID, namespace)
+		b := e.bucketLocked(key, limits.JobRate, limits.JobBurst, now)
+		r := b.limiter.ReserveN(now, 1)
+		reservations = append(reservations, r)
+		if delay := r.DelayFrom(now); delay > 0 {
+			cancel()
+			e.emitThrottled(endpoint, namespace, "job")
+			return structs.NewErrEvalRateLimited(fmt.Sprintf("job %q in namespace %q", jobID, namespace), delay)
+		}
+	}
+
+	return nil
+}
+
+// limits returns the rate limits of the namespace, which are the limits of the
+// server configuration overridden by the limits of the namespace.
+func (e *evalRateLimiter) limits(store *state.StateStore, namespace string) (*structs.EvalRateLimit, error) {
+	ns, err := store.NamespaceByName(nil, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if ns == nil || ns.EvalRateLimit == nil {
+		return e.config, nil
+	}
+	return e.config.Merge(ns.EvalRateLimit), nil
+}
+
+// bucketLocked returns the bucket of the key, creating it or updating its
+// limits if they changed. Must be called with the lock held.
+func (e *evalRateLimiter) bucketLocked(key structs.NamespacedID, r float64, burst int, now time.Time) *evalRateBucket {
+	if burst == 0 {
+		burst = int(math.Ceil(r))
+	}
+
+	b, ok := e.buckets[key]
+	if !ok {
+		b = &evalRateBucket{limiter: rate.NewLimiter(rate.Limit(r), burst)}
+		e.buckets[key] = b
+	}
+
+	if b.limiter.Limit() != rate.Limit(r) {
+		b.limiter.SetLimitAt(now, rate.Limit(r))
+	}
+	if b.limiter.Burst() != burst {
+		b.limiter.SetBurstAt(now, burst)
+	}
+	b.lastUsed = now
+	return b
+}
+
+// pruneLocked removes the buckets which have been idle for long enough to be
+// full again, as a new bucket is equivalent. Must be called with the lock
+// held.
+func (e *evalRateLimiter) pruneLocked(now time.Time) {
+	if now.Sub(e.lastPrune) < evalRateLimiterPruneInterval {
+		return
+	}
+	e.lastPrune = now
+
+	idle := func(b *evalRateBucket) bool {
+		limit := b.limiter.Limit()
+		if limit <= 0 {
+			return true
+		}
+		refill := time.Duration(float64(b.limiter.Burst()) / float64(limit) * float64(time.Second))
+		return now.Sub(b.lastUsed) > refill
+	}
+	for k, b := range e.buckets {
+		if idle(b) {
+			delete(e.buckets, k)
+		}
+	}
+}
+
+// emitThrottled counts a call throttled by the bucket of the scope. The job
+// isn't a label, as the number of jobs is unbounded.
+func (e *evalRateLimiter) emitThrottled(endpoint, namespace, scope string) {
+	metrics.IncrCounterWithLabels([]string{"nomad", "job", "eval_rate_limited"}, 1, []metrics.Label{
+		{Name: "endpoint", Value: endpoint},
+		{Name: "namespace", Value: namespace},
+		{Name: "scope", Value: scope},
+	})
+}
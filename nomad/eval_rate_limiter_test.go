@@ -0,0 +1,100 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalRateLimiter_Check(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	limiter := newEvalRateLimiter(&structs.EvalRateLimit{
+		NamespaceRate:  0.001,
+		NamespaceBurst: 3,
+		JobRate:        0.001,
+		JobBurst:       2,
+	})
+
+	// The job bucket is exhausted first
+	require.NoError(t, limiter.Check(store, "register", "default", "job1"))
+	require.NoError(t, limiter.Check(store, "register", "default", "job1"))
+	err := limiter.Check(store, "register", "default", "job1")
+	require.True(t, structs.IsErrEvalRateLimited(err))
+	require.Contains(t, err.Error(), `job "job1"`)
+
+	code, _, ok := structs.CodeFromRPCCodedErr(err)
+	require.True(t, ok)
+	require.Equal(t, 429, code)
+	retry, ok := structs.EvalRateLimitedRetryAfter(err.Error())
+	require.True(t, ok)
+	require.Greater(t, retry, 0)
+
+	// The rejected call didn't take a token from the namespace bucket
+	require.NoError(t, limiter.Check(store, "register", "default", "job2"))
+	err = limiter.Check(store, "register", "default", "job3")
+	require.True(t, structs.IsErrEvalRateLimited(err))
+	require.Contains(t, err.Error(), `namespace "default"`)
+
+	// Other namespaces have their own buckets
+	require.NoError(t, limiter.Check(store, "register", "other", "job1"))
+}
+
+func TestEvalRateLimiter_NamespaceOverride(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	ns := &structs.Namespace{
+		Name: "limited",
+		EvalRateLimit: &structs.EvalRateLimit{
+			JobRate:  0.001,
+			JobBurst: 1,
+		},
+	}
+	ns.SetHash()
+	require.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// Without server limits only the namespace is limited
+	limiter := newEvalRateLimiter(nil)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Check(store, "evaluate", "default", "job1"))
+	}
+	require.NoError(t, limiter.Check(store, "evaluate", "limited", "job1"))
+	err := limiter.Check(store, "evaluate", "limited", "job1")
+	require.True(t, structs.IsErrEvalRateLimited(err))
+}
+
+func TestEvalRateLimiter_NamespaceDisable(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	ns := &structs.Namespace{
+		Name: "unlimited",
+		EvalRateLimit: &structs.EvalRateLimit{
+			NamespaceRate: structs.EvalRateLimitDisabled,
+			JobRate:       structs.EvalRateLimitDisabled,
+		},
+	}
+	ns.SetHash()
+	require.NoError(t, ns.Validate())
+	require.Error(t, (&structs.EvalRateLimit{JobRate: -2}).Validate())
+	require.NoError(t, store.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+
+	// The namespace disables the limits of the server configuration
+	limiter := newEvalRateLimiter(&structs.EvalRateLimit{
+		NamespaceRate:  0.001,
+		NamespaceBurst: 1,
+		JobRate:        0.001,
+		JobBurst:       1,
+	})
+	for i := 0; i < 5; i++ {
+		require.NoError(t, limiter.Check(store, "evaluate", "unlimited", "job1"))
+	}
+	require.NoError(t, limiter.Check(store, "evaluate", "default", "job1"))
+	err := limiter.Check(store, "evaluate", "default", "job1")
+	require.True(t, structs.IsErrEvalRateLimited(err))
+}
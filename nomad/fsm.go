@@ -15,6 +15,7 @@ import (
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 	"github.com/hashicorp/nomad/scheduler"
 	"github.com/hashicorp/raft"
 )
@@ -54,6 +55,13 @@ const (
 	ScalingEventsSnapshot                SnapshotType = 19
 	EventSinkSnapshot                    SnapshotType = 20
 	ServiceRegistrationSnapshot          SnapshotType = 21
+	NodePoolSnapshot                     SnapshotType = 22
+	RootKeySnapshot                      SnapshotType = 23
+	VariablesSnapshot                    SnapshotType = 24
+	RootKeyMetaSnapshot                  SnapshotType = 25
+	ACLRoleSnapshot                      SnapshotType = 26
+	ACLAuthMethodSnapshot                SnapshotType = 27
+	ACLBindingRuleSnapshot               SnapshotType = 28
 	// Namespace appliers were moved from enterprise and therefore start at 64
 	NamespaceSnapshot SnapshotType = 64
 )
@@ -79,6 +87,7 @@ type nomadFSM struct {
 	evalBroker         *EvalBroker
 	blockedEvals       *BlockedEvals
 	periodicDispatcher *PeriodicDispatch
+	encrypter          *Encrypter
 	logger             hclog.Logger
 	state              *state.StateStore
 	timetable          *TimeTable
@@ -105,6 +114,7 @@ type nomadFSM struct {
 type nomadSnapshot struct {
 	snap      *state.StateSnapshot
 	timetable *TimeTable
+	encrypter *Encrypter
 }
 
 // snapshotHeader is the first entry in our snapshot
@@ -136,6 +146,14 @@ type FSMConfig struct {
 
 	// EventBufferSize is the amount of messages to hold in memory
 	EventBufferSize int64
+
+	// Encrypter is the keyring that root keys are added to and removed from
+	// as they are applied.
+	Encrypter *Encrypter
+
+	// ScorePlugins are the scoring plugins the schedulers run by the FSM
+	// rank nodes with
+	ScorePlugins []*config.ScorePluginConfig
 }
 
 // NewFSM is used to construct a new FSM with a blank state.
@@ -156,6 +174,7 @@ func NewFSM(config *FSMConfig) (*nomadFSM, error) {
 		evalBroker:          config.EvalBroker,
 		periodicDispatcher:  config.Periodic,
 		blockedEvals:        config.Blocked,
+		encrypter:           config.Encrypter,
 		logger:              config.Logger.Named("fsm"),
 		config:              config,
 		state:               state,
@@ -312,6 +331,30 @@ func (n *nomadFSM) Apply(log *raft.Log) interface{} {
 		return n.applyDeleteServiceRegistrationByID(msgType, buf[1:], log.Index)
 	case structs.ServiceRegistrationDeleteByNodeIDRequestType:
 		return n.applyDeleteServiceRegistrationByNodeID(msgType, buf[1:], log.Index)
+	case structs.NodePoolUpsertRequestType:
+		return n.applyNodePoolUpsert(msgType, buf[1:], log.Index)
+	case structs.NodePoolDeleteRequestType:
+		return n.applyNodePoolDelete(msgType, buf[1:], log.Index)
+	case structs.RootKeyUpsertRequestType:
+		return n.applyRootKeyUpsert(msgType, buf[1:], log.Index)
+	case structs.RootKeyMetaUpsertRequestType:
+		return n.applyRootKeyMetaUpsert(msgType, buf[1:], log.Index)
+	case structs.RootKeyDeleteRequestType:
+		return n.applyRootKeyDelete(msgType, buf[1:], log.Index)
+	case structs.VarApplyStateRequestType:
+		return n.applyVariableOperation(msgType, buf[1:], log.Index)
+	case structs.ACLRolesUpsertRequestType:
+		return n.applyACLRolesUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLRolesDeleteRequestType:
+		return n.applyACLRolesDelete(msgType, buf[1:], log.Index)
+	case structs.ACLAuthMethodsUpsertRequestType:
+		return n.applyACLAuthMethodsUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLAuthMethodsDeleteRequestType:
+		return n.applyACLAuthMethodsDelete(msgType, buf[1:], log.Index)
+	case structs.ACLBindingRulesUpsertRequestType:
+		return n.applyACLBindingRulesUpsert(msgType, buf[1:], log.Index)
+	case structs.ACLBindingRulesDeleteRequestType:
+		return n.applyACLBindingRulesDelete(msgType, buf[1:], log.Index)
 	}
 
 	// Check enterprise only message types.
@@ -364,7 +407,8 @@ func (n *nomadFSM) applyUpsertNode(reqType structs.MessageType, buf []byte, inde
 	// Unblock evals for the nodes computed node class if it is in a ready
 	// state.
 	if req.Node.Status == structs.NodeStatusReady {
-		n.blockedEvals.Unblock(req.Node.ComputedClass, index)
+		n.blockedEvals.UnblockClassAndQuotaCapacity(req.Node.ComputedClass, "",
+			n.nodeCapacityFn(req.Node), index)
 	}
 
 	return nil
@@ -422,7 +466,8 @@ func (n *nomadFSM) applyStatusUpdate(msgType structs.MessageType, buf []byte, in
 			return err
 
 		}
-		n.blockedEvals.Unblock(node.ComputedClass, index)
+		n.blockedEvals.UnblockClassAndQuotaCapacity(node.ComputedClass, "",
+			n.nodeCapacityFn(node), index)
 		n.blockedEvals.UnblockNode(req.NodeID, index)
 	}
 
@@ -495,7 +540,8 @@ func (n *nomadFSM) applyNodeEligibilityUpdate(msgType structs.MessageType, buf [
 	// state.
 	if node != nil && node.SchedulingEligibility == structs.NodeSchedulingIneligible &&
 		req.Eligibility == structs.NodeSchedulingEligible {
-		n.blockedEvals.Unblock(node.ComputedClass, index)
+		n.blockedEvals.UnblockClassAndQuotaCapacity(node.ComputedClass, "",
+			n.nodeCapacityFn(node), index)
 		n.blockedEvals.UnblockNode(req.NodeID, index)
 	}
 
@@ -868,7 +914,11 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 	}
 
 	// Unblock evals for the nodes computed node class if the client has
-	// finished running an allocation.
+	// finished running an allocation. The capacity of each node is only
+	// offered once per quota, since it accounts for all the finished
+	// allocations of the update.
+	type nodeQuota struct{ nodeID, quota string }
+	unblocked := make(map[nodeQuota]struct{})
 	for _, alloc := range req.Alloc {
 		if alloc.ClientStatus == structs.AllocClientStatusComplete ||
 			alloc.ClientStatus == structs.AllocClientStatusFailed {
@@ -887,7 +937,14 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 				return err
 			}
 
-			n.blockedEvals.UnblockClassAndQuota(node.ComputedClass, quota, index)
+			key := nodeQuota{nodeID: node.ID, quota: quota}
+			if _, ok := unblocked[key]; ok {
+				continue
+			}
+			unblocked[key] = struct{}{}
+
+			n.blockedEvals.UnblockClassAndQuotaCapacity(node.ComputedClass, quota,
+				n.nodeCapacityFn(node), index)
 			n.blockedEvals.UnblockNode(node.ID, index)
 		}
 	}
@@ -895,6 +952,31 @@ func (n *nomadFSM) applyAllocClientUpdate(msgType structs.MessageType, buf []byt
 	return nil
 }
 
+// nodeCapacityFn returns a function which computes the resources of the node
+// that are not reserved or used by its non-terminal allocations, which bounds
+// the blocked evals that could be placed on it. The blocked evals tracker only
+// calls it on the leader, and only if evals could be unblocked, which keeps the
+// allocation lookup out of the apply path. The function returns nil if the
+// allocations could not be looked up.
+func (n *nomadFSM) nodeCapacityFn(node *structs.Node) func() *structs.ComparableResources {
+	return func() *structs.ComparableResources {
+		allocs, err := n.State().AllocsByNode(nil, node.ID)
+		if err != nil {
+			n.logger.Error("looking up node allocations failed", "node_id", node.ID, "error", err)
+			return nil
+		}
+
+		capacity := node.ComparableResources()
+		capacity.Subtract(node.ComparableReservedResources())
+		for _, alloc := range allocs {
+			if !alloc.TerminalStatus() {
+				capacity.Subtract(alloc.ComparableResources())
+			}
+		}
+		return capacity
+	}
+}
+
 // applyAllocUpdateDesiredTransition is used to update the desired transitions
 // of a set of allocations.
 func (n *nomadFSM) applyAllocUpdateDesiredTransition(msgType structs.MessageType, buf []byte, index uint64) interface{} {
@@ -1133,6 +1215,96 @@ func (n *nomadFSM) applyACLPolicyDelete(msgType structs.MessageType, buf []byte,
 	return nil
 }
 
+// applyACLRolesUpsert is used to upsert a set of ACL roles
+func (n *nomadFSM) applyACLRolesUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_upsert"}, time.Now())
+	var req structs.ACLRolesUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLRoles(msgType, index, req.ACLRoles, req.AllowMissingPolicies); err != nil {
+		n.logger.Error("UpsertACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLRolesDelete is used to delete a set of ACL roles
+func (n *nomadFSM) applyACLRolesDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_role_delete"}, time.Now())
+	var req structs.ACLRolesDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLRoles(msgType, index, req.ACLRoleIDs); err != nil {
+		n.logger.Error("DeleteACLRoles failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodsUpsert is used to upsert a set of ACL auth methods
+func (n *nomadFSM) applyACLAuthMethodsUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_upsert"}, time.Now())
+	var req structs.ACLAuthMethodsUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLAuthMethods(msgType, index, req.AuthMethods); err != nil {
+		n.logger.Error("UpsertACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLAuthMethodsDelete is used to delete a set of ACL auth methods
+func (n *nomadFSM) applyACLAuthMethodsDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_auth_method_delete"}, time.Now())
+	var req structs.ACLAuthMethodsDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLAuthMethods(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteACLAuthMethods failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRulesUpsert is used to upsert a set of ACL binding rules
+func (n *nomadFSM) applyACLBindingRulesUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_upsert"}, time.Now())
+	var req structs.ACLBindingRulesUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertACLBindingRules(msgType, index, req.ACLBindingRules, req.AllowMissingAuthMethods); err != nil {
+		n.logger.Error("UpsertACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
+// applyACLBindingRulesDelete is used to delete a set of ACL binding rules
+func (n *nomadFSM) applyACLBindingRulesDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_binding_rule_delete"}, time.Now())
+	var req structs.ACLBindingRulesDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteACLBindingRules(msgType, index, req.ACLBindingRuleIDs); err != nil {
+		n.logger.Error("DeleteACLBindingRules failed", "error", err)
+		return err
+	}
+	return nil
+}
+
 // applyACLTokenUpsert is used to upsert a set of policies
 func (n *nomadFSM) applyACLTokenUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_acl_token_upsert"}, time.Now())
@@ -1249,6 +1421,9 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 
 	req.Config.Canonicalize()
 
+	// Configure the eval broker once the configuration is applied
+	defer n.setEvalBrokerFairShare()
+
 	if req.CAS {
 		applied, err := n.state.SchedulerCASConfig(index, req.Config.ModifyIndex, &req.Config)
 		if err != nil {
@@ -1259,6 +1434,33 @@ func (n *nomadFSM) applySchedulerConfigUpdate(buf []byte, index uint64) interfac
 	return n.state.SchedulerSetConfig(index, &req.Config)
 }
 
+// setEvalBrokerFairShare configures the fair-share mode of the eval broker and
+// the weights of the namespaces from the state. It must be called whenever
+// the scheduler configuration or the namespaces change.
+func (n *nomadFSM) setEvalBrokerFairShare() {
+	_, config, err := n.state.SchedulerConfig()
+	if err != nil {
+		n.logger.Error("failed to get scheduler config for the eval broker", "error", err)
+		return
+	}
+
+	iter, err := n.state.Namespaces(nil)
+	if err != nil {
+		n.logger.Error("failed to get namespaces for the eval broker", "error", err)
+		return
+	}
+	weights := make(map[string]int)
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+		if ns.FairShareWeight > 0 {
+			weights[ns.Name] = ns.FairShareWeight
+		}
+	}
+
+	enabled := config != nil && config.EvalBrokerFairShareEnabled
+	n.evalBroker.SetFairShare(enabled, weights)
+}
+
 func (n *nomadFSM) applyCSIVolumeRegister(buf []byte, index uint64) interface{} {
 	var req structs.CSIVolumeRegisterRequest
 	if err := structs.Decode(buf, &req); err != nil {
@@ -1367,6 +1569,9 @@ func (n *nomadFSM) applyNamespaceUpsert(buf []byte, index uint64) interface{} {
 		return err
 	}
 
+	// Update the weights of the namespaces in the eval broker
+	n.setEvalBrokerFairShare()
+
 	// Send the unblocks
 	for _, quota := range trigger {
 		n.blockedEvals.UnblockQuota(quota, index)
@@ -1387,6 +1592,9 @@ func (n *nomadFSM) applyNamespaceDelete(buf []byte, index uint64) interface{} {
 		n.logger.Error("DeleteNamespaces failed", "error", err)
 	}
 
+	// Update the weights of the namespaces in the eval broker
+	n.setEvalBrokerFairShare()
+
 	return nil
 }
 
@@ -1400,6 +1608,7 @@ func (n *nomadFSM) Snapshot() (raft.FSMSnapshot, error) {
 	ns := &nomadSnapshot{
 		snap:      snap,
 		timetable: n.timetable,
+		encrypter: n.encrypter,
 	}
 	return ns, nil
 }
@@ -1685,6 +1894,82 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 				return err
 			}
 
+		case NodePoolSnapshot:
+			pool := new(structs.NodePool)
+			if err := dec.Decode(pool); err != nil {
+				return err
+			}
+
+			if err := restore.NodePoolRestore(pool); err != nil {
+				return err
+			}
+
+		case RootKeySnapshot:
+			// Root keys along with their key material were only written to
+			// snapshots by older servers. The key material is added to the
+			// local keystore so it isn't lost on upgrade.
+			rootKey := new(structs.RootKey)
+			if err := dec.Decode(rootKey); err != nil {
+				return err
+			}
+
+			if err := n.encrypter.AddKey(rootKey); err != nil {
+				return err
+			}
+			if err := restore.RootKeyMetaRestore(rootKey.Meta); err != nil {
+				return err
+			}
+
+		case RootKeyMetaSnapshot:
+			keyMeta := new(structs.RootKeyMeta)
+			if err := dec.Decode(keyMeta); err != nil {
+				return err
+			}
+
+			if err := restore.RootKeyMetaRestore(keyMeta); err != nil {
+				return err
+			}
+
+		case VariablesSnapshot:
+			variable := new(structs.VariableEncrypted)
+			if err := dec.Decode(variable); err != nil {
+				return err
+			}
+
+			if err := restore.VariablesRestore(variable); err != nil {
+				return err
+			}
+
+		case ACLRoleSnapshot:
+			aclRole := new(structs.ACLRole)
+			if err := dec.Decode(aclRole); err != nil {
+				return err
+			}
+
+			if err := restore.ACLRoleRestore(aclRole); err != nil {
+				return err
+			}
+
+		case ACLAuthMethodSnapshot:
+			aclAuthMethod := new(structs.ACLAuthMethod)
+			if err := dec.Decode(aclAuthMethod); err != nil {
+				return err
+			}
+
+			if err := restore.ACLAuthMethodRestore(aclAuthMethod); err != nil {
+				return err
+			}
+
+		case ACLBindingRuleSnapshot:
+			aclBindingRule := new(structs.ACLBindingRule)
+			if err := dec.Decode(aclBindingRule); err != nil {
+				return err
+			}
+
+			if err := restore.ACLBindingRuleRestore(aclBindingRule); err != nil {
+				return err
+			}
+
 		default:
 			// Check if this is an enterprise only object being restored
 			restorer, ok := n.enterpriseRestorers[snapType]
@@ -1721,6 +2006,9 @@ func (n *nomadFSM) Restore(old io.ReadCloser) error {
 	// blocking queries won't see any changes and need to be woken up.
 	stateOld.Abandon()
 
+	// Configure the eval broker from the restored state
+	n.setEvalBrokerFairShare()
+
 	return nil
 }
 
@@ -1823,7 +2111,7 @@ func (n *nomadFSM) reconcileQueuedAllocations(index uint64) error {
 		// Ignore eval event creation during snapshot restore
 		snap.UpsertEvals(structs.IgnoreUnknownTypeFlag, 100, []*structs.Evaluation{eval})
 		// Create the scheduler and run it
-		sched, err := scheduler.NewScheduler(eval.Type, n.logger, nil, snap, planner)
+		sched, err := scheduler.NewScheduler(eval.Type, n.logger, nil, snap, planner, n.config.ScorePlugins)
 		if err != nil {
 			return err
 		}
@@ -1944,6 +2232,128 @@ func (n *nomadFSM) applyDeleteServiceRegistrationByNodeID(msgType structs.Messag
 	return nil
 }
 
+// applyNodePoolUpsert is used to upsert a set of node pools.
+func (n *nomadFSM) applyNodePoolUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_upsert"}, time.Now())
+	var req structs.NodePoolUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertNodePools(msgType, index, req.NodePools); err != nil {
+		n.logger.Error("UpsertNodePools failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyNodePoolDelete is used to delete a set of node pools.
+func (n *nomadFSM) applyNodePoolDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_node_pool_delete"}, time.Now())
+	var req structs.NodePoolDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteNodePools(msgType, index, req.Names); err != nil {
+		n.logger.Error("DeleteNodePools failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRootKeyUpsert is used to apply the root key upserts written to Raft
+// by older servers, which included the key material. The key material is
+// added to the local keystore, and the metadata is written to state.
+func (n *nomadFSM) applyRootKeyUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_upsert"}, time.Now())
+	var req structs.RootKeyUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.encrypter.AddKey(req.RootKey); err != nil {
+		n.logger.Error("failed to add root key to keystore", "error", err)
+		return err
+	}
+
+	if err := n.state.UpsertRootKeyMeta(msgType, index, req.RootKey.Meta, false); err != nil {
+		n.logger.Error("UpsertRootKeyMeta failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRootKeyMetaUpsert is used to upsert the metadata of a root key. The
+// key material is not written to raft, and is replicated by each server into
+// its local keystore.
+func (n *nomadFSM) applyRootKeyMetaUpsert(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_meta_upsert"}, time.Now())
+	var req structs.RootKeyMetaUpsertRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.UpsertRootKeyMeta(msgType, index, req.RootKeyMeta, req.Rekey); err != nil {
+		n.logger.Error("UpsertRootKeyMeta failed", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyRootKeyDelete is used to delete a root key from state and the local
+// keystore.
+func (n *nomadFSM) applyRootKeyDelete(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	defer metrics.MeasureSince([]string{"nomad", "fsm", "apply_root_key_delete"}, time.Now())
+	var req structs.RootKeyDeleteRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+
+	if err := n.state.DeleteRootKeyMeta(msgType, index, req.KeyID); err != nil {
+		n.logger.Error("DeleteRootKeyMeta failed", "error", err)
+		return err
+	}
+
+	if err := n.encrypter.RemoveKey(req.KeyID); err != nil {
+		n.logger.Error("failed to remove root key from keystore", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// applyVariableOperation is used to apply a set or delete operation to a
+// variable. The returned value is always a VarApplyStateResponse, so the
+// caller can distinguish between errors and check-and-set conflicts.
+func (n *nomadFSM) applyVariableOperation(msgType structs.MessageType, buf []byte, index uint64) interface{} {
+	var req structs.VarApplyStateRequest
+	if err := structs.Decode(buf, &req); err != nil {
+		panic(fmt.Errorf("failed to decode request: %v", err))
+	}
+	defer metrics.MeasureSinceWithLabels([]string{"nomad", "fsm", "apply_variable_operation"}, time.Now(),
+		[]metrics.Label{{Name: "op", Value: string(req.Op)}})
+
+	switch req.Op {
+	case structs.VarOpSet:
+		return n.state.VarSet(index, &req)
+	case structs.VarOpDelete:
+		return n.state.VarDelete(index, &req)
+	case structs.VarOpDeleteCAS:
+		return n.state.VarDeleteCAS(index, &req)
+	case structs.VarOpCAS:
+		return n.state.VarSetCAS(index, &req)
+	default:
+		err := fmt.Errorf("Invalid variable operation '%s'", req.Op)
+		n.logger.Warn("Invalid variable operation", "operation", req.Op)
+		return req.ErrorResponse(index, err)
+	}
+}
+
 func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 	defer metrics.MeasureSince([]string{"nomad", "fsm", "persist"}, time.Now())
 	// Register the nodes
@@ -2052,6 +2462,30 @@ func (s *nomadSnapshot) Persist(sink raft.SnapshotSink) error {
 		sink.Cancel()
 		return err
 	}
+	if err := s.persistNodePools(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistRootKeyMetas(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistVariables(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLRoles(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLAuthMethods(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
+	if err := s.persistACLBindingRules(sink, encoder); err != nil {
+		sink.Cancel()
+		return err
+	}
 	return nil
 }
 
@@ -2610,6 +3044,139 @@ func (s *nomadSnapshot) persistServiceRegistrations(sink raft.SnapshotSink,
 	}
 }
 
+func (s *nomadSnapshot) persistNodePools(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the node pools.
+	ws := memdb.NewWatchSet()
+	pools, err := s.snap.NodePools(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := pools.Next(); raw != nil; raw = pools.Next() {
+		pool := raw.(*structs.NodePool)
+
+		// Write out a node pool snapshot.
+		sink.Write([]byte{byte(NodePoolSnapshot)})
+		if err := encoder.Encode(pool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistRootKeyMetas(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the root key metadata. The key material is never written to
+	// snapshots, and is replicated by the servers restoring them.
+	ws := memdb.NewWatchSet()
+	keys, err := s.snap.RootKeyMetas(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := keys.Next(); raw != nil; raw = keys.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+
+		// Write out a root key metadata snapshot.
+		sink.Write([]byte{byte(RootKeyMetaSnapshot)})
+		if err := encoder.Encode(keyMeta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistVariables(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the variables.
+	ws := memdb.NewWatchSet()
+	variables, err := s.snap.GetVariables(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := variables.Next(); raw != nil; raw = variables.Next() {
+		variable := raw.(*structs.VariableEncrypted)
+
+		// Write out a variable snapshot.
+		sink.Write([]byte{byte(VariablesSnapshot)})
+		if err := encoder.Encode(variable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLRoles(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL roles.
+	ws := memdb.NewWatchSet()
+	aclRolesIter, err := s.snap.GetACLRoles(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := aclRolesIter.Next(); raw != nil; raw = aclRolesIter.Next() {
+		aclRole := raw.(*structs.ACLRole)
+
+		// Write out an ACL role snapshot.
+		sink.Write([]byte{byte(ACLRoleSnapshot)})
+		if err := encoder.Encode(aclRole); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLAuthMethods(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL auth methods.
+	ws := memdb.NewWatchSet()
+	aclAuthMethodsIter, err := s.snap.GetACLAuthMethods(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := aclAuthMethodsIter.Next(); raw != nil; raw = aclAuthMethodsIter.Next() {
+		aclAuthMethod := raw.(*structs.ACLAuthMethod)
+
+		// Write out an ACL auth method snapshot.
+		sink.Write([]byte{byte(ACLAuthMethodSnapshot)})
+		if err := encoder.Encode(aclAuthMethod); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *nomadSnapshot) persistACLBindingRules(sink raft.SnapshotSink,
+	encoder *codec.Encoder) error {
+
+	// Get all the ACL binding rules.
+	ws := memdb.NewWatchSet()
+	aclBindingRulesIter, err := s.snap.GetACLBindingRules(ws)
+	if err != nil {
+		return err
+	}
+
+	for raw := aclBindingRulesIter.Next(); raw != nil; raw = aclBindingRulesIter.Next() {
+		aclBindingRule := raw.(*structs.ACLBindingRule)
+
+		// Write out an ACL binding rule snapshot.
+		sink.Write([]byte{byte(ACLBindingRuleSnapshot)})
+		if err := encoder.Encode(aclBindingRule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Release is a no-op, as we just need to GC the pointer
 // to the state store snapshot. There is nothing to explicitly
 // cleanup.
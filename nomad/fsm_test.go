@@ -52,6 +52,10 @@ func testFSM(t *testing.T) *nomadFSM {
 	broker := testBroker(t, 0)
 	dispatcher, _ := testPeriodicDispatcher(t)
 	logger := testlog.HCLogger(t)
+	encrypter, err := NewEncrypter(nil, t.TempDir())
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
 	fsmConfig := &FSMConfig{
 		EvalBroker:        broker,
 		Periodic:          dispatcher,
@@ -60,6 +64,7 @@ func testFSM(t *testing.T) *nomadFSM {
 		Region:            "global",
 		EnableEventBroker: true,
 		EventBufferSize:   100,
+		Encrypter:         encrypter,
 	}
 	fsm, err := NewFSM(fsmConfig)
 	if err != nil {
@@ -2343,6 +2348,139 @@ func TestFSM_DeleteACLPolicies(t *testing.T) {
 	assert.Nil(t, out)
 }
 
+func TestFSM_UpsertACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	role := mock.ACLRole()
+	req := structs.ACLRolesUpsertRequest{
+		ACLRoles:             []*structs.ACLRole{role},
+		AllowMissingPolicies: true,
+	}
+	buf, err := structs.Encode(structs.ACLRolesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().GetACLRoleByID(memdb.NewWatchSet(), role.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Linking to a missing policy without allowing it should error.
+	req.ACLRoles = []*structs.ACLRole{mock.ACLRole()}
+	req.AllowMissingPolicies = false
+	buf, err = structs.Encode(structs.ACLRolesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Error(t, fsm.Apply(makeLog(buf)).(error))
+}
+
+func TestFSM_DeleteACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	role := mock.ACLRole()
+	require.NoError(t, fsm.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLRole{role}, true))
+
+	req := structs.ACLRolesDeleteRequest{
+		ACLRoleIDs: []string{role.ID},
+	}
+	buf, err := structs.Encode(structs.ACLRolesDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().GetACLRoleByID(memdb.NewWatchSet(), role.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_UpsertACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	authMethod := mock.ACLAuthMethod()
+	req := structs.ACLAuthMethodsUpsertRequest{
+		AuthMethods: []*structs.ACLAuthMethod{authMethod},
+	}
+	buf, err := structs.Encode(structs.ACLAuthMethodsUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().GetACLAuthMethodByName(memdb.NewWatchSet(), authMethod.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_DeleteACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	authMethod := mock.ACLAuthMethod()
+	require.NoError(t, fsm.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLAuthMethod{authMethod}))
+
+	req := structs.ACLAuthMethodsDeleteRequest{
+		Names: []string{authMethod.Name},
+	}
+	buf, err := structs.Encode(structs.ACLAuthMethodsDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().GetACLAuthMethodByName(memdb.NewWatchSet(), authMethod.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_UpsertACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	rule := mock.ACLBindingRule()
+	req := structs.ACLBindingRulesUpsertRequest{
+		ACLBindingRules:         []*structs.ACLBindingRule{rule},
+		AllowMissingAuthMethods: true,
+	}
+	buf, err := structs.Encode(structs.ACLBindingRulesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are registered
+	out, err := fsm.State().GetACLBindingRule(memdb.NewWatchSet(), rule.ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Linking to a missing auth method without allowing it should error.
+	req.ACLBindingRules = []*structs.ACLBindingRule{mock.ACLBindingRule()}
+	req.AllowMissingAuthMethods = false
+	buf, err = structs.Encode(structs.ACLBindingRulesUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Error(t, fsm.Apply(makeLog(buf)).(error))
+}
+
+func TestFSM_DeleteACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	rule := mock.ACLBindingRule()
+	require.NoError(t, fsm.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLBindingRule{rule}, true))
+
+	req := structs.ACLBindingRulesDeleteRequest{
+		ACLBindingRuleIDs: []string{rule.ID},
+	}
+	buf, err := structs.Encode(structs.ACLBindingRulesDeleteRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	// Verify we are NOT registered
+	out, err := fsm.State().GetACLBindingRule(memdb.NewWatchSet(), rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
 func TestFSM_BootstrapACLTokens(t *testing.T) {
 	ci.Parallel(t)
 	fsm := testFSM(t)
@@ -2804,6 +2942,52 @@ func TestFSM_SnapshotRestore_ACLPolicy(t *testing.T) {
 	assert.Equal(t, p2, out2)
 }
 
+func TestFSM_SnapshotRestore_ACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	require.NoError(t, state.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLRole{r1, r2}, true))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	ws := memdb.NewWatchSet()
+	out1, _ := state2.GetACLRoleByID(ws, r1.ID)
+	out2, _ := state2.GetACLRoleByID(ws, r2.ID)
+	require.Equal(t, r1, out1)
+	require.Equal(t, r2, out2)
+}
+
+func TestFSM_SnapshotRestore_ACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	// Add some state
+	fsm := testFSM(t)
+	state := fsm.State()
+	am1 := mock.ACLAuthMethod()
+	am2 := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 1000, []*structs.ACLAuthMethod{am1, am2}))
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = am1.Name
+	require.NoError(t, state.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 1001, []*structs.ACLBindingRule{rule}, false))
+
+	// Verify the contents
+	fsm2 := testSnapshotRestore(t, fsm)
+	state2 := fsm2.State()
+	ws := memdb.NewWatchSet()
+	out1, _ := state2.GetACLAuthMethodByName(ws, am1.Name)
+	out2, _ := state2.GetACLAuthMethodByName(ws, am2.Name)
+	out3, _ := state2.GetACLBindingRule(ws, rule.ID)
+	require.Equal(t, am1, out1)
+	require.Equal(t, am2, out2)
+	require.Equal(t, rule, out3)
+}
+
 func TestFSM_SnapshotRestore_ACLTokens(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -2893,6 +3077,157 @@ func TestFSM_SnapshotRestore_ServiceRegistrations(t *testing.T) {
 	require.ElementsMatch(t, restoredRegs, serviceRegs)
 }
 
+func TestFSM_SnapshotRestore_NodePools(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some node pools.
+	pool1, pool2 := mock.NodePool(), mock.NodePool()
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool1, pool2}))
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	// Ensure the custom and built-in node pools were restored.
+	for _, pool := range []*structs.NodePool{pool1, pool2} {
+		out, err := restoredState.NodePoolByName(nil, pool.Name)
+		require.NoError(t, err)
+		require.Equal(t, pool, out)
+	}
+	out, err := restoredState.NodePoolByName(nil, structs.NodePoolDefault)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+}
+
+func TestFSM_SnapshotRestore_Variables(t *testing.T) {
+	ci.Parallel(t)
+
+	// Create our initial FSM which will be snapshotted.
+	fsm := testFSM(t)
+	testState := fsm.State()
+
+	// Generate and upsert some variables and a root key.
+	key := mock.RootKey()
+	require.NoError(t, fsm.encrypter.AddKey(key))
+	require.NoError(t, testState.UpsertRootKeyMeta(structs.MsgTypeTestSetup, 10, key.Meta, false))
+
+	svs := []*structs.VariableEncrypted{mock.VariableEncrypted(), mock.VariableEncrypted()}
+	for i, sv := range svs {
+		resp := testState.VarSet(uint64(11+i), &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+		require.True(t, resp.IsOk())
+	}
+
+	// Perform a snapshot restore.
+	restoredFSM := testSnapshotRestore(t, fsm)
+	restoredState := restoredFSM.State()
+
+	out, err := restoredState.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key.Meta.KeyID, out.KeyID)
+
+	// The key material is not written to the snapshot.
+	require.False(t, restoredFSM.encrypter.HasKey(key.Meta.KeyID))
+
+	for _, sv := range svs {
+		out, err := restoredState.GetVariable(nil, sv.Namespace, sv.Path)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		require.Equal(t, sv.Data, out.Data)
+	}
+}
+
+func TestFSM_ApplyVariableOperation(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	sv := mock.VariableEncrypted()
+	req := structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv}
+	buf, err := structs.Encode(structs.VarApplyStateRequestType, req)
+	require.NoError(t, err)
+
+	resp, ok := fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.True(t, resp.IsOk())
+
+	out, err := fsm.State().GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// A check-and-set delete with a stale index returns a conflict.
+	req = structs.VarApplyStateRequest{Op: structs.VarOpDeleteCAS, Var: out.Copy()}
+	req.Var.ModifyIndex--
+	buf, err = structs.Encode(structs.VarApplyStateRequestType, req)
+	require.NoError(t, err)
+
+	resp, ok = fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.True(t, resp.IsConflict())
+
+	// An unconditional delete removes the variable.
+	req = structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv}
+	buf, err = structs.Encode(structs.VarApplyStateRequestType, req)
+	require.NoError(t, err)
+
+	resp, ok = fsm.Apply(makeLog(buf)).(*structs.VarApplyStateResponse)
+	require.True(t, ok)
+	require.True(t, resp.IsOk())
+
+	out, err = fsm.State().GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestFSM_ApplyLegacyRootKeyUpsert(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Older servers wrote the key material to Raft along with the metadata.
+	key := mock.RootKey()
+	req := structs.RootKeyUpsertRequest{RootKey: key}
+	buf, err := structs.Encode(structs.RootKeyUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+	require.Equal(t, key.Meta.KeyID, out.KeyID)
+	require.True(t, fsm.encrypter.HasKey(key.Meta.KeyID))
+}
+
+func TestFSM_UpsertNodePools(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	pool := mock.NodePool()
+	req := structs.NodePoolUpsertRequest{
+		NodePools: []*structs.NodePool{pool},
+	}
+	buf, err := structs.Encode(structs.NodePoolUpsertRequestType, req)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err := fsm.State().NodePoolByName(nil, pool.Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Delete the pool.
+	delReq := structs.NodePoolDeleteRequest{
+		Names: []string{pool.Name},
+	}
+	buf, err = structs.Encode(structs.NodePoolDeleteRequestType, delReq)
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	out, err = fsm.State().NodePoolByName(nil, pool.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
 func TestFSM_ReconcileSummaries(t *testing.T) {
 	ci.Parallel(t)
 	// Add some state
@@ -3173,6 +3508,46 @@ func TestFSM_SchedulerConfig(t *testing.T) {
 	require.True(config.PreemptionConfig.BatchSchedulerEnabled)
 }
 
+func TestFSM_SchedulerConfig_EvalBrokerFairShare(t *testing.T) {
+	ci.Parallel(t)
+	fsm := testFSM(t)
+
+	// Upsert a weighted namespace.
+	ns := mock.Namespace()
+	ns.FairShareWeight = 3
+	buf, err := structs.Encode(structs.NamespaceUpsertRequestType, structs.NamespaceUpsertRequest{
+		Namespaces: []*structs.Namespace{ns},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	enabled, weights := fsm.evalBroker.FairShare()
+	require.False(t, enabled)
+	require.Equal(t, map[string]int{ns.Name: 3}, weights)
+
+	// Enable fair-share dequeuing in the scheduler config.
+	buf, err = structs.Encode(structs.SchedulerConfigRequestType, structs.SchedulerSetConfigRequest{
+		Config: structs.SchedulerConfiguration{EvalBrokerFairShareEnabled: true},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	enabled, weights = fsm.evalBroker.FairShare()
+	require.True(t, enabled)
+	require.Equal(t, map[string]int{ns.Name: 3}, weights)
+
+	// Deleting the namespace removes its weight.
+	buf, err = structs.Encode(structs.NamespaceDeleteRequestType, structs.NamespaceDeleteRequest{
+		Namespaces: []string{ns.Name},
+	})
+	require.NoError(t, err)
+	require.Nil(t, fsm.Apply(makeLog(buf)))
+
+	enabled, weights = fsm.evalBroker.FairShare()
+	require.True(t, enabled)
+	require.Empty(t, weights)
+}
+
 func TestFSM_ClusterMetadata(t *testing.T) {
 	ci.Parallel(t)
 	r := require.New(t)
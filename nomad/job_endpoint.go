@@ -58,19 +58,15 @@ type Job struct {
 // NewJobEndpoints creates a new job endpoint with builtin admission controllers
 func NewJobEndpoints(s *Server) *Job {
 	return &Job{
-		srv:    s,
-		logger: s.logger.Named("job"),
-		mutators: []jobMutator{
-			jobCanonicalizer{},
-			jobConnectHook{},
-			jobExposeCheckHook{},
-			jobImpliedConstraints{},
-		},
+		srv:      s,
+		logger:   s.logger.Named("job"),
+		mutators: builtinJobMutators(),
 		validators: []jobValidator{
 			jobConnectHook{},
 			jobExposeCheckHook{},
 			jobVaultHook{srv: s},
 			jobNamespaceConstraintCheckHook{srv: s},
+			jobNodePoolValidator{srv: s},
 			jobValidate{},
 			&memoryOversubscriptionValidate{srv: s},
 		},
@@ -182,6 +178,10 @@ func (j *Job) Register(args *structs.JobRegisterRequest, reply *structs.JobRegis
 		return structs.ErrJobRegistrationDisabled
 	}
 
+	if err := j.srv.evalRateLimiter.Check(j.srv.State(), "register", args.RequestNamespace(), args.Job.ID); err != nil {
+		return err
+	}
+
 	// Lookup the job
 	snap, err := j.srv.State().Snapshot()
 	if err != nil {
@@ -688,6 +688,10 @@ func (j *Job) Evaluate(args *structs.JobEvaluateRequest, reply *structs.JobRegis
 		return fmt.Errorf("missing job ID for evaluation")
 	}
 
+	if err := j.srv.evalRateLimiter.Check(j.srv.State(), "evaluate", args.RequestNamespace(), args.JobID); err != nil {
+		return err
+	}
+
 	// Lookup the job
 	snap, err := j.srv.fsm.State().Snapshot()
 	if err != nil {
@@ -991,6 +995,14 @@ func (j *Job) Scale(args *structs.JobScaleRequest, reply *structs.JobRegisterRes
 		return structs.ErrJobRegistrationDisabled
 	}
 
+	// Scaling requests without a count only register a scaling event, and
+	// don't create an evaluation
+	if args.Count != nil {
+		if err := j.srv.evalRateLimiter.Check(j.srv.State(), "scale", namespace, args.JobID); err != nil {
+			return err
+		}
+	}
+
 	// Validate args
 	err = args.Validate()
 	if err != nil {
@@ -1715,7 +1727,7 @@ func (j *Job) Plan(args *structs.JobPlanRequest, reply *structs.JobPlanResponse)
 	}
 
 	// Create the scheduler and run it
-	sched, err := scheduler.NewScheduler(eval.Type, j.logger, j.srv.workersEventCh, snap, planner)
+	sched, err := scheduler.NewScheduler(eval.Type, j.logger, j.srv.workersEventCh, snap, planner, j.srv.config.ScorePlugins)
 	if err != nil {
 		return err
 	}
@@ -1826,6 +1838,12 @@ func (j *Job) Dispatch(args *structs.JobDispatchRequest, reply *structs.JobDispa
 		return fmt.Errorf("missing parameterized job ID")
 	}
 
+	// Dispatched jobs are limited as their parameterized job, as each
+	// dispatch creates a new child job
+	if err := j.srv.evalRateLimiter.Check(j.srv.State(), "dispatch", args.RequestNamespace(), args.JobID); err != nil {
+		return err
+	}
+
 	snap, err := j.srv.fsm.State().Snapshot()
 	if err != nil {
 		return err
@@ -2147,6 +2165,46 @@ func (j *Job) ScaleStatus(args *structs.JobScaleStatusRequest,
 	return j.srv.blockingRPC(&opts)
 }
 
+// QueueStatus retrieves the position of a job in the job queue, formed by the
+// blocked evaluations of the leader.
+func (j *Job) QueueStatus(args *structs.JobQueueStatusRequest,
+	reply *structs.JobQueueStatusResponse) error {
+
+	// Only the leader tracks the blocked evals, so stale reads are not allowed.
+	args.AllowStale = false
+	if done, err := j.srv.forward("Job.QueueStatus", args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "job", "queue_status"}, time.Now())
+
+	// Check for read-job permissions
+	if aclObj, err := j.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.AllowNsOp(args.RequestNamespace(), acl.NamespaceCapabilityReadJob) {
+		return structs.ErrPermissionDenied
+	}
+
+	snap, err := j.srv.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	job, err := snap.JobByID(nil, args.RequestNamespace(), args.JobID)
+	if err != nil {
+		return err
+	}
+	if job != nil {
+		reply.JobQueueStatus = j.srv.blockedEvals.QueueStatus(job.Namespace, job.ID)
+	}
+
+	index, err := snap.Index("evals")
+	if err != nil {
+		return err
+	}
+	reply.Index = index
+	j.srv.setQueryMeta(&reply.QueryMeta)
+	return nil
+}
+
 // GetServiceRegistrations returns a list of service registrations which belong
 // to the passed job ID.
 func (j *Job) GetServiceRegistrations(
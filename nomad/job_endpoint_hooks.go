@@ -3,6 +3,7 @@ package nomad
 import (
 	"fmt"
 
+	"github.com/hashicorp/go-hclog"
 	multierror "github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/nomad/helper"
 	"github.com/hashicorp/nomad/nomad/structs"
@@ -60,6 +61,26 @@ type jobValidator interface {
 	Validate(*structs.Job) (warnings []error, err error)
 }
 
+// builtinJobMutators returns the builtin job admission mutators. They don't
+// depend on the server, so they may also run outside of one.
+func builtinJobMutators() []jobMutator {
+	return []jobMutator{
+		jobCanonicalizer{},
+		jobConnectHook{},
+		jobExposeCheckHook{},
+		jobImpliedConstraints{},
+		jobNodePoolMutator{},
+	}
+}
+
+// MutateJob runs the builtin job admission mutators on the job, as the
+// Job.Register endpoint does before validating it. It allows tools working
+// outside of a server, such as the scheduler simulation, to see the job as
+// it would be registered.
+func MutateJob(job *structs.Job) (*structs.Job, []error, error) {
+	return mutateJob(builtinJobMutators(), hclog.NewNullLogger(), job)
+}
+
 func (j *Job) admissionControllers(job *structs.Job) (out *structs.Job, warnings []error, err error) {
 	// Mutators run first before validators, so validators view the final rendered job.
 	// So, mutators must handle invalid jobs.
@@ -79,10 +100,15 @@ func (j *Job) admissionControllers(job *structs.Job) (out *structs.Job, warnings
 
 // admissionMutator returns an updated job as well as warnings or an error.
 func (j *Job) admissionMutators(job *structs.Job) (_ *structs.Job, warnings []error, err error) {
+	return mutateJob(j.mutators, j.logger, job)
+}
+
+// mutateJob runs the mutators on the job in order.
+func mutateJob(mutators []jobMutator, logger hclog.Logger, job *structs.Job) (_ *structs.Job, warnings []error, err error) {
 	var w []error
-	for _, mutator := range j.mutators {
+	for _, mutator := range mutators {
 		job, w, err = mutator.Mutate(job)
-		j.logger.Trace("job mutate results", "mutator", mutator.Name(), "warnings", w, "error", err)
+		logger.Trace("job mutate results", "mutator", mutator.Name(), "warnings", w, "error", err)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error in job mutator %s: %v", mutator.Name(), err)
 		}
@@ -288,6 +314,13 @@ func (v *memoryOversubscriptionValidate) Validate(job *structs.Job) (warnings []
 		return nil, err
 	}
 
+	// The job's node pool may override the global setting.
+	pool, err := v.srv.State().NodePoolByName(nil, job.NodePool)
+	if err != nil {
+		return nil, err
+	}
+	c = c.WithNodePool(pool)
+
 	if c != nil && c.MemoryOversubscriptionEnabled {
 		return nil, nil
 	}
@@ -302,3 +335,43 @@ func (v *memoryOversubscriptionValidate) Validate(job *structs.Job) (warnings []
 
 	return warnings, err
 }
+
+// jobNodePoolMutator sets the node pool of jobs that don't specify one to the
+// default node pool.
+type jobNodePoolMutator struct{}
+
+func (jobNodePoolMutator) Name() string {
+	return "node-pool"
+}
+
+func (jobNodePoolMutator) Mutate(job *structs.Job) (*structs.Job, []error, error) {
+	if job.NodePool == "" {
+		job.NodePool = structs.NodePoolDefault
+	}
+	return job, nil, nil
+}
+
+// jobNodePoolValidator ensures the node pool targeted by a job exists.
+type jobNodePoolValidator struct {
+	srv *Server
+}
+
+func (jobNodePoolValidator) Name() string {
+	return "node-pool"
+}
+
+func (v jobNodePoolValidator) Validate(job *structs.Job) ([]error, error) {
+	poolName := job.NodePool
+	if poolName == "" {
+		poolName = structs.NodePoolDefault
+	}
+
+	pool, err := v.srv.State().NodePoolByName(nil, poolName)
+	if err != nil {
+		return nil, err
+	}
+	if pool == nil {
+		return nil, fmt.Errorf("job %q is in nonexistent node pool %q", job.ID, poolName)
+	}
+	return nil, nil
+}
@@ -201,6 +201,43 @@ func TestJobEndpoint_Register_EvalPriority(t *testing.T) {
 	requireAssert.Equal(99, out[0].Priority)
 }
 
+func TestJobEndpoint_Register_EvalRateLimit(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0
+		c.EvalRateLimit = &structs.EvalRateLimit{JobRate: 0.001, JobBurst: 1}
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+
+	// The first registration takes the only token of the job
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	// The second registration is throttled
+	err := msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.Error(t, err)
+	require.True(t, structs.IsErrEvalRateLimited(err))
+	code, _, ok := structs.CodeFromRPCCodedErr(err)
+	require.True(t, ok)
+	require.Equal(t, 429, code)
+
+	// Other jobs aren't throttled
+	req.Job = mock.Job()
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+}
+
 func TestJobEndpoint_Register_Connect(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
@@ -7817,6 +7854,72 @@ func TestJobEndpoint_GetScaleStatus(t *testing.T) {
 	require.True(reflect.DeepEqual(*resp2.JobScaleStatus, expectedStatus))
 }
 
+func TestJobEndpoint_QueueStatus(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	job := mock.Job()
+	job.SubmitTime = time.Now().UnixNano()
+	get := &structs.JobQueueStatusRequest{
+		JobID: job.ID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: job.Namespace,
+			AuthToken: root.SecretID,
+		},
+	}
+
+	// Unknown jobs have no queue status
+	var resp structs.JobQueueStatusResponse
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.QueueStatus", get, &resp))
+	require.Nil(resp.JobQueueStatus)
+
+	// A job without a blocked eval is not queued
+	require.NoError(state.UpsertJob(structs.MsgTypeTestSetup, 1000, job))
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.QueueStatus", get, &resp))
+	require.NotNil(resp.JobQueueStatus)
+	require.False(resp.JobQueueStatus.Queued)
+
+	// Block an eval for the job behind a higher priority job
+	other := mock.BlockedEval()
+	other.Priority = 90
+	s1.blockedEvals.Block(other)
+
+	eval := mock.BlockedEval()
+	eval.JobID = job.ID
+	eval.JobSubmitTime = job.SubmitTime
+	s1.blockedEvals.Block(eval)
+
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.QueueStatus", get, &resp))
+	status := resp.JobQueueStatus
+	require.True(status.Queued)
+	require.Equal(2, status.Position)
+	require.Equal(2, status.QueueLength)
+	require.Equal(eval.ID, status.EvalID)
+	require.Equal("resources exhausted: memory", status.Reason)
+	require.Equal(job.SubmitTime, status.QueuedTime)
+
+	// Reading the queue status requires read-job
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityListJobs}))
+	validToken := mock.CreatePolicyAndToken(t, state, 1005, "test-valid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	get.AuthToken = invalidToken.SecretID
+	err := msgpackrpc.CallWithCodec(codec, "Job.QueueStatus", get, &resp)
+	require.EqualError(err, structs.ErrPermissionDenied.Error())
+
+	get.AuthToken = validToken.SecretID
+	require.NoError(msgpackrpc.CallWithCodec(codec, "Job.QueueStatus", get, &resp))
+	require.True(resp.JobQueueStatus.Queued)
+}
+
 func TestJobEndpoint_GetScaleStatus_ACL(t *testing.T) {
 	ci.Parallel(t)
 	require := require.New(t)
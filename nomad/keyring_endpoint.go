@@ -0,0 +1,290 @@
+package nomad
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	metrics "github.com/armon/go-metrics"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Keyring endpoint is used for interacting with the root keys held by the
+// servers.
+type Keyring struct {
+	srv       *Server
+	ctx       *RPCContext
+	encrypter *Encrypter
+}
+
+// Rotate generates a new root key and makes it the active key, which is used
+// to encrypt all new data. If a full rotation is requested, the existing data
+// is re-encrypted with the new key by the variables rekey core job.
+func (k *Keyring) Rotate(args *structs.KeyringRotateRootKeyRequest, reply *structs.KeyringRotateRootKeyResponse) error {
+	if done, err := k.srv.forward(structs.KeyringRotateRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "rotate"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Algorithm == "" {
+		args.Algorithm = structs.EncryptionAlgorithmAES256GCM
+	}
+
+	rootKey, err := structs.NewRootKey(args.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	// The key material is added to the local keyring before the metadata is
+	// written to raft, so the other servers can replicate it from the leader.
+	if err := k.encrypter.AddKey(rootKey); err != nil {
+		return err
+	}
+
+	req := structs.RootKeyMetaUpsertRequest{
+		RootKeyMeta:  rootKey.Meta,
+		Rekey:        args.Full,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := k.srv.raftApply(structs.RootKeyMetaUpsertRequestType, req)
+	if err != nil {
+		return err
+	} else if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	// The rekey job is run by the leader, which is where the request has
+	// been forwarded to, so the evaluation is enqueued without being written
+	// to raft like the other core jobs.
+	if args.Full {
+		k.srv.evalBroker.Enqueue(k.srv.coreJobEval(structs.CoreJobVariablesRekey, index))
+	}
+
+	reply.Key = rootKey.Meta
+	reply.Index = index
+	return nil
+}
+
+// List returns the metadata of all the root keys.
+func (k *Keyring) List(args *structs.KeyringListRootKeyMetaRequest, reply *structs.KeyringListRootKeyMetaResponse) error {
+	if done, err := k.srv.forward(structs.KeyringListRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "list"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	return k.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			iter, err := stateStore.RootKeyMetas(ws)
+			if err != nil {
+				return err
+			}
+
+			keys := []*structs.RootKeyMeta{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				keys = append(keys, raw.(*structs.RootKeyMeta))
+			}
+			reply.Keys = keys
+
+			return k.srv.setReplyQueryMeta(stateStore, state.TableRootKeyMeta, &reply.QueryMeta)
+		},
+	})
+}
+
+// Delete removes a root key from all the servers. The active key, and keys
+// which still have variables encrypted with them, cannot be removed.
+func (k *Keyring) Delete(args *structs.KeyringDeleteRootKeyRequest, reply *structs.KeyringDeleteRootKeyResponse) error {
+	if done, err := k.srv.forward(structs.KeyringDeleteRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "delete"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.KeyID == "" {
+		return fmt.Errorf("root key ID is required")
+	}
+
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	keyMeta, err := snap.RootKeyMetaByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if keyMeta == nil {
+		return fmt.Errorf("root key %s not found", args.KeyID)
+	}
+	if keyMeta.Active() {
+		return fmt.Errorf("active root key cannot be deleted - call rotate first")
+	}
+
+	iter, err := snap.GetVariablesByKeyID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+	if iter.Next() != nil {
+		return fmt.Errorf("root key %s is in use by variables - call rotate with full rekeying first", args.KeyID)
+	}
+
+	req := structs.RootKeyDeleteRequest{
+		KeyID:        args.KeyID,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := k.srv.raftApply(structs.RootKeyDeleteRequestType, req)
+	if err != nil {
+		return err
+	} else if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// Update is used to update the state of a root key, such as marking a key
+// as inactive once its data has been rekeyed.
+func (k *Keyring) Update(args *structs.KeyringUpdateRootKeyMetaRequest, reply *structs.KeyringUpdateRootKeyMetaResponse) error {
+	if done, err := k.srv.forward(structs.KeyringUpdateRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "update"}, time.Now())
+
+	if aclObj, err := k.srv.ResolveToken(args.AuthToken); err != nil {
+		return err
+	} else if aclObj != nil && !aclObj.IsManagement() {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := args.RootKeyMeta.Validate(); err != nil {
+		return err
+	}
+	if args.RootKeyMeta.Active() {
+		return fmt.Errorf("root key cannot be activated - call rotate instead")
+	}
+
+	keyMeta, err := k.srv.fsm.State().RootKeyMetaByID(nil, args.RootKeyMeta.KeyID)
+	if err != nil {
+		return err
+	}
+	if keyMeta == nil {
+		return fmt.Errorf("root key %s not found", args.RootKeyMeta.KeyID)
+	}
+
+	req := structs.RootKeyMetaUpsertRequest{
+		RootKeyMeta:  args.RootKeyMeta,
+		WriteRequest: args.WriteRequest,
+	}
+	resp, index, err := k.srv.raftApply(structs.RootKeyMetaUpsertRequestType, req)
+	if err != nil {
+		return err
+	} else if respErr, ok := resp.(error); ok {
+		return respErr
+	}
+
+	reply.Index = index
+	return nil
+}
+
+// ListPublic returns the public keys of all the root keys. These are used by
+// third parties to verify the workload identities signed by the servers, and
+// therefore do not require an ACL token.
+func (k *Keyring) ListPublic(args *structs.GenericRequest, reply *structs.KeyringListPublicResponse) error {
+	if done, err := k.srv.forward(structs.KeyringListPublicRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "list_public"}, time.Now())
+
+	return k.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			iter, err := stateStore.RootKeyMetas(ws)
+			if err != nil {
+				return err
+			}
+
+			pubKeys := []*structs.KeyringPublicKey{}
+			for raw := iter.Next(); raw != nil; raw = iter.Next() {
+				pubKey, err := k.encrypter.PublicKey(raw.(*structs.RootKeyMeta))
+				if err != nil {
+					return err
+				}
+				pubKeys = append(pubKeys, pubKey)
+			}
+			reply.PublicKeys = pubKeys
+
+			return k.srv.setReplyQueryMeta(stateStore, state.TableRootKeyMeta, &reply.QueryMeta)
+		},
+	})
+}
+
+// Get returns a root key, along with its key material, from the keyring of
+// the server. It is not exposed by the HTTP API, and is only used by servers to
+// replicate the key material, which is not written to raft.
+func (k *Keyring) Get(args *structs.KeyringGetRootKeyRequest, reply *structs.KeyringGetRootKeyResponse) error {
+	// Only other servers are allowed to fetch key material, so the caller must
+	// present the keyring replication token shared by the servers and, when
+	// mutual TLS is enabled, a server certificate.
+	if !k.srv.keyringReplicationEnabled() {
+		return structs.ErrPermissionDenied
+	}
+	if err := validateTLSCertificateLevel(k.srv, k.ctx, tlsCertificateLevelServer); err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(args.AuthToken), []byte(k.srv.config.KeyringReplicationToken)) != 1 {
+		return structs.ErrPermissionDenied
+	}
+	if done, err := k.srv.forward(structs.KeyringGetRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "keyring", "get"}, time.Now())
+
+	if args.KeyID == "" {
+		return fmt.Errorf("root key ID is required")
+	}
+
+	snap, err := k.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	keyMeta, err := snap.RootKeyMetaByID(nil, args.KeyID)
+	if err != nil {
+		return err
+	}
+
+	// The server may not have replicated the key material yet, in which case
+	// the caller asks another server.
+	if keyMeta != nil && k.encrypter.HasKey(args.KeyID) {
+		rootKey, err := k.encrypter.GetKey(args.KeyID)
+		if err != nil {
+			return err
+		}
+		rootKey.Meta = keyMeta
+		reply.Key = rootKey
+	}
+
+	return k.srv.setReplyQueryMeta(&snap.StateStore, state.TableRootKeyMeta, &reply.QueryMeta)
+}
@@ -0,0 +1,304 @@
+package nomad
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyringEndpoint_ListPublic(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, nil)
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	keyMeta, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	req := &structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: "global",
+		},
+	}
+	var resp structs.KeyringListPublicResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringListPublicRPCMethod, req, &resp))
+	require.NotZero(t, resp.Index)
+	require.Len(t, resp.PublicKeys, 1)
+	require.Equal(t, keyMeta.KeyID, resp.PublicKeys[0].KeyID)
+	require.Equal(t, structs.WorkloadIdentityAlgorithm, resp.PublicKeys[0].Algorithm)
+	require.Len(t, resp.PublicKeys[0].PublicKey, 32)
+}
+
+func TestKeyringEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, nil)
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	oldKey, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	// Write a variable so the old key is in use
+	sv := mock.Variable()
+	upsertReq := &structs.VariablesUpsertRequest{
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.VariablesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+
+	// Rotate
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringRotateRPCMethod, rotateReq, &rotateResp))
+	require.NotZero(t, rotateResp.Index)
+	newKey := rotateResp.Key
+	require.True(t, newKey.Active())
+	require.Equal(t, structs.EncryptionAlgorithmAES256GCM, newKey.Algorithm)
+
+	// List
+	listReq := &structs.KeyringListRootKeyMetaRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.KeyringListRootKeyMetaResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringListRPCMethod, listReq, &listResp))
+	require.Equal(t, rotateResp.Index, listResp.Index)
+	require.Len(t, listResp.Keys, 2)
+	for _, keyMeta := range listResp.Keys {
+		switch keyMeta.KeyID {
+		case oldKey.KeyID:
+			require.True(t, keyMeta.Inactive())
+		case newKey.KeyID:
+			require.True(t, keyMeta.Active())
+		default:
+			t.Fatalf("unexpected key %s", keyMeta.KeyID)
+		}
+	}
+
+	// The active key cannot be deleted
+	delReq := &structs.KeyringDeleteRootKeyRequest{
+		KeyID:        newKey.KeyID,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var delResp structs.KeyringDeleteRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.KeyringDeleteRPCMethod, delReq, &delResp)
+	require.EqualError(t, err, "active root key cannot be deleted - call rotate first")
+
+	// Nor can a key in use by variables
+	delReq.KeyID = oldKey.KeyID
+	err = msgpackrpc.CallWithCodec(codec, structs.KeyringDeleteRPCMethod, delReq, &delResp)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "in use by variables")
+
+	// Once the variable is deleted the old key can be removed
+	varDelReq := &structs.VariablesDeleteRequest{
+		Path:         sv.Path,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var varDelResp structs.VariablesDeleteResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesDeleteRPCMethod, varDelReq, &varDelResp))
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringDeleteRPCMethod, delReq, &delResp))
+	require.NotZero(t, delResp.Index)
+
+	out, err := srv.fsm.State().RootKeyMetaByID(nil, oldKey.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	_, err = srv.encrypter.GetKey(oldKey.KeyID)
+	require.Error(t, err)
+
+	// Deleting an unknown key fails
+	err = msgpackrpc.CallWithCodec(codec, structs.KeyringDeleteRPCMethod, delReq, &delResp)
+	require.EqualError(t, err, fmt.Sprintf("root key %s not found", oldKey.KeyID))
+}
+
+func TestKeyringEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+	srv, root, cleanupSrv := TestACLServer(t, nil)
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	token := mock.CreatePolicyAndToken(t, srv.fsm.State(), 1001, "operator",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob})+
+			`operator { policy = "write" }`)
+
+	rotateReq := &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	listReq := &structs.KeyringListRootKeyMetaRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.KeyringListRootKeyMetaResponse
+
+	// Requests without a token, or without a management token, are denied
+	for _, secretID := range []string{"", token.SecretID} {
+		rotateReq.AuthToken = secretID
+		err := msgpackrpc.CallWithCodec(codec, structs.KeyringRotateRPCMethod, rotateReq, &rotateResp)
+		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+		listReq.AuthToken = secretID
+		err = msgpackrpc.CallWithCodec(codec, structs.KeyringListRPCMethod, listReq, &listResp)
+		require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	}
+
+	rotateReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringRotateRPCMethod, rotateReq, &rotateResp))
+	listReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Keys, 2)
+
+	// The public keys are available without a token
+	var pubResp structs.KeyringListPublicResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringListPublicRPCMethod,
+		&structs.GenericRequest{QueryOptions: structs.QueryOptions{Region: "global"}}, &pubResp))
+	require.Len(t, pubResp.PublicKeys, 2)
+}
+
+func TestKeyringEndpoint_Replication(t *testing.T) {
+	ci.Parallel(t)
+
+	dir := t.TempDir()
+
+	servers := make([]*Server, 3)
+	for i := range servers {
+		s, cleanup := TestServer(t, func(c *Config) {
+			c.Region = "regionFoo"
+			c.BootstrapExpect = 3
+			c.DevMode = false
+			c.DataDir = path.Join(dir, fmt.Sprintf("node%d", i))
+			c.KeyringReplicationToken = "replication-token"
+		})
+		defer cleanup()
+		servers[i] = s
+	}
+
+	TestJoin(t, servers...)
+	for _, s := range servers {
+		testutil.WaitForLeader(t, s.RPC)
+		waitForKeyring(t, s)
+	}
+
+	// waitForKeyMaterial waits for every server to replicate the key
+	// material of the root key.
+	waitForKeyMaterial := func(keyID string) {
+		testutil.WaitForResult(func() (bool, error) {
+			for _, s := range servers {
+				if !s.encrypter.HasKey(keyID) {
+					return false, fmt.Errorf("server %s has not replicated key %s", s.config.NodeName, keyID)
+				}
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatalf("key was not replicated: %v", err)
+		})
+	}
+
+	keyMeta, err := servers[0].fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	waitForKeyMaterial(keyMeta.KeyID)
+
+	// A rotated key is replicated to all servers
+	var rotateResp structs.KeyringRotateRootKeyResponse
+	require.NoError(t, servers[0].RPC(structs.KeyringRotateRPCMethod, &structs.KeyringRotateRootKeyRequest{
+		WriteRequest: structs.WriteRequest{Region: "regionFoo"},
+	}, &rotateResp))
+	waitForKeyMaterial(rotateResp.Key.KeyID)
+
+	// The key material is identical on all servers
+	expected, err := servers[0].encrypter.GetKey(rotateResp.Key.KeyID)
+	require.NoError(t, err)
+	for _, s := range servers[1:] {
+		out, err := s.encrypter.GetKey(rotateResp.Key.KeyID)
+		require.NoError(t, err)
+		require.Equal(t, expected.Key, out.Key)
+	}
+
+	// The key material can't be fetched without the replication token
+	getReq := &structs.KeyringGetRootKeyRequest{
+		KeyID:        rotateResp.Key.KeyID,
+		QueryOptions: structs.QueryOptions{Region: "regionFoo", AuthToken: "wrong"},
+	}
+	var getResp structs.KeyringGetRootKeyResponse
+	err = servers[0].RPC(structs.KeyringGetRPCMethod, getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	require.Nil(t, getResp.Key)
+
+	// Unknown keys are not returned
+	getReq.KeyID = "unknown"
+	getReq.AuthToken = "replication-token"
+	require.NoError(t, servers[0].RPC(structs.KeyringGetRPCMethod, getReq, &getResp))
+	require.Nil(t, getResp.Key)
+}
+
+func TestKeyringEndpoint_Get_WithoutMTLS(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, func(c *Config) {
+		c.KeyringReplicationToken = "replication-token"
+	})
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	keyMeta, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	// Without mutual TLS the key material is returned to callers presenting
+	// the replication token
+	getReq := &structs.KeyringGetRootKeyRequest{
+		KeyID: keyMeta.KeyID,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: "replication-token",
+		},
+	}
+	var getResp structs.KeyringGetRootKeyResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.KeyringGetRPCMethod, getReq, &getResp))
+	require.NotNil(t, getResp.Key)
+	require.Equal(t, keyMeta.KeyID, getResp.Key.Meta.KeyID)
+
+	// Other tokens are rejected
+	getReq.AuthToken = "wrong"
+	getResp = structs.KeyringGetRootKeyResponse{}
+	err = msgpackrpc.CallWithCodec(codec, structs.KeyringGetRPCMethod, getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	require.Nil(t, getResp.Key)
+}
+
+func TestKeyringEndpoint_Get_WithoutReplicationToken(t *testing.T) {
+	ci.Parallel(t)
+	srv, cleanupSrv := TestServer(t, nil)
+	defer cleanupSrv()
+	codec := rpcClient(t, srv)
+	testutil.WaitForLeader(t, srv.RPC)
+	waitForKeyring(t, srv)
+
+	keyMeta, err := srv.fsm.State().GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+
+	// Key material is never returned by servers without a replication token
+	getReq := &structs.KeyringGetRootKeyRequest{
+		KeyID:        keyMeta.KeyID,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.KeyringGetRootKeyResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.KeyringGetRPCMethod, getReq, &getResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+	require.Nil(t, getResp.Key)
+}
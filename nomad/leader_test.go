@@ -949,6 +949,188 @@ func TestLeader_DiffACLPolicies(t *testing.T) {
 	assert.Equal(t, []string{p3.Name, p4.Name}, update)
 }
 
+func TestLeader_ReplicateACLRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer cleanupS1()
+	s2, _, cleanupS2 := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write a role to the authoritative region. The policies it links to do
+	// not exist, which should not block replication.
+	r1 := mock.ACLRole()
+	require.NoError(t, s1.State().UpsertACLRoles(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLRole{r1}, true))
+
+	// Wait for the role to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().GetACLRoleByID(nil, r1.ID)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate role")
+	})
+}
+
+func TestLeader_DiffACLRoles(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	r1 := mock.ACLRole()
+	r2 := mock.ACLRole()
+	r3 := mock.ACLRole()
+	require.NoError(t, state.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLRole{r1, r2, r3}, true))
+
+	// Simulate a remote list
+	r2Stub := r2.Stub()
+	r2Stub.ModifyIndex = 50 // Ignored, same index
+	r3Stub := r3.Stub()
+	r3Stub.ModifyIndex = 100 // Updated, higher index
+	r3Stub.Hash = []byte{0, 1, 2, 3}
+	r4 := mock.ACLRole()
+	remoteList := []*structs.ACLRoleListStub{
+		r2Stub,
+		r3Stub,
+		r4.Stub(),
+	}
+	delete, update := diffACLRoles(state, 50, remoteList)
+
+	// R1 does not exist on the remote side, should delete
+	require.Equal(t, []string{r1.ID}, delete)
+
+	// R2 is un-modified - ignore. R3 modified, R4 new.
+	require.Equal(t, []string{r3.ID, r4.ID}, update)
+}
+
+func TestLeader_ReplicateACLAuthMethodsAndBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.Region = "region1"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+	})
+	defer cleanupS1()
+	s2, _, cleanupS2 := TestACLServer(t, func(c *Config) {
+		c.Region = "region2"
+		c.AuthoritativeRegion = "region1"
+		c.ACLEnabled = true
+		c.ReplicationBackoff = 20 * time.Millisecond
+		c.ReplicationToken = root.SecretID
+	})
+	defer cleanupS2()
+	TestJoin(t, s1, s2)
+	testutil.WaitForLeader(t, s1.RPC)
+	testutil.WaitForLeader(t, s2.RPC)
+
+	// Write an auth method and one of its binding rules to the authoritative
+	// region.
+	am1 := mock.ACLAuthMethod()
+	require.NoError(t, s1.State().UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLAuthMethod{am1}))
+	br1 := mock.ACLBindingRule()
+	br1.AuthMethod = am1.Name
+	br1.SetHash()
+	require.NoError(t, s1.State().UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 110, []*structs.ACLBindingRule{br1}, false))
+
+	// Wait for both to replicate
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().GetACLAuthMethodByName(nil, am1.Name)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate auth method")
+	})
+	testutil.WaitForResult(func() (bool, error) {
+		out, err := s2.State().GetACLBindingRule(nil, br1.ID)
+		return out != nil, err
+	}, func(err error) {
+		t.Fatalf("should replicate binding rule")
+	})
+}
+
+func TestLeader_DiffACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	am1 := mock.ACLAuthMethod()
+	am2 := mock.ACLAuthMethod()
+	am3 := mock.ACLAuthMethod()
+	require.NoError(t, state.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLAuthMethod{am1, am2, am3}))
+
+	// Simulate a remote list
+	am2Stub := am2.Stub()
+	am2Stub.ModifyIndex = 50 // Ignored, same index
+	am3Stub := am3.Stub()
+	am3Stub.ModifyIndex = 100 // Updated, higher index
+	am3Stub.Hash = []byte{0, 1, 2, 3}
+	am4 := mock.ACLAuthMethod()
+	remoteList := []*structs.ACLAuthMethodListStub{
+		am2Stub,
+		am3Stub,
+		am4.Stub(),
+	}
+	delete, update := diffACLAuthMethods(state, 50, remoteList)
+
+	// AM1 does not exist on the remote side, should delete
+	require.Equal(t, []string{am1.Name}, delete)
+
+	// AM2 is un-modified - ignore. AM3 modified, AM4 new.
+	require.Equal(t, []string{am3.Name, am4.Name}, update)
+}
+
+func TestLeader_DiffACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+
+	state := state.TestStateStore(t)
+
+	// Populate the local state
+	br1 := mock.ACLBindingRule()
+	br2 := mock.ACLBindingRule()
+	br3 := mock.ACLBindingRule()
+	require.NoError(t, state.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 100, []*structs.ACLBindingRule{br1, br2, br3}, true))
+
+	// Simulate a remote list
+	br2Stub := br2.Stub()
+	br2Stub.ModifyIndex = 50 // Ignored, same index
+	br3Stub := br3.Stub()
+	br3Stub.ModifyIndex = 100 // Updated, higher index
+	br3Stub.Hash = []byte{0, 1, 2, 3}
+	br4 := mock.ACLBindingRule()
+	remoteList := []*structs.ACLBindingRuleListStub{
+		br2Stub,
+		br3Stub,
+		br4.Stub(),
+	}
+	delete, update := diffACLBindingRules(state, 50, remoteList)
+
+	// BR1 does not exist on the remote side, should delete
+	require.Equal(t, []string{br1.ID}, delete)
+
+	// BR2 is un-modified - ignore. BR3 modified, BR4 new.
+	require.Equal(t, []string{br3.ID, br4.ID}, update)
+}
+
 func TestLeader_ReplicateACLTokens(t *testing.T) {
 	ci.Parallel(t)
 
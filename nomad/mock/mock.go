@@ -113,6 +113,7 @@ func Node() *structs.Node {
 			"version":  "5.6",
 		},
 		NodeClass:             "linux-medium-pci",
+		NodePool:              structs.NodePoolDefault,
 		Status:                structs.NodeStatusReady,
 		SchedulingEligibility: structs.NodeSchedulingEligible,
 	}
@@ -200,6 +201,7 @@ func SystemBatchJob() *structs.Job {
 		Type:        structs.JobTypeSysBatch,
 		Priority:    10,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -241,6 +243,7 @@ func Job() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -490,6 +493,7 @@ func VariableLifecycleJob(resources structs.Resources, main int, init int, side
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -527,6 +531,7 @@ func LifecycleJob() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -679,6 +684,7 @@ func LifecycleJobWithPoststopDeploy() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -783,6 +789,7 @@ func LifecycleJobWithPoststartDeploy() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -1041,6 +1048,7 @@ func MaxParallelJob() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -1353,6 +1361,7 @@ func BatchJob() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		TaskGroups: []*structs.TaskGroup{
 			{
 				Name:  "web",
@@ -1419,6 +1428,7 @@ func SystemJob() *structs.Job {
 		Priority:    100,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		Constraints: []*structs.Constraint{
 			{
 				LTarget: "${attr.kernel.name}",
@@ -1723,6 +1733,7 @@ func BatchConnectJob() *structs.Job {
 		Priority:    50,
 		AllAtOnce:   false,
 		Datacenters: []string{"dc1"},
+		NodePool:    structs.NodePoolDefault,
 		TaskGroups: []*structs.TaskGroup{{
 			Name:          "mock-connect-batch-job",
 			Count:         1,
@@ -2081,6 +2092,64 @@ func ACLManagementToken() *structs.ACLToken {
 	}
 }
 
+// ACLRole returns a ACL role which links to the policies "foo" and "bar".
+func ACLRole() *structs.ACLRole {
+	role := &structs.ACLRole{
+		ID:          uuid.Generate(),
+		Name:        fmt.Sprintf("acl-role-%s", uuid.Short()),
+		Description: "mocked-test-acl-role",
+		Policies: []*structs.ACLRolePolicyLink{
+			{Name: "foo"},
+			{Name: "bar"},
+		},
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	role.SetHash()
+	return role
+}
+
+func ACLAuthMethod() *structs.ACLAuthMethod {
+	method := &structs.ACLAuthMethod{
+		Name:          fmt.Sprintf("acl-auth-method-%s", uuid.Short()),
+		Type:          structs.ACLAuthMethodTypeOIDC,
+		TokenLocality: structs.ACLAuthMethodTokenLocalityLocal,
+		MaxTokenTTL:   time.Hour,
+		Default:       false,
+		Config: &structs.ACLAuthMethodConfig{
+			OIDCDiscoveryURL:    "http://example.com",
+			OIDCClientID:        "mock",
+			OIDCClientSecret:    "very secret secret",
+			BoundAudiences:      []string{"audience1", "audience2"},
+			AllowedRedirectURIs: []string{"foo", "bar"},
+			SigningAlgs:         []string{"RS256"},
+			ClaimMappings:       map[string]string{"foo": "bar"},
+			ListClaimMappings:   map[string]string{"foo": "bar"},
+		},
+		CreateTime:  time.Now().UTC(),
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	method.SetHash()
+	return method
+}
+
+func ACLBindingRule() *structs.ACLBindingRule {
+	rule := &structs.ACLBindingRule{
+		ID:          uuid.Generate(),
+		Description: "mocked-acl-binding-rule",
+		AuthMethod:  "auth0",
+		Selector:    `"engineering" in list.roles`,
+		BindType:    structs.ACLBindingRuleBindTypeRole,
+		BindName:    "eng-ro",
+		CreateTime:  time.Now().UTC(),
+		CreateIndex: 10,
+		ModifyIndex: 10,
+	}
+	rule.SetHash()
+	return rule
+}
+
 func ScalingPolicy() *structs.ScalingPolicy {
 	return &structs.ScalingPolicy{
 		ID:   uuid.Generate(),
@@ -2266,6 +2335,16 @@ func Namespace() *structs.Namespace {
 	return ns
 }
 
+// NodePool returns a random node pool with no scheduler configuration.
+func NodePool() *structs.NodePool {
+	id := uuid.Generate()
+	return &structs.NodePool{
+		Name:        fmt.Sprintf("pool-%s", id[:8]),
+		Description: "test node pool",
+		Meta:        map[string]string{"team": id},
+	}
+}
+
 // ServiceRegistrations generates an array containing two unique service
 // registrations.
 func ServiceRegistrations() []*structs.ServiceRegistration {
@@ -2296,3 +2375,45 @@ func ServiceRegistrations() []*structs.ServiceRegistration {
 		},
 	}
 }
+
+// Variable returns a decrypted variable with a random path in the default
+// namespace.
+func Variable() *structs.VariableDecrypted {
+	return &structs.VariableDecrypted{
+		VariableMetadata: structs.VariableMetadata{
+			Namespace: structs.DefaultNamespace,
+			Path:      fmt.Sprintf("var/%s", uuid.Generate()[:8]),
+		},
+		Items: structs.VariableItems{
+			"username": "admin",
+			"password": uuid.Generate(),
+		},
+	}
+}
+
+// VariableEncrypted returns an encrypted variable with a random path in the
+// default namespace. The data is not real ciphertext, so the variable can
+// only be used where decryption is not required.
+func VariableEncrypted() *structs.VariableEncrypted {
+	return &structs.VariableEncrypted{
+		VariableMetadata: structs.VariableMetadata{
+			Namespace:  structs.DefaultNamespace,
+			Path:       fmt.Sprintf("var/%s", uuid.Generate()[:8]),
+			CreateTime: time.Now().UnixNano(),
+			ModifyTime: time.Now().UnixNano(),
+		},
+		VariableData: structs.VariableData{
+			Data:  []byte(uuid.Generate()),
+			KeyID: uuid.Generate(),
+		},
+	}
+}
+
+// RootKey returns a new active root key.
+func RootKey() *structs.RootKey {
+	key, err := structs.NewRootKey(structs.EncryptionAlgorithmAES256GCM)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
@@ -697,6 +697,37 @@ func TestNamespaceEndpoint_UpsertNamespaces(t *testing.T) {
 	assert.NotNil(out)
 }
 
+func TestNamespaceEndpoint_UpsertNamespaces_FairShareWeight(t *testing.T) {
+	ci.Parallel(t)
+	assert := assert.New(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	ns := mock.Namespace()
+	ns.FairShareWeight = -1
+	req := &structs.NamespaceUpsertRequest{
+		Namespaces:   []*structs.Namespace{ns},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp)
+	assert.NotNil(err)
+	assert.Contains(err.Error(), "fair share weight cannot be negative")
+
+	// A positive weight is stored and handed to the eval broker.
+	ns.FairShareWeight = 5
+	assert.Nil(msgpackrpc.CallWithCodec(codec, "Namespace.UpsertNamespaces", req, &resp))
+
+	out, err := s1.fsm.State().NamespaceByName(nil, ns.Name)
+	assert.Nil(err)
+	assert.Equal(5, out.FairShareWeight)
+
+	_, weights := s1.evalBroker.FairShare()
+	assert.Equal(5, weights[ns.Name])
+}
+
 func TestNamespaceEndpoint_UpsertNamespaces_ACL(t *testing.T) {
 	ci.Parallel(t)
 	assert := assert.New(t)
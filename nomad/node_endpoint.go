@@ -126,6 +126,17 @@ func (n *Node) Register(args *structs.NodeRegisterRequest, reply *structs.NodeUp
 		args.Node.SchedulingEligibility = structs.NodeSchedulingEligible
 	}
 
+	// Default to the default node pool if unset
+	if args.Node.NodePool == "" {
+		args.Node.NodePool = structs.NodePoolDefault
+	}
+	if !structs.ValidNodePoolName(args.Node.NodePool) {
+		return fmt.Errorf("invalid node pool %q for node", args.Node.NodePool)
+	}
+	if args.Node.NodePool == structs.NodePoolAll {
+		return fmt.Errorf("node is not allowed to register in node pool %q", structs.NodePoolAll)
+	}
+
 	// Set the timestamp when the node is registered
 	args.Node.StatusUpdatedAt = time.Now().Unix()
 
@@ -236,6 +247,7 @@ func shouldCreateNodeEval(original, updated *structs.Node) bool {
 		original.Datacenter == updated.Datacenter &&
 		original.Name == updated.Name &&
 		original.NodeClass == updated.NodeClass &&
+		original.NodePool == updated.NodePool &&
 		reflect.DeepEqual(original.Attributes, updated.Attributes) &&
 		reflect.DeepEqual(original.Meta, updated.Meta) &&
 		reflect.DeepEqual(original.Drivers, updated.Drivers) &&
@@ -0,0 +1,194 @@
+package nomad
+
+import (
+	"testing"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolEndpoint_UpsertNodePools(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool1, pool2 := mock.NodePool(), mock.NodePool()
+	req := &structs.NodePoolUpsertRequest{
+		NodePools:    []*structs.NodePool{pool1, pool2},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp))
+	require.NotZero(t, resp.Index)
+
+	for _, pool := range []*structs.NodePool{pool1, pool2} {
+		out, err := s1.fsm.State().NodePoolByName(nil, pool.Name)
+		require.NoError(t, err)
+		require.NotNil(t, out)
+		require.Equal(t, pool.Description, out.Description)
+	}
+
+	// Built-in pools cannot be modified.
+	req.NodePools = []*structs.NodePool{{Name: structs.NodePoolDefault, Description: "modified"}}
+	err := msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "not allowed")
+
+	// Invalid pools are rejected.
+	req.NodePools = []*structs.NodePool{{Name: "not@valid"}}
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "invalid node pool")
+}
+
+func TestNodePoolEndpoint_DeleteNodePools(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool := mock.NodePool()
+	require.NoError(t, s1.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	// Built-in pools cannot be deleted.
+	req := &structs.NodePoolDeleteRequest{
+		Names:        []string{structs.NodePoolAll},
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.GenericResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.NodePoolDeleteRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "not allowed")
+
+	req.Names = []string{pool.Name}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolDeleteRPCMethod, req, &resp))
+	require.NotZero(t, resp.Index)
+
+	out, err := s1.fsm.State().NodePoolByName(nil, pool.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestNodePoolEndpoint_List_GetNodePool(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	pool := mock.NodePool()
+	require.NoError(t, s1.fsm.State().UpsertNodePools(structs.MsgTypeTestSetup, 1000, []*structs.NodePool{pool}))
+
+	// List all the pools, including the built-in ones.
+	listReq := &structs.NodePoolListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.NodePoolListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.NodePools, 3)
+	require.Equal(t, uint64(1000), listResp.Index)
+
+	// List using a prefix.
+	listReq.Prefix = pool.Name[:8]
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.NodePools, 1)
+	require.Equal(t, pool.Name, listResp.NodePools[0].Name)
+
+	// Lookup a single pool.
+	getReq := &structs.NodePoolSpecificRequest{
+		Name:         pool.Name,
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var getResp structs.SingleNodePoolResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolGetRPCMethod, getReq, &getResp))
+	require.Equal(t, uint64(1000), getResp.Index)
+	require.Equal(t, pool.Name, getResp.NodePool.Name)
+
+	// Lookup a pool that doesn't exist.
+	getReq.Name = "nonexistent"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolGetRPCMethod, getReq, &getResp))
+	require.Nil(t, getResp.NodePool)
+}
+
+func TestNodePoolEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	state := s1.fsm.State()
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "node-read",
+		mock.NodePolicy(acl.PolicyRead))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1003, "test-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	// Listing requires node read.
+	listReq := &structs.NodePoolListRequest{
+		QueryOptions: structs.QueryOptions{Region: "global"},
+	}
+	var listResp structs.NodePoolListResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, listReq, &listResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, listReq, &listResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	listReq.AuthToken = readToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.NodePools, 2)
+
+	// Upserting requires a management token.
+	upsertReq := &structs.NodePoolUpsertRequest{
+		NodePools: []*structs.NodePool{mock.NodePool()},
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var upsertResp structs.GenericResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, upsertReq, &upsertResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.NodePoolUpsertRPCMethod, upsertReq, &upsertResp))
+}
+
+func TestJobEndpoint_Register_NodePool(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+
+	// Jobs without a node pool are placed in the default pool.
+	job := mock.Job()
+	req := &structs.JobRegisterRequest{
+		Job: job,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			Namespace: job.Namespace,
+		},
+	}
+	var resp structs.JobRegisterResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp))
+
+	out, err := s1.fsm.State().JobByID(nil, job.Namespace, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, structs.NodePoolDefault, out.NodePool)
+
+	// Jobs in a nonexistent node pool are rejected.
+	job = mock.Job()
+	job.NodePool = "nonexistent"
+	req.Job = job
+	err = msgpackrpc.CallWithCodec(codec, "Job.Register", req, &resp)
+	require.ErrorContains(t, err, "nonexistent node pool")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/consul/agent/consul/autopilot"
 	cstructs "github.com/hashicorp/nomad/client/structs"
 	"github.com/hashicorp/nomad/helper/snapshot"
+	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
 	"github.com/hashicorp/raft"
 	"github.com/hashicorp/serf/serf"
@@ -368,6 +369,129 @@ func (op *Operator) SchedulerGetConfiguration(args *structs.GenericRequest, repl
 	return nil
 }
 
+// SchedulerGetBrokerStats is used to retrieve the statistics of the eval broker
+// queues by namespace.
+func (op *Operator) SchedulerGetBrokerStats(args *structs.GenericRequest, reply *structs.SchedulerBrokerStatsResponse) error {
+	// Only the leader runs the eval broker, so stale reads are not allowed.
+	args.AllowStale = false
+	if done, err := op.srv.forward("Operator.SchedulerGetBrokerStats", args, args, reply); done {
+		return err
+	}
+
+	// This action requires operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil && !rule.AllowOperatorRead() {
+		return structs.ErrPermissionDenied
+	}
+
+	fairShare, weights := op.srv.evalBroker.FairShare()
+	reply.FairShareEnabled = fairShare
+	reply.Namespaces = make(map[string]*structs.SchedulerBrokerNamespaceStats)
+
+	// Include every namespace, along with any namespace with ready
+	// evaluations which was deleted since.
+	store := op.srv.fsm.State()
+	iter, err := store.Namespaces(nil)
+	if err != nil {
+		return err
+	}
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		ns := raw.(*structs.Namespace)
+		reply.Namespaces[ns.Name] = &structs.SchedulerBrokerNamespaceStats{}
+	}
+	for namespace, stats := range op.srv.evalBroker.Stats().ByNamespace {
+		if _, ok := reply.Namespaces[namespace]; !ok {
+			reply.Namespaces[namespace] = &structs.SchedulerBrokerNamespaceStats{}
+		}
+		reply.Namespaces[namespace].Ready = stats.Ready
+	}
+	for namespace, stats := range reply.Namespaces {
+		stats.Weight = weights[namespace]
+		if stats.Weight == 0 {
+			stats.Weight = 1
+		}
+	}
+
+	index, err := store.Index(state.TableNamespaces)
+	if err != nil {
+		return err
+	}
+	reply.QueryMeta.Index = index
+	op.srv.setQueryMeta(&reply.QueryMeta)
+
+	return nil
+}
+
+// SchedulerRebalance is used to compute and apply a round of rebalancing
+// migrations, which migrate allocations off under-utilized nodes. The
+// migrations are only reported if the request is a dry run.
+func (op *Operator) SchedulerRebalance(args *structs.SchedulerRebalanceRequest, reply *structs.SchedulerRebalanceResponse) error {
+	if done, err := op.srv.forward("Operator.SchedulerRebalance", args, args, reply); done {
+		return err
+	}
+
+	// Applying the migrations requires operator write access, and reporting
+	// them operator read access.
+	rule, err := op.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	} else if rule != nil {
+		if !args.DryRun && !rule.AllowOperatorWrite() {
+			return structs.ErrPermissionDenied
+		} else if !rule.AllowOperatorRead() {
+			return structs.ErrPermissionDenied
+		}
+	}
+
+	snap, err := op.srv.fsm.State().Snapshot()
+	if err != nil {
+		return err
+	}
+	_, schedConfig, err := snap.SchedulerConfig()
+	if err != nil {
+		return err
+	}
+	var config structs.RebalanceConfig
+	if schedConfig != nil {
+		config = schedConfig.RebalanceConfig
+	}
+
+	budget := config.EffectiveMaxMigrations()
+	if args.MaxMigrations > 0 {
+		budget = args.MaxMigrations
+	}
+	reply.Nodes, reply.Migrations, err = computeRebalance(snap, &config, budget)
+	if err != nil {
+		return err
+	}
+
+	if args.DryRun || len(reply.Migrations) == 0 {
+		index, err := snap.LatestIndex()
+		if err != nil {
+			return err
+		}
+		reply.Index = index
+		return nil
+	}
+
+	req, err := rebalanceTransitionRequest(snap, reply.Migrations)
+	if err != nil {
+		return err
+	}
+	_, index, err := op.srv.raftApply(structs.AllocUpdateDesiredTransitionRequestType, req)
+	if err != nil {
+		op.logger.Error("rebalance migrations failed", "error", err)
+		return err
+	}
+	for _, eval := range req.Evals {
+		reply.EvalIDs = append(reply.EvalIDs, eval.ID)
+	}
+	reply.Index = index
+	return nil
+}
+
 func (op *Operator) forwardStreamingRPC(region string, method string, args interface{}, in io.ReadWriteCloser) error {
 	server, err := op.srv.findRegionServer(region)
 	if err != nil {
@@ -535,6 +535,56 @@ func TestOperator_SchedulerSetConfiguration_ACL(t *testing.T) {
 
 }
 
+func TestOperator_SchedulerGetBrokerStats(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	ns := mock.Namespace()
+	ns.FairShareWeight = 4
+	require.NoError(t, state.UpsertNamespaces(1000, []*structs.Namespace{ns}))
+	s1.evalBroker.SetFairShare(true, map[string]int{ns.Name: ns.FairShareWeight})
+
+	// No scheduler worker dequeues evals of an unknown type, so the eval
+	// stays ready.
+	eval := mock.Eval()
+	eval.Namespace = ns.Name
+	eval.Type = "fair-share-test"
+	s1.evalBroker.Enqueue(eval)
+
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1001, "test-invalid", mock.NodePolicy(acl.PolicyWrite))
+	operatorToken := mock.CreatePolicyAndToken(t, state, 1003, "test-operator", `operator { policy = "read" }`)
+
+	arg := structs.GenericRequest{
+		QueryOptions: structs.QueryOptions{
+			Region: s1.config.Region,
+		},
+	}
+
+	// Try with no token and an invalid token and expect permission denied.
+	var reply structs.SchedulerBrokerStatsResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetBrokerStats", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	arg.AuthToken = invalidToken.SecretID
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetBrokerStats", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	for _, token := range []string{operatorToken.SecretID, root.SecretID} {
+		arg.AuthToken = token
+		reply = structs.SchedulerBrokerStatsResponse{}
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerGetBrokerStats", &arg, &reply))
+		require.True(t, reply.FairShareEnabled)
+		require.NotZero(t, reply.Index)
+		require.Equal(t, &structs.SchedulerBrokerNamespaceStats{Weight: 4, Ready: 1}, reply.Namespaces[ns.Name])
+		require.Equal(t, &structs.SchedulerBrokerNamespaceStats{Weight: 1}, reply.Namespaces[structs.DefaultNamespace])
+	}
+}
+
 func TestOperator_SnapshotSave(t *testing.T) {
 	ci.Parallel(t)
 
@@ -974,3 +1024,62 @@ func TestOperator_SnapshotRestore_ACL(t *testing.T) {
 		})
 	}
 }
+
+func TestOperator_SchedulerRebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, root, cleanupS1 := TestACLServer(t, func(c *Config) {
+		c.NumSchedulers = 0 // Prevent automatic dequeue
+	})
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	state := s1.fsm.State()
+
+	_, _, job, allocs := upsertRebalanceTestCluster(t, state)
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "test-read", `operator { policy = "read" }`)
+
+	arg := structs.SchedulerRebalanceRequest{
+		DryRun: true,
+		WriteRequest: structs.WriteRequest{
+			Region: s1.config.Region,
+		},
+	}
+
+	// Try with no token and expect permission denied.
+	var reply structs.SchedulerRebalanceResponse
+	err := msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// A dry run only reports the migrations.
+	arg.AuthToken = readToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	require.Len(t, reply.Migrations, 1)
+	require.Equal(t, allocs[0].ID, reply.Migrations[0].AllocID)
+	require.Empty(t, reply.EvalIDs)
+	require.NotZero(t, reply.Index)
+
+	out, err := state.AllocByID(nil, allocs[0].ID)
+	require.NoError(t, err)
+	require.False(t, out.DesiredTransition.ShouldMigrate())
+
+	// Applying the migrations requires operator write access.
+	arg.DryRun = false
+	err = msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	arg.AuthToken = root.SecretID
+	reply = structs.SchedulerRebalanceResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, "Operator.SchedulerRebalance", &arg, &reply))
+	require.Len(t, reply.Migrations, 1)
+	require.Len(t, reply.EvalIDs, 1)
+
+	out, err = state.AllocByID(nil, allocs[0].ID)
+	require.NoError(t, err)
+	require.True(t, out.DesiredTransition.ShouldMigrate())
+
+	eval, err := state.EvalByID(nil, reply.EvalIDs[0])
+	require.NoError(t, err)
+	require.Equal(t, job.ID, eval.JobID)
+	require.Equal(t, structs.EvalTriggerRebalance, eval.TriggeredBy)
+}
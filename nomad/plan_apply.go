@@ -215,6 +215,11 @@ func (p *planner) applyPlan(plan *structs.Plan, result *structs.PlanResult, snap
 	preemptedJobIDs := make(map[structs.NamespacedID]struct{})
 	now := time.Now().UTC().UnixNano()
 
+	// Sign the workload identities of the tasks of the placed allocations.
+	for _, allocList := range result.NodeAllocation {
+		p.signAllocIdentities(plan.Job, allocList)
+	}
+
 	if ServersMeetMinimumVersion(p.Members(), MinVersionPlanNormalization, true) {
 		// Initialize the allocs request using the new optimized log entry format.
 		// Determine the minimum number of updates, could be more if there
@@ -362,6 +367,45 @@ func updateAllocTimestamps(allocations []*structs.Allocation, timestamp int64) {
 	}
 }
 
+// signAllocIdentities signs a workload identity for each task of the
+// allocations which does not have one yet. If the keyring has not been
+// initialized yet, the allocations are placed without identities.
+func (p *planner) signAllocIdentities(job *structs.Job, allocations []*structs.Allocation) {
+	now := time.Now().UTC()
+	for _, alloc := range allocations {
+		allocJob := alloc.Job
+		if allocJob == nil {
+			allocJob = job
+		}
+		if allocJob == nil {
+			continue
+		}
+		tg := allocJob.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil {
+			continue
+		}
+
+		for _, task := range tg.Tasks {
+			if _, ok := alloc.SignedIdentities[task.Name]; ok {
+				continue
+			}
+
+			claims := structs.NewIdentityClaims(allocJob, alloc, task.Name, now)
+			token, err := p.encrypter.SignClaims(claims)
+			if err != nil {
+				p.logger.Warn("failed to sign workload identity",
+					"alloc_id", alloc.ID, "task", task.Name, "error", err)
+				continue
+			}
+
+			if alloc.SignedIdentities == nil {
+				alloc.SignedIdentities = make(map[string]string, len(tg.Tasks))
+			}
+			alloc.SignedIdentities[task.Name] = token
+		}
+	}
+}
+
 // asyncPlanWait is used to apply and respond to a plan async. On successful
 // commit the plan's index will be sent on the chan. On error the chan will be
 // closed.
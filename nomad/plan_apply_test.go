@@ -237,6 +237,60 @@ func TestPlanApply_applyPlan(t *testing.T) {
 	assert.Equal(index, evalOut.ModifyIndex)
 }
 
+func TestPlanApply_applyPlan_SignedIdentities(t *testing.T) {
+	ci.Parallel(t)
+
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	node := mock.Node()
+	testRegisterNode(t, s1, node)
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	require.NoError(t, s1.State().UpsertJobSummary(1000, mock.JobSummary(alloc.JobID)))
+	eval := mock.Eval()
+	eval.JobID = alloc.JobID
+	require.NoError(t, s1.State().UpsertEvals(structs.MsgTypeTestSetup, 1001, []*structs.Evaluation{eval}))
+
+	planRes := &structs.PlanResult{
+		NodeAllocation: map[string][]*structs.Allocation{
+			node.ID: {alloc},
+		},
+	}
+	plan := &structs.Plan{
+		Job:    alloc.Job,
+		EvalID: eval.ID,
+	}
+
+	snap, err := s1.State().Snapshot()
+	require.NoError(t, err)
+	future, err := s1.applyPlan(plan, planRes, snap)
+	require.NoError(t, err)
+	_, err = planWaitFuture(future)
+	require.NoError(t, err)
+
+	// Every task of the allocation has a signed identity for its workload
+	allocOut, err := s1.fsm.State().AllocByID(nil, alloc.ID)
+	require.NoError(t, err)
+	require.NotNil(t, allocOut)
+
+	tasks := allocOut.Job.LookupTaskGroup(allocOut.TaskGroup).Tasks
+	require.Len(t, allocOut.SignedIdentities, len(tasks))
+	for _, task := range tasks {
+		token, ok := allocOut.SignedIdentities[task.Name]
+		require.True(t, ok, "missing identity for task %q", task.Name)
+
+		claims, err := s1.encrypter.VerifyClaim(token)
+		require.NoError(t, err)
+		require.Equal(t, alloc.ID, claims.AllocationID)
+		require.Equal(t, alloc.JobID, claims.JobID)
+		require.Equal(t, task.Name, claims.TaskName)
+	}
+}
+
 // Verifies that applyPlan properly updates the constituent objects in MemDB,
 // when the plan contains normalized allocs.
 func TestPlanApply_applyPlanWithNormalizedAllocs(t *testing.T) {
@@ -0,0 +1,366 @@
+package nomad
+
+import (
+	"sort"
+	"time"
+
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// rebalanceNode tracks the capacity and the estimated usage of a node while
+// computing a rebalancing round.
+type rebalanceNode struct {
+	node   *structs.Node
+	allocs []*structs.Allocation
+
+	cpu, memory         int64
+	usedCPU, usedMemory int64
+
+	// source is set when allocations are migrated off the node, and target
+	// when the node is estimated to receive allocations. A node is never
+	// both.
+	source bool
+	target bool
+}
+
+func (n *rebalanceNode) utilization() float64 {
+	return utilization(n.usedCPU, n.usedMemory, n.cpu, n.memory)
+}
+
+// utilization returns the highest of the CPU and memory utilization.
+func utilization(usedCPU, usedMemory, cpu, memory int64) float64 {
+	var cpuUtil, memoryUtil float64
+	if cpu > 0 {
+		cpuUtil = float64(usedCPU) / float64(cpu)
+	}
+	if memory > 0 {
+		memoryUtil = float64(usedMemory) / float64(memory)
+	}
+	if cpuUtil > memoryUtil {
+		return cpuUtil
+	}
+	return memoryUtil
+}
+
+// rebalanceTaskGroup is the number of allocations of a task group that can
+// still be migrated, according to its migrate max_parallel.
+type rebalanceTaskGroup struct {
+	allowed int
+}
+
+// computeRebalance computes a round of rebalancing, which migrates the
+// allocations of service jobs off the nodes whose utilization is below the
+// threshold of the configuration. A node is only emptied if all of its
+// migratable allocations are estimated to fit on the remaining nodes, and
+// system allocations are ignored as they run on every node. At most budget
+// allocations are migrated, and no more allocations of a task group than its
+// migrate max_parallel allows, so nodes may take several rounds to be emptied.
+func computeRebalance(snap *state.StateSnapshot, config *structs.RebalanceConfig, budget int) ([]*structs.RebalanceNode, []*structs.RebalanceMigration, error) {
+	ws := memdb.NewWatchSet()
+	iter, err := snap.Nodes(ws)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nodes []*rebalanceNode
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		node := raw.(*structs.Node)
+		if !node.Ready() {
+			continue
+		}
+
+		available := node.ComparableResources()
+		available.Subtract(node.ComparableReservedResources())
+		n := &rebalanceNode{
+			node:   node,
+			cpu:    available.Flattened.Cpu.CpuShares,
+			memory: available.Flattened.Memory.MemoryMB,
+		}
+
+		allocs, err := snap.AllocsByNode(ws, node.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, alloc := range allocs {
+			if alloc.TerminalStatus() {
+				continue
+			}
+			used := alloc.ComparableResources()
+			n.usedCPU += used.Flattened.Cpu.CpuShares
+			n.usedMemory += used.Flattened.Memory.MemoryMB
+			n.allocs = append(n.allocs, alloc)
+		}
+		nodes = append(nodes, n)
+	}
+
+	// Empty the least utilized nodes first.
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ui, uj := nodes[i].utilization(), nodes[j].utilization()
+		if ui != uj {
+			return ui < uj
+		}
+		return nodes[i].node.ID < nodes[j].node.ID
+	})
+
+	threshold := config.EffectiveUtilizationThreshold()
+	taskGroups := make(map[string]*rebalanceTaskGroup)
+	var rebalanced []*structs.RebalanceNode
+	var migrations []*structs.RebalanceMigration
+
+	for _, n := range nodes {
+		if budget <= 0 {
+			break
+		}
+		if n.target || n.utilization() >= threshold {
+			continue
+		}
+
+		movable, ok, err := rebalanceMovableAllocs(snap, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !ok || len(movable) == 0 {
+			continue
+		}
+
+		targets, ok := rebalanceTargets(nodes, n, movable)
+		if !ok {
+			continue
+		}
+
+		var nodeMigrations []*structs.RebalanceMigration
+		for i, alloc := range movable {
+			if budget <= 0 {
+				break
+			}
+
+			tg, err := rebalanceTaskGroupFor(snap, taskGroups, alloc)
+			if err != nil {
+				return nil, nil, err
+			}
+			if tg.allowed <= 0 {
+				continue
+			}
+			tg.allowed--
+			budget--
+
+			nodeMigrations = append(nodeMigrations, &structs.RebalanceMigration{
+				AllocID:      alloc.ID,
+				AllocName:    alloc.Name,
+				Namespace:    alloc.Namespace,
+				JobID:        alloc.JobID,
+				TaskGroup:    alloc.TaskGroup,
+				NodeID:       n.node.ID,
+				TargetNodeID: targets[i].node.ID,
+			})
+		}
+		if len(nodeMigrations) == 0 {
+			continue
+		}
+
+		// Reserve the capacity of all the movable allocations on the
+		// targets, including the ones left for a later round, so the node
+		// isn't refilled in the meantime.
+		n.source = true
+		for i, alloc := range movable {
+			used := alloc.ComparableResources()
+			targets[i].usedCPU += used.Flattened.Cpu.CpuShares
+			targets[i].usedMemory += used.Flattened.Memory.MemoryMB
+			targets[i].target = true
+		}
+
+		rebalanced = append(rebalanced, &structs.RebalanceNode{
+			ID:          n.node.ID,
+			Name:        n.node.Name,
+			Utilization: n.utilization(),
+		})
+		migrations = append(migrations, nodeMigrations...)
+	}
+
+	return rebalanced, migrations, nil
+}
+
+// rebalanceMovableAllocs returns the allocations of the node to migrate, from
+// the largest to the smallest. It returns false if the node can't be emptied
+// because it runs allocations which can't be migrated.
+func rebalanceMovableAllocs(snap *state.StateSnapshot, n *rebalanceNode) ([]*structs.Allocation, bool, error) {
+	var movable []*structs.Allocation
+	for _, alloc := range n.allocs {
+		// The allocation is already being migrated off the node.
+		if alloc.DesiredTransition.ShouldMigrate() {
+			continue
+		}
+
+		job, err := snap.JobByID(nil, alloc.Namespace, alloc.JobID)
+		if err != nil {
+			return nil, false, err
+		}
+		if job == nil || job.Stopped() {
+			return nil, false, nil
+		}
+
+		switch job.Type {
+		case structs.JobTypeSystem, structs.JobTypeSysBatch:
+			continue
+		case structs.JobTypeService:
+		default:
+			return nil, false, nil
+		}
+
+		tg := job.LookupTaskGroup(alloc.TaskGroup)
+		if tg == nil || tg.Migrate == nil {
+			return nil, false, nil
+		}
+		movable = append(movable, alloc)
+	}
+
+	sort.SliceStable(movable, func(i, j int) bool {
+		ri, rj := movable[i].ComparableResources(), movable[j].ComparableResources()
+		if ri.Flattened.Memory.MemoryMB != rj.Flattened.Memory.MemoryMB {
+			return ri.Flattened.Memory.MemoryMB > rj.Flattened.Memory.MemoryMB
+		}
+		return ri.Flattened.Cpu.CpuShares > rj.Flattened.Cpu.CpuShares
+	})
+	return movable, true, nil
+}
+
+// rebalanceTargets estimates the node each of the allocations fits on, by
+// best fit over the nodes in the datacenters and node pool of its job which
+// aren't being emptied themselves. It returns false if any of the allocations
+// doesn't fit.
+func rebalanceTargets(nodes []*rebalanceNode, source *rebalanceNode, allocs []*structs.Allocation) ([]*rebalanceNode, bool) {
+	type usage struct{ cpu, memory int64 }
+	pending := make(map[*rebalanceNode]*usage)
+	targets := make([]*rebalanceNode, len(allocs))
+
+	for i, alloc := range allocs {
+		used := alloc.ComparableResources()
+		cpu, memory := used.Flattened.Cpu.CpuShares, used.Flattened.Memory.MemoryMB
+
+		var best *rebalanceNode
+		var bestUtil float64
+		for _, n := range nodes {
+			if n == source || n.source {
+				continue
+			}
+			if !helper.SliceStringContains(alloc.Job.Datacenters, n.node.Datacenter) ||
+				!structs.NodePoolMatches(alloc.Job.NodePool, n.node.NodePool) {
+				continue
+			}
+
+			p := pending[n]
+			if p == nil {
+				p = &usage{}
+			}
+			usedCPU := n.usedCPU + p.cpu + cpu
+			usedMemory := n.usedMemory + p.memory + memory
+			if usedCPU > n.cpu || usedMemory > n.memory {
+				continue
+			}
+			if util := utilization(usedCPU, usedMemory, n.cpu, n.memory); best == nil || util > bestUtil {
+				best, bestUtil = n, util
+			}
+		}
+		if best == nil {
+			return nil, false
+		}
+
+		if pending[best] == nil {
+			pending[best] = &usage{}
+		}
+		pending[best].cpu += cpu
+		pending[best].memory += memory
+		targets[i] = best
+	}
+	return targets, true
+}
+
+// rebalanceTaskGroupFor returns the migration allowance of the task group of
+// the allocation. Like for node drains, allocations are only migrated while
+// the number of healthy allocations of the group exceeds its count minus its
+// migrate max_parallel.
+func rebalanceTaskGroupFor(snap *state.StateSnapshot, taskGroups map[string]*rebalanceTaskGroup, alloc *structs.Allocation) (*rebalanceTaskGroup, error) {
+	key := alloc.Namespace + "/" + alloc.JobID + "/" + alloc.TaskGroup
+	if tg, ok := taskGroups[key]; ok {
+		return tg, nil
+	}
+
+	job, err := snap.JobByID(nil, alloc.Namespace, alloc.JobID)
+	if err != nil {
+		return nil, err
+	}
+	allocs, err := snap.AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	group := job.LookupTaskGroup(alloc.TaskGroup)
+	healthy := 0
+	for _, a := range allocs {
+		if a.TaskGroup != alloc.TaskGroup || a.TerminalStatus() {
+			continue
+		}
+		if a.DesiredTransition.ShouldMigrate() {
+			continue
+		}
+		if a.DeploymentStatus.HasHealth() {
+			healthy++
+		}
+	}
+
+	tg := &rebalanceTaskGroup{
+		allowed: healthy - (group.Count - group.Migrate.MaxParallel),
+	}
+	taskGroups[key] = tg
+	return tg, nil
+}
+
+// rebalanceTransitionRequest returns the request marking the allocations of
+// the migrations for migration, along with an evaluation for each of their
+// jobs.
+func rebalanceTransitionRequest(snap *state.StateSnapshot, migrations []*structs.RebalanceMigration) (*structs.AllocUpdateDesiredTransitionRequest, error) {
+	transitions := make(map[string]*structs.DesiredTransition, len(migrations))
+	jobs := make(map[structs.NamespacedID]struct{})
+	var evals []*structs.Evaluation
+	now := time.Now().UTC().UnixNano()
+
+	for _, m := range migrations {
+		transitions[m.AllocID] = &structs.DesiredTransition{
+			Migrate: helper.BoolToPtr(true),
+		}
+
+		id := structs.NamespacedID{ID: m.JobID, Namespace: m.Namespace}
+		if _, ok := jobs[id]; ok {
+			continue
+		}
+		jobs[id] = struct{}{}
+
+		job, err := snap.JobByID(nil, m.Namespace, m.JobID)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			continue
+		}
+		evals = append(evals, &structs.Evaluation{
+			ID:          uuid.Generate(),
+			Namespace:   job.Namespace,
+			Priority:    job.Priority,
+			Type:        job.Type,
+			TriggeredBy: structs.EvalTriggerRebalance,
+			JobID:       job.ID,
+			Status:      structs.EvalStatusPending,
+			CreateTime:  now,
+			ModifyTime:  now,
+		})
+	}
+
+	return &structs.AllocUpdateDesiredTransitionRequest{
+		Allocs: transitions,
+		Evals:  evals,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// rebalanceTestAlloc returns a healthy allocation of the job on the node,
+// using the given memory.
+func rebalanceTestAlloc(job *structs.Job, node *structs.Node, memoryMB int64) *structs.Allocation {
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node.ID
+	alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+		Healthy: helper.BoolToPtr(true),
+	}
+	alloc.AllocatedResources.Tasks["web"].Memory.MemoryMB = memoryMB
+	return alloc
+}
+
+// upsertRebalanceTestCluster upserts two nodes: the first runs a small
+// allocation of a service job and is under-utilized, the second runs two
+// large allocations of the same job and is over half full.
+func upsertRebalanceTestCluster(t *testing.T, store *state.StateStore) (*structs.Node, *structs.Node, *structs.Job, []*structs.Allocation) {
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 100, node1))
+	require.NoError(t, store.UpsertNode(structs.MsgTypeTestSetup, 101, node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 3
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 102, job))
+
+	allocs := []*structs.Allocation{
+		rebalanceTestAlloc(job, node1, 256),
+		rebalanceTestAlloc(job, node2, 2048),
+		rebalanceTestAlloc(job, node2, 2048),
+	}
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 103, allocs))
+	return node1, node2, job, allocs
+}
+
+func TestComputeRebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node1, node2, _, allocs := upsertRebalanceTestCluster(t, store)
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	nodes, migrations, err := computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+	require.NoError(t, err)
+
+	require.Len(t, nodes, 1)
+	require.Equal(t, node1.ID, nodes[0].ID)
+	require.Less(t, nodes[0].Utilization, 0.2)
+	require.Equal(t, []*structs.RebalanceMigration{{
+		AllocID:      allocs[0].ID,
+		AllocName:    allocs[0].Name,
+		Namespace:    allocs[0].Namespace,
+		JobID:        allocs[0].JobID,
+		TaskGroup:    allocs[0].TaskGroup,
+		NodeID:       node1.ID,
+		TargetNodeID: node2.ID,
+	}}, migrations)
+
+	// A lower threshold leaves the node alone.
+	_, migrations, err = computeRebalance(snap, &structs.RebalanceConfig{UtilizationThreshold: 0.01}, 5)
+	require.NoError(t, err)
+	require.Empty(t, migrations)
+}
+
+func TestComputeRebalance_Budget(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	node1, _, job, _ := upsertRebalanceTestCluster(t, store)
+
+	// Add a second allocation on the under-utilized node.
+	job = job.Copy()
+	job.TaskGroups[0].Count = 4
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 200, job))
+	alloc := rebalanceTestAlloc(job, node1, 256)
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 201, []*structs.Allocation{alloc}))
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+
+	// The default migrate max_parallel of 1 limits the round.
+	_, migrations, err := computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+
+	// Once max_parallel allows it, the disruption budget limits the round.
+	job = job.Copy()
+	job.TaskGroups[0].Migrate.MaxParallel = 2
+	require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 202, job))
+	snap, err = store.Snapshot()
+	require.NoError(t, err)
+
+	_, migrations, err = computeRebalance(snap, &structs.RebalanceConfig{}, 1)
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+
+	_, migrations, err = computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	// Allocations already being migrated count against max_parallel.
+	migrating := alloc.Copy()
+	migrating.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 203, []*structs.Allocation{migrating}))
+	snap, err = store.Snapshot()
+	require.NoError(t, err)
+
+	_, migrations, err = computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+	require.NoError(t, err)
+	require.Len(t, migrations, 1)
+	require.NotEqual(t, alloc.ID, migrations[0].AllocID)
+}
+
+func TestComputeRebalance_Blocked(t *testing.T) {
+	ci.Parallel(t)
+
+	t.Run("batch allocation", func(t *testing.T) {
+		store := state.TestStateStore(t)
+		node1, _, _, _ := upsertRebalanceTestCluster(t, store)
+
+		batchJob := mock.BatchJob()
+		require.NoError(t, store.UpsertJob(structs.MsgTypeTestSetup, 200, batchJob))
+		alloc := rebalanceTestAlloc(batchJob, node1, 256)
+		alloc.TaskGroup = batchJob.TaskGroups[0].Name
+		require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 201, []*structs.Allocation{alloc}))
+
+		snap, err := store.Snapshot()
+		require.NoError(t, err)
+		_, migrations, err := computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+		require.NoError(t, err)
+		require.Empty(t, migrations)
+	})
+
+	t.Run("no capacity", func(t *testing.T) {
+		store := state.TestStateStore(t)
+		_, node2, job, _ := upsertRebalanceTestCluster(t, store)
+
+		alloc := rebalanceTestAlloc(job, node2, 3800)
+		require.NoError(t, store.UpsertAllocs(structs.MsgTypeTestSetup, 200, []*structs.Allocation{alloc}))
+
+		snap, err := store.Snapshot()
+		require.NoError(t, err)
+		_, migrations, err := computeRebalance(snap, &structs.RebalanceConfig{}, 5)
+		require.NoError(t, err)
+		require.Empty(t, migrations)
+	})
+}
+
+func TestRebalanceTransitionRequest(t *testing.T) {
+	ci.Parallel(t)
+
+	store := state.TestStateStore(t)
+	_, _, job, allocs := upsertRebalanceTestCluster(t, store)
+
+	snap, err := store.Snapshot()
+	require.NoError(t, err)
+	req, err := rebalanceTransitionRequest(snap, []*structs.RebalanceMigration{
+		{AllocID: allocs[0].ID, Namespace: job.Namespace, JobID: job.ID},
+		{AllocID: allocs[1].ID, Namespace: job.Namespace, JobID: job.ID},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, req.Allocs, 2)
+	require.True(t, req.Allocs[allocs[0].ID].ShouldMigrate())
+	require.Len(t, req.Evals, 1)
+	require.Equal(t, structs.EvalTriggerRebalance, req.Evals[0].TriggeredBy)
+	require.Equal(t, job.ID, req.Evals[0].JobID)
+	require.Equal(t, job.Priority, req.Evals[0].Priority)
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/hashicorp/nomad/helper/pool"
 	"github.com/hashicorp/nomad/helper/stats"
 	"github.com/hashicorp/nomad/helper/tlsutil"
+	"github.com/hashicorp/nomad/lib/auth/oidc"
 	"github.com/hashicorp/nomad/nomad/deploymentwatcher"
 	"github.com/hashicorp/nomad/nomad/drainer"
 	"github.com/hashicorp/nomad/nomad/state"
@@ -63,6 +64,7 @@ const (
 
 	raftState         = "raft/"
 	serfSnapshot      = "serf/snapshot"
+	keystoreDir       = "keystore"
 	snapshotsRetained = 2
 
 	// serverRPCCache controls how long we keep an idle connection open to a server
@@ -204,6 +206,10 @@ type Server struct {
 	// that are waiting to be brokered to a sub-scheduler
 	evalBroker *EvalBroker
 
+	// evalRateLimiter is used to limit the rate at which the job endpoints
+	// create evaluations
+	evalRateLimiter *evalRateLimiter
+
 	// periodicDispatcher is used to track and create evaluations for periodic jobs.
 	periodicDispatcher *PeriodicDispatch
 
@@ -236,6 +242,10 @@ type Server struct {
 	// aclCache is used to maintain the parsed ACL objects
 	aclCache *lru.TwoQueueCache
 
+	// oidcProviderCache is used to maintain the providers of the OIDC auth
+	// methods, so discovery is not performed on every login
+	oidcProviderCache *oidc.ProviderCache
+
 	// leaderAcl is the management ACL token that is valid when resolved by the
 	// current leader.
 	leaderAcl     string
@@ -249,6 +259,10 @@ type Server struct {
 	// Nomad router.
 	statsFetcher *StatsFetcher
 
+	// encrypter is the keyring used to encrypt and decrypt data, such as
+	// variables, with the root keys held in state.
+	encrypter *Encrypter
+
 	// EnterpriseState is used to fill in state for Pro/Ent builds
 	EnterpriseState
 
@@ -280,6 +294,8 @@ type endpoints struct {
 	Event               *Event
 	Namespace           *Namespace
 	ServiceRegistration *ServiceRegistration
+	NodePool            *NodePool
+	Variables           *Variables
 
 	// Client endpoints
 	ClientStats       *ClientStats
@@ -293,6 +309,15 @@ type endpoints struct {
 // configuration, potentially returning an error
 func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntries consul.ConfigAPI, consulACLs consul.ACLsAPI) (*Server, error) {
 
+	// Validate the scoring plugins, as the schedulers skip invalid plugins
+	if err := scheduler.ValidateScorePlugins(config.ScorePlugins); err != nil {
+		return nil, fmt.Errorf("invalid score plugin configuration: %v", err)
+	}
+
+	if err := config.EvalRateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid eval rate limit configuration: %v", err)
+	}
+
 	// Create an eval broker
 	evalBroker, err := NewEvalBroker(
 		config.EvalNackTimeout,
@@ -324,24 +349,26 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 
 	// Create the server
 	s := &Server{
-		config:           config,
-		consulCatalog:    consulCatalog,
-		connPool:         pool.NewPool(logger, serverRPCCache, serverMaxStreams, tlsWrap),
-		logger:           logger,
-		tlsWrap:          tlsWrap,
-		rpcServer:        rpc.NewServer(),
-		streamingRpcs:    structs.NewStreamingRpcRegistry(),
-		nodeConns:        make(map[string][]*nodeConnState),
-		peers:            make(map[string][]*serverParts),
-		localPeers:       make(map[raft.ServerAddress]*serverParts),
-		reassertLeaderCh: make(chan chan error),
-		reconcileCh:      make(chan serf.Member, 32),
-		eventCh:          make(chan serf.Event, 256),
-		evalBroker:       evalBroker,
-		blockedEvals:     NewBlockedEvals(evalBroker, logger),
-		rpcTLS:           incomingTLS,
-		aclCache:         aclCache,
-		workersEventCh:   make(chan interface{}, 1),
+		config:            config,
+		consulCatalog:     consulCatalog,
+		connPool:          pool.NewPool(logger, serverRPCCache, serverMaxStreams, tlsWrap),
+		logger:            logger,
+		tlsWrap:           tlsWrap,
+		rpcServer:         rpc.NewServer(),
+		streamingRpcs:     structs.NewStreamingRpcRegistry(),
+		nodeConns:         make(map[string][]*nodeConnState),
+		peers:             make(map[string][]*serverParts),
+		localPeers:        make(map[raft.ServerAddress]*serverParts),
+		reassertLeaderCh:  make(chan chan error),
+		reconcileCh:       make(chan serf.Member, 32),
+		eventCh:           make(chan serf.Event, 256),
+		evalBroker:        evalBroker,
+		blockedEvals:      NewBlockedEvals(evalBroker, logger),
+		evalRateLimiter:   newEvalRateLimiter(config.EvalRateLimit),
+		rpcTLS:            incomingTLS,
+		aclCache:          aclCache,
+		oidcProviderCache: oidc.NewProviderCache(),
+		workersEventCh:    make(chan interface{}, 1),
 	}
 
 	s.shutdownCtx, s.shutdownCancel = context.WithCancel(context.Background())
@@ -360,6 +387,18 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	// Create the node heartbeater
 	s.nodeHeartbeater = newNodeHeartbeater(s)
 
+	// Create the keyring used for encrypting variables and signing workload
+	// identities. Servers in dev mode keep their keys in memory, just like
+	// their raft state.
+	var keystorePath string
+	if !s.config.DevMode {
+		keystorePath = filepath.Join(s.config.DataDir, keystoreDir)
+	}
+	s.encrypter, err = NewEncrypter(s, keystorePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create the periodic dispatcher for launching periodic jobs.
 	s.periodicDispatcher = NewPeriodicDispatch(s.logger, s)
 
@@ -458,6 +497,9 @@ func NewServer(config *Config, consulCatalog consul.CatalogAPI, consulConfigEntr
 	// Emit raft and state store metrics
 	go s.EmitRaftStats(10*time.Second, s.shutdownCh)
 
+	// Replicate the key material of the root keys from the other servers
+	go s.replicateKeyring(s.shutdownCtx)
+
 	// Start enterprise background workers
 	s.startEnterpriseBackground()
 
@@ -1159,6 +1201,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 		s.staticEndpoints.System = &System{srv: s, logger: s.logger.Named("system")}
 		s.staticEndpoints.Search = &Search{srv: s, logger: s.logger.Named("search")}
 		s.staticEndpoints.Namespace = &Namespace{srv: s}
+		s.staticEndpoints.NodePool = &NodePool{srv: s}
+		s.staticEndpoints.Variables = &Variables{srv: s, logger: s.logger.Named("variables"), encrypter: s.encrypter}
 		s.staticEndpoints.Enterprise = NewEnterpriseEndpoints(s)
 
 		// These endpoints are dynamic because they need access to the
@@ -1206,6 +1250,8 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(s.staticEndpoints.FileSystem)
 	server.Register(s.staticEndpoints.Agent)
 	server.Register(s.staticEndpoints.Namespace)
+	server.Register(s.staticEndpoints.NodePool)
+	server.Register(s.staticEndpoints.Variables)
 
 	// Create new dynamic endpoints and add them to the RPC server.
 	alloc := &Alloc{srv: s, ctx: ctx, logger: s.logger.Named("alloc")}
@@ -1214,6 +1260,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	node := &Node{srv: s, ctx: ctx, logger: s.logger.Named("client")}
 	plan := &Plan{srv: s, ctx: ctx, logger: s.logger.Named("plan")}
 	serviceReg := &ServiceRegistration{srv: s, ctx: ctx}
+	keyring := &Keyring{srv: s, ctx: ctx, encrypter: s.encrypter}
 
 	// Register the dynamic endpoints
 	server.Register(alloc)
@@ -1222,6 +1269,7 @@ func (s *Server) setupRpcServer(server *rpc.Server, ctx *RPCContext) {
 	server.Register(node)
 	server.Register(plan)
 	_ = server.Register(serviceReg)
+	_ = server.Register(keyring)
 }
 
 // setupRaft is used to setup and initialize Raft
@@ -1245,6 +1293,8 @@ func (s *Server) setupRaft() error {
 		Region:            s.Region(),
 		EnableEventBroker: s.config.EnableEventBroker,
 		EventBufferSize:   s.config.EventBufferSize,
+		Encrypter:         s.encrypter,
+		ScorePlugins:      s.config.ScorePlugins,
 	}
 	var err error
 	s.fsm, err = NewFSM(fsmConfig)
@@ -1,7 +1,11 @@
 package nomad
 
 import (
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/armon/go-metrics"
@@ -379,6 +383,22 @@ func (s *ServiceRegistration) GetService(
 		return err
 	}
 
+	// Parse the choose parameter up front, so malformed requests do not
+	// perform any state queries. Choosing selects from all the registrations,
+	// which pagination would defeat.
+	var chooseCount int
+	var chooseKey string
+	if args.Choose != "" {
+		var err error
+		if chooseCount, chooseKey, err = parseChooseParameter(args.Choose); err != nil {
+			return structs.NewErrRPCCodedf(http.StatusBadRequest, "%v", err)
+		}
+		if args.PerPage != 0 {
+			return structs.NewErrRPCCodedf(
+				http.StatusBadRequest, "choose parameter cannot be used with pagination")
+		}
+	}
+
 	// Set up the blocking query.
 	return s.srv.blockingRPC(&blockingOptions{
 		queryOpts: &args.QueryOptions,
@@ -403,9 +423,21 @@ func (s *ServiceRegistration) GetService(
 			// Set up our output after we have checked the error.
 			var services []*structs.ServiceRegistration
 
+			// Registrations whose checks are failing are omitted unless
+			// requested, so consumers such as templates only see the
+			// registrations able to serve traffic.
+			var filters []paginator.Filter
+			if !args.IncludeUnhealthy {
+				filters = append(filters, paginator.GenericFilter{
+					Allow: func(raw interface{}) (bool, error) {
+						return raw.(*structs.ServiceRegistration).IsHealthy(), nil
+					},
+				})
+			}
+
 			// Build the paginator. This includes the function that is
 			// responsible for appending a registration to the services array.
-			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, nil, args.QueryOptions,
+			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
 				func(raw interface{}) error {
 					services = append(services, raw.(*structs.ServiceRegistration))
 					return nil
@@ -423,6 +455,11 @@ func (s *ServiceRegistration) GetService(
 					http.StatusBadRequest, "failed to read result page: %v", err)
 			}
 
+			// Select the subset of registrations of the caller if requested.
+			if args.Choose != "" {
+				services = chooseServiceRegistrations(services, chooseCount, chooseKey)
+			}
+
 			// Populate the reply.
 			reply.Services = services
 			reply.NextToken = nextToken
@@ -434,6 +471,63 @@ func (s *ServiceRegistration) GetService(
 	})
 }
 
+// parseChooseParameter parses the choose parameter of a service lookup, which
+// is in the form "<count>|<key>".
+func parseChooseParameter(choose string) (int, string, error) {
+	parts := strings.SplitN(choose, "|", 2)
+	if len(parts) != 2 {
+		return 0, "", fmt.Errorf("invalid choose parameter %q: must be in the form <count>|<key>", choose)
+	}
+	count, err := strconv.Atoi(parts[0])
+	if err != nil || count < 1 {
+		return 0, "", fmt.Errorf("invalid choose parameter %q: count must be a positive integer", choose)
+	}
+	if parts[1] == "" {
+		return 0, "", fmt.Errorf("invalid choose parameter %q: key must not be empty", choose)
+	}
+	return count, parts[1], nil
+}
+
+// chooseServiceRegistrations selects count registrations using rendezvous
+// hashing on the key. Each registration is weighted by hashing it with the
+// key, and the registrations with the highest weights are selected. The same
+// key therefore selects the same registrations, while different keys spread
+// evenly across them. When a registration is removed, only the callers which
+// had selected it select a different one.
+//
+// https://en.wikipedia.org/wiki/Rendezvous_hashing
+func chooseServiceRegistrations(
+	services []*structs.ServiceRegistration, count int, key string) []*structs.ServiceRegistration {
+
+	if count > len(services) {
+		count = len(services)
+	}
+
+	type weighted struct {
+		weight  uint64
+		service *structs.ServiceRegistration
+	}
+	candidates := make([]weighted, len(services))
+	for i, service := range services {
+		candidates[i] = weighted{weight: service.HashWith(key), service: service}
+	}
+
+	// Break ties on the ID so the selection does not depend on the order of
+	// the registrations.
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].weight != candidates[j].weight {
+			return candidates[i].weight > candidates[j].weight
+		}
+		return candidates[i].service.ID < candidates[j].service.ID
+	})
+
+	chosen := make([]*structs.ServiceRegistration, count)
+	for i := range chosen {
+		chosen[i] = candidates[i].service
+	}
+	return chosen
+}
+
 // handleMixedAuthEndpoint is a helper to handle auth on RPC endpoints that can
 // either be called by Nomad nodes, or by external clients.
 func (s *ServiceRegistration) handleMixedAuthEndpoint(args structs.QueryOptions, cap string) error {
@@ -446,7 +540,7 @@ func (s *ServiceRegistration) handleMixedAuthEndpoint(args structs.QueryOptions,
 		// Perform our ACL validation. If the object is nil, this means ACLs
 		// are not enabled, otherwise trigger the allowed namespace function.
 		if aclObj != nil {
-			if !aclObj.AllowNsOp(args.RequestNamespace(), cap) {
+			if !aclObj.AllowNsOp(args.RequestNamespace(), cap) && !s.workloadInNamespace(args) {
 				return structs.ErrPermissionDenied
 			}
 		}
@@ -475,3 +569,19 @@ func (s *ServiceRegistration) handleMixedAuthEndpoint(args structs.QueryOptions,
 
 	return nil
 }
+
+// workloadInNamespace returns whether the auth token is the workload identity
+// of an allocation in the namespace of the request. Workloads can read the
+// services of their own namespace, such as from the nomadService template
+// functions, without being granted read-job on it. The identity has already
+// been resolved, so the allocation is known to be running.
+func (s *ServiceRegistration) workloadInNamespace(args structs.QueryOptions) bool {
+	if !isWorkloadIdentity(args.AuthToken) {
+		return false
+	}
+	claims, err := s.srv.encrypter.VerifyClaim(args.AuthToken)
+	if err != nil {
+		return false
+	}
+	return claims.Namespace == args.RequestNamespace()
+}
@@ -3,6 +3,7 @@ package nomad
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-memdb"
 	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
@@ -1074,6 +1075,66 @@ func TestServiceRegistration_GetService(t *testing.T) {
 			},
 			name: "ACLs enabled using node secret",
 		},
+		{
+			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
+				return TestACLServer(t, nil)
+			},
+			testFn: func(t *testing.T, s *Server, _ *structs.ACLToken) {
+				codec := rpcClient(t, s)
+				testutil.WaitForLeader(t, s.RPC)
+				waitForKeyring(t, s)
+
+				// Generate mock services then upsert them individually using different indexes.
+				services := mock.ServiceRegistrations()
+
+				require.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 10, []*structs.ServiceRegistration{services[0]}))
+
+				require.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 20, []*structs.ServiceRegistration{services[1]}))
+
+				// Generate a running allocation in the default namespace and
+				// sign the workload identity of its task.
+				alloc := mock.Alloc()
+				alloc.ClientStatus = structs.AllocClientStatusRunning
+				require.NoError(t, s.State().UpsertJob(structs.MsgTypeTestSetup, 30, alloc.Job))
+				require.NoError(t, s.State().UpsertAllocs(structs.MsgTypeTestSetup, 40, []*structs.Allocation{alloc}))
+
+				task := alloc.Job.TaskGroups[0].Tasks[0].Name
+				identity, err := s.encrypter.SignClaims(
+					structs.NewIdentityClaims(alloc.Job, alloc, task, time.Now()))
+				require.NoError(t, err)
+
+				// The workload identity can read the services of the
+				// namespace of its allocation.
+				serviceRegReq := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: services[0].ServiceName,
+					QueryOptions: structs.QueryOptions{
+						Namespace: services[0].Namespace,
+						Region:    s.Region(),
+						AuthToken: identity,
+					},
+				}
+				var serviceRegResp structs.ServiceRegistrationByNameResponse
+				err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.NoError(t, err)
+				require.Len(t, serviceRegResp.Services, 1)
+
+				// But not those of other namespaces.
+				serviceRegReq2 := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: services[1].ServiceName,
+					QueryOptions: structs.QueryOptions{
+						Namespace: services[1].Namespace,
+						Region:    s.Region(),
+						AuthToken: identity,
+					},
+				}
+				var serviceRegResp2 structs.ServiceRegistrationByNameResponse
+				err = msgpackrpc.CallWithCodec(codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq2, &serviceRegResp2)
+				require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+			},
+			name: "ACLs enabled using workload identity",
+		},
 		{
 			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
 				server, cleanup := TestServer(t, nil)
@@ -1174,6 +1235,106 @@ func TestServiceRegistration_GetService(t *testing.T) {
 			},
 			name: "filtering and pagination",
 		},
+		{
+			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
+				server, cleanup := TestServer(t, nil)
+				return server, nil, cleanup
+			},
+			testFn: func(t *testing.T, s *Server, _ *structs.ACLToken) {
+				codec := rpcClient(t, s)
+				testutil.WaitForLeader(t, s.RPC)
+
+				// Generate two registrations of the same service, one of which
+				// is failing its checks.
+				services := mock.ServiceRegistrations()
+				unhealthy := services[0].Copy()
+				unhealthy.ID += "_unhealthy"
+				unhealthy.Status = structs.ServiceRegistrationStatusUnhealthy
+				require.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 10, []*structs.ServiceRegistration{services[0], unhealthy}))
+
+				// Unhealthy registrations are not returned by default.
+				serviceRegReq := &structs.ServiceRegistrationByNameRequest{
+					ServiceName: services[0].ServiceName,
+					QueryOptions: structs.QueryOptions{
+						Namespace: services[0].Namespace,
+						Region:    s.Region(),
+					},
+				}
+				var serviceRegResp structs.ServiceRegistrationByNameResponse
+				err := msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+				require.NoError(t, err)
+				require.ElementsMatch(t, []*structs.ServiceRegistration{services[0]}, serviceRegResp.Services)
+
+				// Unhealthy registrations are returned when requested.
+				serviceRegReq.IncludeUnhealthy = true
+				var serviceRegResp2 structs.ServiceRegistrationByNameResponse
+				err = msgpackrpc.CallWithCodec(
+					codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp2)
+				require.NoError(t, err)
+				require.ElementsMatch(t, []*structs.ServiceRegistration{services[0], unhealthy}, serviceRegResp2.Services)
+			},
+			name: "unhealthy services",
+		},
+		{
+			serverFn: func(t *testing.T) (*Server, *structs.ACLToken, func()) {
+				server, cleanup := TestServer(t, nil)
+				return server, nil, cleanup
+			},
+			testFn: func(t *testing.T, s *Server, _ *structs.ACLToken) {
+				codec := rpcClient(t, s)
+				testutil.WaitForLeader(t, s.RPC)
+
+				// Generate several registrations of the same service.
+				var services []*structs.ServiceRegistration
+				for i := 0; i < 10; i++ {
+					service := mock.ServiceRegistrations()[0]
+					service.ID = fmt.Sprintf("%s-%d", service.ID, i)
+					services = append(services, service)
+				}
+				require.NoError(t, s.fsm.State().UpsertServiceRegistrations(
+					structs.MsgTypeTestSetup, 10, services))
+
+				lookup := func(choose string, perPage int32) ([]*structs.ServiceRegistration, error) {
+					serviceRegReq := &structs.ServiceRegistrationByNameRequest{
+						ServiceName: services[0].ServiceName,
+						Choose:      choose,
+						QueryOptions: structs.QueryOptions{
+							Namespace: services[0].Namespace,
+							Region:    s.Region(),
+							PerPage:   perPage,
+						},
+					}
+					var serviceRegResp structs.ServiceRegistrationByNameResponse
+					err := msgpackrpc.CallWithCodec(
+						codec, structs.ServiceRegistrationGetServiceRPCMethod, serviceRegReq, &serviceRegResp)
+					return serviceRegResp.Services, err
+				}
+
+				// The same key chooses the same registrations.
+				chosen, err := lookup("3|abc123", 0)
+				require.NoError(t, err)
+				require.Len(t, chosen, 3)
+				chosen2, err := lookup("3|abc123", 0)
+				require.NoError(t, err)
+				require.Equal(t, chosen, chosen2)
+
+				// Choosing more registrations than exist returns them all.
+				chosen, err = lookup("20|abc123", 0)
+				require.NoError(t, err)
+				require.Len(t, chosen, 10)
+
+				// Malformed parameters and pagination are rejected.
+				_, err = lookup("abc123", 0)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), `invalid choose parameter "abc123"`)
+				_, err = lookup("3|abc123", 2)
+				require.Error(t, err)
+				require.Contains(t, err.Error(), "choose parameter cannot be used with pagination")
+			},
+			name: "choose",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -1184,3 +1345,77 @@ func TestServiceRegistration_GetService(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseChooseParameter(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		input         string
+		expectedCount int
+		expectedKey   string
+		expectedError string
+	}{
+		{input: "3|abc123", expectedCount: 3, expectedKey: "abc123"},
+		{input: "1|a|b", expectedCount: 1, expectedKey: "a|b"},
+		{input: "abc123", expectedError: "must be in the form <count>|<key>"},
+		{input: "x|abc123", expectedError: "count must be a positive integer"},
+		{input: "0|abc123", expectedError: "count must be a positive integer"},
+		{input: "3|", expectedError: "key must not be empty"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			count, key, err := parseChooseParameter(tc.input)
+			if tc.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedCount, count)
+			require.Equal(t, tc.expectedKey, key)
+		})
+	}
+}
+
+func Test_chooseServiceRegistrations(t *testing.T) {
+	ci.Parallel(t)
+
+	var services []*structs.ServiceRegistration
+	for i := 0; i < 50; i++ {
+		service := mock.ServiceRegistrations()[0]
+		service.ID = fmt.Sprintf("%s-%d", service.ID, i)
+		services = append(services, service)
+	}
+
+	// The selection does not depend on the order of the registrations.
+	chosen := chooseServiceRegistrations(services, 3, "key1")
+	require.Len(t, chosen, 3)
+
+	reversed := make([]*structs.ServiceRegistration, len(services))
+	for i, service := range services {
+		reversed[len(services)-1-i] = service
+	}
+	require.Equal(t, chosen, chooseServiceRegistrations(reversed, 3, "key1"))
+
+	// Removing a registration which was not chosen keeps the selection.
+	var remaining []*structs.ServiceRegistration
+	removed := false
+	for _, service := range services {
+		if !removed && service != chosen[0] && service != chosen[1] && service != chosen[2] {
+			removed = true
+			continue
+		}
+		remaining = append(remaining, service)
+	}
+	require.Equal(t, chosen, chooseServiceRegistrations(remaining, 3, "key1"))
+
+	// Different keys spread the selections across the registrations.
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		for _, service := range chooseServiceRegistrations(services, 3, fmt.Sprintf("key-%d", i)) {
+			seen[service.ID] = struct{}{}
+		}
+	}
+	require.Greater(t, len(seen), 25)
+}
@@ -1,8 +1,10 @@
 package state
 
 import (
+	"encoding/binary"
 	"fmt"
 	"sync"
+	"time"
 
 	memdb "github.com/hashicorp/go-memdb"
 
@@ -14,6 +16,12 @@ const (
 
 	TableNamespaces           = "namespaces"
 	TableServiceRegistrations = "service_registrations"
+	TableNodePools            = "node_pools"
+	TableRootKeyMeta          = "root_key_meta"
+	TableVariables            = "variables"
+	TableACLRoles             = "acl_roles"
+	TableACLAuthMethods       = "acl_auth_methods"
+	TableACLBindingRules      = "acl_binding_rules"
 )
 
 const (
@@ -22,6 +30,12 @@ const (
 	indexNodeID      = "node_id"
 	indexAllocID     = "alloc_id"
 	indexServiceName = "service_name"
+	indexKeyID       = "key_id"
+	indexName        = "name"
+	indexAuthMethod  = "auth_method"
+
+	indexExpiresGlobal = "expires-global"
+	indexExpiresLocal  = "expires-local"
 )
 
 var (
@@ -70,6 +84,12 @@ func init() {
 		scalingEventTableSchema,
 		namespaceTableSchema,
 		serviceRegistrationsTableSchema,
+		nodePoolTableSchema,
+		rootKeyMetaTableSchema,
+		variablesTableSchema,
+		aclRolesTableSchema,
+		aclAuthMethodsTableSchema,
+		aclBindingRulesTableSchema,
 	}...)
 }
 
@@ -816,10 +836,73 @@ func aclTokenTableSchema() *memdb.TableSchema {
 					Field: "Global",
 				},
 			},
+			indexExpiresGlobal: {
+				Name:         indexExpiresGlobal,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &ACLTokenExpirationIndex{
+					Global: true,
+				},
+			},
+			indexExpiresLocal: {
+				Name:         indexExpiresLocal,
+				AllowMissing: true,
+				Unique:       false,
+				Indexer: &ACLTokenExpirationIndex{
+					Global: false,
+				},
+			},
 		},
 	}
 }
 
+// ACLTokenExpirationIndex indexes the ACL tokens of one locality by their
+// expiration time, so that expired tokens can be found without walking the
+// entire table. Tokens without an expiration time are not indexed.
+type ACLTokenExpirationIndex struct {
+	// Global controls whether global or local tokens are indexed.
+	Global bool
+}
+
+// FromObject is used to extract an index value from an
+// object or to indicate that the index value is missing.
+func (a *ACLTokenExpirationIndex) FromObject(obj interface{}) (bool, []byte, error) {
+	token, ok := obj.(*structs.ACLToken)
+	if !ok {
+		return false, nil, fmt.Errorf("object %#v is not an ACLToken", obj)
+	}
+
+	if !token.HasExpirationTime() || token.Global != a.Global {
+		return false, nil, nil
+	}
+	return true, encodeExpirationTime(*token.ExpirationTime), nil
+}
+
+// FromArgs is used to build an exact index lookup based on arguments
+func (a *ACLTokenExpirationIndex) FromArgs(args ...interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("must provide only a single argument")
+	}
+	arg, ok := args[0].(time.Time)
+	if !ok {
+		return nil, fmt.Errorf("argument must be a time.Time: %#v", args[0])
+	}
+	return encodeExpirationTime(arg), nil
+}
+
+// encodeExpirationTime encodes the time so that the byte ordering of the
+// index matches the chronological ordering. Times before the Unix epoch are
+// encoded as the epoch.
+func encodeExpirationTime(t time.Time) []byte {
+	var unixNano uint64
+	if t.After(time.Unix(0, 0)) {
+		unixNano = uint64(t.UnixNano())
+	}
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, unixNano)
+	return buf
+}
+
 // oneTimeTokenTableSchema returns the MemDB schema for the tokens table.
 // This table is used to store one-time tokens for ACL tokens
 func oneTimeTokenTableSchema() *memdb.TableSchema {
@@ -1202,3 +1285,149 @@ func serviceRegistrationsTableSchema() *memdb.TableSchema {
 		},
 	}
 }
+
+// nodePoolTableSchema returns the MemDB schema for the node pools table.
+func nodePoolTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableNodePools,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// rootKeyMetaTableSchema returns the MemDB schema for the root key metadata
+// table. The key material itself is held in the keystore of each server.
+func rootKeyMetaTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableRootKeyMeta,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
+// variablesTableSchema returns the MemDB schema for the variables table.
+func variablesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableVariables,
+		Indexes: map[string]*memdb.IndexSchema{
+			// The path in combination with namespace forms a unique
+			// identifier for a variable. The compound index also allows
+			// prefix lookups of paths within a namespace.
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.CompoundIndex{
+					Indexes: []memdb.Indexer{
+						&memdb.StringFieldIndex{
+							Field: "Namespace",
+						},
+						&memdb.StringFieldIndex{
+							Field: "Path",
+						},
+					},
+				},
+			},
+			// The key ID index is used to find the variables encrypted with
+			// a root key, which must be re-encrypted before the key can be
+			// removed.
+			indexKeyID: {
+				Name:         indexKeyID,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "KeyID",
+				},
+			},
+		},
+	}
+}
+
+// aclRolesTableSchema returns the MemDB schema for the ACL roles table. This
+// table is used to store ACL roles, which group policies and can be linked
+// to ACL tokens.
+func aclRolesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLRoles,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			indexName: {
+				Name:         indexName,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclAuthMethodsTableSchema returns the MemDB schema for the ACL auth methods
+// table. This table is used to store the auth methods used for single sign-on,
+// which are keyed by their unique name.
+func aclAuthMethodsTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLAuthMethods,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "Name",
+				},
+			},
+		},
+	}
+}
+
+// aclBindingRulesTableSchema returns the MemDB schema for the ACL binding
+// rules table. This table is used to store the binding rules which link
+// identities authenticated by an auth method to ACL roles and policies.
+func aclBindingRulesTableSchema() *memdb.TableSchema {
+	return &memdb.TableSchema{
+		Name: TableACLBindingRules,
+		Indexes: map[string]*memdb.IndexSchema{
+			indexID: {
+				Name:         indexID,
+				AllowMissing: false,
+				Unique:       true,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "ID",
+				},
+			},
+			indexAuthMethod: {
+				Name:         indexAuthMethod,
+				AllowMissing: false,
+				Unique:       false,
+				Indexer: &memdb.StringFieldIndex{
+					Field: "AuthMethod",
+				},
+			},
+		},
+	}
+}
@@ -141,6 +141,11 @@ func NewStateStore(config *StateStoreConfig) (*StateStore, error) {
 		return nil, fmt.Errorf("enterprise state store initialization failed: %v", err)
 	}
 
+	// Initialize the state store with the built-in node pools.
+	if err := s.nodePoolInit(); err != nil {
+		return nil, fmt.Errorf("node pool state store initialization failed: %v", err)
+	}
+
 	return s, nil
 }
 
@@ -907,6 +912,12 @@ func upsertNodeTxn(txn *txn, index uint64, node *structs.Node) error {
 		node.ModifyIndex = index
 	}
 
+	// Ensure the node pool the node is registering into exists, creating it
+	// if required. Nodes are allowed to define their own pool membership.
+	if err := fetchOrCreateNodePoolTxn(txn, index, node.NodePool); err != nil {
+		return err
+	}
+
 	// Insert the node
 	if err := txn.Insert("nodes", node); err != nil {
 		return fmt.Errorf("node insert failed: %v", err)
@@ -5681,6 +5692,19 @@ func (s *StateStore) ACLTokensByGlobal(ws memdb.WatchSet, globalVal bool, sort S
 	return iter, nil
 }
 
+// ACLTokensByExpired returns an iterator over the global or local ACL tokens
+// which have an expiration time, ordered by the time they expire. The caller
+// can stop iterating once it reaches a token which has not yet expired.
+func (s *StateStore) ACLTokensByExpired(global bool) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	index := indexExpiresLocal
+	if global {
+		index = indexExpiresGlobal
+	}
+	return txn.LowerBound("acl_token", index, time.Unix(0, 0))
+}
+
 // CanBootstrapACLToken checks if bootstrapping is possible and returns the reset index
 func (s *StateStore) CanBootstrapACLToken() (bool, uint64, error) {
 	txn := s.db.ReadTxn()
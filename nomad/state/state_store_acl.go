@@ -0,0 +1,577 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertACLRoles is used to insert a number of ACL roles into the state store.
+// It uses a single write transaction for efficiency, however, any error means
+// no entries will be committed. Unless allowMissingPolicies is set, every
+// policy linked to a role must exist.
+func (s *StateStore) UpsertACLRoles(
+	msgType structs.MessageType, index uint64, roles []*structs.ACLRole, allowMissingPolicies bool) error {
+	// Grab a write transaction.
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// updated tracks whether any inserts have been made. This allows us to
+	// skip updating the index table if we do not need to.
+	var updated bool
+
+	// Iterate the array of roles. In the event of a single error, all inserts
+	// fail via the txn.Abort() defer.
+	for _, role := range roles {
+		roleUpdated, err := s.upsertACLRoleTxn(index, txn, role, allowMissingPolicies)
+		if err != nil {
+			return err
+		}
+
+		// Ensure we track whether any inserts have been made.
+		updated = updated || roleUpdated
+	}
+
+	// If we did not perform any inserts, exit early.
+	if !updated {
+		return nil
+	}
+
+	// Perform the index table update to mark the new insert.
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLRoles, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertACLRoleTxn inserts a single ACL role into the state store using the
+// provided write transaction. It is the responsibility of the caller to update
+// the index table.
+func (s *StateStore) upsertACLRoleTxn(
+	index uint64, txn *txn, role *structs.ACLRole, allowMissingPolicies bool) (bool, error) {
+	// Ensure the role hash is not zero to provide defense in depth. This
+	// should be done outside the state store, so we do not spend time here
+	// and thus Raft, when it can be avoided.
+	if len(role.Hash) == 0 {
+		role.SetHash()
+	}
+
+	// This validation also happens within the RPC handler, but Raft latency
+	// could mean that by the time the state call is invoked, another Raft
+	// update has deleted policies detailed in role. Therefore, check again
+	// while in our write txn.
+	if !allowMissingPolicies {
+		if err := s.validateACLRolePolicyLinksTxn(txn, role); err != nil {
+			return false, err
+		}
+	}
+
+	// Ensure the role name is not already in use by another role.
+	existingByName, err := txn.First(TableACLRoles, indexName, role.Name)
+	if err != nil {
+		return false, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+	if existingByName != nil && existingByName.(*structs.ACLRole).ID != role.ID {
+		return false, fmt.Errorf("ACL role with name %s already exists", role.Name)
+	}
+
+	existing, err := txn.First(TableACLRoles, indexID, role.ID)
+	if err != nil {
+		return false, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		exist := existing.(*structs.ACLRole)
+		if exist.Equal(role) {
+			return false, nil
+		}
+		role.CreateIndex = exist.CreateIndex
+		role.ModifyIndex = index
+	} else {
+		role.CreateIndex = index
+		role.ModifyIndex = index
+	}
+
+	// Insert the role into the table.
+	if err := txn.Insert(TableACLRoles, role); err != nil {
+		return false, fmt.Errorf("ACL role insert failed: %v", err)
+	}
+	return true, nil
+}
+
+// ValidateACLRolePolicyLinks ensures all ACL policies linked to from the ACL
+// role exist within state.
+func (s *StateStore) ValidateACLRolePolicyLinks(role *structs.ACLRole) error {
+	txn := s.db.ReadTxn()
+	defer txn.Abort()
+	return s.validateACLRolePolicyLinksTxn(txn, role)
+}
+
+// validateACLRolePolicyLinksTxn is the same as ValidateACLRolePolicyLinks but
+// allows callers to pass their own transaction.
+func (s *StateStore) validateACLRolePolicyLinksTxn(txn ReadTxn, role *structs.ACLRole) error {
+	for _, policyLink := range role.Policies {
+		existing, err := txn.First("acl_policy", "id", policyLink.Name)
+		if err != nil {
+			return fmt.Errorf("ACL policy lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("cannot find policy %s", policyLink.Name)
+		}
+	}
+	return nil
+}
+
+// DeleteACLRoles is responsible for batch deleting ACL roles. It uses a
+// single write transaction for efficiency, however, any error means no
+// entries will be committed. An error is produced if a role is not found
+// within state which has been passed within the array.
+func (s *StateStore) DeleteACLRoles(msgType structs.MessageType, index uint64, roleIDs []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, roleID := range roleIDs {
+		existing, err := txn.First(TableACLRoles, indexID, roleID)
+		if err != nil {
+			return fmt.Errorf("ACL role lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("ACL role not found")
+		}
+		if err := txn.Delete(TableACLRoles, existing); err != nil {
+			return fmt.Errorf("ACL role deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLRoles, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// GetACLRoles returns an iterator that contains all ACL roles stored within
+// state.
+func (s *StateStore) GetACLRoles(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	// Walk the entire table to get all ACL roles.
+	iter, err := txn.Get(TableACLRoles, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetACLRoleByID returns a single ACL role specified by the input ID. The role
+// object will be nil, if no matching entry was found; it is the responsibility
+// of the caller to check for this.
+func (s *StateStore) GetACLRoleByID(ws memdb.WatchSet, roleID string) (*structs.ACLRole, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableACLRoles, indexID, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLRole), nil
+	}
+	return nil, nil
+}
+
+// GetACLRoleByName returns a single ACL role specified by the input name. The
+// role object will be nil, if no matching entry was found; it is the
+// responsibility of the caller to check for this.
+func (s *StateStore) GetACLRoleByName(ws memdb.WatchSet, roleName string) (*structs.ACLRole, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableACLRoles, indexName, roleName)
+	if err != nil {
+		return nil, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLRole), nil
+	}
+	return nil, nil
+}
+
+// GetACLRoleByIDPrefix is used to lookup ACL roles using a prefix to match on
+// the ID.
+func (s *StateStore) GetACLRoleByIDPrefix(ws memdb.WatchSet, idPrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableACLRoles, indexID+"_prefix", idPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("ACL role lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// UpsertACLAuthMethods is used to insert a number of ACL auth methods into the
+// state store. It uses a single write transaction for efficiency, however, any
+// error means no entries will be committed.
+func (s *StateStore) UpsertACLAuthMethods(
+	msgType structs.MessageType, index uint64, authMethods []*structs.ACLAuthMethod) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// updated tracks whether any inserts have been made. This allows us to
+	// skip updating the index table if we do not need to.
+	var updated bool
+
+	for _, authMethod := range authMethods {
+		methodUpdated, err := s.upsertACLAuthMethodTxn(index, txn, authMethod)
+		if err != nil {
+			return err
+		}
+		updated = updated || methodUpdated
+	}
+
+	if !updated {
+		return nil
+	}
+
+	// Only a single auth method can be the default. This is checked once all
+	// the auth methods have been inserted, so a request can move the default
+	// from one auth method to another.
+	if err := s.validateDefaultACLAuthMethodTxn(txn); err != nil {
+		return err
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLAuthMethods, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertACLAuthMethodTxn inserts a single ACL auth method into the state store
+// using the provided write transaction. It is the responsibility of the caller
+// to update the index table.
+func (s *StateStore) upsertACLAuthMethodTxn(index uint64, txn *txn, authMethod *structs.ACLAuthMethod) (bool, error) {
+	// Ensure the auth method hash is not zero to provide defense in depth.
+	if len(authMethod.Hash) == 0 {
+		authMethod.SetHash()
+	}
+
+	existing, err := txn.First(TableACLAuthMethods, indexID, authMethod.Name)
+	if err != nil {
+		return false, fmt.Errorf("ACL auth method lookup failed: %v", err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		exist := existing.(*structs.ACLAuthMethod)
+		if exist.Equal(authMethod) {
+			return false, nil
+		}
+		authMethod.CreateIndex = exist.CreateIndex
+		authMethod.CreateTime = exist.CreateTime
+		authMethod.ModifyIndex = index
+	} else {
+		authMethod.CreateIndex = index
+		authMethod.ModifyIndex = index
+	}
+
+	if err := txn.Insert(TableACLAuthMethods, authMethod); err != nil {
+		return false, fmt.Errorf("ACL auth method insert failed: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteACLAuthMethods is responsible for batch deleting ACL auth methods. The
+// binding rules of the deleted auth methods are deleted within the same
+// transaction. An error is produced if an auth method is not found within
+// state which has been passed within the array.
+func (s *StateStore) DeleteACLAuthMethods(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	var deletedRules bool
+
+	for _, name := range names {
+		existing, err := txn.First(TableACLAuthMethods, indexID, name)
+		if err != nil {
+			return fmt.Errorf("ACL auth method lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("ACL auth method not found")
+		}
+		if err := txn.Delete(TableACLAuthMethods, existing); err != nil {
+			return fmt.Errorf("ACL auth method deletion failed: %v", err)
+		}
+
+		num, err := txn.DeleteAll(TableACLBindingRules, indexAuthMethod, name)
+		if err != nil {
+			return fmt.Errorf("ACL binding rule deletion failed: %v", err)
+		}
+		deletedRules = deletedRules || num > 0
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLAuthMethods, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	if deletedRules {
+		if err := txn.Insert(tableIndex, &IndexEntry{TableACLBindingRules, index}); err != nil {
+			return fmt.Errorf("index update failed: %v", err)
+		}
+	}
+	return txn.Commit()
+}
+
+// GetACLAuthMethods returns an iterator that contains all ACL auth methods
+// stored within state.
+func (s *StateStore) GetACLAuthMethods(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableACLAuthMethods, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL auth method lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetACLAuthMethodByName returns a single ACL auth method specified by the
+// input name. The auth method object will be nil, if no matching entry was
+// found; it is the responsibility of the caller to check for this.
+func (s *StateStore) GetACLAuthMethodByName(ws memdb.WatchSet, name string) (*structs.ACLAuthMethod, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableACLAuthMethods, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("ACL auth method lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLAuthMethod), nil
+	}
+	return nil, nil
+}
+
+// GetDefaultACLAuthMethod returns the ACL auth method marked as the default.
+// The auth method object will be nil, if no default has been set.
+func (s *StateStore) GetDefaultACLAuthMethod(ws memdb.WatchSet) (*structs.ACLAuthMethod, error) {
+	txn := s.db.ReadTxn()
+	return s.getDefaultACLAuthMethodTxn(ws, txn)
+}
+
+// validateDefaultACLAuthMethodTxn ensures at most one auth method is marked as
+// the default.
+func (s *StateStore) validateDefaultACLAuthMethodTxn(txn ReadTxn) error {
+	iter, err := txn.Get(TableACLAuthMethods, indexID)
+	if err != nil {
+		return fmt.Errorf("ACL auth method lookup failed: %v", err)
+	}
+
+	var defaultName string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		authMethod := raw.(*structs.ACLAuthMethod)
+		if !authMethod.Default {
+			continue
+		}
+		if defaultName != "" {
+			return fmt.Errorf("multiple default ACL auth methods: %s and %s", defaultName, authMethod.Name)
+		}
+		defaultName = authMethod.Name
+	}
+	return nil
+}
+
+// getDefaultACLAuthMethodTxn is the same as GetDefaultACLAuthMethod but allows
+// callers to pass their own transaction.
+func (s *StateStore) getDefaultACLAuthMethodTxn(ws memdb.WatchSet, txn ReadTxn) (*structs.ACLAuthMethod, error) {
+	iter, err := txn.Get(TableACLAuthMethods, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL auth method lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		if authMethod := raw.(*structs.ACLAuthMethod); authMethod.Default {
+			return authMethod, nil
+		}
+	}
+	return nil, nil
+}
+
+// UpsertACLBindingRules is used to insert a number of ACL binding rules into
+// the state store. It uses a single write transaction for efficiency, however,
+// any error means no entries will be committed. Unless
+// allowMissingAuthMethods is set, the auth method of every binding rule must
+// exist.
+func (s *StateStore) UpsertACLBindingRules(
+	msgType structs.MessageType, index uint64, rules []*structs.ACLBindingRule, allowMissingAuthMethods bool) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// updated tracks whether any inserts have been made. This allows us to
+	// skip updating the index table if we do not need to.
+	var updated bool
+
+	for _, rule := range rules {
+		ruleUpdated, err := s.upsertACLBindingRuleTxn(index, txn, rule, allowMissingAuthMethods)
+		if err != nil {
+			return err
+		}
+		updated = updated || ruleUpdated
+	}
+
+	if !updated {
+		return nil
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLBindingRules, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertACLBindingRuleTxn inserts a single ACL binding rule into the state
+// store using the provided write transaction. It is the responsibility of the
+// caller to update the index table.
+func (s *StateStore) upsertACLBindingRuleTxn(
+	index uint64, txn *txn, rule *structs.ACLBindingRule, allowMissingAuthMethods bool) (bool, error) {
+	// Ensure the binding rule hash is not zero to provide defense in depth.
+	if len(rule.Hash) == 0 {
+		rule.SetHash()
+	}
+
+	// This validation also happens within the RPC handler, but Raft latency
+	// could mean that by the time the state call is invoked, another Raft
+	// update has deleted the auth method. Therefore, check again while in
+	// our write txn.
+	if !allowMissingAuthMethods {
+		authMethod, err := txn.First(TableACLAuthMethods, indexID, rule.AuthMethod)
+		if err != nil {
+			return false, fmt.Errorf("ACL auth method lookup failed: %v", err)
+		}
+		if authMethod == nil {
+			return false, fmt.Errorf("ACL auth method %s not found", rule.AuthMethod)
+		}
+	}
+
+	existing, err := txn.First(TableACLBindingRules, indexID, rule.ID)
+	if err != nil {
+		return false, fmt.Errorf("ACL binding rule lookup failed: %v", err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		exist := existing.(*structs.ACLBindingRule)
+		if exist.Equal(rule) {
+			return false, nil
+		}
+		rule.CreateIndex = exist.CreateIndex
+		rule.CreateTime = exist.CreateTime
+		rule.ModifyIndex = index
+	} else {
+		rule.CreateIndex = index
+		rule.ModifyIndex = index
+	}
+
+	if err := txn.Insert(TableACLBindingRules, rule); err != nil {
+		return false, fmt.Errorf("ACL binding rule insert failed: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteACLBindingRules is responsible for batch deleting ACL binding rules.
+// It uses a single write transaction for efficiency, however, any error means
+// no entries will be committed. An error is produced if a binding rule is not
+// found within state which has been passed within the array.
+func (s *StateStore) DeleteACLBindingRules(msgType structs.MessageType, index uint64, ruleIDs []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, ruleID := range ruleIDs {
+		existing, err := txn.First(TableACLBindingRules, indexID, ruleID)
+		if err != nil {
+			return fmt.Errorf("ACL binding rule lookup failed: %v", err)
+		}
+		if existing == nil {
+			return fmt.Errorf("ACL binding rule not found")
+		}
+		if err := txn.Delete(TableACLBindingRules, existing); err != nil {
+			return fmt.Errorf("ACL binding rule deletion failed: %v", err)
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableACLBindingRules, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return txn.Commit()
+}
+
+// GetACLBindingRules returns an iterator that contains all ACL binding rules
+// stored within state.
+func (s *StateStore) GetACLBindingRules(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableACLBindingRules, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL binding rule lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetACLBindingRule returns a single ACL binding rule specified by the input
+// ID. The binding rule object will be nil, if no matching entry was found; it
+// is the responsibility of the caller to check for this.
+func (s *StateStore) GetACLBindingRule(ws memdb.WatchSet, ruleID string) (*structs.ACLBindingRule, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, existing, err := txn.FirstWatch(TableACLBindingRules, indexID, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("ACL binding rule lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing != nil {
+		return existing.(*structs.ACLBindingRule), nil
+	}
+	return nil, nil
+}
+
+// GetACLBindingRulesByAuthMethod returns an iterator with all binding rules
+// associated with the named auth method.
+func (s *StateStore) GetACLBindingRulesByAuthMethod(ws memdb.WatchSet, authMethod string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableACLBindingRules, indexAuthMethod, authMethod)
+	if err != nil {
+		return nil, fmt.Errorf("ACL binding rule lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetACLBindingRuleByIDPrefix is used to lookup ACL binding rules using a
+// prefix to match on the ID.
+func (s *StateStore) GetACLBindingRuleByIDPrefix(ws memdb.WatchSet, idPrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableACLBindingRules, indexID+"_prefix", idPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("ACL binding rule lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
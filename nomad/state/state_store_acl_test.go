@@ -0,0 +1,385 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_UpsertACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Mock the policies our roles will link to.
+	policy1 := mock.ACLPolicy()
+	policy1.Name = "foo"
+	policy2 := mock.ACLPolicy()
+	policy2.Name = "bar"
+	require.NoError(t, testState.UpsertACLPolicies(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLPolicy{policy1, policy2}))
+
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 20, mockedACLRoles, false))
+
+	// The table index should have been bumped.
+	index, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), index)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLRoles(ws)
+	require.NoError(t, err)
+
+	var found []*structs.ACLRole
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found = append(found, raw.(*structs.ACLRole))
+	}
+	require.Len(t, found, 2)
+	for _, role := range found {
+		require.Equal(t, uint64(20), role.CreateIndex)
+		require.Equal(t, uint64(20), role.ModifyIndex)
+	}
+
+	// Upserting the same roles again should not modify the index.
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 30, mockedACLRoles, false))
+	index, err = testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), index)
+
+	// Updating a role should maintain its create index.
+	updated := mockedACLRoles[0].Copy()
+	updated.Description = "updated"
+	updated.SetHash()
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLRole{updated}, false))
+
+	out, err := testState.GetACLRoleByID(ws, updated.ID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.Description)
+	require.Equal(t, uint64(20), out.CreateIndex)
+	require.Equal(t, uint64(40), out.ModifyIndex)
+
+	// A role which uses the name of another role should be rejected.
+	duplicate := mock.ACLRole()
+	duplicate.Name = mockedACLRoles[1].Name
+	err = testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 50, []*structs.ACLRole{duplicate}, false)
+	require.ErrorContains(t, err, "already exists")
+
+	// A role linking to a missing policy is rejected, unless the caller
+	// allows it.
+	missing := mock.ACLRole()
+	missing.Policies = []*structs.ACLRolePolicyLink{{Name: "baz"}}
+	missing.SetHash()
+	err = testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 60, []*structs.ACLRole{missing}, false)
+	require.ErrorContains(t, err, "cannot find policy baz")
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 60, []*structs.ACLRole{missing}, true))
+}
+
+func TestStateStore_DeleteACLRoles(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	// Deleting a role which does not exist should error and not modify the
+	// table.
+	err := testState.DeleteACLRoles(
+		structs.MsgTypeTestSetup, 20, []string{mockedACLRoles[0].ID, "not-a-role"})
+	require.ErrorContains(t, err, "ACL role not found")
+
+	out, err := testState.GetACLRoleByID(nil, mockedACLRoles[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	require.NoError(t, testState.DeleteACLRoles(
+		structs.MsgTypeTestSetup, 30, []string{mockedACLRoles[0].ID}))
+
+	out, err = testState.GetACLRoleByID(nil, mockedACLRoles[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := testState.Index(TableACLRoles)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), index)
+}
+
+func TestStateStore_GetACLRoleByName(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	ws := memdb.NewWatchSet()
+	for _, role := range mockedACLRoles {
+		out, err := testState.GetACLRoleByName(ws, role.Name)
+		require.NoError(t, err)
+		require.Equal(t, role, out)
+	}
+
+	out, err := testState.GetACLRoleByName(ws, "not-a-role")
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestStateStore_GetACLRoleByIDPrefix(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedACLRoles := []*structs.ACLRole{mock.ACLRole(), mock.ACLRole()}
+	mockedACLRoles[0].ID = "10000000-0000-0000-0000-000000000000"
+	mockedACLRoles[1].ID = "20000000-0000-0000-0000-000000000000"
+	require.NoError(t, testState.UpsertACLRoles(
+		structs.MsgTypeTestSetup, 10, mockedACLRoles, true))
+
+	iter, err := testState.GetACLRoleByIDPrefix(nil, "1")
+	require.NoError(t, err)
+
+	var found []*structs.ACLRole
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found = append(found, raw.(*structs.ACLRole))
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, mockedACLRoles[0].ID, found[0].ID)
+}
+
+func TestStateStore_UpsertACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedAuthMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, mockedAuthMethods))
+
+	// The table index should have been bumped.
+	index, err := testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLAuthMethods(ws)
+	require.NoError(t, err)
+
+	var found []*structs.ACLAuthMethod
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found = append(found, raw.(*structs.ACLAuthMethod))
+	}
+	require.Len(t, found, 2)
+	for _, authMethod := range found {
+		require.Equal(t, uint64(10), authMethod.CreateIndex)
+		require.Equal(t, uint64(10), authMethod.ModifyIndex)
+	}
+
+	// Upserting the same auth methods again should not modify the index.
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 20, mockedAuthMethods))
+	index, err = testState.Index(TableACLAuthMethods)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	// Updating an auth method should maintain its create index.
+	updated := mockedAuthMethods[0].Copy()
+	updated.MaxTokenTTL = 2 * updated.MaxTokenTTL
+	updated.SetHash()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 30, []*structs.ACLAuthMethod{updated}))
+
+	out, err := testState.GetACLAuthMethodByName(ws, updated.Name)
+	require.NoError(t, err)
+	require.Equal(t, updated.MaxTokenTTL, out.MaxTokenTTL)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(30), out.ModifyIndex)
+
+	// Only a single auth method can be the default.
+	default1 := mockedAuthMethods[0].Copy()
+	default1.Default = true
+	default1.SetHash()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLAuthMethod{default1}))
+
+	default2 := mockedAuthMethods[1].Copy()
+	default2.Default = true
+	default2.SetHash()
+	err = testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 50, []*structs.ACLAuthMethod{default2})
+	require.ErrorContains(t, err, "multiple default ACL auth methods")
+
+	// Moving the default within a single request is allowed.
+	default1 = default1.Copy()
+	default1.Default = false
+	default1.SetHash()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 50, []*structs.ACLAuthMethod{default2, default1}))
+
+	out, err = testState.GetDefaultACLAuthMethod(ws)
+	require.NoError(t, err)
+	require.Equal(t, default2.Name, out.Name)
+}
+
+func TestStateStore_DeleteACLAuthMethods(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedAuthMethods := []*structs.ACLAuthMethod{mock.ACLAuthMethod(), mock.ACLAuthMethod()}
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, mockedAuthMethods))
+
+	rule := mock.ACLBindingRule()
+	rule.AuthMethod = mockedAuthMethods[0].Name
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 20, []*structs.ACLBindingRule{rule}, false))
+
+	// Deleting an auth method which does not exist should error and not
+	// modify the table.
+	err := testState.DeleteACLAuthMethods(
+		structs.MsgTypeTestSetup, 30, []string{mockedAuthMethods[0].Name, "not-an-auth-method"})
+	require.ErrorContains(t, err, "ACL auth method not found")
+
+	out, err := testState.GetACLAuthMethodByName(nil, mockedAuthMethods[0].Name)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	// Deleting the auth method should also delete its binding rules.
+	require.NoError(t, testState.DeleteACLAuthMethods(
+		structs.MsgTypeTestSetup, 40, []string{mockedAuthMethods[0].Name}))
+
+	out, err = testState.GetACLAuthMethodByName(nil, mockedAuthMethods[0].Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	ruleOut, err := testState.GetACLBindingRule(nil, rule.ID)
+	require.NoError(t, err)
+	require.Nil(t, ruleOut)
+
+	for _, table := range []string{TableACLAuthMethods, TableACLBindingRules} {
+		index, err := testState.Index(table)
+		require.NoError(t, err)
+		require.Equal(t, uint64(40), index)
+	}
+}
+
+func TestStateStore_UpsertACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	authMethod := mock.ACLAuthMethod()
+	require.NoError(t, testState.UpsertACLAuthMethods(
+		structs.MsgTypeTestSetup, 10, []*structs.ACLAuthMethod{authMethod}))
+
+	mockedRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	for _, rule := range mockedRules {
+		rule.AuthMethod = authMethod.Name
+		rule.SetHash()
+	}
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 20, mockedRules, false))
+
+	// The table index should have been bumped.
+	index, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), index)
+
+	ws := memdb.NewWatchSet()
+	iter, err := testState.GetACLBindingRulesByAuthMethod(ws, authMethod.Name)
+	require.NoError(t, err)
+
+	var found []*structs.ACLBindingRule
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found = append(found, raw.(*structs.ACLBindingRule))
+	}
+	require.Len(t, found, 2)
+
+	// Upserting the same binding rules again should not modify the index.
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 30, mockedRules, false))
+	index, err = testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(20), index)
+
+	// Updating a binding rule should maintain its create index.
+	updated := mockedRules[0].Copy()
+	updated.Description = "updated"
+	updated.SetHash()
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 40, []*structs.ACLBindingRule{updated}, false))
+
+	out, err := testState.GetACLBindingRule(ws, updated.ID)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.Description)
+	require.Equal(t, uint64(20), out.CreateIndex)
+	require.Equal(t, uint64(40), out.ModifyIndex)
+
+	// A binding rule of a missing auth method is rejected, unless the caller
+	// allows it.
+	missing := mock.ACLBindingRule()
+	missing.AuthMethod = "not-an-auth-method"
+	missing.SetHash()
+	err = testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 50, []*structs.ACLBindingRule{missing}, false)
+	require.ErrorContains(t, err, "ACL auth method not-an-auth-method not found")
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 50, []*structs.ACLBindingRule{missing}, true))
+}
+
+func TestStateStore_DeleteACLBindingRules(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, mockedRules, true))
+
+	// Deleting a binding rule which does not exist should error and not
+	// modify the table.
+	err := testState.DeleteACLBindingRules(
+		structs.MsgTypeTestSetup, 20, []string{mockedRules[0].ID, "not-a-rule"})
+	require.ErrorContains(t, err, "ACL binding rule not found")
+
+	out, err := testState.GetACLBindingRule(nil, mockedRules[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	require.NoError(t, testState.DeleteACLBindingRules(
+		structs.MsgTypeTestSetup, 30, []string{mockedRules[0].ID}))
+
+	out, err = testState.GetACLBindingRule(nil, mockedRules[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := testState.Index(TableACLBindingRules)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), index)
+}
+
+func TestStateStore_GetACLBindingRuleByIDPrefix(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	mockedRules := []*structs.ACLBindingRule{mock.ACLBindingRule(), mock.ACLBindingRule()}
+	mockedRules[0].ID = "10000000-0000-0000-0000-000000000000"
+	mockedRules[1].ID = "20000000-0000-0000-0000-000000000000"
+	require.NoError(t, testState.UpsertACLBindingRules(
+		structs.MsgTypeTestSetup, 10, mockedRules, true))
+
+	iter, err := testState.GetACLBindingRuleByIDPrefix(nil, "1")
+	require.NoError(t, err)
+
+	var found []*structs.ACLBindingRule
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		found = append(found, raw.(*structs.ACLBindingRule))
+	}
+	require.Len(t, found, 1)
+	require.Equal(t, mockedRules[0].ID, found[0].ID)
+}
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// UpsertRootKeyMeta is used to insert or update the metadata of a root key in
+// the state store. If the key is active, all other active keys are marked as
+// inactive, so there is only ever a single key used for encryption. When
+// rekey is set, the other keys are instead marked as rekeying, so their data
+// is re-encrypted with the new key.
+func (s *StateStore) UpsertRootKeyMeta(msgType structs.MessageType, index uint64, keyMeta *structs.RootKeyMeta, rekey bool) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	// Copy the metadata so that we do not modify the object held by the
+	// caller, which may be the raft log entry.
+	keyMeta = keyMeta.Copy()
+
+	existing, err := txn.First(TableRootKeyMeta, indexID, keyMeta.KeyID)
+	if err != nil {
+		return fmt.Errorf("root key metadata lookup failed: %v", err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		keyMeta.CreateIndex = existing.(*structs.RootKeyMeta).CreateIndex
+	} else {
+		keyMeta.CreateIndex = index
+	}
+	keyMeta.ModifyIndex = index
+
+	if keyMeta.Active() {
+		if err := s.deactivateRootKeyMetaTxn(txn, index, keyMeta.KeyID, rekey); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(TableRootKeyMeta, keyMeta); err != nil {
+		return fmt.Errorf("root key metadata insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRootKeyMeta, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// deactivateRootKeyMetaTxn marks all root keys, other than the key with the
+// given ID, as inactive or rekeying. Keys which are already being rekeyed
+// are left alone, as they still have data encrypted with them.
+func (s *StateStore) deactivateRootKeyMetaTxn(txn *txn, index uint64, keepKeyID string, rekey bool) error {
+	iter, err := txn.Get(TableRootKeyMeta, indexID)
+	if err != nil {
+		return fmt.Errorf("root key metadata lookup failed: %v", err)
+	}
+
+	newState := structs.RootKeyStateInactive
+	if rekey {
+		newState = structs.RootKeyStateRekeying
+	}
+
+	var updates []*structs.RootKeyMeta
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+		if keyMeta.KeyID == keepKeyID || keyMeta.State == newState || keyMeta.Rekeying() {
+			continue
+		}
+		updates = append(updates, keyMeta)
+	}
+
+	for _, keyMeta := range updates {
+		keyMeta = keyMeta.Copy()
+		keyMeta.State = newState
+		keyMeta.ModifyIndex = index
+		if err := txn.Insert(TableRootKeyMeta, keyMeta); err != nil {
+			return fmt.Errorf("root key metadata insert failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// DeleteRootKeyMeta is used to delete the metadata of a root key from the
+// state store.
+func (s *StateStore) DeleteRootKeyMeta(msgType structs.MessageType, index uint64, keyID string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	existing, err := txn.First(TableRootKeyMeta, indexID, keyID)
+	if err != nil {
+		return fmt.Errorf("root key metadata lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("root key %s not found", keyID)
+	}
+
+	if err := txn.Delete(TableRootKeyMeta, existing); err != nil {
+		return fmt.Errorf("root key metadata delete failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableRootKeyMeta, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// RootKeyMetas returns an iterator over the metadata of all root keys held
+// within state.
+func (s *StateStore) RootKeyMetas(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableRootKeyMeta, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("root key metadata lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// RootKeyMetaByID returns the metadata of the root key that matches the given
+// ID. The metadata will be nil if no matching entry was found; it is the
+// responsibility of the caller to check for this.
+func (s *StateStore) RootKeyMetaByID(ws memdb.WatchSet, id string) (*structs.RootKeyMeta, error) {
+	txn := s.db.ReadTxn()
+
+	watchCh, raw, err := txn.FirstWatch(TableRootKeyMeta, indexID, id)
+	if err != nil {
+		return nil, fmt.Errorf("root key metadata lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if raw == nil {
+		return nil, nil
+	}
+	return raw.(*structs.RootKeyMeta), nil
+}
+
+// GetActiveRootKeyMeta returns the metadata of the root key currently used
+// for encryption. The metadata will be nil if no active key exists; it is the
+// responsibility of the caller to check for this.
+func (s *StateStore) GetActiveRootKeyMeta(ws memdb.WatchSet) (*structs.RootKeyMeta, error) {
+	iter, err := s.RootKeyMetas(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		keyMeta := raw.(*structs.RootKeyMeta)
+		if keyMeta.Active() {
+			return keyMeta, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,231 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// nodePoolInit creates the built-in node pools that must always be present
+// in the cluster. This is safe to do every time we create the state store.
+// The built-in pools cannot be modified, so every server will hold the same
+// objects, and a snapshot restore will override them with identical copies.
+func (s *StateStore) nodePoolInit() error {
+	allNodePool := &structs.NodePool{
+		Name:        structs.NodePoolAll,
+		Description: structs.NodePoolAllDescription,
+	}
+	defaultNodePool := &structs.NodePool{
+		Name:        structs.NodePoolDefault,
+		Description: structs.NodePoolDefaultDescription,
+	}
+
+	txn := s.db.WriteTxn(1)
+	defer txn.Abort()
+
+	for _, pool := range []*structs.NodePool{allNodePool, defaultNodePool} {
+		if err := s.upsertNodePoolTxn(txn, 1, pool); err != nil {
+			return fmt.Errorf("inserting built-in node pool failed: %v", err)
+		}
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, 1}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// NodePools returns an iterator over all node pools stored within state.
+func (s *StateStore) NodePools(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodePools, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// NodePoolsByNamePrefix returns an iterator over all node pools whose name
+// begins with the provided prefix.
+func (s *StateStore) NodePoolsByNamePrefix(ws memdb.WatchSet, namePrefix string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableNodePools, indexID+"_prefix", namePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// NodePoolByName returns the node pool that matches the given name. The pool
+// will be nil if no matching entry was found; it is the responsibility of the
+// caller to check for this.
+func (s *StateStore) NodePoolByName(ws memdb.WatchSet, name string) (*structs.NodePool, error) {
+	txn := s.db.ReadTxn()
+	return s.nodePoolByNameTxn(ws, txn, name)
+}
+
+func (s *StateStore) nodePoolByNameTxn(ws memdb.WatchSet, txn ReadTxn, name string) (*structs.NodePool, error) {
+	watchCh, existing, err := txn.FirstWatch(TableNodePools, indexID, name)
+	if err != nil {
+		return nil, fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if existing == nil {
+		return nil, nil
+	}
+	return existing.(*structs.NodePool), nil
+}
+
+// UpsertNodePools is used to insert or update a number of node pools into the
+// state store. It uses a single write transaction for efficiency, however, any
+// error means no entries will be committed.
+func (s *StateStore) UpsertNodePools(msgType structs.MessageType, index uint64, pools []*structs.NodePool) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, pool := range pools {
+		if err := s.upsertNodePoolTxn(txn, index, pool); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+// upsertNodePoolTxn inserts a single node pool into the state store using the
+// provided write transaction. It is the responsibility of the caller to update
+// the index table.
+func (s *StateStore) upsertNodePoolTxn(txn *txn, index uint64, pool *structs.NodePool) error {
+	if pool == nil {
+		return nil
+	}
+
+	existing, err := txn.First(TableNodePools, indexID, pool.Name)
+	if err != nil {
+		return fmt.Errorf("node pool lookup failed: %v", err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		exist := existing.(*structs.NodePool)
+		pool.CreateIndex = exist.CreateIndex
+		pool.ModifyIndex = index
+	} else {
+		pool.CreateIndex = index
+		pool.ModifyIndex = index
+	}
+
+	if err := txn.Insert(TableNodePools, pool); err != nil {
+		return fmt.Errorf("node pool insert failed: %v", err)
+	}
+	return nil
+}
+
+// fetchOrCreateNodePoolTxn ensures a node pool with the given name exists,
+// creating an empty pool if required. An empty name is treated as the
+// default pool which always exists. The index table is updated when a new
+// pool is created.
+func fetchOrCreateNodePoolTxn(txn *txn, index uint64, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	existing, err := txn.First(TableNodePools, indexID, name)
+	if err != nil {
+		return fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	if existing != nil {
+		return nil
+	}
+
+	pool := &structs.NodePool{
+		Name:        name,
+		CreateIndex: index,
+		ModifyIndex: index,
+	}
+	if err := txn.Insert(TableNodePools, pool); err != nil {
+		return fmt.Errorf("node pool insert failed: %v", err)
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+	return nil
+}
+
+// DeleteNodePools removes the named node pools from the state store. Built-in
+// pools cannot be deleted, and a pool cannot be deleted while nodes are still
+// members of it or non-terminal jobs still target it. If any pool fails to be
+// deleted, none of them are.
+func (s *StateStore) DeleteNodePools(msgType structs.MessageType, index uint64, names []string) error {
+	txn := s.db.WriteTxnMsgT(msgType, index)
+	defer txn.Abort()
+
+	for _, name := range names {
+		if err := s.deleteNodePoolTxn(txn, name); err != nil {
+			return err
+		}
+	}
+
+	if err := txn.Insert(tableIndex, &IndexEntry{TableNodePools, index}); err != nil {
+		return fmt.Errorf("index update failed: %v", err)
+	}
+
+	return txn.Commit()
+}
+
+func (s *StateStore) deleteNodePoolTxn(txn *txn, name string) error {
+	if structs.IsBuiltInNodePool(name) {
+		return fmt.Errorf("built-in node pool %q can not be deleted", name)
+	}
+
+	existing, err := txn.First(TableNodePools, indexID, name)
+	if err != nil {
+		return fmt.Errorf("node pool lookup failed: %v", err)
+	}
+	if existing == nil {
+		return fmt.Errorf("node pool %q not found", name)
+	}
+
+	// Ensure no nodes are still registered into the pool.
+	nodeIter, err := txn.Get("nodes", indexID)
+	if err != nil {
+		return fmt.Errorf("node lookup failed: %v", err)
+	}
+	for raw := nodeIter.Next(); raw != nil; raw = nodeIter.Next() {
+		node := raw.(*structs.Node)
+		if node.NodePool == name {
+			return fmt.Errorf("node pool %q has at least one node %q. "+
+				"All nodes must be removed from the pool before it can be deleted", name, node.ID)
+		}
+	}
+
+	// Ensure no non-terminal jobs target the pool.
+	jobIter, err := txn.Get("jobs", indexID)
+	if err != nil {
+		return fmt.Errorf("job lookup failed: %v", err)
+	}
+	for raw := jobIter.Next(); raw != nil; raw = jobIter.Next() {
+		job := raw.(*structs.Job)
+		if job.NodePool == name && job.Status != structs.JobStatusDead {
+			return fmt.Errorf("node pool %q is used by at least one non-terminal job %q. "+
+				"All jobs must be terminal before the pool can be deleted", name, job.NamespacedID())
+		}
+	}
+
+	if err := txn.Delete(TableNodePools, existing); err != nil {
+		return fmt.Errorf("node pool deletion failed: %v", err)
+	}
+	return nil
+}
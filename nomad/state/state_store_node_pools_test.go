@@ -0,0 +1,129 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_NodePoolInit(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// The built-in node pools should always be present.
+	for _, name := range []string{structs.NodePoolAll, structs.NodePoolDefault} {
+		pool, err := testState.NodePoolByName(nil, name)
+		require.NoError(t, err)
+		require.NotNil(t, pool, name)
+		require.True(t, pool.IsBuiltIn())
+	}
+}
+
+func TestStateStore_UpsertNodePools(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	pool1, pool2 := mock.NodePool(), mock.NodePool()
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool1, pool2}))
+
+	index, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	out, err := testState.NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(10), out.ModifyIndex)
+
+	// Updating a pool maintains its create index.
+	update := pool1.Copy()
+	update.Description = "updated"
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 20, []*structs.NodePool{update}))
+
+	out, err = testState.NodePoolByName(nil, pool1.Name)
+	require.NoError(t, err)
+	require.Equal(t, "updated", out.Description)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(20), out.ModifyIndex)
+
+	// The built-in pools plus the two new ones should be listed.
+	iter, err := testState.NodePools(memdb.NewWatchSet())
+	require.NoError(t, err)
+	var count int
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		count++
+	}
+	require.Equal(t, 4, count)
+
+	// Prefix lookups only return matching pools.
+	iter, err = testState.NodePoolsByNamePrefix(memdb.NewWatchSet(), pool2.Name[:10])
+	require.NoError(t, err)
+	raw := iter.Next()
+	require.NotNil(t, raw)
+	require.Equal(t, pool2.Name, raw.(*structs.NodePool).Name)
+	require.Nil(t, iter.Next())
+}
+
+func TestStateStore_UpsertNode_CreatesNodePool(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	node := mock.Node()
+	node.NodePool = "new-pool"
+	require.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 10, node))
+
+	pool, err := testState.NodePoolByName(nil, "new-pool")
+	require.NoError(t, err)
+	require.NotNil(t, pool)
+	require.Equal(t, uint64(10), pool.CreateIndex)
+
+	index, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+}
+
+func TestStateStore_DeleteNodePools(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	pool := mock.NodePool()
+	require.NoError(t, testState.UpsertNodePools(structs.MsgTypeTestSetup, 10, []*structs.NodePool{pool}))
+
+	// Built-in pools cannot be deleted.
+	err := testState.DeleteNodePools(structs.MsgTypeTestSetup, 20, []string{structs.NodePoolDefault})
+	require.ErrorContains(t, err, "can not be deleted")
+
+	// Unknown pools cannot be deleted.
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 20, []string{"unknown"})
+	require.ErrorContains(t, err, "not found")
+
+	// Pools with nodes cannot be deleted.
+	node := mock.Node()
+	node.NodePool = pool.Name
+	require.NoError(t, testState.UpsertNode(structs.MsgTypeTestSetup, 30, node))
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 40, []string{pool.Name})
+	require.ErrorContains(t, err, "has at least one node")
+	require.NoError(t, testState.DeleteNode(structs.MsgTypeTestSetup, 50, []string{node.ID}))
+
+	// Pools with non-terminal jobs cannot be deleted.
+	job := mock.Job()
+	job.NodePool = pool.Name
+	require.NoError(t, testState.UpsertJob(structs.MsgTypeTestSetup, 60, job))
+	err = testState.DeleteNodePools(structs.MsgTypeTestSetup, 70, []string{pool.Name})
+	require.ErrorContains(t, err, "non-terminal job")
+	require.NoError(t, testState.DeleteJob(80, job.Namespace, job.ID))
+
+	// The pool can now be deleted.
+	require.NoError(t, testState.DeleteNodePools(structs.MsgTypeTestSetup, 90, []string{pool.Name}))
+	out, err := testState.NodePoolByName(nil, pool.Name)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err := testState.Index(TableNodePools)
+	require.NoError(t, err)
+	require.Equal(t, uint64(90), index)
+}
@@ -573,3 +573,95 @@ func TestStateStore_ServiceRegistrationRestore(t *testing.T) {
 		require.Equal(t, serviceRegs[i], out)
 	}
 }
+
+func TestStateStore_VariablesRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	// Set up our test variables and index.
+	expectedIndex := uint64(13)
+	svs := []*structs.VariableEncrypted{mock.VariableEncrypted(), mock.VariableEncrypted()}
+
+	restore, err := testState.Restore()
+	require.NoError(t, err)
+
+	for _, sv := range svs {
+		sv.ModifyIndex = expectedIndex
+		sv.CreateIndex = expectedIndex
+		require.NoError(t, restore.VariablesRestore(sv))
+	}
+	require.NoError(t, restore.Commit())
+
+	ws := memdb.NewWatchSet()
+	for _, sv := range svs {
+		out, err := testState.GetVariable(ws, sv.Namespace, sv.Path)
+		require.NoError(t, err)
+		require.Equal(t, sv, out)
+	}
+}
+
+func TestStateStore_RootKeyMetaRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	keyMeta := mock.RootKey().Meta
+	keyMeta.CreateIndex = 13
+	keyMeta.ModifyIndex = 13
+
+	restore, err := testState.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.RootKeyMetaRestore(keyMeta))
+	require.NoError(t, restore.Commit())
+
+	out, err := testState.RootKeyMetaByID(memdb.NewWatchSet(), keyMeta.KeyID)
+	require.NoError(t, err)
+	require.Equal(t, keyMeta, out)
+}
+
+func TestStateStore_ACLRoleRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	role := mock.ACLRole()
+
+	restore, err := testState.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.ACLRoleRestore(role))
+	require.NoError(t, restore.Commit())
+
+	out, err := testState.GetACLRoleByID(memdb.NewWatchSet(), role.ID)
+	require.NoError(t, err)
+	require.Equal(t, role, out)
+}
+
+func TestStateStore_ACLAuthMethodRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	authMethod := mock.ACLAuthMethod()
+
+	restore, err := testState.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.ACLAuthMethodRestore(authMethod))
+	require.NoError(t, restore.Commit())
+
+	out, err := testState.GetACLAuthMethodByName(memdb.NewWatchSet(), authMethod.Name)
+	require.NoError(t, err)
+	require.Equal(t, authMethod, out)
+}
+
+func TestStateStore_ACLBindingRuleRestore(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	rule := mock.ACLBindingRule()
+
+	restore, err := testState.Restore()
+	require.NoError(t, err)
+	require.NoError(t, restore.ACLBindingRuleRestore(rule))
+	require.NoError(t, restore.Commit())
+
+	out, err := testState.GetACLBindingRule(memdb.NewWatchSet(), rule.ID)
+	require.NoError(t, err)
+	require.Equal(t, rule, out)
+}
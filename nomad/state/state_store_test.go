@@ -8775,6 +8775,68 @@ func TestStateStore_ACLTokensByGlobal(t *testing.T) {
 	})
 }
 
+func TestStateStore_ACLTokensByExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	state := testStateStore(t)
+	now := time.Now().UTC()
+
+	// Create local and global tokens expiring at different times, inserted
+	// out of order, along with tokens which do not expire.
+	var localTokens, globalTokens []*structs.ACLToken
+	for _, offset := range []time.Duration{time.Hour, -time.Hour, time.Minute, -time.Minute} {
+		expirationTime := now.Add(offset)
+
+		local := mock.ACLToken()
+		local.ExpirationTime = &expirationTime
+		localTokens = append(localTokens, local)
+
+		global := mock.ACLToken()
+		global.Global = true
+		global.ExpirationTime = &expirationTime
+		globalTokens = append(globalTokens, global)
+	}
+	noExpiryLocal := mock.ACLToken()
+	noExpiryGlobal := mock.ACLToken()
+	noExpiryGlobal.Global = true
+
+	tokens := append([]*structs.ACLToken{noExpiryLocal, noExpiryGlobal}, localTokens...)
+	tokens = append(tokens, globalTokens...)
+	require.NoError(t, state.UpsertACLTokens(structs.MsgTypeTestSetup, 1000, tokens))
+
+	gatherAccessorIDs := func(iter memdb.ResultIterator) []string {
+		var ids []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			ids = append(ids, raw.(*structs.ACLToken).AccessorID)
+		}
+		return ids
+	}
+
+	// The tokens should be ordered by expiration time, without the tokens of
+	// the other locality or which do not expire.
+	iter, err := state.ACLTokensByExpired(false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		localTokens[1].AccessorID, localTokens[3].AccessorID,
+		localTokens[2].AccessorID, localTokens[0].AccessorID,
+	}, gatherAccessorIDs(iter))
+
+	iter, err = state.ACLTokensByExpired(true)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		globalTokens[1].AccessorID, globalTokens[3].AccessorID,
+		globalTokens[2].AccessorID, globalTokens[0].AccessorID,
+	}, gatherAccessorIDs(iter))
+
+	// Deleted tokens should be removed from the index.
+	require.NoError(t, state.DeleteACLTokens(structs.MsgTypeTestSetup, 1001,
+		[]string{localTokens[1].AccessorID}))
+	iter, err = state.ACLTokensByExpired(false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		localTokens[3].AccessorID, localTokens[2].AccessorID, localTokens[0].AccessorID,
+	}, gatherAccessorIDs(iter))
+}
 func TestStateStore_OneTimeTokens(t *testing.T) {
 	ci.Parallel(t)
 	index := uint64(100)
@@ -0,0 +1,238 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// GetVariables returns an iterator over all variables held within state.
+func (s *StateStore) GetVariables(ws memdb.WatchSet) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariables, indexID)
+	if err != nil {
+		return nil, fmt.Errorf("variables lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetVariablesByKeyID returns an iterator over all variables that were
+// encrypted with the root key with the given ID.
+func (s *StateStore) GetVariablesByKeyID(ws memdb.WatchSet, keyID string) (memdb.ResultIterator, error) {
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariables, indexKeyID, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("variables lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetVariablesByNamespace returns an iterator over all variables within the
+// given namespace.
+func (s *StateStore) GetVariablesByNamespace(ws memdb.WatchSet, namespace string) (memdb.ResultIterator, error) {
+	return s.GetVariablesByNamespaceAndPrefix(ws, namespace, "")
+}
+
+// GetVariablesByNamespaceAndPrefix returns an iterator over all variables
+// within the given namespace whose path begins with the given prefix.
+func (s *StateStore) GetVariablesByNamespaceAndPrefix(
+	ws memdb.WatchSet, namespace, prefix string) (memdb.ResultIterator, error) {
+
+	txn := s.db.ReadTxn()
+
+	iter, err := txn.Get(TableVariables, indexID+"_prefix", namespace, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("variables lookup failed: %v", err)
+	}
+	ws.Add(iter.WatchCh())
+
+	return iter, nil
+}
+
+// GetVariable returns the variable stored at the given namespace and path.
+// The variable will be nil if no matching entry was found; it is the
+// responsibility of the caller to check for this.
+func (s *StateStore) GetVariable(
+	ws memdb.WatchSet, namespace, path string) (*structs.VariableEncrypted, error) {
+
+	txn := s.db.ReadTxn()
+	return s.getVariableTxn(ws, txn, namespace, path)
+}
+
+func (s *StateStore) getVariableTxn(
+	ws memdb.WatchSet, txn ReadTxn, namespace, path string) (*structs.VariableEncrypted, error) {
+
+	watchCh, raw, err := txn.FirstWatch(TableVariables, indexID, namespace, path)
+	if err != nil {
+		return nil, fmt.Errorf("variable lookup failed: %v", err)
+	}
+	ws.Add(watchCh)
+
+	if raw == nil {
+		return nil, nil
+	}
+	return raw.(*structs.VariableEncrypted), nil
+}
+
+// VarSet is used to unconditionally insert or update a variable.
+func (s *StateStore) VarSet(index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	txn := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, index)
+	defer txn.Abort()
+
+	resp := s.varSetTxn(txn, index, req)
+	if resp.IsError() {
+		return resp
+	}
+	if err := txn.Commit(); err != nil {
+		return req.ErrorResponse(index, err)
+	}
+	return resp
+}
+
+// VarSetCAS is used to insert or update a variable, but only if its current
+// modify index matches the one held in the request. A request modify index of
+// zero means the variable must not exist.
+func (s *StateStore) VarSetCAS(index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	txn := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, index)
+	defer txn.Abort()
+
+	if conflict, err := s.varCASConflictTxn(txn, req); err != nil {
+		return req.ErrorResponse(index, err)
+	} else if conflict != nil {
+		return req.ConflictResponse(index, conflict)
+	}
+
+	resp := s.varSetTxn(txn, index, req)
+	if resp.IsError() {
+		return resp
+	}
+	if err := txn.Commit(); err != nil {
+		return req.ErrorResponse(index, err)
+	}
+	return resp
+}
+
+// varSetTxn inserts or updates the variable held in the request using the
+// provided write transaction, and updates the index table.
+func (s *StateStore) varSetTxn(txn *txn, index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	// Copy the variable so that we do not modify the object held by the
+	// caller, which may be the raft log entry.
+	sv := req.Var.Copy()
+
+	existing, err := s.getVariableTxn(nil, txn, sv.Namespace, sv.Path)
+	if err != nil {
+		return req.ErrorResponse(index, err)
+	}
+
+	// Set up the indexes correctly to ensure existing indexes are maintained.
+	if existing != nil {
+		sv.CreateIndex = existing.CreateIndex
+		sv.CreateTime = existing.CreateTime
+	} else {
+		sv.CreateIndex = index
+	}
+	sv.ModifyIndex = index
+
+	if err := txn.Insert(TableVariables, sv); err != nil {
+		return req.ErrorResponse(index, fmt.Errorf("variable insert failed: %v", err))
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableVariables, index}); err != nil {
+		return req.ErrorResponse(index, fmt.Errorf("index update failed: %v", err))
+	}
+
+	return req.SuccessResponse(index, sv.VariableMetadata.Copy())
+}
+
+// VarDelete is used to unconditionally delete a variable. Deleting a
+// variable that does not exist is not an error.
+func (s *StateStore) VarDelete(index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	txn := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, index)
+	defer txn.Abort()
+
+	resp := s.varDeleteTxn(txn, index, req)
+	if resp.IsError() {
+		return resp
+	}
+	if err := txn.Commit(); err != nil {
+		return req.ErrorResponse(index, err)
+	}
+	return resp
+}
+
+// VarDeleteCAS is used to delete a variable, but only if its current modify
+// index matches the one held in the request. A request modify index of zero
+// means the variable must not exist.
+func (s *StateStore) VarDeleteCAS(index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	txn := s.db.WriteTxnMsgT(structs.VarApplyStateRequestType, index)
+	defer txn.Abort()
+
+	if conflict, err := s.varCASConflictTxn(txn, req); err != nil {
+		return req.ErrorResponse(index, err)
+	} else if conflict != nil {
+		return req.ConflictResponse(index, conflict)
+	}
+
+	resp := s.varDeleteTxn(txn, index, req)
+	if resp.IsError() {
+		return resp
+	}
+	if err := txn.Commit(); err != nil {
+		return req.ErrorResponse(index, err)
+	}
+	return resp
+}
+
+// varDeleteTxn deletes the variable identified by the request using the
+// provided write transaction, and updates the index table.
+func (s *StateStore) varDeleteTxn(txn *txn, index uint64, req *structs.VarApplyStateRequest) *structs.VarApplyStateResponse {
+	existing, err := s.getVariableTxn(nil, txn, req.Var.Namespace, req.Var.Path)
+	if err != nil {
+		return req.ErrorResponse(index, err)
+	}
+	if existing == nil {
+		return req.SuccessResponse(index, nil)
+	}
+
+	if err := txn.Delete(TableVariables, existing); err != nil {
+		return req.ErrorResponse(index, fmt.Errorf("variable delete failed: %v", err))
+	}
+	if err := txn.Insert(tableIndex, &IndexEntry{TableVariables, index}); err != nil {
+		return req.ErrorResponse(index, fmt.Errorf("index update failed: %v", err))
+	}
+
+	return req.SuccessResponse(index, nil)
+}
+
+// varCASConflictTxn checks the current state of the variable identified by
+// the request against the expected modify index. If they do not match, the
+// conflicting variable is returned. When the variable does not exist but
+// was expected to, the returned conflict only contains its identifiers.
+func (s *StateStore) varCASConflictTxn(txn *txn, req *structs.VarApplyStateRequest) (*structs.VariableEncrypted, error) {
+	existing, err := s.getVariableTxn(nil, txn, req.Var.Namespace, req.Var.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case existing == nil && req.Var.ModifyIndex == 0:
+		return nil, nil
+	case existing == nil:
+		return &structs.VariableEncrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: req.Var.Namespace,
+				Path:      req.Var.Path,
+			},
+		}, nil
+	case existing.ModifyIndex != req.Var.ModifyIndex:
+		return existing.Copy(), nil
+	default:
+		return nil, nil
+	}
+}
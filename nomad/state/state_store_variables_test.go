@@ -0,0 +1,251 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_VarSet(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.VariableEncrypted()
+	resp := testState.VarSet(10, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	require.True(t, resp.IsOk())
+	require.Equal(t, uint64(10), resp.WrittenVarMeta.CreateIndex)
+	require.Equal(t, uint64(10), resp.WrittenVarMeta.ModifyIndex)
+
+	index, err := testState.Index(TableVariables)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), index)
+
+	// The variable held by the request should not have been modified.
+	require.Zero(t, sv.CreateIndex)
+
+	// Updating a variable maintains its create index and time.
+	update := sv.Copy()
+	update.Data = []byte("updated")
+	update.CreateTime = 0
+	resp = testState.VarSet(20, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: update})
+	require.True(t, resp.IsOk())
+
+	out, err := testState.GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Equal(t, []byte("updated"), out.Data)
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(20), out.ModifyIndex)
+	require.Equal(t, sv.CreateTime, out.CreateTime)
+
+	// Deleting the variable removes it and bumps the table index.
+	resp = testState.VarDelete(30, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	require.True(t, resp.IsOk())
+
+	out, err = testState.GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Nil(t, out)
+
+	index, err = testState.Index(TableVariables)
+	require.NoError(t, err)
+	require.Equal(t, uint64(30), index)
+
+	// Deleting a variable which does not exist is not an error.
+	resp = testState.VarDelete(40, &structs.VarApplyStateRequest{Op: structs.VarOpDelete, Var: sv})
+	require.True(t, resp.IsOk())
+}
+
+func TestStateStore_VarSetCAS(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.VariableEncrypted()
+
+	// A check index of zero requires the variable to not exist.
+	req := &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: sv.Copy()}
+	resp := testState.VarSetCAS(10, req)
+	require.True(t, resp.IsOk())
+
+	resp = testState.VarSetCAS(20, req)
+	require.True(t, resp.IsConflict())
+	require.Equal(t, uint64(10), resp.Conflict.ModifyIndex)
+
+	// A mismatched check index conflicts, a matching one writes.
+	req.Var.ModifyIndex = 5
+	resp = testState.VarSetCAS(20, req)
+	require.True(t, resp.IsConflict())
+
+	req.Var.ModifyIndex = 10
+	resp = testState.VarSetCAS(20, req)
+	require.True(t, resp.IsOk())
+	require.Equal(t, uint64(20), resp.WrittenVarMeta.ModifyIndex)
+
+	// A nonzero check index for a missing variable returns a conflict
+	// holding only the identifiers of the variable.
+	missing := mock.VariableEncrypted()
+	missing.ModifyIndex = 10
+	resp = testState.VarSetCAS(30, &structs.VarApplyStateRequest{Op: structs.VarOpCAS, Var: missing})
+	require.True(t, resp.IsConflict())
+	require.Equal(t, missing.Path, resp.Conflict.Path)
+	require.Zero(t, resp.Conflict.ModifyIndex)
+}
+
+func TestStateStore_VarDeleteCAS(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv := mock.VariableEncrypted()
+	resp := testState.VarSet(10, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+	require.True(t, resp.IsOk())
+
+	req := &structs.VarApplyStateRequest{Op: structs.VarOpDeleteCAS, Var: sv.Copy()}
+	req.Var.ModifyIndex = 5
+	resp = testState.VarDeleteCAS(20, req)
+	require.True(t, resp.IsConflict())
+	require.Equal(t, uint64(10), resp.Conflict.ModifyIndex)
+
+	out, err := testState.GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.NotNil(t, out)
+
+	req.Var.ModifyIndex = 10
+	resp = testState.VarDeleteCAS(20, req)
+	require.True(t, resp.IsOk())
+
+	out, err = testState.GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.Nil(t, out)
+}
+
+func TestStateStore_GetVariablesByNamespaceAndPrefix(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	paths := map[string][]string{
+		"default": {"a/b/c", "a/b/d", "a/e", "b"},
+		"other":   {"a/b/c"},
+	}
+	index := uint64(10)
+	for ns, nsPaths := range paths {
+		for _, path := range nsPaths {
+			sv := mock.VariableEncrypted()
+			sv.Namespace = ns
+			sv.Path = path
+			index++
+			resp := testState.VarSet(index, &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+			require.True(t, resp.IsOk())
+		}
+	}
+
+	countIter := func(iter memdb.ResultIterator) []string {
+		var out []string
+		for raw := iter.Next(); raw != nil; raw = iter.Next() {
+			sv := raw.(*structs.VariableEncrypted)
+			out = append(out, sv.Namespace+":"+sv.Path)
+		}
+		return out
+	}
+
+	iter, err := testState.GetVariables(memdb.NewWatchSet())
+	require.NoError(t, err)
+	require.Len(t, countIter(iter), 5)
+
+	iter, err = testState.GetVariablesByNamespace(memdb.NewWatchSet(), "default")
+	require.NoError(t, err)
+	require.Equal(t, []string{"default:a/b/c", "default:a/b/d", "default:a/e", "default:b"}, countIter(iter))
+
+	iter, err = testState.GetVariablesByNamespaceAndPrefix(memdb.NewWatchSet(), "default", "a/b")
+	require.NoError(t, err)
+	require.Equal(t, []string{"default:a/b/c", "default:a/b/d"}, countIter(iter))
+
+	iter, err = testState.GetVariablesByNamespaceAndPrefix(memdb.NewWatchSet(), "other", "a/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"other:a/b/c"}, countIter(iter))
+}
+
+func TestStateStore_UpsertRootKeyMeta(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	key1 := mock.RootKey().Meta
+	require.NoError(t, testState.UpsertRootKeyMeta(structs.MsgTypeTestSetup, 10, key1, false))
+
+	active, err := testState.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key1.KeyID, active.KeyID)
+	require.Equal(t, uint64(10), active.CreateIndex)
+
+	// Inserting a new active key deactivates the previous one.
+	key2 := mock.RootKey().Meta
+	require.NoError(t, testState.UpsertRootKeyMeta(structs.MsgTypeTestSetup, 20, key2, false))
+
+	active, err = testState.GetActiveRootKeyMeta(nil)
+	require.NoError(t, err)
+	require.Equal(t, key2.KeyID, active.KeyID)
+
+	out, err := testState.RootKeyMetaByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.True(t, out.Inactive())
+	require.Equal(t, uint64(10), out.CreateIndex)
+	require.Equal(t, uint64(20), out.ModifyIndex)
+
+	// Inserting a new active key with rekeying marks all the other keys as
+	// rekeying.
+	key3 := mock.RootKey().Meta
+	require.NoError(t, testState.UpsertRootKeyMeta(structs.MsgTypeTestSetup, 30, key3, true))
+
+	for _, keyID := range []string{key1.KeyID, key2.KeyID} {
+		out, err := testState.RootKeyMetaByID(nil, keyID)
+		require.NoError(t, err)
+		require.True(t, out.Rekeying())
+		require.Equal(t, uint64(30), out.ModifyIndex)
+	}
+
+	// Keys which are being rekeyed are not deactivated by a later rotation.
+	key4 := mock.RootKey().Meta
+	require.NoError(t, testState.UpsertRootKeyMeta(structs.MsgTypeTestSetup, 40, key4, false))
+
+	out, err = testState.RootKeyMetaByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.True(t, out.Rekeying())
+	out, err = testState.RootKeyMetaByID(nil, key3.KeyID)
+	require.NoError(t, err)
+	require.True(t, out.Inactive())
+
+	index, err := testState.Index(TableRootKeyMeta)
+	require.NoError(t, err)
+	require.Equal(t, uint64(40), index)
+
+	// Deleting a key removes its metadata.
+	require.NoError(t, testState.DeleteRootKeyMeta(structs.MsgTypeTestSetup, 50, key1.KeyID))
+	out, err = testState.RootKeyMetaByID(nil, key1.KeyID)
+	require.NoError(t, err)
+	require.Nil(t, out)
+	require.Error(t, testState.DeleteRootKeyMeta(structs.MsgTypeTestSetup, 60, key1.KeyID))
+}
+
+func TestStateStore_GetVariablesByKeyID(t *testing.T) {
+	ci.Parallel(t)
+	testState := testStateStore(t)
+
+	sv1 := mock.VariableEncrypted()
+	sv2 := mock.VariableEncrypted()
+	sv2.KeyID = sv1.KeyID
+	sv3 := mock.VariableEncrypted()
+	for i, sv := range []*structs.VariableEncrypted{sv1, sv2, sv3} {
+		resp := testState.VarSet(uint64(10+i), &structs.VarApplyStateRequest{Op: structs.VarOpSet, Var: sv})
+		require.True(t, resp.IsOk())
+	}
+
+	iter, err := testState.GetVariablesByKeyID(memdb.NewWatchSet(), sv1.KeyID)
+	require.NoError(t, err)
+
+	var paths []string
+	for raw := iter.Next(); raw != nil; raw = iter.Next() {
+		paths = append(paths, raw.(*structs.VariableEncrypted).Path)
+	}
+	require.ElementsMatch(t, []string{sv1.Path, sv2.Path}, paths)
+}
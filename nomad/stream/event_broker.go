@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/armon/go-metrics"
 	"github.com/hashicorp/go-memdb"
@@ -98,9 +99,10 @@ func (e *EventBroker) Publish(events *structs.Events) {
 	}
 
 	// Notify the broker to check running subscriptions against potentially
-	// updated ACL Token or Policy
+	// updated ACL Token, Policy or Role
 	for _, event := range events.Events {
-		if event.Topic == structs.TopicACLToken || event.Topic == structs.TopicACLPolicy {
+		switch event.Topic {
+		case structs.TopicACLToken, structs.TopicACLPolicy, structs.TopicACLRole:
 			e.aclCh <- &event
 		}
 	}
@@ -213,9 +215,9 @@ func (e *EventBroker) handleACLUpdates(ctx context.Context) {
 					return !aclAllowsSubscription(aclObj, sub.req)
 				})
 
-			case *structs.ACLPolicyEvent:
+			case *structs.ACLPolicyEvent, *structs.ACLRoleStreamEvent:
 				// Re-evaluate each subscriptions permissions since a policy
-				// change may or may not affect the subscription
+				// or role change may or may not affect the subscription
 				e.checkSubscriptionsAgainstPolicyChange()
 			}
 		}
@@ -266,6 +268,9 @@ func aclObjFromSnapshotForTokenSecretID(aclSnapshot ACLTokenProvider, aclCache *
 	if aclToken == nil {
 		return nil, errors.New("no token for secret ID")
 	}
+	if aclToken.IsExpired(time.Now().UTC()) {
+		return nil, structs.ErrTokenExpired
+	}
 
 	// Check if this is a management token
 	if aclToken.Type == structs.ACLManagementToken {
@@ -281,12 +286,44 @@ func aclObjFromSnapshotForTokenSecretID(aclSnapshot ACLTokenProvider, aclCache *
 		aclPolicies = append(aclPolicies, policy)
 	}
 
+	// Add the policies of the roles linked to the token. Roles and role
+	// policies which no longer exist are ignored, since they don't grant any
+	// more privilege.
+	seen := make(map[string]struct{}, len(aclToken.Policies))
+	for _, policyName := range aclToken.Policies {
+		seen[policyName] = struct{}{}
+	}
+	for _, roleLink := range aclToken.Roles {
+		role, err := aclSnapshot.GetACLRoleByID(nil, roleLink.ID)
+		if err != nil {
+			return nil, errors.New("error finding acl role")
+		}
+		if role == nil {
+			continue
+		}
+		for _, policyLink := range role.Policies {
+			if _, ok := seen[policyLink.Name]; ok {
+				continue
+			}
+			seen[policyLink.Name] = struct{}{}
+
+			policy, err := aclSnapshot.ACLPolicyByName(nil, policyLink.Name)
+			if err != nil {
+				return nil, errors.New("error finding acl policy")
+			}
+			if policy != nil {
+				aclPolicies = append(aclPolicies, policy)
+			}
+		}
+	}
+
 	return structs.CompileACLObject(aclCache, aclPolicies)
 }
 
 type ACLTokenProvider interface {
 	ACLTokenBySecretID(ws memdb.WatchSet, secretID string) (*structs.ACLToken, error)
 	ACLPolicyByName(ws memdb.WatchSet, policyName string) (*structs.ACLPolicy, error)
+	GetACLRoleByID(ws memdb.WatchSet, roleID string) (*structs.ACLRole, error)
 }
 
 type ACLDelegate interface {
@@ -174,6 +174,8 @@ type fakeACLTokenProvider struct {
 	policyErr error
 	token     *structs.ACLToken
 	tokenErr  error
+	role      *structs.ACLRole
+	roleErr   error
 }
 
 func (p *fakeACLTokenProvider) ACLTokenBySecretID(ws memdb.WatchSet, secretID string) (*structs.ACLToken, error) {
@@ -184,6 +186,10 @@ func (p *fakeACLTokenProvider) ACLPolicyByName(ws memdb.WatchSet, policyName str
 	return p.policy, p.policyErr
 }
 
+func (p *fakeACLTokenProvider) GetACLRoleByID(ws memdb.WatchSet, roleID string) (*structs.ACLRole, error) {
+	return p.role, p.roleErr
+}
+
 func TestEventBroker_handleACLUpdates_policyupdated(t *testing.T) {
 	ci.Parallel(t)
 
@@ -449,6 +455,30 @@ func TestEventBroker_handleACLUpdates_policyupdated(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:              "subscribed to nodes and role change no access",
+			policyBeforeRules: mock.NodePolicy(acl.PolicyRead),
+			policyAfterRules:  mock.NodePolicy(acl.PolicyDeny),
+			shouldUnsubscribe: true,
+			event: structs.Event{
+				Topic: structs.TopicNode,
+				Type:  structs.TypeNodeRegistration,
+				Payload: structs.NodeStreamEvent{
+					Node: &structs.Node{
+						ID: "some-id",
+					},
+				},
+			},
+			policyEvent: structs.Event{
+				Topic: structs.TopicACLRole,
+				Type:  structs.TypeACLRoleUpserted,
+				Payload: &structs.ACLRoleStreamEvent{
+					ACLRole: &structs.ACLRole{
+						ID: "some-role",
+					},
+				},
+			},
+		},
 		{
 			desc:              "subscribed to nodes policy deleted",
 			policyBeforeRules: mock.NodePolicy(acl.PolicyRead),
@@ -0,0 +1,1121 @@
+package structs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-bexpr"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ACLUpsertRolesRPCMethod is the RPC method for batch creating or
+	// modifying ACL roles.
+	//
+	// Args: ACLRolesUpsertRequest
+	// Reply: ACLRolesUpsertResponse
+	ACLUpsertRolesRPCMethod = "ACL.UpsertRoles"
+
+	// ACLDeleteRolesRPCMethod the RPC method for batch deleting ACL roles by
+	// their ID.
+	//
+	// Args: ACLRolesDeleteRequest
+	// Reply: GenericResponse
+	ACLDeleteRolesRPCMethod = "ACL.DeleteRoles"
+
+	// ACLListRolesRPCMethod is the RPC method for listing ACL roles.
+	//
+	// Args: ACLRolesListRequest
+	// Reply: ACLRolesListResponse
+	ACLListRolesRPCMethod = "ACL.ListRoles"
+
+	// ACLGetRoleRPCMethod is the RPC method for detailing an individual ACL
+	// role using its ID or name.
+	//
+	// Args: ACLRoleSpecificRequest
+	// Reply: ACLRoleSpecificResponse
+	ACLGetRoleRPCMethod = "ACL.GetRole"
+
+	// ACLGetRolesRPCMethod is the RPC method for detailing a set of ACL roles
+	// using their IDs. It is used by clients resolving tokens and for
+	// replicating roles from the authoritative region.
+	//
+	// Args: ACLRolesByIDRequest
+	// Reply: ACLRolesByIDResponse
+	ACLGetRolesRPCMethod = "ACL.GetRoles"
+)
+
+const (
+	// maxACLRoleDescriptionLength limits an ACL roles description length.
+	maxACLRoleDescriptionLength = 256
+)
+
+var (
+	// validACLRoleName is used to validate an ACL role name.
+	validACLRoleName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// ACLRole is an abstraction for the ACL system which allows the grouping of
+// ACL policies into a single object. ACL tokens can be created and linked to
+// a role; the token then inherits all the permissions granted by the
+// policies.
+type ACLRole struct {
+	// ID is an internally generated UUID for this role and is controlled by
+	// Nomad.
+	ID string
+
+	// Name is unique across the entire set of federated clusters and is
+	// supplied by the operator on role creation. The name can be modified by
+	// updating the role and including the Nomad generated ID. This update will
+	// not affect tokens created and linked to this role. This is a required
+	// field.
+	Name string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the role. This is an operational field.
+	Description string
+
+	// Policies is an array of ACL policy links. Although currently policies
+	// can only be linked using their name, in the future we will want to add
+	// IDs also and thus allow operators to specify either a name, an ID, or
+	// both.
+	Policies []*ACLRolePolicyLink
+
+	// Hash is the hashed value of the role and is generated using all fields
+	// above this point.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLRolePolicyLink is used to link a policy to an ACL role. We use a struct
+// rather than a list of strings as in the future we will want to add IDs to
+// policies and then link via these.
+type ACLRolePolicyLink struct {
+	// Name is the ACLPolicy.Name value which will be linked to the ACL role.
+	Name string
+}
+
+// SetHash is used to compute and set the hash of the ACL role. This should be
+// called every and each time a user specified field on the role is changed
+// before updating the Nomad state store.
+func (a *ACLRole) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields.
+	_, _ = hash.Write([]byte(a.Name))
+	_, _ = hash.Write([]byte(a.Description))
+
+	for _, policyLink := range a.Policies {
+		_, _ = hash.Write([]byte(policyLink.Name))
+	}
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// Validate ensures the ACL role contains valid information which meets Nomad's
+// internal requirements. This does not include any state calls, such as
+// ensuring the linked policies exist.
+func (a *ACLRole) Validate() error {
+	var mErr multierror.Error
+
+	if !validACLRoleName.MatchString(a.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid name '%s'", a.Name))
+	}
+
+	if len(a.Description) > maxACLRoleDescriptionLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("description longer than %d", maxACLRoleDescriptionLength))
+	}
+
+	if len(a.Policies) < 1 {
+		mErr.Errors = append(mErr.Errors, errors.New("at least one policy should be specified"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canonicalize performs basic canonicalization on the ACL role object. It is
+// important for callers to understand certain fields such as ID are set if it
+// is empty, so copies should be taken if needed before calling this function.
+func (a *ACLRole) Canonicalize() {
+	if a.ID == "" {
+		a.ID = uuid.Generate()
+	}
+}
+
+// Equal performs an equality check on the two ACL roles using their hashes.
+// It handles nil objects.
+func (a *ACLRole) Equal(o *ACLRole) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(o.Hash) == 0 {
+		o.SetHash()
+	}
+	return bytes.Equal(a.Hash, o.Hash)
+}
+
+// Copy creates a deep copy of the ACL role. This copy can then be safely
+// modified. It handles nil objects.
+func (a *ACLRole) Copy() *ACLRole {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLRole)
+	*c = *a
+
+	c.Policies = make([]*ACLRolePolicyLink, len(a.Policies))
+	for i, policyLink := range a.Policies {
+		link := *policyLink
+		c.Policies[i] = &link
+	}
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+
+	return c
+}
+
+// PolicyNames returns the names of the policies linked to the ACL role.
+func (a *ACLRole) PolicyNames() []string {
+	names := make([]string, 0, len(a.Policies))
+	for _, policyLink := range a.Policies {
+		names = append(names, policyLink.Name)
+	}
+	return names
+}
+
+// Stub converts the ACLRole object into a ACLRoleListStub object.
+func (a *ACLRole) Stub() *ACLRoleListStub {
+	return &ACLRoleListStub{
+		ID:          a.ID,
+		Name:        a.Name,
+		Description: a.Description,
+		Policies:    a.Policies,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// ACLRoleListStub is the stub object returned when performing a listing of
+// ACL roles. While it might not currently be different to the full response
+// object, it allows us to future-proof the RPC in the event the ACLRole object
+// grows over time.
+type ACLRoleListStub struct {
+	// ID is an internally generated UUID for this role and is controlled by
+	// Nomad.
+	ID string
+
+	// Name is unique across the entire set of federated clusters and is
+	// supplied by the operator on role creation.
+	Name string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the role.
+	Description string
+
+	// Policies is an array of ACL policy links.
+	Policies []*ACLRolePolicyLink
+
+	// Hash is the hashed value of the role and is generated using all fields
+	// from the full object.
+	Hash []byte
+
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLTokenRoleLink is used to link an ACL token to an ACL role. The ACL token
+// can therefore inherit all the ACL policy permissions that the ACL role
+// contains.
+type ACLTokenRoleLink struct {
+	// ID is the ACLRole.ID UUID. This field is immutable and represents the
+	// absolute truth for the link.
+	ID string
+
+	// Name is the human friendly identifier for the ACL role and is a
+	// convenience field for operators. When a token is written, links which
+	// only specify a name are resolved to the ID of the role. The name is not
+	// used when resolving the token, since operators can rename ACL roles.
+	Name string
+}
+
+// ACLRolesUpsertRequest is the request object used to upsert one or more ACL
+// roles.
+type ACLRolesUpsertRequest struct {
+	ACLRoles []*ACLRole
+
+	// AllowMissingPolicies skips the ACL Role policy link verification and is
+	// used by the replication process. The replication cannot ensure policies
+	// are present before ACL Roles are replicated.
+	AllowMissingPolicies bool
+
+	WriteRequest
+}
+
+// ACLRolesUpsertResponse is the response object when one or more ACL roles
+// have been successfully upserted into state.
+type ACLRolesUpsertResponse struct {
+	ACLRoles []*ACLRole
+	WriteMeta
+}
+
+// ACLRolesDeleteRequest is the request object used to delete one or more ACL
+// roles using their IDs.
+type ACLRolesDeleteRequest struct {
+	ACLRoleIDs []string
+	WriteRequest
+}
+
+// ACLRolesListRequest is the request object used to list ACL roles.
+type ACLRolesListRequest struct {
+	QueryOptions
+}
+
+// ACLRolesListResponse is the response object when performing ACL role
+// listings.
+type ACLRolesListResponse struct {
+	ACLRoles []*ACLRoleListStub
+	QueryMeta
+}
+
+// ACLRoleSpecificRequest is the request object used to lookup a single ACL
+// role, using either its ID or its name. The ID takes precedence when both
+// are set.
+type ACLRoleSpecificRequest struct {
+	RoleID   string
+	RoleName string
+	QueryOptions
+}
+
+// ACLRoleSpecificResponse is the response object when performing a lookup of
+// a single ACL role. If the role could not be found, ACLRole will be nil.
+type ACLRoleSpecificResponse struct {
+	ACLRole *ACLRole
+	QueryMeta
+}
+
+// ACLRolesByIDRequest is the request object when performing a lookup of
+// multiple roles by the ID.
+type ACLRolesByIDRequest struct {
+	ACLRoleIDs []string
+	QueryOptions
+}
+
+// ACLRolesByIDResponse is the response object when performing a lookup of
+// multiple roles by their IDs.
+type ACLRolesByIDResponse struct {
+	ACLRoles map[string]*ACLRole
+	QueryMeta
+}
+
+const (
+	// ACLUpsertAuthMethodsRPCMethod is the RPC method for batch creating or
+	// modifying ACL auth methods.
+	//
+	// Args: ACLAuthMethodsUpsertRequest
+	// Reply: ACLAuthMethodsUpsertResponse
+	ACLUpsertAuthMethodsRPCMethod = "ACL.UpsertAuthMethods"
+
+	// ACLDeleteAuthMethodsRPCMethod is the RPC method for batch deleting ACL
+	// auth methods by their name.
+	//
+	// Args: ACLAuthMethodsDeleteRequest
+	// Reply: GenericResponse
+	ACLDeleteAuthMethodsRPCMethod = "ACL.DeleteAuthMethods"
+
+	// ACLListAuthMethodsRPCMethod is the RPC method for listing ACL auth
+	// methods.
+	//
+	// Args: ACLAuthMethodsListRequest
+	// Reply: ACLAuthMethodsListResponse
+	ACLListAuthMethodsRPCMethod = "ACL.ListAuthMethods"
+
+	// ACLGetAuthMethodRPCMethod is the RPC method for detailing an individual
+	// ACL auth method using its name.
+	//
+	// Args: ACLAuthMethodSpecificRequest
+	// Reply: ACLAuthMethodSpecificResponse
+	ACLGetAuthMethodRPCMethod = "ACL.GetAuthMethod"
+
+	// ACLGetAuthMethodsRPCMethod is the RPC method for detailing a set of ACL
+	// auth methods using their names. It is used when replicating auth
+	// methods from the authoritative region.
+	//
+	// Args: ACLAuthMethodsByNameRequest
+	// Reply: ACLAuthMethodsByNameResponse
+	ACLGetAuthMethodsRPCMethod = "ACL.GetAuthMethods"
+
+	// ACLUpsertBindingRulesRPCMethod is the RPC method for batch creating or
+	// modifying ACL binding rules.
+	//
+	// Args: ACLBindingRulesUpsertRequest
+	// Reply: ACLBindingRulesUpsertResponse
+	ACLUpsertBindingRulesRPCMethod = "ACL.UpsertBindingRules"
+
+	// ACLDeleteBindingRulesRPCMethod is the RPC method for batch deleting ACL
+	// binding rules by their ID.
+	//
+	// Args: ACLBindingRulesDeleteRequest
+	// Reply: GenericResponse
+	ACLDeleteBindingRulesRPCMethod = "ACL.DeleteBindingRules"
+
+	// ACLListBindingRulesRPCMethod is the RPC method for listing ACL binding
+	// rules.
+	//
+	// Args: ACLBindingRulesListRequest
+	// Reply: ACLBindingRulesListResponse
+	ACLListBindingRulesRPCMethod = "ACL.ListBindingRules"
+
+	// ACLGetBindingRuleRPCMethod is the RPC method for detailing an
+	// individual ACL binding rule using its ID.
+	//
+	// Args: ACLBindingRuleSpecificRequest
+	// Reply: ACLBindingRuleSpecificResponse
+	ACLGetBindingRuleRPCMethod = "ACL.GetBindingRule"
+
+	// ACLGetBindingRulesRPCMethod is the RPC method for detailing a set of ACL
+	// binding rules using their IDs. It is used when replicating binding
+	// rules from the authoritative region.
+	//
+	// Args: ACLBindingRulesByIDRequest
+	// Reply: ACLBindingRulesByIDResponse
+	ACLGetBindingRulesRPCMethod = "ACL.GetBindingRules"
+
+	// ACLOIDCAuthURLRPCMethod is the RPC method for starting the OIDC login
+	// flow. It returns the URL of the identity provider the user should
+	// visit to authenticate.
+	//
+	// Args: ACLOIDCAuthURLRequest
+	// Reply: ACLOIDCAuthURLResponse
+	ACLOIDCAuthURLRPCMethod = "ACL.OIDCAuthURL"
+
+	// ACLOIDCCompleteAuthRPCMethod is the RPC method for completing the OIDC
+	// login flow. It exchanges the authorization code for an ID token and
+	// mints a Nomad ACL token for the authenticated identity.
+	//
+	// Args: ACLOIDCCompleteAuthRequest
+	// Reply: ACLLoginResponse
+	ACLOIDCCompleteAuthRPCMethod = "ACL.OIDCCompleteAuth"
+)
+
+const (
+	// ACLAuthMethodTypeOIDC is the auth method type which uses an OpenID
+	// Connect identity provider to authenticate users.
+	ACLAuthMethodTypeOIDC = "OIDC"
+
+	// ACLAuthMethodTokenLocalityLocal and ACLAuthMethodTokenLocalityGlobal
+	// are the token localities an auth method can mint tokens with.
+	ACLAuthMethodTokenLocalityLocal  = "local"
+	ACLAuthMethodTokenLocalityGlobal = "global"
+
+	// ACLBindingRuleBindTypeRole and ACLBindingRuleBindTypePolicy are the
+	// types of object a binding rule can link to minted tokens.
+	ACLBindingRuleBindTypeRole   = "role"
+	ACLBindingRuleBindTypePolicy = "policy"
+
+	// maxACLBindingRuleDescriptionLength limits an ACL binding rules
+	// description length.
+	maxACLBindingRuleDescriptionLength = 256
+)
+
+var (
+	// validACLAuthMethodName is used to validate an ACL auth method name.
+	validACLAuthMethodName = regexp.MustCompile("^[a-zA-Z0-9-]{1,128}$")
+)
+
+// ACLAuthMethod is used to capture the properties of an authentication method
+// used for single sign-on. Users logging in through the auth method are issued
+// short-lived ACL tokens, linked to the roles and policies selected by the
+// binding rules of the auth method.
+type ACLAuthMethod struct {
+	// Name is the identifier for this auth method and is unique across the
+	// entire set of federated clusters.
+	Name string
+
+	// Type is the type of the auth method. Currently only OIDC is supported.
+	Type string
+
+	// TokenLocality defines whether tokens minted by this auth method are
+	// local to the region or global.
+	TokenLocality string
+
+	// MaxTokenTTL is the lifetime of tokens minted by this auth method.
+	MaxTokenTTL time.Duration
+
+	// Default identifies whether this is the auth method used by the login
+	// command when one is not specified. Only one auth method can be the
+	// default.
+	Default bool
+
+	// Config contains the provider specific configuration of the auth
+	// method.
+	Config *ACLAuthMethodConfig
+
+	// Hash is the hashed value of the auth method and is generated using all
+	// fields above this point.
+	Hash []byte
+
+	CreateTime  time.Time
+	ModifyTime  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLAuthMethodConfig is used to store the provider specific configuration of
+// an ACL auth method.
+type ACLAuthMethodConfig struct {
+	// OIDCDiscoveryURL is the OIDC discovery URL, without any .well-known
+	// component (base path). It is also the issuer of the ID tokens.
+	OIDCDiscoveryURL string
+
+	// OIDCClientID is the OAuth client ID configured with the identity
+	// provider.
+	OIDCClientID string
+
+	// OIDCClientSecret is the OAuth client secret configured with the
+	// identity provider.
+	OIDCClientSecret string
+
+	// OIDCScopes is a list of scopes to request in addition to the "openid"
+	// scope.
+	OIDCScopes []string
+
+	// BoundAudiences is the list of audiences, one of which must be present
+	// in the ID token. When empty, the client ID is used.
+	BoundAudiences []string
+
+	// AllowedRedirectURIs is the list of redirect URIs the login flow may
+	// use as the callback of the identity provider.
+	AllowedRedirectURIs []string
+
+	// DiscoveryCaPem is a list of PEM encoded CA certificates used to verify
+	// the connection to the identity provider. When empty, the system CA
+	// certificates are used.
+	DiscoveryCaPem []string
+
+	// SigningAlgs is the list of JWS algorithms allowed to sign ID tokens.
+	// When empty, RS256 is used.
+	SigningAlgs []string
+
+	// ClaimMappings maps claims of the ID token to the names of values
+	// available to binding rule selectors and bind names as "value.<name>".
+	ClaimMappings map[string]string
+
+	// ListClaimMappings maps list claims of the ID token to the names of
+	// values available to binding rule selectors as "list.<name>".
+	ListClaimMappings map[string]string
+}
+
+// Copy creates a deep copy of the auth method config. It handles nil
+// objects.
+func (a *ACLAuthMethodConfig) Copy() *ACLAuthMethodConfig {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLAuthMethodConfig)
+	*c = *a
+
+	c.OIDCScopes = helper.CopySliceString(a.OIDCScopes)
+	c.BoundAudiences = helper.CopySliceString(a.BoundAudiences)
+	c.AllowedRedirectURIs = helper.CopySliceString(a.AllowedRedirectURIs)
+	c.DiscoveryCaPem = helper.CopySliceString(a.DiscoveryCaPem)
+	c.SigningAlgs = helper.CopySliceString(a.SigningAlgs)
+	c.ClaimMappings = helper.CopyMapStringString(a.ClaimMappings)
+	c.ListClaimMappings = helper.CopyMapStringString(a.ListClaimMappings)
+	return c
+}
+
+// SetHash is used to compute and set the hash of the ACL auth method. This
+// should be called every and each time a user specified field on the auth
+// method is changed before updating the Nomad state store.
+func (a *ACLAuthMethod) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields.
+	_, _ = hash.Write([]byte(a.Name))
+	_, _ = hash.Write([]byte(a.Type))
+	_, _ = hash.Write([]byte(a.TokenLocality))
+	_, _ = hash.Write([]byte(a.MaxTokenTTL.String()))
+	_, _ = hash.Write([]byte(strconv.FormatBool(a.Default)))
+
+	if a.Config != nil {
+		_, _ = hash.Write([]byte(a.Config.OIDCDiscoveryURL))
+		_, _ = hash.Write([]byte(a.Config.OIDCClientID))
+		_, _ = hash.Write([]byte(a.Config.OIDCClientSecret))
+		for _, values := range [][]string{
+			a.Config.OIDCScopes,
+			a.Config.BoundAudiences,
+			a.Config.AllowedRedirectURIs,
+			a.Config.DiscoveryCaPem,
+			a.Config.SigningAlgs,
+		} {
+			for _, v := range values {
+				_, _ = hash.Write([]byte(v))
+			}
+		}
+		for _, mappings := range []map[string]string{
+			a.Config.ClaimMappings,
+			a.Config.ListClaimMappings,
+		} {
+			keys := make([]string, 0, len(mappings))
+			for k := range mappings {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				_, _ = hash.Write([]byte(k))
+				_, _ = hash.Write([]byte(mappings[k]))
+			}
+		}
+	}
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// Validate ensures the ACL auth method contains valid information which meets
+// Nomad's internal requirements.
+func (a *ACLAuthMethod) Validate() error {
+	var mErr multierror.Error
+
+	if !validACLAuthMethodName.MatchString(a.Name) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid name '%s'", a.Name))
+	}
+
+	if a.Type != ACLAuthMethodTypeOIDC {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid type '%s'", a.Type))
+	}
+
+	switch a.TokenLocality {
+	case ACLAuthMethodTokenLocalityLocal, ACLAuthMethodTokenLocalityGlobal:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid token locality '%s'", a.TokenLocality))
+	}
+
+	if a.MaxTokenTTL <= 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("max token TTL must be positive"))
+	}
+
+	if a.Config == nil {
+		mErr.Errors = append(mErr.Errors, errors.New("missing config"))
+		return mErr.ErrorOrNil()
+	}
+	if a.Config.OIDCDiscoveryURL == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing OIDC discovery URL"))
+	}
+	if a.Config.OIDCClientID == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing OIDC client ID"))
+	}
+	if len(a.Config.AllowedRedirectURIs) == 0 {
+		mErr.Errors = append(mErr.Errors, errors.New("at least one allowed redirect URI should be specified"))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canonicalize performs basic canonicalization on the ACL auth method object.
+// It sets the create and modify times, so copies should be taken if needed
+// before calling this function.
+func (a *ACLAuthMethod) Canonicalize() {
+	now := time.Now().UTC()
+	if a.CreateTime.IsZero() {
+		a.CreateTime = now
+	}
+	a.ModifyTime = now
+}
+
+// Equal performs an equality check on the two ACL auth methods using their
+// hashes. It handles nil objects.
+func (a *ACLAuthMethod) Equal(o *ACLAuthMethod) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(o.Hash) == 0 {
+		o.SetHash()
+	}
+	return bytes.Equal(a.Hash, o.Hash)
+}
+
+// Copy creates a deep copy of the ACL auth method. This copy can then be
+// safely modified. It handles nil objects.
+func (a *ACLAuthMethod) Copy() *ACLAuthMethod {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLAuthMethod)
+	*c = *a
+
+	c.Config = a.Config.Copy()
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+
+	return c
+}
+
+// TokenLocalityIsGlobal returns whether the auth method mints global tokens.
+func (a *ACLAuthMethod) TokenLocalityIsGlobal() bool {
+	return a.TokenLocality == ACLAuthMethodTokenLocalityGlobal
+}
+
+// Stub converts the ACLAuthMethod object into a ACLAuthMethodListStub object.
+func (a *ACLAuthMethod) Stub() *ACLAuthMethodListStub {
+	return &ACLAuthMethodListStub{
+		Name:        a.Name,
+		Type:        a.Type,
+		Default:     a.Default,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// ACLAuthMethodListStub is the stub object returned when performing a listing
+// of ACL auth methods. It does not include the configuration of the auth
+// method, which may contain secrets.
+type ACLAuthMethodListStub struct {
+	Name        string
+	Type        string
+	Default     bool
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLAuthMethodsUpsertRequest is the request object used to upsert one or
+// more ACL auth methods.
+type ACLAuthMethodsUpsertRequest struct {
+	AuthMethods []*ACLAuthMethod
+	WriteRequest
+}
+
+// ACLAuthMethodsUpsertResponse is the response object when one or more ACL
+// auth methods have been successfully upserted into state.
+type ACLAuthMethodsUpsertResponse struct {
+	AuthMethods []*ACLAuthMethod
+	WriteMeta
+}
+
+// ACLAuthMethodsDeleteRequest is the request object used to delete one or
+// more ACL auth methods using their names.
+type ACLAuthMethodsDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// ACLAuthMethodsListRequest is the request object used to list ACL auth
+// methods.
+type ACLAuthMethodsListRequest struct {
+	QueryOptions
+}
+
+// ACLAuthMethodsListResponse is the response object when performing ACL auth
+// method listings.
+type ACLAuthMethodsListResponse struct {
+	AuthMethods []*ACLAuthMethodListStub
+	QueryMeta
+}
+
+// ACLAuthMethodSpecificRequest is the request object used to lookup a single
+// ACL auth method using its name.
+type ACLAuthMethodSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// ACLAuthMethodSpecificResponse is the response object when performing a
+// lookup of a single ACL auth method. If the auth method could not be found,
+// AuthMethod will be nil.
+type ACLAuthMethodSpecificResponse struct {
+	AuthMethod *ACLAuthMethod
+	QueryMeta
+}
+
+// ACLAuthMethodsByNameRequest is the request object when performing a lookup
+// of multiple auth methods by their names.
+type ACLAuthMethodsByNameRequest struct {
+	Names []string
+	QueryOptions
+}
+
+// ACLAuthMethodsByNameResponse is the response object when performing a
+// lookup of multiple auth methods by their names.
+type ACLAuthMethodsByNameResponse struct {
+	AuthMethods map[string]*ACLAuthMethod
+	QueryMeta
+}
+
+// ACLBindingRule contains a direct relation to an ACLAuthMethod and describes
+// which ACL roles or policies are linked to the tokens minted for identities
+// authenticated by the auth method.
+type ACLBindingRule struct {
+	// ID is an internally generated UUID for this binding rule and is
+	// controlled by Nomad.
+	ID string
+
+	// Description is a human-readable, operator set description that can
+	// provide additional context about the binding rule.
+	Description string
+
+	// AuthMethod is the name of the auth method for which this rule applies
+	// to. This is required and the method must exist within state before the
+	// binding rule can be written.
+	AuthMethod string
+
+	// Selector is an expression that matches against verified identity
+	// attributes returned from the auth method during login. An empty
+	// selector matches all identities.
+	Selector string
+
+	// BindType adjusts how this binding rule is applied at login time. The
+	// valid values are "role" and "policy".
+	BindType string
+
+	// BindName is the name of the role or policy to link to the token. It
+	// can use "${value.<name>}" to interpolate the mapped claim values of
+	// the identity.
+	BindName string
+
+	// Hash is the hashed value of the binding rule and is generated using
+	// all fields above this point.
+	Hash []byte
+
+	CreateTime  time.Time
+	ModifyTime  time.Time
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// SetHash is used to compute and set the hash of the ACL binding rule. This
+// should be called every and each time a user specified field on the binding
+// rule is changed before updating the Nomad state store.
+func (a *ACLBindingRule) SetHash() []byte {
+	// Initialize a 256bit Blake2 hash (32 bytes).
+	hash, err := blake2b.New256(nil)
+	if err != nil {
+		panic(err)
+	}
+
+	// Write all the user set fields.
+	_, _ = hash.Write([]byte(a.ID))
+	_, _ = hash.Write([]byte(a.Description))
+	_, _ = hash.Write([]byte(a.AuthMethod))
+	_, _ = hash.Write([]byte(a.Selector))
+	_, _ = hash.Write([]byte(a.BindType))
+	_, _ = hash.Write([]byte(a.BindName))
+
+	// Finalize the hash.
+	hashVal := hash.Sum(nil)
+
+	// Set and return the hash.
+	a.Hash = hashVal
+	return hashVal
+}
+
+// Validate ensures the ACL binding rule contains valid information which
+// meets Nomad's internal requirements. This does not include any state calls,
+// such as ensuring the auth method exists.
+func (a *ACLBindingRule) Validate() error {
+	var mErr multierror.Error
+
+	if a.AuthMethod == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("auth method is missing"))
+	}
+
+	if len(a.Description) > maxACLBindingRuleDescriptionLength {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("description longer than %d", maxACLBindingRuleDescriptionLength))
+	}
+
+	switch a.BindType {
+	case ACLBindingRuleBindTypeRole, ACLBindingRuleBindTypePolicy:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid bind type '%s'", a.BindType))
+	}
+
+	if a.BindName == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("bind name is missing"))
+	}
+
+	if a.Selector != "" {
+		if _, err := bexpr.CreateEvaluator(a.Selector); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid selector: %v", err))
+		}
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Canonicalize performs basic canonicalization on the ACL binding rule
+// object. It is important for callers to understand certain fields such as ID
+// are set if it is empty, so copies should be taken if needed before calling
+// this function.
+func (a *ACLBindingRule) Canonicalize() {
+	now := time.Now().UTC()
+	if a.ID == "" {
+		a.ID = uuid.Generate()
+		a.CreateTime = now
+	}
+	a.ModifyTime = now
+}
+
+// Equal performs an equality check on the two ACL binding rules using their
+// hashes. It handles nil objects.
+func (a *ACLBindingRule) Equal(o *ACLBindingRule) bool {
+	if a == nil || o == nil {
+		return a == o
+	}
+	if len(a.Hash) == 0 {
+		a.SetHash()
+	}
+	if len(o.Hash) == 0 {
+		o.SetHash()
+	}
+	return bytes.Equal(a.Hash, o.Hash)
+}
+
+// Copy creates a deep copy of the ACL binding rule. This copy can then be
+// safely modified. It handles nil objects.
+func (a *ACLBindingRule) Copy() *ACLBindingRule {
+	if a == nil {
+		return nil
+	}
+
+	c := new(ACLBindingRule)
+	*c = *a
+
+	c.Hash = make([]byte, len(a.Hash))
+	copy(c.Hash, a.Hash)
+
+	return c
+}
+
+// Stub converts the ACLBindingRule object into a ACLBindingRuleListStub
+// object.
+func (a *ACLBindingRule) Stub() *ACLBindingRuleListStub {
+	return &ACLBindingRuleListStub{
+		ID:          a.ID,
+		Description: a.Description,
+		AuthMethod:  a.AuthMethod,
+		Hash:        a.Hash,
+		CreateIndex: a.CreateIndex,
+		ModifyIndex: a.ModifyIndex,
+	}
+}
+
+// ACLBindingRuleListStub is the stub object returned when performing a
+// listing of ACL binding rules.
+type ACLBindingRuleListStub struct {
+	ID          string
+	Description string
+	AuthMethod  string
+	Hash        []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// ACLBindingRulesUpsertRequest is the request object used to upsert one or
+// more ACL binding rules.
+type ACLBindingRulesUpsertRequest struct {
+	ACLBindingRules []*ACLBindingRule
+
+	// AllowMissingAuthMethods skips the ACL binding rule auth method link
+	// verification and is used by the replication process. The replication
+	// cannot ensure auth methods are present before binding rules are
+	// replicated.
+	AllowMissingAuthMethods bool
+
+	WriteRequest
+}
+
+// ACLBindingRulesUpsertResponse is the response object when one or more ACL
+// binding rules have been successfully upserted into state.
+type ACLBindingRulesUpsertResponse struct {
+	ACLBindingRules []*ACLBindingRule
+	WriteMeta
+}
+
+// ACLBindingRulesDeleteRequest is the request object used to delete one or
+// more ACL binding rules using their IDs.
+type ACLBindingRulesDeleteRequest struct {
+	ACLBindingRuleIDs []string
+	WriteRequest
+}
+
+// ACLBindingRulesListRequest is the request object used to list ACL binding
+// rules.
+type ACLBindingRulesListRequest struct {
+	QueryOptions
+}
+
+// ACLBindingRulesListResponse is the response object when performing ACL
+// binding rule listings.
+type ACLBindingRulesListResponse struct {
+	ACLBindingRules []*ACLBindingRuleListStub
+	QueryMeta
+}
+
+// ACLBindingRuleSpecificRequest is the request object used to lookup a single
+// ACL binding rule using its ID.
+type ACLBindingRuleSpecificRequest struct {
+	ACLBindingRuleID string
+	QueryOptions
+}
+
+// ACLBindingRuleSpecificResponse is the response object when performing a
+// lookup of a single ACL binding rule. If the binding rule could not be found,
+// ACLBindingRule will be nil.
+type ACLBindingRuleSpecificResponse struct {
+	ACLBindingRule *ACLBindingRule
+	QueryMeta
+}
+
+// ACLBindingRulesByIDRequest is the request object when performing a lookup
+// of multiple binding rules by their IDs.
+type ACLBindingRulesByIDRequest struct {
+	ACLBindingRuleIDs []string
+	QueryOptions
+}
+
+// ACLBindingRulesByIDResponse is the response object when performing a lookup
+// of multiple binding rules by their IDs.
+type ACLBindingRulesByIDResponse struct {
+	ACLBindingRules map[string]*ACLBindingRule
+	QueryMeta
+}
+
+// ACLOIDCAuthURLRequest is the request object used to start the OIDC login
+// flow and generate the URL of the identity provider.
+type ACLOIDCAuthURLRequest struct {
+	// AuthMethodName is the name of the OIDC auth method to log in with.
+	AuthMethodName string
+
+	// RedirectURI is the URL the identity provider redirects to once the
+	// user has authenticated. It must be one of the allowed redirect URIs of
+	// the auth method.
+	RedirectURI string
+
+	// ClientNonce is a randomly generated string, unique to this login
+	// attempt, which must be passed again when completing the login. It is
+	// embedded in the ID token to prevent replay attacks.
+	ClientNonce string
+
+	WriteRequest
+}
+
+// Validate ensures the request object contains all the required fields.
+func (a *ACLOIDCAuthURLRequest) Validate() error {
+	var mErr multierror.Error
+	if a.AuthMethodName == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing auth method name"))
+	}
+	if a.RedirectURI == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing redirect URI"))
+	}
+	if a.ClientNonce == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing client nonce"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLOIDCAuthURLResponse is the response object when generating the URL of
+// the identity provider.
+type ACLOIDCAuthURLResponse struct {
+	// AuthURL is the URL the user should visit to authenticate with the
+	// identity provider.
+	AuthURL string
+	WriteMeta
+}
+
+// ACLOIDCCompleteAuthRequest is the request object used to complete the OIDC
+// login flow, using the parameters the identity provider passed to the
+// redirect URI.
+type ACLOIDCCompleteAuthRequest struct {
+	// AuthMethodName is the name of the OIDC auth method to log in with.
+	AuthMethodName string
+
+	// ClientNonce is the nonce passed when generating the auth URL.
+	ClientNonce string
+
+	// State is the state parameter passed to the redirect URI.
+	State string
+
+	// Code is the authorization code passed to the redirect URI.
+	Code string
+
+	// RedirectURI is the redirect URI used when generating the auth URL.
+	RedirectURI string
+
+	WriteRequest
+}
+
+// Validate ensures the request object contains all the required fields.
+func (a *ACLOIDCCompleteAuthRequest) Validate() error {
+	var mErr multierror.Error
+	if a.AuthMethodName == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing auth method name"))
+	}
+	if a.ClientNonce == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing client nonce"))
+	}
+	if a.State == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing state"))
+	}
+	if a.Code == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing code"))
+	}
+	if a.RedirectURI == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing redirect URI"))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ACLLoginResponse is the response object when a login has completed
+// successfully and an ACL token has been minted.
+type ACLLoginResponse struct {
+	ACLToken *ACLToken
+	WriteMeta
+}
+
+// ACLAuthClaims are the claims of an identity authenticated by an auth
+// method, after applying the claim mappings of the auth method. Binding rule
+// selectors are evaluated against them, using "value.<name>" and
+// "list.<name>" to reference the mapped claims.
+type ACLAuthClaims struct {
+	Value map[string]string   `bexpr:"value"`
+	List  map[string][]string `bexpr:"list"`
+}
@@ -0,0 +1,305 @@
+package structs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestACLRole_SetHash(t *testing.T) {
+	ci.Parallel(t)
+
+	role := &ACLRole{
+		Name:        "acl-role-test",
+		Description: "mocked-test-acl-role",
+		Policies:    []*ACLRolePolicyLink{{Name: "foo"}},
+	}
+	out1 := role.SetHash()
+	require.NotEmpty(t, out1)
+	require.Equal(t, out1, role.Hash)
+
+	// The ID and indexes are not part of the hash.
+	role.ID = uuid.Generate()
+	role.ModifyIndex = 10
+	require.Equal(t, out1, role.SetHash())
+
+	role.Policies = append(role.Policies, &ACLRolePolicyLink{Name: "bar"})
+	out2 := role.SetHash()
+	require.NotEqual(t, out1, out2)
+}
+
+func TestACLRole_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		role     *ACLRole
+		expErr   bool
+		expErrIn string
+	}{
+		{
+			name:     "role name too long",
+			role:     &ACLRole{Name: uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate()},
+			expErr:   true,
+			expErrIn: "invalid name",
+		},
+		{
+			name:     "role name invalid",
+			role:     &ACLRole{Name: "acl role"},
+			expErr:   true,
+			expErrIn: "invalid name",
+		},
+		{
+			name: "role description too long",
+			role: &ACLRole{
+				Name:        "acl-role",
+				Description: uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate() + uuid.Generate(),
+			},
+			expErr:   true,
+			expErrIn: "description longer than",
+		},
+		{
+			name:     "no policies",
+			role:     &ACLRole{Name: "acl-role"},
+			expErr:   true,
+			expErrIn: "at least one policy should be specified",
+		},
+		{
+			name: "valid",
+			role: &ACLRole{
+				Name:     "acl-role",
+				Policies: []*ACLRolePolicyLink{{Name: "foo"}},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.role.Validate()
+			if tc.expErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErrIn)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLRole_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	role := &ACLRole{
+		ID:       uuid.Generate(),
+		Name:     "acl-role",
+		Policies: []*ACLRolePolicyLink{{Name: "foo"}},
+	}
+	role.SetHash()
+
+	out := role.Copy()
+	require.Equal(t, role, out)
+	require.True(t, role.Equal(out))
+
+	out.Policies[0].Name = "bar"
+	require.Equal(t, "foo", role.Policies[0].Name)
+	require.Nil(t, (*ACLRole)(nil).Copy())
+}
+
+func TestACLToken_SetHash_Roles(t *testing.T) {
+	ci.Parallel(t)
+
+	tk := &ACLToken{
+		Name:     "foo",
+		Type:     ACLClientToken,
+		Policies: []string{"foo"},
+	}
+	out1 := tk.SetHash()
+
+	tk.Roles = []*ACLTokenRoleLink{{ID: uuid.Generate()}}
+	out2 := tk.SetHash()
+	require.NotEqual(t, out1, out2)
+}
+
+func TestACLToken_IsExpired(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+	expiry := now.Add(time.Hour)
+
+	require.False(t, (*ACLToken)(nil).IsExpired(now))
+	require.False(t, (&ACLToken{}).IsExpired(now))
+	require.False(t, (&ACLToken{ExpirationTime: &expiry}).IsExpired(now))
+	require.True(t, (&ACLToken{ExpirationTime: &expiry}).IsExpired(expiry.Add(time.Second)))
+}
+
+func TestACLToken_CanonicalizeExpiration(t *testing.T) {
+	ci.Parallel(t)
+
+	now := time.Now().UTC()
+
+	// Tokens without a TTL are left alone.
+	token := &ACLToken{CreateTime: now}
+	token.CanonicalizeExpiration()
+	require.False(t, token.HasExpirationTime())
+
+	// The expiration time is relative to the creation time.
+	token.ExpirationTTL = time.Hour
+	token.CanonicalizeExpiration()
+	require.True(t, token.HasExpirationTime())
+	require.Equal(t, now.Add(time.Hour), *token.ExpirationTime)
+}
+
+func TestACLAuthMethod_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	validConfig := &ACLAuthMethodConfig{
+		OIDCDiscoveryURL:    "https://idp.example.com",
+		OIDCClientID:        "nomad",
+		AllowedRedirectURIs: []string{"http://localhost:4649/oidc/callback"},
+	}
+
+	testCases := []struct {
+		name       string
+		authMethod *ACLAuthMethod
+		expErr     bool
+		expErrIn   string
+	}{
+		{
+			name:       "name invalid",
+			authMethod: &ACLAuthMethod{Name: "auth method"},
+			expErr:     true,
+			expErrIn:   "invalid name",
+		},
+		{
+			name:       "type invalid",
+			authMethod: &ACLAuthMethod{Name: "idp", Type: "LDAP"},
+			expErr:     true,
+			expErrIn:   "invalid type",
+		},
+		{
+			name:       "token locality invalid",
+			authMethod: &ACLAuthMethod{Name: "idp", Type: ACLAuthMethodTypeOIDC, TokenLocality: "regional"},
+			expErr:     true,
+			expErrIn:   "invalid token locality",
+		},
+		{
+			name:       "max token TTL missing",
+			authMethod: &ACLAuthMethod{Name: "idp", Type: ACLAuthMethodTypeOIDC, TokenLocality: ACLAuthMethodTokenLocalityLocal},
+			expErr:     true,
+			expErrIn:   "max token TTL must be positive",
+		},
+		{
+			name: "config missing",
+			authMethod: &ACLAuthMethod{
+				Name:          "idp",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+			},
+			expErr:   true,
+			expErrIn: "missing config",
+		},
+		{
+			name: "redirect URIs missing",
+			authMethod: &ACLAuthMethod{
+				Name:          "idp",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityLocal,
+				MaxTokenTTL:   time.Hour,
+				Config: &ACLAuthMethodConfig{
+					OIDCDiscoveryURL: "https://idp.example.com",
+					OIDCClientID:     "nomad",
+				},
+			},
+			expErr:   true,
+			expErrIn: "at least one allowed redirect URI",
+		},
+		{
+			name: "valid",
+			authMethod: &ACLAuthMethod{
+				Name:          "idp",
+				Type:          ACLAuthMethodTypeOIDC,
+				TokenLocality: ACLAuthMethodTokenLocalityGlobal,
+				MaxTokenTTL:   time.Hour,
+				Config:        validConfig,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.authMethod.Validate()
+			if tc.expErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErrIn)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestACLBindingRule_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name     string
+		rule     *ACLBindingRule
+		expErr   bool
+		expErrIn string
+	}{
+		{
+			name:     "auth method missing",
+			rule:     &ACLBindingRule{BindType: ACLBindingRuleBindTypeRole, BindName: "eng"},
+			expErr:   true,
+			expErrIn: "auth method is missing",
+		},
+		{
+			name:     "bind type invalid",
+			rule:     &ACLBindingRule{AuthMethod: "idp", BindType: "group", BindName: "eng"},
+			expErr:   true,
+			expErrIn: "invalid bind type",
+		},
+		{
+			name:     "bind name missing",
+			rule:     &ACLBindingRule{AuthMethod: "idp", BindType: ACLBindingRuleBindTypePolicy},
+			expErr:   true,
+			expErrIn: "bind name is missing",
+		},
+		{
+			name: "selector invalid",
+			rule: &ACLBindingRule{
+				AuthMethod: "idp",
+				Selector:   `"engineering" in`,
+				BindType:   ACLBindingRuleBindTypeRole,
+				BindName:   "eng",
+			},
+			expErr:   true,
+			expErrIn: "invalid selector",
+		},
+		{
+			name: "valid",
+			rule: &ACLBindingRule{
+				AuthMethod: "idp",
+				Selector:   `"engineering" in list.groups`,
+				BindType:   ACLBindingRuleBindTypeRole,
+				BindName:   "eng",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rule.Validate()
+			if tc.expErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.expErrIn)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
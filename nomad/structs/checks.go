@@ -0,0 +1,57 @@
+package structs
+
+// CheckID is the unique identifier of a check of a service using the nomad
+// provider. It uses the same format as the IDs of checks registered in Consul.
+type CheckID string
+
+// CheckStatus is the status of a check of a service using the nomad provider.
+type CheckStatus string
+
+const (
+	// CheckPending indicates the check has not completed yet.
+	CheckPending CheckStatus = "pending"
+
+	// CheckSuccess indicates the last execution of the check was successful.
+	CheckSuccess CheckStatus = "success"
+
+	// CheckFailure indicates the last execution of the check failed.
+	CheckFailure CheckStatus = "failure"
+)
+
+// CheckQueryResult is the result of the last execution of a check of a service
+// using the nomad provider. The checks are run by the Nomad client which runs
+// the allocation of the service.
+type CheckQueryResult struct {
+	// ID is the unique identifier of the check.
+	ID CheckID
+
+	// Status is the status of the check.
+	Status CheckStatus
+
+	// StatusCode is the HTTP status code returned by an HTTP check.
+	StatusCode int `json:",omitempty"`
+
+	// Output is the human readable output of the check, such as the response
+	// body of an HTTP check or the error of a failed check.
+	Output string
+
+	// Timestamp is the time the check completed, in Unix seconds.
+	Timestamp int64
+
+	// Group, Task, Service and Check identify the check within the
+	// allocation. Task is empty for the checks of group services.
+	Group   string
+	Task    string `json:",omitempty"`
+	Service string
+	Check   string
+}
+
+// Copy returns a copy of the check query result.
+func (r *CheckQueryResult) Copy() *CheckQueryResult {
+	if r == nil {
+		return nil
+	}
+	nr := new(CheckQueryResult)
+	*nr = *r
+	return nr
+}
@@ -0,0 +1,89 @@
+package config
+
+import "github.com/mitchellh/copystructure"
+
+// ScorePluginConfig is used to configure a scoring plugin of the schedulers.
+// Scoring plugins rank nodes for placements alongside the built in scoring
+// iterators, such as bin packing and affinities.
+type ScorePluginConfig struct {
+	// Name is the name of the scoring plugin
+	Name string `hcl:",key"`
+
+	// Weight is the weight of the plugin's scores relative to the built in
+	// scores, which each have a weight of 1. Defaults to 1.
+	Weight float64 `hcl:"weight"`
+
+	// Config is the configuration passed to the plugin
+	Config map[string]interface{} `hcl:"config"`
+
+	// ExtraKeysHCL is used by hcl to surface unexpected keys
+	ExtraKeysHCL []string `hcl:",unusedKeys" json:"-"`
+}
+
+// EffectiveWeight returns the weight of the plugin's scores, defaulting to 1
+// if unset.
+func (p *ScorePluginConfig) EffectiveWeight() float64 {
+	if p.Weight == 0 {
+		return 1
+	}
+	return p.Weight
+}
+
+func (p *ScorePluginConfig) Merge(o *ScorePluginConfig) *ScorePluginConfig {
+	m := *p
+
+	if len(o.Name) != 0 {
+		m.Name = o.Name
+	}
+	if o.Weight != 0 {
+		m.Weight = o.Weight
+	}
+	if len(o.Config) != 0 {
+		m.Config = o.Config
+	}
+
+	return m.Copy()
+}
+
+func (p *ScorePluginConfig) Copy() *ScorePluginConfig {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+	if i, err := copystructure.Copy(p.Config); err != nil {
+		panic(err.Error())
+	} else {
+		c.Config = i.(map[string]interface{})
+	}
+	return &c
+}
+
+// ScorePluginConfigSetMerge merges two sets of scoring plugin configs. For
+// plugins with the same name, the configs are merged. The order of the first
+// set is kept, followed by the plugins only in the second set.
+func ScorePluginConfigSetMerge(first, second []*ScorePluginConfig) []*ScorePluginConfig {
+	sindex := make(map[string]*ScorePluginConfig, len(second))
+	for _, p := range second {
+		sindex[p.Name] = p
+	}
+
+	out := make([]*ScorePluginConfig, 0, len(first)+len(second))
+	merged := make(map[string]struct{}, len(first))
+	for _, original := range first {
+		merged[original.Name] = struct{}{}
+		if other, ok := sindex[original.Name]; ok {
+			out = append(out, original.Merge(other))
+		} else {
+			out = append(out, original.Copy())
+		}
+	}
+
+	for _, p := range second {
+		if _, ok := merged[p.Name]; !ok {
+			out = append(out, p.Copy())
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorePluginConfig_Merge(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+	a := &ScorePluginConfig{
+		Name:   "foo",
+		Weight: 2,
+		Config: map[string]interface{}{
+			"key": "bar",
+		},
+	}
+
+	o1 := a.Merge(&ScorePluginConfig{Weight: 0.5})
+	require.Equal(&ScorePluginConfig{
+		Name:   "foo",
+		Weight: 0.5,
+		Config: map[string]interface{}{
+			"key": "bar",
+		},
+	}, o1)
+
+	o2 := a.Merge(&ScorePluginConfig{
+		Config: map[string]interface{}{
+			"key": "baz",
+		},
+	})
+	require.Equal(&ScorePluginConfig{
+		Name:   "foo",
+		Weight: 2,
+		Config: map[string]interface{}{
+			"key": "baz",
+		},
+	}, o2)
+
+	require.Equal(1.0, (&ScorePluginConfig{}).EffectiveWeight())
+	require.Equal(2.0, a.EffectiveWeight())
+}
+
+func TestScorePluginConfigSet_Merge(t *testing.T) {
+	ci.Parallel(t)
+	require := require.New(t)
+
+	a := &ScorePluginConfig{Name: "a", Weight: 1}
+	b1 := &ScorePluginConfig{Name: "b", Weight: 1}
+	b2 := &ScorePluginConfig{Name: "b", Weight: 2}
+	c := &ScorePluginConfig{Name: "c", Weight: 3}
+
+	out := ScorePluginConfigSetMerge([]*ScorePluginConfig{a, b1}, []*ScorePluginConfig{b2, c})
+	require.Equal([]*ScorePluginConfig{a, b2, c}, out)
+}
@@ -93,6 +93,20 @@ func (j *Job) Diff(other *Job, contextual bool) (*JobDiff, error) {
 		diff.ID = other.ID
 	}
 
+	// Preemptible diff
+	if oldPrimitiveFlat != nil && newPrimitiveFlat != nil {
+		if j.Preemptible == nil {
+			oldPrimitiveFlat["Preemptible"] = ""
+		} else {
+			oldPrimitiveFlat["Preemptible"] = fmt.Sprintf("%v", *j.Preemptible)
+		}
+		if other.Preemptible == nil {
+			newPrimitiveFlat["Preemptible"] = ""
+		} else {
+			newPrimitiveFlat["Preemptible"] = fmt.Sprintf("%v", *other.Preemptible)
+		}
+	}
+
 	// Diff the primitive fields.
 	diff.Fields = fieldDiffs(oldPrimitiveFlat, newPrimitiveFlat, false)
 
@@ -505,7 +519,7 @@ func (t *Task) Diff(other *Task, contextual bool) (*TaskDiff, error) {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(t.LogConfig, other.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(t.LogConfig, other.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -1435,7 +1449,7 @@ func sidecarTaskDiff(old, new *SidecarTask, contextual bool) *ObjectDiff {
 	}
 
 	// LogConfig diff
-	lDiff := primitiveObjectDiff(old.LogConfig, new.LogConfig, nil, "LogConfig", contextual)
+	lDiff := logConfigDiff(old.LogConfig, new.LogConfig, contextual)
 	if lDiff != nil {
 		diff.Objects = append(diff.Objects, lDiff)
 	}
@@ -2044,6 +2058,11 @@ func (r *Resources) Diff(other *Resources, contextual bool) *ObjectDiff {
 		diff.Objects = append(diff.Objects, nDiffs...)
 	}
 
+	// NUMA diff
+	if nDiff := primitiveObjectDiff(r.NUMA, other.NUMA, nil, "NUMA", contextual); nDiff != nil {
+		diff.Objects = append(diff.Objects, nDiff)
+	}
+
 	return diff
 }
 
@@ -2566,6 +2585,26 @@ Loop:
 	return diff
 }
 
+// logConfigDiff returns the diff of two log configs, including the diff of
+// their sinks keyed by name.
+func logConfigDiff(old, new *LogConfig, contextual bool) *ObjectDiff {
+	diff := primitiveObjectDiff(old, new, nil, "LogConfig", contextual)
+
+	sDiffs := primitiveObjectSetDiff(
+		interfaceSlice(old.GetSinks()),
+		interfaceSlice(new.GetSinks()),
+		nil, "Sink", contextual)
+	if len(sDiffs) == 0 {
+		return diff
+	}
+
+	if diff == nil {
+		diff = &ObjectDiff{Type: DiffTypeEdited, Name: "LogConfig"}
+	}
+	diff.Objects = append(diff.Objects, sDiffs...)
+	return diff
+}
+
 // primitiveObjectSetDiff does a set difference of the old and new sets. The
 // filter parameter can be used to filter a set of primitive fields in the
 // passed structs. The name corresponds to the name of the passed objects. If
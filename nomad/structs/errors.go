@@ -3,8 +3,10 @@ package structs
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -12,6 +14,7 @@ const (
 	errNotReadyForConsistentReads = "Not ready to serve consistent reads"
 	errNoRegionPath               = "No path to region"
 	errTokenNotFound              = "ACL token not found"
+	errTokenExpired               = "ACL token expired"
 	errPermissionDenied           = "Permission denied"
 	errJobRegistrationDisabled    = "Job registration, dispatch, and scale are disabled by the scheduler configuration"
 	errNoNodeConn                 = "No path to node"
@@ -20,6 +23,8 @@ const (
 	errNodeLacksRpc               = "Node does not support RPC; requires 0.8 or later"
 	errMissingAllocID             = "Missing allocation ID"
 	errIncompatibleFiltering      = "Filter expression cannot be used with other filter parameters"
+	errEvalRateLimited            = "Evaluation rate limit exceeded"
+	errEvalRateLimitedRetryAfter  = "; retry after "
 
 	// Prefix based errors that are used to check if the error is of a given
 	// type. These errors should be created with the associated constructor.
@@ -47,6 +52,7 @@ var (
 	ErrNotReadyForConsistentReads = errors.New(errNotReadyForConsistentReads)
 	ErrNoRegionPath               = errors.New(errNoRegionPath)
 	ErrTokenNotFound              = errors.New(errTokenNotFound)
+	ErrTokenExpired               = errors.New(errTokenExpired)
 	ErrPermissionDenied           = errors.New(errPermissionDenied)
 	ErrJobRegistrationDisabled    = errors.New(errJobRegistrationDisabled)
 	ErrNoNodeConn                 = errors.New(errNoNodeConn)
@@ -91,6 +97,12 @@ func IsErrTokenNotFound(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errTokenNotFound)
 }
 
+// IsErrTokenExpired returns whether the error is due to the passed token
+// having passed its expiration time.
+func IsErrTokenExpired(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errTokenExpired)
+}
+
 // IsErrPermissionDenied returns whether the error is due to the operation not
 // being allowed due to lack of permissions.
 func IsErrPermissionDenied(err error) bool {
@@ -183,6 +195,42 @@ func IsErrNodeLacksRpc(err error) bool {
 	return err != nil && strings.Contains(err.Error(), errNodeLacksRpc)
 }
 
+// NewErrEvalRateLimited returns a new error caused by a call being rejected by
+// the evaluation rate limits of the scope, such as a namespace or a job. The
+// error is coded with the HTTP status code 429 and the number of seconds after
+// which the call can be retried.
+func NewErrEvalRateLimited(scope string, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return NewErrRPCCodedf(429, "%s for %s%s%ds", errEvalRateLimited, scope, errEvalRateLimitedRetryAfter, seconds)
+}
+
+// IsErrEvalRateLimited returns whether the error is due to the call being
+// rejected by the evaluation rate limits.
+func IsErrEvalRateLimited(err error) bool {
+	return err != nil && strings.Contains(err.Error(), errEvalRateLimited)
+}
+
+// EvalRateLimitedRetryAfter returns the number of seconds after which a call
+// rejected by the evaluation rate limits can be retried, given the message of
+// the error. Returns false if the message isn't from such an error.
+func EvalRateLimitedRetryAfter(msg string) (int, bool) {
+	if !strings.Contains(msg, errEvalRateLimited) {
+		return 0, false
+	}
+	idx := strings.LastIndex(msg, errEvalRateLimitedRetryAfter)
+	if idx == -1 {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(strings.TrimSuffix(msg[idx+len(errEvalRateLimitedRetryAfter):], "s"))
+	if err != nil {
+		return 0, false
+	}
+	return seconds, true
+}
+
 func IsErrNoSuchFileOrDirectory(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "no such file or directory")
 }
@@ -0,0 +1,305 @@
+package structs
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+const (
+	// KeyringRotateRPCMethod is the RPC method for generating a new root key
+	// and making it the active key.
+	//
+	// Args: KeyringRotateRootKeyRequest
+	// Reply: KeyringRotateRootKeyResponse
+	KeyringRotateRPCMethod = "Keyring.Rotate"
+
+	// KeyringListRPCMethod is the RPC method for listing the metadata of
+	// the root keys.
+	//
+	// Args: KeyringListRootKeyMetaRequest
+	// Reply: KeyringListRootKeyMetaResponse
+	KeyringListRPCMethod = "Keyring.List"
+
+	// KeyringDeleteRPCMethod is the RPC method for removing an inactive root
+	// key which is no longer used to encrypt any data.
+	//
+	// Args: KeyringDeleteRootKeyRequest
+	// Reply: KeyringDeleteRootKeyResponse
+	KeyringDeleteRPCMethod = "Keyring.Delete"
+
+	// KeyringUpdateRPCMethod is the RPC method for updating the state of a
+	// root key. It is used by the core scheduler once the data of a key has
+	// been rekeyed.
+	//
+	// Args: KeyringUpdateRootKeyMetaRequest
+	// Reply: KeyringUpdateRootKeyMetaResponse
+	KeyringUpdateRPCMethod = "Keyring.Update"
+
+	// KeyringGetRPCMethod is the RPC method for fetching a root key, along
+	// with its key material, from the keyring of a server. It is only used by
+	// servers to replicate the key material, which is not written to Raft.
+	//
+	// Args: KeyringGetRootKeyRequest
+	// Reply: KeyringGetRootKeyResponse
+	KeyringGetRPCMethod = "Keyring.Get"
+
+	// KeyringListPublicRPCMethod is the RPC method for listing the public
+	// keys used to verify workload identities. It requires no ACL token.
+	//
+	// Args: GenericRequest
+	// Reply: KeyringListPublicResponse
+	KeyringListPublicRPCMethod = "Keyring.ListPublic"
+)
+
+// EncryptionAlgorithm chooses which algorithm is used for encrypting or
+// decrypting data with a root key.
+type EncryptionAlgorithm string
+
+const (
+	// EncryptionAlgorithmAES256GCM uses a 256-bit key with AES-GCM. This is
+	// the only supported algorithm.
+	EncryptionAlgorithmAES256GCM EncryptionAlgorithm = "aes256-gcm"
+)
+
+// RootKeyState enum describes the lifecycle of a root key.
+type RootKeyState string
+
+const (
+	// RootKeyStateActive is the state of the single root key that is used to
+	// encrypt new data.
+	RootKeyStateActive RootKeyState = "active"
+
+	// RootKeyStateInactive is the state of root keys which are no longer used
+	// to encrypt new data, but may still be required to decrypt existing
+	// data.
+	RootKeyStateInactive RootKeyState = "inactive"
+
+	// RootKeyStateRekeying is the state of root keys which are no longer
+	// used to encrypt new data, and whose existing data is being re-encrypted
+	// with the active key. Once that completes the key becomes inactive.
+	RootKeyStateRekeying RootKeyState = "rekeying"
+)
+
+// RootKey is used to encrypt and decrypt data held within state, such as
+// variables, and to sign workload identities. Only the metadata is written to
+// Raft and held in the state store. The key material is generated by the
+// leader and replicated to the other servers over RPC, and each server keeps
+// it encrypted in its local keystore.
+type RootKey struct {
+	Meta *RootKeyMeta
+	Key  []byte
+}
+
+// NewRootKey returns a new root key and its metadata, using cryptographically
+// secure random bytes for the key material.
+func NewRootKey(algorithm EncryptionAlgorithm) (*RootKey, error) {
+	meta := &RootKeyMeta{
+		KeyID:      uuid.Generate(),
+		Algorithm:  algorithm,
+		CreateTime: time.Now().UTC().UnixNano(),
+		State:      RootKeyStateActive,
+	}
+
+	var keyLen int
+	switch algorithm {
+	case EncryptionAlgorithmAES256GCM:
+		keyLen = 32
+	default:
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", algorithm)
+	}
+
+	key := make([]byte, keyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key material: %v", err)
+	}
+
+	return &RootKey{
+		Meta: meta,
+		Key:  key,
+	}, nil
+}
+
+// Copy returns a deep copy of the root key. It handles nil objects.
+func (k *RootKey) Copy() *RootKey {
+	if k == nil {
+		return nil
+	}
+	key := make([]byte, len(k.Key))
+	copy(key, k.Key)
+	return &RootKey{
+		Meta: k.Meta.Copy(),
+		Key:  key,
+	}
+}
+
+// Validate returns an error if the root key is invalid.
+func (k *RootKey) Validate() error {
+	if k == nil {
+		return fmt.Errorf("root key is required")
+	}
+	if len(k.Key) == 0 {
+		return fmt.Errorf("root key material is required")
+	}
+	return k.Meta.Validate()
+}
+
+// RootKeyMeta is the metadata used to refer to a RootKey.
+type RootKeyMeta struct {
+	KeyID       string
+	Algorithm   EncryptionAlgorithm
+	CreateTime  int64
+	CreateIndex uint64
+	ModifyIndex uint64
+	State       RootKeyState
+}
+
+// Active indicates this key is the one currently being used for encryption.
+func (rkm *RootKeyMeta) Active() bool {
+	return rkm != nil && rkm.State == RootKeyStateActive
+}
+
+// Rekeying indicates the data encrypted with this key is being re-encrypted
+// with the active key.
+func (rkm *RootKeyMeta) Rekeying() bool {
+	return rkm != nil && rkm.State == RootKeyStateRekeying
+}
+
+// Inactive indicates this key is no longer used to encrypt data, and is no
+// longer being rekeyed.
+func (rkm *RootKeyMeta) Inactive() bool {
+	return rkm != nil && rkm.State == RootKeyStateInactive
+}
+
+// Copy returns a copy of the root key metadata. It handles nil objects.
+func (rkm *RootKeyMeta) Copy() *RootKeyMeta {
+	if rkm == nil {
+		return nil
+	}
+	out := *rkm
+	return &out
+}
+
+// Validate returns an error if the root key metadata is invalid.
+func (rkm *RootKeyMeta) Validate() error {
+	if rkm == nil {
+		return fmt.Errorf("root key metadata is required")
+	}
+	if rkm.KeyID == "" || !helper.IsUUID(rkm.KeyID) {
+		return fmt.Errorf("root key UUID is required")
+	}
+	if rkm.Algorithm == "" {
+		return fmt.Errorf("root key algorithm is required")
+	}
+	switch rkm.State {
+	case RootKeyStateInactive, RootKeyStateActive, RootKeyStateRekeying:
+	default:
+		return fmt.Errorf("root key state %q is invalid", rkm.State)
+	}
+	return nil
+}
+
+// RootKeyUpsertRequest is the request older servers wrote to Raft to upsert
+// a root key along with its key material. It is only decoded when applying
+// their log entries.
+type RootKeyUpsertRequest struct {
+	RootKey *RootKey
+	WriteRequest
+}
+
+// RootKeyMetaUpsertRequest is used to write the metadata of a root key into
+// state. Writing an active key marks all other active keys as inactive, or as
+// rekeying if Rekey is set.
+type RootKeyMetaUpsertRequest struct {
+	RootKeyMeta *RootKeyMeta
+	Rekey       bool
+	WriteRequest
+}
+
+// RootKeyDeleteRequest is used to remove a root key from the keystore and its
+// metadata from state.
+type RootKeyDeleteRequest struct {
+	KeyID string
+	WriteRequest
+}
+
+// KeyringRotateRootKeyRequest is the argument to the Keyring.Rotate RPC.
+type KeyringRotateRootKeyRequest struct {
+	Algorithm EncryptionAlgorithm
+
+	// Full indicates that all existing data should be re-encrypted with the
+	// new key, so the old keys can be removed.
+	Full bool
+	WriteRequest
+}
+
+// KeyringRotateRootKeyResponse is the response to the Keyring.Rotate RPC.
+type KeyringRotateRootKeyResponse struct {
+	Key *RootKeyMeta
+	WriteMeta
+}
+
+// KeyringListRootKeyMetaRequest is the argument to the Keyring.List RPC.
+type KeyringListRootKeyMetaRequest struct {
+	QueryOptions
+}
+
+// KeyringListRootKeyMetaResponse is the response to the Keyring.List RPC.
+type KeyringListRootKeyMetaResponse struct {
+	Keys []*RootKeyMeta
+	QueryMeta
+}
+
+// KeyringDeleteRootKeyRequest is the argument to the Keyring.Delete RPC.
+type KeyringDeleteRootKeyRequest struct {
+	KeyID string
+	WriteRequest
+}
+
+// KeyringDeleteRootKeyResponse is the response to the Keyring.Delete RPC.
+type KeyringDeleteRootKeyResponse struct {
+	WriteMeta
+}
+
+// KeyringUpdateRootKeyMetaRequest is the argument to the Keyring.Update RPC.
+type KeyringUpdateRootKeyMetaRequest struct {
+	RootKeyMeta *RootKeyMeta
+	WriteRequest
+}
+
+// KeyringUpdateRootKeyMetaResponse is the response to the Keyring.Update RPC.
+type KeyringUpdateRootKeyMetaResponse struct {
+	WriteMeta
+}
+
+// KeyringGetRootKeyRequest is the argument to the Keyring.Get RPC.
+type KeyringGetRootKeyRequest struct {
+	KeyID string
+	QueryOptions
+}
+
+// KeyringGetRootKeyResponse is the response to the Keyring.Get RPC. The key
+// is nil if the server does not hold it.
+type KeyringGetRootKeyResponse struct {
+	Key *RootKey
+	QueryMeta
+}
+
+// KeyringPublicKey is the public portion of a root key, which is used to
+// verify workload identities signed by the servers.
+type KeyringPublicKey struct {
+	KeyID      string
+	PublicKey  []byte
+	Algorithm  string
+	Use        string
+	CreateTime int64
+}
+
+// KeyringListPublicResponse is the response to the Keyring.ListPublic RPC.
+type KeyringListPublicResponse struct {
+	PublicKeys []*KeyringPublicKey
+	QueryMeta
+}
@@ -0,0 +1,241 @@
+package structs
+
+import (
+	"fmt"
+	"regexp"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// NodePoolUpsertRPCMethod is the RPC method for creating or updating node
+	// pools.
+	//
+	// Args: NodePoolUpsertRequest
+	// Reply: GenericResponse
+	NodePoolUpsertRPCMethod = "NodePool.UpsertNodePools"
+
+	// NodePoolDeleteRPCMethod is the RPC method for deleting node pools.
+	//
+	// Args: NodePoolDeleteRequest
+	// Reply: GenericResponse
+	NodePoolDeleteRPCMethod = "NodePool.DeleteNodePools"
+
+	// NodePoolListRPCMethod is the RPC method for listing node pools.
+	//
+	// Args: NodePoolListRequest
+	// Reply: NodePoolListResponse
+	NodePoolListRPCMethod = "NodePool.List"
+
+	// NodePoolGetRPCMethod is the RPC method for detailing a single node pool.
+	//
+	// Args: NodePoolSpecificRequest
+	// Reply: SingleNodePoolResponse
+	NodePoolGetRPCMethod = "NodePool.GetNodePool"
+)
+
+const (
+	// NodePoolAll is a built-in node pool that always includes all nodes in
+	// the cluster. Jobs placed in this pool can be scheduled on any node,
+	// regardless of the pool the node is a member of.
+	NodePoolAll            = "all"
+	NodePoolAllDescription = "Node pool with all nodes in the cluster."
+
+	// NodePoolDefault is a built-in node pool for nodes that don't specify a
+	// pool in their configuration, and for jobs that don't specify a pool.
+	NodePoolDefault            = "default"
+	NodePoolDefaultDescription = "Default node pool."
+
+	// maxNodePoolDescriptionLength is the maximum length allowed for a node
+	// pool description.
+	maxNodePoolDescriptionLength = 256
+)
+
+var (
+	// validNodePoolName is the validation regex for node pool names. It
+	// mirrors the regex used for namespaces.
+	validNodePoolName = regexp.MustCompile("^[a-zA-Z0-9-_]{1,128}$")
+)
+
+// NodePool allows partitioning the nodes in a cluster into logical groups.
+// Jobs are only placed on nodes in the pool they target, and each pool can
+// override parts of the cluster's scheduler configuration.
+type NodePool struct {
+	// Name is the node pool name. It must be unique.
+	Name string
+
+	// Description is the human-friendly description of the node pool.
+	Description string
+
+	// Meta is a set of user-provided metadata for the node pool.
+	Meta map[string]string
+
+	// SchedulerConfiguration is the scheduler configuration specific to the
+	// node pool. Fields which are left unset fall back to the cluster-wide
+	// SchedulerConfiguration.
+	SchedulerConfiguration *NodePoolSchedulerConfiguration
+
+	// Raft indexes.
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+// GetID is a helper for getting the name when the object may be nil and is
+// required for pagination.
+func (n *NodePool) GetID() string {
+	if n == nil {
+		return ""
+	}
+	return n.Name
+}
+
+// Validate returns an error if the node pool is invalid.
+func (n *NodePool) Validate() error {
+	var mErr *multierror.Error
+
+	if !validNodePoolName.MatchString(n.Name) {
+		mErr = multierror.Append(mErr, fmt.Errorf("invalid name %q, must match regex %s", n.Name, validNodePoolName))
+	}
+	if len(n.Description) > maxNodePoolDescriptionLength {
+		mErr = multierror.Append(mErr, fmt.Errorf("description longer than %d", maxNodePoolDescriptionLength))
+	}
+	if err := n.SchedulerConfiguration.Validate(); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// Copy returns a deep copy of the node pool. It handles nil objects.
+func (n *NodePool) Copy() *NodePool {
+	if n == nil {
+		return nil
+	}
+
+	nc := new(NodePool)
+	*nc = *n
+	nc.Meta = helper.CopyMapStringString(nc.Meta)
+	nc.SchedulerConfiguration = nc.SchedulerConfiguration.Copy()
+
+	return nc
+}
+
+// IsBuiltIn returns true if the node pool is one of the built-in pools which
+// are created automatically and cannot be modified or deleted.
+func (n *NodePool) IsBuiltIn() bool {
+	return n != nil && IsBuiltInNodePool(n.Name)
+}
+
+// IsBuiltInNodePool returns true if the name identifies a built-in node pool.
+func IsBuiltInNodePool(name string) bool {
+	switch name {
+	case NodePoolAll, NodePoolDefault:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidNodePoolName returns true if the name is a valid node pool name.
+func ValidNodePoolName(name string) bool {
+	return validNodePoolName.MatchString(name)
+}
+
+// NodePoolMatches returns true if a node that is a member of nodePool is
+// eligible to receive placements for a job that targets jobPool. An empty pool
+// on either side is treated as the default pool, so nodes and jobs written by
+// older versions of Nomad continue to be scheduled together.
+func NodePoolMatches(jobPool, nodePool string) bool {
+	if jobPool == NodePoolAll {
+		return true
+	}
+	if jobPool == "" {
+		jobPool = NodePoolDefault
+	}
+	if nodePool == "" {
+		nodePool = NodePoolDefault
+	}
+	return jobPool == nodePool
+}
+
+// NodePoolSchedulerConfiguration is the scheduler configuration that can be
+// overridden on a per-node-pool basis. Pointer fields are used where the zero
+// value is meaningful, so that an unset field inherits the global value.
+type NodePoolSchedulerConfiguration struct {
+	// SchedulerAlgorithm is the scheduling algorithm to use for the pool. If
+	// empty, the global scheduler algorithm is used.
+	SchedulerAlgorithm SchedulerAlgorithm
+
+	// MemoryOversubscriptionEnabled specifies whether memory oversubscription
+	// is enabled for the pool. If nil, the global setting is used.
+	MemoryOversubscriptionEnabled *bool
+}
+
+// Copy returns a deep copy of the node pool scheduler configuration. It
+// handles nil objects.
+func (n *NodePoolSchedulerConfiguration) Copy() *NodePoolSchedulerConfiguration {
+	if n == nil {
+		return nil
+	}
+
+	nc := new(NodePoolSchedulerConfiguration)
+	*nc = *n
+	if n.MemoryOversubscriptionEnabled != nil {
+		nc.MemoryOversubscriptionEnabled = helper.BoolToPtr(*n.MemoryOversubscriptionEnabled)
+	}
+
+	return nc
+}
+
+// Validate returns an error if the node pool scheduler configuration is
+// invalid.
+func (n *NodePoolSchedulerConfiguration) Validate() error {
+	if n == nil {
+		return nil
+	}
+
+	switch n.SchedulerAlgorithm {
+	case "", SchedulerAlgorithmBinpack, SchedulerAlgorithmSpread:
+	default:
+		return fmt.Errorf("invalid scheduler algorithm: %v", n.SchedulerAlgorithm)
+	}
+
+	return nil
+}
+
+// NodePoolUpsertRequest is used to create or update a set of node pools.
+type NodePoolUpsertRequest struct {
+	NodePools []*NodePool
+	WriteRequest
+}
+
+// NodePoolDeleteRequest is used to delete a set of node pools.
+type NodePoolDeleteRequest struct {
+	Names []string
+	WriteRequest
+}
+
+// NodePoolListRequest is used to list node pools.
+type NodePoolListRequest struct {
+	QueryOptions
+}
+
+// NodePoolListResponse is the response object when listing node pools.
+type NodePoolListResponse struct {
+	NodePools []*NodePool
+	QueryMeta
+}
+
+// NodePoolSpecificRequest is used to make a request for a single node pool.
+type NodePoolSpecificRequest struct {
+	Name string
+	QueryOptions
+}
+
+// SingleNodePoolResponse is the response object when detailing a single node
+// pool.
+type SingleNodePoolResponse struct {
+	NodePool *NodePool
+	QueryMeta
+}
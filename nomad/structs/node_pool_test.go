@@ -0,0 +1,149 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePool_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	pool := &NodePool{
+		Name:        "original",
+		Description: "original node pool",
+		Meta:        map[string]string{"original": "true"},
+		SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+			SchedulerAlgorithm:            SchedulerAlgorithmSpread,
+			MemoryOversubscriptionEnabled: helper.BoolToPtr(true),
+		},
+	}
+	poolCopy := pool.Copy()
+	poolCopy.Name = "copy"
+	poolCopy.Meta["original"] = "false"
+	poolCopy.SchedulerConfiguration.SchedulerAlgorithm = SchedulerAlgorithmBinpack
+	*poolCopy.SchedulerConfiguration.MemoryOversubscriptionEnabled = false
+
+	require.Equal(t, "original", pool.Name)
+	require.Equal(t, "true", pool.Meta["original"])
+	require.Equal(t, SchedulerAlgorithmSpread, pool.SchedulerConfiguration.SchedulerAlgorithm)
+	require.True(t, *pool.SchedulerConfiguration.MemoryOversubscriptionEnabled)
+	require.Nil(t, (*NodePool)(nil).Copy())
+}
+
+func TestNodePool_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		pool        *NodePool
+		expectedErr string
+	}{
+		{
+			name: "valid pool",
+			pool: &NodePool{
+				Name:        "valid",
+				Description: "test node pool",
+			},
+		},
+		{
+			name:        "invalid name",
+			pool:        &NodePool{Name: "not@valid"},
+			expectedErr: "invalid name",
+		},
+		{
+			name:        "missing name",
+			pool:        &NodePool{},
+			expectedErr: "invalid name",
+		},
+		{
+			name: "description too long",
+			pool: &NodePool{
+				Name:        "valid",
+				Description: strings.Repeat("a", 300),
+			},
+			expectedErr: "description longer than 256",
+		},
+		{
+			name: "invalid scheduler algorithm",
+			pool: &NodePool{
+				Name: "valid",
+				SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+					SchedulerAlgorithm: "invalid",
+				},
+			},
+			expectedErr: "invalid scheduler algorithm",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.pool.Validate()
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNodePoolMatches(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		jobPool  string
+		nodePool string
+		expected bool
+	}{
+		{jobPool: "", nodePool: "", expected: true},
+		{jobPool: "", nodePool: NodePoolDefault, expected: true},
+		{jobPool: NodePoolDefault, nodePool: "", expected: true},
+		{jobPool: NodePoolDefault, nodePool: "gpu", expected: false},
+		{jobPool: "gpu", nodePool: "gpu", expected: true},
+		{jobPool: "gpu", nodePool: "", expected: false},
+		{jobPool: NodePoolAll, nodePool: "gpu", expected: true},
+		{jobPool: NodePoolAll, nodePool: "", expected: true},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, NodePoolMatches(tc.jobPool, tc.nodePool),
+			"job pool %q, node pool %q", tc.jobPool, tc.nodePool)
+	}
+}
+
+func TestSchedulerConfiguration_WithNodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	global := &SchedulerConfiguration{
+		SchedulerAlgorithm:            SchedulerAlgorithmBinpack,
+		MemoryOversubscriptionEnabled: false,
+	}
+
+	// A pool without a scheduler configuration returns the global config.
+	require.Same(t, global, global.WithNodePool(&NodePool{Name: "empty"}))
+	require.Same(t, global, global.WithNodePool(nil))
+
+	// Pool overrides are applied to a copy.
+	pool := &NodePool{
+		Name: "spread",
+		SchedulerConfiguration: &NodePoolSchedulerConfiguration{
+			SchedulerAlgorithm:            SchedulerAlgorithmSpread,
+			MemoryOversubscriptionEnabled: helper.BoolToPtr(true),
+		},
+	}
+	out := global.WithNodePool(pool)
+	require.Equal(t, SchedulerAlgorithmSpread, out.SchedulerAlgorithm)
+	require.True(t, out.MemoryOversubscriptionEnabled)
+	require.Equal(t, SchedulerAlgorithmBinpack, global.SchedulerAlgorithm)
+	require.False(t, global.MemoryOversubscriptionEnabled)
+
+	// Unset fields inherit the global configuration.
+	pool.SchedulerConfiguration.MemoryOversubscriptionEnabled = nil
+	out = global.WithNodePool(pool)
+	require.Equal(t, SchedulerAlgorithmSpread, out.SchedulerAlgorithm)
+	require.False(t, out.MemoryOversubscriptionEnabled)
+}
@@ -2,6 +2,7 @@ package structs
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/raft"
@@ -156,6 +157,15 @@ type SchedulerConfiguration struct {
 	// management ACL token
 	RejectJobRegistration bool `hcl:"reject_job_registration"`
 
+	// EvalBrokerFairShareEnabled specifies whether the eval broker dequeues
+	// ready evaluations fairly across namespaces, according to their
+	// FairShareWeight, rather than strictly by priority.
+	EvalBrokerFairShareEnabled bool `hcl:"eval_broker_fair_share_enabled"`
+
+	// RebalanceConfig specifies whether and how allocations are periodically
+	// migrated off under-utilized nodes to improve bin-packing.
+	RebalanceConfig RebalanceConfig `hcl:"rebalance_config"`
+
 	// CreateIndex/ModifyIndex store the create/modify indexes of this configuration.
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -169,6 +179,30 @@ func (s *SchedulerConfiguration) EffectiveSchedulerAlgorithm() SchedulerAlgorith
 	return s.SchedulerAlgorithm
 }
 
+// WithNodePool returns a copy of the scheduler configuration with the
+// overrides of the given node pool applied. The receiver is returned unchanged
+// if the pool has no scheduler configuration.
+func (s *SchedulerConfiguration) WithNodePool(pool *NodePool) *SchedulerConfiguration {
+	if pool == nil || pool.SchedulerConfiguration == nil {
+		return s
+	}
+
+	config := new(SchedulerConfiguration)
+	if s != nil {
+		*config = *s
+	}
+
+	poolConfig := pool.SchedulerConfiguration
+	if poolConfig.SchedulerAlgorithm != "" {
+		config.SchedulerAlgorithm = poolConfig.SchedulerAlgorithm
+	}
+	if poolConfig.MemoryOversubscriptionEnabled != nil {
+		config.MemoryOversubscriptionEnabled = *poolConfig.MemoryOversubscriptionEnabled
+	}
+
+	return config
+}
+
 func (s *SchedulerConfiguration) Canonicalize() {
 	if s != nil && s.SchedulerAlgorithm == "" {
 		s.SchedulerAlgorithm = SchedulerAlgorithmBinpack
@@ -186,7 +220,11 @@ func (s *SchedulerConfiguration) Validate() error {
 		return fmt.Errorf("invalid scheduler algorithm: %v", s.SchedulerAlgorithm)
 	}
 
-	return nil
+	if err := s.PreemptionConfig.Validate(); err != nil {
+		return err
+	}
+
+	return s.RebalanceConfig.Validate()
 }
 
 // SchedulerConfigurationResponse is the response object that wraps SchedulerConfiguration
@@ -197,6 +235,31 @@ type SchedulerConfigurationResponse struct {
 	QueryMeta
 }
 
+// SchedulerBrokerStatsResponse is the response object that wraps the
+// statistics of the eval broker queues by namespace
+type SchedulerBrokerStatsResponse struct {
+	// FairShareEnabled is whether the eval broker dequeues ready evaluations
+	// fairly across namespaces
+	FairShareEnabled bool
+
+	// Namespaces contains the statistics of the namespaces with ready
+	// evaluations or a fair share weight
+	Namespaces map[string]*SchedulerBrokerNamespaceStats
+
+	QueryMeta
+}
+
+// SchedulerBrokerNamespaceStats are the statistics of the eval broker queue of
+// a namespace
+type SchedulerBrokerNamespaceStats struct {
+	// Weight is the fair share weight of the namespace
+	Weight int
+
+	// Ready is the number of ready evaluations of the namespace waiting to be
+	// dequeued by the schedulers
+	Ready int
+}
+
 // SchedulerSetConfigurationResponse is the response object used
 // when updating scheduler configuration
 type SchedulerSetConfigurationResponse struct {
@@ -220,6 +283,168 @@ type PreemptionConfig struct {
 
 	// ServiceSchedulerEnabled specifies if preemption is enabled for service jobs
 	ServiceSchedulerEnabled bool `hcl:"service_scheduler_enabled"`
+
+	// VictimRules are the names of the rules used to protect allocations
+	// from preemption, or to rank them as preemption victims, in addition to
+	// their job priority and resource fit.
+	VictimRules []string `hcl:"victim_rules"`
+}
+
+const (
+	// PreemptionVictimRuleDeployment protects the allocations of active
+	// deployments from preemption.
+	PreemptionVictimRuleDeployment = "deployment"
+
+	// PreemptionVictimRuleMinHealthy protects allocations from preemption
+	// if it would leave fewer allocations of their task group running than
+	// its count minus its migrate max_parallel.
+	PreemptionVictimRuleMinHealthy = "min-healthy"
+
+	// PreemptionVictimRuleEphemeral prefers preempting the allocations of
+	// jobs or task groups with the "ephemeral" metadata set to true.
+	PreemptionVictimRuleEphemeral = "ephemeral"
+)
+
+// PreemptionVictimRules are the known preemption victim rules.
+var PreemptionVictimRules = []string{
+	PreemptionVictimRuleDeployment,
+	PreemptionVictimRuleMinHealthy,
+	PreemptionVictimRuleEphemeral,
+}
+
+// Validate returns an error if any of the victim rules is unknown or is
+// listed more than once.
+func (p *PreemptionConfig) Validate() error {
+	seen := make(map[string]struct{}, len(p.VictimRules))
+	for _, rule := range p.VictimRules {
+		if _, ok := seen[rule]; ok {
+			return fmt.Errorf("preemption victim rule %q is listed more than once", rule)
+		}
+		seen[rule] = struct{}{}
+
+		known := false
+		for _, name := range PreemptionVictimRules {
+			if rule == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("invalid preemption victim rule %q, must be one of: %s",
+				rule, strings.Join(PreemptionVictimRules, ", "))
+		}
+	}
+	return nil
+}
+
+const (
+	// DefaultRebalanceMaxMigrations is the number of allocations migrated in
+	// a rebalancing round if the disruption budget isn't set.
+	DefaultRebalanceMaxMigrations = 5
+
+	// DefaultRebalanceUtilizationThreshold is the utilization below which
+	// nodes are emptied by rebalancing if the threshold isn't set.
+	DefaultRebalanceUtilizationThreshold = 0.5
+)
+
+// RebalanceConfig specifies whether and how allocations are periodically
+// migrated off under-utilized nodes, so that the nodes can be emptied.
+type RebalanceConfig struct {
+	// Enabled specifies if the rebalancing rounds run periodically by the
+	// leader migrate allocations. When disabled, the proposed migrations can
+	// still be reviewed and applied through the API.
+	Enabled bool `hcl:"enabled"`
+
+	// MaxMigrations is the disruption budget of a rebalancing round: the
+	// maximum number of allocations it migrates.
+	MaxMigrations int `hcl:"max_migrations"`
+
+	// UtilizationThreshold is the fraction of CPU or memory utilization,
+	// between 0 and 1, below which nodes are emptied.
+	UtilizationThreshold float64 `hcl:"utilization_threshold"`
+}
+
+// EffectiveMaxMigrations returns the disruption budget of a rebalancing round.
+func (r *RebalanceConfig) EffectiveMaxMigrations() int {
+	if r == nil || r.MaxMigrations == 0 {
+		return DefaultRebalanceMaxMigrations
+	}
+	return r.MaxMigrations
+}
+
+// EffectiveUtilizationThreshold returns the utilization below which nodes are
+// emptied.
+func (r *RebalanceConfig) EffectiveUtilizationThreshold() float64 {
+	if r == nil || r.UtilizationThreshold == 0 {
+		return DefaultRebalanceUtilizationThreshold
+	}
+	return r.UtilizationThreshold
+}
+
+func (r *RebalanceConfig) Validate() error {
+	if r.MaxMigrations < 0 {
+		return fmt.Errorf("rebalance max migrations must be positive: %d", r.MaxMigrations)
+	}
+	if r.UtilizationThreshold < 0 || r.UtilizationThreshold > 1 {
+		return fmt.Errorf("rebalance utilization threshold must be between 0 and 1: %v", r.UtilizationThreshold)
+	}
+	return nil
+}
+
+// SchedulerRebalanceRequest is used by the Operator endpoint to compute, and
+// optionally apply, a round of rebalancing migrations.
+type SchedulerRebalanceRequest struct {
+	// DryRun only reports the proposed migrations. Otherwise the allocations
+	// are marked for migration and evaluations are created for their jobs.
+	DryRun bool
+
+	// MaxMigrations overrides the disruption budget of the scheduler
+	// configuration when set.
+	MaxMigrations int
+
+	WriteRequest
+}
+
+// SchedulerRebalanceResponse is the response of a round of rebalancing.
+type SchedulerRebalanceResponse struct {
+	// Nodes are the under-utilized nodes allocations are migrated off.
+	Nodes []*RebalanceNode
+
+	// Migrations are the proposed allocation migrations.
+	Migrations []*RebalanceMigration
+
+	// EvalIDs are the IDs of the evaluations created when the migrations were
+	// applied, unless the request was a dry run.
+	EvalIDs []string
+
+	WriteMeta
+}
+
+// RebalanceNode is an under-utilized node emptied by rebalancing.
+type RebalanceNode struct {
+	ID   string
+	Name string
+
+	// Utilization is the highest of the CPU and memory utilization of the
+	// node, between 0 and 1.
+	Utilization float64
+}
+
+// RebalanceMigration is an allocation proposed to be migrated by rebalancing.
+type RebalanceMigration struct {
+	AllocID   string
+	AllocName string
+	Namespace string
+	JobID     string
+	TaskGroup string
+
+	// NodeID is the node the allocation is migrated off.
+	NodeID string
+
+	// TargetNodeID is the node estimated to have capacity for the
+	// allocation. The scheduler places the replacement allocation, so it may
+	// land on another node.
+	TargetNodeID string
 }
 
 // SchedulerSetConfigRequest is used by the Operator endpoint to update the
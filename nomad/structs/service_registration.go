@@ -1,6 +1,8 @@
 package structs
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/hashicorp/nomad/helper"
@@ -37,6 +39,17 @@ const (
 	ServiceRegistrationGetServiceRPCMethod = "ServiceRegistration.GetService"
 )
 
+const (
+	// ServiceRegistrationStatusHealthy indicates the checks of the service
+	// registration are not failing. Registrations of services without checks
+	// are always healthy.
+	ServiceRegistrationStatusHealthy = "healthy"
+
+	// ServiceRegistrationStatusUnhealthy indicates at least one of the checks
+	// of the service registration is failing.
+	ServiceRegistrationStatusUnhealthy = "unhealthy"
+)
+
 // ServiceRegistration is the internal representation of a Nomad service
 // registration.
 type ServiceRegistration struct {
@@ -85,6 +98,12 @@ type ServiceRegistration struct {
 	// is determined by a combination of factors on the client.
 	Port int
 
+	// Status is the health of the service registration, as determined by the
+	// checks the client runs for the service. It is either
+	// ServiceRegistrationStatusHealthy or ServiceRegistrationStatusUnhealthy,
+	// and registrations written by older clients leave it empty.
+	Status string
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -136,6 +155,9 @@ func (s *ServiceRegistration) Equals(o *ServiceRegistration) bool {
 	if s.Port != o.Port {
 		return false
 	}
+	if s.Status != o.Status {
+		return false
+	}
 	if !helper.CompareSliceSetString(s.Tags, o.Tags) {
 		return false
 	}
@@ -153,6 +175,25 @@ func (s *ServiceRegistration) Validate() error {
 	return nil
 }
 
+// IsHealthy returns whether the checks of the service registration are not
+// failing. Registrations without a status predate client run checks and are
+// considered healthy.
+func (s *ServiceRegistration) IsHealthy() bool {
+	return s.Status != ServiceRegistrationStatusUnhealthy
+}
+
+// HashWith returns the weight of the service registration for the key, used to
+// select a stable subset of registrations with rendezvous hashing. The weight
+// only depends on the key and the registration ID, so it does not change when
+// other registrations of the service come and go.
+func (s *ServiceRegistration) HashWith(key string) uint64 {
+	h := sha256.New()
+	_, _ = h.Write([]byte(key))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(s.ID))
+	return binary.BigEndian.Uint64(h.Sum(nil))
+}
+
 // GetID is a helper for getting the ID when the object may be nil and is
 // required for pagination.
 func (s *ServiceRegistration) GetID() string {
@@ -245,6 +286,18 @@ type ServiceRegistrationStub struct {
 // of services matching a specific name.
 type ServiceRegistrationByNameRequest struct {
 	ServiceName string
+
+	// IncludeUnhealthy includes the registrations whose checks are failing,
+	// which are otherwise omitted from the response.
+	IncludeUnhealthy bool
+
+	// Choose selects a stable subset of the registrations using rendezvous
+	// hashing. It is in the form "<count>|<key>", where count is the number of
+	// registrations to return and key is a caller provided string, such as an
+	// allocation ID, the selection is stable for. An empty value returns all
+	// the registrations.
+	Choose string
+
 	QueryOptions
 }
 
@@ -382,6 +382,28 @@ func TestServiceRegistration_Equal(t *testing.T) {
 	}
 }
 
+func TestServiceRegistration_HashWith(t *testing.T) {
+	reg := &ServiceRegistration{
+		ID:     "_nomad-task-2873cf75-42e5-7c45-ca1c-415f3e18be3d-group-cache-example-cache-db",
+		Port:   23813,
+		Status: ServiceRegistrationStatusHealthy,
+	}
+
+	// The weight is stable for the same key and differs for other keys.
+	weight := reg.HashWith("key1")
+	require.Equal(t, weight, reg.HashWith("key1"))
+	require.NotEqual(t, weight, reg.HashWith("key2"))
+
+	// The weight only depends on the registration ID.
+	other := reg.Copy()
+	other.Port = 29123
+	other.Status = ServiceRegistrationStatusUnhealthy
+	require.Equal(t, weight, other.HashWith("key1"))
+
+	other.ID += "-other"
+	require.NotEqual(t, weight, other.HashWith("key1"))
+}
+
 func TestServiceRegistration_GetID(t *testing.T) {
 	testCases := []struct {
 		inputServiceRegistration *ServiceRegistration
@@ -325,6 +325,27 @@ func (sc *ServiceCheck) validate() error {
 	return sc.CheckRestart.Validate()
 }
 
+// validateNomad validates a ServiceCheck of a service using the nomad
+// provider. Only the http and tcp check types are supported.
+func (sc *ServiceCheck) validateNomad() error {
+	switch strings.ToLower(sc.Type) {
+	case ServiceCheckHTTP, ServiceCheckTCP:
+	default:
+		return fmt.Errorf(`invalid type (%+q), must be one of "http" or "tcp" with provider nomad`, sc.Type)
+	}
+
+	switch {
+	case sc.Expose:
+		return fmt.Errorf("expose is not supported with provider nomad")
+	case sc.SuccessBeforePassing > 0:
+		return fmt.Errorf("success_before_passing is not supported with provider nomad")
+	case sc.FailuresBeforeCritical > 0:
+		return fmt.Errorf("failures_before_critical is not supported with provider nomad")
+	}
+
+	return sc.validate()
+}
+
 // RequiresPort returns whether the service check requires the task has a port.
 func (sc *ServiceCheck) RequiresPort() bool {
 	switch sc.Type {
@@ -655,11 +676,17 @@ func (s *Service) validateConsulService(mErr *multierror.Error) {
 // nomad provider.
 func (s *Service) validateNomadService(mErr *multierror.Error) {
 
-	// Service blocks for the Nomad provider do not support checks. We perform
-	// a nil check, as an empty check list is nilled within the service
-	// canonicalize function.
-	if s.Checks != nil {
-		mErr.Errors = append(mErr.Errors, errors.New("Service with provider nomad cannot include Check blocks"))
+	// Checks of services using the Nomad provider are run by the Nomad client,
+	// which supports a subset of the check types and options.
+	for _, c := range s.Checks {
+		if s.PortLabel == "" && c.PortLabel == "" && c.RequiresPort() {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: check requires a port but neither check nor service %+q have a port", c.Name, s.Name))
+			continue
+		}
+
+		if err := c.validateNomad(); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Check %s invalid: %v", c.Name, err))
+		}
 	}
 
 	// Services using the Nomad provider do not support Consul connect.
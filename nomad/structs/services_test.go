@@ -1545,8 +1545,8 @@ func TestService_Validate(t *testing.T) {
 				},
 			},
 			expErr:    true,
-			expErrStr: "Service with provider nomad cannot include Check blocks",
-			name:      "provider nomad with checks",
+			expErrStr: `Check servicecheck invalid: invalid type ("")`,
+			name:      "provider nomad with invalid check",
 		},
 		{
 			input: &Service{
@@ -1691,12 +1691,49 @@ func TestService_validateNomadService(t *testing.T) {
 				Namespace: "default",
 				Provider:  "nomad",
 				Checks: []*ServiceCheck{
-					{Name: "some-check"},
+					{Name: "http-check", Type: "http", Path: "/health", Interval: time.Second, Timeout: time.Second},
+					{Name: "tcp-check", Type: "tcp", Interval: time.Second, Timeout: time.Second},
 				},
 			},
 			inputErr:             &multierror.Error{},
-			expectedOutputErrors: []error{errors.New("Service with provider nomad cannot include Check blocks")},
-			name:                 "invalid service due to checks",
+			expectedOutputErrors: []error{},
+			name:                 "valid service with checks",
+		},
+		{
+			inputService: &Service{
+				Name:      "webapp",
+				PortLabel: "http",
+				Namespace: "default",
+				Provider:  "nomad",
+				Checks: []*ServiceCheck{
+					{Name: "script-check", Type: "script", Command: "true", Interval: time.Second, Timeout: time.Second},
+					{Name: "grpc-check", Type: "grpc", Interval: time.Second, Timeout: time.Second},
+					{Name: "tcp-check", Type: "tcp", Interval: time.Second, Timeout: time.Second, FailuresBeforeCritical: 2},
+				},
+			},
+			inputErr: &multierror.Error{},
+			expectedOutputErrors: []error{
+				errors.New(`Check script-check invalid: invalid type ("script"), must be one of "http" or "tcp" with provider nomad`),
+				errors.New(`Check grpc-check invalid: invalid type ("grpc"), must be one of "http" or "tcp" with provider nomad`),
+				errors.New(`Check tcp-check invalid: failures_before_critical is not supported with provider nomad`),
+			},
+			name: "invalid service due to unsupported checks",
+		},
+		{
+			inputService: &Service{
+				Name:      "webapp",
+				PortLabel: "http",
+				Namespace: "default",
+				Provider:  "nomad",
+				Checks: []*ServiceCheck{
+					{Name: "some-check"},
+				},
+			},
+			inputErr: &multierror.Error{},
+			expectedOutputErrors: []error{
+				errors.New(`Check some-check invalid: invalid type (""), must be one of "http" or "tcp" with provider nomad`),
+			},
+			name: "invalid service due to checks",
 		},
 		{
 			inputService: &Service{
@@ -1727,7 +1764,7 @@ func TestService_validateNomadService(t *testing.T) {
 			},
 			inputErr: &multierror.Error{},
 			expectedOutputErrors: []error{
-				errors.New("Service with provider nomad cannot include Check blocks"),
+				errors.New(`Check some-check invalid: invalid type (""), must be one of "http" or "tcp" with provider nomad`),
 				errors.New("Service with provider nomad cannot include Connect blocks"),
 			},
 			name: "invalid service due to checks and connect",
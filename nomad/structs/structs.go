@@ -16,7 +16,9 @@ import (
 	"hash/crc32"
 	"math"
 	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"sort"
@@ -108,6 +110,18 @@ const (
 	ServiceRegistrationUpsertRequestType         MessageType = 47
 	ServiceRegistrationDeleteByIDRequestType     MessageType = 48
 	ServiceRegistrationDeleteByNodeIDRequestType MessageType = 49
+	NodePoolUpsertRequestType                    MessageType = 50
+	NodePoolDeleteRequestType                    MessageType = 51
+	RootKeyUpsertRequestType                     MessageType = 52
+	VarApplyStateRequestType                     MessageType = 53
+	RootKeyDeleteRequestType                     MessageType = 54
+	RootKeyMetaUpsertRequestType                 MessageType = 55
+	ACLRolesUpsertRequestType                    MessageType = 56
+	ACLRolesDeleteRequestType                    MessageType = 57
+	ACLAuthMethodsUpsertRequestType              MessageType = 58
+	ACLAuthMethodsDeleteRequestType              MessageType = 59
+	ACLBindingRulesUpsertRequestType             MessageType = 60
+	ACLBindingRulesDeleteRequestType             MessageType = 61
 
 	// Namespace types were moved from enterprise and therefore start at 64
 	NamespaceUpsertRequestType MessageType = 64
@@ -751,6 +765,12 @@ type JobScaleStatusRequest struct {
 	QueryOptions
 }
 
+// JobQueueStatusRequest is used to get the position of a job in the job queue
+type JobQueueStatusRequest struct {
+	JobID string
+	QueryOptions
+}
+
 // JobDispatchRequest is used to dispatch a job based on a parameterized job
 type JobDispatchRequest struct {
 	JobID   string
@@ -1416,6 +1436,45 @@ type TaskGroupScaleStatus struct {
 	Events    []*ScalingEvent
 }
 
+// JobQueueStatusResponse is used to return the queue status for a job
+type JobQueueStatusResponse struct {
+	JobQueueStatus *JobQueueStatus
+	QueryMeta
+}
+
+// JobQueueStatus is the position of a job in the job queue. A job is queued
+// while it has a blocked evaluation waiting for capacity to place its pending
+// allocations. The queue is ordered by job priority and then by submit time.
+type JobQueueStatus struct {
+	JobID     string
+	Namespace string
+
+	// Queued is whether the job has pending allocations waiting in the queue.
+	// The other fields are only set if the job is queued.
+	Queued bool
+
+	// Position is the 1-based position of the job in the queue.
+	Position int
+
+	// QueueLength is the total number of jobs in the queue.
+	QueueLength int
+
+	// EvalID is the ID of the blocked evaluation holding the job's place in
+	// the queue.
+	EvalID string
+
+	// Reason is a human readable explanation of why the job is waiting.
+	Reason string
+
+	// QueuedAllocations is the number of allocations waiting to be placed,
+	// keyed by task group name.
+	QueuedAllocations map[string]int
+
+	// QueuedTime is the time, in nanoseconds since the epoch, at which the
+	// job was submitted and entered the queue.
+	QueuedTime int64
+}
+
 type JobDispatchResponse struct {
 	DispatchedJobID string
 	EvalID          string
@@ -1914,6 +1973,9 @@ type Node struct {
 	// together for the purpose of determining scheduling pressure.
 	NodeClass string
 
+	// NodePool is the node pool the node belongs to.
+	NodePool string
+
 	// ComputedClass is a unique id that identifies nodes with a common set of
 	// attributes and capabilities.
 	ComputedClass string
@@ -2207,6 +2269,7 @@ func (n *Node) Stub(fields *NodeStubFields) *NodeListStub {
 		Datacenter:            n.Datacenter,
 		Name:                  n.Name,
 		NodeClass:             n.NodeClass,
+		NodePool:              n.NodePool,
 		Version:               n.Attributes["nomad.version"],
 		Drain:                 n.DrainStrategy != nil,
 		SchedulingEligibility: n.SchedulingEligibility,
@@ -2245,6 +2308,7 @@ type NodeListStub struct {
 	Datacenter            string
 	Name                  string
 	NodeClass             string
+	NodePool              string
 	Version               string
 	Drain                 bool
 	SchedulingEligibility string
@@ -2276,6 +2340,63 @@ type Resources struct {
 	IOPS        int // COMPAT(0.10): Only being used to issue warnings
 	Networks    Networks
 	Devices     ResourceDevices
+	NUMA        *NUMA
+}
+
+const (
+	// NUMAAffinityNone places the reserved cores of a task regardless of
+	// the NUMA nodes they belong to.
+	NUMAAffinityNone = "none"
+
+	// NUMAAffinityPrefer places the reserved cores of a task within a
+	// single NUMA node if possible.
+	NUMAAffinityPrefer = "prefer"
+
+	// NUMAAffinityRequire places the reserved cores of a task within a
+	// single NUMA node, or fails the placement.
+	NUMAAffinityRequire = "require"
+)
+
+// NUMA is used to keep the reserved cores of a task, and the memory they
+// access, within a single NUMA node of the client.
+type NUMA struct {
+	// Affinity is how strictly the reserved cores are kept within a single
+	// NUMA node.
+	Affinity string
+}
+
+// Copy returns a deep copy of the NUMA block.
+func (n *NUMA) Copy() *NUMA {
+	if n == nil {
+		return nil
+	}
+	nn := *n
+	return &nn
+}
+
+// Equals returns whether the NUMA blocks are equal. A nil block is equal to
+// one without affinity.
+func (n *NUMA) Equals(o *NUMA) bool {
+	return n.GetAffinity() == o.GetAffinity()
+}
+
+// GetAffinity returns the NUMA affinity, which is none if the block isn't set.
+func (n *NUMA) GetAffinity() string {
+	if n == nil || n.Affinity == "" {
+		return NUMAAffinityNone
+	}
+	return n.Affinity
+}
+
+// Validate returns an error if the affinity is unknown.
+func (n *NUMA) Validate() error {
+	switch n.GetAffinity() {
+	case NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire:
+		return nil
+	default:
+		return fmt.Errorf("NUMA affinity must be one of %q, %q or %q; got %q",
+			NUMAAffinityNone, NUMAAffinityPrefer, NUMAAffinityRequire, n.Affinity)
+	}
 }
 
 const (
@@ -2338,6 +2459,15 @@ func (r *Resources) Validate() error {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("MemoryMaxMB value (%d) should be larger than MemoryMB value (%d)", r.MemoryMaxMB, r.MemoryMB))
 	}
 
+	if r.NUMA != nil {
+		if err := r.NUMA.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, err)
+		}
+		if r.NUMA.GetAffinity() != NUMAAffinityNone && r.Cores == 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Task can only ask for a NUMA affinity along with 'cores'."))
+		}
+	}
+
 	return mErr.ErrorOrNil()
 }
 
@@ -2365,6 +2495,9 @@ func (r *Resources) Merge(other *Resources) {
 	if len(other.Devices) != 0 {
 		r.Devices = other.Devices
 	}
+	if other.NUMA != nil {
+		r.NUMA = other.NUMA.Copy()
+	}
 }
 
 // Equals Resources.
@@ -2384,7 +2517,8 @@ func (r *Resources) Equals(o *Resources) bool {
 		r.DiskMB == o.DiskMB &&
 		r.IOPS == o.IOPS &&
 		r.Networks.Equals(&o.Networks) &&
-		r.Devices.Equals(&o.Devices)
+		r.Devices.Equals(&o.Devices) &&
+		r.NUMA.Equals(o.NUMA)
 }
 
 // ResourceDevices are part of Resources.
@@ -2472,6 +2606,7 @@ func (r *Resources) Copy() *Resources {
 		}
 	}
 
+	newR.NUMA = r.NUMA.Copy()
 	return newR
 }
 
@@ -2898,6 +3033,7 @@ type NodeResources struct {
 	Networks     Networks
 	NodeNetworks []*NodeNetworkResource
 	Devices      []*NodeDeviceResource
+	Numa         NodeNumaResources
 
 	MinDynamicPort int
 	MaxDynamicPort int
@@ -2911,6 +3047,7 @@ func (n *NodeResources) Copy() *NodeResources {
 	newN := new(NodeResources)
 	*newN = *n
 	newN.Cpu = n.Cpu.Copy()
+	newN.Numa = n.Numa.Copy()
 	newN.Networks = n.Networks.Copy()
 
 	if n.NodeNetworks != nil {
@@ -2965,6 +3102,7 @@ func (n *NodeResources) Merge(o *NodeResources) {
 	n.Cpu.Merge(&o.Cpu)
 	n.Memory.Merge(&o.Memory)
 	n.Disk.Merge(&o.Disk)
+	n.Numa.Merge(&o.Numa)
 
 	if len(o.Networks) != 0 {
 		n.Networks = append(n.Networks, o.Networks...)
@@ -3012,6 +3150,9 @@ func (n *NodeResources) Equals(o *NodeResources) bool {
 	if !n.Disk.Equals(&o.Disk) {
 		return false
 	}
+	if !n.Numa.Equals(&o.Numa) {
+		return false
+	}
 	if !n.Networks.Equals(&o.Networks) {
 		return false
 	}
@@ -3164,6 +3305,65 @@ func (n *NodeCpuResources) SharesPerCore() int64 {
 	return n.CpuShares / int64(n.TotalCpuCores)
 }
 
+// NodeNumaResources captures the NUMA topology of the node.
+type NodeNumaResources struct {
+	// Nodes are the NUMA nodes of the machine. This value is currently only
+	// reported on Linux platforms.
+	Nodes []NodeNumaNode
+}
+
+// NodeNumaNode is a NUMA node of the machine and the cores it contains.
+type NodeNumaNode struct {
+	ID    uint16
+	Cores []uint16
+}
+
+func (n NodeNumaResources) Copy() NodeNumaResources {
+	newN := n
+	if n.Nodes != nil {
+		newN.Nodes = make([]NodeNumaNode, len(n.Nodes))
+		for i, node := range n.Nodes {
+			newN.Nodes[i] = NodeNumaNode{ID: node.ID}
+			if node.Cores != nil {
+				newN.Nodes[i].Cores = make([]uint16, len(node.Cores))
+				copy(newN.Nodes[i].Cores, node.Cores)
+			}
+		}
+	}
+	return newN
+}
+
+func (n *NodeNumaResources) Merge(o *NodeNumaResources) {
+	if o == nil {
+		return
+	}
+
+	if len(o.Nodes) != 0 {
+		n.Nodes = o.Nodes
+	}
+}
+
+func (n *NodeNumaResources) Equals(o *NodeNumaResources) bool {
+	if o == nil && n == nil {
+		return true
+	} else if o == nil {
+		return false
+	} else if n == nil {
+		return false
+	}
+
+	if len(n.Nodes) != len(o.Nodes) {
+		return false
+	}
+	for i := range n.Nodes {
+		if n.Nodes[i].ID != o.Nodes[i].ID ||
+			!cpuset.New(n.Nodes[i].Cores...).Equals(cpuset.New(o.Nodes[i].Cores...)) {
+			return false
+		}
+	}
+	return true
+}
+
 // NodeMemoryResources captures the memory resources of the node
 type NodeMemoryResources struct {
 	// MemoryMB is the total available memory on the node
@@ -4105,6 +4305,10 @@ type Job struct {
 	// can preempt other jobs.
 	Priority int
 
+	// Preemptible specifies whether the allocations of the job can be
+	// preempted by higher priority jobs. Jobs are preemptible if unset.
+	Preemptible *bool
+
 	// AllAtOnce is used to control if incremental scheduling of task groups
 	// is allowed or if we must do a gang scheduling of the entire job. This
 	// can slow down larger jobs if resources are not available.
@@ -4113,6 +4317,10 @@ type Job struct {
 	// Datacenters contains all the datacenters this job is allowed to span
 	Datacenters []string
 
+	// NodePool specifies the node pool this job is allowed to run on. An
+	// empty value is treated as the default node pool.
+	NodePool string
+
 	// Constraints can be specified at a job level and apply to
 	// all the task groups and tasks.
 	Constraints []*Constraint
@@ -4288,6 +4496,9 @@ func (j *Job) Copy() *Job {
 	nj.Constraints = CopySliceConstraints(nj.Constraints)
 	nj.Affinities = CopySliceAffinities(nj.Affinities)
 	nj.Multiregion = nj.Multiregion.Copy()
+	if j.Preemptible != nil {
+		nj.Preemptible = helper.BoolToPtr(*j.Preemptible)
+	}
 
 	if j.TaskGroups != nil {
 		tgs := make([]*TaskGroup, len(nj.TaskGroups))
@@ -4344,6 +4555,9 @@ func (j *Job) Validate() error {
 			}
 		}
 	}
+	if j.NodePool != "" && !validNodePoolName.MatchString(j.NodePool) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Invalid node pool %q", j.NodePool))
+	}
 	if len(j.TaskGroups) == 0 {
 		mErr.Errors = append(mErr.Errors, errors.New("Missing job task groups"))
 	}
@@ -4535,6 +4749,11 @@ func (j *Job) Stopped() bool {
 	return j == nil || j.Stop
 }
 
+// IsPreemptible returns whether the allocations of the job can be preempted.
+func (j *Job) IsPreemptible() bool {
+	return j.Preemptible == nil || *j.Preemptible
+}
+
 // HasUpdateStrategy returns if any task group in the job has an update strategy
 func (j *Job) HasUpdateStrategy() bool {
 	for _, tg := range j.TaskGroups {
@@ -4554,6 +4773,7 @@ func (j *Job) Stub(summary *JobSummary) *JobListStub {
 		ParentID:          j.ParentID,
 		Name:              j.Name,
 		Datacenters:       j.Datacenters,
+		NodePool:          j.NodePool,
 		Multiregion:       j.Multiregion,
 		Type:              j.Type,
 		Priority:          j.Priority,
@@ -4738,6 +4958,7 @@ type JobListStub struct {
 	Name              string
 	Namespace         string `json:",omitempty"`
 	Datacenters       []string
+	NodePool          string
 	Multiregion       *Multiregion
 	Type              string
 	Priority          int
@@ -5032,6 +5253,16 @@ type Namespace struct {
 	// Meta is the set of metadata key/value pairs that attached to the namespace
 	Meta map[string]string
 
+	// FairShareWeight is the weight of the namespace when the eval broker
+	// dequeues evaluations fairly across namespaces. Namespaces are served in
+	// proportion to their weights. A zero value is a weight of 1.
+	FairShareWeight int
+
+	// EvalRateLimit overrides the evaluation rate limits of the server
+	// configuration for the namespace. Only the non-zero fields override the
+	// server configuration.
+	EvalRateLimit *EvalRateLimit
+
 	// Hash is the hash of the namespace which is used to efficiently replicate
 	// cross-regions.
 	Hash []byte
@@ -5048,6 +5279,89 @@ type NamespaceCapabilities struct {
 	DisabledTaskDrivers []string
 }
 
+// EvalRateLimitDisabled is the rate which disables a limit. Namespaces use it
+// to disable a limit of the server configuration, as their zero rates inherit
+// the limits of the server configuration.
+const EvalRateLimitDisabled = -1
+
+// EvalRateLimit configures the token buckets limiting the rate at which the
+// job register, evaluate, dispatch and scale endpoints are called, as each
+// call creates an evaluation. Calls are limited per namespace and per job.
+type EvalRateLimit struct {
+	// NamespaceRate is the number of calls per second allowed for all the
+	// jobs of a namespace. Zero or EvalRateLimitDisabled disables the
+	// namespace limit.
+	NamespaceRate float64 `hcl:"namespace_rate"`
+
+	// NamespaceBurst is the number of calls allowed for all the jobs of a
+	// namespace in a burst. Zero defaults to the namespace rate, rounded up.
+	NamespaceBurst int `hcl:"namespace_burst"`
+
+	// JobRate is the number of calls per second allowed for a job. Zero or
+	// EvalRateLimitDisabled disables the job limit.
+	JobRate float64 `hcl:"job_rate"`
+
+	// JobBurst is the number of calls allowed for a job in a burst. Zero
+	// defaults to the job rate, rounded up.
+	JobBurst int `hcl:"job_burst"`
+}
+
+// Copy returns a copy of the rate limits.
+func (e *EvalRateLimit) Copy() *EvalRateLimit {
+	if e == nil {
+		return nil
+	}
+	ne := new(EvalRateLimit)
+	*ne = *e
+	return ne
+}
+
+// Merge returns the rate limits with the non-zero fields of the other rate
+// limits taking precedence, so a rate of EvalRateLimitDisabled disables the
+// limit.
+func (e *EvalRateLimit) Merge(o *EvalRateLimit) *EvalRateLimit {
+	if e == nil {
+		return o.Copy()
+	}
+	result := e.Copy()
+	if o == nil {
+		return result
+	}
+	if o.NamespaceRate != 0 {
+		result.NamespaceRate = o.NamespaceRate
+	}
+	if o.NamespaceBurst != 0 {
+		result.NamespaceBurst = o.NamespaceBurst
+	}
+	if o.JobRate != 0 {
+		result.JobRate = o.JobRate
+	}
+	if o.JobBurst != 0 {
+		result.JobBurst = o.JobBurst
+	}
+	return result
+}
+
+// Validate returns an error if any of the rate limits is negative, other than
+// rates set to EvalRateLimitDisabled.
+func (e *EvalRateLimit) Validate() error {
+	if e == nil {
+		return nil
+	}
+
+	var mErr multierror.Error
+	invalidRate := func(r float64) bool {
+		return r < 0 && r != EvalRateLimitDisabled
+	}
+	if invalidRate(e.NamespaceRate) || invalidRate(e.JobRate) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("eval rate limit rates cannot be negative, except %d to disable the limit", EvalRateLimitDisabled))
+	}
+	if e.NamespaceBurst < 0 || e.JobBurst < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("eval rate limit bursts cannot be negative"))
+	}
+	return mErr.ErrorOrNil()
+}
+
 func (n *Namespace) Validate() error {
 	var mErr multierror.Error
 
@@ -5060,6 +5374,13 @@ func (n *Namespace) Validate() error {
 		err := fmt.Errorf("description longer than %d", maxNamespaceDescriptionLength)
 		mErr.Errors = append(mErr.Errors, err)
 	}
+	if n.FairShareWeight < 0 {
+		err := fmt.Errorf("fair share weight cannot be negative")
+		mErr.Errors = append(mErr.Errors, err)
+	}
+	if err := n.EvalRateLimit.Validate(); err != nil {
+		mErr.Errors = append(mErr.Errors, err)
+	}
 
 	return mErr.ErrorOrNil()
 }
@@ -5076,6 +5397,15 @@ func (n *Namespace) SetHash() []byte {
 	_, _ = hash.Write([]byte(n.Name))
 	_, _ = hash.Write([]byte(n.Description))
 	_, _ = hash.Write([]byte(n.Quota))
+	if n.FairShareWeight != 0 {
+		// Only hashed when set, to keep the hashes of existing namespaces
+		// unchanged
+		_, _ = hash.Write([]byte(strconv.Itoa(n.FairShareWeight)))
+	}
+	if l := n.EvalRateLimit; l != nil {
+		_, _ = hash.Write([]byte(fmt.Sprintf("%v/%d/%v/%d",
+			l.NamespaceRate, l.NamespaceBurst, l.JobRate, l.JobBurst)))
+	}
 	if n.Capabilities != nil {
 		for _, driver := range n.Capabilities.EnabledTaskDrivers {
 			_, _ = hash.Write([]byte(driver))
@@ -5116,6 +5446,7 @@ func (n *Namespace) Copy() *Namespace {
 		c.DisabledTaskDrivers = helper.CopySliceString(n.Capabilities.DisabledTaskDrivers)
 		nc.Capabilities = c
 	}
+	nc.EvalRateLimit = n.EvalRateLimit.Copy()
 	if n.Meta != nil {
 		nc.Meta = make(map[string]string, len(n.Meta))
 		for k, v := range n.Meta {
@@ -6192,6 +6523,11 @@ type TaskGroup struct {
 	// MaxClientDisconnect, if set, configures the client to allow placed
 	// allocations for tasks in this group to attempt to resume running without a restart.
 	MaxClientDisconnect *time.Duration
+
+	// Gang, if set, makes the placement of the task group all-or-nothing. The
+	// allocations the scheduler places for the group in an evaluation are only
+	// submitted if every one of them fits.
+	Gang bool
 }
 
 func (tg *TaskGroup) Copy() *TaskGroup {
@@ -6323,6 +6659,10 @@ func (tg *TaskGroup) Validate(j *Job) error {
 		mErr.Errors = append(mErr.Errors, errors.New("max_client_disconnect cannot be negative"))
 	}
 
+	if tg.Gang && (j.Type == JobTypeSystem || j.Type == JobTypeSysBatch) {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Gang scheduling is not supported for %s jobs", j.Type))
+	}
+
 	for idx, constr := range tg.Constraints {
 		if err := constr.Validate(); err != nil {
 			outer := fmt.Errorf("Constraint %d validation failed: %s", idx+1, err)
@@ -6854,6 +7194,27 @@ const (
 type LogConfig struct {
 	MaxFiles      int
 	MaxFileSizeMB int
+
+	// RotateEvery is the interval after which a log file is rotated whatever
+	// its size. Zero disables time based rotation.
+	RotateEvery time.Duration
+
+	// Compression is the algorithm rotated log files are compressed with.
+	// Empty is the same as LogCompressionNone.
+	Compression string
+
+	// MaxTotalSizeMB is the maximum total size of the log files of a stream
+	// retained on disk. Zero disables the limit.
+	MaxTotalSizeMB int
+
+	// MaxAge is the maximum time since a rotated log file was last written to
+	// for it to be retained. Zero disables the limit.
+	MaxAge time.Duration
+
+	// Sinks are the external destinations the logs are shipped to in
+	// addition to the rotated log files. If empty, the default sinks of the
+	// client are used.
+	Sinks []*LogSink
 }
 
 func (l *LogConfig) Equals(o *LogConfig) bool {
@@ -6869,6 +7230,31 @@ func (l *LogConfig) Equals(o *LogConfig) bool {
 		return false
 	}
 
+	if l.RotateEvery != o.RotateEvery {
+		return false
+	}
+
+	if l.Compression != o.Compression {
+		return false
+	}
+
+	if l.MaxTotalSizeMB != o.MaxTotalSizeMB {
+		return false
+	}
+
+	if l.MaxAge != o.MaxAge {
+		return false
+	}
+
+	if len(l.Sinks) != len(o.Sinks) {
+		return false
+	}
+	for i, sink := range l.Sinks {
+		if !sink.Equals(o.Sinks[i]) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -6876,10 +7262,29 @@ func (l *LogConfig) Copy() *LogConfig {
 	if l == nil {
 		return nil
 	}
-	return &LogConfig{
-		MaxFiles:      l.MaxFiles,
-		MaxFileSizeMB: l.MaxFileSizeMB,
+	nl := &LogConfig{
+		MaxFiles:       l.MaxFiles,
+		MaxFileSizeMB:  l.MaxFileSizeMB,
+		RotateEvery:    l.RotateEvery,
+		Compression:    l.Compression,
+		MaxTotalSizeMB: l.MaxTotalSizeMB,
+		MaxAge:         l.MaxAge,
+	}
+	if l.Sinks != nil {
+		nl.Sinks = make([]*LogSink, len(l.Sinks))
+		for i, sink := range l.Sinks {
+			nl.Sinks[i] = sink.Copy()
+		}
 	}
+	return nl
+}
+
+// GetSinks returns the log sinks of the config, which may be nil.
+func (l *LogConfig) GetSinks() []*LogSink {
+	if l == nil {
+		return nil
+	}
+	return l.Sinks
 }
 
 // DefaultLogConfig returns the default LogConfig values.
@@ -6900,9 +7305,250 @@ func (l *LogConfig) Validate() error {
 	if l.MaxFileSizeMB < 1 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum file size is 1MB; got %d", l.MaxFileSizeMB))
 	}
+	if l.RotateEvery != 0 && l.RotateEvery < time.Second {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("minimum rotation interval is 1s; got %v", l.RotateEvery))
+	}
+	switch l.Compression {
+	case "", LogCompressionNone, LogCompressionGzip, LogCompressionZstd:
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("compression must be one of %q, %q or %q; got %q",
+			LogCompressionNone, LogCompressionGzip, LogCompressionZstd, l.Compression))
+	}
+	if l.MaxTotalSizeMB < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("maximum total size can't be negative; got %d", l.MaxTotalSizeMB))
+	}
+	if l.MaxAge < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("maximum age can't be negative; got %v", l.MaxAge))
+	}
+
+	names := make(map[string]struct{}, len(l.Sinks))
+	for _, sink := range l.Sinks {
+		if _, ok := names[sink.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("duplicate log sink %q", sink.Name))
+		}
+		names[sink.Name] = struct{}{}
+
+		if err := sink.Validate(); err != nil {
+			mErr.Errors = append(mErr.Errors, multierror.Prefix(err, fmt.Sprintf("sink %q:", sink.Name)))
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
+const (
+	// LogCompressionNone keeps the rotated log files uncompressed.
+	LogCompressionNone = "none"
+
+	// LogCompressionGzip compresses the rotated log files with gzip.
+	LogCompressionGzip = "gzip"
+
+	// LogCompressionZstd compresses the rotated log files with zstd.
+	LogCompressionZstd = "zstd"
+)
+
+const (
+	// LogSinkTypeSyslog ships the logs to a syslog server in the RFC5424
+	// format.
+	LogSinkTypeSyslog = "syslog"
+
+	// LogSinkTypeJSONFile appends the logs to a file as line-delimited JSON
+	// records with the metadata of the allocation.
+	LogSinkTypeJSONFile = "json_file"
+
+	// LogSinkTypeOTLP exports the logs to an OpenTelemetry collector with the
+	// OTLP/HTTP protocol.
+	LogSinkTypeOTLP = "otlp"
+)
+
+// syslogFacilities are the names of the syslog facilities a log sink can use.
+var syslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp",
+	"cron", "authpriv", "ftp", "local0", "local1", "local2", "local3",
+	"local4", "local5", "local6", "local7",
+}
+
+// LogSink is an external destination task logs are shipped to. Logmon tails
+// the rotated log files and ships the lines to the sink in batches, tracking
+// its position in the files so delivery resumes where it left off.
+type LogSink struct {
+	// Name uniquely identifies the sink amongst the sinks of the task.
+	Name string
+
+	// Type is the type of the sink: syslog, json_file or otlp.
+	Type string
+
+	// Address is the address of the syslog server as a URL, such as
+	// tcp://10.0.0.1:514, udp://10.0.0.1:514 or unix:///dev/log.
+	Address string
+
+	// Facility is the syslog facility of the messages.
+	Facility string
+
+	// Tag is the syslog app name of the messages, which defaults to the name
+	// of the task.
+	Tag string
+
+	// Path is the path of the JSON file relative to the log directory of the
+	// allocation. It can't escape the allocation directory.
+	Path string
+
+	// Endpoint is the URL the OTLP logs are posted to.
+	Endpoint string
+
+	// Headers are added to the requests of the OTLP exporter.
+	Headers map[string]string
+
+	// BatchSize is the maximum number of lines shipped at once.
+	BatchSize int
+
+	// BatchWait is the maximum time lines wait to fill a batch before they
+	// are shipped.
+	BatchWait time.Duration
+}
+
+func (s *LogSink) Copy() *LogSink {
+	if s == nil {
+		return nil
+	}
+	ns := new(LogSink)
+	*ns = *s
+	ns.Headers = helper.CopyMapStringString(s.Headers)
+	return ns
+}
+
+func (s *LogSink) Equals(o *LogSink) bool {
+	if s == nil || o == nil {
+		return s == o
+	}
+	if s.Name != o.Name ||
+		s.Type != o.Type ||
+		s.Address != o.Address ||
+		s.Facility != o.Facility ||
+		s.Tag != o.Tag ||
+		s.Path != o.Path ||
+		s.Endpoint != o.Endpoint ||
+		s.BatchSize != o.BatchSize ||
+		s.BatchWait != o.BatchWait {
+		return false
+	}
+	return helper.CompareMapStringString(s.Headers, o.Headers)
+}
+
+// DiffID fulfills the DiffableWithID interface.
+func (s *LogSink) DiffID() string {
+	return s.Name
+}
+
+// Validate returns an error if the log sink is invalid.
+func (s *LogSink) Validate() error {
+	var mErr multierror.Error
+	if s.Name == "" {
+		mErr.Errors = append(mErr.Errors, errors.New("missing name"))
+	}
+	if s.BatchSize < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch_size must be positive; got %d", s.BatchSize))
+	}
+	if s.BatchWait < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("batch_wait must be positive; got %v", s.BatchWait))
+	}
+
+	switch s.Type {
+	case LogSinkTypeSyslog:
+		u, err := url.Parse(s.Address)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid address %q: %v", s.Address, err))
+		} else {
+			switch u.Scheme {
+			case "tcp", "udp":
+				if u.Host == "" {
+					mErr.Errors = append(mErr.Errors, fmt.Errorf("address %q is missing a host", s.Address))
+				}
+			case "unix", "unixgram":
+				if u.Path == "" {
+					mErr.Errors = append(mErr.Errors, fmt.Errorf("address %q is missing a path", s.Address))
+				}
+			default:
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("address %q must use the tcp, udp, unix or unixgram scheme", s.Address))
+			}
+		}
+		if s.Facility != "" && !helper.SliceStringContains(syslogFacilities, s.Facility) {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown syslog facility %q", s.Facility))
+		}
+	case LogSinkTypeJSONFile:
+		if s.Path == "" {
+			mErr.Errors = append(mErr.Errors, errors.New("missing path"))
+		} else if escaped, err := escapingfs.PathEscapesAllocViaRelative("alloc/logs", s.Path); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid path %q: %v", s.Path, err))
+		} else if escaped {
+			mErr.Errors = append(mErr.Errors, errors.New("path escapes allocation directory"))
+		}
+	case LogSinkTypeOTLP:
+		u, err := url.Parse(s.Endpoint)
+		if err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid endpoint %q: %v", s.Endpoint, err))
+		} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("endpoint %q must be an http or https URL", s.Endpoint))
+		}
+	default:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown sink type %q; must be %s, %s or %s",
+			s.Type, LogSinkTypeSyslog, LogSinkTypeJSONFile, LogSinkTypeOTLP))
+	}
+	return mErr.ErrorOrNil()
+}
+
+// ValidateDestination returns an error if the sink ships to a destination
+// which isn't allowed. Logs are shipped by the client, so the sinks of jobs may
+// only use the unix sockets and the network destinations, given as host:port,
+// the client configuration allows.
+func (s *LogSink) ValidateDestination(unixSockets, networkDestinations []string) error {
+	var u *url.URL
+	var err error
+	switch s.Type {
+	case LogSinkTypeSyslog:
+		u, err = url.Parse(s.Address)
+		if err != nil {
+			return fmt.Errorf("invalid address %q: %v", s.Address, err)
+		}
+	case LogSinkTypeOTLP:
+		u, err = url.Parse(s.Endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid endpoint %q: %v", s.Endpoint, err)
+		}
+	default:
+		return nil
+	}
+
+	if u.Scheme == "unix" || u.Scheme == "unixgram" {
+		for _, path := range unixSockets {
+			if filepath.Clean(path) == filepath.Clean(u.Path) {
+				return nil
+			}
+		}
+		return fmt.Errorf("sink %q: unix socket %q is not allowed by the client", s.Name, u.Path)
+	}
+
+	host, port := u.Hostname(), u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "http":
+			port = "80"
+		case "https":
+			port = "443"
+		}
+	}
+	for _, dest := range networkDestinations {
+		allowedHost, allowedPort, err := net.SplitHostPort(dest)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(allowedHost, host) && allowedPort == port {
+			return nil
+		}
+	}
+	return fmt.Errorf("sink %q: destination %q is not allowed by the client",
+		s.Name, net.JoinHostPort(host, port))
+}
+
 // Task is a single process typically that is executed as part of a task group.
 type Task struct {
 	// Name of the task
@@ -7174,6 +7820,12 @@ func (t *Task) Validate(ephemeralDisk *EphemeralDisk, jobType string, tgServices
 
 	if t.LogConfig != nil && ephemeralDisk != nil {
 		logUsage := (t.LogConfig.MaxFiles * t.LogConfig.MaxFileSizeMB)
+
+		// The total size retained can only be exceeded by the file being
+		// written
+		if total := t.LogConfig.MaxTotalSizeMB + t.LogConfig.MaxFileSizeMB; t.LogConfig.MaxTotalSizeMB > 0 && total < logUsage {
+			logUsage = total
+		}
 		if ephemeralDisk.SizeMB <= logUsage {
 			mErr.Errors = append(mErr.Errors,
 				fmt.Errorf("log storage (%d MB) must be less than requested disk capacity (%d MB)",
@@ -8015,6 +8667,14 @@ const (
 
 	// TaskClientReconnected indicates that the client running the task disconnected.
 	TaskClientReconnected = "Reconnected"
+
+	// TaskCheckpointed indicates that the task was checkpointed instead of
+	// being killed, to be restored by the allocation replacing it.
+	TaskCheckpointed = "Checkpointed"
+
+	// TaskRestoredCheckpoint indicates that the task was restored from the
+	// checkpoint of the task of the previous allocation.
+	TaskRestoredCheckpoint = "Restored Checkpoint"
 )
 
 // TaskEvent is an event that effects the state of a task and contains meta-data
@@ -8228,6 +8888,10 @@ func (e *TaskEvent) PopulateEventDisplayMessage() {
 		desc = "Main tasks in the group died"
 	case TaskClientReconnected:
 		desc = "Client reconnected"
+	case TaskCheckpointed:
+		desc = "Task checkpointed for migration"
+	case TaskRestoredCheckpoint:
+		desc = "Task restored from the checkpoint of the previous allocation"
 	default:
 		desc = e.Message
 	}
@@ -8798,10 +9462,34 @@ type Spread struct {
 	// SpreadTarget is used to describe desired percentages for each attribute value
 	SpreadTarget []*SpreadTarget
 
+	// MaxSkew is the maximum difference allowed between the number of
+	// allocations placed in any two values of the attribute. When set, the
+	// allocations are spread evenly instead of by target percentages.
+	MaxSkew int
+
+	// WhenUnsatisfiable is how a max_skew spread is enforced. It is either a
+	// hard constraint or a soft preference used in scoring.
+	WhenUnsatisfiable string
+
+	// NestedAttributes are the node attributes the allocations are spread
+	// across within each value of the attribute, in order, with the same
+	// max_skew.
+	NestedAttributes []string
+
 	// Memoized string representation
 	str string
 }
 
+const (
+	// SpreadWhenUnsatisfiableDoNotSchedule filters nodes on which a
+	// placement would exceed the max_skew of the spread.
+	SpreadWhenUnsatisfiableDoNotSchedule = "do_not_schedule"
+
+	// SpreadWhenUnsatisfiableScheduleAnyway penalizes the score of nodes on
+	// which a placement would exceed the max_skew of the spread.
+	SpreadWhenUnsatisfiableScheduleAnyway = "schedule_anyway"
+)
+
 type Affinities []*Affinity
 
 // Equals compares Affinities as a set
@@ -8835,6 +9523,7 @@ func (s *Spread) Copy() *Spread {
 	*ns = *s
 
 	ns.SpreadTarget = CopySliceSpreadTarget(s.SpreadTarget)
+	ns.NestedAttributes = helper.CopySliceString(s.NestedAttributes)
 	return ns
 }
 
@@ -8842,10 +9531,28 @@ func (s *Spread) String() string {
 	if s.str != "" {
 		return s.str
 	}
+	if s.MaxSkew > 0 {
+		s.str = fmt.Sprintf("%s %v max_skew=%d %s %v", s.Attribute, s.NestedAttributes, s.MaxSkew, s.WhenUnsatisfiable, s.Weight)
+		return s.str
+	}
 	s.str = fmt.Sprintf("%s %s %v", s.Attribute, s.SpreadTarget, s.Weight)
 	return s.str
 }
 
+// HardMaxSkew returns whether the max_skew of the spread is enforced as a
+// constraint rather than a scoring preference.
+func (s *Spread) HardMaxSkew() bool {
+	return s.MaxSkew > 0 && s.WhenUnsatisfiable == SpreadWhenUnsatisfiableDoNotSchedule
+}
+
+// SkewAttributes returns the attribute of a max_skew spread followed by its
+// nested attributes.
+func (s *Spread) SkewAttributes() []string {
+	attributes := make([]string, 0, len(s.NestedAttributes)+1)
+	attributes = append(attributes, s.Attribute)
+	return append(attributes, s.NestedAttributes...)
+}
+
 func (s *Spread) Validate() error {
 	var mErr multierror.Error
 	if s.Attribute == "" {
@@ -8873,6 +9580,33 @@ func (s *Spread) Validate() error {
 	if sumPercent > 100 {
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("Sum of spread target percentages must not be greater than 100%%; got %d%%", sumPercent))
 	}
+
+	switch {
+	case s.MaxSkew < 0:
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread max_skew must not be negative; got %d", s.MaxSkew))
+	case s.MaxSkew == 0:
+		if s.WhenUnsatisfiable != "" {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread when_unsatisfiable requires max_skew"))
+		}
+		if len(s.NestedAttributes) != 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread nested_attributes requires max_skew"))
+		}
+	default:
+		if len(s.SpreadTarget) != 0 {
+			mErr.Errors = append(mErr.Errors, errors.New("Spread targets can't be combined with max_skew"))
+		}
+		switch s.WhenUnsatisfiable {
+		case "", SpreadWhenUnsatisfiableDoNotSchedule, SpreadWhenUnsatisfiableScheduleAnyway:
+		default:
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread when_unsatisfiable must be %q or %q; got %q",
+				SpreadWhenUnsatisfiableDoNotSchedule, SpreadWhenUnsatisfiableScheduleAnyway, s.WhenUnsatisfiable))
+		}
+		for _, attribute := range s.NestedAttributes {
+			if attribute == "" || attribute == s.Attribute {
+				mErr.Errors = append(mErr.Errors, fmt.Errorf("Spread nested attribute %q must be set and differ from the spread attribute", attribute))
+			}
+		}
+	}
 	return mErr.ErrorOrNil()
 }
 
@@ -8917,6 +9651,11 @@ type EphemeralDisk struct {
 	// Migrate determines if Nomad client should migrate the allocation dir for
 	// sticky allocations
 	Migrate bool
+
+	// Checkpoint determines if the tasks of allocations being migrated should
+	// be checkpointed, and restored from the checkpoint migrated along with
+	// the allocation dir, when their driver supports it
+	Checkpoint bool
 }
 
 // DefaultEphemeralDisk returns a EphemeralDisk with default configurations
@@ -8931,6 +9670,9 @@ func (d *EphemeralDisk) Validate() error {
 	if d.SizeMB < 10 {
 		return fmt.Errorf("minimum DiskMB value is 10; got %d", d.SizeMB)
 	}
+	if d.Checkpoint && !d.Migrate {
+		return fmt.Errorf("checkpoint requires migrate to be enabled")
+	}
 	return nil
 }
 
@@ -9578,6 +10320,11 @@ type Allocation struct {
 	// to stop running because it got preempted
 	PreemptedByAllocation string
 
+	// SignedIdentities is a map of task names to the signed workload
+	// identities of those tasks. It is populated by the plan applier, and
+	// is never returned by the HTTP API.
+	SignedIdentities map[string]string `json:"-"`
+
 	// Raft Indexes
 	CreateIndex uint64
 	ModifyIndex uint64
@@ -9721,6 +10468,7 @@ func (a *Allocation) copyImpl(job bool) *Allocation {
 
 	na.RescheduleTracker = a.RescheduleTracker.Copy()
 	na.PreemptedAllocations = helper.CopySliceString(a.PreemptedAllocations)
+	na.SignedIdentities = helper.CopyMapStringString(a.SignedIdentities)
 	return na
 }
 
@@ -10401,6 +11149,18 @@ type AllocMetric struct {
 	// This is to prevent creating many failed allocations for a
 	// single task group.
 	CoalescedFailures int
+
+	// GangSize is the number of allocations of a gang scheduled task group
+	// that the scheduler attempted to place together.
+	GangSize int
+
+	// GangPlaced is the number of allocations of the gang that fit before
+	// placement failed. None of them were submitted.
+	GangPlaced int
+
+	// GangBlockedBy is the constraint, resource dimension or quota that
+	// stopped the gang from being placed.
+	GangBlockedBy string
 }
 
 func (a *AllocMetric) Copy() *AllocMetric {
@@ -10520,6 +11280,45 @@ func (a *AllocMetric) ScoreNode(node *Node, name string, score float64) {
 	}
 }
 
+// FailGang records that a gang of size allocations could not be placed
+// because only placed of them fit, and which constraint or resource stopped
+// it.
+func (a *AllocMetric) FailGang(size, placed int) {
+	if a == nil {
+		return
+	}
+
+	a.GangSize = size
+	a.GangPlaced = placed
+	a.GangBlockedBy = a.blockedBy()
+}
+
+// blockedBy returns the constraint or resource dimension that filtered or
+// exhausted the most nodes. Ties are broken by name so the result is stable.
+func (a *AllocMetric) blockedBy() string {
+	if len(a.QuotaExhausted) != 0 {
+		return fmt.Sprintf("quota %s", strings.Join(a.QuotaExhausted, ", "))
+	}
+
+	reason, max := "", 0
+	consider := func(name string, count int) {
+		if count > max || (count == max && count > 0 && name < reason) {
+			reason, max = name, count
+		}
+	}
+	for constraint, count := range a.ConstraintFiltered {
+		consider(fmt.Sprintf("constraint %s", constraint), count)
+	}
+	for dimension, count := range a.DimensionExhausted {
+		consider(fmt.Sprintf("resources %s", dimension), count)
+	}
+
+	if reason == "" && a.NodesEvaluated == 0 {
+		return "no nodes available"
+	}
+	return reason
+}
+
 // PopulateScoreMetaData populates a map of scorer to scoring metadata
 // The map is populated by popping elements from a heap of top K scores
 // maintained per scorer
@@ -10691,6 +11490,7 @@ const (
 	EvalTriggerScaling              = "job-scaling"
 	EvalTriggerMaxDisconnectTimeout = "max-disconnect-timeout"
 	EvalTriggerReconnect            = "reconnect"
+	EvalTriggerRebalance            = "rebalance"
 )
 
 const (
@@ -10730,6 +11530,23 @@ const (
 	// tokens. We periodically scan for expired tokens and delete them.
 	CoreJobOneTimeTokenGC = "one-time-token-gc"
 
+	// CoreJobExpiredACLTokenGC is used for the garbage collection of ACL
+	// tokens which have passed their expiration time.
+	CoreJobExpiredACLTokenGC = "expired-acl-token-gc"
+
+	// CoreJobRootKeyRotateOrGC is used to rotate the active root key once it
+	// reaches its rotation threshold, and for the garbage collection of
+	// inactive root keys which are no longer used by any data.
+	CoreJobRootKeyRotateOrGC = "root-key-rotate-gc"
+
+	// CoreJobRebalance is used to periodically migrate allocations off
+	// under-utilized nodes.
+	CoreJobRebalance = "rebalance"
+
+	// CoreJobVariablesRekey is used to re-encrypt the variables encrypted
+	// with root keys that are being rekeyed, so those keys can be retired.
+	CoreJobVariablesRekey = "variables-rekey"
+
 	// CoreJobForceGC is used to force garbage collection of all GCable objects.
 	CoreJobForceGC = "force-gc"
 )
@@ -10770,6 +11587,11 @@ type Evaluation struct {
 	// the evaluation was created
 	JobModifyIndex uint64
 
+	// JobSubmitTime is the submit time of the job, in nanoseconds since the
+	// epoch. It is set on blocked evaluations, which are queued by priority
+	// and then by the submit time of their job.
+	JobSubmitTime int64
+
 	// NodeID is the node that was affected triggering the evaluation.
 	NodeID string
 
@@ -11021,6 +11843,46 @@ func (e *Evaluation) ShouldBlock() bool {
 	}
 }
 
+// BlockedReason returns a human readable explanation of why a blocked
+// evaluation is waiting, derived from its placement failures.
+func (e *Evaluation) BlockedReason() string {
+	if e.QuotaLimitReached != "" {
+		return fmt.Sprintf("quota %q limit reached", e.QuotaLimitReached)
+	}
+	if e.TriggeredBy == EvalTriggerMaxPlans {
+		return "plan attempts exhausted"
+	}
+
+	var evaluated, filtered int
+	exhausted := make(map[string]struct{})
+	for _, metric := range e.FailedTGAllocs {
+		if metric == nil {
+			continue
+		}
+		evaluated += metric.NodesEvaluated
+		filtered += metric.NodesFiltered
+		for dimension := range metric.DimensionExhausted {
+			exhausted[dimension] = struct{}{}
+		}
+	}
+
+	switch {
+	case len(exhausted) != 0:
+		dimensions := make([]string, 0, len(exhausted))
+		for dimension := range exhausted {
+			dimensions = append(dimensions, dimension)
+		}
+		sort.Strings(dimensions)
+		return fmt.Sprintf("resources exhausted: %s", strings.Join(dimensions, ", "))
+	case evaluated == 0:
+		return "no nodes available"
+	case filtered == evaluated:
+		return "no nodes satisfy the job's constraints"
+	default:
+		return "waiting for capacity"
+	}
+}
+
 // MakePlan is used to make a plan from the given evaluation
 // for a given Job
 func (e *Evaluation) MakePlan(j *Job) *Plan {
@@ -11072,6 +11934,7 @@ func (e *Evaluation) CreateBlockedEval(classEligibility map[string]bool,
 		TriggeredBy:          EvalTriggerQueuedAllocs,
 		JobID:                e.JobID,
 		JobModifyIndex:       e.JobModifyIndex,
+		JobSubmitTime:        e.JobSubmitTime,
 		Status:               EvalStatusBlocked,
 		PreviousEval:         e.ID,
 		FailedTGAllocs:       failedTGAllocs,
@@ -11342,6 +12205,49 @@ func (p *Plan) AppendAlloc(alloc *Allocation, job *Job) {
 	p.NodeAllocation[node] = append(existing, alloc)
 }
 
+// RemoveAlloc removes an allocation placed by the plan along with the
+// evictions of the allocations it preempted.
+func (p *Plan) RemoveAlloc(alloc *Allocation) {
+	node := alloc.NodeID
+	p.NodeAllocation[node] = removeAllocFromSlice(p.NodeAllocation[node], func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(p.NodeAllocation[node]) == 0 {
+		delete(p.NodeAllocation, node)
+	}
+
+	p.NodePreemptions[node] = removeAllocFromSlice(p.NodePreemptions[node], func(a *Allocation) bool {
+		return a.PreemptedByAllocation == alloc.ID
+	})
+	if len(p.NodePreemptions[node]) == 0 {
+		delete(p.NodePreemptions, node)
+	}
+}
+
+// RemoveUpdate removes a stop or eviction of the allocation from the plan.
+// Unlike PopUpdate, the update does not have to be the last one appended for
+// the node.
+func (p *Plan) RemoveUpdate(alloc *Allocation) {
+	node := alloc.NodeID
+	p.NodeUpdate[node] = removeAllocFromSlice(p.NodeUpdate[node], func(a *Allocation) bool {
+		return a.ID == alloc.ID
+	})
+	if len(p.NodeUpdate[node]) == 0 {
+		delete(p.NodeUpdate, node)
+	}
+}
+
+// removeAllocFromSlice returns the allocations for which remove returns false.
+func removeAllocFromSlice(allocs []*Allocation, remove func(*Allocation) bool) []*Allocation {
+	kept := allocs[:0]
+	for _, a := range allocs {
+		if !remove(a) {
+			kept = append(kept, a)
+		}
+	}
+	return kept
+}
+
 // IsNoOp checks if this plan would do nothing
 func (p *Plan) IsNoOp() bool {
 	return len(p.NodeUpdate) == 0 &&
@@ -11432,6 +12338,27 @@ type PlanAnnotations struct {
 
 	// PreemptedAllocs is the set of allocations to be preempted to make the placement successful.
 	PreemptedAllocs []*AllocListStub
+
+	// SpreadDistributions is the distribution of the allocations of each
+	// task group across the domains of its max_skew spreads, once the plan
+	// is applied.
+	SpreadDistributions map[string][]*SpreadDistribution
+}
+
+// SpreadDistribution is the number of allocations of a task group placed in
+// each domain of a max_skew spread. Domains are the values of the spread
+// attributes joined by "/", such as "us-east-1a" and "us-east-1a/rack-1" for
+// a spread across racks nested within zones.
+type SpreadDistribution struct {
+	// Attributes are the attribute of the spread followed by its nested
+	// attributes.
+	Attributes []string
+
+	// MaxSkew is the max_skew of the spread.
+	MaxSkew int
+
+	// Counts is the number of allocations by domain.
+	Counts map[string]int
 }
 
 // DesiredUpdates is the set of changes the scheduler would like to make given
@@ -11710,14 +12637,26 @@ type ACLPolicyUpsertRequest struct {
 
 // ACLToken represents a client token which is used to Authenticate
 type ACLToken struct {
-	AccessorID  string   // Public Accessor ID (UUID)
-	SecretID    string   // Secret ID, private (UUID)
-	Name        string   // Human friendly name
-	Type        string   // Client or Management
-	Policies    []string // Policies this token ties to
-	Global      bool     // Global or Region local
-	Hash        []byte
-	CreateTime  time.Time // Time of creation
+	AccessorID string              // Public Accessor ID (UUID)
+	SecretID   string              // Secret ID, private (UUID)
+	Name       string              // Human friendly name
+	Type       string              // Client or Management
+	Policies   []string            // Policies this token ties to
+	Roles      []*ACLTokenRoleLink // Roles this token ties to
+	Global     bool                // Global or Region local
+	Hash       []byte
+	CreateTime time.Time // Time of creation
+
+	// ExpirationTime represents the point after which a token should be
+	// considered revoked and is eligible for destruction. A nil value
+	// indicates the token does not expire.
+	ExpirationTime *time.Time
+
+	// ExpirationTTL is a convenience field for helping set ExpirationTime to
+	// a value of CreateTime+ExpirationTTL. This can only be set during token
+	// creation.
+	ExpirationTTL time.Duration
+
 	CreateIndex uint64
 	ModifyIndex uint64
 }
@@ -11745,12 +12684,47 @@ func (a *ACLToken) Copy() *ACLToken {
 
 	c.Policies = make([]string, len(a.Policies))
 	copy(c.Policies, a.Policies)
+	if a.Roles != nil {
+		c.Roles = make([]*ACLTokenRoleLink, len(a.Roles))
+		for i, roleLink := range a.Roles {
+			link := *roleLink
+			c.Roles[i] = &link
+		}
+	}
 	c.Hash = make([]byte, len(a.Hash))
 	copy(c.Hash, a.Hash)
+	if a.ExpirationTime != nil {
+		expirationTime := *a.ExpirationTime
+		c.ExpirationTime = &expirationTime
+	}
 
 	return c
 }
 
+// HasExpirationTime returns whether the token has an expiration time set.
+func (a *ACLToken) HasExpirationTime() bool {
+	return a != nil && a.ExpirationTime != nil
+}
+
+// CanonicalizeExpiration sets the expiration time of a new token from its
+// expiration TTL, relative to its creation time.
+func (a *ACLToken) CanonicalizeExpiration() {
+	if a.ExpirationTTL != 0 {
+		expirationTime := a.CreateTime.Add(a.ExpirationTTL)
+		a.ExpirationTime = &expirationTime
+	}
+}
+
+// IsExpired returns whether the token has an expiration time set and that
+// time is before the passed time. Tokens without an expiration time never
+// expire.
+func (a *ACLToken) IsExpired(t time.Time) bool {
+	if a == nil || a.ExpirationTime == nil {
+		return false
+	}
+	return a.ExpirationTime.Before(t)
+}
+
 var (
 	// AnonymousACLToken is used no SecretID is provided, and the
 	// request is made anonymously.
@@ -11764,15 +12738,17 @@ var (
 )
 
 type ACLTokenListStub struct {
-	AccessorID  string
-	Name        string
-	Type        string
-	Policies    []string
-	Global      bool
-	Hash        []byte
-	CreateTime  time.Time
-	CreateIndex uint64
-	ModifyIndex uint64
+	AccessorID     string
+	Name           string
+	Type           string
+	Policies       []string
+	Roles          []*ACLTokenRoleLink
+	Global         bool
+	Hash           []byte
+	CreateTime     time.Time
+	ExpirationTime *time.Time
+	CreateIndex    uint64
+	ModifyIndex    uint64
 }
 
 // SetHash is used to compute and set the hash of the ACL token
@@ -11789,6 +12765,9 @@ func (a *ACLToken) SetHash() []byte {
 	for _, policyName := range a.Policies {
 		_, _ = hash.Write([]byte(policyName))
 	}
+	for _, roleLink := range a.Roles {
+		_, _ = hash.Write([]byte(roleLink.ID))
+	}
 	if a.Global {
 		_, _ = hash.Write([]byte("global"))
 	} else {
@@ -11805,15 +12784,17 @@ func (a *ACLToken) SetHash() []byte {
 
 func (a *ACLToken) Stub() *ACLTokenListStub {
 	return &ACLTokenListStub{
-		AccessorID:  a.AccessorID,
-		Name:        a.Name,
-		Type:        a.Type,
-		Policies:    a.Policies,
-		Global:      a.Global,
-		Hash:        a.Hash,
-		CreateTime:  a.CreateTime,
-		CreateIndex: a.CreateIndex,
-		ModifyIndex: a.ModifyIndex,
+		AccessorID:     a.AccessorID,
+		Name:           a.Name,
+		Type:           a.Type,
+		Policies:       a.Policies,
+		Roles:          a.Roles,
+		Global:         a.Global,
+		Hash:           a.Hash,
+		CreateTime:     a.CreateTime,
+		ExpirationTime: a.ExpirationTime,
+		CreateIndex:    a.CreateIndex,
+		ModifyIndex:    a.ModifyIndex,
 	}
 }
 
@@ -11825,16 +12806,22 @@ func (a *ACLToken) Validate() error {
 	}
 	switch a.Type {
 	case ACLClientToken:
-		if len(a.Policies) == 0 {
-			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies"))
+		if len(a.Policies) == 0 && len(a.Roles) == 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("client token missing policies or roles"))
 		}
 	case ACLManagementToken:
 		if len(a.Policies) != 0 {
 			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with policies"))
 		}
+		if len(a.Roles) != 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("management token cannot be associated with roles"))
+		}
 	default:
 		mErr.Errors = append(mErr.Errors, fmt.Errorf("token type must be client or management"))
 	}
+	if a.ExpirationTTL < 0 {
+		mErr.Errors = append(mErr.Errors, fmt.Errorf("token expiration TTL cannot be negative"))
+	}
 	return mErr.ErrorOrNil()
 }
 
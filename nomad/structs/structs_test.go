@@ -1685,6 +1685,32 @@ func TestTask_Validate_Resources(t *testing.T) {
 			},
 			err: "MemoryMaxMB value (10) should be larger than MemoryMB value (200",
 		},
+		{
+			name: "numa affinity with cores",
+			res: &Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: NUMAAffinityRequire},
+			},
+		},
+		{
+			name: "numa affinity without cores",
+			res: &Resources{
+				CPU:      100,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: NUMAAffinityPrefer},
+			},
+			err: "Task can only ask for a NUMA affinity along with 'cores'.",
+		},
+		{
+			name: "invalid numa affinity",
+			res: &Resources{
+				Cores:    2,
+				MemoryMB: 200,
+				NUMA:     &NUMA{Affinity: "always"},
+			},
+			err: `NUMA affinity must be one of "none", "prefer" or "require"; got "always"`,
+		},
 	}
 
 	for i := range cases {
@@ -2452,6 +2478,91 @@ func TestTask_Validate_LogConfig(t *testing.T) {
 	require.Error(t, err, "log storage")
 }
 
+func TestTask_Validate_LogConfig_MaxTotalSize(t *testing.T) {
+	ci.Parallel(t)
+
+	task := &Task{
+		LogConfig: &LogConfig{
+			MaxFiles:       20,
+			MaxFileSizeMB:  10,
+			MaxTotalSizeMB: 50,
+		},
+	}
+
+	// The total size limits the log storage, exceeded by the file written
+	err := task.Validate(&EphemeralDisk{SizeMB: 100}, JobTypeService, nil, nil)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "log storage")
+
+	err = task.Validate(&EphemeralDisk{SizeMB: 60}, JobTypeService, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "log storage (60 MB)")
+}
+
+func TestEphemeralDisk_Validate_Checkpoint(t *testing.T) {
+	ci.Parallel(t)
+
+	// The checkpoint images are migrated with the alloc dir
+	d := &EphemeralDisk{SizeMB: 100, Sticky: true, Checkpoint: true}
+	require.EqualError(t, d.Validate(), "checkpoint requires migrate to be enabled")
+
+	d.Migrate = true
+	require.NoError(t, d.Validate())
+}
+
+func TestLogConfig_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name   string
+		modify func(*LogConfig)
+		err    string
+	}{
+		{
+			name: "rotation",
+			modify: func(l *LogConfig) {
+				l.RotateEvery = time.Hour
+				l.Compression = LogCompressionZstd
+				l.MaxTotalSizeMB = 50
+				l.MaxAge = 72 * time.Hour
+			},
+		},
+		{
+			name:   "rotation interval",
+			modify: func(l *LogConfig) { l.RotateEvery = time.Millisecond },
+			err:    "minimum rotation interval is 1s",
+		},
+		{
+			name:   "unknown compression",
+			modify: func(l *LogConfig) { l.Compression = "lz4" },
+			err:    `got "lz4"`,
+		},
+		{
+			name:   "negative total size",
+			modify: func(l *LogConfig) { l.MaxTotalSizeMB = -1 },
+			err:    "maximum total size can't be negative",
+		},
+		{
+			name:   "negative age",
+			modify: func(l *LogConfig) { l.MaxAge = -time.Hour },
+			err:    "maximum age can't be negative",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := DefaultLogConfig()
+			tc.modify(l)
+			err := l.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+}
+
 func TestLogConfig_Equals(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2479,6 +2590,16 @@ func TestLogConfig_Equals(t *testing.T) {
 		require.False(t, a.Equals(b))
 	})
 
+	t.Run("sinks", func(t *testing.T) {
+		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200, Sinks: []*LogSink{
+			{Name: "otlp", Type: LogSinkTypeOTLP, Headers: map[string]string{"a": "b"}},
+		}}
+		b := a.Copy()
+		require.True(t, a.Equals(b))
+		b.Sinks[0].Headers["a"] = "c"
+		require.False(t, a.Equals(b))
+	})
+
 	t.Run("same", func(t *testing.T) {
 		a := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
 		b := &LogConfig{MaxFiles: 1, MaxFileSizeMB: 200}
@@ -2486,6 +2607,118 @@ func TestLogConfig_Equals(t *testing.T) {
 	})
 }
 
+func TestLogConfig_Validate_Sinks(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name string
+		sink *LogSink
+		err  string
+	}{
+		{
+			name: "syslog",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeSyslog, Address: "tcp://10.0.0.1:514", Facility: "local3"},
+		},
+		{
+			name: "syslog unix",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeSyslog, Address: "unix:///dev/log"},
+		},
+		{
+			name: "syslog bad scheme",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeSyslog, Address: "http://10.0.0.1:514"},
+			err:  "must use the tcp, udp, unix or unixgram scheme",
+		},
+		{
+			name: "syslog bad facility",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeSyslog, Address: "udp://10.0.0.1:514", Facility: "local9"},
+			err:  `unknown syslog facility "local9"`,
+		},
+		{
+			name: "json file",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeJSONFile, Path: "shipped/web.json"},
+		},
+		{
+			name: "json file escapes",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeJSONFile, Path: "../../../etc/web.json"},
+			err:  "path escapes allocation directory",
+		},
+		{
+			name: "otlp",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "https://collector:4318/v1/logs"},
+		},
+		{
+			name: "otlp bad endpoint",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "collector:4318"},
+			err:  "must be an http or https URL",
+		},
+		{
+			name: "negative batch",
+			sink: &LogSink{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "http://collector", BatchSize: -1},
+			err:  "batch_size must be positive",
+		},
+		{
+			name: "unknown type",
+			sink: &LogSink{Name: "a", Type: "kafka"},
+			err:  `unknown sink type "kafka"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			l := DefaultLogConfig()
+			l.Sinks = []*LogSink{tc.sink}
+			err := l.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.err)
+			}
+		})
+	}
+
+	l := DefaultLogConfig()
+	l.Sinks = []*LogSink{
+		{Name: "a", Type: LogSinkTypeJSONFile, Path: "a.json"},
+		{Name: "a", Type: LogSinkTypeJSONFile, Path: "b.json"},
+	}
+	require.ErrorContains(t, l.Validate(), `duplicate log sink "a"`)
+}
+
+func TestLogSink_ValidateDestination(t *testing.T) {
+	ci.Parallel(t)
+
+	unixSockets := []string{"/dev/log", "/run/syslog/"}
+	destinations := []string{"10.0.0.1:514", "Collector.example.com:4318", "otlp.example.com:443"}
+
+	ok := []*LogSink{
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "tcp://10.0.0.1:514"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "udp://10.0.0.1:514"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "unix:///dev/log"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "unixgram:///run/syslog"},
+		{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "http://collector.example.com:4318/v1/logs"},
+		{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "https://otlp.example.com/v1/logs"},
+		{Name: "a", Type: LogSinkTypeJSONFile, Path: "shipped/web.json"},
+	}
+	for _, sink := range ok {
+		require.NoError(t, sink.ValidateDestination(unixSockets, destinations), sink.Address+sink.Endpoint)
+	}
+
+	denied := []*LogSink{
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "unix:///var/run/docker.sock"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "unixgram:///dev/log/../../var/run/docker.sock"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "tcp://127.0.0.1:514"},
+		{Name: "a", Type: LogSinkTypeSyslog, Address: "udp://10.0.0.1:515"},
+		{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "http://169.254.169.254/latest/meta-data"},
+		{Name: "a", Type: LogSinkTypeOTLP, Endpoint: "http://otlp.example.com/v1/logs"},
+	}
+	for _, sink := range denied {
+		require.ErrorContains(t, sink.ValidateDestination(unixSockets, destinations),
+			"is not allowed by the client", sink.Address+sink.Endpoint)
+	}
+	require.Error(t, ok[0].ValidateDestination(unixSockets, nil))
+	require.Error(t, ok[2].ValidateDestination(nil, destinations))
+}
+
 func TestTask_Validate_CSIPluginConfig(t *testing.T) {
 	ci.Parallel(t)
 
@@ -4411,6 +4644,93 @@ func TestPlan_AppendPreemptedAllocAppendsAllocWithUpdatedAttrs(t *testing.T) {
 	assert.Equal(t, expectedAlloc, appendedAlloc)
 }
 
+func TestPlan_RemoveAlloc(t *testing.T) {
+	ci.Parallel(t)
+	plan := &Plan{
+		NodeUpdate:      make(map[string][]*Allocation),
+		NodeAllocation:  make(map[string][]*Allocation),
+		NodePreemptions: make(map[string][]*Allocation),
+	}
+
+	kept := MockAlloc()
+	removed := MockAlloc()
+	removed.NodeID = kept.NodeID
+	plan.AppendAlloc(kept, nil)
+	plan.AppendAlloc(removed, nil)
+
+	preempted := MockAlloc()
+	preempted.NodeID = kept.NodeID
+	plan.AppendPreemptedAlloc(preempted, removed.ID)
+
+	stopped := MockAlloc()
+	last := MockAlloc()
+	plan.AppendStoppedAlloc(stopped, "", "", "")
+	plan.AppendStoppedAlloc(last, "", "", "")
+
+	plan.RemoveAlloc(removed)
+	require.Equal(t, []*Allocation{kept}, plan.NodeAllocation[kept.NodeID])
+	require.NotContains(t, plan.NodePreemptions, kept.NodeID)
+
+	// Updates are removed even if they weren't the last appended
+	plan.RemoveUpdate(stopped)
+	require.Len(t, plan.NodeUpdate[last.NodeID], 1)
+	require.Equal(t, last.ID, plan.NodeUpdate[last.NodeID][0].ID)
+
+	plan.RemoveUpdate(last)
+	require.NotContains(t, plan.NodeUpdate, last.NodeID)
+}
+
+func TestAllocMetric_FailGang(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		metric   *AllocMetric
+		expected string
+	}{
+		{
+			name:     "no nodes",
+			metric:   &AllocMetric{},
+			expected: "no nodes available",
+		},
+		{
+			name: "constraint",
+			metric: &AllocMetric{
+				NodesEvaluated:     3,
+				ConstraintFiltered: map[string]int{"${attr.kernel.name} = linux": 2},
+				DimensionExhausted: map[string]int{"memory": 1},
+			},
+			expected: "constraint ${attr.kernel.name} = linux",
+		},
+		{
+			name: "tied dimensions",
+			metric: &AllocMetric{
+				NodesEvaluated:     2,
+				DimensionExhausted: map[string]int{"memory": 1, "cpu": 1},
+			},
+			expected: "resources cpu",
+		},
+		{
+			name: "quota",
+			metric: &AllocMetric{
+				NodesEvaluated:     2,
+				DimensionExhausted: map[string]int{"cpu": 2},
+				QuotaExhausted:     []string{"memory exhausted (1024 needed > 512 limit)"},
+			},
+			expected: "quota memory exhausted (1024 needed > 512 limit)",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.metric.FailGang(8, 7)
+			require.Equal(t, 8, tc.metric.GangSize)
+			require.Equal(t, 7, tc.metric.GangPlaced)
+			require.Equal(t, tc.expected, tc.metric.GangBlockedBy)
+		})
+	}
+}
+
 func TestAllocation_MsgPackTags(t *testing.T) {
 	ci.Parallel(t)
 	planType := reflect.TypeOf(Allocation{})
@@ -4429,6 +4749,62 @@ func TestEvaluation_MsgPackTags(t *testing.T) {
 	assert.Equal(t, msgPackTags.Tag, reflect.StructTag(`codec:",omitempty"`))
 }
 
+func TestEvaluation_BlockedReason(t *testing.T) {
+	ci.Parallel(t)
+
+	cases := []struct {
+		name     string
+		eval     *Evaluation
+		expected string
+	}{
+		{
+			name:     "quota",
+			eval:     &Evaluation{QuotaLimitReached: "q1"},
+			expected: `quota "q1" limit reached`,
+		},
+		{
+			name:     "max plans",
+			eval:     &Evaluation{TriggeredBy: EvalTriggerMaxPlans},
+			expected: "plan attempts exhausted",
+		},
+		{
+			name: "resources",
+			eval: &Evaluation{FailedTGAllocs: map[string]*AllocMetric{
+				"web":   {NodesEvaluated: 2, DimensionExhausted: map[string]int{"memory": 2}},
+				"cache": {NodesEvaluated: 2, DimensionExhausted: map[string]int{"cpu": 1}},
+			}},
+			expected: "resources exhausted: cpu, memory",
+		},
+		{
+			name: "no nodes",
+			eval: &Evaluation{FailedTGAllocs: map[string]*AllocMetric{
+				"web": {},
+			}},
+			expected: "no nodes available",
+		},
+		{
+			name: "constraints",
+			eval: &Evaluation{FailedTGAllocs: map[string]*AllocMetric{
+				"web": {NodesEvaluated: 3, NodesFiltered: 3},
+			}},
+			expected: "no nodes satisfy the job's constraints",
+		},
+		{
+			name: "other",
+			eval: &Evaluation{FailedTGAllocs: map[string]*AllocMetric{
+				"web": {NodesEvaluated: 3, NodesFiltered: 1},
+			}},
+			expected: "waiting for capacity",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.eval.BlockedReason())
+		})
+	}
+}
+
 func TestAllocation_Terminated(t *testing.T) {
 	ci.Parallel(t)
 	type desiredState struct {
@@ -5771,6 +6147,19 @@ func TestJobConfig_Validate_MaxClientDisconnect(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestJobConfig_Validate_Gang(t *testing.T) {
+	ci.Parallel(t)
+
+	job := testJob()
+	job.TaskGroups[0].Gang = true
+	require.NoError(t, job.Validate())
+
+	job.Type = JobTypeSystem
+	err := job.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Gang scheduling is not supported for system jobs")
+}
+
 func TestParameterizedJobConfig_Canonicalize(t *testing.T) {
 	ci.Parallel(t)
 
@@ -6014,8 +6403,20 @@ func TestACLTokenValidate(t *testing.T) {
 		t.Fatalf("bad: %v", err)
 	}
 
-	// Invalid policies
+	// Roles alone are enough for a client token
+	tk.Roles = []*ACLTokenRoleLink{{ID: uuid.Generate()}}
+	assert.Nil(t, tk.Validate())
+
+	// Invalid roles
 	tk.Type = ACLManagementToken
+	err = tk.Validate()
+	assert.NotNil(t, err)
+	if !strings.Contains(err.Error(), "associated with roles") {
+		t.Fatalf("bad: %v", err)
+	}
+	tk.Roles = nil
+
+	// Invalid policies
 	tk.Policies = []string{"foo"}
 	err = tk.Validate()
 	assert.NotNil(t, err)
@@ -6039,6 +6440,21 @@ func TestACLTokenValidate(t *testing.T) {
 	tk.Name = "foo"
 	err = tk.Validate()
 	assert.Nil(t, err)
+
+	// Negative expiration TTL
+	tk.ExpirationTTL = -time.Minute
+	err = tk.Validate()
+	assert.NotNil(t, err)
+	if !strings.Contains(err.Error(), "cannot be negative") {
+		t.Fatalf("bad: %v", err)
+	}
+
+	// Stored tokens have both an expiration time and TTL
+	expirationTime := time.Now().Add(time.Hour)
+	tk.ExpirationTTL = time.Hour
+	tk.ExpirationTime = &expirationTime
+	err = tk.Validate()
+	assert.Nil(t, err)
 }
 
 func TestACLTokenPolicySubset(t *testing.T) {
@@ -6612,6 +7028,71 @@ func TestSpread_Validate(t *testing.T) {
 			err:  nil,
 			name: "Valid spread",
 		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   -1,
+			},
+			err:  fmt.Errorf("Spread max_skew must not be negative; got -1"),
+			name: "Invalid max_skew",
+		},
+		{
+			spread: &Spread{
+				Attribute:        "${meta.zone}",
+				Weight:           50,
+				NestedAttributes: []string{"${meta.rack}"},
+			},
+			err:  fmt.Errorf("Spread nested_attributes requires max_skew"),
+			name: "Nested attributes without max_skew",
+		},
+		{
+			spread: &Spread{
+				Attribute: "${node.datacenter}",
+				Weight:    50,
+				MaxSkew:   1,
+				SpreadTarget: []*SpreadTarget{
+					{
+						Value:   "dc1",
+						Percent: 50,
+					},
+				},
+			},
+			err:  fmt.Errorf("Spread targets can't be combined with max_skew"),
+			name: "Targets with max_skew",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${meta.zone}",
+				Weight:            50,
+				MaxSkew:           1,
+				WhenUnsatisfiable: "never",
+			},
+			err:  fmt.Errorf("Spread when_unsatisfiable must be \"do_not_schedule\" or \"schedule_anyway\"; got \"never\""),
+			name: "Invalid when_unsatisfiable",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${meta.zone}",
+				Weight:            50,
+				MaxSkew:           1,
+				WhenUnsatisfiable: SpreadWhenUnsatisfiableDoNotSchedule,
+				NestedAttributes:  []string{"${meta.zone}"},
+			},
+			err:  fmt.Errorf("Spread nested attribute \"${meta.zone}\" must be set and differ from the spread attribute"),
+			name: "Nested attribute repeats the attribute",
+		},
+		{
+			spread: &Spread{
+				Attribute:         "${meta.zone}",
+				Weight:            50,
+				MaxSkew:           1,
+				WhenUnsatisfiable: SpreadWhenUnsatisfiableDoNotSchedule,
+				NestedAttributes:  []string{"${meta.rack}"},
+			},
+			err:  nil,
+			name: "Valid max_skew spread",
+		},
 	}
 
 	for _, tc := range testCases {
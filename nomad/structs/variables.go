@@ -0,0 +1,378 @@
+package structs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/helper"
+)
+
+const (
+	// VariablesUpsertRPCMethod is the RPC method for creating or updating a
+	// variable.
+	//
+	// Args: VariablesUpsertRequest
+	// Reply: VariablesUpsertResponse
+	VariablesUpsertRPCMethod = "Variables.Upsert"
+
+	// VariablesDeleteRPCMethod is the RPC method for deleting a variable.
+	//
+	// Args: VariablesDeleteRequest
+	// Reply: VariablesDeleteResponse
+	VariablesDeleteRPCMethod = "Variables.Delete"
+
+	// VariablesReadRPCMethod is the RPC method for reading a single decrypted
+	// variable by its path.
+	//
+	// Args: VariablesReadRequest
+	// Reply: VariablesReadResponse
+	VariablesReadRPCMethod = "Variables.Read"
+
+	// VariablesListRPCMethod is the RPC method for listing the metadata of
+	// variables within a namespace.
+	//
+	// Args: VariablesListRequest
+	// Reply: VariablesListResponse
+	VariablesListRPCMethod = "Variables.List"
+)
+
+const (
+	// VariablesJobsPrefix is the path prefix under which variables are
+	// implicitly readable by the workloads of the job named in the following
+	// path segment, for example "nomad/jobs/example/web".
+	VariablesJobsPrefix = "nomad/jobs"
+
+	// variablesReservedPrefix is the path prefix reserved for use by Nomad.
+	// Only paths under VariablesJobsPrefix can be written within it.
+	variablesReservedPrefix = "nomad/"
+
+	// maxVariableSize is the maximum total size in bytes of the keys and
+	// values within a single variable.
+	maxVariableSize = 64 * 1024
+)
+
+var (
+	// validVariablePath is the validation regex for variable paths.
+	validVariablePath = regexp.MustCompile("^[a-zA-Z0-9-_~/]{1,128}$")
+)
+
+// VariableMetadata is the metadata envelope for a variable. It is the portion
+// of a variable which is never encrypted, and is therefore what is returned
+// when listing variables.
+type VariableMetadata struct {
+	Namespace   string
+	Path        string
+	CreateIndex uint64
+	CreateTime  int64
+	ModifyIndex uint64
+	ModifyTime  int64
+}
+
+// VariableEncrypted is the form of a variable that is stored within Raft and
+// the state store. The items are only ever held as ciphertext.
+type VariableEncrypted struct {
+	VariableMetadata
+	VariableData
+}
+
+// VariableData is the encrypted portion of a variable, along with the ID of
+// the root key that was used to encrypt it.
+type VariableData struct {
+	Data  []byte
+	KeyID string
+}
+
+// VariableDecrypted is the form of a variable that is sent to and received
+// from users of the RPC and HTTP APIs.
+type VariableDecrypted struct {
+	VariableMetadata
+	Items VariableItems
+}
+
+// VariableItems are the actual secrets stored in a variable. They are always
+// encrypted and decrypted as a single unit.
+type VariableItems map[string]string
+
+// Size returns the total size in bytes of the keys and values of the items.
+func (vi VariableItems) Size() uint64 {
+	var out uint64
+	for k, v := range vi {
+		out += uint64(len(k))
+		out += uint64(len(v))
+	}
+	return out
+}
+
+// Copy returns a deep copy of the metadata. It handles nil objects.
+func (vm *VariableMetadata) Copy() *VariableMetadata {
+	if vm == nil {
+		return nil
+	}
+	out := *vm
+	return &out
+}
+
+// GetID is a helper for getting the path when the object may be nil and is
+// required for pagination.
+func (vm *VariableMetadata) GetID() string {
+	if vm == nil {
+		return ""
+	}
+	return vm.Path
+}
+
+// GetNamespace is a helper for getting the namespace when the object may be
+// nil and is required for pagination.
+func (vm *VariableMetadata) GetNamespace() string {
+	if vm == nil {
+		return ""
+	}
+	return vm.Namespace
+}
+
+// Copy returns a deep copy of the encrypted variable. It handles nil objects.
+func (v *VariableEncrypted) Copy() *VariableEncrypted {
+	if v == nil {
+		return nil
+	}
+	data := make([]byte, len(v.Data))
+	copy(data, v.Data)
+	return &VariableEncrypted{
+		VariableMetadata: v.VariableMetadata,
+		VariableData: VariableData{
+			Data:  data,
+			KeyID: v.KeyID,
+		},
+	}
+}
+
+// Validate returns an error if the encrypted variable is invalid. The items
+// have already been validated in their decrypted form, so only the envelope
+// is checked here.
+func (v *VariableEncrypted) Validate() error {
+	if len(v.Namespace) == 0 {
+		return fmt.Errorf("variable namespace is required")
+	}
+	if len(v.Path) == 0 {
+		return fmt.Errorf("variable path is required")
+	}
+	if len(v.Data) == 0 {
+		return fmt.Errorf("variable data is required")
+	}
+	if len(v.KeyID) == 0 {
+		return fmt.Errorf("variable key ID is required")
+	}
+	return nil
+}
+
+// Copy returns a deep copy of the decrypted variable. It handles nil objects.
+func (v *VariableDecrypted) Copy() *VariableDecrypted {
+	if v == nil {
+		return nil
+	}
+	return &VariableDecrypted{
+		VariableMetadata: v.VariableMetadata,
+		Items:            VariableItems(helper.CopyMapStringString(v.Items)),
+	}
+}
+
+// Validate returns an error if the decrypted variable is invalid.
+func (v *VariableDecrypted) Validate() error {
+	var mErr *multierror.Error
+
+	if err := ValidateVariablePath(v.Path); err != nil {
+		mErr = multierror.Append(mErr, err)
+	}
+	if len(v.Items) == 0 {
+		mErr = multierror.Append(mErr, fmt.Errorf("variable missing Items"))
+	}
+	for k := range v.Items {
+		if k == "" {
+			mErr = multierror.Append(mErr, fmt.Errorf("variable items must have non-empty keys"))
+			break
+		}
+	}
+	if size := v.Items.Size(); size > maxVariableSize {
+		mErr = multierror.Append(mErr, fmt.Errorf("variable items size %d exceeds maximum of %d bytes", size, maxVariableSize))
+	}
+
+	return mErr.ErrorOrNil()
+}
+
+// ValidateVariablePath returns an error if the path cannot be used to store
+// a variable.
+func ValidateVariablePath(path string) error {
+	if !validVariablePath.MatchString(path) {
+		return fmt.Errorf("invalid path %q, must match regex %s", path, validVariablePath)
+	}
+	if strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") || strings.Contains(path, "//") {
+		return fmt.Errorf("invalid path %q, must not have leading, trailing or repeated slashes", path)
+	}
+	if strings.HasPrefix(path, variablesReservedPrefix) &&
+		path != VariablesJobsPrefix && !strings.HasPrefix(path, VariablesJobsPrefix+"/") {
+		return fmt.Errorf("invalid path %q, only paths under %q may be written within the reserved %q prefix",
+			path, VariablesJobsPrefix, variablesReservedPrefix)
+	}
+	return nil
+}
+
+// VarOp is the operation being applied to a variable within the state store.
+type VarOp string
+
+const (
+	VarOpSet       VarOp = "set"
+	VarOpDelete    VarOp = "delete"
+	VarOpDeleteCAS VarOp = "delete-cas"
+	VarOpCAS       VarOp = "cas"
+)
+
+// VarOpResult is the outcome of applying a VarOp to the state store.
+type VarOpResult string
+
+const (
+	VarOpResultOk       VarOpResult = "ok"
+	VarOpResultConflict VarOpResult = "conflict"
+	VarOpResultError    VarOpResult = "error"
+)
+
+// VarApplyStateRequest is used by the Variables RPC endpoint to apply an
+// encrypted variable operation via Raft. For the check-and-set operations,
+// the expected current modify index is carried in the variable ModifyIndex;
+// a value of zero indicates the variable must not exist.
+type VarApplyStateRequest struct {
+	Op  VarOp
+	Var *VariableEncrypted
+	WriteRequest
+}
+
+// SuccessResponse builds the response for an operation that was applied.
+func (r *VarApplyStateRequest) SuccessResponse(index uint64, meta *VariableMetadata) *VarApplyStateResponse {
+	return &VarApplyStateResponse{
+		Op:             r.Op,
+		Result:         VarOpResultOk,
+		WrittenVarMeta: meta,
+		WriteMeta:      WriteMeta{Index: index},
+	}
+}
+
+// ConflictResponse builds the response for a check-and-set operation that
+// failed because the variable was modified.
+func (r *VarApplyStateRequest) ConflictResponse(index uint64, conflict *VariableEncrypted) *VarApplyStateResponse {
+	return &VarApplyStateResponse{
+		Op:        r.Op,
+		Result:    VarOpResultConflict,
+		Conflict:  conflict,
+		WriteMeta: WriteMeta{Index: index},
+	}
+}
+
+// ErrorResponse builds the response for an operation that failed.
+func (r *VarApplyStateRequest) ErrorResponse(index uint64, err error) *VarApplyStateResponse {
+	return &VarApplyStateResponse{
+		Op:        r.Op,
+		Result:    VarOpResultError,
+		Error:     err,
+		WriteMeta: WriteMeta{Index: index},
+	}
+}
+
+// VarApplyStateResponse is returned by the FSM when applying a
+// VarApplyStateRequest.
+type VarApplyStateResponse struct {
+	Op     VarOp
+	Result VarOpResult
+
+	// Conflict is the current state of the variable when a check-and-set
+	// operation failed.
+	Conflict *VariableEncrypted
+
+	// WrittenVarMeta is the metadata of the variable that was written by a
+	// successful set operation.
+	WrittenVarMeta *VariableMetadata
+
+	// Error is any error encountered while applying the operation.
+	Error error
+	WriteMeta
+}
+
+// IsOk returns true if the operation was applied successfully.
+func (r *VarApplyStateResponse) IsOk() bool {
+	return r.Result == VarOpResultOk
+}
+
+// IsConflict returns true if a check-and-set operation failed because the
+// variable has been modified.
+func (r *VarApplyStateResponse) IsConflict() bool {
+	return r.Result == VarOpResultConflict
+}
+
+// IsError returns true if applying the operation failed.
+func (r *VarApplyStateResponse) IsError() bool {
+	return r.Result == VarOpResultError
+}
+
+// VariablesUpsertRequest is used to create or update a variable. If
+// CheckIndex is set, the write only succeeds if the variable's current modify
+// index matches it; zero means the variable must not already exist.
+type VariablesUpsertRequest struct {
+	Var        *VariableDecrypted
+	CheckIndex *uint64
+	WriteRequest
+}
+
+// VariablesUpsertResponse is the response object when creating or updating a
+// variable.
+type VariablesUpsertResponse struct {
+	// Conflict is set when a check-and-set write failed and holds the current
+	// variable. The items are only populated if the caller may read them.
+	Conflict *VariableDecrypted
+
+	// Output is the variable as written.
+	Output *VariableDecrypted
+	WriteMeta
+}
+
+// VariablesDeleteRequest is used to delete a variable. If CheckIndex is set,
+// the delete only succeeds if the variable's current modify index matches it.
+type VariablesDeleteRequest struct {
+	Path       string
+	CheckIndex *uint64
+	WriteRequest
+}
+
+// VariablesDeleteResponse is the response object when deleting a variable.
+type VariablesDeleteResponse struct {
+	// Conflict is set when a check-and-set delete failed and holds the
+	// current variable. The items are only populated if the caller may read
+	// them.
+	Conflict *VariableDecrypted
+	WriteMeta
+}
+
+// VariablesReadRequest is used to read a single variable by path.
+type VariablesReadRequest struct {
+	Path string
+	QueryOptions
+}
+
+// VariablesReadResponse is the response object when reading a single
+// variable. Data is nil if the variable was not found.
+type VariablesReadResponse struct {
+	Data *VariableDecrypted
+	QueryMeta
+}
+
+// VariablesListRequest is used to list variables. The QueryOptions Prefix
+// field restricts the listing to paths with the given prefix.
+type VariablesListRequest struct {
+	QueryOptions
+}
+
+// VariablesListResponse is the response object when listing variables. Only
+// metadata is returned.
+type VariablesListResponse struct {
+	Data []*VariableMetadata
+	QueryMeta
+}
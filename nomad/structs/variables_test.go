@@ -0,0 +1,154 @@
+package structs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariableDecrypted_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := &VariableDecrypted{
+		VariableMetadata: VariableMetadata{
+			Namespace: "a",
+			Path:      "a/b/c",
+		},
+		Items: VariableItems{"foo": "bar"},
+	}
+	svCopy := sv.Copy()
+	svCopy.Path = "d/e/f"
+	svCopy.Items["foo"] = "baz"
+
+	require.Equal(t, "a/b/c", sv.Path)
+	require.Equal(t, "bar", sv.Items["foo"])
+	require.Nil(t, (*VariableDecrypted)(nil).Copy())
+}
+
+func TestVariableEncrypted_Copy(t *testing.T) {
+	ci.Parallel(t)
+
+	sv := &VariableEncrypted{
+		VariableMetadata: VariableMetadata{
+			Namespace: "a",
+			Path:      "a/b/c",
+		},
+		VariableData: VariableData{
+			Data:  []byte("ciphertext"),
+			KeyID: "key",
+		},
+	}
+	svCopy := sv.Copy()
+	svCopy.Data[0] = 'C'
+
+	require.Equal(t, []byte("ciphertext"), sv.Data)
+	require.Nil(t, (*VariableEncrypted)(nil).Copy())
+}
+
+func TestVariableDecrypted_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	testCases := []struct {
+		name        string
+		path        string
+		items       VariableItems
+		expectedErr string
+	}{
+		{
+			name:  "valid",
+			path:  "a/b/c",
+			items: VariableItems{"foo": "bar"},
+		},
+		{
+			name:  "valid job path",
+			path:  "nomad/jobs/example",
+			items: VariableItems{"foo": "bar"},
+		},
+		{
+			name:        "invalid characters",
+			path:        "a/b@c",
+			items:       VariableItems{"foo": "bar"},
+			expectedErr: "must match regex",
+		},
+		{
+			name:        "leading slash",
+			path:        "/a/b",
+			items:       VariableItems{"foo": "bar"},
+			expectedErr: "must not have leading, trailing or repeated slashes",
+		},
+		{
+			name:        "repeated slash",
+			path:        "a//b",
+			items:       VariableItems{"foo": "bar"},
+			expectedErr: "must not have leading, trailing or repeated slashes",
+		},
+		{
+			name:        "reserved prefix",
+			path:        "nomad/foo",
+			items:       VariableItems{"foo": "bar"},
+			expectedErr: "reserved",
+		},
+		{
+			name:        "reserved jobs prefix lookalike",
+			path:        "nomad/jobsfoo",
+			items:       VariableItems{"foo": "bar"},
+			expectedErr: "reserved",
+		},
+		{
+			name:        "missing items",
+			path:        "a/b/c",
+			expectedErr: "variable missing Items",
+		},
+		{
+			name:        "empty key",
+			path:        "a/b/c",
+			items:       VariableItems{"": "bar"},
+			expectedErr: "non-empty keys",
+		},
+		{
+			name:        "too large",
+			path:        "a/b/c",
+			items:       VariableItems{"foo": strings.Repeat("a", maxVariableSize)},
+			expectedErr: "exceeds maximum",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sv := &VariableDecrypted{
+				VariableMetadata: VariableMetadata{
+					Namespace: DefaultNamespace,
+					Path:      tc.path,
+				},
+				Items: tc.items,
+			}
+			err := sv.Validate()
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRootKey_Validate(t *testing.T) {
+	ci.Parallel(t)
+
+	key, err := NewRootKey(EncryptionAlgorithmAES256GCM)
+	require.NoError(t, err)
+	require.NoError(t, key.Validate())
+	require.True(t, key.Meta.Active())
+	require.Len(t, key.Key, 32)
+
+	keyCopy := key.Copy()
+	keyCopy.Key[0]++
+	keyCopy.Meta.State = RootKeyStateInactive
+	require.NotEqual(t, keyCopy.Key[0], key.Key[0])
+	require.True(t, key.Meta.Active())
+
+	key.Meta.KeyID = "not-a-uuid"
+	require.Error(t, key.Validate())
+}
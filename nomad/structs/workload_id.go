@@ -0,0 +1,53 @@
+package structs
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/hashicorp/nomad/helper/uuid"
+)
+
+const (
+	// WorkloadIdentityFile is the name of the file holding the signed
+	// workload identity of a task within its secrets directory.
+	WorkloadIdentityFile = "nomad_token"
+
+	// WorkloadIdentityAlgorithm is the JWT signing algorithm used for
+	// workload identities.
+	WorkloadIdentityAlgorithm = "EdDSA"
+
+	// WorkloadIdentityKeyUse is the intended use of the public keys which
+	// verify workload identities, as published in the JWKS.
+	WorkloadIdentityKeyUse = "sig"
+)
+
+// IdentityClaims are the claims of the workload identity signed by the
+// servers for each task of an allocation. They identify the allocation and
+// task the token was issued to, and are used to resolve the implicit ACL
+// policy of the workload when the token is used as a Nomad API credential.
+type IdentityClaims struct {
+	Namespace    string `json:"nomad_namespace"`
+	JobID        string `json:"nomad_job_id"`
+	TaskGroup    string `json:"nomad_task_group"`
+	TaskName     string `json:"nomad_task"`
+	AllocationID string `json:"nomad_allocation_id"`
+
+	jwt.StandardClaims
+}
+
+// NewIdentityClaims returns the claims of the workload identity for the
+// task of the given allocation.
+func NewIdentityClaims(job *Job, alloc *Allocation, taskName string, now time.Time) *IdentityClaims {
+	return &IdentityClaims{
+		Namespace:    alloc.Namespace,
+		JobID:        job.ID,
+		TaskGroup:    alloc.TaskGroup,
+		TaskName:     taskName,
+		AllocationID: alloc.ID,
+		StandardClaims: jwt.StandardClaims{
+			Id:       uuid.Generate(),
+			Subject:  alloc.ID + ":" + taskName,
+			IssuedAt: now.Unix(),
+		},
+	}
+}
@@ -0,0 +1,404 @@
+package nomad
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/state/paginator"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// Variables encapsulates the variables RPC endpoint which is callable via the
+// Variables RPCs and externally via the "/v1/var{s}" HTTP API.
+type Variables struct {
+	srv       *Server
+	logger    hclog.Logger
+	encrypter *Encrypter
+}
+
+// Upsert creates or updates a variable held within Nomad. If the request has
+// a CheckIndex, the write is only performed if it matches the current modify
+// index of the variable, otherwise the current variable is returned within
+// the reply as a conflict.
+func (v *Variables) Upsert(
+	args *structs.VariablesUpsertRequest,
+	reply *structs.VariablesUpsertResponse) error {
+
+	if done, err := v.srv.forward(structs.VariablesUpsertRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "upsert"}, time.Now())
+
+	if args.Var == nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing variable")
+	}
+
+	// The variable is always written into the namespace of the request, which
+	// is used for forwarding and ACL checks.
+	ns := args.RequestNamespace()
+	if args.Var.Namespace != "" && args.Var.Namespace != ns {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest,
+			"variable namespace %q does not match request namespace %q", args.Var.Namespace, ns)
+	}
+	args.Var.Namespace = ns
+
+	aclObj, err := v.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if aclObj != nil && !aclObj.AllowVariableOperation(ns, args.Var.Path, acl.VariablesCapabilityWrite) {
+		return structs.ErrPermissionDenied
+	}
+
+	if err := args.Var.Validate(); err != nil {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, err.Error())
+	}
+	if err := v.namespaceExists(ns); err != nil {
+		return err
+	}
+
+	encrypted, err := v.encrypt(args.Var)
+	if err != nil {
+		return err
+	}
+
+	req := &structs.VarApplyStateRequest{
+		Op:           structs.VarOpSet,
+		Var:          encrypted,
+		WriteRequest: args.WriteRequest,
+	}
+	if args.CheckIndex != nil {
+		req.Op = structs.VarOpCAS
+		req.Var.ModifyIndex = *args.CheckIndex
+	}
+
+	resp, err := v.apply(req)
+	if err != nil {
+		return err
+	}
+	reply.Index = resp.Index
+
+	if resp.IsConflict() {
+		reply.Conflict, err = v.conflict(aclObj, resp.Conflict)
+		return err
+	}
+
+	reply.Output = &structs.VariableDecrypted{
+		VariableMetadata: *resp.WrittenVarMeta,
+		Items:            args.Var.Copy().Items,
+	}
+	return nil
+}
+
+// Delete removes a variable from Nomad. If the request has a CheckIndex, the
+// delete is only performed if it matches the current modify index of the
+// variable, otherwise the current variable is returned within the reply as a
+// conflict.
+func (v *Variables) Delete(
+	args *structs.VariablesDeleteRequest,
+	reply *structs.VariablesDeleteResponse) error {
+
+	if done, err := v.srv.forward(structs.VariablesDeleteRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "delete"}, time.Now())
+
+	ns := args.RequestNamespace()
+
+	aclObj, err := v.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return err
+	}
+	if aclObj != nil && !aclObj.AllowVariableOperation(ns, args.Path, acl.VariablesCapabilityDestroy) {
+		return structs.ErrPermissionDenied
+	}
+
+	if args.Path == "" {
+		return structs.NewErrRPCCoded(http.StatusBadRequest, "missing variable path")
+	}
+
+	req := &structs.VarApplyStateRequest{
+		Op: structs.VarOpDelete,
+		Var: &structs.VariableEncrypted{
+			VariableMetadata: structs.VariableMetadata{
+				Namespace: ns,
+				Path:      args.Path,
+			},
+		},
+		WriteRequest: args.WriteRequest,
+	}
+	if args.CheckIndex != nil {
+		req.Op = structs.VarOpDeleteCAS
+		req.Var.ModifyIndex = *args.CheckIndex
+	}
+
+	resp, err := v.apply(req)
+	if err != nil {
+		return err
+	}
+	reply.Index = resp.Index
+
+	if resp.IsConflict() {
+		reply.Conflict, err = v.conflict(aclObj, resp.Conflict)
+		return err
+	}
+	return nil
+}
+
+// Read is used to read a single decrypted variable by its path. The reply
+// data is nil if the variable does not exist.
+func (v *Variables) Read(
+	args *structs.VariablesReadRequest,
+	reply *structs.VariablesReadResponse) error {
+
+	if done, err := v.srv.forward(structs.VariablesReadRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "read"}, time.Now())
+
+	allowFunc, err := v.handleMixedAuthEndpoint(args.QueryOptions, acl.VariablesCapabilityRead)
+	if err != nil {
+		return err
+	}
+	if !allowFunc(args.RequestNamespace(), args.Path) {
+		return structs.ErrPermissionDenied
+	}
+
+	return v.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+			out, err := stateStore.GetVariable(ws, args.RequestNamespace(), args.Path)
+			if err != nil {
+				return err
+			}
+
+			reply.Data = nil
+			if out == nil {
+				// Use the index table to populate the query meta as we have
+				// no way of tracking the max index on deletes.
+				return v.srv.setReplyQueryMeta(stateStore, state.TableVariables, &reply.QueryMeta)
+			}
+
+			reply.Data, err = v.decrypt(out)
+			if err != nil {
+				return err
+			}
+			reply.Index = out.ModifyIndex
+			v.srv.setQueryMeta(&reply.QueryMeta)
+			return nil
+		},
+	})
+}
+
+// List is used to list the metadata of the variables held within Nomad. It
+// supports single and wildcard namespace listings, and only returns the
+// variables the caller is permitted to list.
+func (v *Variables) List(
+	args *structs.VariablesListRequest,
+	reply *structs.VariablesListResponse) error {
+
+	if done, err := v.srv.forward(structs.VariablesListRPCMethod, args, args, reply); done {
+		return err
+	}
+	defer metrics.MeasureSince([]string{"nomad", "variables", "list"}, time.Now())
+
+	allowFunc, err := v.handleMixedAuthEndpoint(args.QueryOptions, acl.VariablesCapabilityList)
+	if err != nil {
+		return err
+	}
+
+	return v.srv.blockingRPC(&blockingOptions{
+		queryOpts: &args.QueryOptions,
+		queryMeta: &reply.QueryMeta,
+		run: func(ws memdb.WatchSet, stateStore *state.StateStore) error {
+
+			// Perform the state query to get an iterator.
+			var iter memdb.ResultIterator
+			if ns := args.RequestNamespace(); ns == structs.AllNamespacesSentinel {
+				iter, err = stateStore.GetVariables(ws)
+			} else {
+				iter, err = stateStore.GetVariablesByNamespaceAndPrefix(ws, ns, args.Prefix)
+			}
+			if err != nil {
+				return err
+			}
+
+			// Generate the tokenizer to use for pagination using namespace
+			// and path to ensure complete uniqueness.
+			tokenizer := paginator.NewStructsTokenizer(iter,
+				paginator.StructsTokenizerOptions{
+					WithNamespace: true,
+					WithID:        true,
+				},
+			)
+
+			// Only include the variables the caller is permitted to list,
+			// and which match the prefix for wildcard namespace listings.
+			filters := []paginator.Filter{
+				paginator.GenericFilter{
+					Allow: func(raw interface{}) (bool, error) {
+						sv := raw.(*structs.VariableEncrypted)
+						if !strings.HasPrefix(sv.Path, args.Prefix) {
+							return false, nil
+						}
+						return allowFunc(sv.Namespace, sv.Path), nil
+					},
+				},
+			}
+
+			// Set up our output after we have checked the error.
+			svs := []*structs.VariableMetadata{}
+
+			// Build the paginator. This includes the function that is
+			// responsible for appending a variable to the results.
+			paginatorImpl, err := paginator.NewPaginator(iter, tokenizer, filters, args.QueryOptions,
+				func(raw interface{}) error {
+					sv := raw.(*structs.VariableEncrypted)
+					svs = append(svs, sv.VariableMetadata.Copy())
+					return nil
+				})
+			if err != nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "failed to create result paginator: %v", err)
+			}
+
+			// Calling page populates our output variable metadata array as
+			// well as returns the next token.
+			nextToken, err := paginatorImpl.Page()
+			if err != nil {
+				return structs.NewErrRPCCodedf(
+					http.StatusBadRequest, "failed to read result page: %v", err)
+			}
+
+			// Populate the reply.
+			reply.Data = svs
+			reply.NextToken = nextToken
+
+			// Use the index table to populate the query meta as we have no way
+			// of tracking the max index on deletes.
+			return v.srv.setReplyQueryMeta(stateStore, state.TableVariables, &reply.QueryMeta)
+		},
+	})
+}
+
+// apply submits the variable operation to Raft and returns the response of
+// the FSM. Errors encountered by the FSM are returned as errors.
+func (v *Variables) apply(req *structs.VarApplyStateRequest) (*structs.VarApplyStateResponse, error) {
+	out, index, err := v.srv.raftApply(structs.VarApplyStateRequestType, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, ok := out.(*structs.VarApplyStateResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T from variable operation", out)
+	}
+	if resp.IsError() {
+		return nil, resp.Error
+	}
+	resp.Index = index
+	return resp, nil
+}
+
+// encrypt serializes and encrypts the items of the variable, and returns the
+// variable in the form it is stored in state. The create and modify times are
+// set here, so that every server applies identical values.
+func (v *Variables) encrypt(sv *structs.VariableDecrypted) (*structs.VariableEncrypted, error) {
+	buf, err := json.Marshal(sv.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	data, keyID, err := v.encrypter.Encrypt(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixNano()
+	return &structs.VariableEncrypted{
+		VariableMetadata: structs.VariableMetadata{
+			Namespace:  sv.Namespace,
+			Path:       sv.Path,
+			CreateTime: now,
+			ModifyTime: now,
+		},
+		VariableData: structs.VariableData{
+			Data:  data,
+			KeyID: keyID,
+		},
+	}, nil
+}
+
+// decrypt returns the decrypted form of the variable held in state.
+func (v *Variables) decrypt(sv *structs.VariableEncrypted) (*structs.VariableDecrypted, error) {
+	buf, err := v.encrypter.Decrypt(sv.Data, sv.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &structs.VariableDecrypted{
+		VariableMetadata: sv.VariableMetadata,
+	}
+	if err := json.Unmarshal(buf, &out.Items); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// conflict returns the decrypted form of a variable which caused a
+// check-and-set operation to fail. The items are only included if the caller
+// is permitted to read them.
+func (v *Variables) conflict(aclObj *acl.ACL, sv *structs.VariableEncrypted) (*structs.VariableDecrypted, error) {
+	if sv == nil {
+		return nil, nil
+	}
+	if len(sv.Data) == 0 ||
+		(aclObj != nil && !aclObj.AllowVariableOperation(sv.Namespace, sv.Path, acl.VariablesCapabilityRead)) {
+		return &structs.VariableDecrypted{VariableMetadata: sv.VariableMetadata}, nil
+	}
+	return v.decrypt(sv)
+}
+
+// namespaceExists returns an error if the namespace does not exist.
+func (v *Variables) namespaceExists(ns string) error {
+	out, err := v.srv.State().NamespaceByName(nil, ns)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return structs.NewErrRPCCodedf(http.StatusBadRequest, "nonexistent namespace %q", ns)
+	}
+	return nil
+}
+
+// handleMixedAuthEndpoint is a helper to handle auth on RPC endpoints that can
+// either be called by tasks, in order to render templates, or by external
+// clients. It returns a function which reports whether the caller has the
+// capability on the variable at a given namespace and path.
+//
+// Tasks authenticate with their workload identity, whose implicit policy only
+// grants access to the variables of their own job, group and task.
+func (v *Variables) handleMixedAuthEndpoint(args structs.QueryOptions, cap string) (func(ns, path string) bool, error) {
+	aclObj, err := v.srv.ResolveToken(args.AuthToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the object is nil, this means ACLs are not enabled and all
+	// variables are accessible.
+	if aclObj == nil {
+		return func(string, string) bool { return true }, nil
+	}
+	return func(ns, path string) bool {
+		return aclObj.AllowVariableOperation(ns, path, cap)
+	}, nil
+}
@@ -0,0 +1,383 @@
+package nomad
+
+import (
+	"testing"
+	"time"
+
+	msgpackrpc "github.com/hashicorp/net-rpc-msgpackrpc"
+	"github.com/hashicorp/nomad/acl"
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// waitForKeyring waits until the leader has initialized the keyring, so that
+// variables can be encrypted.
+func waitForKeyring(t *testing.T, s *Server) {
+	testutil.WaitForResult(func() (bool, error) {
+		key, err := s.fsm.State().GetActiveRootKeyMeta(nil)
+		if err != nil {
+			return false, err
+		}
+		return key != nil, nil
+	}, func(err error) {
+		t.Fatalf("keyring was not initialized: %v", err)
+	})
+}
+
+func TestVariablesEndpoint_CRUD(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	sv := mock.Variable()
+	upsertReq := &structs.VariablesUpsertRequest{
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var upsertResp structs.VariablesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+	require.NotZero(t, upsertResp.Index)
+	require.Nil(t, upsertResp.Conflict)
+	require.Equal(t, sv.Items, upsertResp.Output.Items)
+	require.Equal(t, upsertResp.Index, upsertResp.Output.ModifyIndex)
+
+	// The variable should be encrypted at rest.
+	stored, err := s1.fsm.State().GetVariable(nil, sv.Namespace, sv.Path)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.NotEmpty(t, stored.KeyID)
+	require.NotContains(t, string(stored.Data), sv.Items["password"])
+
+	// Read the variable back decrypted.
+	readReq := &structs.VariablesReadRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: sv.Namespace,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.NotNil(t, readResp.Data)
+	require.Equal(t, sv.Items, readResp.Data.Items)
+	require.Equal(t, upsertResp.Index, readResp.Index)
+
+	// A check-and-set write with a stale index conflicts.
+	update := sv.Copy()
+	update.Items["password"] = "updated"
+	upsertReq = &structs.VariablesUpsertRequest{
+		Var:          update,
+		CheckIndex:   helper.Uint64ToPtr(upsertResp.Index - 1),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+	require.NotNil(t, upsertResp.Conflict)
+	require.Equal(t, sv.Items, upsertResp.Conflict.Items)
+
+	// A check-and-set write with the current index succeeds.
+	upsertReq.CheckIndex = helper.Uint64ToPtr(upsertResp.Conflict.ModifyIndex)
+	upsertResp = structs.VariablesUpsertResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+	require.Nil(t, upsertResp.Conflict)
+	require.Equal(t, "updated", upsertResp.Output.Items["password"])
+
+	// Listing returns only metadata.
+	listReq := &structs.VariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: sv.Namespace,
+			Prefix:    "var/",
+		},
+	}
+	var listResp structs.VariablesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 1)
+	require.Equal(t, sv.Path, listResp.Data[0].Path)
+
+	listReq.Prefix = "other/"
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 0)
+
+	// A check-and-set delete with a stale index conflicts.
+	deleteReq := &structs.VariablesDeleteRequest{
+		Path:         sv.Path,
+		CheckIndex:   helper.Uint64ToPtr(1),
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var deleteResp structs.VariablesDeleteResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesDeleteRPCMethod, deleteReq, &deleteResp))
+	require.NotNil(t, deleteResp.Conflict)
+
+	deleteReq.CheckIndex = nil
+	deleteResp = structs.VariablesDeleteResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesDeleteRPCMethod, deleteReq, &deleteResp))
+	require.Nil(t, deleteResp.Conflict)
+
+	readResp = structs.VariablesReadResponse{}
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Nil(t, readResp.Data)
+}
+
+func TestVariablesEndpoint_Upsert_Invalid(t *testing.T) {
+	ci.Parallel(t)
+	s1, cleanupS1 := TestServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	// Invalid paths are rejected.
+	sv := mock.Variable()
+	sv.Path = "nomad/reserved"
+	req := &structs.VariablesUpsertRequest{
+		Var:          sv,
+		WriteRequest: structs.WriteRequest{Region: "global"},
+	}
+	var resp structs.VariablesUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "reserved")
+
+	// Variables cannot be written to namespaces which do not exist.
+	sv = mock.Variable()
+	sv.Namespace = "nonexistent"
+	req.Var = sv
+	req.Namespace = "nonexistent"
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "nonexistent namespace")
+
+	// The variable namespace must match the request namespace.
+	sv = mock.Variable()
+	sv.Namespace = "other"
+	req.Var = sv
+	req.Namespace = structs.DefaultNamespace
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, req, &resp)
+	require.ErrorContains(t, err, "does not match request namespace")
+}
+
+func TestVariablesEndpoint_ACL(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	state := s1.fsm.State()
+	readToken := mock.CreatePolicyAndToken(t, state, 1001, "vars-read", `
+namespace "default" {
+  variables {
+    path "var/*" {
+      capabilities = ["read"]
+    }
+  }
+}`)
+	writeToken := mock.CreatePolicyAndToken(t, state, 1003, "vars-write",
+		mock.NamespacePolicy(structs.DefaultNamespace, "write", nil))
+	invalidToken := mock.CreatePolicyAndToken(t, state, 1005, "vars-invalid",
+		mock.NamespacePolicy(structs.DefaultNamespace, "", []string{acl.NamespaceCapabilityReadJob}))
+
+	sv := mock.Variable()
+	upsertReq := &structs.VariablesUpsertRequest{
+		Var: sv,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var upsertResp structs.VariablesUpsertResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	upsertReq.AuthToken = writeToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+
+	// Write a variable outside of the paths the read token has access to.
+	other := mock.Variable()
+	other.Path = "other/path"
+	upsertReq.Var = other
+	upsertReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, upsertReq, &upsertResp))
+
+	readReq := &structs.VariablesReadRequest{
+		Path: sv.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: invalidToken.SecretID,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	readReq.AuthToken = readToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Equal(t, sv.Items, readResp.Data.Items)
+
+	readReq.Path = other.Path
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	// Listing only returns the variables the token can list.
+	listReq := &structs.VariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			Namespace: structs.AllNamespacesSentinel,
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var listResp structs.VariablesListResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 1)
+	require.Equal(t, sv.Path, listResp.Data[0].Path)
+
+	listReq.AuthToken = root.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp))
+	require.Len(t, listResp.Data, 2)
+
+	// Deleting requires the destroy capability.
+	deleteReq := &structs.VariablesDeleteRequest{
+		Path: sv.Path,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: readToken.SecretID,
+		},
+	}
+	var deleteResp structs.VariablesDeleteResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesDeleteRPCMethod, deleteReq, &deleteResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+
+	deleteReq.AuthToken = writeToken.SecretID
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesDeleteRPCMethod, deleteReq, &deleteResp))
+}
+
+func TestVariablesEndpoint_NodeSecretAuth(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	state := s1.fsm.State()
+	node := mock.Node()
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	alloc := mock.Alloc()
+	alloc.NodeID = node.ID
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, alloc.Job))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	jobVar := mock.Variable()
+	jobVar.Path = structs.VariablesJobsPrefix + "/" + alloc.JobID
+	req := &structs.VariablesUpsertRequest{
+		Var: jobVar,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: root.SecretID,
+		},
+	}
+	var resp structs.VariablesUpsertResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, req, &resp))
+
+	// The node secret can't read the variables of the jobs running on the
+	// node, which are only readable with the workload identities of their
+	// tasks.
+	readReq := &structs.VariablesReadRequest{
+		Path: jobVar.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: node.SecretID,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	err := msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp)
+	require.EqualError(t, err, structs.ErrTokenNotFound.Error())
+
+	listReq := &structs.VariablesListRequest{
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: node.SecretID,
+		},
+	}
+	var listResp structs.VariablesListResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesListRPCMethod, listReq, &listResp)
+	require.EqualError(t, err, structs.ErrTokenNotFound.Error())
+}
+
+func TestVariablesEndpoint_WorkloadIdentity(t *testing.T) {
+	ci.Parallel(t)
+	s1, root, cleanupS1 := TestACLServer(t, nil)
+	defer cleanupS1()
+	codec := rpcClient(t, s1)
+	testutil.WaitForLeader(t, s1.RPC)
+	waitForKeyring(t, s1)
+
+	state := s1.fsm.State()
+	alloc := mock.Alloc()
+	alloc.ClientStatus = structs.AllocClientStatusRunning
+	require.NoError(t, state.UpsertJob(structs.MsgTypeTestSetup, 1001, alloc.Job))
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1002, []*structs.Allocation{alloc}))
+
+	task := alloc.Job.TaskGroups[0].Tasks[0].Name
+	token, err := s1.encrypter.SignClaims(
+		structs.NewIdentityClaims(alloc.Job, alloc, task, time.Now()))
+	require.NoError(t, err)
+
+	jobVar := mock.Variable()
+	jobVar.Path = structs.VariablesJobsPrefix + "/" + alloc.JobID + "/" + alloc.TaskGroup + "/" + task
+	otherVar := mock.Variable()
+	otherTaskVar := mock.Variable()
+	otherTaskVar.Path = structs.VariablesJobsPrefix + "/" + alloc.JobID + "/" + alloc.TaskGroup + "/other"
+	otherJobVar := mock.Variable()
+	otherJobVar.Path = structs.VariablesJobsPrefix + "/other/" + alloc.TaskGroup + "/" + task
+	for _, sv := range []*structs.VariableDecrypted{jobVar, otherVar, otherTaskVar, otherJobVar} {
+		req := &structs.VariablesUpsertRequest{
+			Var: sv,
+			WriteRequest: structs.WriteRequest{
+				Region:    "global",
+				AuthToken: root.SecretID,
+			},
+		}
+		var resp structs.VariablesUpsertResponse
+		require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, req, &resp))
+	}
+
+	// The workload identity can read the variables of its task.
+	readReq := &structs.VariablesReadRequest{
+		Path: jobVar.Path,
+		QueryOptions: structs.QueryOptions{
+			Region:    "global",
+			AuthToken: token,
+		},
+	}
+	var readResp structs.VariablesReadResponse
+	require.NoError(t, msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp))
+	require.Equal(t, jobVar.Items, readResp.Data.Items)
+
+	// But not those of other tasks or jobs.
+	for _, path := range []string{otherVar.Path, otherTaskVar.Path, otherJobVar.Path} {
+		readReq.Path = path
+		err = msgpackrpc.CallWithCodec(codec, structs.VariablesReadRPCMethod, readReq, &readResp)
+		require.EqualError(t, err, structs.ErrPermissionDenied.Error(), path)
+	}
+
+	// Nor can it write them.
+	writeReq := &structs.VariablesUpsertRequest{
+		Var: jobVar,
+		WriteRequest: structs.WriteRequest{
+			Region:    "global",
+			AuthToken: token,
+		},
+	}
+	var writeResp structs.VariablesUpsertResponse
+	err = msgpackrpc.CallWithCodec(codec, structs.VariablesUpsertRPCMethod, writeReq, &writeResp)
+	require.EqualError(t, err, structs.ErrPermissionDenied.Error())
+}
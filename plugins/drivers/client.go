@@ -74,6 +74,7 @@ func (d *driverPluginClient) Capabilities() (*Capabilities, error) {
 
 		caps.MountConfigs = MountConfigSupport(resp.Capabilities.MountConfigs)
 		caps.RemoteTasks = resp.Capabilities.RemoteTasks
+		caps.Checkpoint = resp.Capabilities.Checkpoint
 	}
 
 	return caps, nil
@@ -154,19 +155,7 @@ func (d *driverPluginClient) StartTask(c *TaskConfig) (*TaskHandle, *DriverNetwo
 		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
 	}
 
-	var net *DriverNetwork
-	if resp.NetworkOverride != nil {
-		net = &DriverNetwork{
-			PortMap:       map[string]int{},
-			IP:            resp.NetworkOverride.Addr,
-			AutoAdvertise: resp.NetworkOverride.AutoAdvertise,
-		}
-		for k, v := range resp.NetworkOverride.PortMap {
-			net.PortMap[k] = int(v)
-		}
-	}
-
-	return taskHandleFromProto(resp.Handle), net, nil
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
 }
 
 // WaitTask returns a channel that will have an ExitResult pushed to it once when the task
@@ -497,3 +486,33 @@ func (d *driverPluginClient) DestroyNetwork(allocID string, spec *NetworkIsolati
 
 	return nil
 }
+
+var _ DriverCheckpointer = (*driverPluginClient)(nil)
+
+func (d *driverPluginClient) CheckpointTask(taskID string, imageDir string) error {
+	req := &proto.CheckpointTaskRequest{
+		TaskId:   taskID,
+		ImageDir: imageDir,
+	}
+
+	_, err := d.client.CheckpointTask(d.doneCtx, req)
+	if err != nil {
+		return grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return nil
+}
+
+func (d *driverPluginClient) RestoreTask(c *TaskConfig, imageDir string) (*TaskHandle, *DriverNetwork, error) {
+	req := &proto.RestoreTaskRequest{
+		Task:     taskConfigToProto(c),
+		ImageDir: imageDir,
+	}
+
+	resp, err := d.client.RestoreTask(d.doneCtx, req)
+	if err != nil {
+		return nil, nil, grpcutils.HandleGrpcErr(err, d.doneCtx)
+	}
+
+	return taskHandleFromProto(resp.Handle), networkOverrideFromProto(resp.NetworkOverride), nil
+}
@@ -94,6 +94,20 @@ type DriverNetworkManager interface {
 	DestroyNetwork(allocID string, spec *NetworkIsolationSpec) error
 }
 
+// DriverCheckpointer is the interface with exposes functions for
+// checkpointing a running task and restoring it from its checkpoint, possibly
+// on another node. This only needs to be implemented if the driver sets the
+// Checkpoint capability.
+type DriverCheckpointer interface {
+	// CheckpointTask writes the checkpoint images of the task to imageDir,
+	// which stops the task.
+	CheckpointTask(taskID string, imageDir string) error
+
+	// RestoreTask starts the task from the checkpoint images in imageDir
+	// instead of starting it anew.
+	RestoreTask(cfg *TaskConfig, imageDir string) (*TaskHandle, *DriverNetwork, error)
+}
+
 // DriverSignalTaskNotSupported can be embedded by drivers which don't support
 // the SignalTask RPC. This satisfies the SignalTask func requirement for the
 // DriverPlugin interface.
@@ -171,6 +185,11 @@ type Capabilities struct {
 	// adjust behavior such as propogating task handles between allocations
 	// to avoid downtime when a client is lost.
 	RemoteTasks bool
+
+	// Checkpoint tells Nomad that the driver can checkpoint tasks and
+	// restore them from their checkpoint, and that the CheckpointTask and
+	// RestoreTask RPCs are implemented.
+	Checkpoint bool
 }
 
 func (c *Capabilities) HasNetIsolationMode(m NetIsolationMode) bool {
@@ -284,6 +303,10 @@ type TaskConfig struct {
 	AllocID          string
 	NetworkIsolation *NetworkIsolationSpec
 	DNS              *DNSConfig
+
+	// Checkpointable is set if the task may be checkpointed, for drivers
+	// which have to start tasks differently for them to be checkpointed.
+	Checkpointable bool
 }
 
 func (tc *TaskConfig) Copy() *TaskConfig {
@@ -137,7 +137,7 @@ func (x DriverCapabilities_FSIsolation) String() string {
 }
 
 func (DriverCapabilities_FSIsolation) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{36, 0}
 }
 
 type DriverCapabilities_MountConfigs int32
@@ -165,7 +165,7 @@ func (x DriverCapabilities_MountConfigs) String() string {
 }
 
 func (DriverCapabilities_MountConfigs) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32, 1}
+	return fileDescriptor_4a8f45747846a74d, []int{36, 1}
 }
 
 type NetworkIsolationSpec_NetworkIsolationMode int32
@@ -196,7 +196,7 @@ func (x NetworkIsolationSpec_NetworkIsolationMode) String() string {
 }
 
 func (NetworkIsolationSpec_NetworkIsolationMode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{37, 0}
 }
 
 type CPUUsage_Fields int32
@@ -233,7 +233,7 @@ func (x CPUUsage_Fields) String() string {
 }
 
 func (CPUUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{58, 0}
 }
 
 type MemoryUsage_Fields int32
@@ -273,7 +273,7 @@ func (x MemoryUsage_Fields) String() string {
 }
 
 func (MemoryUsage_Fields) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55, 0}
+	return fileDescriptor_4a8f45747846a74d, []int{59, 0}
 }
 
 type TaskConfigSchemaRequest struct {
@@ -1833,6 +1833,186 @@ func (m *DestroyNetworkResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DestroyNetworkResponse proto.InternalMessageInfo
 
+type CheckpointTaskRequest struct {
+	// TaskId is the ID of the target task
+	TaskId string `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	// ImageDir is the directory the checkpoint images are written to
+	ImageDir             string   `protobuf:"bytes,2,opt,name=image_dir,json=imageDir,proto3" json:"image_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskRequest) Reset()         { *m = CheckpointTaskRequest{} }
+func (m *CheckpointTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskRequest) ProtoMessage()    {}
+func (*CheckpointTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{32}
+}
+
+func (m *CheckpointTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskRequest.Unmarshal(m, b)
+}
+func (m *CheckpointTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskRequest.Merge(m, src)
+}
+func (m *CheckpointTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskRequest.Size(m)
+}
+func (m *CheckpointTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskRequest proto.InternalMessageInfo
+
+func (m *CheckpointTaskRequest) GetTaskId() string {
+	if m != nil {
+		return m.TaskId
+	}
+	return ""
+}
+
+func (m *CheckpointTaskRequest) GetImageDir() string {
+	if m != nil {
+		return m.ImageDir
+	}
+	return ""
+}
+
+type CheckpointTaskResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckpointTaskResponse) Reset()         { *m = CheckpointTaskResponse{} }
+func (m *CheckpointTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckpointTaskResponse) ProtoMessage()    {}
+func (*CheckpointTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{33}
+}
+
+func (m *CheckpointTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckpointTaskResponse.Unmarshal(m, b)
+}
+func (m *CheckpointTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckpointTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckpointTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckpointTaskResponse.Merge(m, src)
+}
+func (m *CheckpointTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckpointTaskResponse.Size(m)
+}
+func (m *CheckpointTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckpointTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckpointTaskResponse proto.InternalMessageInfo
+
+type RestoreTaskRequest struct {
+	// Task is the configuration of the task to restore
+	Task *TaskConfig `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	// ImageDir is the directory the checkpoint images are read from
+	ImageDir             string   `protobuf:"bytes,2,opt,name=image_dir,json=imageDir,proto3" json:"image_dir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreTaskRequest) Reset()         { *m = RestoreTaskRequest{} }
+func (m *RestoreTaskRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskRequest) ProtoMessage()    {}
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{34}
+}
+
+func (m *RestoreTaskRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskRequest.Unmarshal(m, b)
+}
+func (m *RestoreTaskRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskRequest.Merge(m, src)
+}
+func (m *RestoreTaskRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskRequest.Size(m)
+}
+func (m *RestoreTaskRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskRequest proto.InternalMessageInfo
+
+func (m *RestoreTaskRequest) GetTask() *TaskConfig {
+	if m != nil {
+		return m.Task
+	}
+	return nil
+}
+
+func (m *RestoreTaskRequest) GetImageDir() string {
+	if m != nil {
+		return m.ImageDir
+	}
+	return ""
+}
+
+type RestoreTaskResponse struct {
+	// Handle is opaque to the client, but must be stored in order to recover
+	// the task.
+	Handle *TaskHandle `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"`
+	// NetworkOverride is set if the driver sets network settings and the service ip/port
+	// needs to be set differently.
+	NetworkOverride      *NetworkOverride `protobuf:"bytes,2,opt,name=network_override,json=networkOverride,proto3" json:"network_override,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *RestoreTaskResponse) Reset()         { *m = RestoreTaskResponse{} }
+func (m *RestoreTaskResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreTaskResponse) ProtoMessage()    {}
+func (*RestoreTaskResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4a8f45747846a74d, []int{35}
+}
+
+func (m *RestoreTaskResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreTaskResponse.Unmarshal(m, b)
+}
+func (m *RestoreTaskResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreTaskResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreTaskResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreTaskResponse.Merge(m, src)
+}
+func (m *RestoreTaskResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreTaskResponse.Size(m)
+}
+func (m *RestoreTaskResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreTaskResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreTaskResponse proto.InternalMessageInfo
+
+func (m *RestoreTaskResponse) GetHandle() *TaskHandle {
+	if m != nil {
+		return m.Handle
+	}
+	return nil
+}
+
+func (m *RestoreTaskResponse) GetNetworkOverride() *NetworkOverride {
+	if m != nil {
+		return m.NetworkOverride
+	}
+	return nil
+}
+
 type DriverCapabilities struct {
 	// SendSignals indicates that the driver can send process signals (ex. SIGUSR1)
 	// to the task.
@@ -1848,7 +2028,10 @@ type DriverCapabilities struct {
 	MountConfigs DriverCapabilities_MountConfigs `protobuf:"varint,6,opt,name=mount_configs,json=mountConfigs,proto3,enum=hashicorp.nomad.plugins.drivers.proto.DriverCapabilities_MountConfigs" json:"mount_configs,omitempty"`
 	// remote_tasks indicates whether the driver executes tasks remotely such
 	// on cloud runtimes like AWS ECS.
-	RemoteTasks          bool     `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	RemoteTasks bool `protobuf:"varint,7,opt,name=remote_tasks,json=remoteTasks,proto3" json:"remote_tasks,omitempty"`
+	// checkpoint indicates whether the driver can checkpoint tasks and restore
+	// them from their checkpoint, possibly on another node.
+	Checkpoint           bool     `protobuf:"varint,8,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1858,7 +2041,7 @@ func (m *DriverCapabilities) Reset()         { *m = DriverCapabilities{} }
 func (m *DriverCapabilities) String() string { return proto.CompactTextString(m) }
 func (*DriverCapabilities) ProtoMessage()    {}
 func (*DriverCapabilities) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{32}
+	return fileDescriptor_4a8f45747846a74d, []int{36}
 }
 
 func (m *DriverCapabilities) XXX_Unmarshal(b []byte) error {
@@ -1928,6 +2111,13 @@ func (m *DriverCapabilities) GetRemoteTasks() bool {
 	return false
 }
 
+func (m *DriverCapabilities) GetCheckpoint() bool {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return false
+}
+
 type NetworkIsolationSpec struct {
 	Mode                 NetworkIsolationSpec_NetworkIsolationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec_NetworkIsolationMode" json:"mode,omitempty"`
 	Path                 string                                    `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
@@ -1942,7 +2132,7 @@ func (m *NetworkIsolationSpec) Reset()         { *m = NetworkIsolationSpec{} }
 func (m *NetworkIsolationSpec) String() string { return proto.CompactTextString(m) }
 func (*NetworkIsolationSpec) ProtoMessage()    {}
 func (*NetworkIsolationSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{33}
+	return fileDescriptor_4a8f45747846a74d, []int{37}
 }
 
 func (m *NetworkIsolationSpec) XXX_Unmarshal(b []byte) error {
@@ -2003,7 +2193,7 @@ func (m *HostsConfig) Reset()         { *m = HostsConfig{} }
 func (m *HostsConfig) String() string { return proto.CompactTextString(m) }
 func (*HostsConfig) ProtoMessage()    {}
 func (*HostsConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{34}
+	return fileDescriptor_4a8f45747846a74d, []int{38}
 }
 
 func (m *HostsConfig) XXX_Unmarshal(b []byte) error {
@@ -2051,7 +2241,7 @@ func (m *DNSConfig) Reset()         { *m = DNSConfig{} }
 func (m *DNSConfig) String() string { return proto.CompactTextString(m) }
 func (*DNSConfig) ProtoMessage()    {}
 func (*DNSConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{35}
+	return fileDescriptor_4a8f45747846a74d, []int{39}
 }
 
 func (m *DNSConfig) XXX_Unmarshal(b []byte) error {
@@ -2133,17 +2323,20 @@ type TaskConfig struct {
 	// to use for the task. *Only supported on Linux
 	NetworkIsolationSpec *NetworkIsolationSpec `protobuf:"bytes,16,opt,name=network_isolation_spec,json=networkIsolationSpec,proto3" json:"network_isolation_spec,omitempty"`
 	// DNSConfig is the configuration for task DNS resolvers and other options
-	Dns                  *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+	Dns *DNSConfig `protobuf:"bytes,17,opt,name=dns,proto3" json:"dns,omitempty"`
+	// Checkpointable is set if the task may be checkpointed, for drivers
+	// which have to start tasks differently for them to be checkpointed
+	Checkpointable       bool     `protobuf:"varint,18,opt,name=checkpointable,proto3" json:"checkpointable,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TaskConfig) Reset()         { *m = TaskConfig{} }
 func (m *TaskConfig) String() string { return proto.CompactTextString(m) }
 func (*TaskConfig) ProtoMessage()    {}
 func (*TaskConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{36}
+	return fileDescriptor_4a8f45747846a74d, []int{40}
 }
 
 func (m *TaskConfig) XXX_Unmarshal(b []byte) error {
@@ -2283,6 +2476,13 @@ func (m *TaskConfig) GetDns() *DNSConfig {
 	return nil
 }
 
+func (m *TaskConfig) GetCheckpointable() bool {
+	if m != nil {
+		return m.Checkpointable
+	}
+	return false
+}
+
 type Resources struct {
 	// AllocatedResources are the resources set for the task
 	AllocatedResources *AllocatedTaskResources `protobuf:"bytes,1,opt,name=allocated_resources,json=allocatedResources,proto3" json:"allocated_resources,omitempty"`
@@ -2300,7 +2500,7 @@ func (m *Resources) Reset()         { *m = Resources{} }
 func (m *Resources) String() string { return proto.CompactTextString(m) }
 func (*Resources) ProtoMessage()    {}
 func (*Resources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{37}
+	return fileDescriptor_4a8f45747846a74d, []int{41}
 }
 
 func (m *Resources) XXX_Unmarshal(b []byte) error {
@@ -2355,7 +2555,7 @@ func (m *AllocatedTaskResources) Reset()         { *m = AllocatedTaskResources{}
 func (m *AllocatedTaskResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedTaskResources) ProtoMessage()    {}
 func (*AllocatedTaskResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{38}
+	return fileDescriptor_4a8f45747846a74d, []int{42}
 }
 
 func (m *AllocatedTaskResources) XXX_Unmarshal(b []byte) error {
@@ -2408,7 +2608,7 @@ func (m *AllocatedCpuResources) Reset()         { *m = AllocatedCpuResources{} }
 func (m *AllocatedCpuResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedCpuResources) ProtoMessage()    {}
 func (*AllocatedCpuResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{39}
+	return fileDescriptor_4a8f45747846a74d, []int{43}
 }
 
 func (m *AllocatedCpuResources) XXX_Unmarshal(b []byte) error {
@@ -2448,7 +2648,7 @@ func (m *AllocatedMemoryResources) Reset()         { *m = AllocatedMemoryResourc
 func (m *AllocatedMemoryResources) String() string { return proto.CompactTextString(m) }
 func (*AllocatedMemoryResources) ProtoMessage()    {}
 func (*AllocatedMemoryResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{40}
+	return fileDescriptor_4a8f45747846a74d, []int{44}
 }
 
 func (m *AllocatedMemoryResources) XXX_Unmarshal(b []byte) error {
@@ -2499,7 +2699,7 @@ func (m *NetworkResource) Reset()         { *m = NetworkResource{} }
 func (m *NetworkResource) String() string { return proto.CompactTextString(m) }
 func (*NetworkResource) ProtoMessage()    {}
 func (*NetworkResource) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{41}
+	return fileDescriptor_4a8f45747846a74d, []int{45}
 }
 
 func (m *NetworkResource) XXX_Unmarshal(b []byte) error {
@@ -2574,7 +2774,7 @@ func (m *NetworkPort) Reset()         { *m = NetworkPort{} }
 func (m *NetworkPort) String() string { return proto.CompactTextString(m) }
 func (*NetworkPort) ProtoMessage()    {}
 func (*NetworkPort) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{42}
+	return fileDescriptor_4a8f45747846a74d, []int{46}
 }
 
 func (m *NetworkPort) XXX_Unmarshal(b []byte) error {
@@ -2623,7 +2823,7 @@ func (m *PortMapping) Reset()         { *m = PortMapping{} }
 func (m *PortMapping) String() string { return proto.CompactTextString(m) }
 func (*PortMapping) ProtoMessage()    {}
 func (*PortMapping) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{43}
+	return fileDescriptor_4a8f45747846a74d, []int{47}
 }
 
 func (m *PortMapping) XXX_Unmarshal(b []byte) error {
@@ -2700,7 +2900,7 @@ func (m *LinuxResources) Reset()         { *m = LinuxResources{} }
 func (m *LinuxResources) String() string { return proto.CompactTextString(m) }
 func (*LinuxResources) ProtoMessage()    {}
 func (*LinuxResources) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{44}
+	return fileDescriptor_4a8f45747846a74d, []int{48}
 }
 
 func (m *LinuxResources) XXX_Unmarshal(b []byte) error {
@@ -2793,7 +2993,7 @@ func (m *Mount) Reset()         { *m = Mount{} }
 func (m *Mount) String() string { return proto.CompactTextString(m) }
 func (*Mount) ProtoMessage()    {}
 func (*Mount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{45}
+	return fileDescriptor_4a8f45747846a74d, []int{49}
 }
 
 func (m *Mount) XXX_Unmarshal(b []byte) error {
@@ -2857,7 +3057,7 @@ func (m *Device) Reset()         { *m = Device{} }
 func (m *Device) String() string { return proto.CompactTextString(m) }
 func (*Device) ProtoMessage()    {}
 func (*Device) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{46}
+	return fileDescriptor_4a8f45747846a74d, []int{50}
 }
 
 func (m *Device) XXX_Unmarshal(b []byte) error {
@@ -2919,7 +3119,7 @@ func (m *TaskHandle) Reset()         { *m = TaskHandle{} }
 func (m *TaskHandle) String() string { return proto.CompactTextString(m) }
 func (*TaskHandle) ProtoMessage()    {}
 func (*TaskHandle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{47}
+	return fileDescriptor_4a8f45747846a74d, []int{51}
 }
 
 func (m *TaskHandle) XXX_Unmarshal(b []byte) error {
@@ -2987,7 +3187,7 @@ func (m *NetworkOverride) Reset()         { *m = NetworkOverride{} }
 func (m *NetworkOverride) String() string { return proto.CompactTextString(m) }
 func (*NetworkOverride) ProtoMessage()    {}
 func (*NetworkOverride) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{48}
+	return fileDescriptor_4a8f45747846a74d, []int{52}
 }
 
 func (m *NetworkOverride) XXX_Unmarshal(b []byte) error {
@@ -3046,7 +3246,7 @@ func (m *ExitResult) Reset()         { *m = ExitResult{} }
 func (m *ExitResult) String() string { return proto.CompactTextString(m) }
 func (*ExitResult) ProtoMessage()    {}
 func (*ExitResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{49}
+	return fileDescriptor_4a8f45747846a74d, []int{53}
 }
 
 func (m *ExitResult) XXX_Unmarshal(b []byte) error {
@@ -3110,7 +3310,7 @@ func (m *TaskStatus) Reset()         { *m = TaskStatus{} }
 func (m *TaskStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()    {}
 func (*TaskStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{50}
+	return fileDescriptor_4a8f45747846a74d, []int{54}
 }
 
 func (m *TaskStatus) XXX_Unmarshal(b []byte) error {
@@ -3186,7 +3386,7 @@ func (m *TaskDriverStatus) Reset()         { *m = TaskDriverStatus{} }
 func (m *TaskDriverStatus) String() string { return proto.CompactTextString(m) }
 func (*TaskDriverStatus) ProtoMessage()    {}
 func (*TaskDriverStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{51}
+	return fileDescriptor_4a8f45747846a74d, []int{55}
 }
 
 func (m *TaskDriverStatus) XXX_Unmarshal(b []byte) error {
@@ -3232,7 +3432,7 @@ func (m *TaskStats) Reset()         { *m = TaskStats{} }
 func (m *TaskStats) String() string { return proto.CompactTextString(m) }
 func (*TaskStats) ProtoMessage()    {}
 func (*TaskStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{52}
+	return fileDescriptor_4a8f45747846a74d, []int{56}
 }
 
 func (m *TaskStats) XXX_Unmarshal(b []byte) error {
@@ -3295,7 +3495,7 @@ func (m *TaskResourceUsage) Reset()         { *m = TaskResourceUsage{} }
 func (m *TaskResourceUsage) String() string { return proto.CompactTextString(m) }
 func (*TaskResourceUsage) ProtoMessage()    {}
 func (*TaskResourceUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{53}
+	return fileDescriptor_4a8f45747846a74d, []int{57}
 }
 
 func (m *TaskResourceUsage) XXX_Unmarshal(b []byte) error {
@@ -3348,7 +3548,7 @@ func (m *CPUUsage) Reset()         { *m = CPUUsage{} }
 func (m *CPUUsage) String() string { return proto.CompactTextString(m) }
 func (*CPUUsage) ProtoMessage()    {}
 func (*CPUUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{54}
+	return fileDescriptor_4a8f45747846a74d, []int{58}
 }
 
 func (m *CPUUsage) XXX_Unmarshal(b []byte) error {
@@ -3437,7 +3637,7 @@ func (m *MemoryUsage) Reset()         { *m = MemoryUsage{} }
 func (m *MemoryUsage) String() string { return proto.CompactTextString(m) }
 func (*MemoryUsage) ProtoMessage()    {}
 func (*MemoryUsage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{55}
+	return fileDescriptor_4a8f45747846a74d, []int{59}
 }
 
 func (m *MemoryUsage) XXX_Unmarshal(b []byte) error {
@@ -3536,7 +3736,7 @@ func (m *DriverTaskEvent) Reset()         { *m = DriverTaskEvent{} }
 func (m *DriverTaskEvent) String() string { return proto.CompactTextString(m) }
 func (*DriverTaskEvent) ProtoMessage()    {}
 func (*DriverTaskEvent) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4a8f45747846a74d, []int{56}
+	return fileDescriptor_4a8f45747846a74d, []int{60}
 }
 
 func (m *DriverTaskEvent) XXX_Unmarshal(b []byte) error {
@@ -3643,6 +3843,10 @@ func init() {
 	proto.RegisterType((*CreateNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CreateNetworkResponse")
 	proto.RegisterType((*DestroyNetworkRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkRequest")
 	proto.RegisterType((*DestroyNetworkResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.DestroyNetworkResponse")
+	proto.RegisterType((*CheckpointTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskRequest")
+	proto.RegisterType((*CheckpointTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.CheckpointTaskResponse")
+	proto.RegisterType((*RestoreTaskRequest)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskRequest")
+	proto.RegisterType((*RestoreTaskResponse)(nil), "hashicorp.nomad.plugins.drivers.proto.RestoreTaskResponse")
 	proto.RegisterType((*DriverCapabilities)(nil), "hashicorp.nomad.plugins.drivers.proto.DriverCapabilities")
 	proto.RegisterType((*NetworkIsolationSpec)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec")
 	proto.RegisterMapType((map[string]string)(nil), "hashicorp.nomad.plugins.drivers.proto.NetworkIsolationSpec.LabelsEntry")
@@ -3682,243 +3886,250 @@ func init() {
 }
 
 var fileDescriptor_4a8f45747846a74d = []byte{
-	// 3776 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x5a, 0xcd, 0x6f, 0x1b, 0x49,
-	0x76, 0x57, 0xb3, 0xf9, 0xf9, 0x48, 0x51, 0xad, 0xb2, 0x6c, 0xd3, 0x9c, 0x24, 0xe3, 0xed, 0x60,
-	0x02, 0x61, 0x77, 0x86, 0x9e, 0xd5, 0x22, 0xe3, 0xf1, 0xac, 0x67, 0x3d, 0x1c, 0x8a, 0xb6, 0x34,
-	0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x16, 0xbb, 0x4c, 0xb5, 0xc5, 0xfe, 0x98,
-	0xae, 0xa6, 0x2c, 0x6d, 0x10, 0x24, 0xd8, 0x00, 0xc1, 0x06, 0x48, 0x90, 0x5c, 0x26, 0x7b, 0xc9,
-	0x69, 0x81, 0x9c, 0xf2, 0x0f, 0x04, 0x1b, 0xec, 0x29, 0x87, 0xfc, 0x13, 0xb9, 0xe4, 0x96, 0x63,
-	0x72, 0xca, 0x35, 0xa8, 0x8f, 0x6e, 0x76, 0x93, 0xf4, 0xb8, 0x49, 0xf9, 0xc4, 0x7e, 0xf5, 0xf1,
-	0xab, 0xc7, 0xf7, 0x5e, 0xbd, 0x7a, 0xf5, 0xea, 0x81, 0xee, 0x8f, 0x27, 0x23, 0xdb, 0xa5, 0xf7,
-	0xac, 0xc0, 0xbe, 0x20, 0x01, 0xbd, 0xe7, 0x07, 0x5e, 0xe8, 0x49, 0xaa, 0xc5, 0x09, 0xf4, 0xc1,
-	0x99, 0x49, 0xcf, 0xec, 0xa1, 0x17, 0xf8, 0x2d, 0xd7, 0x73, 0x4c, 0xab, 0x25, 0xe7, 0xb4, 0xe4,
-	0x1c, 0x31, 0xac, 0xf9, 0x7b, 0x23, 0xcf, 0x1b, 0x8d, 0x89, 0x40, 0x38, 0x9d, 0xbc, 0xbc, 0x67,
-	0x4d, 0x02, 0x33, 0xb4, 0x3d, 0x57, 0xf6, 0xbf, 0x3f, 0xdb, 0x1f, 0xda, 0x0e, 0xa1, 0xa1, 0xe9,
-	0xf8, 0x72, 0xc0, 0x07, 0x11, 0x2f, 0xf4, 0xcc, 0x0c, 0x88, 0x75, 0xef, 0x6c, 0x38, 0xa6, 0x3e,
-	0x19, 0xb2, 0x5f, 0x83, 0x7d, 0xc8, 0x61, 0x1f, 0xce, 0x0c, 0xa3, 0x61, 0x30, 0x19, 0x86, 0x11,
-	0xe7, 0x66, 0x18, 0x06, 0xf6, 0xe9, 0x24, 0x24, 0x62, 0xb4, 0x7e, 0x07, 0x6e, 0x0f, 0x4c, 0x7a,
-	0xde, 0xf1, 0xdc, 0x97, 0xf6, 0xa8, 0x3f, 0x3c, 0x23, 0x8e, 0x89, 0xc9, 0x37, 0x13, 0x42, 0x43,
-	0xfd, 0x4f, 0xa0, 0x31, 0xdf, 0x45, 0x7d, 0xcf, 0xa5, 0x04, 0x7d, 0x01, 0x79, 0xb6, 0x64, 0x43,
-	0xb9, 0xab, 0x6c, 0x57, 0x77, 0x3e, 0x6c, 0xbd, 0x49, 0x04, 0x82, 0x87, 0x96, 0x64, 0xb5, 0xd5,
-	0xf7, 0xc9, 0x10, 0xf3, 0x99, 0xfa, 0x4d, 0xb8, 0xd1, 0x31, 0x7d, 0xf3, 0xd4, 0x1e, 0xdb, 0xa1,
-	0x4d, 0x68, 0xb4, 0xe8, 0x04, 0xb6, 0xd2, 0xcd, 0x72, 0xc1, 0x9f, 0x41, 0x6d, 0x98, 0x68, 0x97,
-	0x0b, 0x3f, 0x68, 0x65, 0x92, 0x7d, 0x6b, 0x97, 0x53, 0x29, 0xe0, 0x14, 0x9c, 0xbe, 0x05, 0xe8,
-	0xb1, 0xed, 0x8e, 0x48, 0xe0, 0x07, 0xb6, 0x1b, 0x46, 0xcc, 0xfc, 0x56, 0x85, 0x1b, 0xa9, 0x66,
-	0xc9, 0xcc, 0x2b, 0x80, 0x58, 0x8e, 0x8c, 0x15, 0x75, 0xbb, 0xba, 0xf3, 0x55, 0x46, 0x56, 0x16,
-	0xe0, 0xb5, 0xda, 0x31, 0x58, 0xd7, 0x0d, 0x83, 0x2b, 0x9c, 0x40, 0x47, 0x5f, 0x43, 0xf1, 0x8c,
-	0x98, 0xe3, 0xf0, 0xac, 0x91, 0xbb, 0xab, 0x6c, 0xd7, 0x77, 0x1e, 0x5f, 0x63, 0x9d, 0x3d, 0x0e,
-	0xd4, 0x0f, 0xcd, 0x90, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x8b, 0xd0, 0x61, 0x60,
-	0xfb, 0xcc, 0x24, 0x1b, 0xea, 0x5d, 0x65, 0xbb, 0x82, 0x37, 0x45, 0xcf, 0xee, 0xb4, 0xa3, 0xe9,
-	0xc3, 0xc6, 0x0c, 0xb7, 0x48, 0x03, 0xf5, 0x9c, 0x5c, 0x71, 0x8d, 0x54, 0x30, 0xfb, 0x44, 0x4f,
-	0xa0, 0x70, 0x61, 0x8e, 0x27, 0x84, 0xb3, 0x5c, 0xdd, 0xf9, 0xe1, 0xdb, 0xcc, 0x43, 0x9a, 0xe8,
-	0x54, 0x0e, 0x58, 0xcc, 0xff, 0x2c, 0xf7, 0xa9, 0xa2, 0x3f, 0x80, 0x6a, 0x82, 0x6f, 0x54, 0x07,
-	0x38, 0xe9, 0xed, 0x76, 0x07, 0xdd, 0xce, 0xa0, 0xbb, 0xab, 0xad, 0xa1, 0x75, 0xa8, 0x9c, 0xf4,
-	0xf6, 0xba, 0xed, 0x83, 0xc1, 0xde, 0x73, 0x4d, 0x41, 0x55, 0x28, 0x45, 0x44, 0x4e, 0xbf, 0x04,
-	0x84, 0xc9, 0xd0, 0xbb, 0x20, 0x01, 0x33, 0x64, 0xa9, 0x55, 0x74, 0x1b, 0x4a, 0xa1, 0x49, 0xcf,
-	0x0d, 0xdb, 0x92, 0x3c, 0x17, 0x19, 0xb9, 0x6f, 0xa1, 0x7d, 0x28, 0x9e, 0x99, 0xae, 0x35, 0x7e,
-	0x3b, 0xdf, 0x69, 0x51, 0x33, 0xf0, 0x3d, 0x3e, 0x11, 0x4b, 0x00, 0x66, 0xdd, 0xa9, 0x95, 0x85,
-	0x02, 0xf4, 0xe7, 0xa0, 0xf5, 0x43, 0x33, 0x08, 0x93, 0xec, 0x74, 0x21, 0xcf, 0xd6, 0x97, 0x16,
-	0xbd, 0xcc, 0x9a, 0x62, 0x67, 0x62, 0x3e, 0x5d, 0xff, 0xdf, 0x1c, 0x6c, 0x26, 0xb0, 0xa5, 0xa5,
-	0x3e, 0x83, 0x62, 0x40, 0xe8, 0x64, 0x1c, 0x72, 0xf8, 0xfa, 0xce, 0xa3, 0x8c, 0xf0, 0x73, 0x48,
-	0x2d, 0xcc, 0x61, 0xb0, 0x84, 0x43, 0xdb, 0xa0, 0x89, 0x19, 0x06, 0x09, 0x02, 0x2f, 0x30, 0x1c,
-	0x3a, 0xe2, 0x52, 0xab, 0xe0, 0xba, 0x68, 0xef, 0xb2, 0xe6, 0x43, 0x3a, 0x4a, 0x48, 0x55, 0xbd,
-	0xa6, 0x54, 0x91, 0x09, 0x9a, 0x4b, 0xc2, 0xd7, 0x5e, 0x70, 0x6e, 0x30, 0xd1, 0x06, 0xb6, 0x45,
-	0x1a, 0x79, 0x0e, 0xfa, 0x49, 0x46, 0xd0, 0x9e, 0x98, 0x7e, 0x24, 0x67, 0xe3, 0x0d, 0x37, 0xdd,
-	0xa0, 0xff, 0x00, 0x8a, 0xe2, 0x9f, 0x32, 0x4b, 0xea, 0x9f, 0x74, 0x3a, 0xdd, 0x7e, 0x5f, 0x5b,
-	0x43, 0x15, 0x28, 0xe0, 0xee, 0x00, 0x33, 0x0b, 0xab, 0x40, 0xe1, 0x71, 0x7b, 0xd0, 0x3e, 0xd0,
-	0x72, 0xfa, 0xf7, 0x61, 0xe3, 0x99, 0x69, 0x87, 0x59, 0x8c, 0x4b, 0xf7, 0x40, 0x9b, 0x8e, 0x95,
-	0xda, 0xd9, 0x4f, 0x69, 0x27, 0xbb, 0x68, 0xba, 0x97, 0x76, 0x38, 0xa3, 0x0f, 0x0d, 0x54, 0x12,
-	0x04, 0x52, 0x05, 0xec, 0x53, 0x7f, 0x0d, 0x1b, 0xfd, 0xd0, 0xf3, 0x33, 0x59, 0xfe, 0x8f, 0xa0,
-	0xc4, 0x4e, 0x1b, 0x6f, 0x12, 0x4a, 0xd3, 0xbf, 0xd3, 0x12, 0xa7, 0x51, 0x2b, 0x3a, 0x8d, 0x5a,
-	0xbb, 0xf2, 0xb4, 0xc2, 0xd1, 0x48, 0x74, 0x0b, 0x8a, 0xd4, 0x1e, 0xb9, 0xe6, 0x58, 0x7a, 0x0b,
-	0x49, 0xe9, 0x88, 0x19, 0x79, 0xb4, 0xb0, 0x34, 0xfc, 0x0e, 0xa0, 0x5d, 0x42, 0xc3, 0xc0, 0xbb,
-	0xca, 0xc4, 0xcf, 0x16, 0x14, 0x5e, 0x7a, 0xc1, 0x50, 0x6c, 0xc4, 0x32, 0x16, 0x04, 0xdb, 0x54,
-	0x29, 0x10, 0x89, 0xfd, 0x11, 0xa0, 0x7d, 0x97, 0x9d, 0x29, 0xd9, 0x14, 0xf1, 0x0f, 0x39, 0xb8,
-	0x91, 0x1a, 0x2f, 0x95, 0xb1, 0xfa, 0x3e, 0x64, 0x8e, 0x69, 0x42, 0xc5, 0x3e, 0x44, 0x47, 0x50,
-	0x14, 0x23, 0xa4, 0x24, 0xef, 0x2f, 0x01, 0x24, 0x8e, 0x29, 0x09, 0x27, 0x61, 0x16, 0x1a, 0xbd,
-	0xfa, 0x6e, 0x8d, 0xfe, 0x35, 0x68, 0xd1, 0xff, 0xa0, 0x6f, 0xd5, 0xcd, 0x57, 0x70, 0x63, 0xe8,
-	0x8d, 0xc7, 0x64, 0xc8, 0xac, 0xc1, 0xb0, 0xdd, 0x90, 0x04, 0x17, 0xe6, 0xf8, 0xed, 0x76, 0x83,
-	0xa6, 0xb3, 0xf6, 0xe5, 0x24, 0xfd, 0x05, 0x6c, 0x26, 0x16, 0x96, 0x8a, 0x78, 0x0c, 0x05, 0xca,
-	0x1a, 0xa4, 0x26, 0x3e, 0x5e, 0x52, 0x13, 0x14, 0x8b, 0xe9, 0xfa, 0x0d, 0x01, 0xde, 0xbd, 0x20,
-	0x6e, 0xfc, 0xb7, 0xf4, 0x5d, 0xd8, 0xec, 0x73, 0x33, 0xcd, 0x64, 0x87, 0x53, 0x13, 0xcf, 0xa5,
-	0x4c, 0x7c, 0x0b, 0x50, 0x12, 0x45, 0x1a, 0xe2, 0x15, 0x6c, 0x74, 0x2f, 0xc9, 0x30, 0x13, 0x72,
-	0x03, 0x4a, 0x43, 0xcf, 0x71, 0x4c, 0xd7, 0x6a, 0xe4, 0xee, 0xaa, 0xdb, 0x15, 0x1c, 0x91, 0xc9,
-	0xbd, 0xa8, 0x66, 0xdd, 0x8b, 0xfa, 0xdf, 0x29, 0xa0, 0x4d, 0xd7, 0x96, 0x82, 0x64, 0xdc, 0x87,
-	0x16, 0x03, 0x62, 0x6b, 0xd7, 0xb0, 0xa4, 0x64, 0x7b, 0xe4, 0x2e, 0x44, 0x3b, 0x09, 0x82, 0x84,
-	0x3b, 0x52, 0xaf, 0xe9, 0x8e, 0xf4, 0x3d, 0xf8, 0x9d, 0x88, 0x9d, 0x7e, 0x18, 0x10, 0xd3, 0xb1,
-	0xdd, 0xd1, 0xfe, 0xd1, 0x91, 0x4f, 0x04, 0xe3, 0x08, 0x41, 0xde, 0x32, 0x43, 0x53, 0x32, 0xc6,
-	0xbf, 0xd9, 0xa6, 0x1f, 0x8e, 0x3d, 0x1a, 0x6f, 0x7a, 0x4e, 0xe8, 0xff, 0xa1, 0x42, 0x63, 0x0e,
-	0x2a, 0x12, 0xef, 0x0b, 0x28, 0x50, 0x12, 0x4e, 0x7c, 0x69, 0x2a, 0xdd, 0xcc, 0x0c, 0x2f, 0xc6,
-	0x6b, 0xf5, 0x19, 0x18, 0x16, 0x98, 0x68, 0x04, 0xe5, 0x30, 0xbc, 0x32, 0xa8, 0xfd, 0xf3, 0x28,
-	0x20, 0x38, 0xb8, 0x2e, 0xfe, 0x80, 0x04, 0x8e, 0xed, 0x9a, 0xe3, 0xbe, 0xfd, 0x73, 0x82, 0x4b,
-	0x61, 0x78, 0xc5, 0x3e, 0xd0, 0x73, 0x66, 0xf0, 0x96, 0xed, 0x4a, 0xb1, 0x77, 0x56, 0x5d, 0x25,
-	0x21, 0x60, 0x2c, 0x10, 0x9b, 0x07, 0x50, 0xe0, 0xff, 0x69, 0x15, 0x43, 0xd4, 0x40, 0x0d, 0xc3,
-	0x2b, 0xce, 0x54, 0x19, 0xb3, 0xcf, 0xe6, 0x43, 0xa8, 0x25, 0xff, 0x01, 0x33, 0xa4, 0x33, 0x62,
-	0x8f, 0xce, 0x84, 0x81, 0x15, 0xb0, 0xa4, 0x98, 0x26, 0x5f, 0xdb, 0x96, 0x0c, 0x59, 0x0b, 0x58,
-	0x10, 0xfa, 0xbf, 0xe6, 0xe0, 0xce, 0x02, 0xc9, 0x48, 0x63, 0x7d, 0x91, 0x32, 0xd6, 0x77, 0x24,
-	0x85, 0xc8, 0xe2, 0x5f, 0xa4, 0x2c, 0xfe, 0x1d, 0x82, 0xb3, 0x6d, 0x73, 0x0b, 0x8a, 0xe4, 0xd2,
-	0x0e, 0x89, 0x25, 0x45, 0x25, 0xa9, 0xc4, 0x76, 0xca, 0x5f, 0x77, 0x3b, 0x1d, 0xc2, 0x56, 0x27,
-	0x20, 0x66, 0x48, 0xa4, 0x2b, 0x8f, 0xec, 0xff, 0x0e, 0x94, 0xcd, 0xf1, 0xd8, 0x1b, 0x4e, 0xd5,
-	0x5a, 0xe2, 0xf4, 0xbe, 0x85, 0x9a, 0x50, 0x3e, 0xf3, 0x68, 0xe8, 0x9a, 0x0e, 0x91, 0xce, 0x2b,
-	0xa6, 0xf5, 0x6f, 0x15, 0xb8, 0x39, 0x83, 0x27, 0xb5, 0x70, 0x0a, 0x75, 0x9b, 0x7a, 0x63, 0xfe,
-	0x07, 0x8d, 0xc4, 0x0d, 0xef, 0xc7, 0xcb, 0x1d, 0x35, 0xfb, 0x11, 0x06, 0xbf, 0xf0, 0xad, 0xdb,
-	0x49, 0x92, 0x5b, 0x1c, 0x5f, 0xdc, 0x92, 0x3b, 0x3d, 0x22, 0xf5, 0x7f, 0x54, 0xe0, 0xa6, 0x3c,
-	0xe1, 0xb3, 0xff, 0xd1, 0x79, 0x96, 0x73, 0xef, 0x9a, 0x65, 0xbd, 0x01, 0xb7, 0x66, 0xf9, 0x92,
-	0x3e, 0xff, 0xff, 0xf2, 0x80, 0xe6, 0x6f, 0x97, 0xe8, 0x7b, 0x50, 0xa3, 0xc4, 0xb5, 0x0c, 0x71,
-	0x5e, 0x88, 0xa3, 0xac, 0x8c, 0xab, 0xac, 0x4d, 0x1c, 0x1c, 0x94, 0xb9, 0x40, 0x72, 0x29, 0xb9,
-	0x2d, 0x63, 0xfe, 0x8d, 0xce, 0xa0, 0xf6, 0x92, 0x1a, 0xf1, 0xda, 0xdc, 0xa0, 0xea, 0x99, 0xdd,
-	0xda, 0x3c, 0x1f, 0xad, 0xc7, 0xfd, 0xf8, 0x7f, 0xe1, 0xea, 0x4b, 0x1a, 0x13, 0xe8, 0x97, 0x0a,
-	0xdc, 0x8e, 0xc2, 0x8a, 0xa9, 0xf8, 0x1c, 0xcf, 0x22, 0xb4, 0x91, 0xbf, 0xab, 0x6e, 0xd7, 0x77,
-	0x8e, 0xaf, 0x21, 0xbf, 0xb9, 0xc6, 0x43, 0xcf, 0x22, 0xf8, 0xa6, 0xbb, 0xa0, 0x95, 0xa2, 0x16,
-	0xdc, 0x70, 0x26, 0x34, 0x34, 0x84, 0x15, 0x18, 0x72, 0x50, 0xa3, 0xc0, 0xe5, 0xb2, 0xc9, 0xba,
-	0x52, 0xb6, 0x8a, 0xce, 0x61, 0xdd, 0xf1, 0x26, 0x6e, 0x68, 0x0c, 0xf9, 0xfd, 0x87, 0x36, 0x8a,
-	0x4b, 0x5d, 0x8c, 0x17, 0x48, 0xe9, 0x90, 0xc1, 0x89, 0xdb, 0x14, 0xc5, 0x35, 0x27, 0x41, 0x31,
-	0x45, 0x06, 0xc4, 0xf1, 0x42, 0x62, 0x30, 0x7f, 0x49, 0x1b, 0x25, 0xa1, 0x48, 0xd1, 0xc6, 0x5c,
-	0x03, 0xd5, 0x5b, 0x50, 0x4d, 0x88, 0x19, 0x95, 0x21, 0xdf, 0x3b, 0xea, 0x75, 0xb5, 0x35, 0x04,
-	0x50, 0xec, 0xec, 0xe1, 0xa3, 0xa3, 0x81, 0xb8, 0x35, 0xec, 0x1f, 0xb6, 0x9f, 0x74, 0xb5, 0x9c,
-	0xde, 0x85, 0x5a, 0x72, 0x41, 0x84, 0xa0, 0x7e, 0xd2, 0x7b, 0xda, 0x3b, 0x7a, 0xd6, 0x33, 0x0e,
-	0x8f, 0x4e, 0x7a, 0x03, 0x76, 0xdf, 0xa8, 0x03, 0xb4, 0x7b, 0xcf, 0xa7, 0xf4, 0x3a, 0x54, 0x7a,
-	0x47, 0x11, 0xa9, 0x34, 0x73, 0x9a, 0xa2, 0xff, 0xbb, 0x0a, 0x5b, 0x8b, 0x64, 0x8f, 0x2c, 0xc8,
-	0x33, 0x3d, 0xca, 0x1b, 0xdf, 0xbb, 0x57, 0x23, 0x47, 0x67, 0xe6, 0xeb, 0x9b, 0xd2, 0xc5, 0x57,
-	0x30, 0xff, 0x46, 0x06, 0x14, 0xc7, 0xe6, 0x29, 0x19, 0xd3, 0x86, 0xca, 0x73, 0x22, 0x4f, 0xae,
-	0xb3, 0xf6, 0x01, 0x47, 0x12, 0x09, 0x11, 0x09, 0x8b, 0x06, 0x50, 0x65, 0x4e, 0x8c, 0x0a, 0xd1,
-	0x49, 0xbf, 0xba, 0x93, 0x71, 0x95, 0xbd, 0xe9, 0x4c, 0x9c, 0x84, 0x69, 0x3e, 0x80, 0x6a, 0x62,
-	0xb1, 0x05, 0xf9, 0x8c, 0xad, 0x64, 0x3e, 0xa3, 0x92, 0x4c, 0x4e, 0x3c, 0x9a, 0xd7, 0x01, 0x93,
-	0x11, 0x33, 0x82, 0xbd, 0xa3, 0xfe, 0x40, 0xdc, 0x1c, 0x9f, 0xe0, 0xa3, 0x93, 0x63, 0x4d, 0x61,
-	0x8d, 0x83, 0x76, 0xff, 0xa9, 0x96, 0x8b, 0x6d, 0x44, 0xd5, 0x3b, 0x50, 0x4d, 0xf0, 0x95, 0xf2,
-	0xda, 0x4a, 0xda, 0x6b, 0x33, 0xbf, 0x69, 0x5a, 0x56, 0x40, 0x28, 0x95, 0x7c, 0x44, 0xa4, 0xfe,
-	0x02, 0x2a, 0xbb, 0xbd, 0xbe, 0x84, 0x68, 0x40, 0x89, 0x92, 0x80, 0xfd, 0x6f, 0x9e, 0x99, 0xaa,
-	0xe0, 0x88, 0x64, 0xe0, 0x94, 0x98, 0xc1, 0xf0, 0x8c, 0x50, 0x79, 0xd6, 0xc7, 0x34, 0x9b, 0xe5,
-	0xf1, 0x0c, 0x8f, 0xd0, 0x5d, 0x05, 0x47, 0xa4, 0xfe, 0x3f, 0x25, 0x80, 0x69, 0xb6, 0x01, 0xd5,
-	0x21, 0x17, 0xfb, 0xe0, 0x9c, 0x6d, 0x31, 0x3b, 0x48, 0x9c, 0x31, 0xfc, 0x1b, 0xed, 0xc0, 0x4d,
-	0x87, 0x8e, 0x7c, 0x73, 0x78, 0x6e, 0xc8, 0x24, 0x81, 0xd8, 0xaa, 0xdc, 0x9f, 0xd5, 0xf0, 0x0d,
-	0xd9, 0x29, 0x77, 0xa2, 0xc0, 0x3d, 0x00, 0x95, 0xb8, 0x17, 0xdc, 0xf7, 0x54, 0x77, 0x3e, 0x5b,
-	0x3a, 0x0b, 0xd2, 0xea, 0xba, 0x17, 0xc2, 0x56, 0x18, 0x0c, 0x32, 0x00, 0x2c, 0x72, 0x61, 0x0f,
-	0x89, 0xc1, 0x40, 0x0b, 0x1c, 0xf4, 0x8b, 0xe5, 0x41, 0x77, 0x39, 0x46, 0x0c, 0x5d, 0xb1, 0x22,
-	0x1a, 0xf5, 0xa0, 0x12, 0x10, 0xea, 0x4d, 0x82, 0x21, 0x11, 0x0e, 0x28, 0xfb, 0x45, 0x05, 0x47,
-	0xf3, 0xf0, 0x14, 0x02, 0xed, 0x42, 0x91, 0xfb, 0x1d, 0xe6, 0x61, 0xd4, 0xef, 0x4c, 0xa9, 0xa6,
-	0xc1, 0xb8, 0x27, 0xc1, 0x72, 0x2e, 0x7a, 0x02, 0x25, 0xc1, 0x22, 0x6d, 0x94, 0x39, 0xcc, 0x47,
-	0x59, 0x9d, 0x22, 0x9f, 0x85, 0xa3, 0xd9, 0x4c, 0xab, 0x13, 0x4a, 0x82, 0x46, 0x45, 0x68, 0x95,
-	0x7d, 0xa3, 0xf7, 0xa0, 0x22, 0xce, 0x60, 0xcb, 0x0e, 0x1a, 0x20, 0x8c, 0x93, 0x37, 0xec, 0xda,
-	0x01, 0x7a, 0x1f, 0xaa, 0x22, 0xd6, 0x32, 0xb8, 0x57, 0xa8, 0xf2, 0x6e, 0x10, 0x4d, 0xc7, 0xcc,
-	0x37, 0x88, 0x01, 0x24, 0x08, 0xc4, 0x80, 0x5a, 0x3c, 0x80, 0x04, 0x01, 0x1f, 0xf0, 0x07, 0xb0,
-	0xc1, 0x23, 0xd4, 0x51, 0xe0, 0x4d, 0x7c, 0x83, 0xdb, 0xd4, 0x3a, 0x1f, 0xb4, 0xce, 0x9a, 0x9f,
-	0xb0, 0xd6, 0x1e, 0x33, 0xae, 0x3b, 0x50, 0x7e, 0xe5, 0x9d, 0x8a, 0x01, 0x75, 0xb1, 0x0f, 0x5e,
-	0x79, 0xa7, 0x51, 0x57, 0x1c, 0x25, 0x6c, 0xa4, 0xa3, 0x84, 0x6f, 0xe0, 0xd6, 0xfc, 0x71, 0xc7,
-	0xa3, 0x05, 0xed, 0xfa, 0xd1, 0xc2, 0x96, 0xbb, 0xc8, 0x0f, 0x7f, 0x09, 0xaa, 0xe5, 0xd2, 0xc6,
-	0xe6, 0x52, 0xc6, 0x11, 0xef, 0x63, 0xcc, 0x26, 0x37, 0x3f, 0x81, 0x72, 0x64, 0x7d, 0xcb, 0xf8,
-	0xa5, 0xe6, 0x43, 0xa8, 0xa7, 0x6d, 0x77, 0x29, 0xaf, 0xf6, 0xcf, 0x39, 0xa8, 0xc4, 0x56, 0x8a,
-	0x5c, 0xb8, 0xc1, 0xa5, 0xc8, 0x42, 0x34, 0x63, 0x6a, 0xf4, 0x22, 0x30, 0xfc, 0x3c, 0xe3, 0xff,
-	0x6a, 0x47, 0x08, 0xf2, 0x86, 0x2a, 0x77, 0x00, 0x8a, 0x91, 0xa7, 0xeb, 0x7d, 0x0d, 0x1b, 0x63,
-	0xdb, 0x9d, 0x5c, 0x26, 0xd6, 0x12, 0x11, 0xdd, 0x1f, 0x66, 0x5c, 0xeb, 0x80, 0xcd, 0x9e, 0xae,
-	0x51, 0x1f, 0xa7, 0x68, 0xb4, 0x07, 0x05, 0xdf, 0x0b, 0xc2, 0xe8, 0x90, 0xca, 0x7a, 0x7c, 0x1c,
-	0x7b, 0x41, 0x78, 0x68, 0xfa, 0x3e, 0xbb, 0xb4, 0x08, 0x00, 0xfd, 0xdb, 0x1c, 0xdc, 0x5a, 0xfc,
-	0xc7, 0x50, 0x0f, 0xd4, 0xa1, 0x3f, 0x91, 0x42, 0x7a, 0xb8, 0xac, 0x90, 0x3a, 0xfe, 0x64, 0xca,
-	0x3f, 0x03, 0x42, 0xcf, 0xa0, 0xe8, 0x10, 0xc7, 0x0b, 0xae, 0xa4, 0x2c, 0x1e, 0x2d, 0x0b, 0x79,
-	0xc8, 0x67, 0x4f, 0x51, 0x25, 0x1c, 0xc2, 0x50, 0x96, 0xd6, 0x4b, 0xa5, 0x9f, 0x5c, 0x32, 0xad,
-	0x14, 0x41, 0xe2, 0x18, 0x47, 0xff, 0x04, 0x6e, 0x2e, 0xfc, 0x2b, 0xe8, 0x77, 0x01, 0x86, 0xfe,
-	0xc4, 0xe0, 0x69, 0x7f, 0x61, 0x41, 0x2a, 0xae, 0x0c, 0xfd, 0x49, 0x9f, 0x37, 0xe8, 0x2f, 0xa0,
-	0xf1, 0x26, 0x7e, 0x99, 0xf7, 0x11, 0x1c, 0x1b, 0xce, 0x29, 0x97, 0x81, 0x8a, 0xcb, 0xa2, 0xe1,
-	0xf0, 0x14, 0xe9, 0xb0, 0x1e, 0x75, 0x9a, 0x97, 0x6c, 0x80, 0xca, 0x07, 0x54, 0xe5, 0x00, 0xf3,
-	0xf2, 0xf0, 0x54, 0xff, 0x55, 0x0e, 0x36, 0x66, 0x58, 0x66, 0x57, 0x37, 0xe1, 0xf1, 0xa2, 0x4b,
-	0xb1, 0xa0, 0x98, 0xfb, 0x1b, 0xda, 0x56, 0x94, 0x4e, 0xe5, 0xdf, 0xfc, 0xe0, 0xf3, 0x65, 0xaa,
-	0x33, 0x67, 0xfb, 0x6c, 0xfb, 0x38, 0xa7, 0x76, 0x48, 0x79, 0x14, 0x52, 0xc0, 0x82, 0x40, 0xcf,
-	0xa1, 0x1e, 0x10, 0x7e, 0xe0, 0x5a, 0x86, 0xb0, 0xb2, 0xc2, 0x52, 0x56, 0x26, 0x39, 0x64, 0xc6,
-	0x86, 0xd7, 0x23, 0x24, 0x46, 0x51, 0xf4, 0x0c, 0xd6, 0xad, 0x2b, 0xd7, 0x74, 0xec, 0xa1, 0x44,
-	0x2e, 0xae, 0x8c, 0x5c, 0x93, 0x40, 0x1c, 0x58, 0x7f, 0x00, 0xd5, 0x44, 0x27, 0xfb, 0x63, 0x3c,
-	0xdc, 0x92, 0x32, 0x11, 0x44, 0xda, 0x5b, 0x14, 0xa4, 0xb7, 0xd0, 0x4f, 0xa1, 0x9a, 0xd8, 0x17,
-	0xcb, 0x4c, 0x65, 0xf2, 0x0c, 0x3d, 0x2e, 0xcf, 0x02, 0xce, 0x85, 0x1e, 0xba, 0x0d, 0x25, 0x16,
-	0xea, 0x18, 0xb6, 0xcf, 0x25, 0x5a, 0xc1, 0x45, 0x46, 0xee, 0xfb, 0xfa, 0x6f, 0x72, 0x50, 0x4f,
-	0x6f, 0xe9, 0xc8, 0x8e, 0x7c, 0x12, 0xd8, 0x9e, 0x95, 0xb0, 0xa3, 0x63, 0xde, 0xc0, 0x6c, 0x85,
-	0x75, 0x7f, 0x33, 0xf1, 0x42, 0x33, 0xb2, 0x95, 0xa1, 0x3f, 0xf9, 0x23, 0x46, 0xcf, 0xd8, 0xa0,
-	0x3a, 0x63, 0x83, 0xe8, 0x43, 0x40, 0xd2, 0x94, 0xc6, 0xb6, 0x63, 0x87, 0xc6, 0xe9, 0x55, 0x48,
-	0x84, 0x8e, 0x55, 0xac, 0x89, 0x9e, 0x03, 0xd6, 0xf1, 0x25, 0x6b, 0x67, 0x86, 0xe7, 0x79, 0x8e,
-	0x41, 0x87, 0x5e, 0x40, 0x0c, 0xd3, 0x7a, 0xc5, 0x6f, 0x2d, 0x2a, 0xae, 0x7a, 0x9e, 0xd3, 0x67,
-	0x6d, 0x6d, 0xeb, 0x15, 0x3b, 0xf9, 0x86, 0xfe, 0x84, 0x92, 0xd0, 0x60, 0x3f, 0x3c, 0x58, 0xa8,
-	0x60, 0x10, 0x4d, 0x1d, 0x7f, 0x42, 0xd1, 0xef, 0xc3, 0x7a, 0x34, 0x80, 0x1f, 0x7e, 0xf2, 0xd4,
-	0xad, 0xc9, 0x21, 0xbc, 0x0d, 0xe9, 0x50, 0x3b, 0x26, 0xc1, 0x90, 0xb8, 0xe1, 0xc0, 0x1e, 0x9e,
-	0xb3, 0xf3, 0x5d, 0xd9, 0x56, 0x70, 0xaa, 0xed, 0xab, 0x7c, 0xb9, 0xa4, 0x95, 0x71, 0xb4, 0x9a,
-	0x43, 0x1c, 0xaa, 0xff, 0x0c, 0x0a, 0x3c, 0x44, 0x60, 0x32, 0xe1, 0xc7, 0x2b, 0x3f, 0x7d, 0x65,
-	0x68, 0xc9, 0x1a, 0xf8, 0xd9, 0xfb, 0x1e, 0x54, 0xb8, 0xec, 0x13, 0x11, 0x3d, 0x8f, 0x3b, 0x79,
-	0x67, 0x13, 0xca, 0x01, 0x31, 0x2d, 0xcf, 0x1d, 0x47, 0xc9, 0xa0, 0x98, 0xd6, 0xbf, 0x81, 0xa2,
-	0x38, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3e, 0x1d, 0x9b, 0x52, 0x19, 0x85,
-	0xf2, 0x17, 0x48, 0xd1, 0x73, 0x3c, 0xed, 0xd0, 0xff, 0x53, 0x11, 0xf1, 0xa8, 0x78, 0x1b, 0x62,
-	0x81, 0x2b, 0x33, 0x72, 0x76, 0x5b, 0x16, 0x49, 0xa8, 0x88, 0x44, 0xfb, 0x50, 0x94, 0x61, 0x67,
-	0x6e, 0xd5, 0xa7, 0x35, 0x09, 0x10, 0xa5, 0xa4, 0x89, 0xbc, 0x90, 0x2f, 0x9b, 0x92, 0x26, 0x22,
-	0x25, 0x4d, 0xd8, 0x6d, 0x52, 0x06, 0xc4, 0x02, 0x2e, 0xcf, 0xe3, 0xe1, 0xaa, 0x15, 0xe7, 0xfd,
-	0x89, 0xfe, 0xdf, 0x4a, 0xec, 0xa6, 0xa2, 0xfc, 0x3c, 0xfa, 0x1a, 0xca, 0x6c, 0xc7, 0x1b, 0x8e,
-	0xe9, 0xcb, 0xd7, 0xe6, 0xce, 0x6a, 0xa9, 0xff, 0xe8, 0x10, 0x13, 0xe1, 0x6c, 0xc9, 0x17, 0x14,
-	0x73, 0x77, 0xec, 0x2a, 0x11, 0xb9, 0x3b, 0xf6, 0x8d, 0x3e, 0x80, 0xba, 0x39, 0x09, 0x3d, 0xc3,
-	0xb4, 0x2e, 0x48, 0x10, 0xda, 0x94, 0x48, 0xdd, 0xaf, 0xb3, 0xd6, 0x76, 0xd4, 0xd8, 0xfc, 0x0c,
-	0x6a, 0x49, 0xcc, 0xb7, 0x85, 0x19, 0x85, 0x64, 0x98, 0xf1, 0xa7, 0x00, 0xd3, 0x5c, 0x17, 0xb3,
-	0x11, 0x72, 0x69, 0xb3, 0x5b, 0xbd, 0xbc, 0xbb, 0x16, 0x70, 0x99, 0x35, 0x74, 0xd8, 0x7d, 0x2a,
-	0x9d, 0x88, 0x2f, 0x44, 0x89, 0x78, 0xb6, 0x99, 0xd9, 0xfe, 0x3b, 0xb7, 0xc7, 0xe3, 0x38, 0xff,
-	0x56, 0xf1, 0x3c, 0xe7, 0x29, 0x6f, 0xd0, 0x7f, 0x9b, 0x13, 0xb6, 0x22, 0x9e, 0x54, 0x32, 0xdd,
-	0x5d, 0xde, 0x95, 0xaa, 0x1f, 0x00, 0xd0, 0xd0, 0x0c, 0x58, 0xcc, 0x64, 0x46, 0x19, 0xc0, 0xe6,
-	0x5c, 0x26, 0x7f, 0x10, 0xd5, 0x78, 0xe0, 0x8a, 0x1c, 0xdd, 0x0e, 0xd1, 0xe7, 0x50, 0x1b, 0x7a,
-	0x8e, 0x3f, 0x26, 0x72, 0x72, 0xe1, 0xad, 0x93, 0xab, 0xf1, 0xf8, 0x76, 0x98, 0xc8, 0x3b, 0x16,
-	0xaf, 0x9b, 0x77, 0xfc, 0x8d, 0x22, 0x5e, 0x86, 0x92, 0x0f, 0x53, 0x68, 0xb4, 0xa0, 0xfa, 0xe1,
-	0xc9, 0x8a, 0xaf, 0x5c, 0xdf, 0x55, 0xfa, 0xd0, 0xfc, 0x3c, 0x4b, 0xad, 0xc1, 0x9b, 0xa3, 0xd8,
-	0x7f, 0x53, 0xa1, 0x12, 0x3f, 0x0a, 0xcd, 0xe9, 0xfe, 0x53, 0xa8, 0xc4, 0x05, 0x36, 0xd2, 0x41,
-	0x7c, 0xa7, 0x7a, 0xe2, 0xc1, 0xe8, 0x25, 0x20, 0x73, 0x34, 0x8a, 0xa3, 0x53, 0x63, 0x42, 0xcd,
-	0x51, 0xf4, 0x24, 0xf7, 0xe9, 0x12, 0x72, 0x88, 0x8e, 0xb3, 0x13, 0x36, 0x1f, 0x6b, 0xe6, 0x68,
-	0x94, 0x6a, 0x41, 0x7f, 0x06, 0x37, 0xd3, 0x6b, 0x18, 0xa7, 0x57, 0x86, 0x6f, 0x5b, 0xf2, 0x8e,
-	0xbc, 0xb7, 0xec, 0xbb, 0x58, 0x2b, 0x05, 0xff, 0xe5, 0xd5, 0xb1, 0x6d, 0x09, 0x99, 0xa3, 0x60,
-	0xae, 0xa3, 0xf9, 0x17, 0x70, 0xfb, 0x0d, 0xc3, 0x17, 0xe8, 0xa0, 0x97, 0xae, 0xf7, 0x58, 0x5d,
-	0x08, 0x09, 0xed, 0xfd, 0x5a, 0x11, 0xcf, 0x77, 0x69, 0x99, 0xb4, 0x93, 0x61, 0xf5, 0xbd, 0x8c,
-	0xeb, 0x74, 0x8e, 0x4f, 0x04, 0x3c, 0x8f, 0xa4, 0xbf, 0x9a, 0x89, 0xa4, 0xb3, 0xc6, 0x4f, 0x22,
-	0x20, 0x15, 0x40, 0x12, 0x41, 0xff, 0x17, 0x15, 0xca, 0x11, 0x3a, 0xbf, 0xe1, 0x5e, 0xd1, 0x90,
-	0x38, 0x46, 0x9c, 0x7e, 0x53, 0x30, 0x88, 0x26, 0x9e, 0x14, 0x7a, 0x0f, 0x2a, 0xec, 0x22, 0x2d,
-	0xba, 0x73, 0xbc, 0xbb, 0xcc, 0x1a, 0x78, 0xe7, 0xfb, 0x50, 0x0d, 0xbd, 0xd0, 0x1c, 0x1b, 0x21,
-	0x3f, 0xde, 0x55, 0x31, 0x9b, 0x37, 0xf1, 0xc3, 0x1d, 0xfd, 0x00, 0x36, 0xc3, 0xb3, 0xc0, 0x0b,
-	0xc3, 0x31, 0x0b, 0x2d, 0x79, 0xa0, 0x23, 0xe2, 0x92, 0x3c, 0xd6, 0xe2, 0x0e, 0x11, 0x00, 0x51,
-	0xe6, 0xbd, 0xa7, 0x83, 0x99, 0xe9, 0x72, 0x27, 0x92, 0xc7, 0xeb, 0x71, 0x2b, 0x33, 0x6d, 0x76,
-	0x78, 0xfa, 0x22, 0x80, 0xe0, 0xbe, 0x42, 0xc1, 0x11, 0x89, 0x0c, 0xd8, 0x70, 0x88, 0x49, 0x27,
-	0x01, 0xb1, 0x8c, 0x97, 0x36, 0x19, 0x5b, 0x22, 0x31, 0x51, 0xcf, 0x7c, 0x3b, 0x88, 0xc4, 0xd2,
-	0x7a, 0xcc, 0x67, 0xe3, 0x7a, 0x04, 0x27, 0x68, 0x16, 0x39, 0x88, 0x2f, 0xb4, 0x01, 0xd5, 0xfe,
-	0xf3, 0xfe, 0xa0, 0x7b, 0x68, 0x1c, 0x1e, 0xed, 0x76, 0x65, 0x49, 0x4f, 0xbf, 0x8b, 0x05, 0xa9,
-	0xb0, 0xfe, 0xc1, 0xd1, 0xa0, 0x7d, 0x60, 0x0c, 0xf6, 0x3b, 0x4f, 0xfb, 0x5a, 0x0e, 0xdd, 0x84,
-	0xcd, 0xc1, 0x1e, 0x3e, 0x1a, 0x0c, 0x0e, 0xba, 0xbb, 0xc6, 0x71, 0x17, 0xef, 0x1f, 0xed, 0xf6,
-	0x35, 0x15, 0x21, 0xa8, 0x4f, 0x9b, 0x07, 0xfb, 0x87, 0x5d, 0x2d, 0x8f, 0xaa, 0x50, 0x3a, 0xee,
-	0xe2, 0x4e, 0xb7, 0x37, 0xd0, 0x0a, 0xfa, 0xaf, 0x54, 0xa8, 0x26, 0xb4, 0xc8, 0x0c, 0x39, 0xa0,
-	0xe2, 0x1a, 0x92, 0xc7, 0xec, 0x93, 0x3f, 0x41, 0x9a, 0xc3, 0x33, 0xa1, 0x9d, 0x3c, 0x16, 0x04,
-	0xbf, 0x7a, 0x98, 0x97, 0x89, 0x7d, 0x9e, 0xc7, 0x65, 0xc7, 0xbc, 0x14, 0x20, 0xdf, 0x83, 0xda,
-	0x39, 0x09, 0x5c, 0x32, 0x96, 0xfd, 0x42, 0x23, 0x55, 0xd1, 0x26, 0x86, 0x6c, 0x83, 0x26, 0x87,
-	0x4c, 0x61, 0x84, 0x3a, 0xea, 0xa2, 0xfd, 0x30, 0x02, 0xdb, 0x82, 0x82, 0xe8, 0x2e, 0x89, 0xf5,
-	0x39, 0xc1, 0x8e, 0x29, 0xfa, 0xda, 0xf4, 0x79, 0xc8, 0x97, 0xc7, 0xfc, 0x1b, 0x9d, 0xce, 0xeb,
-	0xa7, 0xc8, 0xf5, 0xf3, 0x60, 0x79, 0x73, 0x7e, 0x93, 0x8a, 0xce, 0x62, 0x15, 0x95, 0x40, 0xc5,
-	0x51, 0x1d, 0x4c, 0xa7, 0xdd, 0xd9, 0x63, 0x6a, 0x59, 0x87, 0xca, 0x61, 0xfb, 0xa7, 0xc6, 0x49,
-	0x9f, 0x67, 0xb5, 0x91, 0x06, 0xb5, 0xa7, 0x5d, 0xdc, 0xeb, 0x1e, 0xc8, 0x16, 0x15, 0x6d, 0x81,
-	0x26, 0x5b, 0xa6, 0xe3, 0xf2, 0x0c, 0x41, 0x7c, 0x16, 0x50, 0x19, 0xf2, 0xfd, 0x67, 0xed, 0x63,
-	0xad, 0xa8, 0xff, 0x57, 0x0e, 0x36, 0xc4, 0xb1, 0x10, 0xbf, 0xd8, 0xbf, 0xf9, 0xc5, 0x32, 0x99,
-	0xe5, 0xc9, 0xa5, 0xb3, 0x3c, 0x51, 0x10, 0xca, 0x4f, 0x75, 0x75, 0x1a, 0x84, 0xf2, 0xec, 0x50,
-	0xca, 0xe3, 0xe7, 0x97, 0xf1, 0xf8, 0x0d, 0x28, 0x39, 0x84, 0xc6, 0x7a, 0xab, 0xe0, 0x88, 0x44,
-	0x36, 0x54, 0x4d, 0xd7, 0xf5, 0x42, 0x53, 0xa4, 0x4e, 0x8b, 0x4b, 0x1d, 0x86, 0x33, 0xff, 0xb8,
-	0xd5, 0x9e, 0x22, 0x09, 0xc7, 0x9c, 0xc4, 0x6e, 0xfe, 0x04, 0xb4, 0xd9, 0x01, 0xcb, 0x1c, 0x87,
-	0xdf, 0xff, 0xe1, 0xf4, 0x34, 0x24, 0x6c, 0x5f, 0xc8, 0x37, 0x07, 0x6d, 0x8d, 0x11, 0xf8, 0xa4,
-	0xd7, 0xdb, 0xef, 0x3d, 0xd1, 0x14, 0x04, 0x50, 0xec, 0xfe, 0x74, 0x7f, 0xd0, 0xdd, 0xd5, 0x72,
-	0x3b, 0xbf, 0xde, 0x84, 0xa2, 0x60, 0x12, 0x7d, 0x2b, 0x23, 0x81, 0x64, 0x35, 0x28, 0xfa, 0xc9,
-	0xd2, 0x11, 0x75, 0xaa, 0xc2, 0xb4, 0xf9, 0x68, 0xe5, 0xf9, 0xf2, 0xf5, 0x6d, 0x0d, 0xfd, 0x8d,
-	0x02, 0xb5, 0xd4, 0xcb, 0x5b, 0xd6, 0xd4, 0xf1, 0x82, 0xe2, 0xd3, 0xe6, 0x8f, 0x57, 0x9a, 0x1b,
-	0xf3, 0xf2, 0x4b, 0x05, 0xaa, 0x89, 0xb2, 0x4b, 0xf4, 0x60, 0x95, 0x52, 0x4d, 0xc1, 0xc9, 0x67,
-	0xab, 0x57, 0x79, 0xea, 0x6b, 0x1f, 0x2b, 0xe8, 0xaf, 0x15, 0xa8, 0x26, 0x0a, 0x10, 0x33, 0xb3,
-	0x32, 0x5f, 0x2e, 0x99, 0x99, 0x95, 0x45, 0xf5, 0x8e, 0x6b, 0xe8, 0x2f, 0x15, 0xa8, 0xc4, 0xc5,
-	0x84, 0xe8, 0xfe, 0xf2, 0xe5, 0x87, 0x82, 0x89, 0x4f, 0x57, 0xad, 0x5b, 0xd4, 0xd7, 0xd0, 0x9f,
-	0x43, 0x39, 0xaa, 0xbc, 0x43, 0x59, 0x4f, 0xaf, 0x99, 0xb2, 0xbe, 0xe6, 0xfd, 0xa5, 0xe7, 0x25,
-	0x97, 0x8f, 0xca, 0xe1, 0x32, 0x2f, 0x3f, 0x53, 0xb8, 0xd7, 0xbc, 0xbf, 0xf4, 0xbc, 0x78, 0x79,
-	0x66, 0x09, 0x89, 0xaa, 0xb9, 0xcc, 0x96, 0x30, 0x5f, 0xae, 0x97, 0xd9, 0x12, 0x16, 0x15, 0xe9,
-	0x09, 0x46, 0x12, 0x75, 0x77, 0x99, 0x19, 0x99, 0xaf, 0xed, 0xcb, 0xcc, 0xc8, 0x82, 0x32, 0x3f,
-	0x7d, 0x0d, 0xfd, 0x42, 0x49, 0xde, 0x0b, 0xee, 0x2f, 0x5d, 0x5e, 0xb6, 0xa4, 0x49, 0xce, 0x15,
-	0xb8, 0xf1, 0x0d, 0xfa, 0x0b, 0x99, 0xc5, 0x10, 0xd5, 0x69, 0x68, 0x19, 0xb0, 0x54, 0x41, 0x5b,
-	0xf3, 0x93, 0xd5, 0x0e, 0x1b, 0xce, 0xc4, 0x5f, 0x29, 0x00, 0xd3, 0x3a, 0xb6, 0xcc, 0x4c, 0xcc,
-	0x15, 0xd0, 0x35, 0x1f, 0xac, 0x30, 0x33, 0xb9, 0x41, 0xa2, 0x3a, 0x9b, 0xcc, 0x1b, 0x64, 0xa6,
-	0xce, 0x2e, 0xf3, 0x06, 0x99, 0xad, 0x91, 0xd3, 0xd7, 0xd0, 0x3f, 0x29, 0xb0, 0x39, 0x57, 0xe7,
-	0x83, 0x1e, 0x5d, 0xb3, 0xd4, 0xab, 0xf9, 0xc5, 0xea, 0x00, 0x11, 0x6b, 0xdb, 0xca, 0xc7, 0x0a,
-	0xfa, 0x5b, 0x05, 0xd6, 0xd3, 0xf5, 0x0f, 0x99, 0x4f, 0xa9, 0x05, 0x15, 0x43, 0xcd, 0x87, 0xab,
-	0x4d, 0x8e, 0xa5, 0xf5, 0xf7, 0x0a, 0xd4, 0xd3, 0xa5, 0x30, 0xe8, 0xe1, 0x72, 0x6e, 0x61, 0x86,
-	0xa1, 0xcf, 0x57, 0x9c, 0x1d, 0x71, 0xf4, 0x65, 0xe9, 0x8f, 0x0b, 0x22, 0x7a, 0x2b, 0xf2, 0x9f,
-	0x1f, 0xfd, 0x7f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x76, 0x8b, 0xb6, 0x52, 0xb4, 0x33, 0x00, 0x00,
+	// 3881 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5a, 0x4f, 0x6f, 0x1b, 0x49,
+	0x76, 0x77, 0xb3, 0x49, 0x8a, 0x7c, 0x94, 0xa8, 0x56, 0x49, 0xf2, 0xd0, 0xdc, 0x64, 0xc7, 0xdb,
+	0xc1, 0x2c, 0x84, 0xdd, 0x19, 0x7a, 0x56, 0x8b, 0x8c, 0xc7, 0x5e, 0x7b, 0x3d, 0x1c, 0x8a, 0xb6,
+	0x34, 0x96, 0x28, 0xa5, 0x48, 0xc1, 0xeb, 0x38, 0x3b, 0x9d, 0x56, 0x77, 0x99, 0x6a, 0x8b, 0xfd,
+	0x67, 0xba, 0x9a, 0xb2, 0xb4, 0x41, 0x90, 0x60, 0x03, 0x04, 0x1b, 0x20, 0x41, 0x72, 0x99, 0x4c,
+	0x0e, 0x39, 0x05, 0xc8, 0x29, 0x5f, 0x20, 0xd8, 0x60, 0x4e, 0x7b, 0xc8, 0x97, 0xc8, 0x25, 0xb7,
+	0x5c, 0xf3, 0x0d, 0x82, 0xfa, 0xd3, 0xcd, 0x6e, 0x52, 0x1e, 0x37, 0x29, 0xe7, 0xc4, 0x7e, 0xaf,
+	0xaa, 0x7e, 0xf5, 0xf8, 0xde, 0xab, 0x7a, 0xaf, 0xaa, 0x1e, 0xe8, 0xc1, 0x68, 0x3c, 0x74, 0x3c,
+	0x7a, 0xc7, 0x0e, 0x9d, 0x73, 0x12, 0xd2, 0x3b, 0x41, 0xe8, 0x47, 0xbe, 0xa4, 0x5a, 0x9c, 0x40,
+	0x1f, 0x9c, 0x9a, 0xf4, 0xd4, 0xb1, 0xfc, 0x30, 0x68, 0x79, 0xbe, 0x6b, 0xda, 0x2d, 0x39, 0xa6,
+	0x25, 0xc7, 0x88, 0x6e, 0xcd, 0xef, 0x0f, 0x7d, 0x7f, 0x38, 0x22, 0x02, 0xe1, 0x64, 0xfc, 0xf2,
+	0x8e, 0x3d, 0x0e, 0xcd, 0xc8, 0xf1, 0x3d, 0xd9, 0xfe, 0xfe, 0x74, 0x7b, 0xe4, 0xb8, 0x84, 0x46,
+	0xa6, 0x1b, 0xc8, 0x0e, 0x1f, 0xc4, 0xb2, 0xd0, 0x53, 0x33, 0x24, 0xf6, 0x9d, 0x53, 0x6b, 0x44,
+	0x03, 0x62, 0xb1, 0x5f, 0x83, 0x7d, 0xc8, 0x6e, 0x1f, 0x4e, 0x75, 0xa3, 0x51, 0x38, 0xb6, 0xa2,
+	0x58, 0x72, 0x33, 0x8a, 0x42, 0xe7, 0x64, 0x1c, 0x11, 0xd1, 0x5b, 0xbf, 0x05, 0xef, 0x0d, 0x4c,
+	0x7a, 0xd6, 0xf1, 0xbd, 0x97, 0xce, 0xb0, 0x6f, 0x9d, 0x12, 0xd7, 0xc4, 0xe4, 0xab, 0x31, 0xa1,
+	0x91, 0xfe, 0x27, 0xd0, 0x98, 0x6d, 0xa2, 0x81, 0xef, 0x51, 0x82, 0x3e, 0x83, 0x22, 0x9b, 0xb2,
+	0xa1, 0xdc, 0x56, 0xb6, 0x6a, 0xdb, 0x1f, 0xb6, 0xde, 0xa4, 0x02, 0x21, 0x43, 0x4b, 0x8a, 0xda,
+	0xea, 0x07, 0xc4, 0xc2, 0x7c, 0xa4, 0xbe, 0x09, 0xeb, 0x1d, 0x33, 0x30, 0x4f, 0x9c, 0x91, 0x13,
+	0x39, 0x84, 0xc6, 0x93, 0x8e, 0x61, 0x23, 0xcb, 0x96, 0x13, 0xfe, 0x12, 0x96, 0xad, 0x14, 0x5f,
+	0x4e, 0x7c, 0xaf, 0x95, 0x4b, 0xf7, 0xad, 0x1d, 0x4e, 0x65, 0x80, 0x33, 0x70, 0xfa, 0x06, 0xa0,
+	0xc7, 0x8e, 0x37, 0x24, 0x61, 0x10, 0x3a, 0x5e, 0x14, 0x0b, 0xf3, 0xad, 0x0a, 0xeb, 0x19, 0xb6,
+	0x14, 0xe6, 0x15, 0x40, 0xa2, 0x47, 0x26, 0x8a, 0xba, 0x55, 0xdb, 0xfe, 0x22, 0xa7, 0x28, 0x57,
+	0xe0, 0xb5, 0xda, 0x09, 0x58, 0xd7, 0x8b, 0xc2, 0x4b, 0x9c, 0x42, 0x47, 0x5f, 0x42, 0xf9, 0x94,
+	0x98, 0xa3, 0xe8, 0xb4, 0x51, 0xb8, 0xad, 0x6c, 0xd5, 0xb7, 0x1f, 0x5f, 0x63, 0x9e, 0x5d, 0x0e,
+	0xd4, 0x8f, 0xcc, 0x88, 0x60, 0x89, 0x8a, 0x3e, 0x02, 0x24, 0xbe, 0x0c, 0x9b, 0x50, 0x2b, 0x74,
+	0x02, 0xe6, 0x92, 0x0d, 0xf5, 0xb6, 0xb2, 0x55, 0xc5, 0x6b, 0xa2, 0x65, 0x67, 0xd2, 0xd0, 0x0c,
+	0x60, 0x75, 0x4a, 0x5a, 0xa4, 0x81, 0x7a, 0x46, 0x2e, 0xb9, 0x45, 0xaa, 0x98, 0x7d, 0xa2, 0x27,
+	0x50, 0x3a, 0x37, 0x47, 0x63, 0xc2, 0x45, 0xae, 0x6d, 0xff, 0xe4, 0x6d, 0xee, 0x21, 0x5d, 0x74,
+	0xa2, 0x07, 0x2c, 0xc6, 0xdf, 0x2f, 0x7c, 0xaa, 0xe8, 0xf7, 0xa0, 0x96, 0x92, 0x1b, 0xd5, 0x01,
+	0x8e, 0x7b, 0x3b, 0xdd, 0x41, 0xb7, 0x33, 0xe8, 0xee, 0x68, 0x37, 0xd0, 0x0a, 0x54, 0x8f, 0x7b,
+	0xbb, 0xdd, 0xf6, 0xfe, 0x60, 0xf7, 0xb9, 0xa6, 0xa0, 0x1a, 0x2c, 0xc5, 0x44, 0x41, 0xbf, 0x00,
+	0x84, 0x89, 0xe5, 0x9f, 0x93, 0x90, 0x39, 0xb2, 0xb4, 0x2a, 0x7a, 0x0f, 0x96, 0x22, 0x93, 0x9e,
+	0x19, 0x8e, 0x2d, 0x65, 0x2e, 0x33, 0x72, 0xcf, 0x46, 0x7b, 0x50, 0x3e, 0x35, 0x3d, 0x7b, 0xf4,
+	0x76, 0xb9, 0xb3, 0xaa, 0x66, 0xe0, 0xbb, 0x7c, 0x20, 0x96, 0x00, 0xcc, 0xbb, 0x33, 0x33, 0x0b,
+	0x03, 0xe8, 0xcf, 0x41, 0xeb, 0x47, 0x66, 0x18, 0xa5, 0xc5, 0xe9, 0x42, 0x91, 0xcd, 0xdf, 0x50,
+	0xe6, 0x9e, 0x53, 0xac, 0x4c, 0xcc, 0x87, 0xeb, 0xff, 0x5b, 0x80, 0xb5, 0x14, 0xb6, 0xf4, 0xd4,
+	0x67, 0x50, 0x0e, 0x09, 0x1d, 0x8f, 0x22, 0x0e, 0x5f, 0xdf, 0x7e, 0x94, 0x13, 0x7e, 0x06, 0xa9,
+	0x85, 0x39, 0x0c, 0x96, 0x70, 0x68, 0x0b, 0x34, 0x31, 0xc2, 0x20, 0x61, 0xe8, 0x87, 0x86, 0x4b,
+	0x87, 0x5c, 0x6b, 0x55, 0x5c, 0x17, 0xfc, 0x2e, 0x63, 0x1f, 0xd0, 0x61, 0x4a, 0xab, 0xea, 0x35,
+	0xb5, 0x8a, 0x4c, 0xd0, 0x3c, 0x12, 0xbd, 0xf6, 0xc3, 0x33, 0x83, 0xa9, 0x36, 0x74, 0x6c, 0xd2,
+	0x28, 0x72, 0xd0, 0x4f, 0x72, 0x82, 0xf6, 0xc4, 0xf0, 0x43, 0x39, 0x1a, 0xaf, 0x7a, 0x59, 0x86,
+	0xfe, 0x63, 0x28, 0x8b, 0x7f, 0xca, 0x3c, 0xa9, 0x7f, 0xdc, 0xe9, 0x74, 0xfb, 0x7d, 0xed, 0x06,
+	0xaa, 0x42, 0x09, 0x77, 0x07, 0x98, 0x79, 0x58, 0x15, 0x4a, 0x8f, 0xdb, 0x83, 0xf6, 0xbe, 0x56,
+	0xd0, 0x7f, 0x04, 0xab, 0xcf, 0x4c, 0x27, 0xca, 0xe3, 0x5c, 0xba, 0x0f, 0xda, 0xa4, 0xaf, 0xb4,
+	0xce, 0x5e, 0xc6, 0x3a, 0xf9, 0x55, 0xd3, 0xbd, 0x70, 0xa2, 0x29, 0x7b, 0x68, 0xa0, 0x92, 0x30,
+	0x94, 0x26, 0x60, 0x9f, 0xfa, 0x6b, 0x58, 0xed, 0x47, 0x7e, 0x90, 0xcb, 0xf3, 0x7f, 0x0a, 0x4b,
+	0x2c, 0xda, 0xf8, 0xe3, 0x48, 0xba, 0xfe, 0xad, 0x96, 0x88, 0x46, 0xad, 0x38, 0x1a, 0xb5, 0x76,
+	0x64, 0xb4, 0xc2, 0x71, 0x4f, 0x74, 0x13, 0xca, 0xd4, 0x19, 0x7a, 0xe6, 0x48, 0xee, 0x16, 0x92,
+	0xd2, 0x11, 0x68, 0x93, 0x89, 0xa5, 0xe3, 0x77, 0x00, 0xed, 0x10, 0x1a, 0x85, 0xfe, 0x65, 0x2e,
+	0x79, 0x36, 0xa0, 0xf4, 0xd2, 0x0f, 0x2d, 0xb1, 0x10, 0x2b, 0x58, 0x10, 0x6c, 0x51, 0x65, 0x40,
+	0x24, 0xf6, 0x47, 0x80, 0xf6, 0x3c, 0x16, 0x53, 0xf2, 0x19, 0xe2, 0x1f, 0x0a, 0xb0, 0x9e, 0xe9,
+	0x2f, 0x8d, 0xb1, 0xf8, 0x3a, 0x64, 0x1b, 0xd3, 0x98, 0x8a, 0x75, 0x88, 0x0e, 0xa1, 0x2c, 0x7a,
+	0x48, 0x4d, 0xde, 0x9d, 0x03, 0x48, 0x84, 0x29, 0x09, 0x27, 0x61, 0xae, 0x74, 0x7a, 0xf5, 0xdd,
+	0x3a, 0xfd, 0x6b, 0xd0, 0xe2, 0xff, 0x41, 0xdf, 0x6a, 0x9b, 0x2f, 0x60, 0xdd, 0xf2, 0x47, 0x23,
+	0x62, 0x31, 0x6f, 0x30, 0x1c, 0x2f, 0x22, 0xe1, 0xb9, 0x39, 0x7a, 0xbb, 0xdf, 0xa0, 0xc9, 0xa8,
+	0x3d, 0x39, 0x48, 0x7f, 0x01, 0x6b, 0xa9, 0x89, 0xa5, 0x21, 0x1e, 0x43, 0x89, 0x32, 0x86, 0xb4,
+	0xc4, 0xc7, 0x73, 0x5a, 0x82, 0x62, 0x31, 0x5c, 0x5f, 0x17, 0xe0, 0xdd, 0x73, 0xe2, 0x25, 0x7f,
+	0x4b, 0xdf, 0x81, 0xb5, 0x3e, 0x77, 0xd3, 0x5c, 0x7e, 0x38, 0x71, 0xf1, 0x42, 0xc6, 0xc5, 0x37,
+	0x00, 0xa5, 0x51, 0xa4, 0x23, 0x5e, 0xc2, 0x6a, 0xf7, 0x82, 0x58, 0xb9, 0x90, 0x1b, 0xb0, 0x64,
+	0xf9, 0xae, 0x6b, 0x7a, 0x76, 0xa3, 0x70, 0x5b, 0xdd, 0xaa, 0xe2, 0x98, 0x4c, 0xaf, 0x45, 0x35,
+	0xef, 0x5a, 0xd4, 0xff, 0x4e, 0x01, 0x6d, 0x32, 0xb7, 0x54, 0x24, 0x93, 0x3e, 0xb2, 0x19, 0x10,
+	0x9b, 0x7b, 0x19, 0x4b, 0x4a, 0xf2, 0xe3, 0xed, 0x42, 0xf0, 0x49, 0x18, 0xa6, 0xb6, 0x23, 0xf5,
+	0x9a, 0xdb, 0x91, 0xbe, 0x0b, 0xbf, 0x17, 0x8b, 0xd3, 0x8f, 0x42, 0x62, 0xba, 0x8e, 0x37, 0xdc,
+	0x3b, 0x3c, 0x0c, 0x88, 0x10, 0x1c, 0x21, 0x28, 0xda, 0x66, 0x64, 0x4a, 0xc1, 0xf8, 0x37, 0x5b,
+	0xf4, 0xd6, 0xc8, 0xa7, 0xc9, 0xa2, 0xe7, 0x84, 0xfe, 0x9f, 0x2a, 0x34, 0x66, 0xa0, 0x62, 0xf5,
+	0xbe, 0x80, 0x12, 0x25, 0xd1, 0x38, 0x90, 0xae, 0xd2, 0xcd, 0x2d, 0xf0, 0xd5, 0x78, 0xad, 0x3e,
+	0x03, 0xc3, 0x02, 0x13, 0x0d, 0xa1, 0x12, 0x45, 0x97, 0x06, 0x75, 0x7e, 0x15, 0x27, 0x04, 0xfb,
+	0xd7, 0xc5, 0x1f, 0x90, 0xd0, 0x75, 0x3c, 0x73, 0xd4, 0x77, 0x7e, 0x45, 0xf0, 0x52, 0x14, 0x5d,
+	0xb2, 0x0f, 0xf4, 0x9c, 0x39, 0xbc, 0xed, 0x78, 0x52, 0xed, 0x9d, 0x45, 0x67, 0x49, 0x29, 0x18,
+	0x0b, 0xc4, 0xe6, 0x3e, 0x94, 0xf8, 0x7f, 0x5a, 0xc4, 0x11, 0x35, 0x50, 0xa3, 0xe8, 0x92, 0x0b,
+	0x55, 0xc1, 0xec, 0xb3, 0xf9, 0x00, 0x96, 0xd3, 0xff, 0x80, 0x39, 0xd2, 0x29, 0x71, 0x86, 0xa7,
+	0xc2, 0xc1, 0x4a, 0x58, 0x52, 0xcc, 0x92, 0xaf, 0x1d, 0x5b, 0xa6, 0xac, 0x25, 0x2c, 0x08, 0xfd,
+	0xdf, 0x0b, 0x70, 0xeb, 0x0a, 0xcd, 0x48, 0x67, 0x7d, 0x91, 0x71, 0xd6, 0x77, 0xa4, 0x85, 0xd8,
+	0xe3, 0x5f, 0x64, 0x3c, 0xfe, 0x1d, 0x82, 0xb3, 0x65, 0x73, 0x13, 0xca, 0xe4, 0xc2, 0x89, 0x88,
+	0x2d, 0x55, 0x25, 0xa9, 0xd4, 0x72, 0x2a, 0x5e, 0x77, 0x39, 0x1d, 0xc0, 0x46, 0x27, 0x24, 0x66,
+	0x44, 0xe4, 0x56, 0x1e, 0xfb, 0xff, 0x2d, 0xa8, 0x98, 0xa3, 0x91, 0x6f, 0x4d, 0xcc, 0xba, 0xc4,
+	0xe9, 0x3d, 0x1b, 0x35, 0xa1, 0x72, 0xea, 0xd3, 0xc8, 0x33, 0x5d, 0x22, 0x37, 0xaf, 0x84, 0xd6,
+	0xbf, 0x56, 0x60, 0x73, 0x0a, 0x4f, 0x5a, 0xe1, 0x04, 0xea, 0x0e, 0xf5, 0x47, 0xfc, 0x0f, 0x1a,
+	0xa9, 0x13, 0xde, 0xcf, 0xe6, 0x0b, 0x35, 0x7b, 0x31, 0x06, 0x3f, 0xf0, 0xad, 0x38, 0x69, 0x92,
+	0x7b, 0x1c, 0x9f, 0xdc, 0x96, 0x2b, 0x3d, 0x26, 0xf5, 0x7f, 0x54, 0x60, 0x53, 0x46, 0xf8, 0xfc,
+	0x7f, 0x74, 0x56, 0xe4, 0xc2, 0xbb, 0x16, 0x59, 0x6f, 0xc0, 0xcd, 0x69, 0xb9, 0xe4, 0x9e, 0x7f,
+	0x00, 0x9b, 0x9d, 0x53, 0x62, 0x9d, 0x05, 0xbe, 0xe3, 0xe5, 0xca, 0x3f, 0xd0, 0xf7, 0xa0, 0xea,
+	0xb8, 0xe6, 0x90, 0x18, 0xb6, 0x13, 0xe7, 0x6b, 0x15, 0xce, 0xd8, 0x71, 0x42, 0x36, 0xd1, 0x34,
+	0x9c, 0x9c, 0x88, 0x9f, 0x65, 0x68, 0xe4, 0x87, 0xe4, 0xdd, 0x1f, 0x1e, 0xbe, 0x5b, 0xa6, 0xdf,
+	0x29, 0xb0, 0x9e, 0x99, 0x7a, 0x92, 0xbd, 0xca, 0xc4, 0x5e, 0xf9, 0xff, 0x48, 0xec, 0x0b, 0xef,
+	0x36, 0xc7, 0xf9, 0xa6, 0x04, 0x68, 0xf6, 0x1a, 0x00, 0xfd, 0x00, 0x96, 0x29, 0xf1, 0x6c, 0x43,
+	0x04, 0x76, 0x91, 0x73, 0x54, 0x70, 0x8d, 0xf1, 0x44, 0x84, 0xa7, 0x2c, 0x56, 0x91, 0x0b, 0xe9,
+	0x56, 0x15, 0xcc, 0xbf, 0xd1, 0x29, 0x2c, 0xbf, 0xa4, 0x46, 0xe2, 0x24, 0x7c, 0xe5, 0xd7, 0x73,
+	0xc7, 0x9f, 0x59, 0x39, 0x5a, 0x8f, 0xfb, 0x89, 0x03, 0xe2, 0xda, 0x4b, 0x9a, 0x10, 0xe8, 0x37,
+	0x0a, 0xbc, 0x17, 0xeb, 0x66, 0xe2, 0xe7, 0xae, 0x6f, 0x13, 0xda, 0x28, 0xde, 0x56, 0xb7, 0xea,
+	0xdb, 0x47, 0xd7, 0x70, 0xf4, 0x19, 0xe6, 0x81, 0x6f, 0x13, 0xbc, 0xe9, 0x5d, 0xc1, 0xa5, 0xa8,
+	0x05, 0xeb, 0xee, 0x98, 0x46, 0x86, 0x58, 0xae, 0x86, 0xec, 0xd4, 0x28, 0x71, 0xbd, 0xac, 0xb1,
+	0xa6, 0xcc, 0xa6, 0x82, 0xce, 0x60, 0xc5, 0xf5, 0xc7, 0x5e, 0x64, 0x58, 0xdc, 0xd7, 0x68, 0xa3,
+	0x3c, 0xd7, 0x0d, 0xc6, 0x15, 0x5a, 0x3a, 0x60, 0x70, 0xc2, 0x73, 0x29, 0x5e, 0x76, 0x53, 0x14,
+	0x33, 0x64, 0x48, 0x5c, 0x3f, 0x22, 0x06, 0xf3, 0x68, 0xda, 0x58, 0x12, 0x86, 0x14, 0x3c, 0xe6,
+	0x72, 0x14, 0x7d, 0x1f, 0xc0, 0x4a, 0x16, 0x57, 0xa3, 0xc2, 0x3b, 0xa4, 0x38, 0x7a, 0x0b, 0x6a,
+	0x29, 0x33, 0xa0, 0x0a, 0x14, 0x7b, 0x87, 0xbd, 0xae, 0x76, 0x03, 0x01, 0x94, 0x3b, 0xbb, 0xf8,
+	0xf0, 0x70, 0x20, 0x8e, 0x7f, 0x7b, 0x07, 0xed, 0x27, 0x5d, 0xad, 0xa0, 0x77, 0x61, 0x39, 0x2d,
+	0x10, 0x42, 0x50, 0x3f, 0xee, 0x3d, 0xed, 0x1d, 0x3e, 0xeb, 0x19, 0x07, 0x87, 0xc7, 0xbd, 0x01,
+	0x3b, 0x38, 0xd6, 0x01, 0xda, 0xbd, 0xe7, 0x13, 0x7a, 0x05, 0xaa, 0xbd, 0xc3, 0x98, 0x54, 0x9a,
+	0x05, 0x4d, 0xd1, 0x7f, 0xa7, 0xc2, 0xc6, 0x55, 0xb6, 0x41, 0x36, 0x14, 0x99, 0x9d, 0xe5, 0xd1,
+	0xfd, 0xdd, 0x9b, 0x99, 0xa3, 0x33, 0xf7, 0x0e, 0x4c, 0x19, 0xab, 0xab, 0x98, 0x7f, 0x23, 0x03,
+	0xca, 0x23, 0xf3, 0x84, 0x8c, 0x68, 0x43, 0xe5, 0x97, 0x5b, 0x4f, 0xae, 0x33, 0xf7, 0x3e, 0x47,
+	0x12, 0x37, 0x5b, 0x12, 0x16, 0x0d, 0xa0, 0xc6, 0xa2, 0x11, 0x15, 0xaa, 0x93, 0x01, 0x72, 0x3b,
+	0xe7, 0x2c, 0xbb, 0x93, 0x91, 0x38, 0x0d, 0xd3, 0xbc, 0x07, 0xb5, 0xd4, 0x64, 0x57, 0x5c, 0x4c,
+	0x6d, 0xa4, 0x2f, 0xa6, 0xaa, 0xe9, 0x5b, 0xa6, 0x47, 0xb0, 0x71, 0x95, 0x8e, 0x98, 0x13, 0xec,
+	0x1e, 0xf6, 0x07, 0xe2, 0x0a, 0xe0, 0x09, 0x3e, 0x3c, 0x3e, 0xd2, 0x14, 0xc6, 0x1c, 0xb4, 0xfb,
+	0x4f, 0xb5, 0x42, 0xe2, 0x23, 0xaa, 0xde, 0x81, 0x5a, 0x4a, 0xae, 0x4c, 0xf8, 0x55, 0xb2, 0xe1,
+	0x97, 0x05, 0x40, 0xd3, 0xb6, 0x43, 0x42, 0xa9, 0x94, 0x23, 0x26, 0xf5, 0x17, 0x50, 0xdd, 0xe9,
+	0xf5, 0x25, 0x44, 0x03, 0x96, 0x28, 0x09, 0xd9, 0xff, 0xe6, 0x57, 0x8c, 0x55, 0x1c, 0x93, 0x0c,
+	0x9c, 0x12, 0x33, 0xb4, 0x4e, 0x09, 0x95, 0x49, 0x5b, 0x42, 0xb3, 0x51, 0x3e, 0xbf, 0xaa, 0x13,
+	0xb6, 0xab, 0xe2, 0x98, 0xd4, 0xff, 0xa9, 0x02, 0x30, 0xd9, 0xf9, 0x51, 0x1d, 0x0a, 0x49, 0x6c,
+	0x2a, 0x38, 0x36, 0xf3, 0x83, 0x54, 0xb2, 0xc0, 0xbf, 0xd1, 0x36, 0x6c, 0xba, 0x74, 0x18, 0x98,
+	0xd6, 0x99, 0x21, 0x6f, 0x7b, 0xc4, 0x52, 0xe6, 0xfb, 0xdd, 0x32, 0x5e, 0x97, 0x8d, 0x72, 0xa5,
+	0x0a, 0xdc, 0x7d, 0x50, 0x89, 0x77, 0xce, 0xf7, 0xa6, 0xda, 0xf6, 0xfd, 0xb9, 0x23, 0x52, 0xab,
+	0xeb, 0x9d, 0x0b, 0x5f, 0x61, 0x30, 0xc8, 0x00, 0xb0, 0xc9, 0xb9, 0x63, 0x11, 0x83, 0x81, 0x96,
+	0x38, 0xe8, 0x67, 0xf3, 0x83, 0xee, 0x70, 0x8c, 0x04, 0xba, 0x6a, 0xc7, 0x34, 0xea, 0x41, 0x35,
+	0x24, 0xd4, 0x1f, 0x87, 0x16, 0x11, 0x1b, 0x54, 0xfe, 0x13, 0x27, 0x8e, 0xc7, 0xe1, 0x09, 0x04,
+	0xda, 0x81, 0x32, 0xdf, 0x97, 0xd8, 0x0e, 0xa4, 0x7e, 0xe7, 0xdd, 0x78, 0x16, 0x8c, 0xef, 0x24,
+	0x58, 0x8e, 0x45, 0x4f, 0x60, 0x49, 0x88, 0x48, 0x1b, 0x15, 0x0e, 0xf3, 0x51, 0xde, 0x4d, 0x93,
+	0x8f, 0xc2, 0xf1, 0x68, 0x66, 0xd5, 0x31, 0x25, 0x61, 0xa3, 0x2a, 0xac, 0xca, 0xbe, 0x59, 0xb4,
+	0x17, 0xc9, 0x14, 0x8b, 0xf6, 0x20, 0x9c, 0x93, 0x33, 0x76, 0x9c, 0x10, 0xbd, 0x0f, 0x35, 0x91,
+	0x34, 0x1b, 0x7c, 0x57, 0xa8, 0xf1, 0x66, 0x10, 0xac, 0x23, 0xb6, 0x37, 0x88, 0x0e, 0x24, 0x0c,
+	0x45, 0x87, 0xe5, 0xa4, 0x03, 0x09, 0x43, 0xde, 0xe1, 0x87, 0xb0, 0xca, 0x33, 0x9f, 0x61, 0xe8,
+	0x8f, 0x03, 0x83, 0xfb, 0xd4, 0x0a, 0xef, 0xb4, 0xc2, 0xd8, 0x4f, 0x18, 0xb7, 0xc7, 0x9c, 0xeb,
+	0x16, 0x54, 0x5e, 0xf9, 0x27, 0xa2, 0x43, 0x5d, 0xac, 0x83, 0x57, 0xfe, 0x49, 0xdc, 0x94, 0xa4,
+	0x7b, 0xab, 0xd9, 0x74, 0xef, 0x2b, 0xb8, 0x39, 0x1b, 0x0e, 0x79, 0xda, 0xa7, 0x5d, 0x3f, 0xed,
+	0xdb, 0xf0, 0xae, 0xe0, 0xa2, 0xcf, 0x41, 0xb5, 0x3d, 0xda, 0x58, 0x9b, 0xcb, 0x39, 0x92, 0x75,
+	0x8c, 0xd9, 0x60, 0xf4, 0x43, 0xa8, 0x4f, 0x22, 0x8d, 0x79, 0x32, 0x22, 0x0d, 0xc4, 0xe3, 0xcf,
+	0x14, 0xb7, 0xf9, 0x09, 0x54, 0x62, 0x2f, 0x9d, 0x67, 0xff, 0x6a, 0x3e, 0x80, 0x7a, 0xd6, 0xc7,
+	0xe7, 0xda, 0xfd, 0xfe, 0xb5, 0x00, 0xd5, 0xc4, 0x9b, 0x91, 0x07, 0xeb, 0x5c, 0xdb, 0x66, 0x44,
+	0x6c, 0x63, 0xb2, 0x38, 0x44, 0x96, 0xf7, 0x30, 0xe7, 0xff, 0x6f, 0xc7, 0x08, 0x32, 0x67, 0x94,
+	0x2b, 0x05, 0x25, 0xc8, 0x93, 0xf9, 0xbe, 0x84, 0xd5, 0x91, 0xe3, 0x8d, 0x2f, 0x52, 0x73, 0x89,
+	0xe4, 0xef, 0x0f, 0x73, 0xce, 0xb5, 0xcf, 0x46, 0x4f, 0xe6, 0xa8, 0x8f, 0x32, 0x34, 0xda, 0x85,
+	0x52, 0xe0, 0x87, 0x51, 0x1c, 0xcc, 0xf2, 0x86, 0x99, 0x23, 0x3f, 0x8c, 0x0e, 0xcc, 0x20, 0x60,
+	0xa7, 0x54, 0x01, 0xa0, 0x7f, 0x5d, 0x80, 0x9b, 0x57, 0xff, 0x31, 0xd4, 0x03, 0xd5, 0x0a, 0xc6,
+	0x52, 0x49, 0x0f, 0xe6, 0x55, 0x52, 0x27, 0x18, 0x4f, 0xe4, 0x67, 0x40, 0xec, 0xe6, 0xde, 0x25,
+	0xae, 0x1f, 0x5e, 0x4a, 0x5d, 0x3c, 0x9a, 0x17, 0xf2, 0x80, 0x8f, 0x9e, 0xa0, 0x4a, 0x38, 0x84,
+	0xa1, 0x22, 0xbd, 0x9c, 0xca, 0xfd, 0x74, 0xce, 0x1c, 0x3b, 0x86, 0xc4, 0x09, 0x8e, 0xfe, 0x09,
+	0x6c, 0x5e, 0xf9, 0x57, 0xd0, 0xef, 0x03, 0x58, 0xc1, 0xd8, 0xe0, 0xef, 0x3c, 0xc2, 0x83, 0x54,
+	0x5c, 0xb5, 0x82, 0x71, 0x9f, 0x33, 0xf4, 0x17, 0xd0, 0x78, 0x93, 0xbc, 0x6c, 0x97, 0x12, 0x12,
+	0x1b, 0xee, 0x09, 0xd7, 0x81, 0x8a, 0x2b, 0x82, 0x71, 0x70, 0x82, 0x74, 0x58, 0x89, 0x1b, 0xcd,
+	0x0b, 0xd6, 0x41, 0xe5, 0x1d, 0x6a, 0xb2, 0x83, 0x79, 0x71, 0x70, 0xa2, 0x7f, 0x53, 0x80, 0xd5,
+	0x29, 0x91, 0xd9, 0x59, 0x5d, 0xec, 0x8c, 0xf1, 0xa1, 0x4c, 0x50, 0x6c, 0x9b, 0xb4, 0x1c, 0x3b,
+	0x3e, 0xfb, 0xf0, 0x6f, 0x1e, 0x20, 0x03, 0x79, 0xb7, 0x5d, 0x70, 0x02, 0xb6, 0x7c, 0xdc, 0x13,
+	0x27, 0xa2, 0x3c, 0x5b, 0x29, 0x61, 0x41, 0xa0, 0xe7, 0x50, 0x0f, 0x09, 0x0f, 0xcc, 0xb6, 0x21,
+	0xbc, 0xac, 0x34, 0x97, 0x97, 0x49, 0x09, 0x99, 0xb3, 0xe1, 0x95, 0x18, 0x89, 0x51, 0x14, 0x3d,
+	0x83, 0x15, 0xfb, 0xd2, 0x33, 0x5d, 0xc7, 0x92, 0xc8, 0xe5, 0x85, 0x91, 0x97, 0x25, 0x10, 0x07,
+	0x66, 0x4f, 0x6a, 0xa9, 0x46, 0xf6, 0xc7, 0x78, 0x5a, 0x26, 0x75, 0x22, 0x88, 0xec, 0x6e, 0x51,
+	0x92, 0xbb, 0x85, 0x7e, 0x02, 0xb5, 0xd4, 0xba, 0x98, 0x67, 0x28, 0xd3, 0x67, 0xe4, 0x73, 0x7d,
+	0x96, 0x70, 0x21, 0xf2, 0xd9, 0x09, 0x99, 0xa5, 0x44, 0x86, 0x13, 0x70, 0x8d, 0x56, 0x71, 0x99,
+	0x91, 0x7b, 0x81, 0xfe, 0xdb, 0x02, 0xd4, 0xb3, 0x4b, 0x3a, 0xf6, 0xa3, 0x80, 0x84, 0x8e, 0x6f,
+	0xa7, 0xfc, 0xe8, 0x88, 0x33, 0x98, 0xaf, 0xb0, 0xe6, 0xaf, 0xc6, 0x7e, 0x64, 0xc6, 0xbe, 0x62,
+	0x05, 0xe3, 0x3f, 0x62, 0xf4, 0x94, 0x0f, 0xaa, 0x53, 0x3e, 0x88, 0x3e, 0x04, 0x24, 0x5d, 0x69,
+	0xe4, 0xb8, 0x4e, 0x64, 0x9c, 0x5c, 0x46, 0x44, 0xd8, 0x58, 0xc5, 0x9a, 0x68, 0xd9, 0x67, 0x0d,
+	0x9f, 0x33, 0x3e, 0x73, 0x3c, 0xdf, 0x77, 0x0d, 0x6a, 0xf9, 0x21, 0x31, 0x4c, 0xfb, 0x15, 0x3f,
+	0xfd, 0xa8, 0xb8, 0xe6, 0xfb, 0x6e, 0x9f, 0xf1, 0xda, 0xf6, 0x2b, 0x16, 0x21, 0xad, 0x60, 0x4c,
+	0x49, 0x64, 0xb0, 0x1f, 0x9e, 0x54, 0x54, 0x31, 0x08, 0x56, 0x27, 0x18, 0x53, 0xf4, 0x07, 0xb0,
+	0x12, 0x77, 0xe0, 0x41, 0x52, 0x46, 0xe7, 0x65, 0xd9, 0x85, 0xf3, 0x90, 0x0e, 0xcb, 0x47, 0x24,
+	0xb4, 0x88, 0x17, 0x0d, 0x1c, 0xeb, 0x8c, 0xf2, 0xf3, 0x8a, 0x82, 0x33, 0xbc, 0x2f, 0x8a, 0x95,
+	0x25, 0xad, 0x82, 0xe3, 0xd9, 0x5c, 0xe2, 0x52, 0xfd, 0x97, 0x50, 0xe2, 0xa9, 0x04, 0xd3, 0x09,
+	0x0f, 0xc3, 0x3c, 0x4a, 0xcb, 0x14, 0x94, 0x31, 0x78, 0x8c, 0xfe, 0x1e, 0x54, 0xb9, 0xee, 0x53,
+	0x99, 0x3f, 0xcf, 0x4f, 0x79, 0x63, 0x13, 0x2a, 0x21, 0x31, 0x6d, 0xdf, 0x1b, 0xc5, 0xb7, 0x7f,
+	0x09, 0xad, 0x7f, 0x05, 0x65, 0x11, 0x67, 0xae, 0x81, 0xff, 0x11, 0x20, 0xf1, 0xbf, 0x99, 0x3d,
+	0x5d, 0x87, 0x52, 0x99, 0xad, 0xf2, 0x27, 0x67, 0xd1, 0x72, 0x34, 0x69, 0xd0, 0xff, 0x4b, 0x01,
+	0x98, 0xdc, 0x19, 0xb0, 0x04, 0x97, 0x39, 0x39, 0x3b, 0x75, 0x8b, 0x5b, 0xc7, 0x98, 0x64, 0x17,
+	0x12, 0x32, 0x3d, 0x2d, 0x2c, 0x7a, 0x1d, 0x22, 0x01, 0xe2, 0x37, 0x08, 0x22, 0x0f, 0xf6, 0xf3,
+	0xbe, 0x41, 0x10, 0xf1, 0x06, 0x41, 0xd8, 0xa9, 0x54, 0x26, 0xce, 0x02, 0xae, 0xc8, 0xf3, 0xe6,
+	0x9a, 0x9d, 0x3c, 0xf4, 0x10, 0xfd, 0x7f, 0x94, 0x64, 0x9b, 0x8a, 0x2f, 0x2b, 0xd0, 0x97, 0x50,
+	0x61, 0x2b, 0xde, 0x70, 0xcd, 0x40, 0x96, 0x17, 0x74, 0x16, 0xbb, 0x07, 0x89, 0x83, 0x98, 0x48,
+	0x7b, 0x97, 0x02, 0x41, 0xb1, 0xed, 0x8e, 0x1d, 0x39, 0xe2, 0xed, 0x8e, 0x7d, 0xa3, 0x0f, 0xa0,
+	0x6e, 0x8e, 0x23, 0xdf, 0x30, 0xed, 0x73, 0x12, 0x46, 0x0e, 0x25, 0xd2, 0xf6, 0x2b, 0x8c, 0xdb,
+	0x8e, 0x99, 0xcd, 0xfb, 0xb0, 0x9c, 0xc6, 0x7c, 0x5b, 0x9a, 0x51, 0x4a, 0xa7, 0x19, 0x7f, 0x0a,
+	0x30, 0xb9, 0xdc, 0x64, 0x3e, 0xc2, 0x6e, 0x4a, 0x0d, 0x2b, 0x3e, 0xe3, 0x96, 0x70, 0x85, 0x31,
+	0x3a, 0xec, 0xdc, 0x95, 0x7d, 0x79, 0x29, 0xc5, 0x2f, 0x2f, 0x6c, 0x31, 0xb3, 0xf5, 0x77, 0xe6,
+	0x8c, 0x46, 0xc9, 0x85, 0x6b, 0xd5, 0xf7, 0xdd, 0xa7, 0x9c, 0xa1, 0x7f, 0x5b, 0x10, 0xbe, 0x22,
+	0xde, 0xd0, 0x72, 0x9d, 0x71, 0xde, 0x95, 0xa9, 0xef, 0x01, 0xd0, 0xc8, 0x0c, 0x59, 0xce, 0x64,
+	0xc6, 0x57, 0xbe, 0xcd, 0x99, 0xa7, 0x9b, 0x41, 0x5c, 0xd4, 0x83, 0xab, 0xb2, 0x77, 0x3b, 0x42,
+	0x0f, 0x61, 0xd9, 0xf2, 0xdd, 0x60, 0x44, 0xe4, 0xe0, 0xd2, 0x5b, 0x07, 0xd7, 0x92, 0xfe, 0xed,
+	0x28, 0x75, 0xd1, 0x5c, 0xbe, 0xee, 0x45, 0xf3, 0x6f, 0x15, 0xf1, 0x14, 0x98, 0x7e, 0x89, 0x44,
+	0xc3, 0x2b, 0xca, 0x5d, 0x9e, 0x2c, 0xf8, 0xac, 0xf9, 0x5d, 0xb5, 0x2e, 0xcd, 0x87, 0x79, 0x8a,
+	0x4b, 0xde, 0x9c, 0xc5, 0xfe, 0x87, 0x0a, 0xd5, 0xd8, 0x2c, 0xb3, 0xb6, 0xff, 0x14, 0xaa, 0x49,
+	0x45, 0x55, 0xa3, 0xf0, 0x56, 0x0d, 0x4f, 0x3a, 0xa3, 0x97, 0x80, 0xcc, 0xe1, 0x30, 0xc9, 0x4e,
+	0x8d, 0x31, 0x35, 0x87, 0xf1, 0x1b, 0xec, 0xa7, 0x73, 0xe8, 0x21, 0x0e, 0x67, 0xc7, 0x6c, 0x3c,
+	0xd6, 0xcc, 0xe1, 0x30, 0xc3, 0x41, 0x7f, 0x06, 0x9b, 0xd9, 0x39, 0x8c, 0x93, 0x4b, 0x23, 0x70,
+	0x6c, 0x79, 0x96, 0xde, 0x9d, 0xf7, 0x21, 0xb4, 0x95, 0x81, 0xff, 0xfc, 0xf2, 0xc8, 0xb1, 0x85,
+	0xce, 0x51, 0x38, 0xd3, 0xd0, 0xfc, 0x0b, 0x78, 0xef, 0x0d, 0xdd, 0xaf, 0xb0, 0x41, 0x2f, 0x5b,
+	0xe0, 0xb3, 0xb8, 0x12, 0x52, 0xd6, 0xfb, 0x17, 0x05, 0xd6, 0x66, 0x3a, 0xa0, 0x76, 0x3a, 0xad,
+	0xbe, 0x93, 0x73, 0x9e, 0xce, 0xd1, 0xb1, 0x80, 0x67, 0x63, 0xd1, 0x17, 0x53, 0x99, 0x74, 0xde,
+	0xfc, 0x49, 0x24, 0xa4, 0x02, 0x48, 0x22, 0xe8, 0xff, 0xa6, 0x42, 0x25, 0x46, 0xe7, 0x27, 0xe1,
+	0x4b, 0x1a, 0x11, 0xd7, 0x48, 0xae, 0xe9, 0x14, 0x0c, 0x82, 0xc5, 0x2f, 0x8f, 0xbe, 0x07, 0x55,
+	0x76, 0xe0, 0x16, 0xcd, 0x05, 0xde, 0x5c, 0x61, 0x0c, 0xde, 0xf8, 0x3e, 0xd4, 0x22, 0x3f, 0x32,
+	0x47, 0x46, 0xc4, 0xc3, 0xbb, 0x2a, 0x46, 0x73, 0x16, 0x0f, 0xee, 0xe8, 0xc7, 0xb0, 0x16, 0x9d,
+	0x86, 0x7e, 0x14, 0x8d, 0x58, 0x6a, 0xc9, 0x13, 0x1d, 0x91, 0x97, 0x14, 0xb1, 0x96, 0x34, 0x88,
+	0x04, 0x88, 0xb2, 0xdd, 0x7b, 0xd2, 0x99, 0xb9, 0x2e, 0xdf, 0x44, 0x8a, 0x78, 0x25, 0xe1, 0x32,
+	0xd7, 0x66, 0xc1, 0x33, 0x10, 0x09, 0x04, 0xdf, 0x2b, 0x14, 0x1c, 0x93, 0xc8, 0x80, 0x55, 0x97,
+	0x98, 0x74, 0x1c, 0x12, 0xdb, 0x78, 0xe9, 0x90, 0x91, 0x2d, 0x2e, 0x30, 0xea, 0xb9, 0x4f, 0x07,
+	0xb1, 0x5a, 0x5a, 0x8f, 0xf9, 0x68, 0x5c, 0x8f, 0xe1, 0x04, 0xcd, 0x32, 0x07, 0xf1, 0x85, 0x56,
+	0xa1, 0xd6, 0x7f, 0xde, 0x1f, 0x74, 0x0f, 0x8c, 0x83, 0xc3, 0x9d, 0xae, 0xac, 0xe1, 0xea, 0x77,
+	0xb1, 0x20, 0x15, 0xd6, 0x3e, 0x38, 0x1c, 0xb4, 0xf7, 0x8d, 0xc1, 0x5e, 0xe7, 0x69, 0x5f, 0x2b,
+	0xa0, 0x4d, 0x58, 0x1b, 0xec, 0xe2, 0xc3, 0xc1, 0x60, 0xbf, 0xbb, 0x63, 0x1c, 0x75, 0xf1, 0xde,
+	0xe1, 0x4e, 0x5f, 0x53, 0xd9, 0x7d, 0xeb, 0x84, 0x3d, 0xd8, 0x3b, 0xe8, 0x6a, 0x45, 0x56, 0xb5,
+	0x73, 0xd4, 0xc5, 0x9d, 0x6e, 0x6f, 0xa0, 0x95, 0xf4, 0x6f, 0x54, 0xa8, 0xa5, 0xac, 0xc8, 0x1c,
+	0x39, 0xa4, 0xe2, 0x18, 0x52, 0xc4, 0xec, 0x93, 0xbf, 0x39, 0x9b, 0xd6, 0xa9, 0xb0, 0x4e, 0x11,
+	0x0b, 0x82, 0x1f, 0x3d, 0xcc, 0x8b, 0xd4, 0x3a, 0x2f, 0xe2, 0x8a, 0x6b, 0x5e, 0x08, 0x90, 0x1f,
+	0xc0, 0xf2, 0x19, 0x09, 0x3d, 0x32, 0x92, 0xed, 0xc2, 0x22, 0x35, 0xc1, 0x13, 0x5d, 0xb6, 0x40,
+	0x93, 0x5d, 0x26, 0x30, 0xc2, 0x1c, 0x75, 0xc1, 0x3f, 0x88, 0xc1, 0x36, 0xa0, 0x24, 0x9a, 0x97,
+	0xc4, 0xfc, 0x9c, 0x60, 0x61, 0x8a, 0xbe, 0x36, 0x03, 0x9e, 0xf2, 0x15, 0x31, 0xff, 0x46, 0x27,
+	0xb3, 0xf6, 0x29, 0x73, 0xfb, 0xdc, 0x9b, 0xdf, 0x9d, 0xdf, 0x64, 0xa2, 0xd3, 0xc4, 0x44, 0x4b,
+	0xa0, 0xe2, 0xb8, 0xf0, 0xa9, 0xd3, 0xee, 0xec, 0x32, 0xb3, 0xac, 0x40, 0xf5, 0xa0, 0xfd, 0x0b,
+	0xe3, 0xb8, 0xcf, 0x6f, 0xbf, 0x91, 0x06, 0xcb, 0x4f, 0xbb, 0xb8, 0xd7, 0xdd, 0x97, 0x1c, 0x15,
+	0x6d, 0x80, 0x26, 0x39, 0x93, 0x7e, 0x45, 0x86, 0x20, 0x3e, 0x4b, 0xec, 0xb6, 0xb4, 0xff, 0xac,
+	0x7d, 0xa4, 0x95, 0xf5, 0xff, 0x2e, 0xc0, 0xaa, 0x08, 0x0b, 0x49, 0x89, 0xc6, 0x9b, 0x5f, 0xcc,
+	0xd2, 0xb7, 0x41, 0x85, 0xec, 0x6d, 0x50, 0x9c, 0x84, 0xf2, 0xa8, 0xae, 0x4e, 0x92, 0x50, 0x7e,
+	0x8b, 0x94, 0xd9, 0xf1, 0x8b, 0xf3, 0xec, 0xf8, 0x0d, 0x58, 0x72, 0x09, 0x4d, 0xec, 0x56, 0xc5,
+	0x31, 0x89, 0x1c, 0xa8, 0x99, 0x9e, 0xe7, 0x47, 0xa6, 0xb8, 0x62, 0x2d, 0xcf, 0x15, 0x0c, 0xa7,
+	0xfe, 0x71, 0xab, 0x3d, 0x41, 0x12, 0x1b, 0x73, 0x1a, 0xbb, 0xf9, 0x73, 0xd0, 0xa6, 0x3b, 0xcc,
+	0x13, 0x0e, 0x7f, 0xf4, 0x93, 0x49, 0x34, 0x24, 0x6c, 0x5d, 0xc8, 0xb7, 0x09, 0xed, 0x06, 0x23,
+	0xf0, 0x71, 0xaf, 0xb7, 0xd7, 0x7b, 0xa2, 0x29, 0xec, 0x71, 0xa3, 0xfb, 0x8b, 0x3d, 0x56, 0x4c,
+	0x59, 0xd8, 0xfe, 0x76, 0x1d, 0xca, 0x42, 0x48, 0xf4, 0xb5, 0xcc, 0x04, 0xd2, 0xe5, 0xbf, 0xe8,
+	0xe7, 0x73, 0x67, 0xd4, 0x99, 0x92, 0xe2, 0xe6, 0xa3, 0x85, 0xc7, 0xcb, 0x57, 0xd0, 0x1b, 0xe8,
+	0x6f, 0x14, 0x58, 0xce, 0xbc, 0xe0, 0xe5, 0xbd, 0x62, 0xbe, 0xa2, 0xda, 0xb8, 0xf9, 0xb3, 0x85,
+	0xc6, 0x26, 0xb2, 0xfc, 0x46, 0x81, 0x5a, 0xaa, 0xce, 0x16, 0xdd, 0x5b, 0xa4, 0x36, 0x57, 0x48,
+	0x72, 0x7f, 0xf1, 0xb2, 0x5e, 0xfd, 0xc6, 0xc7, 0x0a, 0xfa, 0x6b, 0x05, 0x6a, 0xa9, 0x8a, 0xd3,
+	0xdc, 0xa2, 0xcc, 0xd6, 0xc7, 0x36, 0xef, 0x2f, 0x32, 0x34, 0xd1, 0xc9, 0x5f, 0x2a, 0x50, 0x4d,
+	0xaa, 0x47, 0xd1, 0xdd, 0xf9, 0xeb, 0x4d, 0x85, 0x10, 0x9f, 0x2e, 0x5a, 0xa8, 0xaa, 0xdf, 0x40,
+	0x7f, 0x0e, 0x95, 0xb8, 0xd4, 0x12, 0xe5, 0x8d, 0x5e, 0x53, 0x75, 0x9c, 0xcd, 0xbb, 0x73, 0x8f,
+	0x4b, 0x4f, 0x1f, 0xd7, 0x3f, 0xe6, 0x9e, 0x7e, 0xaa, 0x52, 0xb3, 0x79, 0x77, 0xee, 0x71, 0xc9,
+	0xf4, 0xcc, 0x13, 0x52, 0x65, 0x92, 0xb9, 0x3d, 0x61, 0xb6, 0x3e, 0xb3, 0x79, 0x7f, 0x91, 0xa1,
+	0x19, 0x41, 0x52, 0x85, 0x96, 0xb9, 0x05, 0x99, 0x2d, 0xe6, 0x6c, 0xde, 0x5f, 0x64, 0x68, 0x22,
+	0xc8, 0xaf, 0x95, 0xf4, 0xb9, 0xe0, 0xee, 0xdc, 0xf5, 0x84, 0x73, 0xba, 0xe4, 0x4c, 0x45, 0x23,
+	0x5f, 0xa0, 0xbf, 0x96, 0xb7, 0x18, 0xa2, 0x1c, 0x11, 0xcd, 0x03, 0x96, 0xa9, 0x60, 0x6c, 0x7e,
+	0xb2, 0x58, 0xb0, 0xe1, 0x42, 0xfc, 0x95, 0x02, 0x30, 0x29, 0x5c, 0xcc, 0x2d, 0xc4, 0x4c, 0xc5,
+	0x64, 0xf3, 0xde, 0x02, 0x23, 0xd3, 0x0b, 0x24, 0x2e, 0xac, 0xca, 0xbd, 0x40, 0xa6, 0x0a, 0x2b,
+	0x9b, 0x77, 0xe7, 0x1e, 0x97, 0x4c, 0xff, 0xcf, 0x0a, 0xac, 0xcd, 0x14, 0x76, 0xa1, 0x47, 0xd7,
+	0xac, 0xed, 0x6b, 0x7e, 0xb6, 0x38, 0x40, 0x2c, 0xda, 0x96, 0xf2, 0xb1, 0x82, 0xfe, 0x56, 0x81,
+	0x95, 0x6c, 0x1d, 0x45, 0xee, 0x28, 0x75, 0x45, 0x89, 0x58, 0xf3, 0xc1, 0x62, 0x83, 0x13, 0x6d,
+	0xfd, 0xbd, 0x02, 0x75, 0xb9, 0xbe, 0x63, 0x79, 0x1e, 0xcc, 0xb7, 0x2d, 0x4c, 0x09, 0xf4, 0x70,
+	0xc1, 0xd1, 0x19, 0x89, 0xb2, 0x45, 0x52, 0xb9, 0x25, 0xba, 0xb2, 0x54, 0xab, 0xf9, 0x70, 0xc1,
+	0xd1, 0x99, 0x9d, 0x2e, 0x55, 0x21, 0x35, 0x47, 0xf0, 0x9d, 0x2e, 0xe8, 0x6a, 0xde, 0x5f, 0x64,
+	0x68, 0x2c, 0xc8, 0xe7, 0x4b, 0x7f, 0x5c, 0x12, 0x89, 0x6d, 0x99, 0xff, 0xfc, 0xf4, 0xff, 0x06,
+	0x00, 0xf2, 0xd1, 0xa8, 0x0a, 0xc0, 0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -3986,6 +4197,14 @@ type DriverClient interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(ctx context.Context, in *DestroyNetworkRequest, opts ...grpc.CallOption) (*DestroyNetworkResponse, error)
+	// CheckpointTask checkpoints a running task to the given directory, which
+	// stops the task. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the checkpoint in the given directory,
+	// instead of starting it anew. This rpc is only implemented if the driver
+	// sets the checkpoint capability.
+	RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error)
 }
 
 type driverClient struct {
@@ -4231,6 +4450,24 @@ func (c *driverClient) DestroyNetwork(ctx context.Context, in *DestroyNetworkReq
 	return out, nil
 }
 
+func (c *driverClient) CheckpointTask(ctx context.Context, in *CheckpointTaskRequest, opts ...grpc.CallOption) (*CheckpointTaskResponse, error) {
+	out := new(CheckpointTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *driverClient) RestoreTask(ctx context.Context, in *RestoreTaskRequest, opts ...grpc.CallOption) (*RestoreTaskResponse, error) {
+	out := new(RestoreTaskResponse)
+	err := c.cc.Invoke(ctx, "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DriverServer is the server API for Driver service.
 type DriverServer interface {
 	// TaskConfigSchema returns the schema for parsing the driver
@@ -4286,6 +4523,14 @@ type DriverServer interface {
 	// DestroyNetwork destroys a previously created network. This rpc is only
 	// implemented if the driver needs to manage network namespace creation.
 	DestroyNetwork(context.Context, *DestroyNetworkRequest) (*DestroyNetworkResponse, error)
+	// CheckpointTask checkpoints a running task to the given directory, which
+	// stops the task. This rpc is only implemented if the driver sets the
+	// checkpoint capability.
+	CheckpointTask(context.Context, *CheckpointTaskRequest) (*CheckpointTaskResponse, error)
+	// RestoreTask starts a task from the checkpoint in the given directory,
+	// instead of starting it anew. This rpc is only implemented if the driver
+	// sets the checkpoint capability.
+	RestoreTask(context.Context, *RestoreTaskRequest) (*RestoreTaskResponse, error)
 }
 
 // UnimplementedDriverServer can be embedded to have forward compatible implementations.
@@ -4340,6 +4585,12 @@ func (*UnimplementedDriverServer) CreateNetwork(ctx context.Context, req *Create
 func (*UnimplementedDriverServer) DestroyNetwork(ctx context.Context, req *DestroyNetworkRequest) (*DestroyNetworkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DestroyNetwork not implemented")
 }
+func (*UnimplementedDriverServer) CheckpointTask(ctx context.Context, req *CheckpointTaskRequest) (*CheckpointTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckpointTask not implemented")
+}
+func (*UnimplementedDriverServer) RestoreTask(ctx context.Context, req *RestoreTaskRequest) (*RestoreTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTask not implemented")
+}
 
 func RegisterDriverServer(s *grpc.Server, srv DriverServer) {
 	s.RegisterService(&_Driver_serviceDesc, srv)
@@ -4650,6 +4901,42 @@ func _Driver_DestroyNetwork_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Driver_CheckpointTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckpointTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).CheckpointTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/CheckpointTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).CheckpointTask(ctx, req.(*CheckpointTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Driver_RestoreTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DriverServer).RestoreTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hashicorp.nomad.plugins.drivers.proto.Driver/RestoreTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DriverServer).RestoreTask(ctx, req.(*RestoreTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Driver_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hashicorp.nomad.plugins.drivers.proto.Driver",
 	HandlerType: (*DriverServer)(nil),
@@ -4702,6 +4989,14 @@ var _Driver_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DestroyNetwork",
 			Handler:    _Driver_DestroyNetwork_Handler,
 		},
+		{
+			MethodName: "CheckpointTask",
+			Handler:    _Driver_CheckpointTask_Handler,
+		},
+		{
+			MethodName: "RestoreTask",
+			Handler:    _Driver_RestoreTask_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -28,6 +28,7 @@ const (
 	FilterConstraintDrivers                        = "missing drivers"
 	FilterConstraintDevices                        = "missing devices"
 	FilterConstraintsCSIPluginTopology             = "did not meet topology requirement"
+	FilterConstraintNodePool                       = "node is not in the job's node pool"
 )
 
 var (
@@ -449,6 +450,33 @@ func (c *NetworkChecker) hasNetwork(option *structs.Node) bool {
 	return false
 }
 
+// NodePoolChecker is a FeasibilityChecker which returns whether a node is a
+// member of the node pool targeted by the job.
+type NodePoolChecker struct {
+	ctx  Context
+	pool string
+}
+
+// NewNodePoolChecker creates a NodePoolChecker for the given node pool
+func NewNodePoolChecker(ctx Context, pool string) *NodePoolChecker {
+	return &NodePoolChecker{
+		ctx:  ctx,
+		pool: pool,
+	}
+}
+
+func (c *NodePoolChecker) SetNodePool(pool string) {
+	c.pool = pool
+}
+
+func (c *NodePoolChecker) Feasible(option *structs.Node) bool {
+	if structs.NodePoolMatches(c.pool, option.NodePool) {
+		return true
+	}
+	c.ctx.Metrics().FilterNode(option, FilterConstraintNodePool)
+	return false
+}
+
 // DriverChecker is a FeasibilityChecker which returns whether a node has the
 // drivers necessary to scheduler a task group.
 type DriverChecker struct {
@@ -724,6 +752,121 @@ func (iter *DistinctPropertyIterator) Reset() {
 	}
 }
 
+// SpreadSkewIterator is a FeasibleIterator which returns nodes that pass the
+// spreads with a max_skew enforced as a constraint. The constraint ensures that
+// the number of allocations of a task group placed in the domains of the spread
+// attributes don't differ by more than the max_skew.
+type SpreadSkewIterator struct {
+	ctx    Context
+	source FeasibleIterator
+	tg     *structs.TaskGroup
+	job    *structs.Job
+
+	// nodes are the base nodes whose domains allocations are spread across
+	nodes []*structs.Node
+
+	hasSkewConstraints bool
+	groupSkewSets      map[string][]*skewSet
+}
+
+// NewSpreadSkewIterator creates a SpreadSkewIterator from a source.
+func NewSpreadSkewIterator(ctx Context, source FeasibleIterator) *SpreadSkewIterator {
+	return &SpreadSkewIterator{
+		ctx:           ctx,
+		source:        source,
+		groupSkewSets: make(map[string][]*skewSet),
+	}
+}
+
+// SetNodes sets the base nodes whose domains allocations are spread across.
+func (iter *SpreadSkewIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	for _, sets := range iter.groupSkewSets {
+		for _, ss := range sets {
+			ss.SetNodes(nodes)
+		}
+	}
+}
+
+func (iter *SpreadSkewIterator) SetJob(job *structs.Job) {
+	iter.job = job
+
+	// Skew sets count the allocations of a single task group, so the job
+	// level spreads are built along with the task group ones
+	iter.groupSkewSets = make(map[string][]*skewSet)
+}
+
+func (iter *SpreadSkewIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+
+	// Build the skew sets at the taskgroup level
+	if _, ok := iter.groupSkewSets[tg.Name]; !ok {
+		var jobSpreads []*structs.Spread
+		if iter.job != nil {
+			jobSpreads = iter.job.Spreads
+		}
+
+		var sets []*skewSet
+		for _, spreads := range [][]*structs.Spread{jobSpreads, tg.Spreads} {
+			for _, spread := range spreads {
+				if !spread.HardMaxSkew() {
+					continue
+				}
+
+				ss := newSkewSet(iter.ctx, iter.job, tg, spread)
+				ss.SetNodes(iter.nodes)
+				sets = append(sets, ss)
+			}
+		}
+		iter.groupSkewSets[tg.Name] = sets
+	}
+
+	// Check if there is a max_skew constraint
+	iter.hasSkewConstraints = len(iter.groupSkewSets[tg.Name]) != 0
+}
+
+func (iter *SpreadSkewIterator) Next() *structs.Node {
+	for {
+		// Get the next option from the source
+		option := iter.source.Next()
+
+		// Hot path if there is nothing to check
+		if option == nil || !iter.hasSkewConstraints {
+			return option
+		}
+
+		// Check if the constraints are met
+		if !iter.satisfiesMaxSkew(option) {
+			continue
+		}
+
+		return option
+	}
+}
+
+// satisfiesMaxSkew returns whether the option satisfies the max_skew of the
+// spreads of the task group. If not it will be filtered.
+func (iter *SpreadSkewIterator) satisfiesMaxSkew(option *structs.Node) bool {
+	for _, ss := range iter.groupSkewSets[iter.tg.Name] {
+		if satisfies, reason := ss.SatisfiesMaxSkew(option); !satisfies {
+			iter.ctx.Metrics().FilterNode(option, reason)
+			return false
+		}
+	}
+
+	return true
+}
+
+func (iter *SpreadSkewIterator) Reset() {
+	iter.source.Reset()
+
+	for _, sets := range iter.groupSkewSets {
+		for _, ss := range sets {
+			ss.PopulateProposed()
+		}
+	}
+}
+
 // ConstraintChecker is a FeasibilityChecker which returns nodes that match a
 // given set of constraints. This is used to filter on job, task group, and task
 // constraints.
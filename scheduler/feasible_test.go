@@ -682,6 +682,50 @@ func TestNetworkChecker_bridge_upgrade_path(t *testing.T) {
 	})
 }
 
+func TestNodePoolChecker(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+		mock.Node(),
+	}
+	nodes[0].NodePool = ""
+	nodes[1].NodePool = structs.NodePoolDefault
+	nodes[2].NodePool = "gpu"
+
+	cases := []struct {
+		Pool   string
+		Result []bool
+	}{
+		{
+			Pool:   "",
+			Result: []bool{true, true, false},
+		},
+		{
+			Pool:   structs.NodePoolDefault,
+			Result: []bool{true, true, false},
+		},
+		{
+			Pool:   "gpu",
+			Result: []bool{false, false, true},
+		},
+		{
+			Pool:   structs.NodePoolAll,
+			Result: []bool{true, true, true},
+		},
+	}
+
+	checker := NewNodePoolChecker(ctx, "")
+	for _, c := range cases {
+		checker.SetNodePool(c.Pool)
+		for i, node := range nodes {
+			require.Equal(t, c.Result[i], checker.Feasible(node), "pool %q node %d", c.Pool, i)
+		}
+	}
+}
+
 func TestDriverChecker_DriverInfo(t *testing.T) {
 	ci.Parallel(t)
 
@@ -2302,6 +2346,94 @@ func TestDistinctPropertyIterator_TaskGroupDistinctProperty(t *testing.T) {
 	}
 }
 
+func TestSpreadSkewIterator_NestedMaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	// Create nodes in two racks of zone a, one rack of zone b, and a node
+	// missing the zone
+	domains := [][2]string{{"a", "r1"}, {"a", "r2"}, {"b", "r3"}, {"", "r4"}}
+	var nodes []*structs.Node
+	for i, domain := range domains {
+		node := mock.Node()
+		if domain[0] != "" {
+			node.Meta["zone"] = domain[0]
+		}
+		node.Meta["rack"] = domain[1]
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{
+		{
+			Attribute:         "${meta.zone}",
+			Weight:            50,
+			MaxSkew:           1,
+			WhenUnsatisfiable: structs.SpreadWhenUnsatisfiableDoNotSchedule,
+			NestedAttributes:  []string{"${meta.rack}"},
+		},
+		// Soft spreads don't filter nodes
+		{
+			Attribute: "${meta.rack}",
+			Weight:    50,
+			MaxSkew:   1,
+		},
+	}
+
+	// Place an existing alloc in rack r1 and a proposed one in rack r3
+	existing := mock.Alloc()
+	existing.Job = job
+	existing.JobID = job.ID
+	existing.NodeID = nodes[0].ID
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{existing}))
+
+	proposed := mock.Alloc()
+	proposed.Job = job
+	proposed.JobID = job.ID
+	proposed.NodeID = nodes[2].ID
+	ctx.Plan().NodeAllocation[nodes[2].ID] = []*structs.Allocation{proposed}
+
+	static := NewStaticIterator(ctx, nodes)
+	skewIter := NewSpreadSkewIterator(ctx, static)
+	skewIter.SetNodes(nodes)
+	skewIter.SetJob(job)
+	skewIter.SetTaskGroup(tg)
+
+	// The zones are balanced, so rack r1 is filtered for being skewed
+	// within zone a, and the node missing the zone is filtered
+	nodeIDs := func(nodes ...*structs.Node) []string {
+		ids := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			ids = append(ids, node.ID)
+		}
+		return ids
+	}
+	out := collectFeasible(skewIter)
+	require.ElementsMatch(t, nodeIDs(nodes[1], nodes[2]), nodeIDs(out...))
+
+	// Placing in rack r2 skews zone a, which filters all of its racks
+	placed := mock.Alloc()
+	placed.Job = job
+	placed.JobID = job.ID
+	placed.NodeID = nodes[1].ID
+	ctx.Plan().NodeAllocation[nodes[1].ID] = []*structs.Allocation{placed}
+	skewIter.Reset()
+
+	out = collectFeasible(skewIter)
+	require.Equal(t, nodeIDs(nodes[2]), nodeIDs(out...))
+
+	// Stopping the existing alloc rebalances the zones, and leaves rack r1
+	// as the least used rack of zone a
+	ctx.Plan().NodeUpdate[nodes[0].ID] = []*structs.Allocation{existing}
+	skewIter.Reset()
+
+	out = collectFeasible(skewIter)
+	require.ElementsMatch(t, nodeIDs(nodes[0], nodes[2]), nodeIDs(out...))
+}
+
 func collectFeasible(iter FeasibleIterator) (out []*structs.Node) {
 	for {
 		next := iter.Next()
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -98,6 +99,9 @@ type GenericScheduler struct {
 	planner  Planner
 	batch    bool
 
+	// scorePlugins are the scoring plugins the stack ranks nodes with
+	scorePlugins []*config.ScorePluginConfig
+
 	eval       *structs.Evaluation
 	job        *structs.Job
 	plan       *structs.Plan
@@ -117,25 +121,29 @@ type GenericScheduler struct {
 }
 
 // NewServiceScheduler is a factory function to instantiate a new service scheduler
-func NewServiceScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner) Scheduler {
+func NewServiceScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner,
+	scorePlugins []*config.ScorePluginConfig) Scheduler {
 	s := &GenericScheduler{
-		logger:   logger.Named("service_sched"),
-		eventsCh: eventsCh,
-		state:    state,
-		planner:  planner,
-		batch:    false,
+		logger:       logger.Named("service_sched"),
+		eventsCh:     eventsCh,
+		state:        state,
+		planner:      planner,
+		batch:        false,
+		scorePlugins: scorePlugins,
 	}
 	return s
 }
 
 // NewBatchScheduler is a factory function to instantiate a new batch scheduler
-func NewBatchScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner) Scheduler {
+func NewBatchScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner,
+	scorePlugins []*config.ScorePluginConfig) Scheduler {
 	s := &GenericScheduler{
-		logger:   logger.Named("batch_sched"),
-		eventsCh: eventsCh,
-		state:    state,
-		planner:  planner,
-		batch:    true,
+		logger:       logger.Named("batch_sched"),
+		eventsCh:     eventsCh,
+		state:        state,
+		planner:      planner,
+		batch:        true,
+		scorePlugins: scorePlugins,
 	}
 	return s
 }
@@ -164,7 +172,8 @@ func (s *GenericScheduler) Process(eval *structs.Evaluation) (err error) {
 		structs.EvalTriggerPeriodicJob, structs.EvalTriggerMaxPlans,
 		structs.EvalTriggerDeploymentWatcher, structs.EvalTriggerRetryFailedAlloc,
 		structs.EvalTriggerFailedFollowUp, structs.EvalTriggerPreemption,
-		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect:
+		structs.EvalTriggerScaling, structs.EvalTriggerMaxDisconnectTimeout, structs.EvalTriggerReconnect,
+		structs.EvalTriggerRebalance:
 	default:
 		desc := fmt.Sprintf("scheduler cannot handle '%s' evaluation reason",
 			eval.TriggeredBy)
@@ -227,6 +236,10 @@ func (s *GenericScheduler) createBlockedEval(planFailure bool) error {
 	}
 
 	s.blocked = s.eval.CreateBlockedEval(classEligibility, escaped, e.QuotaLimitReached(), s.failedTGAllocs)
+	if s.job != nil {
+		// Queue the blocked eval by the submit time of the job.
+		s.blocked.JobSubmitTime = s.job.SubmitTime
+	}
 	if planFailure {
 		s.blocked.TriggeredBy = structs.EvalTriggerMaxPlans
 		s.blocked.StatusDescription = blockedEvalMaxPlanDesc
@@ -259,6 +272,17 @@ func (s *GenericScheduler) process() (bool, error) {
 	// Create a plan
 	s.plan = s.eval.MakePlan(s.job)
 
+	// The placements of a gang are all-or-nothing, so the plan applier must
+	// reject the whole plan instead of committing a part of a gang
+	if !stopped {
+		for _, tg := range s.job.TaskGroups {
+			if tg.Gang {
+				s.plan.AllAtOnce = true
+				break
+			}
+		}
+	}
+
 	if !s.batch {
 		// Get any existing deployment
 		s.deployment, err = s.state.LatestDeploymentByJobID(ws, s.eval.Namespace, s.eval.JobID)
@@ -275,6 +299,7 @@ func (s *GenericScheduler) process() (bool, error) {
 
 	// Construct the placement stack
 	s.stack = NewGenericStack(s.batch, s.ctx)
+	s.stack.SetScorePlugins(s.scorePlugins)
 	if !s.job.Stopped() {
 		s.stack.SetJob(s.job)
 	}
@@ -439,7 +464,7 @@ func (s *GenericScheduler) computeJobAllocs() error {
 				s.queuedAllocs[tg.Name] = 0
 			}
 		}
-		return nil
+		return s.annotateSpreadDistributions()
 	}
 
 	// Compute the placements
@@ -454,7 +479,47 @@ func (s *GenericScheduler) computeJobAllocs() error {
 		s.queuedAllocs[p.placeTaskGroup.Name] += 1
 		destructive = append(destructive, p)
 	}
-	return s.computePlacements(destructive, place)
+	if err := s.computePlacements(destructive, place); err != nil {
+		return err
+	}
+	return s.annotateSpreadDistributions()
+}
+
+// annotateSpreadDistributions adds the distribution of the allocations of each
+// task group across the domains of its max_skew spreads to the plan
+// annotations, once the plan is applied.
+func (s *GenericScheduler) annotateSpreadDistributions() error {
+	if s.plan.Annotations == nil || s.job == nil {
+		return nil
+	}
+
+	var nodes []*structs.Node
+	for _, tg := range s.job.TaskGroups {
+		for _, spreads := range [][]*structs.Spread{s.job.Spreads, tg.Spreads} {
+			for _, spread := range spreads {
+				if spread.MaxSkew == 0 {
+					continue
+				}
+
+				if nodes == nil {
+					var err error
+					nodes, _, _, err = readyNodesInDCs(s.state, s.job.Datacenters, s.job.NodePool)
+					if err != nil {
+						return err
+					}
+				}
+
+				ss := newSkewSet(s.ctx, s.job, tg, spread)
+				ss.SetNodes(nodes)
+				if s.plan.Annotations.SpreadDistributions == nil {
+					s.plan.Annotations.SpreadDistributions = make(map[string][]*structs.SpreadDistribution)
+				}
+				s.plan.Annotations.SpreadDistributions[tg.Name] = append(
+					s.plan.Annotations.SpreadDistributions[tg.Name], ss.Distribution())
+			}
+		}
+	}
+	return nil
 }
 
 // downgradedJobForPlacement returns the job appropriate for non-canary placement replacement
@@ -498,7 +563,7 @@ func (s *GenericScheduler) downgradedJobForPlacement(p placementResult) (string,
 // destructive updates to place and the set of new placements to place.
 func (s *GenericScheduler) computePlacements(destructive, place []placementResult) error {
 	// Get the base nodes
-	nodes, _, byDC, err := readyNodesInDCs(s.state, s.job.Datacenters)
+	nodes, _, byDC, err := readyNodesInDCs(s.state, s.job.Datacenters, s.job.NodePool)
 	if err != nil {
 		return err
 	}
@@ -514,170 +579,324 @@ func (s *GenericScheduler) computePlacements(destructive, place []placementResul
 	// Capture current time to use as the start time for any rescheduled allocations
 	now := time.Now()
 
+	// Track the placements of gang scheduled task groups so they can be rolled
+	// back if any allocation of the group doesn't fit
+	gangs := make(map[string]*gangPlacement)
+
 	// Have to handle destructive changes first as we need to discount their
 	// resources. To understand this imagine the resources were reduced and the
-	// count was scaled up.
-	for _, results := range [][]placementResult{destructive, place} {
-		for _, missing := range results {
-			// Get the task group
-			tg := missing.TaskGroup()
-
-			var downgradedJob *structs.Job
-
-			if missing.DowngradeNonCanary() {
-				jobDeploymentID, job, err := s.downgradedJobForPlacement(missing)
-				if err != nil {
-					return err
-				}
+	// count was scaled up. The placements of a gang are kept together, so a
+	// failed gang is rolled back before the next group is placed.
+	for _, missing := range groupGangPlacements(destructive, place) {
+		// Get the task group
+		tg := missing.TaskGroup()
 
-				// Defensive check - if there is no appropriate deployment for this job, use the latest
-				if job != nil && job.Version >= missing.MinJobVersion() && job.LookupTaskGroup(tg.Name) != nil {
-					tg = job.LookupTaskGroup(tg.Name)
-					downgradedJob = job
-					deploymentID = jobDeploymentID
-				} else {
-					jobVersion := -1
-					if job != nil {
-						jobVersion = int(job.Version)
-					}
-					s.logger.Debug("failed to find appropriate job; using the latest", "expected_version", missing.MinJobVersion, "found_version", jobVersion)
-				}
-			}
+		var downgradedJob *structs.Job
 
-			// Check if this task group has already failed
-			if metric, ok := s.failedTGAllocs[tg.Name]; ok {
-				metric.CoalescedFailures += 1
-				metric.ExhaustResources(tg)
-				continue
+		if missing.DowngradeNonCanary() {
+			jobDeploymentID, job, err := s.downgradedJobForPlacement(missing)
+			if err != nil {
+				return err
 			}
 
-			// Use downgraded job in scheduling stack to honor
-			// old job resources and constraints
-			if downgradedJob != nil {
-				s.stack.SetJob(downgradedJob)
+			// Defensive check - if there is no appropriate deployment for this job, use the latest
+			if job != nil && job.Version >= missing.MinJobVersion() && job.LookupTaskGroup(tg.Name) != nil {
+				tg = job.LookupTaskGroup(tg.Name)
+				downgradedJob = job
+				deploymentID = jobDeploymentID
+			} else {
+				jobVersion := -1
+				if job != nil {
+					jobVersion = int(job.Version)
+				}
+				s.logger.Debug("failed to find appropriate job; using the latest", "expected_version", missing.MinJobVersion, "found_version", jobVersion)
 			}
+		}
 
-			// Find the preferred node
-			preferredNode, err := s.findPreferredNode(missing)
-			if err != nil {
-				return err
+		var gang *gangPlacement
+		if tg.Gang {
+			gang = gangs[tg.Name]
+			if gang == nil {
+				gang = &gangPlacement{tg: tg}
+				gangs[tg.Name] = gang
 			}
+			gang.size++
+		}
 
-			// Check if we should stop the previous allocation upon successful
-			// placement of its replacement. This allow atomic placements/stops. We
-			// stop the allocation before trying to find a replacement because this
-			// frees the resources currently used by the previous allocation.
-			stopPrevAlloc, stopPrevAllocDesc := missing.StopPreviousAlloc()
-			prevAllocation := missing.PreviousAllocation()
-			if stopPrevAlloc {
-				s.plan.AppendStoppedAlloc(prevAllocation, stopPrevAllocDesc, "", "")
-			}
+		// Check if this task group has already failed
+		if metric, ok := s.failedTGAllocs[tg.Name]; ok {
+			metric.CoalescedFailures += 1
+			metric.ExhaustResources(tg)
+			continue
+		}
 
-			// Compute penalty nodes for rescheduled allocs
-			selectOptions := getSelectOptions(prevAllocation, preferredNode)
-			selectOptions.AllocName = missing.Name()
-			option := s.selectNextOption(tg, selectOptions)
+		// Use downgraded job in scheduling stack to honor
+		// old job resources and constraints
+		if downgradedJob != nil {
+			s.stack.SetJob(downgradedJob)
+		}
 
-			// Store the available nodes by datacenter
-			s.ctx.Metrics().NodesAvailable = byDC
+		// Find the preferred node
+		preferredNode, err := s.findPreferredNode(missing)
+		if err != nil {
+			return err
+		}
 
-			// Compute top K scoring node metadata
-			s.ctx.Metrics().PopulateScoreMetaData()
+		// Check if we should stop the previous allocation upon successful
+		// placement of its replacement. This allow atomic placements/stops. We
+		// stop the allocation before trying to find a replacement because this
+		// frees the resources currently used by the previous allocation.
+		stopPrevAlloc, stopPrevAllocDesc := missing.StopPreviousAlloc()
+		prevAllocation := missing.PreviousAllocation()
+		if stopPrevAlloc {
+			s.plan.AppendStoppedAlloc(prevAllocation, stopPrevAllocDesc, "", "")
+		}
 
-			// Restore stack job now that placement is done, to use plan job version
-			if downgradedJob != nil {
-				s.stack.SetJob(s.job)
-			}
+		// Compute penalty nodes for rescheduled allocs
+		selectOptions := getSelectOptions(prevAllocation, preferredNode)
+		selectOptions.AllocName = missing.Name()
 
-			// Set fields based on if we found an allocation option
-			if option != nil {
-				resources := &structs.AllocatedResources{
-					Tasks:          option.TaskResources,
-					TaskLifecycles: option.TaskLifecycles,
-					Shared: structs.AllocatedSharedResources{
-						DiskMB: int64(tg.EphemeralDisk.SizeMB),
-					},
-				}
-				if option.AllocResources != nil {
-					resources.Shared.Networks = option.AllocResources.Networks
-					resources.Shared.Ports = option.AllocResources.Ports
-				}
+		// The node an alloc is rebalanced off remains eligible, so exclude
+		// it to avoid placing the replacement back on the same node.
+		rebalancing := s.eval.TriggeredBy == structs.EvalTriggerRebalance &&
+			prevAllocation != nil && prevAllocation.DesiredTransition.ShouldMigrate()
+		if rebalancing {
+			selectOptions.ExcludedNodeIDs = map[string]struct{}{prevAllocation.NodeID: {}}
+		}
+		option := s.selectNextOption(tg, selectOptions)
 
-				// Create an allocation for this
-				alloc := &structs.Allocation{
-					ID:                 uuid.Generate(),
-					Namespace:          s.job.Namespace,
-					EvalID:             s.eval.ID,
-					Name:               missing.Name(),
-					JobID:              s.job.ID,
-					TaskGroup:          tg.Name,
-					Metrics:            s.ctx.Metrics(),
-					NodeID:             option.Node.ID,
-					NodeName:           option.Node.Name,
-					DeploymentID:       deploymentID,
-					TaskResources:      resources.OldTaskResources(),
-					AllocatedResources: resources,
-					DesiredStatus:      structs.AllocDesiredStatusRun,
-					ClientStatus:       structs.AllocClientStatusPending,
-					// SharedResources is considered deprecated, will be removed in 0.11.
-					// It is only set for compat reasons.
-					SharedResources: &structs.Resources{
-						DiskMB:   tg.EphemeralDisk.SizeMB,
-						Networks: resources.Shared.Networks,
-					},
-				}
+		// Store the available nodes by datacenter
+		s.ctx.Metrics().NodesAvailable = byDC
 
-				// If the new allocation is replacing an older allocation then we
-				// set the record the older allocation id so that they are chained
-				if prevAllocation != nil {
-					alloc.PreviousAllocation = prevAllocation.ID
-					if missing.IsRescheduling() {
-						updateRescheduleTracker(alloc, prevAllocation, now)
-					}
+		// Compute top K scoring node metadata
+		s.ctx.Metrics().PopulateScoreMetaData()
 
-					// If the allocation has task handles,
-					// copy them to the new allocation
-					propagateTaskState(alloc, prevAllocation, missing.PreviousLost())
-				}
+		// Restore stack job now that placement is done, to use plan job version
+		if downgradedJob != nil {
+			s.stack.SetJob(s.job)
+		}
 
-				// If we are placing a canary and we found a match, add the canary
-				// to the deployment state object and mark it as a canary.
-				if missing.Canary() && s.deployment != nil {
-					alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
-						Canary: true,
-					}
-				}
+		// Set fields based on if we found an allocation option
+		if option != nil {
+			resources := &structs.AllocatedResources{
+				Tasks:          option.TaskResources,
+				TaskLifecycles: option.TaskLifecycles,
+				Shared: structs.AllocatedSharedResources{
+					DiskMB: int64(tg.EphemeralDisk.SizeMB),
+				},
+			}
+			if option.AllocResources != nil {
+				resources.Shared.Networks = option.AllocResources.Networks
+				resources.Shared.Ports = option.AllocResources.Ports
+			}
 
-				s.handlePreemptions(option, alloc, missing)
+			// Create an allocation for this
+			alloc := &structs.Allocation{
+				ID:                 uuid.Generate(),
+				Namespace:          s.job.Namespace,
+				EvalID:             s.eval.ID,
+				Name:               missing.Name(),
+				JobID:              s.job.ID,
+				TaskGroup:          tg.Name,
+				Metrics:            s.ctx.Metrics(),
+				NodeID:             option.Node.ID,
+				NodeName:           option.Node.Name,
+				DeploymentID:       deploymentID,
+				TaskResources:      resources.OldTaskResources(),
+				AllocatedResources: resources,
+				DesiredStatus:      structs.AllocDesiredStatusRun,
+				ClientStatus:       structs.AllocClientStatusPending,
+				// SharedResources is considered deprecated, will be removed in 0.11.
+				// It is only set for compat reasons.
+				SharedResources: &structs.Resources{
+					DiskMB:   tg.EphemeralDisk.SizeMB,
+					Networks: resources.Shared.Networks,
+				},
+			}
 
-				// Track the placement
-				s.plan.AppendAlloc(alloc, downgradedJob)
+			// If the new allocation is replacing an older allocation then we
+			// set the record the older allocation id so that they are chained
+			if prevAllocation != nil {
+				alloc.PreviousAllocation = prevAllocation.ID
+				if missing.IsRescheduling() {
+					updateRescheduleTracker(alloc, prevAllocation, now)
+				}
 
-			} else {
-				// Lazy initialize the failed map
-				if s.failedTGAllocs == nil {
-					s.failedTGAllocs = make(map[string]*structs.AllocMetric)
+				// If the allocation has task handles,
+				// copy them to the new allocation
+				propagateTaskState(alloc, prevAllocation, missing.PreviousLost())
+			}
+
+			// If we are placing a canary and we found a match, add the canary
+			// to the deployment state object and mark it as a canary.
+			if missing.Canary() && s.deployment != nil {
+				alloc.DeploymentStatus = &structs.AllocDeploymentStatus{
+					Canary: true,
 				}
+			}
 
-				// Update metrics with the resources requested by the task group.
-				s.ctx.Metrics().ExhaustResources(tg)
+			s.handlePreemptions(option, alloc, missing)
 
-				// Track the fact that we didn't find a placement
-				s.failedTGAllocs[tg.Name] = s.ctx.Metrics()
+			// Track the placement
+			s.plan.AppendAlloc(alloc, downgradedJob)
 
-				// If we weren't able to find a replacement for the allocation, back
-				// out the fact that we asked to stop the allocation.
+			if gang != nil {
+				gang.allocs = append(gang.allocs, alloc)
 				if stopPrevAlloc {
-					s.plan.PopUpdate(prevAllocation)
+					gang.stopped = append(gang.stopped, prevAllocation)
 				}
 			}
 
+		} else {
+			// Lazy initialize the failed map
+			if s.failedTGAllocs == nil {
+				s.failedTGAllocs = make(map[string]*structs.AllocMetric)
+			}
+
+			// Update metrics with the resources requested by the task group.
+			s.ctx.Metrics().ExhaustResources(tg)
+
+			// Track the fact that we didn't find a placement
+			s.failedTGAllocs[tg.Name] = s.ctx.Metrics()
+
+			// If we weren't able to find a replacement for the allocation, back
+			// out the fact that we asked to stop the allocation.
+			if stopPrevAlloc {
+				s.plan.PopUpdate(prevAllocation)
+			}
+
+			// A rebalanced allocation keeps running on its node if no
+			// other node fits its replacement.
+			if rebalancing {
+				s.plan.RemoveUpdate(prevAllocation)
+			}
+
+			// Free the resources of the placements of the gang for the
+			// placement of the next groups
+			if gang != nil {
+				s.rollbackGang(gang, s.failedTGAllocs[tg.Name])
+			}
 		}
 	}
 
+	s.recordFailedGangs(gangs)
 	return nil
 }
 
+// gangPlacement tracks the placements made for a gang scheduled task group so
+// they can be rolled back if any allocation of the group fails to fit.
+type gangPlacement struct {
+	tg *structs.TaskGroup
+
+	// size is the number of allocations the group needed to place
+	size int
+
+	// placed is the number of allocations placed before the group failed
+	placed int
+
+	// allocs are the allocations placed for the group
+	allocs []*structs.Allocation
+
+	// stopped are the previous allocations stopped by the placements
+	stopped []*structs.Allocation
+}
+
+// groupGangPlacements returns the destructive updates followed by the
+// placements, with the placements of each gang scheduled task group moved
+// next to its first one, so that a gang is placed or rolled back as a whole
+// before the next group is placed.
+func groupGangPlacements(destructive, place []placementResult) []placementResult {
+	all := make([]placementResult, 0, len(destructive)+len(place))
+	all = append(all, destructive...)
+	all = append(all, place...)
+
+	gangs := make(map[string][]placementResult)
+	for _, missing := range all {
+		if tg := missing.TaskGroup(); tg.Gang {
+			gangs[tg.Name] = append(gangs[tg.Name], missing)
+		}
+	}
+	if len(gangs) == 0 {
+		return all
+	}
+
+	ordered := make([]placementResult, 0, len(all))
+	for _, missing := range all {
+		tg := missing.TaskGroup()
+		if !tg.Gang {
+			ordered = append(ordered, missing)
+			continue
+		}
+		if results, ok := gangs[tg.Name]; ok {
+			ordered = append(ordered, results...)
+			delete(gangs, tg.Name)
+		}
+	}
+	return ordered
+}
+
+// rollbackGang removes the placements of a gang scheduled task group that
+// failed to place one of its allocations from the plan, freeing their
+// resources for the placement of the next groups. The rolled back allocations
+// are counted as failed placements of the group, so that the blocked eval
+// waits for capacity for the whole gang.
+func (s *GenericScheduler) rollbackGang(gang *gangPlacement, metric *structs.AllocMetric) {
+	gang.placed = len(gang.allocs)
+	for _, alloc := range gang.allocs {
+		s.plan.RemoveAlloc(alloc)
+		s.removePreemptionAnnotations(alloc)
+
+		metric.CoalescedFailures += 1
+		metric.ExhaustResources(gang.tg)
+	}
+	for _, stopped := range gang.stopped {
+		s.plan.RemoveUpdate(stopped)
+	}
+	gang.allocs = nil
+	gang.stopped = nil
+}
+
+// recordFailedGangs records the size of the gang scheduled task groups that
+// failed in their metrics, once all their placements have been counted.
+func (s *GenericScheduler) recordFailedGangs(gangs map[string]*gangPlacement) {
+	for name, gang := range gangs {
+		metric, ok := s.failedTGAllocs[name]
+		if !ok {
+			continue
+		}
+
+		metric.FailGang(gang.size, gang.placed)
+		s.logger.Debug("gang placement failed", "task_group", name,
+			"size", gang.size, "placed", gang.placed, "blocked_by", metric.GangBlockedBy)
+	}
+}
+
+// removePreemptionAnnotations removes the annotations of the allocations
+// preempted by a placement that was rolled back.
+func (s *GenericScheduler) removePreemptionAnnotations(alloc *structs.Allocation) {
+	if len(alloc.PreemptedAllocations) == 0 || !s.eval.AnnotatePlan || s.plan.Annotations == nil {
+		return
+	}
+
+	preempted := make(map[string]struct{}, len(alloc.PreemptedAllocations))
+	for _, id := range alloc.PreemptedAllocations {
+		preempted[id] = struct{}{}
+	}
+
+	annotations := s.plan.Annotations
+	kept := annotations.PreemptedAllocs[:0]
+	for _, stub := range annotations.PreemptedAllocs {
+		if _, ok := preempted[stub.ID]; !ok {
+			kept = append(kept, stub)
+		}
+	}
+	annotations.PreemptedAllocs = kept
+
+	if desired, ok := annotations.DesiredTGUpdates[alloc.TaskGroup]; ok {
+		desired.Preemptions -= uint64(len(alloc.PreemptedAllocations))
+	}
+}
+
 // propagateTaskState copies task handles from previous allocations to
 // replacement allocations when the previous allocation is being drained or was
 // lost. Remote task drivers rely on this to reconnect to remote tasks when the
@@ -729,6 +948,12 @@ func getSelectOptions(prevAllocation *structs.Allocation, preferredNode *structs
 		if prevAllocation.ClientStatus == structs.AllocClientStatusFailed {
 			penaltyNodes[prevAllocation.NodeID] = struct{}{}
 		}
+
+		// If alloc is migrated, penalize the node it is migrated off, as it
+		// may still be eligible when the migration rebalances nodes.
+		if prevAllocation.DesiredTransition.ShouldMigrate() {
+			penaltyNodes[prevAllocation.NodeID] = struct{}{}
+		}
 		if prevAllocation.RescheduleTracker != nil {
 			for _, reschedEvent := range prevAllocation.RescheduleTracker.Events {
 				penaltyNodes[reschedEvent.PrevNodeID] = struct{}{}
@@ -221,6 +221,51 @@ func TestServiceSched_JobRegister_MemoryMaxHonored(t *testing.T) {
 	}
 }
 
+func TestServiceSched_JobRegister_NodePool(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create some nodes, half of them in a custom node pool
+	poolNodes := map[string]struct{}{}
+	for i := 0; i < 10; i++ {
+		node := mock.Node()
+		if i%2 == 0 {
+			node.NodePool = "gpu"
+			poolNodes[node.ID] = struct{}{}
+		}
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+
+	// Create a job in the custom node pool
+	job := mock.Job()
+	job.NodePool = "gpu"
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+
+	// Ensure all allocations were placed on nodes in the pool
+	var planned []*structs.Allocation
+	for nodeID, allocList := range h.Plans[0].NodeAllocation {
+		require.Contains(t, poolNodes, nodeID)
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 10)
+}
+
 func TestServiceSched_JobRegister_StickyAllocs(t *testing.T) {
 	ci.Parallel(t)
 
@@ -834,6 +879,81 @@ func TestServiceSched_Spread(t *testing.T) {
 	}
 }
 
+// Test job registration with a hard max_skew spread across zones and the racks
+// nested within them
+func TestServiceSched_SpreadMaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create nodes in unevenly sized zones and racks
+	domains := [][2]string{
+		{"a", "r1"}, {"a", "r1"}, {"a", "r1"}, {"a", "r2"},
+		{"b", "r3"}, {"b", "r3"},
+		{"c", "r4"},
+	}
+	nodeMap := make(map[string]*structs.Node)
+	for _, domain := range domains {
+		node := mock.Node()
+		node.Meta["zone"] = domain[0]
+		node.Meta["rack"] = domain[1]
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+		nodeMap[node.ID] = node
+	}
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 6
+	job.TaskGroups[0].Spreads = []*structs.Spread{{
+		Attribute:         "${meta.zone}",
+		Weight:            100,
+		MaxSkew:           1,
+		WhenUnsatisfiable: structs.SpreadWhenUnsatisfiableDoNotSchedule,
+		NestedAttributes:  []string{"${meta.rack}"},
+	}}
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:    structs.DefaultNamespace,
+		ID:           uuid.Generate(),
+		Priority:     job.Priority,
+		TriggeredBy:  structs.EvalTriggerJobRegister,
+		JobID:        job.ID,
+		Status:       structs.EvalStatusPending,
+		AnnotatePlan: true,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	// Ensure the allocs are spread evenly across zones, then racks
+	counts := make(map[string]int)
+	for nodeID, allocs := range plan.NodeAllocation {
+		node := nodeMap[nodeID]
+		counts[node.Meta["zone"]] += len(allocs)
+		counts[node.Meta["zone"]+"/"+node.Meta["rack"]] += len(allocs)
+	}
+	expected := map[string]int{
+		"a": 2, "a/r1": 1, "a/r2": 1,
+		"b": 2, "b/r3": 2,
+		"c": 2, "c/r4": 2,
+	}
+	require.Equal(t, expected, counts)
+
+	// Ensure the plan annotations show the distribution
+	require.NotNil(t, plan.Annotations)
+	dists := plan.Annotations.SpreadDistributions[job.TaskGroups[0].Name]
+	require.Len(t, dists, 1)
+	require.Equal(t, []string{"${meta.zone}", "${meta.rack}"}, dists[0].Attributes)
+	require.Equal(t, 1, dists[0].MaxSkew)
+	require.Equal(t, expected, dists[0].Counts)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
 // Test job registration with even spread across dc
 func TestServiceSched_EvenSpread(t *testing.T) {
 	ci.Parallel(t)
@@ -1218,6 +1338,202 @@ func TestServiceSched_JobRegister_CreateBlockedEval(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_JobRegister_GangAllOrNothing(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node that fits only some of the gang
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	// Create a job with a gang that doesn't fit and a small group that does
+	job := mock.Job()
+	gang := job.TaskGroups[0]
+	gang.Gang = true
+	gang.Tasks[0].Resources.CPU = 1000
+
+	other := gang.Copy()
+	other.Name = "other"
+	other.Gang = false
+	other.Count = 1
+	other.Tasks[0].Resources.CPU = 100
+	job.TaskGroups = append(job.TaskGroups, other)
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Create a mock evaluation to register the job
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	// Process the evaluation
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// Ensure only the allocation of the other group was planned
+	require.Len(t, h.Plans, 1)
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 1)
+	require.Equal(t, "other", planned[0].TaskGroup)
+
+	// Ensure a single blocked eval was created for the gang
+	require.Len(t, h.CreateEvals, 1)
+	require.Equal(t, structs.EvalStatusBlocked, h.CreateEvals[0].Status)
+
+	// Ensure the failure metrics explain the gang failure
+	require.Len(t, h.Evals, 1)
+	outEval := h.Evals[0]
+	require.Len(t, outEval.FailedTGAllocs, 1)
+	metrics := outEval.FailedTGAllocs[gang.Name]
+	require.NotNil(t, metrics)
+	require.Equal(t, gang.Count-1, metrics.CoalescedFailures)
+	require.Equal(t, gang.Count, metrics.GangSize)
+	require.Equal(t, 3, metrics.GangPlaced)
+	require.Equal(t, "resources cpu", metrics.GangBlockedBy)
+
+	// Ensure the whole gang is queued
+	require.Equal(t, gang.Count, outEval.QueuedAllocations[gang.Name])
+	require.Equal(t, 0, outEval.QueuedAllocations["other"])
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestServiceSched_JobRegister_GangRollbackFreesResources(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a node that fits two of the gang's allocations, or the other
+	// group's allocation alongside one of them
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	gang := job.TaskGroups[0]
+	gang.Gang = true
+	gang.Count = 3
+	gang.Tasks[0].Resources.CPU = 1500
+
+	other := gang.Copy()
+	other.Name = "other"
+	other.Gang = false
+	other.Count = 1
+	other.Tasks[0].Resources.CPU = 2000
+	job.TaskGroups = append(job.TaskGroups, other)
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The other group is placed with the resources of the rolled back gang,
+	// whichever group is placed first
+	require.Len(t, h.Plans, 1)
+	var planned []*structs.Allocation
+	for _, allocList := range h.Plans[0].NodeAllocation {
+		planned = append(planned, allocList...)
+	}
+	require.Len(t, planned, 1)
+	require.Equal(t, "other", planned[0].TaskGroup)
+
+	require.Len(t, h.Evals, 1)
+	outEval := h.Evals[0]
+	require.Len(t, outEval.FailedTGAllocs, 1)
+	metrics := outEval.FailedTGAllocs[gang.Name]
+	require.NotNil(t, metrics)
+	require.Equal(t, gang.Count, metrics.GangSize)
+	require.Equal(t, gang.Count-1, metrics.CoalescedFailures)
+}
+
+func TestServiceSched_JobRegister_GangPlanRejected(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create two nodes that each fit one of the gang's allocations
+	var nodes []*structs.Node
+	for i := 0; i < 2; i++ {
+		node := mock.Node()
+		nodes = append(nodes, node)
+		require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+	}
+	h.Planner = &RejectNodePlan{Harness: h, NodeID: nodes[1].ID}
+
+	job := mock.Job()
+	gang := job.TaskGroups[0]
+	gang.Gang = true
+	gang.Count = 2
+	gang.Tasks[0].Resources.CPU = 2500
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    job.Priority,
+		TriggeredBy: structs.EvalTriggerJobRegister,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The plans are all-at-once, so no part of the gang is committed when one
+	// of its nodes is rejected
+	require.NotEmpty(t, h.Plans)
+	for _, plan := range h.Plans {
+		require.True(t, plan.AllAtOnce)
+	}
+
+	ws := memdb.NewWatchSet()
+	out, err := h.State.AllocsByJob(ws, job.Namespace, job.ID, false)
+	require.NoError(t, err)
+	require.Empty(t, out)
+
+	h.AssertEvalStatus(t, structs.EvalStatusFailed)
+}
+
+func TestGroupGangPlacements(t *testing.T) {
+	ci.Parallel(t)
+
+	job := mock.Job()
+	web := job.TaskGroups[0]
+	gang := web.Copy()
+	gang.Name = "gang"
+	gang.Gang = true
+
+	result := func(tg *structs.TaskGroup, i int) placementResult {
+		return &allocPlaceResult{name: fmt.Sprintf("%s.%s[%d]", job.ID, tg.Name, i), taskGroup: tg}
+	}
+	destructive := []placementResult{result(gang, 0), result(web, 0)}
+	place := []placementResult{result(web, 1), result(gang, 1)}
+
+	var names []string
+	for _, missing := range groupGangPlacements(destructive, place) {
+		names = append(names, missing.Name())
+	}
+	require.Equal(t, []string{
+		job.ID + ".gang[0]",
+		job.ID + ".gang[1]",
+		job.ID + ".web[0]",
+		job.ID + ".web[1]",
+	}, names)
+}
+
 func TestServiceSched_JobRegister_FeasibleAndInfeasibleTG(t *testing.T) {
 	ci.Parallel(t)
 
@@ -3333,6 +3649,95 @@ func TestServiceSched_NodeDrain(t *testing.T) {
 	h.AssertEvalStatus(t, structs.EvalStatusComplete)
 }
 
+func TestServiceSched_Rebalance(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create two eligible nodes
+	node1, node2 := mock.Node(), mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node1))
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node2))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	// Migrate the allocation off the first node, which remains eligible.
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node1.ID
+	alloc.Name = "my-job.web[0]"
+	alloc.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+	require.Len(t, h.Plans, 1)
+	plan := h.Plans[0]
+
+	// The allocation is stopped, and its replacement is placed on the other
+	// node as the node it is migrated off is excluded.
+	require.Len(t, plan.NodeUpdate[node1.ID], 1)
+	require.Len(t, plan.NodeAllocation[node2.ID], 1)
+	require.Equal(t, alloc.ID, plan.NodeAllocation[node2.ID][0].PreviousAllocation)
+
+	h.AssertEvalStatus(t, structs.EvalStatusComplete)
+}
+
+func TestServiceSched_Rebalance_SourceNodeOnly(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+
+	// Create a single eligible node
+	node := mock.Node()
+	require.NoError(t, h.State.UpsertNode(structs.MsgTypeTestSetup, h.NextIndex(), node))
+
+	job := mock.Job()
+	job.TaskGroups[0].Count = 1
+	require.NoError(t, h.State.UpsertJob(structs.MsgTypeTestSetup, h.NextIndex(), job))
+
+	alloc := mock.Alloc()
+	alloc.Job = job
+	alloc.JobID = job.ID
+	alloc.NodeID = node.ID
+	alloc.Name = "my-job.web[0]"
+	alloc.DesiredTransition.Migrate = helper.BoolToPtr(true)
+	require.NoError(t, h.State.UpsertAllocs(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Allocation{alloc}))
+
+	eval := &structs.Evaluation{
+		Namespace:   structs.DefaultNamespace,
+		ID:          uuid.Generate(),
+		Priority:    50,
+		TriggeredBy: structs.EvalTriggerRebalance,
+		JobID:       job.ID,
+		Status:      structs.EvalStatusPending,
+	}
+	require.NoError(t, h.State.UpsertEvals(structs.MsgTypeTestSetup, h.NextIndex(), []*structs.Evaluation{eval}))
+
+	require.NoError(t, h.Process(NewServiceScheduler, eval))
+
+	// The replacement can't be placed back on the node the allocation is
+	// rebalanced off, so the allocation must not be stopped.
+	for _, plan := range h.Plans {
+		require.Empty(t, plan.NodeUpdate)
+		require.Empty(t, plan.NodeAllocation)
+	}
+	require.Len(t, h.Evals, 1)
+	require.Contains(t, h.Evals[0].FailedTGAllocs, job.TaskGroups[0].Name)
+}
+
 func TestServiceSched_NodeDrain_Down(t *testing.T) {
 	ci.Parallel(t)
 
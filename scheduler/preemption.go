@@ -20,6 +20,9 @@ type groupedAllocs struct {
 type allocInfo struct {
 	maxParallel int
 	resources   *structs.ComparableResources
+
+	// penalty is the sum of the penalties of the victim rules
+	penalty float64
 }
 
 // PreemptionResource interface is implemented by different
@@ -117,6 +120,9 @@ type Preemptor struct {
 	// currentAllocs is the candidate set used to find preemptible allocations
 	currentAllocs []*structs.Allocation
 
+	// victimRules protect candidates from preemption or rank them
+	victimRules []VictimRule
+
 	// ctx is the context from the scheduler stack
 	ctx Context
 }
@@ -142,6 +148,11 @@ func (p *Preemptor) SetNode(node *structs.Node) {
 	p.nodeRemainingResources = nodeRemainingResources
 }
 
+// SetVictimRules sets the rules used to protect and rank the candidates
+func (p *Preemptor) SetVictimRules(rules []VictimRule) {
+	p.victimRules = rules
+}
+
 // SetCandidates initializes the candidate set from which preemptions are chosen
 func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 	// Reset candidate set
@@ -158,7 +169,15 @@ func (p *Preemptor) SetCandidates(allocs []*structs.Allocation) {
 		if tg != nil && tg.Migrate != nil {
 			maxParallel = tg.Migrate.MaxParallel
 		}
-		p.allocDetails[alloc.ID] = &allocInfo{maxParallel: maxParallel, resources: alloc.ComparableResources()}
+		penalty := 0.0
+		for _, rule := range p.victimRules {
+			penalty += rule.Penalty(alloc)
+		}
+		p.allocDetails[alloc.ID] = &allocInfo{
+			maxParallel: maxParallel,
+			resources:   alloc.ComparableResources(),
+			penalty:     penalty,
+		}
 		p.currentAllocs = append(p.currentAllocs, alloc)
 	}
 }
@@ -192,6 +211,18 @@ func (p *Preemptor) getNumPreemptions(alloc *structs.Allocation) int {
 	return c
 }
 
+// isProtected returns true if any of the victim rules protects the alloc from
+// preemption, given the number of allocations of its job and task group being
+// preempted.
+func (p *Preemptor) isProtected(alloc *structs.Allocation, numPreemptedAllocs int) bool {
+	for _, rule := range p.victimRules {
+		if rule.Protect(alloc, numPreemptedAllocs) {
+			return true
+		}
+	}
+	return false
+}
+
 // PreemptForTaskGroup computes a list of allocations to preempt to accommodate
 // the resources asked for. Only allocs with a job priority < 10 of jobPriority are considered
 // This method is meant only for finding preemptible allocations based on CPU/Memory/Disk
@@ -213,6 +244,10 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 	// Initialize variable to track resources as they become available from preemption
 	availableResources := p.nodeRemainingResources.Copy()
 
+	// Track the allocs chosen per job/task group, which the victim rules
+	// consider in addition to the existing preemptions
+	chosen := make(map[structs.NamespacedID]map[string]int)
+
 	resourcesAsked := resourceAsk.Comparable()
 	// Iterate over allocations grouped by priority to find preemptible allocations
 	for _, allocGrp := range allocsByPriority {
@@ -221,16 +256,30 @@ func (p *Preemptor) PreemptForTaskGroup(resourceAsk *structs.AllocatedResources)
 			bestDistance := math.MaxFloat64
 			// Find the alloc with the closest distance
 			for index, alloc := range allocGrp.allocs {
+				id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
 				currentPreemptionCount := p.getNumPreemptions(alloc)
+				if p.isProtected(alloc, currentPreemptionCount+chosen[id][alloc.TaskGroup]) {
+					continue
+				}
 				allocDetails := p.allocDetails[alloc.ID]
 				maxParallel := allocDetails.maxParallel
-				distance := scoreForTaskGroup(resourcesNeeded, allocDetails.resources, maxParallel, currentPreemptionCount)
+				distance := scoreForTaskGroup(resourcesNeeded, allocDetails.resources, maxParallel, currentPreemptionCount) + allocDetails.penalty
 				if distance < bestDistance {
 					bestDistance = distance
 					closestAllocIndex = index
 				}
 			}
+
+			// The remaining allocs of the group are all protected
+			if closestAllocIndex == -1 {
+				break
+			}
 			closestAlloc := allocGrp.allocs[closestAllocIndex]
+			closestID := structs.NewNamespacedID(closestAlloc.JobID, closestAlloc.Namespace)
+			if chosen[closestID] == nil {
+				chosen[closestID] = make(map[string]int)
+			}
+			chosen[closestID][closestAlloc.TaskGroup]++
 			closestResources := p.allocDetails[closestAlloc.ID].resources
 			availableResources.Add(closestResources)
 
@@ -306,8 +355,10 @@ func (p *Preemptor) PreemptForNetwork(networkResourceAsk *structs.NetworkResourc
 		// We only check first network - TODO: why?!?!
 		net := networks[0]
 
-		// Filter out alloc that's ineligible due to priority
-		if p.jobPriority-alloc.Job.Priority < 10 {
+		// Filter out alloc that's ineligible due to priority, or protected
+		// from preemption
+		if p.jobPriority-alloc.Job.Priority < 10 || !alloc.Job.IsPreemptible() ||
+			p.isProtected(alloc, p.getNumPreemptions(alloc)) {
 			// Populate any reserved ports used by
 			// this allocation that cannot be preempted
 			for _, port := range net.ReservedPorts {
@@ -525,8 +576,21 @@ OUTER:
 		// Initialize slice of preempted allocations
 		var preemptedAllocs []*structs.Allocation
 
+		// Track the allocs chosen per job/task group for this device, which
+		// the victim rules consider in addition to the existing preemptions
+		chosen := make(map[structs.NamespacedID]map[string]int)
+
 		for _, grpAllocs := range allocsByPriority {
 			for _, alloc := range grpAllocs.allocs {
+				id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+				if p.isProtected(alloc, p.getNumPreemptions(alloc)+chosen[id][alloc.TaskGroup]) {
+					continue
+				}
+				if chosen[id] == nil {
+					chosen[id] = make(map[string]int)
+				}
+				chosen[id][alloc.TaskGroup]++
+
 				// Look up the device instance from the device allocator
 				devInst := devAlloc.Devices[deviceIDTuple]
 
@@ -673,6 +737,11 @@ func filterAndGroupPreemptibleAllocs(jobPriority int, current []*structs.Allocat
 		if jobPriority-alloc.Job.Priority < 10 {
 			continue
 		}
+
+		// Skip allocs of jobs that opted out of preemption
+		if !alloc.Job.IsPreemptible() {
+			continue
+		}
 		grpAllocs, ok := allocsByPriority[alloc.Job.Priority]
 		if !ok {
 			grpAllocs = make([]*structs.Allocation, 0)
@@ -754,7 +823,8 @@ func (p *Preemptor) distanceComparatorForNetwork(allocs []*structs.Allocation, n
 		firstAllocNetResourceUsed = firstAllocNetworks[0]
 	}
 
-	distance1 := scoreForNetwork(firstAllocNetResourceUsed, networkResourceAsk, maxParallel1, currentPreemptionCount1)
+	distance1 := scoreForNetwork(firstAllocNetResourceUsed, networkResourceAsk, maxParallel1, currentPreemptionCount1) +
+		p.allocDetails[firstAlloc.ID].penalty
 
 	secondAlloc := allocs[j]
 	currentPreemptionCount2 := p.getNumPreemptions(secondAlloc)
@@ -771,6 +841,7 @@ func (p *Preemptor) distanceComparatorForNetwork(allocs []*structs.Allocation, n
 		secondAllocNetResourceUsed = secondAllocNetworks[0]
 	}
 
-	distance2 := scoreForNetwork(secondAllocNetResourceUsed, networkResourceAsk, maxParallel2, currentPreemptionCount2)
+	distance2 := scoreForNetwork(secondAllocNetResourceUsed, networkResourceAsk, maxParallel2, currentPreemptionCount2) +
+		p.allocDetails[secondAlloc.ID].penalty
 	return distance1 < distance2
 }
@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// ephemeralVictimBonus is the score bonus of allocations preferred as
+// preemption victims by the ephemeral rule. It outweighs the resource
+// distance of the allocations, but not the max_parallel penalty.
+const ephemeralVictimBonus = 10.0
+
+// ephemeralMetaKey is the job or task group metadata marking allocations as
+// preferred preemption victims.
+const ephemeralMetaKey = "ephemeral"
+
+// BuiltinVictimRules contains the preemption victim rules compiled into Nomad,
+// keyed by name. Victim rules are enabled through the preemption configuration
+// of the scheduler configuration.
+var BuiltinVictimRules = map[string]VictimRuleFactory{
+	structs.PreemptionVictimRuleDeployment: NewDeploymentVictimRule,
+	structs.PreemptionVictimRuleMinHealthy: NewMinHealthyVictimRule,
+	structs.PreemptionVictimRuleEphemeral:  NewEphemeralVictimRule,
+}
+
+// VictimRuleFactory is used to instantiate a victim rule for the placements
+// of an evaluation.
+type VictimRuleFactory func(ctx Context) VictimRule
+
+// VictimRule protects allocations from preemption, or ranks them as
+// preemption victims, alongside their job priority and resource fit.
+type VictimRule interface {
+	// Protect returns true if the allocation must not be preempted, given
+	// the number of other allocations of its task group already chosen for
+	// preemption.
+	Protect(alloc *structs.Allocation, numPreempted int) bool
+
+	// Penalty returns the penalty added to the preemption score of the
+	// allocation. Allocations with lower scores are preempted first.
+	Penalty(alloc *structs.Allocation) float64
+}
+
+// newVictimRules instantiates the named victim rules. Unknown rules are
+// logged and skipped, as the scheduler configuration is validated when set.
+func newVictimRules(ctx Context, names []string) []VictimRule {
+	if len(names) == 0 {
+		return nil
+	}
+
+	rules := make([]VictimRule, 0, len(names))
+	for _, name := range names {
+		factory, ok := BuiltinVictimRules[name]
+		if !ok {
+			ctx.Logger().Error("unknown preemption victim rule", "rule", name)
+			continue
+		}
+		rules = append(rules, factory(ctx))
+	}
+	return rules
+}
+
+// DeploymentVictimRule protects the allocations of active deployments from
+// preemption, so that preemption doesn't fail or stall the deployments. Only
+// the latest deployment of a job can be active.
+type DeploymentVictimRule struct {
+	ctx Context
+
+	// active caches the ID of the active deployment of jobs, which is empty
+	// if the job has no active deployment
+	active map[structs.NamespacedID]string
+}
+
+// NewDeploymentVictimRule creates a DeploymentVictimRule.
+func NewDeploymentVictimRule(ctx Context) VictimRule {
+	return &DeploymentVictimRule{
+		ctx:    ctx,
+		active: make(map[structs.NamespacedID]string),
+	}
+}
+
+func (r *DeploymentVictimRule) Protect(alloc *structs.Allocation, _ int) bool {
+	if alloc.DeploymentID == "" {
+		return false
+	}
+
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	active, ok := r.active[id]
+	if !ok {
+		deployment, err := r.ctx.State().LatestDeploymentByJobID(nil, alloc.Namespace, alloc.JobID)
+		if err != nil {
+			r.ctx.Logger().Error("failed to look up deployment", "job_id", alloc.JobID, "error", err)
+			return true
+		}
+		if deployment != nil && deployment.Active() {
+			active = deployment.ID
+		}
+		r.active[id] = active
+	}
+	return active == alloc.DeploymentID
+}
+
+func (r *DeploymentVictimRule) Penalty(*structs.Allocation) float64 {
+	return 0
+}
+
+// MinHealthyVictimRule protects allocations from preemption if it would leave
+// fewer allocations of their task group running than its count minus its
+// migrate max_parallel, which is the same disruption node drains allow.
+// Task groups without a migrate stanza aren't protected.
+type MinHealthyVictimRule struct {
+	ctx Context
+
+	// running caches the number of running allocations by job and task group
+	running map[structs.NamespacedID]map[string]int
+}
+
+// NewMinHealthyVictimRule creates a MinHealthyVictimRule.
+func NewMinHealthyVictimRule(ctx Context) VictimRule {
+	return &MinHealthyVictimRule{
+		ctx:     ctx,
+		running: make(map[structs.NamespacedID]map[string]int),
+	}
+}
+
+func (r *MinHealthyVictimRule) Protect(alloc *structs.Allocation, numPreempted int) bool {
+	tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup)
+	if tg == nil || tg.Migrate == nil {
+		return false
+	}
+
+	id := structs.NewNamespacedID(alloc.JobID, alloc.Namespace)
+	counts, ok := r.running[id]
+	if !ok {
+		allocs, err := r.ctx.State().AllocsByJob(nil, alloc.Namespace, alloc.JobID, false)
+		if err != nil {
+			r.ctx.Logger().Error("failed to look up job allocations", "job_id", alloc.JobID, "error", err)
+			return true
+		}
+		counts = make(map[string]int)
+		for _, a := range allocs {
+			if !a.TerminalStatus() {
+				counts[a.TaskGroup]++
+			}
+		}
+		r.running[id] = counts
+	}
+
+	remaining := counts[alloc.TaskGroup] - numPreempted - 1
+	return remaining < tg.Count-tg.Migrate.MaxParallel
+}
+
+func (r *MinHealthyVictimRule) Penalty(*structs.Allocation) float64 {
+	return 0
+}
+
+// EphemeralVictimRule prefers preempting the allocations of jobs or task
+// groups whose "ephemeral" metadata is set to true, such as caches or
+// workloads which can be restarted at no cost.
+type EphemeralVictimRule struct{}
+
+// NewEphemeralVictimRule creates an EphemeralVictimRule.
+func NewEphemeralVictimRule(Context) VictimRule {
+	return &EphemeralVictimRule{}
+}
+
+func (r *EphemeralVictimRule) Protect(*structs.Allocation, int) bool {
+	return false
+}
+
+func (r *EphemeralVictimRule) Penalty(alloc *structs.Allocation) float64 {
+	raw, ok := alloc.Job.Meta[ephemeralMetaKey]
+	if tg := alloc.Job.LookupTaskGroup(alloc.TaskGroup); tg != nil {
+		if v, tgOk := tg.Meta[ephemeralMetaKey]; tgOk {
+			raw, ok = v, true
+		}
+	}
+	if !ok {
+		return 0
+	}
+
+	if ephemeral, err := strconv.ParseBool(raw); err != nil || !ephemeral {
+		return 0
+	}
+	return -ephemeralVictimBonus
+}
@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper"
+	"github.com/hashicorp/nomad/helper/uuid"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/state"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/stretchr/testify/require"
+)
+
+// preemptWithVictimRules places a task group asking for the CPU on a node
+// running the allocations, with the victim rules enabled, and returns the IDs
+// of the preempted allocations. It returns nil if the task group can't be
+// placed.
+func preemptWithVictimRules(t *testing.T, rules []string, allocs []*structs.Allocation, cpu int, setup func(*testing.T, *state.StateStore)) map[string]struct{} {
+	node := mock.Node()
+	node.NodeResources = &structs.NodeResources{
+		Cpu:    structs.NodeCpuResources{CpuShares: 4000},
+		Memory: structs.NodeMemoryResources{MemoryMB: 8192},
+		Disk:   structs.NodeDiskResources{DiskMB: 100 * 1024},
+	}
+	node.ReservedResources = &structs.NodeReservedResources{
+		Cpu: structs.NodeReservedCpuResources{CpuShares: 100},
+	}
+
+	state, ctx := testContext(t)
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+	for _, alloc := range allocs {
+		alloc.NodeID = node.ID
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+	if setup != nil {
+		setup(t, state)
+	}
+
+	schedConfig := &structs.SchedulerConfiguration{
+		PreemptionConfig: structs.PreemptionConfig{VictimRules: rules},
+	}
+	static := NewStaticRankIterator(ctx, []*RankedNode{{Node: node}})
+	binPackIter := NewBinPackIterator(ctx, static, true, 100, schedConfig)
+	job := mock.Job()
+	job.Priority = 100
+	binPackIter.SetJob(job)
+	binPackIter.SetTaskGroup(&structs.TaskGroup{
+		EphemeralDisk: &structs.EphemeralDisk{},
+		Tasks: []*structs.Task{{
+			Name:      "web",
+			Resources: &structs.Resources{CPU: cpu, MemoryMB: 256},
+		}},
+	})
+
+	option := binPackIter.Next()
+	if option == nil {
+		return nil
+	}
+	preempted := make(map[string]struct{}, len(option.PreemptedAllocs))
+	for _, alloc := range option.PreemptedAllocs {
+		preempted[alloc.ID] = struct{}{}
+	}
+	return preempted
+}
+
+func TestPreemption_VictimRules(t *testing.T) {
+	ci.Parallel(t)
+
+	lowPrioJob := func() *structs.Job {
+		job := mock.Job()
+		job.Priority = 30
+		return job
+	}
+
+	t.Run("preemptible opt-out", func(t *testing.T) {
+		optOutJob := lowPrioJob()
+		optOutJob.Preemptible = helper.BoolToPtr(false)
+		allocs := []*structs.Allocation{
+			createAlloc(uuid.Generate(), optOutJob, &structs.Resources{CPU: 1900, MemoryMB: 256}),
+			createAlloc(uuid.Generate(), lowPrioJob(), &structs.Resources{CPU: 1900, MemoryMB: 256}),
+		}
+		preempted := preemptWithVictimRules(t, nil, allocs, 2000, nil)
+		require.Equal(t, map[string]struct{}{allocs[1].ID: {}}, preempted)
+
+		// Nothing is placed if only the opted out job can be preempted.
+		allocs = []*structs.Allocation{
+			createAlloc(uuid.Generate(), optOutJob, &structs.Resources{CPU: 3900, MemoryMB: 256}),
+		}
+		require.Nil(t, preemptWithVictimRules(t, nil, allocs, 2000, nil))
+	})
+
+	t.Run("deployment", func(t *testing.T) {
+		deployedJob := lowPrioJob()
+		deployment := mock.Deployment()
+		deployment.JobID = deployedJob.ID
+
+		allocs := []*structs.Allocation{
+			createAlloc(uuid.Generate(), deployedJob, &structs.Resources{CPU: 1900, MemoryMB: 256}),
+			createAlloc(uuid.Generate(), lowPrioJob(), &structs.Resources{CPU: 2000, MemoryMB: 256}),
+		}
+		allocs[0].DeploymentID = deployment.ID
+
+		setup := func(t *testing.T, store *state.StateStore) {
+			require.NoError(t, store.UpsertDeployment(1002, deployment))
+		}
+
+		// The allocation of the deployment fits best, but is protected.
+		preempted := preemptWithVictimRules(t, nil, allocs, 1800, setup)
+		require.Equal(t, map[string]struct{}{allocs[0].ID: {}}, preempted)
+		preempted = preemptWithVictimRules(t, []string{structs.PreemptionVictimRuleDeployment}, allocs, 1800, setup)
+		require.Equal(t, map[string]struct{}{allocs[1].ID: {}}, preempted)
+	})
+
+	t.Run("min-healthy", func(t *testing.T) {
+		// The job runs two allocations and has a migrate max_parallel of
+		// 1, so only one of its allocations can be preempted, while the
+		// allocation of the other job can.
+		job := lowPrioJob()
+		job.TaskGroups[0].Count = 2
+		otherJob := lowPrioJob()
+		otherJob.TaskGroups[0].Count = 1
+		allocs := []*structs.Allocation{
+			createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 1300, MemoryMB: 256}),
+			createAlloc(uuid.Generate(), job, &structs.Resources{CPU: 1300, MemoryMB: 256}),
+			createAlloc(uuid.Generate(), otherJob, &structs.Resources{CPU: 1300, MemoryMB: 256}),
+		}
+
+		preempted := preemptWithVictimRules(t, []string{structs.PreemptionVictimRuleMinHealthy}, allocs, 2600, nil)
+		require.Len(t, preempted, 2)
+		require.Contains(t, preempted, allocs[2].ID)
+
+		// Nothing is placed if the job's allocations must all be preempted.
+		preempted = preemptWithVictimRules(t, []string{structs.PreemptionVictimRuleMinHealthy}, allocs, 3900, nil)
+		require.Nil(t, preempted)
+	})
+
+	t.Run("ephemeral", func(t *testing.T) {
+		ephemeralJob := lowPrioJob()
+		ephemeralJob.TaskGroups[0].Meta = map[string]string{"ephemeral": "true"}
+		allocs := []*structs.Allocation{
+			createAlloc(uuid.Generate(), ephemeralJob, &structs.Resources{CPU: 2500, MemoryMB: 256}),
+			createAlloc(uuid.Generate(), lowPrioJob(), &structs.Resources{CPU: 1400, MemoryMB: 256}),
+		}
+
+		// The other allocation fits best, but the ephemeral one is preferred.
+		preempted := preemptWithVictimRules(t, nil, allocs, 1400, nil)
+		require.Equal(t, map[string]struct{}{allocs[1].ID: {}}, preempted)
+		preempted = preemptWithVictimRules(t, []string{structs.PreemptionVictimRuleEphemeral}, allocs, 1400, nil)
+		require.Equal(t, map[string]struct{}{allocs[0].ID: {}}, preempted)
+	})
+}
+
+func TestPreemption_VictimRules_MinHealthyDevices(t *testing.T) {
+	ci.Parallel(t)
+
+	node := mock.NvidiaNode()
+	state, ctx := testContext(t)
+	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1000, node))
+
+	// The job runs two allocations using a GPU each, and has a migrate
+	// max_parallel of 1, so only one of them can be preempted.
+	job := mock.Job()
+	job.Priority = 30
+	job.TaskGroups[0].Count = 2
+	var allocs []*structs.Allocation
+	for _, instance := range node.NodeResources.Devices[0].Instances {
+		alloc := createAllocWithDevice(uuid.Generate(), job, &structs.Resources{CPU: 500, MemoryMB: 256},
+			&structs.AllocatedDeviceResource{
+				Type:      "gpu",
+				Vendor:    "nvidia",
+				Name:      "1080ti",
+				DeviceIDs: []string{instance.ID},
+			})
+		alloc.NodeID = node.ID
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1001, allocs))
+
+	preemptFor := func(count uint64) []*structs.Allocation {
+		preemptor := NewPreemptor(100, ctx, &structs.NamespacedID{ID: "high", Namespace: structs.DefaultNamespace})
+		preemptor.SetNode(node)
+		preemptor.SetVictimRules(newVictimRules(ctx, []string{structs.PreemptionVictimRuleMinHealthy}))
+		preemptor.SetCandidates(allocs)
+
+		devAlloc := newDeviceAllocator(ctx, node)
+		devAlloc.AddAllocs(allocs)
+		return preemptor.PreemptForDevice(&structs.RequestedDevice{Name: "nvidia/gpu", Count: count}, devAlloc)
+	}
+
+	require.Len(t, preemptFor(1), 1)
+
+	// Both allocations would have to be preempted in the same pass
+	require.Nil(t, preemptFor(2))
+}
@@ -33,6 +33,10 @@ type RankedNode struct {
 	// PreemptedAllocs is used by the BinpackIterator to identify allocs
 	// that should be preempted in order to make the placement
 	PreemptedAllocs []*structs.Allocation
+
+	// extraScoreWeight is the weight of the scores of scoring plugins beyond
+	// the weight of 1 that every score counts for when normalized
+	extraScoreWeight float64
 }
 
 func (r *RankedNode) GoString() string {
@@ -156,29 +160,41 @@ type BinPackIterator struct {
 	jobId                  structs.NamespacedID
 	taskGroup              *structs.TaskGroup
 	memoryOversubscription bool
+	victimRules            []VictimRule
 	scoreFit               func(*structs.Node, *structs.ComparableResources) float64
 }
 
 // NewBinPackIterator returns a BinPackIterator which tries to fit tasks
 // potentially evicting other tasks based on a given priority.
 func NewBinPackIterator(ctx Context, source RankIterator, evict bool, priority int, schedConfig *structs.SchedulerConfiguration) *BinPackIterator {
+	iter := &BinPackIterator{
+		ctx:      ctx,
+		source:   source,
+		evict:    evict,
+		priority: priority,
+	}
+	iter.SetSchedulerConfiguration(schedConfig)
+	return iter
+}
 
+// SetSchedulerConfiguration updates the scoring algorithm, memory
+// oversubscription setting and preemption victim rules used by the iterator.
+// This allows the stacks to apply node pool specific overrides once the job
+// is known.
+func (iter *BinPackIterator) SetSchedulerConfiguration(schedConfig *structs.SchedulerConfiguration) {
 	algorithm := schedConfig.EffectiveSchedulerAlgorithm()
 	scoreFn := structs.ScoreFitBinPack
 	if algorithm == structs.SchedulerAlgorithmSpread {
 		scoreFn = structs.ScoreFitSpread
 	}
 
-	iter := &BinPackIterator{
-		ctx:                    ctx,
-		source:                 source,
-		evict:                  evict,
-		priority:               priority,
-		memoryOversubscription: schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled,
-		scoreFit:               scoreFn,
-	}
-	iter.ctx.Logger().Named("binpack").Trace("NewBinPackIterator created", "algorithm", algorithm)
-	return iter
+	iter.memoryOversubscription = schedConfig != nil && schedConfig.MemoryOversubscriptionEnabled
+	iter.victimRules = nil
+	if schedConfig != nil {
+		iter.victimRules = newVictimRules(iter.ctx, schedConfig.PreemptionConfig.VictimRules)
+	}
+	iter.scoreFit = scoreFn
+	iter.ctx.Logger().Named("binpack").Trace("BinPackIterator configured", "algorithm", algorithm)
 }
 
 func (iter *BinPackIterator) SetJob(job *structs.Job) {
@@ -259,6 +275,7 @@ OUTER:
 		// Initialize preemptor with node
 		preemptor := NewPreemptor(iter.priority, iter.ctx, &iter.jobId)
 		preemptor.SetNode(option.Node)
+		preemptor.SetVictimRules(iter.victimRules)
 
 		// Count the number of existing preemptions
 		allPreemptions := iter.ctx.Plan().NodePreemptions
@@ -482,8 +499,15 @@ OUTER:
 					continue OUTER
 				}
 
-				// Set the task's reserved cores
-				taskResources.Cpu.ReservedCores = availableCPUSet.ToSlice()[0:task.Resources.Cores]
+				// Set the task's reserved cores, keeping them within a single
+				// NUMA node if the task asks for it
+				reservedCores, ok := selectCores(availableCPUSet, &option.Node.NodeResources.Numa,
+					task.Resources.Cores, task.Resources.NUMA.GetAffinity())
+				if !ok {
+					iter.ctx.Metrics().ExhaustedNode(option.Node, "numa cores")
+					continue OUTER
+				}
+				taskResources.Cpu.ReservedCores = reservedCores
 				// Total CPU usage on the node is still tracked by CPUShares. Even though the task will have the entire
 				// core reserved, we still track overall usage by cpu shares.
 				taskResources.Cpu.CpuShares = option.Node.NodeResources.Cpu.SharesPerCore() * int64(task.Resources.Cores)
@@ -783,12 +807,12 @@ func (iter *ScoreNormalizationIterator) Next() *RankedNode {
 	if option == nil || len(option.Scores) == 0 {
 		return option
 	}
-	numScorers := len(option.Scores)
+	numScorers := float64(len(option.Scores)) + option.extraScoreWeight
 	sum := 0.0
 	for _, score := range option.Scores {
 		sum += score
 	}
-	option.FinalScore = sum / float64(numScorers)
+	option.FinalScore = sum / numScorers
 	//TODO(preetha): Turn map in allocmetrics into a heap of topK scores
 	iter.ctx.Metrics().ScoreNode(option.Node, "normalized-score", option.FinalScore)
 	return option
@@ -866,3 +890,34 @@ func preemptionScore(netPriority float64) float64 {
 	// This function manifests as an s curve that asympotically moves towards zero for large values of netPriority
 	return 1.0 / (1 + math.Exp(rate*(netPriority-origin)))
 }
+
+// selectCores selects the given number of cores out of the available ones
+// according to the NUMA affinity of the task. With an affinity other than none
+// the cores are taken from the NUMA node with the fewest available cores that
+// still fits the task, so that larger NUMA nodes remain free for larger tasks.
+// If the affinity is require and no NUMA node fits the task, including on a
+// node without a NUMA topology, false is returned.
+func selectCores(available cpuset.CPUSet, topology *structs.NodeNumaResources, count int, affinity string) ([]uint16, bool) {
+	if affinity == structs.NUMAAffinityNone {
+		return available.ToSlice()[0:count], true
+	}
+
+	var best cpuset.CPUSet
+	for _, node := range topology.Nodes {
+		cores := available.Intersection(cpuset.New(node.Cores...))
+		if cores.Size() < count {
+			continue
+		}
+		if best.Size() == 0 || cores.Size() < best.Size() {
+			best = cores
+		}
+	}
+
+	if best.Size() == 0 {
+		if affinity == structs.NUMAAffinityRequire {
+			return nil, false
+		}
+		return available.ToSlice()[0:count], true
+	}
+	return best.ToSlice()[0:count], true
+}
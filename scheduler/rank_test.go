@@ -1279,6 +1279,132 @@ func TestBinPackIterator_ReservedCores(t *testing.T) {
 	require.Equal([]uint16{1}, out[0].TaskResources["web"].Cpu.ReservedCores)
 }
 
+func TestBinPackIterator_ReservedCores_NUMA(t *testing.T) {
+	newNode := func() *structs.Node {
+		return &structs.Node{
+			ID: uuid.Generate(),
+			NodeResources: &structs.NodeResources{
+				Cpu: structs.NodeCpuResources{
+					CpuShares:          4096,
+					TotalCpuCores:      4,
+					ReservableCpuCores: []uint16{0, 1, 2, 3},
+				},
+				Memory: structs.NodeMemoryResources{
+					MemoryMB: 4096,
+				},
+				Numa: structs.NodeNumaResources{
+					Nodes: []structs.NodeNumaNode{
+						{ID: 0, Cores: []uint16{0, 1}},
+						{ID: 1, Cores: []uint16{2, 3}},
+					},
+				},
+			},
+		}
+	}
+	newAlloc := func(node *structs.Node, cores []uint16) *structs.Allocation {
+		job := mock.Job()
+		return &structs.Allocation{
+			Namespace: structs.DefaultNamespace,
+			ID:        uuid.Generate(),
+			EvalID:    uuid.Generate(),
+			NodeID:    node.ID,
+			JobID:     job.ID,
+			Job:       job,
+			AllocatedResources: &structs.AllocatedResources{
+				Tasks: map[string]*structs.AllocatedTaskResources{
+					"web": {
+						Cpu: structs.AllocatedCpuResources{
+							CpuShares:     int64(1024 * len(cores)),
+							ReservedCores: cores,
+						},
+						Memory: structs.AllocatedMemoryResources{
+							MemoryMB: 256,
+						},
+					},
+				},
+			},
+			DesiredStatus: structs.AllocDesiredStatusRun,
+			ClientStatus:  structs.AllocClientStatusPending,
+			TaskGroup:     "web",
+		}
+	}
+
+	cases := []struct {
+		name     string
+		affinity string
+		// expected maps the index of the node to its reserved cores, for
+		// the nodes the task fits on
+		expected map[int][]uint16
+	}{
+		{
+			name:     "none",
+			affinity: structs.NUMAAffinityNone,
+			expected: map[int][]uint16{0: {1, 3}, 1: {1, 2}, 2: {0, 1}},
+		},
+		{
+			name:     "prefer",
+			affinity: structs.NUMAAffinityPrefer,
+			expected: map[int][]uint16{0: {1, 3}, 1: {2, 3}, 2: {0, 1}},
+		},
+		{
+			name:     "require",
+			affinity: structs.NUMAAffinityRequire,
+			expected: map[int][]uint16{1: {2, 3}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			state, ctx := testContext(t)
+
+			// The first node has a free core on each NUMA node, the second
+			// node has a whole NUMA node free, the third node has no NUMA
+			// topology
+			nodes := []*RankedNode{{Node: newNode()}, {Node: newNode()}, {Node: newNode()}}
+			nodes[2].Node.NodeResources.Numa = structs.NodeNumaResources{}
+			alloc1 := newAlloc(nodes[0].Node, []uint16{0, 2})
+			alloc2 := newAlloc(nodes[1].Node, []uint16{0})
+			require.NoError(t, state.UpsertJobSummary(998, mock.JobSummary(alloc1.JobID)))
+			require.NoError(t, state.UpsertJobSummary(999, mock.JobSummary(alloc2.JobID)))
+			require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, []*structs.Allocation{alloc1, alloc2}))
+
+			taskGroup := &structs.TaskGroup{
+				EphemeralDisk: &structs.EphemeralDisk{},
+				Tasks: []*structs.Task{
+					{
+						Name: "web",
+						Resources: &structs.Resources{
+							Cores:    2,
+							MemoryMB: 256,
+							NUMA:     &structs.NUMA{Affinity: tc.affinity},
+						},
+					},
+				},
+			}
+			static := NewStaticRankIterator(ctx, nodes)
+			binp := NewBinPackIterator(ctx, static, false, 0, testSchedulerConfig)
+			binp.SetTaskGroup(taskGroup)
+
+			out := collectRanked(NewScoreNormalizationIterator(ctx, binp))
+			require.Len(t, out, len(tc.expected))
+			for i, node := range nodes {
+				cores, ok := tc.expected[i]
+				if !ok {
+					continue
+				}
+				var found *RankedNode
+				for _, o := range out {
+					if o.Node.ID == node.Node.ID {
+						found = o
+					}
+				}
+				require.NotNil(t, found, "expected node %d to fit", i)
+				require.Equal(t, cores, found.TaskResources["web"].Cpu.ReservedCores)
+			}
+		})
+	}
+}
+
 func TestBinPackIterator_ExistingAlloc(t *testing.T) {
 	state, ctx := testContext(t)
 	nodes := []*RankedNode{
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -29,8 +30,10 @@ var BuiltinSchedulers = map[string]Factory{
 }
 
 // NewScheduler is used to instantiate and return a new scheduler
-// given the scheduler name, initial state, and planner.
-func NewScheduler(name string, logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner) (Scheduler, error) {
+// given the scheduler name, initial state, planner, and the scoring plugins
+// it ranks nodes with.
+func NewScheduler(name string, logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner,
+	scorePlugins []*config.ScorePluginConfig) (Scheduler, error) {
 	// Lookup the factory function
 	factory, ok := BuiltinSchedulers[name]
 	if !ok {
@@ -38,12 +41,13 @@ func NewScheduler(name string, logger log.Logger, eventsCh chan<- interface{}, s
 	}
 
 	// Instantiate the scheduler
-	sched := factory(logger, eventsCh, state, planner)
+	sched := factory(logger, eventsCh, state, planner, scorePlugins)
 	return sched, nil
 }
 
-// Factory is used to instantiate a new Scheduler
-type Factory func(log.Logger, chan<- interface{}, State, Planner) Scheduler
+// Factory is used to instantiate a new Scheduler, which ranks nodes with the
+// given scoring plugins
+type Factory func(log.Logger, chan<- interface{}, State, Planner, []*config.ScorePluginConfig) Scheduler
 
 // Scheduler is the top level instance for a scheduler. A scheduler is
 // meant to only encapsulate business logic, pushing the various plumbing
@@ -103,6 +107,9 @@ type State interface {
 	// SchedulerConfig returns config options for the scheduler
 	SchedulerConfig() (uint64, *structs.SchedulerConfiguration, error)
 
+	// NodePoolByName is used to lookup a node pool by name
+	NodePoolByName(ws memdb.WatchSet, name string) (*structs.NodePool, error)
+
 	// CSIVolumeByID fetch CSI volumes, containing controller jobs
 	CSIVolumeByID(memdb.WatchSet, string, string) (*structs.CSIVolume, error)
 
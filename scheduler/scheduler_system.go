@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/nomad/helper/uuid"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -31,6 +32,9 @@ type SystemScheduler struct {
 	planner  Planner
 	sysbatch bool
 
+	// scorePlugins are the scoring plugins the stack ranks nodes with
+	scorePlugins []*config.ScorePluginConfig
+
 	eval       *structs.Evaluation
 	job        *structs.Job
 	plan       *structs.Plan
@@ -51,23 +55,27 @@ type SystemScheduler struct {
 
 // NewSystemScheduler is a factory function to instantiate a new system
 // scheduler.
-func NewSystemScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner) Scheduler {
+func NewSystemScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner,
+	scorePlugins []*config.ScorePluginConfig) Scheduler {
 	return &SystemScheduler{
-		logger:   logger.Named("system_sched"),
-		eventsCh: eventsCh,
-		state:    state,
-		planner:  planner,
-		sysbatch: false,
+		logger:       logger.Named("system_sched"),
+		eventsCh:     eventsCh,
+		state:        state,
+		planner:      planner,
+		sysbatch:     false,
+		scorePlugins: scorePlugins,
 	}
 }
 
-func NewSysBatchScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner) Scheduler {
+func NewSysBatchScheduler(logger log.Logger, eventsCh chan<- interface{}, state State, planner Planner,
+	scorePlugins []*config.ScorePluginConfig) Scheduler {
 	return &SystemScheduler{
-		logger:   logger.Named("sysbatch_sched"),
-		eventsCh: eventsCh,
-		state:    state,
-		planner:  planner,
-		sysbatch: true,
+		logger:       logger.Named("sysbatch_sched"),
+		eventsCh:     eventsCh,
+		state:        state,
+		planner:      planner,
+		sysbatch:     true,
+		scorePlugins: scorePlugins,
 	}
 }
 
@@ -132,7 +140,7 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Get the ready nodes in the required datacenters
 	if !s.job.Stopped() {
-		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCs(s.state, s.job.Datacenters)
+		s.nodes, s.notReadyNodes, s.nodesByDC, err = readyNodesInDCs(s.state, s.job.Datacenters, s.job.NodePool)
 		if err != nil {
 			return false, fmt.Errorf("failed to get ready nodes: %v", err)
 		}
@@ -149,6 +157,7 @@ func (s *SystemScheduler) process() (bool, error) {
 
 	// Construct the placement stack
 	s.stack = NewSystemStack(s.sysbatch, s.ctx)
+	s.stack.SetScorePlugins(s.scorePlugins)
 	if !s.job.Stopped() {
 		s.stack.SetJob(s.job)
 	}
@@ -497,6 +506,9 @@ func (s *SystemScheduler) addBlocked(node *structs.Node) error {
 	blocked := s.eval.CreateBlockedEval(classEligibility, escaped, e.QuotaLimitReached(), s.failedTGAllocs)
 	blocked.StatusDescription = blockedEvalFailedPlacements
 	blocked.NodeID = node.ID
+	if s.job != nil {
+		blocked.JobSubmitTime = s.job.SubmitTime
+	}
 
 	return s.planner.CreateEval(blocked)
 }
@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	log "github.com/hashicorp/go-hclog"
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/mitchellh/mapstructure"
+)
+
+// BuiltinScorePlugins contains the scoring plugins compiled into Nomad, keyed
+// by name. Scoring plugins are enabled and weighted through the server's
+// score_plugin configuration.
+var BuiltinScorePlugins = map[string]ScorePluginFactory{
+	"node-meta": NewNodeMetaScorePlugin,
+}
+
+// ScorePluginFactory is used to instantiate a scoring plugin from its
+// configuration.
+type ScorePluginFactory func(logger log.Logger, config map[string]interface{}) (ScorePlugin, error)
+
+// ScorePlugin scores nodes for the placement of a task group, alongside the
+// built in scoring iterators such as the BinPackIterator.
+type ScorePlugin interface {
+	// Name returns the name the plugin's scores are recorded under in the
+	// allocation metrics.
+	Name() string
+
+	// Score returns the score of placing the task group of the job on the
+	// node, between -1 and 1. If ok is false the plugin doesn't score the
+	// node, and the node is ranked by the other scores only.
+	Score(ctx Context, node *structs.Node, job *structs.Job, tg *structs.TaskGroup) (score float64, ok bool)
+}
+
+// ValidateScorePlugins returns an error if any of the configured scoring
+// plugins is unknown, has an invalid weight or fails to be instantiated.
+func ValidateScorePlugins(configs []*config.ScorePluginConfig) error {
+	var mErr multierror.Error
+	seen := make(map[string]struct{}, len(configs))
+	for _, c := range configs {
+		if _, ok := seen[c.Name]; ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("score plugin %q is configured more than once", c.Name))
+			continue
+		}
+		seen[c.Name] = struct{}{}
+
+		if c.Weight < 0 {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("score plugin %q weight must not be negative; a weight of 0 defaults to 1", c.Name))
+		}
+		factory, ok := BuiltinScorePlugins[c.Name]
+		if !ok {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("unknown score plugin %q", c.Name))
+			continue
+		}
+		if _, err := factory(log.NewNullLogger(), c.Config); err != nil {
+			mErr.Errors = append(mErr.Errors, fmt.Errorf("invalid score plugin %q config: %v", c.Name, err))
+		}
+	}
+	return mErr.ErrorOrNil()
+}
+
+// weightedScorePlugin is a scoring plugin instance and the weight of its
+// scores.
+type weightedScorePlugin struct {
+	plugin ScorePlugin
+	weight float64
+}
+
+// newScorePlugins instantiates the configured scoring plugins. Plugins that
+// fail to be instantiated are logged and skipped, as their configuration is
+// validated when the server starts.
+func newScorePlugins(ctx Context, configs []*config.ScorePluginConfig) []*weightedScorePlugin {
+	if len(configs) == 0 {
+		return nil
+	}
+
+	plugins := make([]*weightedScorePlugin, 0, len(configs))
+	for _, c := range configs {
+		factory, ok := BuiltinScorePlugins[c.Name]
+		if !ok {
+			ctx.Logger().Error("unknown score plugin", "plugin", c.Name)
+			continue
+		}
+		plugin, err := factory(ctx.Logger().Named("score_plugin"), c.Config)
+		if err != nil {
+			ctx.Logger().Error("failed to create score plugin", "plugin", c.Name, "error", err)
+			continue
+		}
+		plugins = append(plugins, &weightedScorePlugin{plugin: plugin, weight: c.EffectiveWeight()})
+	}
+	return plugins
+}
+
+// ScorePluginIterator is a RankIterator that scores nodes using the configured
+// scoring plugins. The scores are weighted by the ScoreNormalizationIterator
+// according to the weight of each plugin.
+type ScorePluginIterator struct {
+	ctx     Context
+	source  RankIterator
+	plugins []*weightedScorePlugin
+	job     *structs.Job
+	tg      *structs.TaskGroup
+}
+
+// NewScorePluginIterator creates a ScorePluginIterator, which passes the nodes
+// through unscored until its scoring plugins are set.
+func NewScorePluginIterator(ctx Context, source RankIterator) *ScorePluginIterator {
+	return &ScorePluginIterator{
+		ctx:    ctx,
+		source: source,
+	}
+}
+
+// SetPlugins instantiates the configured scoring plugins the nodes are scored
+// with.
+func (iter *ScorePluginIterator) SetPlugins(configs []*config.ScorePluginConfig) {
+	iter.plugins = newScorePlugins(iter.ctx, configs)
+}
+
+func (iter *ScorePluginIterator) SetJob(job *structs.Job) {
+	iter.job = job
+}
+
+func (iter *ScorePluginIterator) SetTaskGroup(tg *structs.TaskGroup) {
+	iter.tg = tg
+}
+
+func (iter *ScorePluginIterator) Next() *RankedNode {
+	option := iter.source.Next()
+	if option == nil || len(iter.plugins) == 0 {
+		return option
+	}
+
+	for _, p := range iter.plugins {
+		score, ok := p.plugin.Score(iter.ctx, option.Node, iter.job, iter.tg)
+		if !ok {
+			continue
+		}
+		score = math.Max(-1, math.Min(1, score))
+		option.Scores = append(option.Scores, score*p.weight)
+		option.extraScoreWeight += p.weight - 1
+		iter.ctx.Metrics().ScoreNode(option.Node, p.plugin.Name(), score)
+	}
+	return option
+}
+
+func (iter *ScorePluginIterator) Reset() {
+	iter.source.Reset()
+}
+
+// NodeMetaScorePlugin is a scoring plugin that scores nodes by a numeric
+// value of their metadata, such as a measured utilization or the cost of
+// power, set on the nodes by an external system. The value is scaled from
+// the [min, max] range to a score between -1 and 1, so that nodes with higher
+// values are preferred, unless the scores are inverted.
+type NodeMetaScorePlugin struct {
+	logger log.Logger
+	config *nodeMetaScoreConfig
+}
+
+// nodeMetaScoreConfig is the configuration of the NodeMetaScorePlugin
+type nodeMetaScoreConfig struct {
+	// Key is the key of the node metadata to score nodes by
+	Key string `mapstructure:"key"`
+
+	// Min and Max are the range of the metadata values. Values outside of
+	// the range are clamped to it. Default to 0 and 1.
+	Min float64  `mapstructure:"min"`
+	Max *float64 `mapstructure:"max"`
+
+	// Invert prefers nodes with lower values, such as a lower power cost
+	Invert bool `mapstructure:"invert"`
+}
+
+// NewNodeMetaScorePlugin creates a NodeMetaScorePlugin from its configuration.
+func NewNodeMetaScorePlugin(logger log.Logger, raw map[string]interface{}) (ScorePlugin, error) {
+	var c nodeMetaScoreConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		ErrorUnused:      true,
+		Result:           &c,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(raw); err != nil {
+		return nil, err
+	}
+
+	if c.Key == "" {
+		return nil, fmt.Errorf("missing node metadata key")
+	}
+	if c.Max == nil {
+		max := 1.0
+		c.Max = &max
+	}
+	if *c.Max <= c.Min {
+		return nil, fmt.Errorf("max %v must be greater than min %v", *c.Max, c.Min)
+	}
+
+	return &NodeMetaScorePlugin{
+		logger: logger.Named("node_meta"),
+		config: &c,
+	}, nil
+}
+
+func (p *NodeMetaScorePlugin) Name() string {
+	return "node-meta." + p.config.Key
+}
+
+func (p *NodeMetaScorePlugin) Score(_ Context, node *structs.Node, _ *structs.Job, _ *structs.TaskGroup) (float64, bool) {
+	raw, ok := node.Meta[p.config.Key]
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		p.logger.Debug("ignoring invalid node metadata value", "node_id", node.ID, "key", p.config.Key, "value", raw)
+		return 0, false
+	}
+
+	min, max := p.config.Min, *p.config.Max
+	value = math.Max(min, math.Min(max, value))
+	score := 2*(value-min)/(max-min) - 1
+	if p.config.Invert {
+		score = -score
+	}
+	return score, true
+}
@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/hashicorp/nomad/ci"
+	"github.com/hashicorp/nomad/helper/testlog"
+	"github.com/hashicorp/nomad/nomad/mock"
+	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateScorePlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	require.NoError(t, ValidateScorePlugins(nil))
+	require.NoError(t, ValidateScorePlugins([]*config.ScorePluginConfig{
+		{Name: "node-meta", Config: map[string]interface{}{"key": "power_cost"}},
+	}))
+
+	err := ValidateScorePlugins([]*config.ScorePluginConfig{
+		{Name: "unknown"},
+		{Name: "node-meta", Weight: -1, Config: map[string]interface{}{"key": "power_cost", "max": -1}},
+		{Name: "node-meta", Config: map[string]interface{}{"key": "power_cost"}},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown score plugin "unknown"`)
+	require.Contains(t, err.Error(), `score plugin "node-meta" weight must not be negative`)
+	require.Contains(t, err.Error(), "max -1 must be greater than min 0")
+	require.Contains(t, err.Error(), `score plugin "node-meta" is configured more than once`)
+}
+
+func TestNodeMetaScorePlugin(t *testing.T) {
+	ci.Parallel(t)
+
+	_, err := NewNodeMetaScorePlugin(testlog.HCLogger(t), nil)
+	require.EqualError(t, err, "missing node metadata key")
+
+	_, err = NewNodeMetaScorePlugin(testlog.HCLogger(t), map[string]interface{}{"key": "cost", "unknown": 1})
+	require.Error(t, err)
+
+	plugin, err := NewNodeMetaScorePlugin(testlog.HCLogger(t), map[string]interface{}{
+		"key": "cost",
+		"min": "10",
+		"max": 30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "node-meta.cost", plugin.Name())
+
+	inverted, err := NewNodeMetaScorePlugin(testlog.HCLogger(t), map[string]interface{}{
+		"key":    "cost",
+		"min":    10,
+		"max":    30,
+		"invert": "true",
+	})
+	require.NoError(t, err)
+
+	cases := []struct {
+		value    string
+		ok       bool
+		score    float64
+		inverted float64
+	}{
+		{value: "", ok: false},
+		{value: "invalid", ok: false},
+		{value: "10", ok: true, score: -1, inverted: 1},
+		{value: "25", ok: true, score: 0.5, inverted: -0.5},
+		{value: "100", ok: true, score: 1, inverted: -1},
+	}
+	for _, tc := range cases {
+		node := mock.Node()
+		if tc.value != "" {
+			node.Meta["cost"] = tc.value
+		}
+
+		score, ok := plugin.Score(nil, node, nil, nil)
+		require.Equal(t, tc.ok, ok, tc.value)
+		require.Equal(t, tc.score, score, tc.value)
+
+		score, _ = inverted.Score(nil, node, nil, nil)
+		require.Equal(t, tc.inverted, score, tc.value)
+	}
+}
+
+func TestScorePluginIterator_Weight(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+
+	nodes := []*RankedNode{
+		{Node: mock.Node(), Scores: []float64{0.5}},
+		{Node: mock.Node(), Scores: []float64{0.5}},
+	}
+	nodes[0].Node.Meta["cost"] = "0"
+	static := NewStaticRankIterator(ctx, nodes)
+
+	job := mock.Job()
+	plugins := NewScorePluginIterator(ctx, static)
+	plugins.SetPlugins([]*config.ScorePluginConfig{
+		{
+			Name:   "node-meta",
+			Weight: 3,
+			Config: map[string]interface{}{"key": "cost", "invert": true},
+		},
+	})
+	plugins.SetJob(job)
+	plugins.SetTaskGroup(job.TaskGroups[0])
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, plugins)
+	out := collectRanked(scoreNorm)
+	require.Len(t, out, 2)
+
+	// The plugin score of 1 has three times the weight of the other score
+	require.Equal(t, (0.5+3)/4, out[0].FinalScore)
+
+	// Nodes the plugin doesn't score are ranked by the other scores only
+	require.Equal(t, 0.5, out[1].FinalScore)
+
+	ctx.Metrics().PopulateScoreMetaData()
+	var found bool
+	for _, meta := range ctx.Metrics().ScoreMetaData {
+		if meta.NodeID == nodes[0].Node.ID {
+			require.Equal(t, 1.0, meta.Scores["node-meta.cost"])
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestServiceStack_Select_ScorePlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+
+	nodes := []*structs.Node{mock.Node(), mock.Node()}
+	nodes[0].Meta["cost"] = "1"
+	nodes[1].Meta["cost"] = "0"
+	cheapest := nodes[1]
+
+	// Setting the nodes shuffles them
+	stack := NewGenericStack(false, ctx)
+	stack.SetScorePlugins([]*config.ScorePluginConfig{
+		{
+			Name:   "node-meta",
+			Weight: 10,
+			Config: map[string]interface{}{"key": "cost", "invert": true},
+		},
+	})
+	stack.SetNodes(nodes)
+
+	job := mock.Job()
+	stack.SetJob(job)
+	selectOptions := &SelectOptions{AllocName: structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)}
+	option := stack.Select(job.TaskGroups[0], selectOptions)
+	require.NotNil(t, option)
+	require.Equal(t, cheapest.ID, option.Node.ID)
+}
+
+func TestSystemStack_Select_ScorePlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+
+	node := mock.Node()
+	node.Meta["cost"] = "1"
+
+	stack := NewSystemStack(false, ctx)
+	stack.SetScorePlugins([]*config.ScorePluginConfig{
+		{Name: "node-meta", Config: map[string]interface{}{"key": "cost"}},
+	})
+	stack.SetNodes([]*structs.Node{node})
+
+	job := mock.SystemJob()
+	stack.SetJob(job)
+	selectOptions := &SelectOptions{AllocName: structs.AllocName(job.ID, job.TaskGroups[0].Name, 0)}
+	option := stack.Select(job.TaskGroups[0], selectOptions)
+	require.NotNil(t, option)
+
+	ctx.Metrics().PopulateScoreMetaData()
+	require.Len(t, ctx.Metrics().ScoreMetaData, 1)
+	require.Contains(t, ctx.Metrics().ScoreMetaData[0].Scores, "node-meta.cost")
+}
+
+func TestNewScheduler_ScorePlugins(t *testing.T) {
+	ci.Parallel(t)
+
+	h := NewHarness(t)
+	configs := []*config.ScorePluginConfig{
+		{Name: "node-meta", Config: map[string]interface{}{"key": "cost"}},
+	}
+
+	sched, err := NewScheduler(structs.JobTypeService, testlog.HCLogger(t), nil, h.State, h, configs)
+	require.NoError(t, err)
+	require.Equal(t, configs, sched.(*GenericScheduler).scorePlugins)
+
+	sched, err = NewScheduler(structs.JobTypeSysBatch, testlog.HCLogger(t), nil, h.State, h, configs)
+	require.NoError(t, err)
+	require.Equal(t, configs, sched.(*SystemScheduler).scorePlugins)
+}
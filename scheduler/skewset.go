@@ -0,0 +1,317 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/hashicorp/go-hclog"
+	memdb "github.com/hashicorp/go-memdb"
+	"github.com/hashicorp/nomad/nomad/structs"
+)
+
+// skewDomainSeparator joins the values of the attributes of a max_skew spread
+// into the key of a nested domain.
+const skewDomainSeparator = "/"
+
+// skewSet is used to track the number of allocations of a task group placed in
+// each domain of a max_skew spread. The domains of the first attribute of the
+// spread are the values of the attribute, and the domains of each nested
+// attribute are its values within a domain of the previous attribute.
+type skewSet struct {
+	// ctx is used to lookup the plan and state
+	ctx Context
+
+	// logger is the logger for the skew set
+	logger log.Logger
+
+	// jobID is the job we are operating on
+	jobID string
+
+	// namespace is the namespace of the job we are operating on
+	namespace string
+
+	// taskGroup is the task group whose allocations are spread
+	taskGroup string
+
+	// attributes are the attribute of the spread followed by its nested
+	// attributes
+	attributes []string
+
+	// maxSkew is the max_skew of the spread
+	maxSkew int
+
+	// weight is the weight of the spread
+	weight int8
+
+	// constraints are the job and task group constraints nodes must meet
+	// for their domains to be considered when computing the skew
+	constraints *ConstraintChecker
+
+	// errorBuilding marks whether there was an error when building the skew
+	// set
+	errorBuilding error
+
+	// domains is a mapping of the keys of domains to the keys of the domains
+	// nested within them. The top level domains are nested within "".
+	domains map[string]map[string]struct{}
+
+	// existing is a mapping of the IDs of the non-terminal allocations of the
+	// task group to the ID of their node
+	existing map[string]string
+
+	// counts is a mapping of the keys of domains to the number of existing
+	// and proposed allocations placed in them
+	counts map[string]int
+
+	// nodes caches the nodes of the allocations
+	nodes map[string]*structs.Node
+}
+
+// newSkewSet returns a new skew set used to keep the allocations of the task
+// group evenly spread across the domains of the max_skew spread.
+func newSkewSet(ctx Context, job *structs.Job, tg *structs.TaskGroup, spread *structs.Spread) *skewSet {
+	constraints := make([]*structs.Constraint, 0, len(job.Constraints)+len(tg.Constraints))
+	constraints = append(constraints, job.Constraints...)
+	constraints = append(constraints, taskGroupConstraints(tg).constraints...)
+
+	s := &skewSet{
+		ctx:         ctx,
+		logger:      ctx.Logger().Named("skew_set"),
+		jobID:       job.ID,
+		namespace:   job.Namespace,
+		taskGroup:   tg.Name,
+		attributes:  spread.SkewAttributes(),
+		maxSkew:     spread.MaxSkew,
+		weight:      spread.Weight,
+		constraints: NewConstraintChecker(ctx, constraints),
+		domains:     make(map[string]map[string]struct{}),
+		existing:    make(map[string]string),
+		nodes:       make(map[string]*structs.Node),
+	}
+	s.populateExisting()
+	s.PopulateProposed()
+	return s
+}
+
+// SetNodes sets the nodes whose domains allocations can be spread across.
+// Nodes which don't meet the job and task group constraints are ignored, so
+// that domains the task group can't be placed in don't count towards the skew.
+func (s *skewSet) SetNodes(nodes []*structs.Node) {
+	s.domains = make(map[string]map[string]struct{})
+	for _, node := range nodes {
+		keys, missing := s.domainKeys(node)
+		if missing != "" || !s.meetsConstraints(node) {
+			continue
+		}
+
+		parent := ""
+		for _, key := range keys {
+			children, ok := s.domains[parent]
+			if !ok {
+				children = make(map[string]struct{})
+				s.domains[parent] = children
+			}
+			children[key] = struct{}{}
+			parent = key
+		}
+	}
+}
+
+// meetsConstraints returns whether the node meets the job and task group
+// constraints, without recording the node as filtered.
+func (s *skewSet) meetsConstraints(node *structs.Node) bool {
+	for _, constraint := range s.constraints.constraints {
+		if !s.constraints.meetsConstraint(constraint, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// populateExisting populates the allocations of the task group placed before
+// the evaluation.
+func (s *skewSet) populateExisting() {
+	ws := memdb.NewWatchSet()
+	allocs, err := s.ctx.State().AllocsByJob(ws, s.namespace, s.jobID, false)
+	if err != nil {
+		s.errorBuilding = fmt.Errorf("failed to get job's allocations: %v", err)
+		s.logger.Error("failed to get job's allocations", "job", s.jobID, "namespace", s.namespace, "error", err)
+		return
+	}
+
+	for _, alloc := range allocs {
+		if alloc.TaskGroup == s.taskGroup && !alloc.TerminalStatus() {
+			s.existing[alloc.ID] = alloc.NodeID
+		}
+	}
+}
+
+// PopulateProposed recomputes the number of allocations placed in each domain
+// from the existing allocations and the plan. It should be called whenever the
+// plan is updated to ensure correct results when checking an option.
+func (s *skewSet) PopulateProposed() {
+	placed := make(map[string]string, len(s.existing))
+	for allocID, nodeID := range s.existing {
+		placed[allocID] = nodeID
+	}
+
+	// Discount the proposed stops and add the proposed allocations. In-place
+	// updates are both in the existing allocations and the plan, so the
+	// allocations are tracked by ID.
+	for _, updates := range s.ctx.Plan().NodeUpdate {
+		for _, alloc := range updates {
+			delete(placed, alloc.ID)
+		}
+	}
+	for _, allocs := range s.ctx.Plan().NodeAllocation {
+		for _, alloc := range allocs {
+			if alloc.JobID != s.jobID || alloc.Namespace != s.namespace ||
+				alloc.TaskGroup != s.taskGroup || alloc.TerminalStatus() {
+				continue
+			}
+			placed[alloc.ID] = alloc.NodeID
+		}
+	}
+
+	s.counts = make(map[string]int)
+	for _, nodeID := range placed {
+		node, err := s.node(nodeID)
+		if err != nil {
+			s.errorBuilding = err
+			s.logger.Error("failed to build skew counts", "error", err)
+			return
+		}
+
+		keys, missing := s.domainKeys(node)
+		if missing != "" {
+			continue
+		}
+		for _, key := range keys {
+			s.counts[key]++
+		}
+	}
+}
+
+// node returns the node with the given ID, looking it up in the state store
+// the first time.
+func (s *skewSet) node(nodeID string) (*structs.Node, error) {
+	if node, ok := s.nodes[nodeID]; ok {
+		return node, nil
+	}
+
+	node, err := s.ctx.State().NodeByID(memdb.NewWatchSet(), nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup node ID %q: %v", nodeID, err)
+	}
+	s.nodes[nodeID] = node
+	return node, nil
+}
+
+// domainKeys returns the keys of the domains of the node, from the top level
+// domain to the most nested one. If the node is missing one of the attributes
+// of the spread, the attribute is returned instead.
+func (s *skewSet) domainKeys(node *structs.Node) ([]string, string) {
+	keys := make([]string, len(s.attributes))
+	values := make([]string, 0, len(s.attributes))
+	for i, attribute := range s.attributes {
+		value, ok := getProperty(node, attribute)
+		if !ok {
+			return nil, attribute
+		}
+		values = append(values, value)
+		keys[i] = strings.Join(values, skewDomainSeparator)
+	}
+	return keys, ""
+}
+
+// Skews returns the skew of each domain of the option if an allocation was
+// placed on it, from the top level domain to the most nested one. The skew of
+// a domain is its number of allocations minus the minimum number of
+// allocations of the domains nested within the same parent. If the skews
+// can't be computed an explanation is given.
+func (s *skewSet) Skews(option *structs.Node) ([]string, []int, string) {
+	if s.errorBuilding != nil {
+		return nil, nil, s.errorBuilding.Error()
+	}
+
+	keys, missing := s.domainKeys(option)
+	if missing != "" {
+		return nil, nil, fmt.Sprintf("missing property %q", missing)
+	}
+
+	skews := make([]int, len(keys))
+	parent := ""
+	for i, key := range keys {
+		count := s.counts[key]
+		min := count
+		for sibling := range s.domains[parent] {
+			if c := s.counts[sibling]; c < min {
+				min = c
+			}
+		}
+		skews[i] = count + 1 - min
+		parent = key
+	}
+	return keys, skews, ""
+}
+
+// SatisfiesMaxSkew checks if placing an allocation on the option keeps the
+// skew of each of its domains within the max_skew of the spread. If the option
+// does not satisfy the max_skew an explanation is given.
+func (s *skewSet) SatisfiesMaxSkew(option *structs.Node) (bool, string) {
+	keys, skews, errorMsg := s.Skews(option)
+	if errorMsg != "" {
+		return false, errorMsg
+	}
+
+	for i, skew := range skews {
+		if skew > s.maxSkew {
+			return false, fmt.Sprintf("spread max_skew: %s=%s skew %d exceeds %d",
+				s.attributes[i], keys[i], skew, s.maxSkew)
+		}
+	}
+	return true, ""
+}
+
+// Score returns the score of placing an allocation on the option, from 1 when
+// it is placed in the least used domains to -1 when the placement exceeds the
+// max_skew of the spread, averaged across the nested domains. If the score
+// can't be computed an explanation is given.
+func (s *skewSet) Score(option *structs.Node) (float64, string) {
+	_, skews, errorMsg := s.Skews(option)
+	if errorMsg != "" {
+		return 0, errorMsg
+	}
+
+	total := 0.0
+	for _, skew := range skews {
+		score := 1.0 - 2.0*float64(skew-1)/float64(s.maxSkew)
+		if score < -1.0 {
+			score = -1.0
+		}
+		total += score
+	}
+	return total / float64(len(skews)), ""
+}
+
+// Distribution returns the number of allocations placed in each domain,
+// including the domains without allocations.
+func (s *skewSet) Distribution() *structs.SpreadDistribution {
+	counts := make(map[string]int, len(s.counts))
+	for _, children := range s.domains {
+		for key := range children {
+			counts[key] = 0
+		}
+	}
+	for key, count := range s.counts {
+		counts[key] = count
+	}
+
+	attributes := make([]string, len(s.attributes))
+	copy(attributes, s.attributes)
+	return &structs.SpreadDistribution{
+		Attributes: attributes,
+		MaxSkew:    s.maxSkew,
+		Counts:     counts,
+	}
+}
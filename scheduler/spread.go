@@ -38,6 +38,14 @@ type SpreadIterator struct {
 	// existing allocs are computed once, and allocs from the plan are updated
 	// when Reset is called
 	groupPropertySets map[string][]*propertySet
+
+	// groupSkewSets is a memoized map from task group to the skew sets of
+	// its spreads with a max_skew, which are scored by skew instead of by
+	// target percentages
+	groupSkewSets map[string][]*skewSet
+
+	// nodes are the base nodes whose domains allocations are spread across
+	nodes []*structs.Node
 }
 
 type spreadAttributeMap map[string]*spreadInfo
@@ -52,11 +60,23 @@ func NewSpreadIterator(ctx Context, source RankIterator) *SpreadIterator {
 		ctx:               ctx,
 		source:            source,
 		groupPropertySets: make(map[string][]*propertySet),
+		groupSkewSets:     make(map[string][]*skewSet),
 		tgSpreadInfo:      make(map[string]spreadAttributeMap),
 	}
 	return iter
 }
 
+// SetNodes sets the base nodes whose domains allocations are spread across
+// by spreads with a max_skew.
+func (iter *SpreadIterator) SetNodes(nodes []*structs.Node) {
+	iter.nodes = nodes
+	for _, sets := range iter.groupSkewSets {
+		for _, ss := range sets {
+			ss.SetNodes(nodes)
+		}
+	}
+}
+
 func (iter *SpreadIterator) Reset() {
 	iter.source.Reset()
 	for _, sets := range iter.groupPropertySets {
@@ -64,6 +84,11 @@ func (iter *SpreadIterator) Reset() {
 			ps.PopulateProposed()
 		}
 	}
+	for _, sets := range iter.groupSkewSets {
+		for _, ss := range sets {
+			ss.PopulateProposed()
+		}
+	}
 }
 
 func (iter *SpreadIterator) SetJob(job *structs.Job) {
@@ -77,6 +102,7 @@ func (iter *SpreadIterator) SetJob(job *structs.Job) {
 	// versions of spread/properties to the new job version
 	iter.tgSpreadInfo = make(map[string]spreadAttributeMap)
 	iter.groupPropertySets = make(map[string][]*propertySet)
+	iter.groupSkewSets = make(map[string][]*skewSet)
 }
 
 func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
@@ -84,23 +110,29 @@ func (iter *SpreadIterator) SetTaskGroup(tg *structs.TaskGroup) {
 
 	// Build the property set at the taskgroup level
 	if _, ok := iter.groupPropertySets[tg.Name]; !ok {
-		// First add property sets that are at the job level for this task group
-		for _, spread := range iter.jobSpreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
-		}
+		iter.groupPropertySets[tg.Name] = nil
+		iter.groupSkewSets[tg.Name] = nil
+
+		// First add property sets that are at the job level for this task
+		// group, then include property sets at the task group level
+		for _, spreads := range [][]*structs.Spread{iter.jobSpreads, tg.Spreads} {
+			for _, spread := range spreads {
+				if spread.MaxSkew > 0 {
+					ss := newSkewSet(iter.ctx, iter.job, tg, spread)
+					ss.SetNodes(iter.nodes)
+					iter.groupSkewSets[tg.Name] = append(iter.groupSkewSets[tg.Name], ss)
+					continue
+				}
 
-		// Include property sets at the task group level
-		for _, spread := range tg.Spreads {
-			pset := NewPropertySet(iter.ctx, iter.job)
-			pset.SetTargetAttribute(spread.Attribute, tg.Name)
-			iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
+				pset := NewPropertySet(iter.ctx, iter.job)
+				pset.SetTargetAttribute(spread.Attribute, tg.Name)
+				iter.groupPropertySets[tg.Name] = append(iter.groupPropertySets[tg.Name], pset)
+			}
 		}
 	}
 
 	// Check if there are any spreads configured
-	iter.hasSpread = len(iter.groupPropertySets[tg.Name]) != 0
+	iter.hasSpread = len(iter.groupPropertySets[tg.Name]) != 0 || len(iter.groupSkewSets[tg.Name]) != 0
 
 	// Build tgSpreadInfo at the task group level
 	if _, ok := iter.tgSpreadInfo[tg.Name]; !ok {
@@ -180,6 +212,18 @@ func (iter *SpreadIterator) Next() *RankedNode {
 			}
 		}
 
+		// Add the weighted score of the spreads with a max_skew
+		for _, ss := range iter.groupSkewSets[tgName] {
+			score, errorMsg := ss.Score(option.Node)
+			if errorMsg != "" {
+				iter.ctx.Logger().Named("spread").Debug("error building spread attributes for task group", "task_group", tgName, "error", errorMsg)
+				totalSpreadScore -= 1.0
+				continue
+			}
+			spreadWeight := float64(ss.weight) / float64(iter.sumSpreadWeights)
+			totalSpreadScore += score * spreadWeight
+		}
+
 		if totalSpreadScore != 0.0 {
 			option.Scores = append(option.Scores, totalSpreadScore)
 			iter.ctx.Metrics().ScoreNode(option.Node, "allocation-spread", totalSpreadScore)
@@ -253,6 +297,13 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 	combinedSpreads = append(combinedSpreads, tg.Spreads...)
 	combinedSpreads = append(combinedSpreads, iter.jobSpreads...)
 	for _, spread := range combinedSpreads {
+		iter.sumSpreadWeights += int32(spread.Weight)
+
+		// Spreads with a max_skew are scored by their skew sets
+		if spread.MaxSkew > 0 {
+			continue
+		}
+
 		si := &spreadInfo{weight: spread.Weight, desiredCounts: make(map[string]float64)}
 		sumDesiredCounts := 0.0
 		for _, st := range spread.SpreadTarget {
@@ -266,7 +317,6 @@ func (iter *SpreadIterator) computeSpreadInfo(tg *structs.TaskGroup) {
 			si.desiredCounts[implicitTarget] = remainingCount
 		}
 		spreadInfos[spread.Attribute] = si
-		iter.sumSpreadWeights += int32(spread.Weight)
 	}
 	iter.tgSpreadInfo[tg.Name] = spreadInfos
 }
@@ -558,6 +558,63 @@ func TestSpreadIterator_MaxPenalty(t *testing.T) {
 
 }
 
+func TestSpreadIterator_MaxSkew(t *testing.T) {
+	ci.Parallel(t)
+
+	state, ctx := testContext(t)
+
+	// Create a node per zone, with two allocs in zone a and one in zone c
+	zones := []string{"a", "b", "c"}
+	var nodes []*structs.Node
+	var ranked []*RankedNode
+	for i, zone := range zones {
+		node := mock.Node()
+		node.Meta["zone"] = zone
+		require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, uint64(100+i), node))
+		nodes = append(nodes, node)
+		ranked = append(ranked, &RankedNode{Node: node})
+	}
+
+	job := mock.Job()
+	tg := job.TaskGroups[0]
+	tg.Spreads = []*structs.Spread{{
+		Attribute: "${meta.zone}",
+		Weight:    100,
+		MaxSkew:   2,
+	}}
+
+	var allocs []*structs.Allocation
+	for _, node := range []*structs.Node{nodes[0], nodes[0], nodes[2]} {
+		alloc := mock.Alloc()
+		alloc.Job = job
+		alloc.JobID = job.ID
+		alloc.NodeID = node.ID
+		allocs = append(allocs, alloc)
+	}
+	require.NoError(t, state.UpsertAllocs(structs.MsgTypeTestSetup, 1000, allocs))
+
+	static := NewStaticRankIterator(ctx, ranked)
+	spreadIter := NewSpreadIterator(ctx, static)
+	spreadIter.SetNodes(nodes)
+	spreadIter.SetJob(job)
+	spreadIter.SetTaskGroup(tg)
+
+	scoreNorm := NewScoreNormalizationIterator(ctx, spreadIter)
+	out := collectRanked(scoreNorm)
+
+	// The least used zone gets the maximum boost, while placing in zone a
+	// would exceed the max_skew and gets the maximum penalty
+	expectedScores := map[string]float64{
+		"a": -1.0,
+		"b": 1.0,
+		"c": 0.0,
+	}
+	require.Len(t, out, 3)
+	for _, rn := range out {
+		require.Equal(t, expectedScores[rn.Node.Meta["zone"]], rn.FinalScore, rn.Node.Meta["zone"])
+	}
+}
+
 func Test_evenSpreadScoreBoost(t *testing.T) {
 	ci.Parallel(t)
 
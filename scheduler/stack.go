@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 const (
@@ -32,10 +33,11 @@ type Stack interface {
 }
 
 type SelectOptions struct {
-	PenaltyNodeIDs map[string]struct{}
-	PreferredNodes []*structs.Node
-	Preempt        bool
-	AllocName      string
+	PenaltyNodeIDs  map[string]struct{}
+	ExcludedNodeIDs map[string]struct{}
+	PreferredNodes  []*structs.Node
+	Preempt         bool
+	AllocName       string
 }
 
 // GenericStack is the Stack used for the Generic scheduler. It is
@@ -48,6 +50,7 @@ type GenericStack struct {
 	wrappedChecks        *FeasibilityWrapper
 	quota                FeasibleIterator
 	jobVersion           *uint64
+	jobNodePool          *NodePoolChecker
 	jobConstraint        *ConstraintChecker
 	taskGroupDrivers     *DriverChecker
 	taskGroupConstraint  *ConstraintChecker
@@ -58,6 +61,7 @@ type GenericStack struct {
 
 	distinctHostsConstraint    *DistinctHostsIterator
 	distinctPropertyConstraint *DistinctPropertyIterator
+	spreadSkewConstraint       *SpreadSkewIterator
 	binPack                    *BinPackIterator
 	jobAntiAff                 *JobAntiAffinityIterator
 	nodeReschedulingPenalty    *NodeReschedulingPenaltyIterator
@@ -65,9 +69,15 @@ type GenericStack struct {
 	maxScore                   *MaxScoreIterator
 	nodeAffinity               *NodeAffinityIterator
 	spread                     *SpreadIterator
+	scorePlugins               *ScorePluginIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
+// SetScorePlugins sets the scoring plugins the stack ranks nodes with.
+func (s *GenericStack) SetScorePlugins(configs []*config.ScorePluginConfig) {
+	s.scorePlugins.SetPlugins(configs)
+}
+
 func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 	// Shuffle base nodes
 	idx, _ := s.ctx.State().LatestIndex()
@@ -76,6 +86,10 @@ func (s *GenericStack) SetNodes(baseNodes []*structs.Node) {
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
 
+	// Update the domains max_skew spreads are computed across
+	s.spreadSkewConstraint.SetNodes(baseNodes)
+	s.spread.SetNodes(baseNodes)
+
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	// For batch jobs we only need to evaluate 2 options and depend on the
 	// power of two choices. For services jobs we need to visit "enough".
@@ -99,13 +113,17 @@ func (s *GenericStack) SetJob(job *structs.Job) {
 	jobVer := job.Version
 	s.jobVersion = &jobVer
 
+	s.jobNodePool.SetNodePool(job.NodePool)
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctHostsConstraint.SetJob(job)
 	s.distinctPropertyConstraint.SetJob(job)
+	s.spreadSkewConstraint.SetJob(job)
 	s.binPack.SetJob(job)
+	s.binPack.SetSchedulerConfiguration(nodePoolSchedulerConfig(s.ctx.State(), job))
 	s.jobAntiAff.SetJob(job)
 	s.nodeAffinity.SetJob(job)
 	s.spread.SetJob(job)
+	s.scorePlugins.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 	s.taskGroupCSIVolumes.SetNamespace(job.Namespace)
 	s.taskGroupCSIVolumes.SetJobID(job.ID)
@@ -132,6 +150,24 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 		return s.Select(tg, &optionsNew)
 	}
 
+	// This block removes the excluded nodes from the set of nodes to select
+	// from. It also sets back the set of nodes to the original nodes
+	if options != nil && len(options.ExcludedNodeIDs) > 0 {
+		originalNodes := s.source.nodes
+		nodes := make([]*structs.Node, 0, len(originalNodes))
+		for _, node := range originalNodes {
+			if _, ok := options.ExcludedNodeIDs[node.ID]; !ok {
+				nodes = append(nodes, node)
+			}
+		}
+		s.source.SetNodes(nodes)
+		optionsNew := *options
+		optionsNew.ExcludedNodeIDs = nil
+		option := s.Select(tg, &optionsNew)
+		s.source.SetNodes(originalNodes)
+		return option
+	}
+
 	// Reset the max selector and context
 	s.maxScore.Reset()
 	s.ctx.Reset()
@@ -151,6 +187,7 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.distinctHostsConstraint.SetTaskGroup(tg)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
+	s.spreadSkewConstraint.SetTaskGroup(tg)
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.binPack.SetTaskGroup(tg)
 	if options != nil {
@@ -162,6 +199,7 @@ func (s *GenericStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ra
 	}
 	s.nodeAffinity.SetTaskGroup(tg)
 	s.spread.SetTaskGroup(tg)
+	s.scorePlugins.SetTaskGroup(tg)
 
 	if s.nodeAffinity.hasAffinities() || s.spread.hasSpreads() {
 		// scoring spread across all nodes has quadratic behavior, so
@@ -194,6 +232,7 @@ type SystemStack struct {
 
 	wrappedChecks        *FeasibilityWrapper
 	quota                FeasibleIterator
+	jobNodePool          *NodePoolChecker
 	jobConstraint        *ConstraintChecker
 	taskGroupDrivers     *DriverChecker
 	taskGroupConstraint  *ConstraintChecker
@@ -204,6 +243,7 @@ type SystemStack struct {
 
 	distinctPropertyConstraint *DistinctPropertyIterator
 	binPack                    *BinPackIterator
+	scorePlugins               *ScorePluginIterator
 	scoreNorm                  *ScoreNormalizationIterator
 }
 
@@ -220,6 +260,10 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// have to evaluate on all nodes.
 	s.source = NewStaticIterator(ctx, nil)
 
+	// Filter on the job's node pool before any other check. The job is
+	// filled in later.
+	s.jobNodePool = NewNodePoolChecker(ctx, "")
+
 	// Attach the job constraints. The job is filled in later.
 	s.jobConstraint = NewConstraintChecker(ctx, nil)
 
@@ -245,7 +289,7 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
 	// checks that only needs to examine the single node to determine feasibility.
-	jobs := []FeasibilityChecker{s.jobConstraint}
+	jobs := []FeasibilityChecker{s.jobNodePool, s.jobConstraint}
 	tgs := []FeasibilityChecker{
 		s.taskGroupDrivers,
 		s.taskGroupConstraint,
@@ -285,20 +329,31 @@ func NewSystemStack(sysbatch bool, ctx Context) *SystemStack {
 	// Create binpack iterator
 	s.binPack = NewBinPackIterator(ctx, rankSource, enablePreemption, 0, schedConfig)
 
+	// Apply the scores of the configured scoring plugins
+	s.scorePlugins = NewScorePluginIterator(ctx, s.binPack)
+
 	// Apply score normalization
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.binPack)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.scorePlugins)
 	return s
 }
 
+// SetScorePlugins sets the scoring plugins the stack ranks nodes with.
+func (s *SystemStack) SetScorePlugins(configs []*config.ScorePluginConfig) {
+	s.scorePlugins.SetPlugins(configs)
+}
+
 func (s *SystemStack) SetNodes(baseNodes []*structs.Node) {
 	// Update the set of base nodes
 	s.source.SetNodes(baseNodes)
 }
 
 func (s *SystemStack) SetJob(job *structs.Job) {
+	s.jobNodePool.SetNodePool(job.NodePool)
 	s.jobConstraint.SetConstraints(job.Constraints)
 	s.distinctPropertyConstraint.SetJob(job)
 	s.binPack.SetJob(job)
+	s.binPack.SetSchedulerConfiguration(nodePoolSchedulerConfig(s.ctx.State(), job))
+	s.scorePlugins.SetJob(job)
 	s.ctx.Eligibility().SetJob(job)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
@@ -327,6 +382,7 @@ func (s *SystemStack) Select(tg *structs.TaskGroup, options *SelectOptions) *Ran
 	s.wrappedChecks.SetTaskGroup(tg.Name)
 	s.distinctPropertyConstraint.SetTaskGroup(tg)
 	s.binPack.SetTaskGroup(tg)
+	s.scorePlugins.SetTaskGroup(tg)
 
 	if contextual, ok := s.quota.(ContextualIterator); ok {
 		contextual.SetTaskGroup(tg)
@@ -353,6 +409,10 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// balancing across eligible nodes.
 	s.source = NewRandomIterator(ctx, nil)
 
+	// Filter on the job's node pool before any other check. The job is
+	// filled in later.
+	s.jobNodePool = NewNodePoolChecker(ctx, "")
+
 	// Attach the job constraints. The job is filled in later.
 	s.jobConstraint = NewConstraintChecker(ctx, nil)
 
@@ -378,7 +438,7 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// which feasibility checking can be skipped if the computed node class has
 	// previously been marked as eligible or ineligible. Generally this will be
 	// checks that only needs to examine the single node to determine feasibility.
-	jobs := []FeasibilityChecker{s.jobConstraint}
+	jobs := []FeasibilityChecker{s.jobNodePool, s.jobConstraint}
 	tgs := []FeasibilityChecker{
 		s.taskGroupDrivers,
 		s.taskGroupConstraint,
@@ -395,12 +455,15 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Filter on distinct property constraints.
 	s.distinctPropertyConstraint = NewDistinctPropertyIterator(ctx, s.distinctHostsConstraint)
 
+	// Filter on spreads with a max_skew enforced as a constraint.
+	s.spreadSkewConstraint = NewSpreadSkewIterator(ctx, s.distinctPropertyConstraint)
+
 	// Create the quota iterator to determine if placements would result in
 	// the quota attached to the namespace of the job to go over.
 	// Note: the quota iterator must be the last feasibility iterator before
 	// we upgrade to ranking, or our quota usage will include ineligible
 	// nodes!
-	s.quota = NewQuotaIterator(ctx, s.distinctPropertyConstraint)
+	s.quota = NewQuotaIterator(ctx, s.spreadSkewConstraint)
 
 	// Upgrade from feasible to rank iterator
 	rankSource := NewFeasibleRankIterator(ctx, s.quota)
@@ -427,8 +490,11 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	// Add the preemption options scoring iterator
 	preemptionScorer := NewPreemptionScoringIterator(ctx, s.spread)
 
+	// Apply the scores of the configured scoring plugins
+	s.scorePlugins = NewScorePluginIterator(ctx, preemptionScorer)
+
 	// Normalizes scores by averaging them across various scorers
-	s.scoreNorm = NewScoreNormalizationIterator(ctx, preemptionScorer)
+	s.scoreNorm = NewScoreNormalizationIterator(ctx, s.scorePlugins)
 
 	// Apply a limit function. This is to avoid scanning *every* possible node.
 	s.limit = NewLimitIterator(ctx, s.scoreNorm, 2, skipScoreThreshold, maxSkip)
@@ -437,3 +503,17 @@ func NewGenericStack(batch bool, ctx Context) *GenericStack {
 	s.maxScore = NewMaxScoreIterator(ctx, s.limit)
 	return s
 }
+
+// nodePoolSchedulerConfig returns the scheduler configuration to use for the
+// job, taking into account any overrides set on the job's node pool.
+func nodePoolSchedulerConfig(state State, job *structs.Job) *structs.SchedulerConfiguration {
+	_, schedConfig, _ := state.SchedulerConfig()
+
+	poolName := job.NodePool
+	if poolName == "" {
+		poolName = structs.NodePoolDefault
+	}
+	pool, _ := state.NodePoolByName(nil, poolName)
+
+	return schedConfig.WithNodePool(pool)
+}
@@ -174,6 +174,39 @@ func TestServiceStack_Select_PreferringNodes(t *testing.T) {
 	require.Equal(t, prefNodes1, selectOptions.PreferredNodes)
 }
 
+func TestServiceStack_Select_ExcludingNodes(t *testing.T) {
+	ci.Parallel(t)
+
+	_, ctx := testContext(t)
+	nodes := []*structs.Node{
+		mock.Node(),
+		mock.Node(),
+	}
+	stack := NewGenericStack(false, ctx)
+	stack.SetNodes(nodes)
+
+	job := mock.Job()
+	stack.SetJob(job)
+
+	// Exclude the first node and ensure the allocation is placed on the other
+	excluded := map[string]struct{}{nodes[0].ID: {}}
+	selectOptions := &SelectOptions{ExcludedNodeIDs: excluded}
+	option := stack.Select(job.TaskGroups[0], selectOptions)
+	require.NotNil(t, option)
+	require.Equal(t, nodes[1].ID, option.Node.ID)
+
+	// Make sure select doesn't have a side effect on the stack's nodes
+	require.Len(t, stack.source.nodes, 2)
+
+	// Exclude both nodes and ensure no node is selected
+	selectOptions = &SelectOptions{ExcludedNodeIDs: map[string]struct{}{
+		nodes[0].ID: {},
+		nodes[1].ID: {},
+	}}
+	require.Nil(t, stack.Select(job.TaskGroups[0], selectOptions))
+	require.Len(t, stack.source.nodes, 2)
+}
+
 func TestServiceStack_Select_MetricsReset(t *testing.T) {
 	ci.Parallel(t)
 
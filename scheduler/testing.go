@@ -8,11 +8,13 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	log "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-memdb"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/nomad/helper/testlog"
 	"github.com/hashicorp/nomad/nomad/state"
 	"github.com/hashicorp/nomad/nomad/structs"
+	"github.com/hashicorp/nomad/nomad/structs/config"
 )
 
 // RejectPlan is used to always reject the entire plan and force a state refresh
@@ -42,16 +44,72 @@ func (r *RejectPlan) ReblockEval(*structs.Evaluation) error {
 	return nil
 }
 
+// RejectNodePlan is used to reject the allocations on a node, as the plan
+// applier does when they no longer fit, and commit the rest of the plan unless
+// it is all-at-once
+type RejectNodePlan struct {
+	Harness *Harness
+	NodeID  string
+}
+
+func (r *RejectNodePlan) ServersMeetMinimumVersion(minVersion *version.Version, checkFailedServers bool) bool {
+	return r.Harness.serversMeetMinimumVersion
+}
+
+func (r *RejectNodePlan) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
+	result := new(structs.PlanResult)
+	if len(plan.NodeAllocation[r.NodeID]) > 0 {
+		result.RefreshIndex = r.Harness.NextIndex()
+		if plan.AllAtOnce {
+			return result, r.Harness.State, nil
+		}
+	}
+
+	result.NodeAllocation = make(map[string][]*structs.Allocation)
+	var allocs []*structs.Allocation
+	for nodeID, allocList := range plan.NodeAllocation {
+		if nodeID == r.NodeID {
+			continue
+		}
+		result.NodeAllocation[nodeID] = allocList
+		for _, alloc := range allocList {
+			alloc = alloc.Copy()
+			alloc.Job = plan.Job
+			allocs = append(allocs, alloc)
+		}
+	}
+	index := r.Harness.NextIndex()
+	result.AllocIndex = index
+	err := r.Harness.State.UpsertAllocs(structs.MsgTypeTestSetup, index, allocs)
+	return result, r.Harness.State, err
+}
+
+func (r *RejectNodePlan) UpdateEval(eval *structs.Evaluation) error {
+	return nil
+}
+
+func (r *RejectNodePlan) CreateEval(*structs.Evaluation) error {
+	return nil
+}
+
+func (r *RejectNodePlan) ReblockEval(*structs.Evaluation) error {
+	return nil
+}
+
 // Harness is a lightweight testing harness for schedulers. It manages a state
 // store copy and provides the planner interface. It can be extended for various
 // testing uses or for invoking the scheduler without side effects.
 type Harness struct {
-	t     testing.TB
-	State *state.StateStore
+	t      testing.TB
+	logger log.Logger
+	State  *state.StateStore
 
 	Planner  Planner
 	planLock sync.Mutex
 
+	// ScorePlugins are the scoring plugins the schedulers rank nodes with
+	ScorePlugins []*config.ScorePluginConfig
+
 	Plans        []*structs.Plan
 	Evals        []*structs.Evaluation
 	CreateEvals  []*structs.Evaluation
@@ -86,6 +144,22 @@ func NewHarnessWithState(t testing.TB, state *state.StateStore) *Harness {
 	}
 }
 
+// NewSimulationHarness creates a harness used to run the schedulers against
+// the given state outside of tests, for example against a restored snapshot.
+// Plans are applied to the state at indexes following its latest index.
+func NewSimulationHarness(state *state.StateStore, logger log.Logger) (*Harness, error) {
+	index, err := state.LatestIndex()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest index: %v", err)
+	}
+	return &Harness{
+		logger:                    logger,
+		State:                     state,
+		nextIndex:                 index + 1,
+		serversMeetMinimumVersion: true,
+	}, nil
+}
+
 // SubmitPlan is used to handle plan submission
 func (h *Harness) SubmitPlan(plan *structs.Plan) (*structs.PlanResult, State, error) {
 	// Ensure sequential plan application
@@ -272,7 +346,10 @@ func (h *Harness) Snapshot() State {
 // Scheduler is used to return a new scheduler from
 // a snapshot of current state using the harness for planning.
 func (h *Harness) Scheduler(factory Factory) Scheduler {
-	logger := testlog.HCLogger(h.t)
+	logger := h.logger
+	if logger == nil {
+		logger = testlog.HCLogger(h.t)
+	}
 	eventsCh := make(chan interface{})
 
 	// Listen for and log events from the scheduler.
@@ -280,12 +357,16 @@ func (h *Harness) Scheduler(factory Factory) Scheduler {
 		for e := range eventsCh {
 			switch event := e.(type) {
 			case *PortCollisionEvent:
+				if h.t == nil {
+					logger.Warn("unexpected worker eval event", "reason", event.Reason)
+					continue
+				}
 				h.t.Errorf("unexpected worker eval event: %v", event.Reason)
 			}
 		}
 	}()
 
-	return factory(logger, eventsCh, h.Snapshot(), h)
+	return factory(logger, eventsCh, h.Snapshot(), h, h.ScorePlugins)
 }
 
 // Process is used to process an evaluation given a factory
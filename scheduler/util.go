@@ -346,9 +346,9 @@ func diffSystemAllocs(
 	return result
 }
 
-// readyNodesInDCs returns all the ready nodes in the given datacenters and a
-// mapping of each data center to the count of ready nodes.
-func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]struct{}, map[string]int, error) {
+// readyNodesInDCs returns all the ready nodes in the given datacenters and node
+// pool, and a mapping of each data center to the count of ready nodes.
+func readyNodesInDCs(state State, dcs []string, pool string) ([]*structs.Node, map[string]struct{}, map[string]int, error) {
 	// Index the DCs
 	dcMap := make(map[string]int, len(dcs))
 	for _, dc := range dcs {
@@ -369,7 +369,7 @@ func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]str
 			break
 		}
 
-		// Filter on datacenter and status
+		// Filter on datacenter, node pool and status
 		node := raw.(*structs.Node)
 		if !node.Ready() {
 			notReady[node.ID] = struct{}{}
@@ -378,6 +378,9 @@ func readyNodesInDCs(state State, dcs []string) ([]*structs.Node, map[string]str
 		if _, ok := dcMap[node.Datacenter]; !ok {
 			continue
 		}
+		if !structs.NodePoolMatches(pool, node.NodePool) {
+			continue
+		}
 		out = append(out, node)
 		dcMap[node.Datacenter]++
 	}
@@ -555,6 +558,11 @@ func tasksUpdated(jobA, jobB *structs.Job, taskGroup string) bool {
 			return true
 		}
 
+		// Log sinks are only set when logmon starts
+		if !reflect.DeepEqual(at.LogConfig.GetSinks(), bt.LogConfig.GetSinks()) {
+			return true
+		}
+
 		// Check the metadata
 		if !reflect.DeepEqual(
 			jobA.CombinedTaskMeta(taskGroup, at.Name),
@@ -855,6 +863,11 @@ func inplaceUpdate(ctx Context, eval *structs.Evaluation, job *structs.Job,
 			continue
 		}
 
+		// The alloc is on a node that's not in the job's node pool
+		if !structs.NodePoolMatches(job.NodePool, node.NodePool) {
+			continue
+		}
+
 		// Set the existing node as the base set
 		stack.SetNodes([]*structs.Node{node})
 
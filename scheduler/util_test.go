@@ -418,7 +418,7 @@ func TestReadyNodesInDCs(t *testing.T) {
 	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1002, node3))
 	require.NoError(t, state.UpsertNode(structs.MsgTypeTestSetup, 1003, node4))
 
-	nodes, notReady, dc, err := readyNodesInDCs(state, []string{"dc1", "dc2"})
+	nodes, notReady, dc, err := readyNodesInDCs(state, []string{"dc1", "dc2"}, structs.NodePoolAll)
 	require.NoError(t, err)
 	require.Equal(t, 2, len(nodes))
 	require.NotEqual(t, node3.ID, nodes[0].ID)
@@ -811,6 +811,13 @@ func TestTasksUpdated(t *testing.T) {
 	// Compare changed Template wait configs
 	j23.TaskGroups[0].Tasks[0].Templates[0].Wait.Max = helper.TimeToPtr(10 * time.Second)
 	require.True(t, tasksUpdated(j22, j23, name))
+
+	// Add a log sink
+	j24 := mock.Job()
+	j24.TaskGroups[0].Tasks[0].LogConfig.Sinks = []*structs.LogSink{
+		{Name: "siem", Type: structs.LogSinkTypeSyslog, Address: "udp://127.0.0.1:514"},
+	}
+	require.True(t, tasksUpdated(j1, j24, name))
 }
 
 func TestTasksUpdated_connectServiceUpdated(t *testing.T) {
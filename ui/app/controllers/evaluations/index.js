@@ -126,6 +126,7 @@ export default class EvaluationsController extends Controller {
       { key: 'queued-allocs', label: 'Queued Allocations' },
       { key: 'preemption', label: 'Preemption' },
       { key: 'job-scaling', label: 'Job Scalling' },
+      { key: 'rebalance', label: 'Rebalance' },
     ];
   }
 